@@ -101,6 +101,42 @@ type Configuration struct {
 	// Resources provides additional configuration options for handling the resources.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduler controls the behaviour of the Kueue scheduler.
+	// +optional
+	Scheduler *Scheduler `json:"scheduler,omitempty"`
+
+	// LocalQueues controls the validation of LocalQueues.
+	// +optional
+	LocalQueues *LocalQueues `json:"localQueues,omitempty"`
+
+	// ClusterQueues controls the validation of ClusterQueues.
+	// +optional
+	ClusterQueues *ClusterQueues `json:"clusterQueues,omitempty"`
+
+	// ObjectRetentionPolicies provides the configuration for the retention
+	// of the objects managed by Kueue.
+	// +optional
+	ObjectRetentionPolicies *ObjectRetentionPolicies `json:"objectRetentionPolicies,omitempty"`
+
+	// WorkloadNotifications configures an outgoing webhook which is notified
+	// of the lifecycle transitions of the workloads.
+	// +optional
+	WorkloadNotifications *WorkloadNotifications `json:"workloadNotifications,omitempty"`
+
+	// Requeuing controls the requeuing of the evicted workloads.
+	// +optional
+	Requeuing *Requeuing `json:"requeuing,omitempty"`
+
+	// AuditLog configures a structured audit log of the lifecycle transitions
+	// of the workloads, which, unlike the Kubernetes Events, doesn't expire.
+	// +optional
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+
+	// TopologyAwareScheduling controls how the placement decided by the
+	// Topology Aware Scheduling is passed to the pods.
+	// +optional
+	TopologyAwareScheduling *TopologyAwareScheduling `json:"topologyAwareScheduling,omitempty"`
+
 	// FeatureGates is a map of feature names to bools that allows to override the
 	// default enablement status of a feature. The map cannot be used in conjunction
 	// with passing the list of features via the command line argument "--feature-gates"
@@ -246,6 +282,59 @@ type WaitForPodsReady struct {
 	// If not set, there is no timeout.
 	// +optional
 	RecoveryTimeout *metav1.Duration `json:"recoveryTimeout,omitempty"`
+
+	// PodsReadyConditions allows to consider the pods of the jobs of some
+	// integrations ready based on a condition of the job, rather than on the
+	// Ready condition of the individual pods.
+	// +optional
+	PodsReadyConditions []PodsReadyCondition `json:"podsReadyConditions,omitempty"`
+
+	// TimeoutStartPolicy defines when the timeout starts counting for the jobs
+	// which have only some of their pods ready. The possible values are:
+	//
+	// - `Admission` (default) indicates that the timeout counts since the
+	//   admission of the workload.
+	// - `FirstPodReady` indicates that the timeout starts again when the first
+	//   pod of the job is ready.
+	// - `Quorum` indicates that the timeout starts again when more than half
+	//   of the pods of the job are ready.
+	//
+	// The policy only applies to the integrations reporting the number of
+	// ready pods, currently batch/job.
+	// +optional
+	TimeoutStartPolicy *PodsReadyTimeoutStartPolicy `json:"timeoutStartPolicy,omitempty"`
+
+	// ReservationTimeout defines an opt-in timeout, measured since the
+	// Workload got its quota reserved, for the Workload to reach the
+	// PodsReady=true condition for the first time. Unlike Timeout, it also
+	// covers the time spent waiting for the admission checks.
+	// After exceeding the timeout the Workload is evicted with the
+	// ReservationNotReady reason, and requeued after the backoff delay.
+	// The timeout is enforced only if waitForPodsReady.enable=true.
+	// If not set, there is no timeout.
+	// +optional
+	ReservationTimeout *metav1.Duration `json:"reservationTimeout,omitempty"`
+}
+
+type PodsReadyTimeoutStartPolicy string
+
+const (
+	PodsReadyTimeoutStartAdmission     PodsReadyTimeoutStartPolicy = "Admission"
+	PodsReadyTimeoutStartFirstPodReady PodsReadyTimeoutStartPolicy = "FirstPodReady"
+	PodsReadyTimeoutStartQuorum        PodsReadyTimeoutStartPolicy = "Quorum"
+)
+
+// PodsReadyCondition defines the condition of the jobs of an integration
+// which indicates that their pods are ready.
+type PodsReadyCondition struct {
+	// Framework is the name of the integration, as listed in
+	// integrations.frameworks.
+	Framework string `json:"framework"`
+
+	// ConditionType is the type of the condition, in the .status.conditions
+	// of the job, which indicates that the pods of the job are ready when
+	// its status is True.
+	ConditionType string `json:"conditionType"`
 }
 
 type MultiKueue struct {
@@ -268,6 +357,210 @@ type MultiKueue struct {
 	// Defaults to 15 minutes.
 	// +optional
 	WorkerLostTimeout *metav1.Duration `json:"workerLostTimeout,omitempty"`
+
+	// WorkerReconnectGracePeriod defines the time, since the connection with a worker
+	// cluster was lost, during which the manager keeps the local workloads' multikueue
+	// admission check state Ready while reconnecting, even if the WorkerLostTimeout is
+	// exceeded. The workloads are put back in the queue only once the grace period expires.
+	//
+	// Defaults to 0, meaning no grace period.
+	// +optional
+	WorkerReconnectGracePeriod *metav1.Duration `json:"workerReconnectGracePeriod,omitempty"`
+
+	// DisabledIntegrations is a list of framework names, from the enabled
+	// integrations, whose workloads are not dispatched to the worker clusters.
+	// The MultiKueue admission check of such workloads is set to Ready, so that
+	// they are admitted in the manager cluster only, and their jobs are not
+	// defaulted to be managed by MultiKueue.
+	// +optional
+	DisabledIntegrations []string `json:"disabledIntegrations,omitempty"`
+}
+
+type Scheduler struct {
+	// MaxAdmissionsPerCycle limits the number of workloads which can get
+	// quota reserved within a single scheduling cycle. The remaining
+	// workloads which could be admitted are deferred to the next cycle,
+	// where they take precedence over the workloads which were not deferred.
+	// When null, the number of admissions per cycle is not limited.
+	// +optional
+	MaxAdmissionsPerCycle *int32 `json:"maxAdmissionsPerCycle,omitempty"`
+
+	// AdmissionCooldown is the period, counted from the quota reservation of a
+	// workload, during which the workload can't be preempted. It prevents the
+	// workloads which preempted others from being preempted right after their
+	// admission.
+	// When null, the workloads can be preempted right after their admission.
+	// +optional
+	AdmissionCooldown *metav1.Duration `json:"admissionCooldown,omitempty"`
+
+	// PreemptionObjective defines how the workloads to preempt are chosen
+	// among the candidates allowed by the preemption policies. The possible
+	// values are:
+	//
+	// - `CandidateOrder` (default) indicates that the candidates are
+	//   preempted in order, until the incoming workload fits.
+	// - `FewestWorkloads` indicates that, among the candidates with the same
+	//   priority, the ones using more of the resources that the incoming
+	//   workload needs are preempted first, so that fewer workloads are
+	//   preempted.
+	// - `FewestResources` indicates that, among the candidates with the same
+	//   priority, the ones using less of the resources that the incoming
+	//   workload needs are preempted first, so that fewer resources are
+	//   disrupted.
+	//
+	// The objective only applies to the classic preemption, when fair sharing
+	// is disabled.
+	// +optional
+	PreemptionObjective *PreemptionObjective `json:"preemptionObjective,omitempty"`
+
+	// WorkloadOrdering is the name of a custom ordering of the pending
+	// workloads in the ClusterQueues, registered with queue.RegisterOrdering
+	// by a custom build of Kueue.
+	// When null, the pending workloads are ordered by priority, and then by
+	// their creation or eviction timestamp.
+	// +optional
+	WorkloadOrdering *string `json:"workloadOrdering,omitempty"`
+}
+
+type PreemptionObjective string
+
+const (
+	// PreemptionObjectiveCandidateOrder preempts the candidates in order.
+	PreemptionObjectiveCandidateOrder PreemptionObjective = "CandidateOrder"
+
+	// PreemptionObjectiveFewestWorkloads minimizes the number of preempted
+	// workloads.
+	PreemptionObjectiveFewestWorkloads PreemptionObjective = "FewestWorkloads"
+
+	// PreemptionObjectiveFewestResources minimizes the quantity of disrupted
+	// resources.
+	PreemptionObjectiveFewestResources PreemptionObjective = "FewestResources"
+)
+
+type LocalQueues struct {
+	// MissingClusterQueuePolicy defines how a LocalQueue referencing a ClusterQueue
+	// which doesn't exist is handled on creation. The possible values are:
+	//
+	// - `Warn` (default) indicates that the LocalQueue is created and a warning is
+	//   returned, as the ClusterQueue might be created later.
+	// - `Reject` indicates that the LocalQueue is rejected.
+	//
+	// +optional
+	MissingClusterQueuePolicy *MissingClusterQueuePolicy `json:"missingClusterQueuePolicy,omitempty"`
+}
+
+type MissingClusterQueuePolicy string
+
+const (
+	// MissingClusterQueueWarn accepts the LocalQueue with a warning.
+	MissingClusterQueueWarn MissingClusterQueuePolicy = "Warn"
+
+	// MissingClusterQueueReject rejects the LocalQueue.
+	MissingClusterQueueReject MissingClusterQueuePolicy = "Reject"
+)
+
+type ClusterQueues struct {
+	// CohortChangePolicy defines how an update of the cohort of a ClusterQueue
+	// is handled. The possible values are:
+	//
+	// - `Allow` (default) indicates that the cohort can be changed at any time.
+	// - `Reject` indicates that the cohort change is rejected while the
+	//   ClusterQueue has workloads with quota reserved, so that the
+	//   ClusterQueue needs to be drained first.
+	//
+	// +optional
+	CohortChangePolicy *CohortChangePolicy `json:"cohortChangePolicy,omitempty"`
+}
+
+type CohortChangePolicy string
+
+const (
+	// CohortChangeAllow accepts the cohort change.
+	CohortChangeAllow CohortChangePolicy = "Allow"
+
+	// CohortChangeReject rejects the cohort change while the ClusterQueue has
+	// workloads with quota reserved.
+	CohortChangeReject CohortChangePolicy = "Reject"
+)
+
+type Requeuing struct {
+	// MaxCount is the maximum number of times a workload can be requeued after
+	// an eviction, like a preemption. The evictions caused by the deactivation of
+	// the workload, or by the stop of its queue, are not counted.
+	// Once the limit is exceeded, the workload is deactivated with the
+	// MaxRequeuesExceeded reason, to break the cycles of repeated preemptions.
+	// When null, the workloads are requeued without a limit.
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+}
+
+type AuditLog struct {
+	// Path is the file to which a JSON line is appended when a workload is
+	// admitted, evicted, preempted or finished. When set to "-", the records
+	// are written to the standard output.
+	Path string `json:"path"`
+}
+
+type TopologyAwareScheduling struct {
+	// NodeAffinityMode indicates how the topology domains assigned to the
+	// pods are injected into them, when the pods are ungated.
+	// Possible values are:
+	// - `Required`: the labels of all the topology levels are added to the
+	//   node selector of the pods.
+	// - `Preferred`: the labels of the levels up to the level required by the
+	//   PodSet are added to the node selector, and the labels of the lower
+	//   levels, or of all the levels if the PodSet doesn't require a level, are
+	//   added as preferred node affinity, so that kube-scheduler can still place
+	//   the pods on other nodes when the assigned ones are busy.
+	// Defaults to `Required`.
+	// +optional
+	NodeAffinityMode *TASNodeAffinityMode `json:"nodeAffinityMode,omitempty"`
+}
+
+type TASNodeAffinityMode string
+
+const (
+	TASNodeAffinityRequired  TASNodeAffinityMode = "Required"
+	TASNodeAffinityPreferred TASNodeAffinityMode = "Preferred"
+)
+
+type WorkloadNotifications struct {
+	// URL is the endpoint to which Kueue POSTs a JSON event when a workload
+	// is admitted, evicted or finished.
+	URL string `json:"url"`
+
+	// MaxRetries is the number of times the delivery of an event is retried
+	// after a failure, before the event is dropped.
+	// Defaults to 3.
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, doubled for each
+	// subsequent retry.
+	// Defaults to 1s.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+}
+
+type ObjectRetentionPolicies struct {
+	// Workloads configures the retention of Workloads.
+	// +optional
+	Workloads *WorkloadRetentionPolicy `json:"workloads,omitempty"`
+}
+
+type WorkloadRetentionPolicy struct {
+	// AfterDeactivated is the period, counted from the deactivation, after
+	// which a deactivated Workload is deleted.
+	// If not set, deactivated Workloads are not deleted.
+	// +optional
+	AfterDeactivated *metav1.Duration `json:"afterDeactivated,omitempty"`
+
+	// DeleteOwners indicates whether the job owning a deactivated Workload is
+	// deleted along with it once AfterDeactivated expires. Otherwise, the
+	// Workloads owned by a job are kept, as the job would create them again.
+	// Defaults to false.
+	// +optional
+	DeleteOwners *bool `json:"deleteOwners,omitempty"`
 }
 
 type RequeuingStrategy struct {
@@ -388,8 +681,72 @@ type Integrations struct {
 	// during the workload creation and are not updated even if the labels of the
 	// underlying job are changed.
 	LabelKeysToCopy []string `json:"labelKeysToCopy,omitempty"`
+
+	// priorityResolver is the name of the priority resolver determining the
+	// priority of the workloads created for the jobs. Defaults to "default",
+	// which uses the WorkloadPriorityClass of the job, or the PriorityClass of
+	// its pods. Alternative resolvers need to be registered in the kueue
+	// manager binary.
+	PriorityResolver string `json:"priorityResolver,omitempty"`
+
+	// admittedJobUpdatePolicy defines how Kueue handles the changes to the
+	// resource requests of the jobs which are admitted. The possible values
+	// are:
+	//
+	// - `Readmit` (default) indicates that the job is suspended, and its
+	//   workload is recreated to be admitted again with the new requests.
+	// - `Reject` indicates that the changes to the resource requests of the
+	//   admitted jobs are rejected by the webhooks.
+	//
+	// +optional
+	AdmittedJobUpdatePolicy *AdmittedJobUpdatePolicy `json:"admittedJobUpdatePolicy,omitempty"`
+
+	// missingQueueNamePolicy defines how the webhooks handle the jobs created
+	// without the kueue.x-k8s.io/queue-name label. The possible values are:
+	//
+	// - `Ignore` (default) indicates that the jobs are created as they are, and
+	//   managed by Kueue only if manageJobsWithoutQueueName is true.
+	// - `Reject` indicates that the jobs are rejected by the webhooks.
+	// - `AssignDefaultQueue` indicates that the jobs get the LocalQueue named
+	//   by defaultQueueName, in their namespace, assigned.
+	//
+	// The policy doesn't apply to the jobs owned by jobs managed by Kueue.
+	// +optional
+	MissingQueueNamePolicy *MissingQueueNamePolicy `json:"missingQueueNamePolicy,omitempty"`
+
+	// defaultQueueName is the name of the LocalQueue assigned to the jobs
+	// without the kueue.x-k8s.io/queue-name label, when missingQueueNamePolicy
+	// is AssignDefaultQueue.
+	// +optional
+	DefaultQueueName *string `json:"defaultQueueName,omitempty"`
 }
 
+type AdmittedJobUpdatePolicy string
+
+const (
+	// AdmittedJobUpdatePolicyReadmit re-evaluates the admission of the job
+	// with the new requests.
+	AdmittedJobUpdatePolicyReadmit AdmittedJobUpdatePolicy = "Readmit"
+
+	// AdmittedJobUpdatePolicyReject rejects the changes to the requests of
+	// the admitted jobs.
+	AdmittedJobUpdatePolicyReject AdmittedJobUpdatePolicy = "Reject"
+)
+
+type MissingQueueNamePolicy string
+
+const (
+	// MissingQueueNameIgnore creates the jobs as they are.
+	MissingQueueNameIgnore MissingQueueNamePolicy = "Ignore"
+
+	// MissingQueueNameReject rejects the jobs.
+	MissingQueueNameReject MissingQueueNamePolicy = "Reject"
+
+	// MissingQueueNameAssignDefaultQueue assigns the default LocalQueue to
+	// the jobs.
+	MissingQueueNameAssignDefaultQueue MissingQueueNamePolicy = "AssignDefaultQueue"
+)
+
 type PodIntegrationOptions struct {
 	// NamespaceSelector can be used to omit some namespaces from pod reconciliation
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
@@ -425,6 +782,46 @@ type Resources struct {
 	// Transformations defines how to transform PodSpec resources into Workload resource requests.
 	// This is intended to be a map with Input as the key (enforced by validation code)
 	Transformations []ResourceTransformation `json:"transformations,omitempty"`
+
+	// TransformationsConfigMapName is the name of a ConfigMap, in the namespace
+	// of Kueue, holding additional resource transformations, in the same format
+	// as Transformations, under the "transformations" key. They take precedence
+	// over the Transformations with the same Input.
+	// When the ConfigMap changes, the requests of the pending workloads are
+	// recomputed.
+	// +optional
+	TransformationsConfigMapName *string `json:"transformationsConfigMapName,omitempty"`
+
+	// BackgroundReservations are the resources, per ResourceFlavor, consumed
+	// by workloads which are always running and not managed by Kueue, like
+	// DaemonSets. The reserved resources are subtracted from the nominal quota
+	// of every ClusterQueue defining quota for the ResourceFlavor.
+	// +optional
+	BackgroundReservations []BackgroundReservation `json:"backgroundReservations,omitempty"`
+
+	// NamespaceLimits cap the resources reserved by the workloads of a
+	// namespace, summed over all its LocalQueues and ClusterQueues. A workload
+	// which would make its namespace exceed the cap is not admitted, even if
+	// its ClusterQueue has enough quota.
+	// +optional
+	NamespaceLimits []NamespaceResourceLimit `json:"namespaceLimits,omitempty"`
+}
+
+type BackgroundReservation struct {
+	// Flavor is the name of the ResourceFlavor.
+	Flavor string `json:"flavor"`
+
+	// Resources are the reserved quantities of the resources.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+type NamespaceResourceLimit struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+
+	// Limits are the maximum quantities of the resources which the workloads
+	// of the namespace can reserve, across all the flavors.
+	Limits corev1.ResourceList `json:"limits"`
 }
 
 type ResourceTransformationStrategy string
@@ -474,8 +871,33 @@ type FairSharing struct {
 	//   newest start time first.
 	// The default strategy is ["LessThanOrEqualToFinalShare", "LessThanInitialShare"].
 	PreemptionStrategies []PreemptionStrategy `json:"preemptionStrategies,omitempty"`
+
+	// resourceWeights assigns weights to resources, by which their share is
+	// multiplied when computing the dominant resource share of the ClusterQueues
+	// and Cohorts. A resource with the weight 0 doesn't drive the fair sharing
+	// decisions.
+	// Defaults to 1.
+	ResourceWeights map[corev1.ResourceName]float64 `json:"resourceWeights,omitempty"`
+
+	// shareMode indicates how the weighted shares of the resources are combined
+	// into the share of the ClusterQueues and Cohorts. Possible values are:
+	// - DominantResource: the share is the highest weighted share among the
+	//   resources.
+	// - WeightedSum: the share is the sum of the weighted shares of all the
+	//   resources, so that borrowing several resources results in a higher
+	//   share than borrowing only one of them by the same amount.
+	// Defaults to `DominantResource`.
+	// +optional
+	ShareMode *FairSharingShareMode `json:"shareMode,omitempty"`
 }
 
+type FairSharingShareMode string
+
+const (
+	FairSharingDominantResource FairSharingShareMode = "DominantResource"
+	FairSharingWeightedSum      FairSharingShareMode = "WeightedSum"
+)
+
 type AdmissionFairSharing struct {
 	// usageHalfLifeTime indicates the time after which the current usage will decay by a half
 	// If set to 0, usage will be reset to 0 immediately.
@@ -53,6 +53,8 @@ const (
 	DefaultRequeuingBackoffBaseSeconds                  = 60
 	DefaultRequeuingBackoffMaxSeconds                   = 3600
 	DefaultResourceTransformationStrategy               = Retain
+	DefaultWorkloadNotificationsMaxRetries      int32   = 3
+	DefaultWorkloadNotificationsRetryBackoff            = time.Second
 )
 
 func getOperatorNamespace() string {
@@ -215,6 +217,14 @@ func SetDefaults_Configuration(cfg *Configuration) {
 			afs.UsageSamplingInterval = metav1.Duration{Duration: 5 * time.Minute}
 		}
 	}
+	if wn := cfg.WorkloadNotifications; wn != nil {
+		if wn.MaxRetries == nil {
+			wn.MaxRetries = ptr.To(DefaultWorkloadNotificationsMaxRetries)
+		}
+		if wn.RetryBackoff == nil {
+			wn.RetryBackoff = &metav1.Duration{Duration: DefaultWorkloadNotificationsRetryBackoff}
+		}
+	}
 
 	if cfg.Resources != nil {
 		for idx := range cfg.Resources.Transformations {
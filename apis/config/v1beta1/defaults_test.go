@@ -624,6 +624,33 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 		},
+		"workloadNotifications": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				WorkloadNotifications: &WorkloadNotifications{
+					URL: "https://example.com/events",
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				WorkloadNotifications: &WorkloadNotifications{
+					URL:          "https://example.com/events",
+					MaxRetries:   ptr.To(DefaultWorkloadNotificationsMaxRetries),
+					RetryBackoff: &metav1.Duration{Duration: DefaultWorkloadNotificationsRetryBackoff},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
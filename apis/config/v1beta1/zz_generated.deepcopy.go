@@ -52,6 +52,43 @@ func (in *AdmissionFairSharing) DeepCopy() *AdmissionFairSharing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackgroundReservation) DeepCopyInto(out *BackgroundReservation) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackgroundReservation.
+func (in *BackgroundReservation) DeepCopy() *BackgroundReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BackgroundReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 	*out = *in
@@ -92,6 +129,26 @@ func (in *ClusterQueueVisibility) DeepCopy() *ClusterQueueVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQueues) DeepCopyInto(out *ClusterQueues) {
+	*out = *in
+	if in.CohortChangePolicy != nil {
+		in, out := &in.CohortChangePolicy, &out.CohortChangePolicy
+		*out = new(CohortChangePolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueues.
+func (in *ClusterQueues) DeepCopy() *ClusterQueues {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQueues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -152,6 +209,46 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(Scheduler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalQueues != nil {
+		in, out := &in.LocalQueues, &out.LocalQueues
+		*out = new(LocalQueues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterQueues != nil {
+		in, out := &in.ClusterQueues, &out.ClusterQueues
+		*out = new(ClusterQueues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectRetentionPolicies != nil {
+		in, out := &in.ObjectRetentionPolicies, &out.ObjectRetentionPolicies
+		*out = new(ObjectRetentionPolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadNotifications != nil {
+		in, out := &in.WorkloadNotifications, &out.WorkloadNotifications
+		*out = new(WorkloadNotifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requeuing != nil {
+		in, out := &in.Requeuing, &out.Requeuing
+		*out = new(Requeuing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		**out = **in
+	}
+	if in.TopologyAwareScheduling != nil {
+		in, out := &in.TopologyAwareScheduling, &out.TopologyAwareScheduling
+		*out = new(TopologyAwareScheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make(map[string]bool, len(*in))
@@ -292,6 +389,18 @@ func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 		*out = make([]PreemptionStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceWeights != nil {
+		in, out := &in.ResourceWeights, &out.ResourceWeights
+		*out = make(map[corev1.ResourceName]float64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ShareMode != nil {
+		in, out := &in.ShareMode, &out.ShareMode
+		*out = new(FairSharingShareMode)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharing.
@@ -327,6 +436,21 @@ func (in *Integrations) DeepCopyInto(out *Integrations) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdmittedJobUpdatePolicy != nil {
+		in, out := &in.AdmittedJobUpdatePolicy, &out.AdmittedJobUpdatePolicy
+		*out = new(AdmittedJobUpdatePolicy)
+		**out = **in
+	}
+	if in.MissingQueueNamePolicy != nil {
+		in, out := &in.MissingQueueNamePolicy, &out.MissingQueueNamePolicy
+		*out = new(MissingQueueNamePolicy)
+		**out = **in
+	}
+	if in.DefaultQueueName != nil {
+		in, out := &in.DefaultQueueName, &out.DefaultQueueName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Integrations.
@@ -369,6 +493,26 @@ func (in *InternalCertManagement) DeepCopy() *InternalCertManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueues) DeepCopyInto(out *LocalQueues) {
+	*out = *in
+	if in.MissingClusterQueuePolicy != nil {
+		in, out := &in.MissingClusterQueuePolicy, &out.MissingClusterQueuePolicy
+		*out = new(MissingClusterQueuePolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueues.
+func (in *LocalQueues) DeepCopy() *LocalQueues {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiKueue) DeepCopyInto(out *MultiKueue) {
 	*out = *in
@@ -387,6 +531,16 @@ func (in *MultiKueue) DeepCopyInto(out *MultiKueue) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.WorkerReconnectGracePeriod != nil {
+		in, out := &in.WorkerReconnectGracePeriod, &out.WorkerReconnectGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DisabledIntegrations != nil {
+		in, out := &in.DisabledIntegrations, &out.DisabledIntegrations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueue.
@@ -399,6 +553,48 @@ func (in *MultiKueue) DeepCopy() *MultiKueue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceResourceLimit) DeepCopyInto(out *NamespaceResourceLimit) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceResourceLimit.
+func (in *NamespaceResourceLimit) DeepCopy() *NamespaceResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRetentionPolicies) DeepCopyInto(out *ObjectRetentionPolicies) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(WorkloadRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRetentionPolicies.
+func (in *ObjectRetentionPolicies) DeepCopy() *ObjectRetentionPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRetentionPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 	*out = *in
@@ -424,6 +620,21 @@ func (in *PodIntegrationOptions) DeepCopy() *PodIntegrationOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodsReadyCondition) DeepCopyInto(out *PodsReadyCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodsReadyCondition.
+func (in *PodsReadyCondition) DeepCopy() *PodsReadyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PodsReadyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueueVisibility) DeepCopyInto(out *QueueVisibility) {
 	*out = *in
@@ -444,6 +655,26 @@ func (in *QueueVisibility) DeepCopy() *QueueVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Requeuing) DeepCopyInto(out *Requeuing) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Requeuing.
+func (in *Requeuing) DeepCopy() *Requeuing {
+	if in == nil {
+		return nil
+	}
+	out := new(Requeuing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequeuingStrategy) DeepCopyInto(out *RequeuingStrategy) {
 	*out = *in
@@ -521,6 +752,25 @@ func (in *Resources) DeepCopyInto(out *Resources) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TransformationsConfigMapName != nil {
+		in, out := &in.TransformationsConfigMapName, &out.TransformationsConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackgroundReservations != nil {
+		in, out := &in.BackgroundReservations, &out.BackgroundReservations
+		*out = make([]BackgroundReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceLimits != nil {
+		in, out := &in.NamespaceLimits, &out.NamespaceLimits
+		*out = make([]NamespaceResourceLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
@@ -533,6 +783,61 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduler) DeepCopyInto(out *Scheduler) {
+	*out = *in
+	if in.MaxAdmissionsPerCycle != nil {
+		in, out := &in.MaxAdmissionsPerCycle, &out.MaxAdmissionsPerCycle
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AdmissionCooldown != nil {
+		in, out := &in.AdmissionCooldown, &out.AdmissionCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PreemptionObjective != nil {
+		in, out := &in.PreemptionObjective, &out.PreemptionObjective
+		*out = new(PreemptionObjective)
+		**out = **in
+	}
+	if in.WorkloadOrdering != nil {
+		in, out := &in.WorkloadOrdering, &out.WorkloadOrdering
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduler.
+func (in *Scheduler) DeepCopy() *Scheduler {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyAwareScheduling) DeepCopyInto(out *TopologyAwareScheduling) {
+	*out = *in
+	if in.NodeAffinityMode != nil {
+		in, out := &in.NodeAffinityMode, &out.NodeAffinityMode
+		*out = new(TASNodeAffinityMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAwareScheduling.
+func (in *TopologyAwareScheduling) DeepCopy() *TopologyAwareScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyAwareScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
@@ -556,6 +861,21 @@ func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.PodsReadyConditions != nil {
+		in, out := &in.PodsReadyConditions, &out.PodsReadyConditions
+		*out = make([]PodsReadyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutStartPolicy != nil {
+		in, out := &in.TimeoutStartPolicy, &out.TimeoutStartPolicy
+		*out = new(PodsReadyTimeoutStartPolicy)
+		**out = **in
+	}
+	if in.ReservationTimeout != nil {
+		in, out := &in.ReservationTimeout, &out.ReservationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForPodsReady.
@@ -567,3 +887,53 @@ func (in *WaitForPodsReady) DeepCopy() *WaitForPodsReady {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadNotifications) DeepCopyInto(out *WorkloadNotifications) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadNotifications.
+func (in *WorkloadNotifications) DeepCopy() *WorkloadNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadRetentionPolicy) DeepCopyInto(out *WorkloadRetentionPolicy) {
+	*out = *in
+	if in.AfterDeactivated != nil {
+		in, out := &in.AfterDeactivated, &out.AfterDeactivated
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeleteOwners != nil {
+		in, out := &in.DeleteOwners, &out.DeleteOwners
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadRetentionPolicy.
+func (in *WorkloadRetentionPolicy) DeepCopy() *WorkloadRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
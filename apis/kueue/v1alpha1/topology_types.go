@@ -46,6 +46,23 @@ const (
 	// +kubebuilder:validation:Type=boolean
 	PodSetUnconstrainedTopologyAnnotation = "kueue.x-k8s.io/podset-unconstrained-topology"
 
+	// PodSetSpreadTopologyAnnotation indicates that a PodSet requires
+	// Topology Aware Scheduling, and requires spreading its pods across the
+	// topology domains corresponding to the topology level indicated by the
+	// annotation value (e.g. across racks or across blocks).
+	//
+	// The pods are distributed over as many distinct topology domains as
+	// possible, rather than packed within as few domains as possible, to
+	// improve the resilience of the PodSet to the failure of a domain.
+	PodSetSpreadTopologyAnnotation = "kueue.x-k8s.io/podset-spread-topology"
+
+	// PodSetNodeCapacityPercentageAnnotation indicates the percentage of the
+	// capacity of a node requested by each pod of the PodSet, resolved by
+	// Topology Aware Scheduling against the capacity of the nodes. For
+	// example, the value "100" requests whole nodes. The annotation requires
+	// one of the topology annotations.
+	PodSetNodeCapacityPercentageAnnotation = "kueue.x-k8s.io/podset-node-capacity-percentage"
+
 	// TopologySchedulingGate is used to delay scheduling of a Pod until the
 	// nodeSelectors corresponding to the assigned topology domain are injected
 	// into the Pod. For the Pod-based integrations the gate is added in webhook
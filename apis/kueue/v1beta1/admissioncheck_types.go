@@ -64,6 +64,16 @@ type AdmissionCheckSpec struct {
 	// check.
 	// +optional
 	Parameters *AdmissionCheckParametersReference `json:"parameters,omitempty"`
+
+	// reservationHoldSeconds makes the check hold the quota reservation of the
+	// workloads while its state is Pending, for up to the given number of seconds
+	// since the quota reservation. While the reservation is held, the workloads
+	// can't be preempted.
+	// When the check is still Pending at the end of the period, the workload is
+	// evicted, releasing its quota reservation.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ReservationHoldSeconds *int32 `json:"reservationHoldSeconds,omitempty"`
 }
 
 type AdmissionCheckParametersReference struct {
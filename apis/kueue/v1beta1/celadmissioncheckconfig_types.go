@@ -0,0 +1,76 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CELAdmissionCheckControllerName is the name used by the CEL
+	// admission check controller.
+	CELAdmissionCheckControllerName = "kueue.x-k8s.io/cel"
+)
+
+// CELAdmissionCheckConfigSpec defines the desired state of CELAdmissionCheckConfig
+type CELAdmissionCheckConfigSpec struct {
+	// expression is a CEL expression evaluated against the Workload, which is
+	// available as the `object` variable. The expression must evaluate to a bool.
+	// When it evaluates to true, the admission check is set to Ready, otherwise
+	// the admission check is set to Rejected.
+	//
+	// Example: `object.spec.podSets.all(ps, ps.count <= 8)`
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Expression string `json:"expression"`
+
+	// message is set in the state of the admission check when the expression
+	// evaluates to false.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+
+// CELAdmissionCheckConfig is the Schema for the celadmissioncheckconfig API
+type CELAdmissionCheckConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CELAdmissionCheckConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CELAdmissionCheckConfigList contains a list of CELAdmissionCheckConfig
+type CELAdmissionCheckConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CELAdmissionCheckConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CELAdmissionCheckConfig{}, &CELAdmissionCheckConfigList{})
+}
@@ -37,6 +37,17 @@ const (
 	ClusterQueueActiveReasonReady                                    = "Ready"
 )
 
+// ClusterQueue event reasons.
+const (
+	// ClusterQueueNearCapacityReason is the reason of the event emitted when the
+	// quota reservation of a ClusterQueue crosses above its near capacity threshold.
+	ClusterQueueNearCapacityReason = "NearCapacity"
+	// ClusterQueueBelowNearCapacityReason is the reason of the event emitted when
+	// the quota reservation of a ClusterQueue crosses back below its near capacity
+	// threshold.
+	ClusterQueueBelowNearCapacityReason = "BelowNearCapacity"
+)
+
 // ClusterQueueReference is the name of the ClusterQueue.
 // It must be a DNS (RFC 1123) and has the maximum length of 253 characters.
 //
@@ -54,6 +65,7 @@ type CohortReference string
 
 // ClusterQueueSpec defines the desired state of ClusterQueue
 // +kubebuilder:validation:XValidation:rule="!has(self.cohort) && has(self.resourceGroups) ? self.resourceGroups.all(rg, rg.flavors.all(f, f.resources.all(r, !has(r.borrowingLimit)))) : true", message="borrowingLimit must be nil when cohort is empty"
+// +kubebuilder:validation:XValidation:rule="!has(self.fallbackCohort) || !has(self.cohort) || self.fallbackCohort != self.cohort", message="fallbackCohort must be different from cohort"
 type ClusterQueueSpec struct {
 	// resourceGroups describes groups of resources.
 	// Each resource group defines the list of resources and a list of flavors
@@ -78,6 +90,15 @@ type ClusterQueueSpec struct {
 	// object.
 	Cohort CohortReference `json:"cohort,omitempty"`
 
+	// fallbackCohort is a cohort that this ClusterQueue can borrow unused
+	// resources from, once the quota available in its cohort is exhausted.
+	// Workloads admitted using the quota of the fallback cohort record its
+	// name in .status.admission.fallbackCohort.
+	//
+	// The ClusterQueue doesn't become a member of the fallback cohort, and
+	// doesn't lend its quota to it.
+	FallbackCohort CohortReference `json:"fallbackCohort,omitempty"`
+
 	// QueueingStrategy indicates the queueing strategy of the workloads
 	// across the queues in this ClusterQueue.
 	// Current Supported Strategies:
@@ -118,6 +139,36 @@ type ClusterQueueSpec struct {
 	// +optional
 	AdmissionChecksStrategy *AdmissionChecksStrategy `json:"admissionChecksStrategy,omitempty"`
 
+	// maxConcurrentChecks is the maximum number of workloads of the ClusterQueue
+	// which can be in the admission checks phase at the same time, that is
+	// having the quota reserved while waiting for the admission checks.
+	// The other workloads which require admission checks wait for a free slot
+	// before reserving the quota, so that the external systems, like the
+	// provisioning of nodes, are not flooded with requests.
+	// If not set, the number of workloads in the admission checks phase is
+	// not limited.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentChecks *int32 `json:"maxConcurrentChecks,omitempty"`
+
+	// minAdmissionIntervalSeconds is the minimum time, in seconds, between
+	// two consecutive quota reservations in the ClusterQueue. The workloads
+	// which could be admitted earlier wait until the interval has elapsed, so
+	// that the admissions are spread over time, rather than admitted and
+	// evicted in rapid cycles when the load is highly dynamic.
+	// If not set, the admissions are not spaced.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinAdmissionIntervalSeconds *int32 `json:"minAdmissionIntervalSeconds,omitempty"`
+
+	// borrowingMinPriority is the minimum priority of the workloads which
+	// can borrow quota from the cohort. The workloads with a lower priority
+	// are only admitted within the quota of the ClusterQueue, so that they
+	// never consume the capacity shared in the cohort.
+	// If not set, the workloads of any priority can borrow.
+	// +optional
+	BorrowingMinPriority *int32 `json:"borrowingMinPriority,omitempty"`
+
 	// stopPolicy - if set to a value different from None, the ClusterQueue is considered Inactive, no new reservation being
 	// made.
 	//
@@ -141,6 +192,68 @@ type ClusterQueueSpec struct {
 	// admissionScope indicates whether ClusterQueue uses the Admission Fair Sharing
 	// +optional
 	AdmissionScope *AdmissionScope `json:"admissionScope,omitempty"`
+
+	// nearCapacityThresholdPercentage is the percentage of the nominal quota
+	// above which the ClusterQueue is considered near its capacity. It applies
+	// to the quota reserved in each of the flavors and resources.
+	// When the reserved quota crosses the threshold, in either direction, an
+	// event is emitted for the ClusterQueue and the
+	// kueue_cluster_queue_near_capacity metric is updated.
+	// If not set, the ClusterQueue doesn't report when it's near its capacity.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	NearCapacityThresholdPercentage *int32 `json:"nearCapacityThresholdPercentage,omitempty"`
+
+	// admissionTolerations are extra tolerations that will be added to the
+	// pods of all the workloads admitted in the ClusterQueue, regardless of
+	// the flavors assigned to them. They are added in addition to the
+	// tolerations of the assigned flavors.
+	//
+	// admissionTolerations can be up to 8 elements.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(x, !has(x.key) ? x.operator == 'Exists' : true)", message="operator must be Exists when 'key' is empty, which means 'match all values and all keys'"
+	// +kubebuilder:validation:XValidation:rule="self.all(x, has(x.tolerationSeconds) ? x.effect == 'NoExecute' : true)", message="effect must be 'NoExecute' when 'tolerationSeconds' is set"
+	// +kubebuilder:validation:XValidation:rule="self.all(x, !has(x.operator) || x.operator in ['Equal', 'Exists'])", message="supported toleration values: 'Equal'(default), 'Exists'"
+	// +kubebuilder:validation:XValidation:rule="self.all(x, has(x.operator) && x.operator == 'Exists' ? !has(x.value) : true)", message="a value must be empty when 'operator' is 'Exists'"
+	// +kubebuilder:validation:XValidation:rule="self.all(x, !has(x.effect) || x.effect in ['NoSchedule', 'PreferNoSchedule', 'NoExecute'])", message="supported taint effect values: 'NoSchedule', 'PreferNoSchedule', 'NoExecute'"
+	AdmissionTolerations []corev1.Toleration `json:"admissionTolerations,omitempty"`
+
+	// resourceOverheads are added to the requests of the pods of the workloads
+	// in the ClusterQueue, for quota accounting, so that the admissions account
+	// for the resources that the pods consume beyond their requests, like
+	// the OS or JVM overhead. The requests of the pods are not changed.
+	//
+	// resourceOverheads can be up to 16 elements.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	ResourceOverheads []ResourceOverhead `json:"resourceOverheads,omitempty"`
+}
+
+// ResourceOverhead defines the overhead added to the request of a resource of
+// each pod. The request is first multiplied by the factor, and then the addend
+// is added to it. The overhead only applies to the pods which request the resource.
+type ResourceOverhead struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// factor by which the request of each pod is multiplied.
+	// If null, the request is not multiplied.
+	// If not null, it must be greater than or equal to 1.
+	// +optional
+	Factor *resource.Quantity `json:"factor,omitempty"`
+
+	// addend is the quantity added to the request of each pod.
+	// If null, no quantity is added.
+	// If not null, it must be non-negative.
+	// +optional
+	Addend *resource.Quantity `json:"addend,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -243,6 +356,15 @@ type ResourceQuota struct {
 	// +optional
 	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
 
+	// borrowable indicates whether this ClusterQueue is allowed to borrow the
+	// [flavor, resource] combination from the unused quota of other ClusterQueues
+	// in the same cohort. It allows to prevent the borrowing of the resources that
+	// must not be shared, like licenses, while the other resources can be borrowed.
+	// If null, it means that the resource is borrowable.
+	// borrowable can't be false if borrowingLimit is set.
+	// +optional
+	Borrowable *bool `json:"borrowable,omitempty"`
+
 	// lendingLimit is the maximum amount of unused quota for the [flavor, resource]
 	// combination that this ClusterQueue can lend to other ClusterQueues in the same cohort.
 	// In total, at a given time, ClusterQueue reserves for its exclusive use
@@ -254,6 +376,78 @@ type ResourceQuota struct {
 	// This field is in beta stage and is enabled by default.
 	// +optional
 	LendingLimit *resource.Quantity `json:"lendingLimit,omitempty"`
+
+	// overcommitFactor is the factor by which the nominalQuota is multiplied
+	// to obtain the quota that Workloads can be admitted against, for clusters
+	// that intentionally overcommit the resource, like CPU.
+	// The nominalQuota is still the quota reported for the ClusterQueue.
+	// If null, the nominalQuota is not overcommitted.
+	// If not null, it must be greater than or equal to 1.
+	// +optional
+	OvercommitFactor *resource.Quantity `json:"overcommitFactor,omitempty"`
+
+	// priorityReservation is the part of the nominalQuota reserved for the
+	// Workloads with a high priority, so that they are never blocked by the
+	// Workloads with a lower priority.
+	// If null, the whole nominalQuota can be used by any Workload.
+	// +optional
+	PriorityReservation *PriorityReservation `json:"priorityReservation,omitempty"`
+
+	// scheduledQuota is the part of the nominalQuota which only becomes
+	// available at a known time, for example when a reservation of the nodes
+	// by another party ends. Until then, the Workloads which don't fit in the
+	// rest of the quota can reserve the scheduled quota, and they are admitted
+	// once it becomes available.
+	// If null, the whole nominalQuota is available.
+	// +optional
+	ScheduledQuota *ScheduledQuota `json:"scheduledQuota,omitempty"`
+
+	// elasticQuota makes the nominalQuota follow the size of a pool of nodes,
+	// rather than being static. The ClusterQueue controller keeps the
+	// nominalQuota equal to the sum of the allocatable quantities of the
+	// resource on the ready and schedulable nodes of the pool.
+	// elasticQuota can't be set along with lendingLimit, priorityReservation
+	// or scheduledQuota, and can't be set in a Cohort.
+	// This field is in alpha stage and requires the ElasticClusterQueueQuota
+	// feature gate to be enabled.
+	// +optional
+	ElasticQuota *ElasticQuota `json:"elasticQuota,omitempty"`
+}
+
+// ElasticQuota is the pool of nodes whose size the nominalQuota of a
+// [flavor, resource] combination follows.
+type ElasticQuota struct {
+	// nodeLabels are the labels of the nodes in the pool.
+	// +mapType=atomic
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:MaxProperties=8
+	NodeLabels map[string]string `json:"nodeLabels"`
+}
+
+// ScheduledQuota is the part of the quota of a [flavor, resource] combination
+// which only becomes available at a known time.
+type ScheduledQuota struct {
+	// quantity is the part of the nominalQuota which is not available
+	// before startTime.
+	// quantity must be non-negative and not greater than the nominalQuota.
+	Quantity resource.Quantity `json:"quantity"`
+
+	// startTime is the time at which the quantity becomes available.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// PriorityReservation is the part of the quota of a [flavor, resource]
+// combination which is reserved for the Workloads with a high priority.
+type PriorityReservation struct {
+	// quantity is the part of the nominalQuota which can only be used by the
+	// Workloads with a priority greater than or equal to minPriority.
+	// The Workloads with a lower priority can't use it, even when it's unused.
+	// quantity must be non-negative and not greater than the nominalQuota.
+	Quantity resource.Quantity `json:"quantity"`
+
+	// minPriority is the minimum priority of the Workloads which can use
+	// the reserved quantity.
+	MinPriority int32 `json:"minPriority"`
 }
 
 // ResourceFlavorReference is the name of the ResourceFlavor.
@@ -470,8 +664,28 @@ type ClusterQueuePreemption struct {
 	// +kubebuilder:default=Never
 	// +kubebuilder:validation:Enum=Never;LowerPriority;LowerOrNewerEqualPriority
 	WithinClusterQueue PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+
+	// borrowingPreference determines whether a pending Workload, that fits
+	// in the ClusterQueue by borrowing quota from the cohort, borrows the quota
+	// or preempts Workloads in the ClusterQueue, according to withinClusterQueue,
+	// to fit within the nominal quota instead. The possible values are:
+	//
+	// - `PreferBorrowing` (default): borrow quota from the cohort.
+	// - `PreferPreemption`: preempt Workloads in the ClusterQueue. When there are
+	//   no Workloads to preempt, the pending Workload borrows quota from the cohort.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=PreferBorrowing;PreferPreemption
+	BorrowingPreference *BorrowingPreference `json:"borrowingPreference,omitempty"`
 }
 
+type BorrowingPreference string
+
+const (
+	PreferBorrowing  BorrowingPreference = "PreferBorrowing"
+	PreferPreemption BorrowingPreference = "PreferPreemption"
+)
+
 type BorrowWithinCohortPolicy string
 
 const (
@@ -54,6 +54,36 @@ type LocalQueueSpec struct {
 	// if AdmissionFairSharing is enabled in the Kueue configuration.
 	// +optional
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// resourceLimits are the sub-quotas of the resources of the ClusterQueue
+	// for the workloads submitted to this LocalQueue. The limits apply to the
+	// usage of a resource across all the flavors of the ClusterQueue.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ResourceLimits []LocalQueueResourceLimit `json:"resourceLimits,omitempty"`
+}
+
+type LocalQueueResourceLimit struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the quantity of the resource which is guaranteed for
+	// the workloads submitted to this LocalQueue. The workloads submitted to
+	// the other LocalQueues of the ClusterQueue can't use the part of the
+	// nominalQuota which is unused by this LocalQueue.
+	// If not null, it must be non-negative.
+	// +optional
+	NominalQuota *resource.Quantity `json:"nominalQuota,omitempty"`
+
+	// maxQuota is the maximum quantity of the resource that the workloads
+	// submitted to this LocalQueue can use, even if the ClusterQueue has
+	// unused quota.
+	// If not null, it must be non-negative and greater than or equal to the
+	// nominalQuota.
+	// +optional
+	MaxQuota *resource.Quantity `json:"maxQuota,omitempty"`
 }
 
 type LocalQueueFlavorStatus struct {
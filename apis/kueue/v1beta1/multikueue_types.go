@@ -24,6 +24,21 @@ const (
 	MultiKueueConfigSecretKey = "kubeconfig"
 	MultiKueueClusterActive   = "Active"
 
+	// MultiKueueClientCertificateSecretKey and MultiKueueClientKeySecretKey are the
+	// optional keys of the kubeconfig secret holding the PEM encoded client
+	// certificate and key used to authenticate to the worker cluster with mTLS.
+	// They override the client credentials of the current context of the kubeconfig.
+	MultiKueueClientCertificateSecretKey = "tls.crt"
+	MultiKueueClientKeySecretKey         = "tls.key"
+
+	// MultiKueueCASecretKey is the optional key of the kubeconfig secret holding
+	// the PEM encoded CA bundle used to verify the worker cluster's server certificate.
+	MultiKueueCASecretKey = "ca.crt"
+
+	// MultiKueueTLSServerNameSecretKey is the optional key of the kubeconfig secret
+	// holding the server name used to verify the worker cluster's server certificate.
+	MultiKueueTLSServerNameSecretKey = "tls-server-name"
+
 	// MultiKueueOriginLabel is a label used to track the creator
 	// of multikueue remote objects.
 	MultiKueueOriginLabel = "kueue.x-k8s.io/multikueue-origin"
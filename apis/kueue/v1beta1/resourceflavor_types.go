@@ -43,6 +43,7 @@ type TopologyReference string
 // ResourceFlavorSpec defines the desired state of the ResourceFlavor
 // +kubebuilder:validation:XValidation:rule="!has(self.topologyName) || self.nodeLabels.size() >= 1", message="at least one nodeLabel is required when topology is set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.topologyName) || self == oldSelf", message="resourceFlavorSpec are immutable when topologyName is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultRequiredTopologyLevel) || has(self.topologyName)", message="topologyName is required when defaultRequiredTopologyLevel is set"
 type ResourceFlavorSpec struct {
 	// nodeLabels are labels that associate the ResourceFlavor with Nodes that
 	// have the same labels.
@@ -97,14 +98,76 @@ type ResourceFlavorSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self.all(x, !has(x.effect) || x.effect in ['NoSchedule', 'PreferNoSchedule', 'NoExecute'])", message="supported taint effect values: 'NoSchedule', 'PreferNoSchedule', 'NoExecute'"
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// taintsToTolerate are taints that the pods of the Workloads' podsets must
+	// tolerate, through their own tolerations, in order to get assigned this
+	// ResourceFlavor during admission.
+	// Unlike nodeTaints, taintsToTolerate are evaluated even when this
+	// ResourceFlavor sets matching tolerations (in .spec.tolerations), which
+	// allows to gate the ResourceFlavor on the tolerations of the pods.
+	// All the taint effects are evaluated.
+	//
+	// taintsToTolerate can be up to 8 elements.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(x, x.effect in ['NoSchedule', 'PreferNoSchedule', 'NoExecute'])", message="supported taint effect values: 'NoSchedule', 'PreferNoSchedule', 'NoExecute'"
+	TaintsToTolerate []corev1.Taint `json:"taintsToTolerate,omitempty"`
+
 	// topologyName indicates topology for the TAS ResourceFlavor.
 	// When specified, it enables scraping of the topology information from the
 	// nodes matching to the Resource Flavor node labels.
 	//
 	// +optional
 	TopologyName *TopologyReference `json:"topologyName,omitempty"`
+
+	// defaultRequiredTopologyLevel indicates the topology level at which the
+	// pods of the Workloads' podsets assigned this ResourceFlavor are required
+	// to be placed, when the podsets don't request a topology explicitly.
+	// It can only be set along with topologyName, and should match one of
+	// the levels of the Topology.
+	//
+	// +optional
+	DefaultRequiredTopologyLevel *string `json:"defaultRequiredTopologyLevel,omitempty"`
+
+	// disabled indicates that this ResourceFlavor can't be assigned to new
+	// workloads. The workloads which are already admitted using this
+	// ResourceFlavor keep running, which allows to phase out the flavor
+	// without deleting it.
+	// Defaults to false.
+	//
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// deletionPolicy determines how the deletion of the ResourceFlavor is
+	// handled while there are Workloads with quota reserved using it.
+	// The deletion is always blocked while ClusterQueues reference the
+	// ResourceFlavor.
+	//
+	// - Block - The deletion is blocked until the Workloads finish or release
+	//   the quota reservation.
+	// - EvictWorkloads - The Workloads are evicted, and the deletion completes
+	//   once they release the quota reservation.
+	//
+	// Defaults to Block.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Block;EvictWorkloads
+	DeletionPolicy *ResourceFlavorDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+type ResourceFlavorDeletionPolicy string
+
+const (
+	// ResourceFlavorDeletionBlock blocks the deletion of the ResourceFlavor
+	// while Workloads have quota reserved using it.
+	ResourceFlavorDeletionBlock ResourceFlavorDeletionPolicy = "Block"
+
+	// ResourceFlavorDeletionEvictWorkloads evicts the Workloads which have
+	// quota reserved using the ResourceFlavor being deleted.
+	ResourceFlavorDeletionEvictWorkloads ResourceFlavorDeletionPolicy = "EvictWorkloads"
+)
+
 // +kubebuilder:object:root=true
 
 // ResourceFlavorList contains a list of ResourceFlavor
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // WorkloadSpec defines the desired state of Workload
@@ -110,6 +111,28 @@ type PodSetTopologyRequest struct {
 	// +kubebuilder:validation:Type=boolean
 	Unconstrained *bool `json:"unconstrained,omitempty"`
 
+	// spread indicates the topology level across which the pods of the PodSet
+	// are spread, maximizing the number of distinct topology domains used, as
+	// indicated by the `kueue.x-k8s.io/podset-spread-topology` PodSet
+	// annotation.
+	//
+	// +optional
+	Spread *string `json:"spread,omitempty"`
+
+	// nodeCapacityPercentage indicates the percentage of the capacity of a
+	// node requested by each pod of the PodSet, as indicated by the
+	// `kueue.x-k8s.io/podset-node-capacity-percentage` PodSet annotation.
+	// For example, 100 requests whole nodes. The requests of the pods are
+	// resolved against the capacity of each node, which isn't used by the pods
+	// of non-TAS workloads, for all the resources of the node, unless the
+	// requests of the pods are larger. It requires the lowest level of the
+	// topology to be the node.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	NodeCapacityPercentage *int32 `json:"nodeCapacityPercentage,omitempty"`
+
 	// PodIndexLabel indicates the name of the label indexing the pods.
 	// For example, in the context of
 	// - kubernetes job this is: kubernetes.io/job-completion-index
@@ -135,6 +158,18 @@ type Admission struct {
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=8
 	PodSetAssignments []PodSetAssignment `json:"podSetAssignments"`
+
+	// fallbackCohort is the name of the fallback cohort of the ClusterQueue
+	// which provided the quota for this workload, when the quota available in
+	// the cohort of the ClusterQueue was exhausted.
+	// +optional
+	FallbackCohort CohortReference `json:"fallbackCohort,omitempty"`
+
+	// startTime is the time before which the workload can't be admitted,
+	// because its quota was reserved against the scheduled quota of the
+	// ClusterQueue, which only becomes available at that time.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
 }
 
 // PodSetReference is the name of a PodSet.
@@ -296,6 +331,17 @@ type PodSet struct {
 	// +kubebuilder:validation:Minimum=1
 	MinCount *int32 `json:"minCount,omitempty"`
 
+	// priority of the PodSet relative to the other PodSets of the workload.
+	// When the PodSetPriority feature gate is enabled, the PodSets with a
+	// higher priority are assigned flavors and topology domains first, so that
+	// they get the preferred ones when the quota or the capacity is scarce.
+	// PodSets without a priority have a priority of 0.
+	// When the workload is created for a job, it is populated from the
+	// PriorityClass of the pod template, if set.
+	//
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
 	// topologyRequest defines the topology request for the PodSet.
 	//
 	// +optional
@@ -315,6 +361,19 @@ type WorkloadStatus struct {
 	// +optional
 	RequeueState *RequeueState `json:"requeueState,omitempty"`
 
+	// requeueCount records the number of times the workload was requeued after
+	// an eviction released its quota reservation, for any reason other than the
+	// deactivation or the stop of its queue, like a preemption.
+	// Unlike requeueState.count, which only counts the requeues with a backoff,
+	// like after a PodsReady timeout, and is used to compute the backoff,
+	// this count covers all the requeues and is only used to limit them.
+	// When a deactivated (`.spec.activate`=`false`) workload is reactivated (`.spec.activate`=`true`),
+	// this count would be reset to null.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequeueCount *int32 `json:"requeueCount,omitempty"`
+
 	// conditions hold the latest available observations of the Workload
 	// current state.
 	//
@@ -365,6 +424,190 @@ type WorkloadStatus struct {
 	//
 	// +optional
 	AccumulatedPastExexcutionTimeSeconds *int32 `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+
+	// preemptedBy references the workload which preempted this workload the
+	// last time it was evicted by preemption.
+	//
+	// +optional
+	PreemptedBy *WorkloadPreemptionReference `json:"preemptedBy,omitempty"`
+
+	// preemptedWorkloads lists the workloads which were preempted to make room
+	// for this workload the last time it issued preemptions.
+	//
+	// +optional
+	// +listType=atomic
+	PreemptedWorkloads []WorkloadPreemptionReference `json:"preemptedWorkloads,omitempty"`
+
+	// admissionHistory records the most recent admissions and evictions of
+	// the workload, oldest first. Only the last 10 entries are kept.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10
+	AdmissionHistory []AdmissionHistoryEntry `json:"admissionHistory,omitempty"`
+
+	// admissionPath records how the quota was obtained when the workload got
+	// its quota reserved by the scheduler. While the workload is pending the
+	// preemptions it issued, it records the path by which the workload is
+	// expected to get its quota. It is cleared when the quota reservation is
+	// released, or when the preemptions don't make room for the workload.
+	//
+	// +optional
+	AdmissionPath AdmissionPath `json:"admissionPath,omitempty"`
+
+	// queuePosition is the position of the pending workload among the pending
+	// workloads of its ClusterQueue, where 0 means that the workload is the
+	// next one to be considered for admission. It is updated periodically,
+	// so it might not reflect the most recent changes of the queue, and it is
+	// cleared when the workload is no longer pending.
+	// Populated only when the WorkloadQueuePosition feature gate is enabled.
+	//
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
+
+	// lastPlacement records the flavors and the topology assignments of the
+	// PodSets in the last admission of the workload. It is kept when the
+	// workload is evicted, so that the workload is preferably admitted again
+	// to the same flavors and topology domains, if they still fit.
+	// Populated only when the WorkloadResumeHints feature gate is enabled.
+	//
+	// +optional
+	LastPlacement *WorkloadPlacement `json:"lastPlacement,omitempty"`
+
+	// evictionCount records the number of times the workload was evicted.
+	// Populated only when the WorkloadEvictionStatus feature gate is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	EvictionCount *int32 `json:"evictionCount,omitempty"`
+
+	// lastEvictionReason is the reason of the last eviction of the workload,
+	// as recorded in the Evicted condition.
+	// Populated only when the WorkloadEvictionStatus feature gate is enabled.
+	//
+	// +optional
+	LastEvictionReason string `json:"lastEvictionReason,omitempty"`
+
+	// lastEvictionTime is the time of the last eviction of the workload.
+	// Populated only when the WorkloadEvictionStatus feature gate is enabled.
+	//
+	// +optional
+	LastEvictionTime *metav1.Time `json:"lastEvictionTime,omitempty"`
+}
+
+// WorkloadPlacement is the placement of the PodSets of a workload admitted
+// in a ClusterQueue.
+type WorkloadPlacement struct {
+	// clusterQueue in which the workload was admitted.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
+
+	// podSets lists the placement of each PodSet of the workload.
+	//
+	// +required
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	PodSets []PodSetPlacement `json:"podSets"`
+}
+
+// PodSetPlacement is the placement of a PodSet of an admitted workload.
+type PodSetPlacement struct {
+	// name of the PodSet.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Name PodSetReference `json:"name"`
+
+	// flavors are the flavors assigned to the resources of the PodSet.
+	//
+	// +optional
+	Flavors map[corev1.ResourceName]ResourceFlavorReference `json:"flavors,omitempty"`
+
+	// topologyAssignment is the topology assignment of the PodSet, if any.
+	//
+	// +optional
+	TopologyAssignment *TopologyAssignment `json:"topologyAssignment,omitempty"`
+}
+
+// AdmissionPath is the way the quota of a workload was obtained.
+// +kubebuilder:validation:Enum=Nominal;Borrowing;PreemptionWithinClusterQueue;ReclaimWithinCohort
+type AdmissionPath string
+
+const (
+	// AdmissionPathNominal means that the workload fit in the nominal quota
+	// of its ClusterQueue.
+	AdmissionPathNominal AdmissionPath = "Nominal"
+
+	// AdmissionPathBorrowing means that the workload fit by borrowing the
+	// unused quota of its cohort.
+	AdmissionPathBorrowing AdmissionPath = "Borrowing"
+
+	// AdmissionPathPreemptionWithinClusterQueue means that the workload fit
+	// after preempting workloads of its ClusterQueue.
+	AdmissionPathPreemptionWithinClusterQueue AdmissionPath = "PreemptionWithinClusterQueue"
+
+	// AdmissionPathReclaimWithinCohort means that the workload fit after
+	// preempting workloads of other ClusterQueues in its cohort.
+	AdmissionPathReclaimWithinCohort AdmissionPath = "ReclaimWithinCohort"
+)
+
+// AdmissionHistoryEntry records an admission or an eviction of a workload.
+type AdmissionHistoryEntry struct {
+	// type of the event, either Admitted or Evicted.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Admitted;Evicted
+	Type string `json:"type"`
+
+	// time of the event.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Time metav1.Time `json:"time"`
+
+	// reason of the event, as recorded in the corresponding condition.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+
+	// clusterQueue in which the workload was admitted, or from which it
+	// was evicted.
+	//
+	// +optional
+	ClusterQueue ClusterQueueReference `json:"clusterQueue,omitempty"`
+
+	// flavors lists the ResourceFlavors assigned to the workload at the time
+	// of the event.
+	//
+	// +optional
+	// +listType=set
+	Flavors []ResourceFlavorReference `json:"flavors,omitempty"`
+}
+
+// WorkloadPreemptionReference identifies a workload involved in a preemption.
+type WorkloadPreemptionReference struct {
+	// namespace of the workload.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// name of the workload.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// uid of the workload.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	UID types.UID `json:"uid"`
 }
 
 type RequeueState struct {
@@ -416,6 +659,32 @@ type AdmissionCheckState struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=8
 	PodSetUpdates []PodSetUpdate `json:"podSetUpdates,omitempty"`
+
+	// podSetResources lists the resources requested by the admission check
+	// for each pod of the PodSets, in addition to the requests of their
+	// containers, like the resources of a sidecar container injected by the
+	// admission check controller. They are added to the requests of the
+	// workload when its quota is reserved.
+	// Honored only when the AdmissionCheckPodSetResources feature gate is enabled.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	PodSetResources []PodSetResources `json:"podSetResources,omitempty"`
+}
+
+// PodSetResources contains the resources requested by an AdmissionCheck for
+// each pod of a PodSet.
+type PodSetResources struct {
+	// name of the PodSet. Should match one of the Workload's PodSets.
+	// +required
+	// +kubebuilder:validation:Required
+	Name PodSetReference `json:"name"`
+
+	// resources requested for each pod of the PodSet.
+	// +required
+	// +kubebuilder:validation:Required
+	Resources corev1.ResourceList `json:"resources"`
 }
 
 // PodSetUpdate contains a list of pod set modifications suggested by AdmissionChecks.
@@ -538,6 +807,15 @@ const (
 	// due to LocalQueue or ClusterQueue doesn't exist or inactive.
 	WorkloadInadmissible = "Inadmissible"
 
+	// WorkloadNamespaceNotAllowed means that the Workload can't reserve quota
+	// because its namespace doesn't match the namespaceSelector of the ClusterQueue.
+	WorkloadNamespaceNotAllowed = "NamespaceNotAllowed"
+
+	// WorkloadExceedsQueueCapacity means that the Workload can't reserve quota
+	// because it requests more resources than the maximum capacity of the
+	// ClusterQueue, including the capacity it can borrow.
+	WorkloadExceedsQueueCapacity = "WorkloadExceedsQueueCapacity"
+
 	// WorkloadEvictedByPreemption indicates that the workload was evicted
 	// in order to free resources for a workload with a higher priority.
 	WorkloadEvictedByPreemption = "Preempted"
@@ -546,10 +824,20 @@ const (
 	// place due to a PodsReady timeout.
 	WorkloadEvictedByPodsReadyTimeout = "PodsReadyTimeout"
 
+	// WorkloadEvictedByReservationNotReady indicates that the workload was
+	// evicted because it didn't become ready within the reservation timeout
+	// since its quota was reserved.
+	WorkloadEvictedByReservationNotReady = "ReservationNotReady"
+
 	// WorkloadEvictedByAdmissionCheck indicates that the workload was evicted
 	// because at least one admission check transitioned to False.
 	WorkloadEvictedByAdmissionCheck = "AdmissionCheck"
 
+	// WorkloadEvictedByAdmissionCheckRejectedPostAdmission indicates that the
+	// workload was evicted because at least one admission check transitioned
+	// to Rejected after the workload was admitted.
+	WorkloadEvictedByAdmissionCheckRejectedPostAdmission = "AdmissionCheckRejectedPostAdmission"
+
 	// WorkloadEvictedByClusterQueueStopped indicates that the workload was evicted
 	// because the ClusterQueue is Stopped.
 	WorkloadEvictedByClusterQueueStopped = "ClusterQueueStopped"
@@ -558,6 +846,19 @@ const (
 	// because the LocalQueue is Stopped.
 	WorkloadEvictedByLocalQueueStopped = "LocalQueueStopped"
 
+	// WorkloadEvictedByTopologyDomainUnavailable indicates that the workload was
+	// evicted because a topology domain assigned to it lost all its ready and
+	// schedulable nodes.
+	WorkloadEvictedByTopologyDomainUnavailable = "TopologyDomainUnavailable"
+
+	// WorkloadEvictedByResourceFlavorDeletion indicates that the workload was
+	// evicted because a ResourceFlavor assigned to it is being deleted.
+	WorkloadEvictedByResourceFlavorDeletion = "ResourceFlavorDeleted"
+
+	// WorkloadEvictedByNodeDrain indicates that the workload was evicted
+	// because some of its pods were running on nodes being drained.
+	WorkloadEvictedByNodeDrain = "NodeDrained"
+
 	// WorkloadDeactivated indicates that the workload was evicted
 	// because spec.active is set to false.
 	WorkloadDeactivated = "Deactivated"
@@ -582,10 +883,18 @@ const (
 	// of re-queuing retries.
 	WorkloadRequeuingLimitExceeded = "RequeuingLimitExceeded"
 
+	// WorkloadMaxRequeuesExceeded indicates that the workload exceeded the
+	// maximum number of requeues after the evictions.
+	WorkloadMaxRequeuesExceeded = "MaxRequeuesExceeded"
+
 	// WorkloadMaximumExecutionTimeExceeded indicates that the workload exceeded its
 	// maximum execution time.
 	WorkloadMaximumExecutionTimeExceeded = "MaximumExecutionTimeExceeded"
 
+	// WorkloadAdmitDeadlineExceeded indicates that the workload wasn't admitted
+	// within the deadline set by the kueue.x-k8s.io/admit-deadline-seconds annotation.
+	WorkloadAdmitDeadlineExceeded = "AdmitDeadlineExceeded"
+
 	// WorkloadWaitForStart indicates the reason for PodsReady=False condition
 	// when the pods have not been ready since admission, or the workload is not admitted.
 	WorkloadWaitForStart = "WaitForStart"
@@ -595,6 +904,11 @@ const (
 	// and workload waits for recovering.
 	WorkloadWaitForRecovery = "WaitForRecovery"
 
+	// WorkloadPartiallyReady indicates the reason for the PodsReady=False condition
+	// when enough Pods are ready to start the PodsReady timeout again, according to
+	// waitForPodsReady.timeoutStartPolicy, but not all of them
+	WorkloadPartiallyReady = "PartiallyReady"
+
 	// WorkloadStarted indicates that all Pods are ready and the Workload has successfully started
 	WorkloadStarted = "Started"
 
@@ -36,6 +36,10 @@ func (in *Admission) DeepCopyInto(out *Admission) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Admission.
@@ -135,6 +139,11 @@ func (in *AdmissionCheckSpec) DeepCopyInto(out *AdmissionCheckSpec) {
 		*out = new(AdmissionCheckParametersReference)
 		**out = **in
 	}
+	if in.ReservationHoldSeconds != nil {
+		in, out := &in.ReservationHoldSeconds, &out.ReservationHoldSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckSpec.
@@ -158,6 +167,13 @@ func (in *AdmissionCheckState) DeepCopyInto(out *AdmissionCheckState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodSetResources != nil {
+		in, out := &in.PodSetResources, &out.PodSetResources
+		*out = make([]PodSetResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckState.
@@ -257,6 +273,27 @@ func (in *AdmissionFairSharingStatus) DeepCopy() *AdmissionFairSharingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionHistoryEntry) DeepCopyInto(out *AdmissionHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]ResourceFlavorReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionHistoryEntry.
+func (in *AdmissionHistoryEntry) DeepCopy() *AdmissionHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionScope) DeepCopyInto(out *AdmissionScope) {
 	*out = *in
@@ -292,6 +329,79 @@ func (in *BorrowWithinCohort) DeepCopy() *BorrowWithinCohort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELAdmissionCheckConfig) DeepCopyInto(out *CELAdmissionCheckConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELAdmissionCheckConfig.
+func (in *CELAdmissionCheckConfig) DeepCopy() *CELAdmissionCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CELAdmissionCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CELAdmissionCheckConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELAdmissionCheckConfigList) DeepCopyInto(out *CELAdmissionCheckConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CELAdmissionCheckConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELAdmissionCheckConfigList.
+func (in *CELAdmissionCheckConfigList) DeepCopy() *CELAdmissionCheckConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(CELAdmissionCheckConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CELAdmissionCheckConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELAdmissionCheckConfigSpec) DeepCopyInto(out *CELAdmissionCheckConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELAdmissionCheckConfigSpec.
+func (in *CELAdmissionCheckConfigSpec) DeepCopy() *CELAdmissionCheckConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CELAdmissionCheckConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -395,6 +505,11 @@ func (in *ClusterQueuePreemption) DeepCopyInto(out *ClusterQueuePreemption) {
 		*out = new(BorrowWithinCohort)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BorrowingPreference != nil {
+		in, out := &in.BorrowingPreference, &out.BorrowingPreference
+		*out = new(BorrowingPreference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueuePreemption.
@@ -442,6 +557,21 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(AdmissionChecksStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxConcurrentChecks != nil {
+		in, out := &in.MaxConcurrentChecks, &out.MaxConcurrentChecks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinAdmissionIntervalSeconds != nil {
+		in, out := &in.MinAdmissionIntervalSeconds, &out.MinAdmissionIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BorrowingMinPriority != nil {
+		in, out := &in.BorrowingMinPriority, &out.BorrowingMinPriority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.StopPolicy != nil {
 		in, out := &in.StopPolicy, &out.StopPolicy
 		*out = new(StopPolicy)
@@ -457,6 +587,25 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(AdmissionScope)
 		**out = **in
 	}
+	if in.NearCapacityThresholdPercentage != nil {
+		in, out := &in.NearCapacityThresholdPercentage, &out.NearCapacityThresholdPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AdmissionTolerations != nil {
+		in, out := &in.AdmissionTolerations, &out.AdmissionTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceOverheads != nil {
+		in, out := &in.ResourceOverheads, &out.ResourceOverheads
+		*out = make([]ResourceOverhead, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -515,6 +664,28 @@ func (in *ClusterQueueStatus) DeepCopy() *ClusterQueueStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticQuota) DeepCopyInto(out *ElasticQuota) {
+	*out = *in
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticQuota.
+func (in *ElasticQuota) DeepCopy() *ElasticQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 	*out = *in
@@ -749,6 +920,31 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueResourceLimit) DeepCopyInto(out *LocalQueueResourceLimit) {
+	*out = *in
+	if in.NominalQuota != nil {
+		in, out := &in.NominalQuota, &out.NominalQuota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxQuota != nil {
+		in, out := &in.MaxQuota, &out.MaxQuota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueResourceLimit.
+func (in *LocalQueueResourceLimit) DeepCopy() *LocalQueueResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueResourceUsage) DeepCopyInto(out *LocalQueueResourceUsage) {
 	*out = *in
@@ -778,6 +974,13 @@ func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = make([]LocalQueueResourceLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -1022,6 +1225,11 @@ func (in *PodSet) DeepCopyInto(out *PodSet) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.TopologyRequest != nil {
 		in, out := &in.TopologyRequest, &out.TopologyRequest
 		*out = new(PodSetTopologyRequest)
@@ -1078,6 +1286,33 @@ func (in *PodSetAssignment) DeepCopy() *PodSetAssignment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetPlacement) DeepCopyInto(out *PodSetPlacement) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[corev1.ResourceName]ResourceFlavorReference, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TopologyAssignment != nil {
+		in, out := &in.TopologyAssignment, &out.TopologyAssignment
+		*out = new(TopologyAssignment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetPlacement.
+func (in *PodSetPlacement) DeepCopy() *PodSetPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSetRequest) DeepCopyInto(out *PodSetRequest) {
 	*out = *in
@@ -1100,6 +1335,28 @@ func (in *PodSetRequest) DeepCopy() *PodSetRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetResources) DeepCopyInto(out *PodSetResources) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetResources.
+func (in *PodSetResources) DeepCopy() *PodSetResources {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSetTopologyRequest) DeepCopyInto(out *PodSetTopologyRequest) {
 	*out = *in
@@ -1118,6 +1375,16 @@ func (in *PodSetTopologyRequest) DeepCopyInto(out *PodSetTopologyRequest) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Spread != nil {
+		in, out := &in.Spread, &out.Spread
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodeCapacityPercentage != nil {
+		in, out := &in.NodeCapacityPercentage, &out.NodeCapacityPercentage
+		*out = new(int32)
+		**out = **in
+	}
 	if in.PodIndexLabel != nil {
 		in, out := &in.PodIndexLabel, &out.PodIndexLabel
 		*out = new(string)
@@ -1188,6 +1455,22 @@ func (in *PodSetUpdate) DeepCopy() *PodSetUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityReservation) DeepCopyInto(out *PriorityReservation) {
+	*out = *in
+	out.Quantity = in.Quantity.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityReservation.
+func (in *PriorityReservation) DeepCopy() *PriorityReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvisioningRequestConfig) DeepCopyInto(out *ProvisioningRequestConfig) {
 	*out = *in
@@ -1429,11 +1712,33 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TaintsToTolerate != nil {
+		in, out := &in.TaintsToTolerate, &out.TaintsToTolerate
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TopologyName != nil {
 		in, out := &in.TopologyName, &out.TopologyName
 		*out = new(TopologyReference)
 		**out = **in
 	}
+	if in.DefaultRequiredTopologyLevel != nil {
+		in, out := &in.DefaultRequiredTopologyLevel, &out.DefaultRequiredTopologyLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(ResourceFlavorDeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -1473,6 +1778,31 @@ func (in *ResourceGroup) DeepCopy() *ResourceGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOverhead) DeepCopyInto(out *ResourceOverhead) {
+	*out = *in
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Addend != nil {
+		in, out := &in.Addend, &out.Addend
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOverhead.
+func (in *ResourceOverhead) DeepCopy() *ResourceOverhead {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOverhead)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 	*out = *in
@@ -1482,11 +1812,36 @@ func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Borrowable != nil {
+		in, out := &in.Borrowable, &out.Borrowable
+		*out = new(bool)
+		**out = **in
+	}
 	if in.LendingLimit != nil {
 		in, out := &in.LendingLimit, &out.LendingLimit
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.OvercommitFactor != nil {
+		in, out := &in.OvercommitFactor, &out.OvercommitFactor
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PriorityReservation != nil {
+		in, out := &in.PriorityReservation, &out.PriorityReservation
+		*out = new(PriorityReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScheduledQuota != nil {
+		in, out := &in.ScheduledQuota, &out.ScheduledQuota
+		*out = new(ScheduledQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ElasticQuota != nil {
+		in, out := &in.ElasticQuota, &out.ElasticQuota
+		*out = new(ElasticQuota)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuota.
@@ -1516,6 +1871,23 @@ func (in *ResourceUsage) DeepCopy() *ResourceUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledQuota) DeepCopyInto(out *ScheduledQuota) {
+	*out = *in
+	out.Quantity = in.Quantity.DeepCopy()
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledQuota.
+func (in *ScheduledQuota) DeepCopy() *ScheduledQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyAssignment) DeepCopyInto(out *TopologyAssignment) {
 	*out = *in
@@ -1642,6 +2014,43 @@ func (in *WorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacement) DeepCopyInto(out *WorkloadPlacement) {
+	*out = *in
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]PodSetPlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacement.
+func (in *WorkloadPlacement) DeepCopy() *WorkloadPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPreemptionReference) DeepCopyInto(out *WorkloadPreemptionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPreemptionReference.
+func (in *WorkloadPreemptionReference) DeepCopy() *WorkloadPreemptionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPreemptionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadPriorityClass) DeepCopyInto(out *WorkloadPriorityClass) {
 	*out = *in
@@ -1749,6 +2158,11 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 		*out = new(RequeueState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RequeueCount != nil {
+		in, out := &in.RequeueCount, &out.RequeueCount
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1780,6 +2194,42 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PreemptedBy != nil {
+		in, out := &in.PreemptedBy, &out.PreemptedBy
+		*out = new(WorkloadPreemptionReference)
+		**out = **in
+	}
+	if in.PreemptedWorkloads != nil {
+		in, out := &in.PreemptedWorkloads, &out.PreemptedWorkloads
+		*out = make([]WorkloadPreemptionReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdmissionHistory != nil {
+		in, out := &in.AdmissionHistory, &out.AdmissionHistory
+		*out = make([]AdmissionHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastPlacement != nil {
+		in, out := &in.LastPlacement, &out.LastPlacement
+		*out = new(WorkloadPlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionCount != nil {
+		in, out := &in.EvictionCount, &out.EvictionCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastEvictionTime != nil {
+		in, out := &in.LastEvictionTime, &out.LastEvictionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -27,6 +29,7 @@ import (
 // +k8s:openapi-gen=true
 // +genclient:nonNamespaced
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=GetBorrowingWorkloadsSummary,verb=get,subresource=borrowingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.BorrowingWorkloadsSummary
 type ClusterQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -61,6 +64,26 @@ type LocalQueueList struct {
 	Items []LocalQueue `json:"items"`
 }
 
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +genclient:nonNamespaced
+// +genclient:method=GetCohortTree,verb=get,subresource=tree,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.CohortTree
+type Cohort struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Tree CohortTree `json:"tree"`
+}
+
+// +kubebuilder:object:root=true
+type CohortList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Cohort `json:"items"`
+}
+
 // PendingWorkload is a user-facing representation of a pending workload that summarizes the relevant information for
 // position in the cluster queue.
 type PendingWorkload struct {
@@ -91,6 +114,96 @@ type PendingWorkloadsSummary struct {
 	Items []PendingWorkload `json:"items"`
 }
 
+// BorrowedResource is the quota of a resource in a flavor reserved by a workload,
+// and the part of it which is borrowed from the cohort.
+type BorrowedResource struct {
+	// Flavor is the name of the ResourceFlavor
+	Flavor v1beta1.ResourceFlavorReference `json:"flavor"`
+
+	// Resource is the name of the resource
+	Resource corev1.ResourceName `json:"resource"`
+
+	// Total is the quota reserved by the workload
+	Total resource.Quantity `json:"total"`
+
+	// Borrowed is the part of the reserved quota which is above the nominal quota of the ClusterQueue
+	Borrowed resource.Quantity `json:"borrowed"`
+}
+
+// BorrowingWorkload is a user-facing representation of a workload with quota reserved
+// in the ClusterQueue, which uses quota borrowed from the cohort.
+type BorrowingWorkload struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Priority indicates the workload's priority
+	Priority int32 `json:"priority"`
+
+	// LocalQueueName indicates the name of the LocalQueue the workload is submitted to
+	LocalQueueName v1beta1.LocalQueueName `json:"localQueueName"`
+
+	// Resources lists the quota reserved by the workload, per flavor and resource,
+	// and how much of it is borrowed
+	Resources []BorrowedResource `json:"resources"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// BorrowingWorkloadsSummary contains the list of workloads with quota reserved
+// in the ClusterQueue, which use quota borrowed from the cohort.
+type BorrowingWorkloadsSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Items []BorrowingWorkload `json:"items"`
+}
+
+// CohortResource is the aggregated quota and usage of a resource in a flavor
+// within the subtree of a Cohort.
+type CohortResource struct {
+	// Flavor is the name of the ResourceFlavor
+	Flavor v1beta1.ResourceFlavorReference `json:"flavor"`
+
+	// Resource is the name of the resource
+	Resource corev1.ResourceName `json:"resource"`
+
+	// NominalQuota is the sum of the nominal quotas of the Cohorts and ClusterQueues in the subtree
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+
+	// Usage is the sum of the quota used by the admitted workloads in the subtree
+	Usage resource.Quantity `json:"usage"`
+}
+
+// CohortTreeNode is a user-facing representation of a Cohort within a tree of Cohorts.
+type CohortTreeNode struct {
+	// Name is the name of the Cohort
+	Name v1beta1.CohortReference `json:"name"`
+
+	// Parent is the name of the parent Cohort, empty for the root
+	Parent v1beta1.CohortReference `json:"parent,omitempty"`
+
+	// ClusterQueues lists the names of the ClusterQueues directly in the Cohort
+	ClusterQueues []v1beta1.ClusterQueueReference `json:"clusterQueues,omitempty"`
+
+	// Children lists the child Cohorts
+	Children []CohortTreeNode `json:"children,omitempty"`
+
+	// Resources lists the aggregated quota and usage of the subtree, per flavor and resource
+	Resources []CohortResource `json:"resources,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// CohortTree contains the tree of Cohorts which the requested Cohort belongs to,
+// starting from its root.
+type CohortTree struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Root CohortTreeNode `json:"root"`
+}
+
 // +kubebuilder:object:root=true
 // +k8s:openapi-gen=true
 // +k8s:conversion-gen:explicit-from=net/url.Values
@@ -111,5 +224,7 @@ func init() {
 	SchemeBuilder.Register(
 		&PendingWorkloadsSummary{},
 		&PendingWorkloadOptions{},
+		&BorrowingWorkloadsSummary{},
+		&CohortTree{},
 	)
 }
@@ -22,8 +22,81 @@ package v1beta1
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowedResource) DeepCopyInto(out *BorrowedResource) {
+	*out = *in
+	out.Total = in.Total.DeepCopy()
+	out.Borrowed = in.Borrowed.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowedResource.
+func (in *BorrowedResource) DeepCopy() *BorrowedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowingWorkload) DeepCopyInto(out *BorrowingWorkload) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]BorrowedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowingWorkload.
+func (in *BorrowingWorkload) DeepCopy() *BorrowingWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowingWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowingWorkloadsSummary) DeepCopyInto(out *BorrowingWorkloadsSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BorrowingWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowingWorkloadsSummary.
+func (in *BorrowingWorkloadsSummary) DeepCopy() *BorrowingWorkloadsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowingWorkloadsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BorrowingWorkloadsSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -82,6 +155,141 @@ func (in *ClusterQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cohort) DeepCopyInto(out *Cohort) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Tree.DeepCopyInto(&out.Tree)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cohort.
+func (in *Cohort) DeepCopy() *Cohort {
+	if in == nil {
+		return nil
+	}
+	out := new(Cohort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Cohort) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortList) DeepCopyInto(out *CohortList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Cohort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortList.
+func (in *CohortList) DeepCopy() *CohortList {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CohortList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortResource) DeepCopyInto(out *CohortResource) {
+	*out = *in
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+	out.Usage = in.Usage.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortResource.
+func (in *CohortResource) DeepCopy() *CohortResource {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortTree) DeepCopyInto(out *CohortTree) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Root.DeepCopyInto(&out.Root)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortTree.
+func (in *CohortTree) DeepCopy() *CohortTree {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CohortTree) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortTreeNode) DeepCopyInto(out *CohortTreeNode) {
+	*out = *in
+	if in.ClusterQueues != nil {
+		in, out := &in.ClusterQueues, &out.ClusterQueues
+		*out = make([]kueuev1beta1.ClusterQueueReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]CohortTreeNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CohortResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortTreeNode.
+func (in *CohortTreeNode) DeepCopy() *CohortTreeNode {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortTreeNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueue) DeepCopyInto(out *LocalQueue) {
 	*out = *in
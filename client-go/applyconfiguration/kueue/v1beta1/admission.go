@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
@@ -26,6 +27,8 @@ import (
 type AdmissionApplyConfiguration struct {
 	ClusterQueue      *kueuev1beta1.ClusterQueueReference  `json:"clusterQueue,omitempty"`
 	PodSetAssignments []PodSetAssignmentApplyConfiguration `json:"podSetAssignments,omitempty"`
+	FallbackCohort    *kueuev1beta1.CohortReference        `json:"fallbackCohort,omitempty"`
+	StartTime         *v1.Time                             `json:"startTime,omitempty"`
 }
 
 // AdmissionApplyConfiguration constructs a declarative configuration of the Admission type for use with
@@ -54,3 +57,19 @@ func (b *AdmissionApplyConfiguration) WithPodSetAssignments(values ...*PodSetAss
 	}
 	return b
 }
+
+// WithFallbackCohort sets the FallbackCohort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FallbackCohort field is set to the value of the last call.
+func (b *AdmissionApplyConfiguration) WithFallbackCohort(value kueuev1beta1.CohortReference) *AdmissionApplyConfiguration {
+	b.FallbackCohort = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *AdmissionApplyConfiguration) WithStartTime(value v1.Time) *AdmissionApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
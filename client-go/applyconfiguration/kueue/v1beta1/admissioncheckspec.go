@@ -20,9 +20,10 @@ package v1beta1
 // AdmissionCheckSpecApplyConfiguration represents a declarative configuration of the AdmissionCheckSpec type for use
 // with apply.
 type AdmissionCheckSpecApplyConfiguration struct {
-	ControllerName    *string                                              `json:"controllerName,omitempty"`
-	RetryDelayMinutes *int64                                               `json:"retryDelayMinutes,omitempty"`
-	Parameters        *AdmissionCheckParametersReferenceApplyConfiguration `json:"parameters,omitempty"`
+	ControllerName         *string                                              `json:"controllerName,omitempty"`
+	RetryDelayMinutes      *int64                                               `json:"retryDelayMinutes,omitempty"`
+	Parameters             *AdmissionCheckParametersReferenceApplyConfiguration `json:"parameters,omitempty"`
+	ReservationHoldSeconds *int32                                               `json:"reservationHoldSeconds,omitempty"`
 }
 
 // AdmissionCheckSpecApplyConfiguration constructs a declarative configuration of the AdmissionCheckSpec type for use with
@@ -54,3 +55,11 @@ func (b *AdmissionCheckSpecApplyConfiguration) WithParameters(value *AdmissionCh
 	b.Parameters = value
 	return b
 }
+
+// WithReservationHoldSeconds sets the ReservationHoldSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReservationHoldSeconds field is set to the value of the last call.
+func (b *AdmissionCheckSpecApplyConfiguration) WithReservationHoldSeconds(value int32) *AdmissionCheckSpecApplyConfiguration {
+	b.ReservationHoldSeconds = &value
+	return b
+}
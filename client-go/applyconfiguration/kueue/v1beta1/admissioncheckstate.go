@@ -30,6 +30,7 @@ type AdmissionCheckStateApplyConfiguration struct {
 	LastTransitionTime *v1.Time                              `json:"lastTransitionTime,omitempty"`
 	Message            *string                               `json:"message,omitempty"`
 	PodSetUpdates      []PodSetUpdateApplyConfiguration      `json:"podSetUpdates,omitempty"`
+	PodSetResources    []PodSetResourcesApplyConfiguration   `json:"podSetResources,omitempty"`
 }
 
 // AdmissionCheckStateApplyConfiguration constructs a declarative configuration of the AdmissionCheckState type for use with
@@ -82,3 +83,16 @@ func (b *AdmissionCheckStateApplyConfiguration) WithPodSetUpdates(values ...*Pod
 	}
 	return b
 }
+
+// WithPodSetResources adds the given value to the PodSetResources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodSetResources field.
+func (b *AdmissionCheckStateApplyConfiguration) WithPodSetResources(values ...*PodSetResourcesApplyConfiguration) *AdmissionCheckStateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPodSetResources")
+		}
+		b.PodSetResources = append(b.PodSetResources, *values[i])
+	}
+	return b
+}
@@ -27,6 +27,7 @@ type ClusterQueuePreemptionApplyConfiguration struct {
 	ReclaimWithinCohort *kueuev1beta1.PreemptionPolicy        `json:"reclaimWithinCohort,omitempty"`
 	BorrowWithinCohort  *BorrowWithinCohortApplyConfiguration `json:"borrowWithinCohort,omitempty"`
 	WithinClusterQueue  *kueuev1beta1.PreemptionPolicy        `json:"withinClusterQueue,omitempty"`
+	BorrowingPreference *kueuev1beta1.BorrowingPreference     `json:"borrowingPreference,omitempty"`
 }
 
 // ClusterQueuePreemptionApplyConfiguration constructs a declarative configuration of the ClusterQueuePreemption type for use with
@@ -58,3 +59,11 @@ func (b *ClusterQueuePreemptionApplyConfiguration) WithWithinClusterQueue(value
 	b.WithinClusterQueue = &value
 	return b
 }
+
+// WithBorrowingPreference sets the BorrowingPreference field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BorrowingPreference field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithBorrowingPreference(value kueuev1beta1.BorrowingPreference) *ClusterQueuePreemptionApplyConfiguration {
+	b.BorrowingPreference = &value
+	return b
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
@@ -25,17 +26,24 @@ import (
 // ClusterQueueSpecApplyConfiguration represents a declarative configuration of the ClusterQueueSpec type for use
 // with apply.
 type ClusterQueueSpecApplyConfiguration struct {
-	ResourceGroups          []ResourceGroupApplyConfiguration          `json:"resourceGroups,omitempty"`
-	Cohort                  *kueuev1beta1.CohortReference              `json:"cohort,omitempty"`
-	QueueingStrategy        *kueuev1beta1.QueueingStrategy             `json:"queueingStrategy,omitempty"`
-	NamespaceSelector       *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
-	FlavorFungibility       *FlavorFungibilityApplyConfiguration       `json:"flavorFungibility,omitempty"`
-	Preemption              *ClusterQueuePreemptionApplyConfiguration  `json:"preemption,omitempty"`
-	AdmissionChecks         []kueuev1beta1.AdmissionCheckReference     `json:"admissionChecks,omitempty"`
-	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
-	StopPolicy              *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
-	FairSharing             *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
-	AdmissionScope          *AdmissionScopeApplyConfiguration          `json:"admissionScope,omitempty"`
+	ResourceGroups                  []ResourceGroupApplyConfiguration          `json:"resourceGroups,omitempty"`
+	Cohort                          *kueuev1beta1.CohortReference              `json:"cohort,omitempty"`
+	FallbackCohort                  *kueuev1beta1.CohortReference              `json:"fallbackCohort,omitempty"`
+	QueueingStrategy                *kueuev1beta1.QueueingStrategy             `json:"queueingStrategy,omitempty"`
+	NamespaceSelector               *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
+	FlavorFungibility               *FlavorFungibilityApplyConfiguration       `json:"flavorFungibility,omitempty"`
+	Preemption                      *ClusterQueuePreemptionApplyConfiguration  `json:"preemption,omitempty"`
+	AdmissionChecks                 []kueuev1beta1.AdmissionCheckReference     `json:"admissionChecks,omitempty"`
+	AdmissionChecksStrategy         *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
+	MaxConcurrentChecks             *int32                                     `json:"maxConcurrentChecks,omitempty"`
+	MinAdmissionIntervalSeconds     *int32                                     `json:"minAdmissionIntervalSeconds,omitempty"`
+	BorrowingMinPriority            *int32                                     `json:"borrowingMinPriority,omitempty"`
+	StopPolicy                      *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
+	FairSharing                     *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	AdmissionScope                  *AdmissionScopeApplyConfiguration          `json:"admissionScope,omitempty"`
+	NearCapacityThresholdPercentage *int32                                     `json:"nearCapacityThresholdPercentage,omitempty"`
+	AdmissionTolerations            []corev1.TolerationApplyConfiguration      `json:"admissionTolerations,omitempty"`
+	ResourceOverheads               []ResourceOverheadApplyConfiguration       `json:"resourceOverheads,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -65,6 +73,14 @@ func (b *ClusterQueueSpecApplyConfiguration) WithCohort(value kueuev1beta1.Cohor
 	return b
 }
 
+// WithFallbackCohort sets the FallbackCohort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FallbackCohort field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithFallbackCohort(value kueuev1beta1.CohortReference) *ClusterQueueSpecApplyConfiguration {
+	b.FallbackCohort = &value
+	return b
+}
+
 // WithQueueingStrategy sets the QueueingStrategy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the QueueingStrategy field is set to the value of the last call.
@@ -115,6 +131,30 @@ func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionChecksStrategy(value *
 	return b
 }
 
+// WithMaxConcurrentChecks sets the MaxConcurrentChecks field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentChecks field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMaxConcurrentChecks(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MaxConcurrentChecks = &value
+	return b
+}
+
+// WithMinAdmissionIntervalSeconds sets the MinAdmissionIntervalSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinAdmissionIntervalSeconds field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMinAdmissionIntervalSeconds(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MinAdmissionIntervalSeconds = &value
+	return b
+}
+
+// WithBorrowingMinPriority sets the BorrowingMinPriority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BorrowingMinPriority field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithBorrowingMinPriority(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.BorrowingMinPriority = &value
+	return b
+}
+
 // WithStopPolicy sets the StopPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the StopPolicy field is set to the value of the last call.
@@ -138,3 +178,37 @@ func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionScope(value *Admission
 	b.AdmissionScope = value
 	return b
 }
+
+// WithNearCapacityThresholdPercentage sets the NearCapacityThresholdPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NearCapacityThresholdPercentage field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithNearCapacityThresholdPercentage(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.NearCapacityThresholdPercentage = &value
+	return b
+}
+
+// WithAdmissionTolerations adds the given value to the AdmissionTolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdmissionTolerations field.
+func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionTolerations(values ...*corev1.TolerationApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdmissionTolerations")
+		}
+		b.AdmissionTolerations = append(b.AdmissionTolerations, *values[i])
+	}
+	return b
+}
+
+// WithResourceOverheads adds the given value to the ResourceOverheads field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceOverheads field.
+func (b *ClusterQueueSpecApplyConfiguration) WithResourceOverheads(values ...*ResourceOverheadApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceOverheads")
+		}
+		b.ResourceOverheads = append(b.ResourceOverheads, *values[i])
+	}
+	return b
+}
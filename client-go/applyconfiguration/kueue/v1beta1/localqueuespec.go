@@ -24,9 +24,10 @@ import (
 // LocalQueueSpecApplyConfiguration represents a declarative configuration of the LocalQueueSpec type for use
 // with apply.
 type LocalQueueSpecApplyConfiguration struct {
-	ClusterQueue *kueuev1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
-	StopPolicy   *kueuev1beta1.StopPolicy            `json:"stopPolicy,omitempty"`
-	FairSharing  *FairSharingApplyConfiguration      `json:"fairSharing,omitempty"`
+	ClusterQueue   *kueuev1beta1.ClusterQueueReference         `json:"clusterQueue,omitempty"`
+	StopPolicy     *kueuev1beta1.StopPolicy                    `json:"stopPolicy,omitempty"`
+	FairSharing    *FairSharingApplyConfiguration              `json:"fairSharing,omitempty"`
+	ResourceLimits []LocalQueueResourceLimitApplyConfiguration `json:"resourceLimits,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs a declarative configuration of the LocalQueueSpec type for use with
@@ -58,3 +59,16 @@ func (b *LocalQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingApp
 	b.FairSharing = value
 	return b
 }
+
+// WithResourceLimits adds the given value to the ResourceLimits field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceLimits field.
+func (b *LocalQueueSpecApplyConfiguration) WithResourceLimits(values ...*LocalQueueResourceLimitApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceLimits")
+		}
+		b.ResourceLimits = append(b.ResourceLimits, *values[i])
+	}
+	return b
+}
@@ -29,6 +29,7 @@ type PodSetApplyConfiguration struct {
 	Template        *v1.PodTemplateSpecApplyConfiguration    `json:"template,omitempty"`
 	Count           *int32                                   `json:"count,omitempty"`
 	MinCount        *int32                                   `json:"minCount,omitempty"`
+	Priority        *int32                                   `json:"priority,omitempty"`
 	TopologyRequest *PodSetTopologyRequestApplyConfiguration `json:"topologyRequest,omitempty"`
 }
 
@@ -70,6 +71,14 @@ func (b *PodSetApplyConfiguration) WithMinCount(value int32) *PodSetApplyConfigu
 	return b
 }
 
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *PodSetApplyConfiguration) WithPriority(value int32) *PodSetApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
 // WithTopologyRequest sets the TopologyRequest field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the TopologyRequest field is set to the value of the last call.
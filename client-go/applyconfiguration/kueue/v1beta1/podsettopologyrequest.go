@@ -20,12 +20,14 @@ package v1beta1
 // PodSetTopologyRequestApplyConfiguration represents a declarative configuration of the PodSetTopologyRequest type for use
 // with apply.
 type PodSetTopologyRequestApplyConfiguration struct {
-	Required           *string `json:"required,omitempty"`
-	Preferred          *string `json:"preferred,omitempty"`
-	Unconstrained      *bool   `json:"unconstrained,omitempty"`
-	PodIndexLabel      *string `json:"podIndexLabel,omitempty"`
-	SubGroupIndexLabel *string `json:"subGroupIndexLabel,omitempty"`
-	SubGroupCount      *int32  `json:"subGroupCount,omitempty"`
+	Required               *string `json:"required,omitempty"`
+	Preferred              *string `json:"preferred,omitempty"`
+	Unconstrained          *bool   `json:"unconstrained,omitempty"`
+	Spread                 *string `json:"spread,omitempty"`
+	NodeCapacityPercentage *int32  `json:"nodeCapacityPercentage,omitempty"`
+	PodIndexLabel          *string `json:"podIndexLabel,omitempty"`
+	SubGroupIndexLabel     *string `json:"subGroupIndexLabel,omitempty"`
+	SubGroupCount          *int32  `json:"subGroupCount,omitempty"`
 }
 
 // PodSetTopologyRequestApplyConfiguration constructs a declarative configuration of the PodSetTopologyRequest type for use with
@@ -58,6 +60,22 @@ func (b *PodSetTopologyRequestApplyConfiguration) WithUnconstrained(value bool)
 	return b
 }
 
+// WithSpread sets the Spread field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spread field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithSpread(value string) *PodSetTopologyRequestApplyConfiguration {
+	b.Spread = &value
+	return b
+}
+
+// WithNodeCapacityPercentage sets the NodeCapacityPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCapacityPercentage field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithNodeCapacityPercentage(value int32) *PodSetTopologyRequestApplyConfiguration {
+	b.NodeCapacityPercentage = &value
+	return b
+}
+
 // WithPodIndexLabel sets the PodIndexLabel field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PodIndexLabel field is set to the value of the last call.
@@ -25,10 +25,14 @@ import (
 // ResourceFlavorSpecApplyConfiguration represents a declarative configuration of the ResourceFlavorSpec type for use
 // with apply.
 type ResourceFlavorSpecApplyConfiguration struct {
-	NodeLabels   map[string]string                 `json:"nodeLabels,omitempty"`
-	NodeTaints   []v1.TaintApplyConfiguration      `json:"nodeTaints,omitempty"`
-	Tolerations  []v1.TolerationApplyConfiguration `json:"tolerations,omitempty"`
-	TopologyName *kueuev1beta1.TopologyReference   `json:"topologyName,omitempty"`
+	NodeLabels                   map[string]string                          `json:"nodeLabels,omitempty"`
+	NodeTaints                   []v1.TaintApplyConfiguration               `json:"nodeTaints,omitempty"`
+	Tolerations                  []v1.TolerationApplyConfiguration          `json:"tolerations,omitempty"`
+	TaintsToTolerate             []v1.TaintApplyConfiguration               `json:"taintsToTolerate,omitempty"`
+	TopologyName                 *kueuev1beta1.TopologyReference            `json:"topologyName,omitempty"`
+	DefaultRequiredTopologyLevel *string                                    `json:"defaultRequiredTopologyLevel,omitempty"`
+	Disabled                     *bool                                      `json:"disabled,omitempty"`
+	DeletionPolicy               *kueuev1beta1.ResourceFlavorDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs a declarative configuration of the ResourceFlavorSpec type for use with
@@ -77,6 +81,19 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTolerations(values ...*v1.Tol
 	return b
 }
 
+// WithTaintsToTolerate adds the given value to the TaintsToTolerate field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TaintsToTolerate field.
+func (b *ResourceFlavorSpecApplyConfiguration) WithTaintsToTolerate(values ...*v1.TaintApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTaintsToTolerate")
+		}
+		b.TaintsToTolerate = append(b.TaintsToTolerate, *values[i])
+	}
+	return b
+}
+
 // WithTopologyName sets the TopologyName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the TopologyName field is set to the value of the last call.
@@ -84,3 +101,27 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTopologyName(value kueuev1bet
 	b.TopologyName = &value
 	return b
 }
+
+// WithDefaultRequiredTopologyLevel sets the DefaultRequiredTopologyLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultRequiredTopologyLevel field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithDefaultRequiredTopologyLevel(value string) *ResourceFlavorSpecApplyConfiguration {
+	b.DefaultRequiredTopologyLevel = &value
+	return b
+}
+
+// WithDisabled sets the Disabled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Disabled field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithDisabled(value bool) *ResourceFlavorSpecApplyConfiguration {
+	b.Disabled = &value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithDeletionPolicy(value kueuev1beta1.ResourceFlavorDeletionPolicy) *ResourceFlavorSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
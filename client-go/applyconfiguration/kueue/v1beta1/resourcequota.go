@@ -25,10 +25,15 @@ import (
 // ResourceQuotaApplyConfiguration represents a declarative configuration of the ResourceQuota type for use
 // with apply.
 type ResourceQuotaApplyConfiguration struct {
-	Name           *v1.ResourceName   `json:"name,omitempty"`
-	NominalQuota   *resource.Quantity `json:"nominalQuota,omitempty"`
-	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
-	LendingLimit   *resource.Quantity `json:"lendingLimit,omitempty"`
+	Name                *v1.ResourceName                       `json:"name,omitempty"`
+	NominalQuota        *resource.Quantity                     `json:"nominalQuota,omitempty"`
+	BorrowingLimit      *resource.Quantity                     `json:"borrowingLimit,omitempty"`
+	Borrowable          *bool                                  `json:"borrowable,omitempty"`
+	LendingLimit        *resource.Quantity                     `json:"lendingLimit,omitempty"`
+	OvercommitFactor    *resource.Quantity                     `json:"overcommitFactor,omitempty"`
+	PriorityReservation *PriorityReservationApplyConfiguration `json:"priorityReservation,omitempty"`
+	ScheduledQuota      *ScheduledQuotaApplyConfiguration      `json:"scheduledQuota,omitempty"`
+	ElasticQuota        *ElasticQuotaApplyConfiguration        `json:"elasticQuota,omitempty"`
 }
 
 // ResourceQuotaApplyConfiguration constructs a declarative configuration of the ResourceQuota type for use with
@@ -61,6 +66,14 @@ func (b *ResourceQuotaApplyConfiguration) WithBorrowingLimit(value resource.Quan
 	return b
 }
 
+// WithBorrowable sets the Borrowable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Borrowable field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithBorrowable(value bool) *ResourceQuotaApplyConfiguration {
+	b.Borrowable = &value
+	return b
+}
+
 // WithLendingLimit sets the LendingLimit field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LendingLimit field is set to the value of the last call.
@@ -68,3 +81,35 @@ func (b *ResourceQuotaApplyConfiguration) WithLendingLimit(value resource.Quanti
 	b.LendingLimit = &value
 	return b
 }
+
+// WithOvercommitFactor sets the OvercommitFactor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OvercommitFactor field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithOvercommitFactor(value resource.Quantity) *ResourceQuotaApplyConfiguration {
+	b.OvercommitFactor = &value
+	return b
+}
+
+// WithPriorityReservation sets the PriorityReservation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityReservation field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithPriorityReservation(value *PriorityReservationApplyConfiguration) *ResourceQuotaApplyConfiguration {
+	b.PriorityReservation = value
+	return b
+}
+
+// WithScheduledQuota sets the ScheduledQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScheduledQuota field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithScheduledQuota(value *ScheduledQuotaApplyConfiguration) *ResourceQuotaApplyConfiguration {
+	b.ScheduledQuota = value
+	return b
+}
+
+// WithElasticQuota sets the ElasticQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ElasticQuota field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithElasticQuota(value *ElasticQuotaApplyConfiguration) *ResourceQuotaApplyConfiguration {
+	b.ElasticQuota = value
+	return b
+}
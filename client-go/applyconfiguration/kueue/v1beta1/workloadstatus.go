@@ -18,19 +18,31 @@ limitations under the License.
 package v1beta1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
 // WorkloadStatusApplyConfiguration represents a declarative configuration of the WorkloadStatus type for use
 // with apply.
 type WorkloadStatusApplyConfiguration struct {
-	Admission                            *AdmissionApplyConfiguration            `json:"admission,omitempty"`
-	RequeueState                         *RequeueStateApplyConfiguration         `json:"requeueState,omitempty"`
-	Conditions                           []v1.ConditionApplyConfiguration        `json:"conditions,omitempty"`
-	ReclaimablePods                      []ReclaimablePodApplyConfiguration      `json:"reclaimablePods,omitempty"`
-	AdmissionChecks                      []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
-	ResourceRequests                     []PodSetRequestApplyConfiguration       `json:"resourceRequests,omitempty"`
-	AccumulatedPastExexcutionTimeSeconds *int32                                  `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+	Admission                            *AdmissionApplyConfiguration                    `json:"admission,omitempty"`
+	RequeueState                         *RequeueStateApplyConfiguration                 `json:"requeueState,omitempty"`
+	RequeueCount                         *int32                                          `json:"requeueCount,omitempty"`
+	Conditions                           []v1.ConditionApplyConfiguration                `json:"conditions,omitempty"`
+	ReclaimablePods                      []ReclaimablePodApplyConfiguration              `json:"reclaimablePods,omitempty"`
+	AdmissionChecks                      []AdmissionCheckStateApplyConfiguration         `json:"admissionChecks,omitempty"`
+	ResourceRequests                     []PodSetRequestApplyConfiguration               `json:"resourceRequests,omitempty"`
+	AccumulatedPastExexcutionTimeSeconds *int32                                          `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+	PreemptedBy                          *WorkloadPreemptionReferenceApplyConfiguration  `json:"preemptedBy,omitempty"`
+	PreemptedWorkloads                   []WorkloadPreemptionReferenceApplyConfiguration `json:"preemptedWorkloads,omitempty"`
+	AdmissionHistory                     []AdmissionHistoryEntryApplyConfiguration       `json:"admissionHistory,omitempty"`
+	AdmissionPath                        *kueuev1beta1.AdmissionPath                     `json:"admissionPath,omitempty"`
+	QueuePosition                        *int32                                          `json:"queuePosition,omitempty"`
+	LastPlacement                        *WorkloadPlacementApplyConfiguration            `json:"lastPlacement,omitempty"`
+	EvictionCount                        *int32                                          `json:"evictionCount,omitempty"`
+	LastEvictionReason                   *string                                         `json:"lastEvictionReason,omitempty"`
+	LastEvictionTime                     *metav1.Time                                    `json:"lastEvictionTime,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs a declarative configuration of the WorkloadStatus type for use with
@@ -55,6 +67,14 @@ func (b *WorkloadStatusApplyConfiguration) WithRequeueState(value *RequeueStateA
 	return b
 }
 
+// WithRequeueCount sets the RequeueCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequeueCount field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithRequeueCount(value int32) *WorkloadStatusApplyConfiguration {
+	b.RequeueCount = &value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
@@ -114,3 +134,85 @@ func (b *WorkloadStatusApplyConfiguration) WithAccumulatedPastExexcutionTimeSeco
 	b.AccumulatedPastExexcutionTimeSeconds = &value
 	return b
 }
+
+// WithPreemptedBy sets the PreemptedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreemptedBy field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithPreemptedBy(value *WorkloadPreemptionReferenceApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.PreemptedBy = value
+	return b
+}
+
+// WithPreemptedWorkloads adds the given value to the PreemptedWorkloads field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PreemptedWorkloads field.
+func (b *WorkloadStatusApplyConfiguration) WithPreemptedWorkloads(values ...*WorkloadPreemptionReferenceApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPreemptedWorkloads")
+		}
+		b.PreemptedWorkloads = append(b.PreemptedWorkloads, *values[i])
+	}
+	return b
+}
+
+// WithAdmissionHistory adds the given value to the AdmissionHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdmissionHistory field.
+func (b *WorkloadStatusApplyConfiguration) WithAdmissionHistory(values ...*AdmissionHistoryEntryApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdmissionHistory")
+		}
+		b.AdmissionHistory = append(b.AdmissionHistory, *values[i])
+	}
+	return b
+}
+
+// WithAdmissionPath sets the AdmissionPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdmissionPath field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithAdmissionPath(value kueuev1beta1.AdmissionPath) *WorkloadStatusApplyConfiguration {
+	b.AdmissionPath = &value
+	return b
+}
+
+// WithQueuePosition sets the QueuePosition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QueuePosition field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithQueuePosition(value int32) *WorkloadStatusApplyConfiguration {
+	b.QueuePosition = &value
+	return b
+}
+
+// WithLastPlacement sets the LastPlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastPlacement field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithLastPlacement(value *WorkloadPlacementApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.LastPlacement = value
+	return b
+}
+
+// WithEvictionCount sets the EvictionCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EvictionCount field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithEvictionCount(value int32) *WorkloadStatusApplyConfiguration {
+	b.EvictionCount = &value
+	return b
+}
+
+// WithLastEvictionReason sets the LastEvictionReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastEvictionReason field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithLastEvictionReason(value string) *WorkloadStatusApplyConfiguration {
+	b.LastEvictionReason = &value
+	return b
+}
+
+// WithLastEvictionTime sets the LastEvictionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastEvictionTime field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithLastEvictionTime(value metav1.Time) *WorkloadStatusApplyConfiguration {
+	b.LastEvictionTime = &value
+	return b
+}
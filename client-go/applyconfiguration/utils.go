@@ -61,10 +61,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionFairSharingStatus"):
 		return &kueuev1beta1.AdmissionFairSharingStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionHistoryEntry"):
+		return &kueuev1beta1.AdmissionHistoryEntryApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionScope"):
 		return &kueuev1beta1.AdmissionScopeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta1.BorrowWithinCohortApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CELAdmissionCheckConfig"):
+		return &kueuev1beta1.CELAdmissionCheckConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CELAdmissionCheckConfigSpec"):
+		return &kueuev1beta1.CELAdmissionCheckConfigSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &kueuev1beta1.ClusterQueueApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePendingWorkload"):
@@ -77,6 +83,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ClusterQueueSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueStatus"):
 		return &kueuev1beta1.ClusterQueueStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ElasticQuota"):
+		return &kueuev1beta1.ElasticQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharing"):
 		return &kueuev1beta1.FairSharingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharingStatus"):
@@ -95,6 +103,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.LocalQueueFlavorStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
 		return &kueuev1beta1.LocalQueueFlavorUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceLimit"):
+		return &kueuev1beta1.LocalQueueResourceLimitApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceUsage"):
 		return &kueuev1beta1.LocalQueueResourceUsageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueSpec"):
@@ -115,12 +125,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
 		return &kueuev1beta1.PodSetAssignmentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetPlacement"):
+		return &kueuev1beta1.PodSetPlacementApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetRequest"):
 		return &kueuev1beta1.PodSetRequestApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetResources"):
+		return &kueuev1beta1.PodSetResourcesApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetTopologyRequest"):
 		return &kueuev1beta1.PodSetTopologyRequestApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PriorityReservation"):
+		return &kueuev1beta1.PriorityReservationApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
@@ -137,10 +153,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ResourceFlavorSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceGroup"):
 		return &kueuev1beta1.ResourceGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceOverhead"):
+		return &kueuev1beta1.ResourceOverheadApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
 		return &kueuev1beta1.ResourceUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ScheduledQuota"):
+		return &kueuev1beta1.ScheduledQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyAssignment"):
 		return &kueuev1beta1.TopologyAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyDomainAssignment"):
@@ -149,6 +169,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.TopologyInfoApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Workload"):
 		return &kueuev1beta1.WorkloadApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPlacement"):
+		return &kueuev1beta1.WorkloadPlacementApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPreemptionReference"):
+		return &kueuev1beta1.WorkloadPreemptionReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPriorityClass"):
 		return &kueuev1beta1.WorkloadPriorityClassApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadSpec"):
@@ -157,8 +181,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.WorkloadStatusApplyConfiguration{}
 
 		// Group=visibility.kueue.x-k8s.io, Version=v1beta1
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("BorrowedResource"):
+		return &applyconfigurationvisibilityv1beta1.BorrowedResourceApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("BorrowingWorkload"):
+		return &applyconfigurationvisibilityv1beta1.BorrowingWorkloadApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("BorrowingWorkloadsSummary"):
+		return &applyconfigurationvisibilityv1beta1.BorrowingWorkloadsSummaryApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &applyconfigurationvisibilityv1beta1.ClusterQueueApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("Cohort"):
+		return &applyconfigurationvisibilityv1beta1.CohortApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("CohortResource"):
+		return &applyconfigurationvisibilityv1beta1.CohortResourceApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("CohortTree"):
+		return &applyconfigurationvisibilityv1beta1.CohortTreeApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("CohortTreeNode"):
+		return &applyconfigurationvisibilityv1beta1.CohortTreeNodeApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("LocalQueue"):
 		return &applyconfigurationvisibilityv1beta1.LocalQueueApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("PendingWorkload"):
@@ -31,6 +31,10 @@ func (c *FakeKueueV1beta1) AdmissionChecks() v1beta1.AdmissionCheckInterface {
 	return newFakeAdmissionChecks(c)
 }
 
+func (c *FakeKueueV1beta1) CELAdmissionCheckConfigs() v1beta1.CELAdmissionCheckConfigInterface {
+	return newFakeCELAdmissionCheckConfigs(c)
+}
+
 func (c *FakeKueueV1beta1) ClusterQueues() v1beta1.ClusterQueueInterface {
 	return newFakeClusterQueues(c)
 }
@@ -19,6 +19,8 @@ package v1beta1
 
 type AdmissionCheckExpansion interface{}
 
+type CELAdmissionCheckConfigExpansion interface{}
+
 type ClusterQueueExpansion interface{}
 
 type LocalQueueExpansion interface{}
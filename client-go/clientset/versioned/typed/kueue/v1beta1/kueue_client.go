@@ -28,6 +28,7 @@ import (
 type KueueV1beta1Interface interface {
 	RESTClient() rest.Interface
 	AdmissionChecksGetter
+	CELAdmissionCheckConfigsGetter
 	ClusterQueuesGetter
 	LocalQueuesGetter
 	MultiKueueClustersGetter
@@ -47,6 +48,10 @@ func (c *KueueV1beta1Client) AdmissionChecks() AdmissionCheckInterface {
 	return newAdmissionChecks(c)
 }
 
+func (c *KueueV1beta1Client) CELAdmissionCheckConfigs() CELAdmissionCheckConfigInterface {
+	return newCELAdmissionCheckConfigs(c)
+}
+
 func (c *KueueV1beta1Client) ClusterQueues() ClusterQueueInterface {
 	return newClusterQueues(c)
 }
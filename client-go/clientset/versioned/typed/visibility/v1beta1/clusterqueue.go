@@ -47,6 +47,7 @@ type ClusterQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *visibilityv1beta1.ClusterQueue, err error)
 	Apply(ctx context.Context, clusterQueue *applyconfigurationvisibilityv1beta1.ClusterQueueApplyConfiguration, opts v1.ApplyOptions) (result *visibilityv1beta1.ClusterQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*visibilityv1beta1.PendingWorkloadsSummary, error)
+	GetBorrowingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*visibilityv1beta1.BorrowingWorkloadsSummary, error)
 
 	ClusterQueueExpansion
 }
@@ -82,3 +83,16 @@ func (c *clusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clusterQ
 		Into(result)
 	return
 }
+
+// GetBorrowingWorkloadsSummary takes name of the clusterQueue, and returns the corresponding visibilityv1beta1.BorrowingWorkloadsSummary object, and an error if there is any.
+func (c *clusterQueues) GetBorrowingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *visibilityv1beta1.BorrowingWorkloadsSummary, err error) {
+	result = &visibilityv1beta1.BorrowingWorkloadsSummary{}
+	err = c.GetClient().Get().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("borrowingworkloads").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -65,3 +65,14 @@ func (c *fakeClusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clus
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// GetBorrowingWorkloadsSummary takes name of the clusterQueue, and returns the corresponding borrowingWorkloadsSummary object, and an error if there is any.
+func (c *fakeClusterQueues) GetBorrowingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *v1beta1.BorrowingWorkloadsSummary, err error) {
+	emptyResult := &v1beta1.BorrowingWorkloadsSummary{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetSubresourceActionWithOptions(c.Resource(), "borrowingworkloads", clusterQueueName, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.BorrowingWorkloadsSummary), err
+}
@@ -31,6 +31,10 @@ func (c *FakeVisibilityV1beta1) ClusterQueues() v1beta1.ClusterQueueInterface {
 	return newFakeClusterQueues(c)
 }
 
+func (c *FakeVisibilityV1beta1) Cohorts() v1beta1.CohortInterface {
+	return newFakeCohorts(c)
+}
+
 func (c *FakeVisibilityV1beta1) LocalQueues(namespace string) v1beta1.LocalQueueInterface {
 	return newFakeLocalQueues(c, namespace)
 }
@@ -19,4 +19,6 @@ package v1beta1
 
 type ClusterQueueExpansion interface{}
 
+type CohortExpansion interface{}
+
 type LocalQueueExpansion interface{}
@@ -28,6 +28,7 @@ import (
 type VisibilityV1beta1Interface interface {
 	RESTClient() rest.Interface
 	ClusterQueuesGetter
+	CohortsGetter
 	LocalQueuesGetter
 }
 
@@ -40,6 +41,10 @@ func (c *VisibilityV1beta1Client) ClusterQueues() ClusterQueueInterface {
 	return newClusterQueues(c)
 }
 
+func (c *VisibilityV1beta1Client) Cohorts() CohortInterface {
+	return newCohorts(c)
+}
+
 func (c *VisibilityV1beta1Client) LocalQueues(namespace string) LocalQueueInterface {
 	return newLocalQueues(c, namespace)
 }
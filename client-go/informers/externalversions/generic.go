@@ -60,6 +60,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=kueue.x-k8s.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("admissionchecks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1beta1().AdmissionChecks().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("celadmissioncheckconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1beta1().CELAdmissionCheckConfigs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("clusterqueues"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1beta1().ClusterQueues().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("localqueues"):
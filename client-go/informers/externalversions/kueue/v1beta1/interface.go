@@ -25,6 +25,8 @@ import (
 type Interface interface {
 	// AdmissionChecks returns a AdmissionCheckInformer.
 	AdmissionChecks() AdmissionCheckInformer
+	// CELAdmissionCheckConfigs returns a CELAdmissionCheckConfigInformer.
+	CELAdmissionCheckConfigs() CELAdmissionCheckConfigInformer
 	// ClusterQueues returns a ClusterQueueInformer.
 	ClusterQueues() ClusterQueueInformer
 	// LocalQueues returns a LocalQueueInformer.
@@ -59,6 +61,11 @@ func (v *version) AdmissionChecks() AdmissionCheckInformer {
 	return &admissionCheckInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// CELAdmissionCheckConfigs returns a CELAdmissionCheckConfigInformer.
+func (v *version) CELAdmissionCheckConfigs() CELAdmissionCheckConfigInformer {
+	return &cELAdmissionCheckConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterQueues returns a ClusterQueueInformer.
 func (v *version) ClusterQueues() ClusterQueueInformer {
 	return &clusterQueueInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -21,6 +21,10 @@ package v1beta1
 // AdmissionCheckLister.
 type AdmissionCheckListerExpansion interface{}
 
+// CELAdmissionCheckConfigListerExpansion allows custom methods to be added to
+// CELAdmissionCheckConfigLister.
+type CELAdmissionCheckConfigListerExpansion interface{}
+
 // ClusterQueueListerExpansion allows custom methods to be added to
 // ClusterQueueLister.
 type ClusterQueueListerExpansion interface{}
@@ -30,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -41,7 +42,9 @@ import (
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -53,6 +56,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/config"
 	"sigs.k8s.io/kueue/pkg/constants"
+	celacc "sigs.k8s.io/kueue/pkg/controller/admissionchecks/cel"
 	"sigs.k8s.io/kueue/pkg/controller/admissionchecks/multikueue"
 	"sigs.k8s.io/kueue/pkg/controller/admissionchecks/provisioning"
 	"sigs.k8s.io/kueue/pkg/controller/core"
@@ -178,6 +182,17 @@ func main() {
 	}
 	options.Metrics = metricsServerOptions
 
+	if cfg.Resources != nil && cfg.Resources.TransformationsConfigMapName != nil {
+		// Only the ConfigMap holding the resource transformations is needed.
+		if options.Cache.ByObject == nil {
+			options.Cache.ByObject = make(map[client.Object]ctrlcache.ByObject)
+		}
+		options.Cache.ByObject[&corev1.ConfigMap{}] = ctrlcache.ByObject{
+			Namespaces: map[string]ctrlcache.Config{*cfg.Namespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", *cfg.Resources.TransformationsConfigMapName),
+		}
+	}
+
 	metrics.Register()
 
 	kubeConfig := ctrl.GetConfigOrDie()
@@ -215,12 +230,23 @@ func main() {
 		cacheOptions = append(cacheOptions, cache.WithResourceTransformations(cfg.Resources.Transformations))
 		queueOptions = append(queueOptions, queue.WithResourceTransformations(cfg.Resources.Transformations))
 	}
+	if cfg.Resources != nil && len(cfg.Resources.BackgroundReservations) > 0 {
+		cacheOptions = append(cacheOptions, cache.WithBackgroundReservations(cfg.Resources.BackgroundReservations))
+	}
+	if cfg.Resources != nil && len(cfg.Resources.NamespaceLimits) > 0 {
+		cacheOptions = append(cacheOptions, cache.WithNamespaceLimits(cfg.Resources.NamespaceLimits))
+	}
 	if cfg.FairSharing != nil {
-		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable))
+		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable),
+			cache.WithFairSharingResourceWeights(cfg.FairSharing.ResourceWeights),
+			cache.WithFairSharingShareMode(ptr.Deref(cfg.FairSharing.ShareMode, configapi.FairSharingDominantResource)))
 	}
 	if cfg.AdmissionFairSharing != nil {
 		queueOptions = append(queueOptions, queue.WithAdmissionFairSharing(cfg.AdmissionFairSharing))
 	}
+	if cfg.Scheduler != nil && cfg.Scheduler.WorkloadOrdering != nil {
+		queueOptions = append(queueOptions, queue.WithOrdering(*cfg.Scheduler.WorkloadOrdering))
+	}
 	cCache := cache.New(mgr.GetClient(), cacheOptions...)
 	queues := queue.NewManager(mgr.GetClient(), cCache, queueOptions...)
 
@@ -243,11 +269,18 @@ func main() {
 	go cCache.CleanUpOnContext(ctx)
 
 	if features.Enabled(features.VisibilityOnDemand) {
-		go visibility.CreateAndStartVisibilityServer(ctx, queues)
+		go visibility.CreateAndStartVisibilityServer(ctx, queues, cCache)
 	}
 
 	setupScheduler(mgr, cCache, queues, &cfg)
 
+	if features.Enabled(features.WorkloadQueuePosition) {
+		if err := mgr.Add(queue.NewQueuePositionUpdater(queues, mgr.GetClient())); err != nil {
+			setupLog.Error(err, "Unable to add queue position updater to manager")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Could not run manager")
@@ -271,6 +304,13 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configur
 		}
 	}
 
+	if features.Enabled(features.CELAdmissionCheck) {
+		if err := celacc.SetupIndexer(ctx, mgr.GetFieldIndexer()); err != nil {
+			setupLog.Error(err, "Could not setup CEL admission check indexer")
+			os.Exit(1)
+		}
+	}
+
 	if features.Enabled(features.TopologyAwareScheduling) {
 		if err := tasindexer.SetupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 			setupLog.Error(err, "Could not setup TAS indexer")
@@ -319,17 +359,36 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
+	if features.Enabled(features.CELAdmissionCheck) {
+		ctrl, err := celacc.NewController(mgr.GetClient(), mgr.GetEventRecorderFor("kueue-cel-admission-check-controller"))
+		if err != nil {
+			setupLog.Error(err, "Could not create the CEL admission check controller")
+			os.Exit(1)
+		}
+		if err := ctrl.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Could not setup CEL admission check controller")
+			os.Exit(1)
+		}
+	}
+
 	if features.Enabled(features.MultiKueue) {
 		adapters, err := jobframework.GetMultiKueueAdapters(sets.New(cfg.Integrations.Frameworks...))
 		if err != nil {
 			setupLog.Error(err, "Could not get the enabled multikueue adapters")
 			os.Exit(1)
 		}
+		disabledAdapters, err := jobframework.GetMultiKueueAdapters(sets.New(cfg.MultiKueue.DisabledIntegrations...))
+		if err != nil {
+			setupLog.Error(err, "Could not get the disabled multikueue adapters")
+			os.Exit(1)
+		}
 		if err := multikueue.SetupControllers(mgr, *cfg.Namespace,
 			multikueue.WithGCInterval(cfg.MultiKueue.GCInterval.Duration),
 			multikueue.WithOrigin(ptr.Deref(cfg.MultiKueue.Origin, configapi.DefaultMultiKueueOrigin)),
 			multikueue.WithWorkerLostTimeout(cfg.MultiKueue.WorkerLostTimeout.Duration),
+			multikueue.WithWorkerReconnectGracePeriod(ptr.Deref(cfg.MultiKueue.WorkerReconnectGracePeriod, metav1.Duration{}).Duration),
 			multikueue.WithAdapters(adapters),
+			multikueue.WithDisabledAdapters(disabledAdapters),
 		); err != nil {
 			setupLog.Error(err, "Could not setup MultiKueue controller")
 			os.Exit(1)
@@ -343,7 +402,14 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
-	if failedWebhook, err := webhooks.Setup(mgr); err != nil {
+	var webhookOpts []webhooks.Option
+	if cfg.LocalQueues != nil {
+		webhookOpts = append(webhookOpts, webhooks.WithMissingClusterQueuePolicy(cfg.LocalQueues.MissingClusterQueuePolicy))
+	}
+	if cfg.ClusterQueues != nil {
+		webhookOpts = append(webhookOpts, webhooks.WithCohortChangePolicy(cfg.ClusterQueues.CohortChangePolicy))
+	}
+	if failedWebhook, err := webhooks.Setup(mgr, webhookOpts...); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", failedWebhook)
 		os.Exit(1)
 	}
@@ -356,9 +422,15 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		jobframework.WithEnabledExternalFrameworks(cfg.Integrations.ExternalFrameworks),
 		jobframework.WithManagerName(constants.KueueName),
 		jobframework.WithLabelKeysToCopy(cfg.Integrations.LabelKeysToCopy),
+		jobframework.WithAdmittedJobUpdatePolicy(cfg.Integrations.AdmittedJobUpdatePolicy),
+		jobframework.WithMissingQueueNamePolicy(cfg.Integrations.MissingQueueNamePolicy, cfg.Integrations.DefaultQueueName),
+		jobframework.WithMultiKueueDisabledIntegrations(cfg.MultiKueue.DisabledIntegrations),
 		jobframework.WithCache(cCache),
 		jobframework.WithQueues(queues),
 	}
+	if priorityResolver, found := jobframework.GetPriorityResolver(cfg.Integrations.PriorityResolver); found {
+		opts = append(opts, jobframework.WithPriorityResolver(priorityResolver))
+	}
 	if cfg.Integrations.PodOptions != nil {
 		opts = append(opts, jobframework.WithIntegrationOptions(corev1.SchemeGroupVersion.WithKind("Pod").String(), cfg.Integrations.PodOptions))
 	}
@@ -407,13 +479,23 @@ func setupProbeEndpoints(mgr ctrl.Manager, certsReady <-chan struct{}) {
 }
 
 func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
+	opts := []scheduler.Option{
+		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
+		scheduler.WithFairSharing(cfg.FairSharing),
+	}
+	if cfg.Scheduler != nil {
+		opts = append(opts,
+			scheduler.WithMaxAdmissionsPerCycle(cfg.Scheduler.MaxAdmissionsPerCycle),
+			scheduler.WithAdmissionCooldown(cfg.Scheduler.AdmissionCooldown),
+			scheduler.WithPreemptionObjective(cfg.Scheduler.PreemptionObjective),
+		)
+	}
 	sched := scheduler.New(
 		queues,
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
-		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
-		scheduler.WithFairSharing(cfg.FairSharing),
+		opts...,
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
@@ -0,0 +1,46 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	adoptExample = templates.Examples(`
+		# Adopt the running job into Kueue
+		kueuectl adopt job my-job --queue my-local-queue
+	`)
+)
+
+func NewAdoptCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "adopt",
+		Short:   "Adopt a resource not managed by Kueue",
+		Example: adoptExample,
+	}
+
+	util.AddDryRunFlag(cmd)
+
+	cmd.AddCommand(NewJobCmd(clientGetter, streams))
+
+	return cmd
+}
@@ -0,0 +1,188 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+var (
+	jobLong = templates.LongDesc(`
+		Starts managing the given Job, not managed by Kueue yet, with the given LocalQueue.
+		The queue-name label and the kueue.x-k8s.io/adopt annotation are set on the Job.
+		When the Job is running, Kueue creates an admitted Workload for it without
+		suspending the Job. The usage of the Job is accounted in the ClusterQueue of the
+		LocalQueue, even if it exceeds the available quota.
+	`)
+	jobExample = templates.Examples(`
+		# Adopt the running job
+		kueuectl adopt job my-job --queue my-local-queue
+
+		# Preview the adoption of the job
+		kueuectl adopt job my-job --queue my-local-queue --dry-run client
+	`)
+)
+
+type JobOptions struct {
+	JobName   string
+	QueueName string
+	Namespace string
+
+	DryRunStrategy util.DryRunStrategy
+
+	Client    kueuev1beta1.KueueV1beta1Interface
+	JobClient batchv1client.BatchV1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewJobOptions(streams genericiooptions.IOStreams) *JobOptions {
+	return &JobOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewJobCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewJobOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "job NAME --queue LOCAL_QUEUE [--namespace NAMESPACE] [--dry-run STRATEGY]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"jobs"},
+		Short:                 "Adopt the Job into Kueue",
+		Long:                  jobLong,
+		Example:               jobExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.QueueName, "queue", "q", "", "The LocalQueue to adopt the Job into.")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("queue"))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("queue", completion.LocalQueueNameFunc(clientGetter, nil)))
+
+	util.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *JobOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Command, args []string) error {
+	o.JobName = args[0]
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+	o.Client = clientset.KueueV1beta1()
+
+	k8sClientset, err := clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+	o.JobClient = k8sClientset.BatchV1()
+
+	return nil
+}
+
+// Run adopts the job
+func (o *JobOptions) Run(ctx context.Context) error {
+	if _, err := o.Client.LocalQueues(o.Namespace).Get(ctx, o.QueueName, metav1.GetOptions{}); err != nil {
+		return err
+	}
+
+	job, err := o.JobClient.Jobs(o.Namespace).Get(ctx, o.JobName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if queueName, found := job.Labels[constants.QueueLabel]; found {
+		return fmt.Errorf("job %s/%s is already managed by the LocalQueue %s", job.Namespace, job.Name, queueName)
+	}
+	if job.Status.CompletionTime != nil {
+		return fmt.Errorf("job %s/%s is already completed", job.Namespace, job.Name)
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels":      map[string]string{constants.QueueLabel: o.QueueName},
+			"annotations": map[string]string{constants.AdoptAnnotation: "true"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	patchOptions := metav1.PatchOptions{}
+	if o.DryRunStrategy == util.DryRunServer {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if o.DryRunStrategy != util.DryRunClient {
+		if _, err := o.JobClient.Jobs(o.Namespace).Patch(ctx, o.JobName, types.MergePatchType, patch, patchOptions); err != nil {
+			return err
+		}
+	}
+	o.printAdopted(fmt.Sprintf("job.batch/%s", o.JobName))
+
+	return nil
+}
+
+func (o *JobOptions) printAdopted(name string) {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		fmt.Fprintf(o.Out, "%s adopted by %s (client dry run)\n", name, o.QueueName)
+	case util.DryRunServer:
+		fmt.Fprintf(o.Out, "%s adopted by %s (server dry run)\n", name, o.QueueName)
+	default:
+		fmt.Fprintf(o.Out, "%s adopted by %s\n", name, o.QueueName)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+)
+
+func TestJobCmd(t *testing.T) {
+	baseJob := utiltestingjob.MakeJob("job", metav1.NamespaceDefault).Suspend(false)
+	queues := []runtime.Object{
+		utiltesting.MakeLocalQueue("lq", metav1.NamespaceDefault).Obj(),
+	}
+
+	testCases := map[string]struct {
+		args    []string
+		objs    []runtime.Object
+		job     *batchv1.Job
+		wantJob *batchv1.Job
+		wantOut string
+		wantErr string
+	}{
+		"queue not found": {
+			args:    []string{"job", "--queue", "lq2"},
+			objs:    queues,
+			job:     baseJob.Clone().Obj(),
+			wantJob: baseJob.Clone().Obj(),
+			wantErr: `localqueues.kueue.x-k8s.io "lq2" not found`,
+		},
+		"job not found": {
+			args:    []string{"job2", "--queue", "lq"},
+			objs:    queues,
+			job:     baseJob.Clone().Obj(),
+			wantJob: baseJob.Clone().Obj(),
+			wantErr: `jobs.batch "job2" not found`,
+		},
+		"job already managed by Kueue": {
+			args:    []string{"job", "--queue", "lq"},
+			objs:    queues,
+			job:     baseJob.Clone().Queue("other").Obj(),
+			wantJob: baseJob.Clone().Queue("other").Obj(),
+			wantErr: "job default/job is already managed by the LocalQueue other",
+		},
+		"adopts the job": {
+			args: []string{"job", "--queue", "lq"},
+			objs: queues,
+			job:  baseJob.Clone().Obj(),
+			wantJob: baseJob.Clone().
+				Queue("lq").
+				SetAnnotation(constants.AdoptAnnotation, "true").
+				Obj(),
+			wantOut: "job.batch/job adopted by lq\n",
+		},
+		"client dry run": {
+			args:    []string{"job", "--queue", "lq", "--dry-run", "client"},
+			objs:    queues,
+			job:     baseJob.Clone().Obj(),
+			wantJob: baseJob.Clone().Obj(),
+			wantOut: "job.batch/job adopted by lq (client dry run)\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			k8sClientset := k8sfake.NewSimpleClientset(tc.job)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithK8sClientset(k8sClientset)
+
+			cmd := NewJobCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotJob, err := k8sClientset.BatchV1().Jobs(tc.job.Namespace).Get(t.Context(), tc.job.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantJob, gotJob, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected job (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -24,9 +24,13 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/adopt"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/create"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/drain"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/explain"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/list"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/migrate"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/passthrough"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/resume"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/stop"
@@ -79,6 +83,10 @@ func NewKueuectlCmd(o KueuectlOptions) *cobra.Command {
 	cmd.AddCommand(resume.NewResumeCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(stop.NewStopCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(list.NewListCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(migrate.NewMigrateCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(drain.NewDrainCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(adopt.NewAdoptCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(explain.NewExplainCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(passthrough.NewCommands(clientGetter, o.IOStreams)...)
 	cmd.AddCommand(version.NewVersionCmd(clientGetter, o.IOStreams))
 
@@ -17,7 +17,10 @@ limitations under the License.
 package create
 
 import (
+	"encoding/json"
+
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/kubectl/pkg/util/templates"
 
@@ -46,3 +49,17 @@ func NewCreateCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStr
 
 	return cmd
 }
+
+// toApplyConfiguration converts the object into its apply configuration, so it
+// can be sent as a server-side apply request.
+func toApplyConfiguration[T any](obj runtime.Object) (*T, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	applyConfiguration := new(T)
+	if err := json.Unmarshal(data, applyConfiguration); err != nil {
+		return nil, err
+	}
+	return applyConfiguration, nil
+}
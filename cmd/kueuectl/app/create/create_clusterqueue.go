@@ -34,6 +34,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueueapplyv1beta1 "sigs.k8s.io/kueue/client-go/applyconfiguration/kueue/v1beta1"
 	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
 	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
@@ -89,6 +90,7 @@ type ClusterQueueOptions struct {
 	PrintFlags *genericclioptions.PrintFlags
 
 	DryRunStrategy               util.DryRunStrategy
+	ServerSideApply              util.ServerSideApplyOptions
 	Name                         string
 	Cohort                       string
 	QueueingStrategy             v1beta1.QueueingStrategy
@@ -135,7 +137,8 @@ func NewClusterQueueCmd(clientGetter util.ClientGetter, streams genericiooptions
 			"[--nominal-quota RESOURCE_FLAVOR:RESOURCE=VALUE] " +
 			"[--borrowing-limit RESOURCE_FLAVOR:RESOURCE=VALUE] " +
 			"[--lending-limit RESOURCE_FLAVOR:RESOURCE=VALUE] " +
-			"[--dry-run STRATEGY]",
+			"[--dry-run STRATEGY] " +
+			"[--server-side]",
 		// To do not add "[flags]" suffix on the end of usage line
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"cq"},
@@ -158,6 +161,7 @@ func NewClusterQueueCmd(clientGetter util.ClientGetter, streams genericiooptions
 	}
 
 	o.PrintFlags.AddFlags(cmd)
+	util.AddServerSideApplyFlags(cmd)
 
 	cmd.Flags().StringVar(&o.Cohort, cohort, o.Cohort,
 		"The cohort that this ClusterQueue belongs to.")
@@ -221,6 +225,13 @@ func (o *ClusterQueueOptions) Complete(clientGetter util.ClientGetter, cmd *cobr
 		return err
 	}
 
+	o.ServerSideApply, err = util.GetServerSideApplyOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	util.PrintFlagsWithServerSideApply(o.PrintFlags, o.ServerSideApply)
+
 	err = util.PrintFlagsWithDryRunStrategy(o.PrintFlags, o.DryRunStrategy)
 	if err != nil {
 		return err
@@ -249,14 +260,12 @@ func (o *ClusterQueueOptions) validate() error {
 func (o *ClusterQueueOptions) Run(ctx context.Context) error {
 	cq := o.createClusterQueue()
 	if o.DryRunStrategy != util.DryRunClient {
-		var (
-			createOptions metav1.CreateOptions
-			err           error
-		)
-		if o.DryRunStrategy == util.DryRunServer {
-			createOptions.DryRun = []string{metav1.DryRunAll}
+		var err error
+		if o.ServerSideApply.ServerSide {
+			cq, err = o.applyClusterQueue(ctx, cq)
+		} else {
+			cq, err = o.Client.ClusterQueues().Create(ctx, cq, o.ServerSideApply.CreateOptions(o.DryRunStrategy))
 		}
-		cq, err = o.Client.ClusterQueues().Create(ctx, cq, createOptions)
 		if err != nil {
 			return err
 		}
@@ -499,3 +508,12 @@ func isResourceGroupValid(indexByResourceGroup map[string]int, newResourceGroup
 
 	return true
 }
+
+func (o *ClusterQueueOptions) applyClusterQueue(ctx context.Context, cq *v1beta1.ClusterQueue) (*v1beta1.ClusterQueue, error) {
+	cqApplyConfiguration, err := toApplyConfiguration[kueueapplyv1beta1.ClusterQueueApplyConfiguration](cq)
+	if err != nil {
+		return nil, err
+	}
+	cqApplyConfiguration.Status = nil
+	return o.Client.ClusterQueues().Apply(ctx, cqApplyConfiguration, o.ServerSideApply.ApplyOptions(o.DryRunStrategy))
+}
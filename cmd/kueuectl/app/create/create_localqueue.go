@@ -28,6 +28,7 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueueapplyv1beta1 "sigs.k8s.io/kueue/client-go/applyconfiguration/kueue/v1beta1"
 	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
 	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
@@ -49,6 +50,7 @@ type LocalQueueOptions struct {
 	PrintFlags *genericclioptions.PrintFlags
 
 	DryRunStrategy   util.DryRunStrategy
+	ServerSideApply  util.ServerSideApplyOptions
 	Name             string
 	Namespace        string
 	EnforceNamespace bool
@@ -75,7 +77,7 @@ func NewLocalQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.I
 	o := NewLocalQueueOptions(streams)
 
 	cmd := &cobra.Command{
-		Use: "localqueue NAME -c CLUSTER_QUEUE_NAME [--ignore-unknown-cq] [--dry-run STRATEGY]",
+		Use: "localqueue NAME -c CLUSTER_QUEUE_NAME [--ignore-unknown-cq] [--dry-run STRATEGY] [--server-side]",
 		// To do not add "[flags]" suffix on the end of usage line
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"lq"},
@@ -99,6 +101,7 @@ func NewLocalQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.I
 	}
 
 	o.PrintFlags.AddFlags(cmd)
+	util.AddServerSideApplyFlags(cmd)
 
 	cmd.Flags().StringVarP(&o.UserSpecifiedClusterQueue, "clusterqueue", "c", "",
 		"The cluster queue name which will be associated with the local queue (required).")
@@ -136,6 +139,13 @@ func (o *LocalQueueOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.
 		return err
 	}
 
+	o.ServerSideApply, err = util.GetServerSideApplyOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	util.PrintFlagsWithServerSideApply(o.PrintFlags, o.ServerSideApply)
+
 	err = util.PrintFlagsWithDryRunStrategy(o.PrintFlags, o.DryRunStrategy)
 	if err != nil {
 		return err
@@ -175,14 +185,12 @@ func (o *LocalQueueOptions) Validate(ctx context.Context) error {
 func (o *LocalQueueOptions) Run(ctx context.Context) error {
 	lq := o.createLocalQueue()
 	if o.DryRunStrategy != util.DryRunClient {
-		var (
-			createOptions metav1.CreateOptions
-			err           error
-		)
-		if o.DryRunStrategy == util.DryRunServer {
-			createOptions.DryRun = []string{metav1.DryRunAll}
+		var err error
+		if o.ServerSideApply.ServerSide {
+			lq, err = o.applyLocalQueue(ctx, lq)
+		} else {
+			lq, err = o.Client.LocalQueues(o.Namespace).Create(ctx, lq, o.ServerSideApply.CreateOptions(o.DryRunStrategy))
 		}
-		lq, err = o.Client.LocalQueues(o.Namespace).Create(ctx, lq, createOptions)
 		if err != nil {
 			return err
 		}
@@ -197,3 +205,12 @@ func (o *LocalQueueOptions) createLocalQueue() *v1beta1.LocalQueue {
 		Spec:       v1beta1.LocalQueueSpec{ClusterQueue: o.ClusterQueue},
 	}
 }
+
+func (o *LocalQueueOptions) applyLocalQueue(ctx context.Context, lq *v1beta1.LocalQueue) (*v1beta1.LocalQueue, error) {
+	lqApplyConfiguration, err := toApplyConfiguration[kueueapplyv1beta1.LocalQueueApplyConfiguration](lq)
+	if err != nil {
+		return nil, err
+	}
+	lqApplyConfiguration.Status = nil
+	return o.Client.LocalQueues(o.Namespace).Apply(ctx, lqApplyConfiguration, o.ServerSideApply.ApplyOptions(o.DryRunStrategy))
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueueapplyv1beta1 "sigs.k8s.io/kueue/client-go/applyconfiguration/kueue/v1beta1"
 	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
 	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
@@ -64,11 +65,12 @@ var (
 type ResourceFlavorOptions struct {
 	PrintFlags *genericclioptions.PrintFlags
 
-	DryRunStrategy util.DryRunStrategy
-	Name           string
-	NodeLabels     map[string]string
-	NodeTaints     []corev1.Taint
-	Tolerations    []corev1.Toleration
+	DryRunStrategy  util.DryRunStrategy
+	ServerSideApply util.ServerSideApplyOptions
+	Name            string
+	NodeLabels      map[string]string
+	NodeTaints      []corev1.Taint
+	Tolerations     []corev1.Toleration
 
 	UserSpecifiedNodeTaints  []string
 	UserSpecifiedTolerations []string
@@ -95,7 +97,8 @@ func NewResourceFlavorCmd(clientGetter util.ClientGetter, streams genericiooptio
 			"[--node-labels KEY=VALUE] " +
 			"[--node-taints KEY[=VALUE]:EFFECT] " +
 			"[--tolerations KEY[=VALUE][:EFFECT]]|:EFFECT " +
-			"[--dry-run STRATEGY]",
+			"[--dry-run STRATEGY] " +
+			"[--server-side]",
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"rf"},
 		Short:                 "Creates a resource flavor",
@@ -113,6 +116,7 @@ func NewResourceFlavorCmd(clientGetter util.ClientGetter, streams genericiooptio
 	}
 
 	o.PrintFlags.AddFlags(cmd)
+	util.AddServerSideApplyFlags(cmd)
 
 	cmd.Flags().StringToStringVar(&o.NodeLabels, nodeLabelsFlagName, nil,
 		"Labels that associate the ResourceFlavor with Nodes that have the same labels.")
@@ -152,6 +156,13 @@ func (o *ResourceFlavorOptions) Complete(clientGetter util.ClientGetter, cmd *co
 		return err
 	}
 
+	o.ServerSideApply, err = util.GetServerSideApplyOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	util.PrintFlagsWithServerSideApply(o.PrintFlags, o.ServerSideApply)
+
 	err = util.PrintFlagsWithDryRunStrategy(o.PrintFlags, o.DryRunStrategy)
 	if err != nil {
 		return err
@@ -171,14 +182,12 @@ func (o *ResourceFlavorOptions) Complete(clientGetter util.ClientGetter, cmd *co
 func (o *ResourceFlavorOptions) Run(ctx context.Context) error {
 	rf := o.createResourceFlavor()
 	if o.DryRunStrategy != util.DryRunClient {
-		var (
-			createOptions metav1.CreateOptions
-			err           error
-		)
-		if o.DryRunStrategy == util.DryRunServer {
-			createOptions.DryRun = []string{metav1.DryRunAll}
+		var err error
+		if o.ServerSideApply.ServerSide {
+			rf, err = o.applyResourceFlavor(ctx, rf)
+		} else {
+			rf, err = o.Client.ResourceFlavors().Create(ctx, rf, o.ServerSideApply.CreateOptions(o.DryRunStrategy))
 		}
-		rf, err = o.Client.ResourceFlavors().Create(ctx, rf, createOptions)
 		if err != nil {
 			return err
 		}
@@ -366,3 +375,11 @@ func parseToleration(str string) (corev1.Toleration, error) {
 
 	return toleration, nil
 }
+
+func (o *ResourceFlavorOptions) applyResourceFlavor(ctx context.Context, rf *v1beta1.ResourceFlavor) (*v1beta1.ResourceFlavor, error) {
+	rfApplyConfiguration, err := toApplyConfiguration[kueueapplyv1beta1.ResourceFlavorApplyConfiguration](rf)
+	if err != nil {
+		return nil, err
+	}
+	return o.Client.ResourceFlavors().Apply(ctx, rfApplyConfiguration, o.ServerSideApply.ApplyOptions(o.DryRunStrategy))
+}
@@ -0,0 +1,182 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package create
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+type recordedRequest struct {
+	Verb         string
+	Resource     string
+	PatchType    types.PatchType
+	FieldManager string
+	Force        *bool
+	DryRun       []string
+}
+
+func recordedRequests(actions []kubetesting.Action) []recordedRequest {
+	var requests []recordedRequest
+	for _, action := range actions {
+		switch a := action.(type) {
+		case kubetesting.CreateActionImpl:
+			requests = append(requests, recordedRequest{
+				Verb:         a.GetVerb(),
+				Resource:     a.GetResource().Resource,
+				FieldManager: a.CreateOptions.FieldManager,
+				DryRun:       a.CreateOptions.DryRun,
+			})
+		case kubetesting.PatchActionImpl:
+			requests = append(requests, recordedRequest{
+				Verb:         a.GetVerb(),
+				Resource:     a.GetResource().Resource,
+				PatchType:    a.GetPatchType(),
+				FieldManager: a.PatchOptions.FieldManager,
+				Force:        a.PatchOptions.Force,
+				DryRun:       a.PatchOptions.DryRun,
+			})
+		}
+	}
+	return requests
+}
+
+// applyReactor responds to server-side apply requests with the applied object,
+// as the fake object tracker can't apply objects which don't exist yet.
+func applyReactor(action kubetesting.Action) (bool, runtime.Object, error) {
+	patchAction, ok := action.(kubetesting.PatchAction)
+	if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+		return false, nil, nil
+	}
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(patchAction.GetPatch(), nil, nil)
+	return true, obj, err
+}
+
+func TestCreateCmdServerSideApply(t *testing.T) {
+	testCases := map[string]struct {
+		newCmd       func(util.ClientGetter, genericiooptions.IOStreams) *cobra.Command
+		args         []string
+		wantRequests []recordedRequest
+		wantOut      string
+		wantErr      string
+	}{
+		"should create local queue with the default field manager": {
+			newCmd: NewLocalQueueCmd,
+			args:   []string{"lq", "-c", "cq", "-i"},
+			wantRequests: []recordedRequest{{
+				Verb:         "create",
+				Resource:     "localqueues",
+				FieldManager: util.DefaultFieldManager,
+			}},
+			wantOut: "localqueue.kueue.x-k8s.io/lq created\n",
+		},
+		"should apply local queue": {
+			newCmd: NewLocalQueueCmd,
+			args:   []string{"lq", "-c", "cq", "-i", "--server-side"},
+			wantRequests: []recordedRequest{{
+				Verb:         "patch",
+				Resource:     "localqueues",
+				PatchType:    types.ApplyPatchType,
+				FieldManager: util.DefaultFieldManager,
+				Force:        ptr.To(false),
+			}},
+			wantOut: "localqueue.kueue.x-k8s.io/lq serverside-applied\n",
+		},
+		"should apply cluster queue with a custom field manager forcing conflicts": {
+			newCmd: NewClusterQueueCmd,
+			args:   []string{"cq", "--server-side", "--field-manager", "my-manager", "--force-conflicts"},
+			wantRequests: []recordedRequest{{
+				Verb:         "patch",
+				Resource:     "clusterqueues",
+				PatchType:    types.ApplyPatchType,
+				FieldManager: "my-manager",
+				Force:        ptr.To(true),
+			}},
+			wantOut: "clusterqueue.kueue.x-k8s.io/cq serverside-applied\n",
+		},
+		"should apply resource flavor with dry-run server": {
+			newCmd: NewResourceFlavorCmd,
+			args:   []string{"rf", "--server-side", "--dry-run", "server"},
+			wantRequests: []recordedRequest{{
+				Verb:         "patch",
+				Resource:     "resourceflavors",
+				PatchType:    types.ApplyPatchType,
+				FieldManager: util.DefaultFieldManager,
+				Force:        ptr.To(false),
+				DryRun:       []string{metav1.DryRunAll},
+			}},
+			wantOut: "resourceflavor.kueue.x-k8s.io/rf serverside-applied (server dry run)\n",
+		},
+		"shouldn't apply resource flavor with dry-run client": {
+			newCmd:  NewResourceFlavorCmd,
+			args:    []string{"rf", "--server-side", "--dry-run", "client"},
+			wantOut: "resourceflavor.kueue.x-k8s.io/rf serverside-applied (client dry run)\n",
+		},
+		"shouldn't force conflicts without server-side": {
+			newCmd:  NewResourceFlavorCmd,
+			args:    []string{"rf", "--force-conflicts"},
+			wantErr: "--force-conflicts only works with --server-side",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("patch", "*", applyReactor)
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := tc.newCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+			cmd.Flags().String("dry-run", "none", "")
+
+			gotErr := cmd.Execute()
+
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantRequests, recordedRequests(clientset.Actions())); diff != "" {
+				t.Errorf("Unexpected requests (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
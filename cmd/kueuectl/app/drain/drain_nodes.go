@@ -0,0 +1,342 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	nodesLong = templates.LongDesc(`
+		Evicts the Workloads with pods running on the nodes matching the label selector,
+		for example to prepare the nodes for maintenance. The Workloads get the Evicted
+		condition with the NodeDrained reason, Kueue stops their Jobs and puts the Workloads
+		back to the queue. Only the Workloads holding quota reservation are evicted.
+
+		The nodes are not cordoned. Use "kubectl cordon" before draining the nodes, so that
+		the pods of the requeued Workloads are not scheduled on them again.
+
+		As the command can evict many Workloads at once, it requires the --confirm flag,
+		unless --dry-run is set.
+	`)
+	nodesExample = templates.Examples(`
+		# Preview the workloads that would be evicted
+		kueuectl drain nodes --selector pool=my-pool --dry-run client
+
+		# Evict the workloads running on the nodes of a node pool
+		kueuectl drain nodes --selector pool=my-pool --confirm
+	`)
+)
+
+// maxOwnerDepth is the maximum number of controllers followed from a pod to
+// find its Workload, for example a Pod controlled by a Job controlled by a JobSet.
+const maxOwnerDepth = 3
+
+var errConfirmationRequired = errors.New("draining nodes requires --confirm, use --dry-run to preview the changes")
+
+type NodesOptions struct {
+	Selector  string
+	Confirmed bool
+
+	DryRunStrategy util.DryRunStrategy
+
+	Client        kueuev1beta1.KueueV1beta1Interface
+	CoreClient    corev1client.CoreV1Interface
+	DynamicClient dynamic.Interface
+	RestMapper    meta.RESTMapper
+
+	genericiooptions.IOStreams
+}
+
+func NewNodesOptions(streams genericiooptions.IOStreams) *NodesOptions {
+	return &NodesOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewNodesCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewNodesOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "nodes --selector SELECTOR [--confirm] [--dry-run STRATEGY]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"node", "no"},
+		Short:                 "Evict the Workloads with pods running on the nodes matching a selector",
+		Long:                  nodesLong,
+		Example:               nodesExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Selector, "selector", "l", "", "Selector (label query) to filter the nodes on, supports '=', '==', and '!='.")
+	cmd.Flags().BoolVar(&o.Confirmed, "confirm", false, "Confirm the eviction of the Workloads.")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("selector"))
+
+	util.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *NodesOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Command) error {
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return err
+	}
+	if selector.Empty() {
+		return errors.New("--selector must not be empty")
+	}
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	if o.DryRunStrategy == util.DryRunNone && !o.Confirmed {
+		return errConfirmationRequired
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	k8sClientset, err := clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.CoreClient = k8sClientset.CoreV1()
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run evicts the workloads running on the selected nodes
+func (o *NodesOptions) Run(ctx context.Context) error {
+	nodes, err := o.CoreClient.Nodes().List(ctx, metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		fmt.Fprintf(o.ErrOut, "No nodes match the selector %q\n", o.Selector)
+		return nil
+	}
+
+	list, err := o.Client.Workloads(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	f := &workloadFinder{
+		options:  o,
+		byOwner:  make(map[types.UID][]*v1beta1.Workload),
+		resolved: make(map[types.UID][]*v1beta1.Workload),
+	}
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if !workload.HasQuotaReservation(wl) || workload.IsFinished(wl) || workload.IsEvicted(wl) {
+			continue
+		}
+		for _, owner := range wl.OwnerReferences {
+			f.byOwner[owner.UID] = append(f.byOwner[owner.UID], wl)
+		}
+	}
+
+	drainedNodes := make(map[*v1beta1.Workload]sets.Set[string])
+	for _, node := range nodes.Items {
+		pods, err := o.CoreClient.Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+		})
+		if err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Spec.NodeName != node.Name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			workloads, err := f.workloadsOf(ctx, pod.Namespace, pod.UID, pod.OwnerReferences, 0)
+			if err != nil {
+				return err
+			}
+			for _, wl := range workloads {
+				if drainedNodes[wl] == nil {
+					drainedNodes[wl] = sets.New[string]()
+				}
+				drainedNodes[wl].Insert(node.Name)
+			}
+		}
+	}
+
+	workloads := make([]*v1beta1.Workload, 0, len(drainedNodes))
+	for wl := range drainedNodes {
+		workloads = append(workloads, wl)
+	}
+	slices.SortFunc(workloads, func(a, b *v1beta1.Workload) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	updateOptions := metav1.UpdateOptions{}
+	if o.DryRunStrategy == util.DryRunServer {
+		updateOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	for _, wl := range workloads {
+		if o.DryRunStrategy != util.DryRunClient {
+			message := fmt.Sprintf("The workload has pods on the drained nodes: %s", strings.Join(sets.List(drainedNodes[wl]), ", "))
+			workload.SetEvictedCondition(wl, v1beta1.WorkloadEvictedByNodeDrain, message)
+			if _, err := o.Client.Workloads(wl.Namespace).UpdateStatus(ctx, wl, updateOptions); err != nil {
+				return err
+			}
+		}
+		o.printEvicted(fmt.Sprintf("%s/%s", wl.Namespace, wl.Name))
+	}
+
+	return nil
+}
+
+func (o *NodesOptions) printEvicted(name string) {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		fmt.Fprintf(o.Out, "workload %s evicted (client dry run)\n", name)
+	case util.DryRunServer:
+		fmt.Fprintf(o.Out, "workload %s evicted (server dry run)\n", name)
+	default:
+		fmt.Fprintf(o.Out, "workload %s evicted\n", name)
+	}
+}
+
+// workloadFinder finds the Workloads of the pods, following the chain of
+// the controllers of the pods.
+type workloadFinder struct {
+	options *NodesOptions
+
+	// byOwner indexes the Workloads which can be evicted by the UIDs of their owners.
+	byOwner map[types.UID][]*v1beta1.Workload
+	// resolved caches the Workloads found for the controllers fetched so far.
+	resolved map[types.UID][]*v1beta1.Workload
+}
+
+// workloadsOf returns the Workloads owned by the object with the given UID and
+// owner references, or by one of the controllers above it.
+func (f *workloadFinder) workloadsOf(ctx context.Context, namespace string, uid types.UID, owners []metav1.OwnerReference, depth int) ([]*v1beta1.Workload, error) {
+	if workloads, found := f.byOwner[uid]; found {
+		return workloads, nil
+	}
+	if depth == maxOwnerDepth {
+		return nil, nil
+	}
+
+	idx := slices.IndexFunc(owners, func(owner metav1.OwnerReference) bool {
+		return owner.Controller != nil && *owner.Controller
+	})
+	if idx == -1 {
+		return nil, nil
+	}
+	controller := owners[idx]
+	if workloads, found := f.byOwner[controller.UID]; found {
+		return workloads, nil
+	}
+	if workloads, found := f.resolved[controller.UID]; found {
+		return workloads, nil
+	}
+
+	gvr, err := f.options.ownerResource(&controller)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			f.resolved[controller.UID] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+	obj, err := f.options.DynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, controller.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			f.resolved[controller.UID] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	workloads, err := f.workloadsOf(ctx, namespace, obj.GetUID(), obj.GetOwnerReferences(), depth+1)
+	if err != nil {
+		return nil, err
+	}
+	f.resolved[controller.UID] = workloads
+	return workloads, nil
+}
+
+func (o *NodesOptions) ownerResource(owner *metav1.OwnerReference) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	mapping, err := o.RestMapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return mapping.Resource, nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestNodesCmd(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	jobSetGVK := schema.GroupVersionKind{Group: "jobset.x-k8s.io", Version: "v1alpha2", Kind: "JobSet"}
+	podGVK := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
+
+	nodes := []runtime.Object{
+		testingnode.MakeNode("node1").Label("pool", "maintenance").Obj(),
+		testingnode.MakeNode("node2").Label("pool", "maintenance").Obj(),
+		testingnode.MakeNode("node3").Label("pool", "other").Obj(),
+	}
+	admission := utiltesting.MakeAdmission("cq").Obj()
+	evicted := func(message string) metav1.Condition {
+		return metav1.Condition{
+			Type:    v1beta1.WorkloadEvicted,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.WorkloadEvictedByNodeDrain,
+			Message: message,
+		}
+	}
+
+	testCases := map[string]struct {
+		args          []string
+		workloads     []runtime.Object
+		pods          []runtime.Object
+		jobs          []runtime.Object
+		wantWorkloads []v1beta1.Workload
+		wantOut       string
+		wantOutErr    string
+		wantErr       string
+	}{
+		"missing confirmation": {
+			args:    []string{"--selector", "pool=maintenance"},
+			wantErr: "draining nodes requires --confirm, use --dry-run to preview the changes",
+		},
+		"empty selector": {
+			args:    []string{"--selector", "", "--confirm"},
+			wantErr: "--selector must not be empty",
+		},
+		"no matching nodes": {
+			args: []string{"--selector", "pool=missing", "--confirm"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).Obj(),
+			},
+			pods: []runtime.Object{
+				testingpod.MakePod("p1", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j1", jobGVK).Obj(),
+			},
+			wantWorkloads: []v1beta1.Workload{
+				*utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).Obj(),
+			},
+			wantOutErr: "No nodes match the selector \"pool=missing\"\n",
+		},
+		"evicts the workloads with pods on the selected nodes": {
+			args: []string{"--selector", "pool=maintenance", "--confirm"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j2", "j2").ReserveQuota(admission).Obj(),
+				utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).
+					ControllerReference(jobSetGVK, "js3", "js3").ReserveQuota(admission).Obj(),
+				utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j4", "j4").Obj(),
+				utiltesting.MakeWorkload("wl5", "ns").
+					OwnerReference(podGVK, "p5a", "p5a").
+					OwnerReference(podGVK, "p5b", "p5b").ReserveQuota(admission).Obj(),
+				utiltesting.MakeWorkload("wl6", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j6", "j6").ReserveQuota(admission).Obj(),
+			},
+			pods: []runtime.Object{
+				testingpod.MakePod("p1a", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j1", jobGVK).Obj(),
+				testingpod.MakePod("p1b", metav1.NamespaceDefault).NodeName("node2").OwnerReference("j1", jobGVK).Obj(),
+				testingpod.MakePod("p1c", metav1.NamespaceDefault).NodeName("node3").OwnerReference("j1", jobGVK).Obj(),
+				testingpod.MakePod("p2", metav1.NamespaceDefault).NodeName("node3").OwnerReference("j2", jobGVK).Obj(),
+				testingpod.MakePod("p3", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j3", jobGVK).Obj(),
+				testingpod.MakePod("p4", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j4", jobGVK).Obj(),
+				testingpod.MakePod("p5a", "ns").UID("p5a").NodeName("node3").Obj(),
+				testingpod.MakePod("p5b", "ns").UID("p5b").NodeName("node2").Obj(),
+				testingpod.MakePod("p6", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j6", jobGVK).
+					StatusPhase(corev1.PodSucceeded).Obj(),
+				testingpod.MakePod("p7", metav1.NamespaceDefault).NodeName("node1").Obj(),
+			},
+			jobs: []runtime.Object{
+				&batchv1.Job{
+					TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "j3",
+						Namespace: metav1.NamespaceDefault,
+						UID:       "j3",
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: jobSetGVK.GroupVersion().String(),
+							Kind:       jobSetGVK.Kind,
+							Name:       "js3",
+							UID:        "js3",
+							Controller: ptr.To(true),
+						}},
+					},
+				},
+			},
+			wantWorkloads: []v1beta1.Workload{
+				*utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).
+					Condition(evicted("The workload has pods on the drained nodes: node1, node2")).Obj(),
+				*utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j2", "j2").ReserveQuota(admission).Obj(),
+				*utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).
+					ControllerReference(jobSetGVK, "js3", "js3").ReserveQuota(admission).
+					Condition(evicted("The workload has pods on the drained nodes: node1")).Obj(),
+				*utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j4", "j4").Obj(),
+				*utiltesting.MakeWorkload("wl6", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j6", "j6").ReserveQuota(admission).Obj(),
+				*utiltesting.MakeWorkload("wl5", "ns").
+					OwnerReference(podGVK, "p5a", "p5a").
+					OwnerReference(podGVK, "p5b", "p5b").ReserveQuota(admission).
+					Condition(evicted("The workload has pods on the drained nodes: node2")).Obj(),
+			},
+			wantOut: "workload default/wl1 evicted\nworkload default/wl3 evicted\nworkload ns/wl5 evicted\n",
+		},
+		"client dry run": {
+			args: []string{"--selector", "pool=maintenance", "--dry-run", "client"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).Obj(),
+			},
+			pods: []runtime.Object{
+				testingpod.MakePod("p1", metav1.NamespaceDefault).NodeName("node1").OwnerReference("j1", jobGVK).Obj(),
+			},
+			wantWorkloads: []v1beta1.Workload{
+				*utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "j1").ReserveQuota(admission).Obj(),
+			},
+			wantOut: "workload default/wl1 evicted (client dry run)\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.workloads...)
+			k8sClientset := k8sfake.NewSimpleClientset(append(tc.pods, nodes...)...)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, tc.jobs...)
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithK8sClientset(k8sClientset).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			cmd := NewNodesCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+
+			gotWorkloads, err := clientset.KueueV1beta1().Workloads(metav1.NamespaceAll).List(t.Context(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads.Items,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected workloads (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	wlLong = templates.LongDesc(`
+		Explains whether a pending Workload fits in the ClusterQueue of its LocalQueue.
+		The flavors are assigned to the Workload as in a scheduling cycle, based on the
+		current quota usage of the ClusterQueues. The command prints the flavors the
+		Workload would get, the reasons why it doesn't fit, and whether preempting
+		other Workloads would make room for it.
+
+		The quotas defined by Cohort objects and the topology of the nodes are not
+		taken into account.
+	`)
+	wlExample = templates.Examples(`
+		# Explain whether the workload fits in its ClusterQueue
+		kueuectl explain workload my-workload
+	`)
+)
+
+type WorkloadOptions struct {
+	Name      string
+	Namespace string
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "workload NAME [--namespace NAMESPACE]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Explain the admission of a pending Workload",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+// Run explains the admission of the workload
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	wl, err := o.Client.Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if workload.HasQuotaReservation(wl) {
+		fmt.Fprintf(o.Out, "Workload %s/%s has quota reserved in ClusterQueue %q, nothing to explain\n", wl.Namespace, wl.Name, wl.Status.Admission.ClusterQueue)
+		return nil
+	}
+	if workload.IsFinished(wl) {
+		fmt.Fprintf(o.Out, "Workload %s/%s is finished, nothing to explain\n", wl.Namespace, wl.Name)
+		return nil
+	}
+	if wl.Spec.QueueName == "" {
+		return fmt.Errorf("workload %s/%s doesn't specify a LocalQueue", wl.Namespace, wl.Name)
+	}
+	lq, err := o.Client.LocalQueues(o.Namespace).Get(ctx, string(wl.Spec.QueueName), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := o.snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot.WithholdPendingScheduledQuotas(clock.RealClock{}.Now())
+	cq := snapshot.ClusterQueue(lq.Spec.ClusterQueue)
+	if cq == nil {
+		if snapshot.InactiveClusterQueueSets.Has(lq.Spec.ClusterQueue) {
+			return fmt.Errorf("ClusterQueue %q is inactive", lq.Spec.ClusterQueue)
+		}
+		return fmt.Errorf("ClusterQueue %q doesn't exist", lq.Spec.ClusterQueue)
+	}
+
+	log := logr.Discard()
+	info := workload.NewInfo(wl)
+	info.ClusterQueue = cq.Name
+	preemptor := preemption.New(nil, workload.Ordering{}, nil, config.FairSharing{}, 0, "", clock.RealClock{})
+	assigner := flavorassigner.New(info, cq, snapshot.ResourceFlavors, false, preemption.NewOracle(preemptor, snapshot))
+	assignment := assigner.Assign(log, nil)
+
+	mode := assignment.RepresentativeMode()
+	if mode == flavorassigner.Fit {
+		fmt.Fprintf(o.Out, "Workload %s/%s fits in ClusterQueue %q\n", wl.Namespace, wl.Name, cq.Name)
+	} else {
+		fmt.Fprintf(o.Out, "Workload %s/%s doesn't fit in ClusterQueue %q\n", wl.Namespace, wl.Name, cq.Name)
+	}
+	if mode != flavorassigner.NoFit {
+		o.printFlavors(&assignment)
+		if assignment.Borrows() > 0 {
+			fmt.Fprintln(o.Out, "The Workload borrows quota from the cohort")
+		}
+	}
+	if msg := assignment.Message(); mode != flavorassigner.Fit && msg != "" {
+		fmt.Fprintln(o.Out, "Short on:")
+		fmt.Fprintf(o.Out, "  %s\n", msg)
+	}
+
+	switch mode {
+	case flavorassigner.Preempt:
+		targets := preemptor.GetTargets(log, *info, assignment, snapshot)
+		if len(targets) == 0 {
+			fmt.Fprintln(o.Out, "Preemption: can't make room for the Workload")
+			break
+		}
+		keys := make([]string, 0, len(targets))
+		for _, target := range targets {
+			keys = append(keys, workload.Key(target.WorkloadInfo.Obj))
+		}
+		slices.Sort(keys)
+		fmt.Fprintf(o.Out, "Preemption: makes room for the Workload by preempting %d workload(s): %s\n", len(keys), strings.Join(keys, ", "))
+	case flavorassigner.NoFit:
+		fmt.Fprintln(o.Out, "Preemption: doesn't help, the Workload doesn't fit in the quota of the ClusterQueue")
+	}
+
+	return nil
+}
+
+func (o *WorkloadOptions) printFlavors(assignment *flavorassigner.Assignment) {
+	fmt.Fprintln(o.Out, "Flavors:")
+	for _, ps := range assignment.PodSets {
+		fmt.Fprintf(o.Out, "  %s:\n", ps.Name)
+		resourceNames := make([]corev1.ResourceName, 0, len(ps.Flavors))
+		for name := range ps.Flavors {
+			resourceNames = append(resourceNames, name)
+		}
+		slices.Sort(resourceNames)
+		for _, name := range resourceNames {
+			flavor := ps.Flavors[name]
+			if flavor.Mode == flavorassigner.Preempt {
+				fmt.Fprintf(o.Out, "    %s: %s (requires preemption)\n", name, flavor.Name)
+			} else {
+				fmt.Fprintf(o.Out, "    %s: %s\n", name, flavor.Name)
+			}
+		}
+	}
+}
+
+// snapshot builds the snapshot of the ClusterQueues, in the same way as the
+// scheduler, from the objects in the cluster.
+func (o *WorkloadOptions) snapshot(ctx context.Context) (*cache.Snapshot, error) {
+	flavors, err := o.Client.ResourceFlavors().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	checks, err := o.Client.AdmissionChecks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterQueues, err := o.Client.ClusterQueues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	localQueues, err := o.Client.LocalQueues(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := o.Client.Workloads(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The cache lists the LocalQueues and Workloads of the ClusterQueues
+	// with a client, so it's backed by an in-memory client.
+	objs := make([]client.Object, 0, len(localQueues.Items)+len(workloads.Items))
+	for i := range localQueues.Items {
+		objs = append(objs, &localQueues.Items[i])
+	}
+	for i := range workloads.Items {
+		if wl := &workloads.Items[i]; workload.HasQuotaReservation(wl) && !workload.IsFinished(wl) {
+			objs = append(objs, wl)
+		}
+	}
+	scheme := runtime.NewScheme()
+	if err := kueue.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&kueue.LocalQueue{}, indexer.QueueClusterQueueKey, indexer.IndexQueueClusterQueue).
+		WithIndex(&kueue.Workload{}, indexer.WorkloadClusterQueueKey, indexer.IndexWorkloadClusterQueue).
+		Build()
+
+	c := cache.New(cl)
+	for i := range flavors.Items {
+		c.AddOrUpdateResourceFlavor(&flavors.Items[i])
+	}
+	for i := range checks.Items {
+		c.AddOrUpdateAdmissionCheck(&checks.Items[i])
+	}
+	for i := range clusterQueues.Items {
+		if err := c.AddClusterQueue(ctx, &clusterQueues.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return c.Snapshot(ctx)
+}
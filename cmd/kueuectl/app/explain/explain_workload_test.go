@@ -0,0 +1,153 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	queueObjects := []runtime.Object{
+		utiltesting.MakeResourceFlavor("default").Obj(),
+		utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{
+				WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+			}).
+			Obj(),
+		utiltesting.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+	}
+	baseWorkload := utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).Queue("lq").Priority(100)
+	runningWorkload := utiltesting.MakeWorkload("running", metav1.NamespaceDefault).
+		Queue("lq").
+		Request(corev1.ResourceCPU, "8").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "8").Obj())
+
+	testCases := map[string]struct {
+		args      []string
+		workloads []runtime.Object
+		wantOut   string
+		wantErr   string
+	}{
+		"should explain a fitting workload": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				baseWorkload.Clone().Request(corev1.ResourceCPU, "2").Obj(),
+				runningWorkload.Clone().Priority(200).Obj(),
+			},
+			wantOut: `Workload default/wl1 fits in ClusterQueue "cq"
+Flavors:
+  main:
+    cpu: default
+`,
+		},
+		"should explain a workload which fits by preempting workloads": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				baseWorkload.Clone().Request(corev1.ResourceCPU, "4").Obj(),
+				runningWorkload.Clone().Priority(0).Obj(),
+			},
+			wantOut: `Workload default/wl1 doesn't fit in ClusterQueue "cq"
+Flavors:
+  main:
+    cpu: default (requires preemption)
+Short on:
+  couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 2 more needed
+Preemption: makes room for the Workload by preempting 1 workload(s): default/running
+`,
+		},
+		"should explain a workload which can't fit even with preemption": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				baseWorkload.Clone().Request(corev1.ResourceCPU, "4").Obj(),
+				runningWorkload.Clone().Priority(200).Obj(),
+			},
+			wantOut: `Workload default/wl1 doesn't fit in ClusterQueue "cq"
+Flavors:
+  main:
+    cpu: default (requires preemption)
+Short on:
+  couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 2 more needed
+Preemption: can't make room for the Workload
+`,
+		},
+		"should explain a workload which doesn't fit in the quota": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				baseWorkload.Clone().Request(corev1.ResourceCPU, "20").Obj(),
+			},
+			wantOut: `Workload default/wl1 doesn't fit in ClusterQueue "cq"
+Short on:
+  couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (20 > 10)
+Preemption: doesn't help, the Workload doesn't fit in the quota of the ClusterQueue
+`,
+		},
+		"shouldn't explain a workload with quota reservation": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				baseWorkload.Clone().
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+			},
+			wantOut: "Workload default/wl1 has quota reserved in ClusterQueue \"cq\", nothing to explain\n",
+		},
+		"shouldn't explain a workload which is not found": {
+			args:    []string{"wl1"},
+			wantErr: "workloads.kueue.x-k8s.io \"wl1\" not found",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+			clientset := fake.NewSimpleClientset(append(queueObjects, tc.workloads...)...)
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewExplainCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(append([]string{"workload"}, tc.args...))
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	migrateExample = templates.Examples(`
+		# Migrate the pending workloads of a LocalQueue to another LocalQueue
+		kueuectl migrate workloads --from-queue my-old-queue --to-queue my-new-queue --confirm
+	`)
+)
+
+func NewMigrateCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Short:   "Migrate resources between queues",
+		Example: migrateExample,
+	}
+
+	util.AddDryRunFlag(cmd)
+
+	cmd.AddCommand(NewWorkloadsCmd(clientGetter, streams))
+
+	return cmd
+}
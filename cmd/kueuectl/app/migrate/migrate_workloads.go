@@ -0,0 +1,247 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	wlLong = templates.LongDesc(`
+		Moves the pending Workloads of a LocalQueue to another LocalQueue, in the same namespace.
+		The queue-name label of the Jobs owning the Workloads is updated, and Kueue moves the
+		Workloads to the new LocalQueue. Workloads holding quota reservation, finished Workloads
+		and Workloads without an owning Job are not migrated.
+
+		As the command can move many Workloads at once, it requires the --confirm flag,
+		unless --dry-run is set.
+	`)
+	wlExample = templates.Examples(`
+		# Preview the migration of the pending workloads
+		kueuectl migrate workloads --from-queue my-old-queue --to-queue my-new-queue --dry-run client
+
+		# Migrate the pending workloads
+		kueuectl migrate workloads --from-queue my-old-queue --to-queue my-new-queue --confirm
+	`)
+)
+
+var errConfirmationRequired = errors.New("migrating workloads requires --confirm, use --dry-run to preview the changes")
+
+type WorkloadsOptions struct {
+	FromQueue string
+	ToQueue   string
+	Namespace string
+	Confirmed bool
+
+	DryRunStrategy util.DryRunStrategy
+
+	Client        kueuev1beta1.KueueV1beta1Interface
+	DynamicClient dynamic.Interface
+	RestMapper    meta.RESTMapper
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadsOptions(streams genericiooptions.IOStreams) *WorkloadsOptions {
+	return &WorkloadsOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadsCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadsOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "workloads --from-queue LOCAL_QUEUE --to-queue LOCAL_QUEUE [--confirm] [--dry-run STRATEGY]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"workload", "wl"},
+		Short:                 "Migrate the pending Workloads of a LocalQueue to another LocalQueue",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.FromQueue, "from-queue", "", "The LocalQueue to migrate the Workloads from.")
+	cmd.Flags().StringVar(&o.ToQueue, "to-queue", "", "The LocalQueue to migrate the Workloads to.")
+	cmd.Flags().BoolVar(&o.Confirmed, "confirm", false, "Confirm the migration of the Workloads.")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("from-queue"))
+	cobra.CheckErr(cmd.MarkFlagRequired("to-queue"))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("from-queue", completion.LocalQueueNameFunc(clientGetter, nil)))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("to-queue", completion.LocalQueueNameFunc(clientGetter, nil)))
+
+	util.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadsOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Command) error {
+	if o.FromQueue == o.ToQueue {
+		return errors.New("--from-queue and --to-queue must be different")
+	}
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	if o.DryRunStrategy == util.DryRunNone && !o.Confirmed {
+		return errConfirmationRequired
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run migrates the workloads
+func (o *WorkloadsOptions) Run(ctx context.Context) error {
+	if _, err := o.Client.LocalQueues(o.Namespace).Get(ctx, o.ToQueue, metav1.GetOptions{}); err != nil {
+		return err
+	}
+
+	list, err := o.Client.Workloads(o.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	workloads := make([]*v1beta1.Workload, 0, len(list.Items))
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if string(wl.Spec.QueueName) != o.FromQueue || workload.HasQuotaReservation(wl) || workload.IsFinished(wl) {
+			continue
+		}
+		workloads = append(workloads, wl)
+	}
+	slices.SortFunc(workloads, func(a, b *v1beta1.Workload) int { return cmp.Compare(a.Name, b.Name) })
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{constants.QueueLabel: o.ToQueue},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	patchOptions := metav1.PatchOptions{}
+	if o.DryRunStrategy == util.DryRunServer {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	for _, wl := range workloads {
+		owner := metav1.GetControllerOf(wl)
+		if owner == nil {
+			fmt.Fprintf(o.ErrOut, "workload %s/%s has no owning job, skipping\n", wl.Namespace, wl.Name)
+			continue
+		}
+
+		gvr, err := o.ownerResource(owner)
+		if err != nil {
+			return err
+		}
+
+		if o.DryRunStrategy != util.DryRunClient {
+			if _, err := o.DynamicClient.Resource(gvr).Namespace(wl.Namespace).
+				Patch(ctx, owner.Name, types.MergePatchType, patch, patchOptions); err != nil {
+				return err
+			}
+		}
+		o.printMigrated(fmt.Sprintf("%s/%s", gvr.GroupResource().String(), owner.Name))
+	}
+
+	return nil
+}
+
+func (o *WorkloadsOptions) ownerResource(owner *metav1.OwnerReference) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	mapping, err := o.RestMapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return mapping.Resource, nil
+}
+
+func (o *WorkloadsOptions) printMigrated(name string) {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		fmt.Fprintf(o.Out, "%s migrated to %s (client dry run)\n", name, o.ToQueue)
+	case util.DryRunServer:
+		fmt.Fprintf(o.Out, "%s migrated to %s (server dry run)\n", name, o.ToQueue)
+	default:
+		fmt.Fprintf(o.Out, "%s migrated to %s\n", name, o.ToQueue)
+	}
+}
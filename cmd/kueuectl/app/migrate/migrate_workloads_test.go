@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadsCmd(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	jobGVR := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+	makeJob := func(name, queue string) *batchv1.Job {
+		return &batchv1.Job{
+			TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{constants.QueueLabel: queue},
+			},
+		}
+	}
+	queues := []runtime.Object{
+		utiltesting.MakeLocalQueue("lq1", metav1.NamespaceDefault).Obj(),
+		utiltesting.MakeLocalQueue("lq2", metav1.NamespaceDefault).Obj(),
+	}
+
+	testCases := map[string]struct {
+		args       []string
+		objs       []runtime.Object
+		jobs       []runtime.Object
+		wantJobs   []batchv1.Job
+		wantOut    string
+		wantOutErr string
+		wantErr    string
+	}{
+		"missing confirmation": {
+			args:     []string{"--from-queue", "lq1", "--to-queue", "lq2"},
+			objs:     queues,
+			jobs:     []runtime.Object{makeJob("j1", "lq1")},
+			wantJobs: []batchv1.Job{*makeJob("j1", "lq1")},
+			wantErr:  "migrating workloads requires --confirm, use --dry-run to preview the changes",
+		},
+		"same queues": {
+			args:    []string{"--from-queue", "lq1", "--to-queue", "lq1", "--confirm"},
+			objs:    queues,
+			wantErr: "--from-queue and --to-queue must be different",
+		},
+		"target queue not found": {
+			args:    []string{"--from-queue", "lq1", "--to-queue", "lq3", "--confirm"},
+			objs:    queues,
+			wantErr: `localqueues.kueue.x-k8s.io "lq3" not found`,
+		},
+		"migrates the jobs of the pending workloads": {
+			args: []string{"--from-queue", "lq1", "--to-queue", "lq2", "--confirm"},
+			objs: append([]runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).Queue("lq1").
+					ControllerReference(jobGVK, "j1", "").Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).Queue("lq1").
+					ControllerReference(jobGVK, "j2", "").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj(),
+				utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).Queue("lq1").
+					ControllerReference(jobGVK, "j3", "").Finished().Obj(),
+				utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).Queue("other").
+					ControllerReference(jobGVK, "j4", "").Obj(),
+				utiltesting.MakeWorkload("wl5", metav1.NamespaceDefault).Queue("lq1").Obj(),
+			}, queues...),
+			jobs: []runtime.Object{
+				makeJob("j1", "lq1"),
+				makeJob("j2", "lq1"),
+				makeJob("j3", "lq1"),
+				makeJob("j4", "other"),
+			},
+			wantJobs: []batchv1.Job{
+				*makeJob("j1", "lq2"),
+				*makeJob("j2", "lq1"),
+				*makeJob("j3", "lq1"),
+				*makeJob("j4", "other"),
+			},
+			wantOut:    "jobs.batch/j1 migrated to lq2\n",
+			wantOutErr: "workload default/wl5 has no owning job, skipping\n",
+		},
+		"client dry run": {
+			args: []string{"--from-queue", "lq1", "--to-queue", "lq2", "--dry-run", "client"},
+			objs: append([]runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).Queue("lq1").
+					ControllerReference(jobGVK, "j1", "").Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).Queue("lq1").
+					ControllerReference(jobGVK, "j2", "").Obj(),
+			}, queues...),
+			jobs: []runtime.Object{
+				makeJob("j1", "lq1"),
+				makeJob("j2", "lq1"),
+			},
+			wantJobs: []batchv1.Job{
+				*makeJob("j1", "lq1"),
+				*makeJob("j2", "lq1"),
+			},
+			wantOut: "jobs.batch/j1 migrated to lq2 (client dry run)\njobs.batch/j2 migrated to lq2 (client dry run)\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, tc.jobs...)
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			cmd := NewWorkloadsCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+
+			unstructured, err := dynamicClient.Resource(jobGVR).Namespace(metav1.NamespaceDefault).
+				List(t.Context(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotJobs := &batchv1.JobList{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.UnstructuredContent(), gotJobs); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs.Items); diff != "" {
+				t.Errorf("Unexpected jobs (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
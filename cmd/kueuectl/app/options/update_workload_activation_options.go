@@ -18,6 +18,7 @@ package options
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,9 +29,11 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
 	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 type UpdateWorkloadActivationOptions struct {
@@ -101,6 +104,11 @@ func (o *UpdateWorkloadActivationOptions) Run(ctx context.Context) error {
 		return err
 	}
 
+	if ptr.Deref(wl.Spec.Active, true) == o.Active {
+		_, err = fmt.Fprintf(o.Out, "Workload %s/%s is already %s, nothing to do\n", wl.Namespace, wl.Name, activationState(o.Active))
+		return err
+	}
+
 	wlOriginal := wl.DeepCopy()
 	wl.Spec.Active = ptr.To(o.Active)
 
@@ -120,5 +128,32 @@ func (o *UpdateWorkloadActivationOptions) Run(ctx context.Context) error {
 		}
 	}
 
-	return o.PrintObj(wl, o.Out)
+	if err := o.PrintObj(wl, o.Out); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(o.ErrOut, activationImplications(wlOriginal, o.Active))
+	return err
+}
+
+func activationState(active bool) string {
+	if active {
+		return "active"
+	}
+	return "stopped"
+}
+
+// activationImplications describes the effect of changing the activation
+// of wl on its admission.
+func activationImplications(wl *kueue.Workload, active bool) string {
+	if active {
+		return fmt.Sprintf("The Workload is queued for admission in LocalQueue %q.", wl.Spec.QueueName)
+	}
+	switch {
+	case workload.IsAdmitted(wl):
+		return fmt.Sprintf("The Workload is evicted, its pods are terminated and its quota in ClusterQueue %q is released.", wl.Status.Admission.ClusterQueue)
+	case workload.HasQuotaReservation(wl):
+		return fmt.Sprintf("The quota reserved by the Workload in ClusterQueue %q is released.", wl.Status.Admission.ClusterQueue)
+	default:
+		return "The Workload is not considered for admission until it is resumed."
+	}
 }
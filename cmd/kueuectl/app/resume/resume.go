@@ -28,12 +28,16 @@ var (
 	resumeExample = templates.Examples(`
 		# Resume the workload 
 		kueuectl resume workload my-workload
+
+		# Start the workload
+		kueuectl start workload my-workload
 	`)
 )
 
 func NewResumeCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "resume",
+		Aliases: []string{"start"},
 		Short:   "Resume the resource",
 		Example: resumeExample,
 	}
@@ -0,0 +1,141 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	baseWorkload := utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).Queue("lq")
+	admission := utiltesting.MakeAdmission("cq").Obj()
+
+	testCases := map[string]struct {
+		args         []string
+		workloads    []runtime.Object
+		wantWorkload *kueue.Workload
+		wantOut      string
+		wantOutErr   string
+		wantErr      string
+	}{
+		"should stop a pending workload": {
+			args:         []string{"wl1"},
+			workloads:    []runtime.Object{baseWorkload.Clone().Obj()},
+			wantWorkload: baseWorkload.Clone().Active(false).Obj(),
+			wantOut:      "workload.kueue.x-k8s.io/wl1 stopped\n",
+			wantOutErr:   "The Workload is not considered for admission until it is resumed.\n",
+		},
+		"should stop a workload with quota reservation": {
+			args:         []string{"wl1"},
+			workloads:    []runtime.Object{baseWorkload.Clone().ReserveQuota(admission).Obj()},
+			wantWorkload: baseWorkload.Clone().ReserveQuota(admission).Active(false).Obj(),
+			wantOut:      "workload.kueue.x-k8s.io/wl1 stopped\n",
+			wantOutErr:   "The quota reserved by the Workload in ClusterQueue \"cq\" is released.\n",
+		},
+		"should stop an admitted workload": {
+			args:         []string{"wl1"},
+			workloads:    []runtime.Object{baseWorkload.Clone().ReserveQuota(admission).Admitted(true).Obj()},
+			wantWorkload: baseWorkload.Clone().ReserveQuota(admission).Admitted(true).Active(false).Obj(),
+			wantOut:      "workload.kueue.x-k8s.io/wl1 stopped\n",
+			wantOutErr:   "The Workload is evicted, its pods are terminated and its quota in ClusterQueue \"cq\" is released.\n",
+		},
+		"shouldn't patch a workload which is already stopped": {
+			args:         []string{"wl1"},
+			workloads:    []runtime.Object{baseWorkload.Clone().Active(false).Obj()},
+			wantWorkload: baseWorkload.Clone().Active(false).Obj(),
+			wantOut:      "Workload default/wl1 is already stopped, nothing to do\n",
+		},
+		"shouldn't stop a workload with client dry-run": {
+			args:         []string{"wl1", "--dry-run", "client"},
+			workloads:    []runtime.Object{baseWorkload.Clone().Obj()},
+			wantWorkload: baseWorkload.Clone().Obj(),
+			wantOut:      "workload.kueue.x-k8s.io/wl1 stopped (client dry run)\n",
+			wantOutErr:   "The Workload is not considered for admission until it is resumed.\n",
+		},
+		"shouldn't stop a workload with server dry-run": {
+			args:         []string{"wl1", "--dry-run", "server"},
+			workloads:    []runtime.Object{baseWorkload.Clone().Obj()},
+			wantWorkload: baseWorkload.Clone().Obj(),
+			wantOut:      "workload.kueue.x-k8s.io/wl1 stopped (server dry run)\n",
+			wantOutErr:   "The Workload is not considered for admission until it is resumed.\n",
+		},
+		"shouldn't stop a workload which is not found": {
+			args:    []string{"wl1"},
+			wantErr: "workloads.kueue.x-k8s.io \"wl1\" not found",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+			clientset := fake.NewSimpleClientset(tc.workloads...)
+			clientset.PrependReactor("patch", "workloads", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+				patchAction := action.(kubetesting.PatchActionImpl)
+				if slices.Contains(patchAction.PatchOptions.DryRun, metav1.DryRunAll) {
+					handled = true
+					ret, err = clientset.Tracker().Get(patchAction.GetResource(), patchAction.GetNamespace(), patchAction.GetName())
+				}
+				return handled, ret, err
+			})
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewStopCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(append([]string{"workload"}, tc.args...))
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+
+			gotWorkload, err := clientset.KueueV1beta1().Workloads(metav1.NamespaceDefault).Get(t.Context(), "wl1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected workload (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
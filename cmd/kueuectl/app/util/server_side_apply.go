@@ -0,0 +1,102 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	DefaultFieldManager = "kueuectl"
+
+	serverSideApplyOperation = "serverside-applied"
+)
+
+var errForceConflictsWithoutServerSide = errors.New("--force-conflicts only works with --server-side")
+
+// ServerSideApplyOptions holds the options of the server-side apply flags.
+type ServerSideApplyOptions struct {
+	// ServerSide indicates the object should be sent as a server-side apply
+	// request rather than created.
+	ServerSide bool
+	// FieldManager is the name of the manager owning the fields set by the request.
+	FieldManager string
+	// ForceConflicts indicates the conflicting fields owned by other managers
+	// should be taken over.
+	ForceConflicts bool
+}
+
+// AddServerSideApplyFlags adds the server-side apply flags to a command.
+func AddServerSideApplyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("server-side", false,
+		"If true, the object is sent as a server-side apply request, so it can be applied repeatedly without conflicts.")
+	cmd.Flags().String("field-manager", DefaultFieldManager,
+		"Name of the manager used to track field ownership.")
+	cmd.Flags().Bool("force-conflicts", false,
+		"If true, server-side apply will force the changes against conflicts.")
+}
+
+// GetServerSideApplyOptions reads the server-side apply flags of a command.
+func GetServerSideApplyOptions(cmd *cobra.Command) (ServerSideApplyOptions, error) {
+	var (
+		opts ServerSideApplyOptions
+		err  error
+	)
+	if opts.ServerSide, err = cmd.Flags().GetBool("server-side"); err != nil {
+		return opts, err
+	}
+	if opts.FieldManager, err = cmd.Flags().GetString("field-manager"); err != nil {
+		return opts, err
+	}
+	if opts.ForceConflicts, err = cmd.Flags().GetBool("force-conflicts"); err != nil {
+		return opts, err
+	}
+	if opts.ForceConflicts && !opts.ServerSide {
+		return opts, errForceConflictsWithoutServerSide
+	}
+	return opts, nil
+}
+
+// CreateOptions returns the options of a create request for the dry run strategy.
+func (o ServerSideApplyOptions) CreateOptions(dryRunStrategy DryRunStrategy) metav1.CreateOptions {
+	createOptions := metav1.CreateOptions{FieldManager: o.FieldManager}
+	if dryRunStrategy == DryRunServer {
+		createOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	return createOptions
+}
+
+// ApplyOptions returns the options of a server-side apply request for the dry run strategy.
+func (o ServerSideApplyOptions) ApplyOptions(dryRunStrategy DryRunStrategy) metav1.ApplyOptions {
+	applyOptions := metav1.ApplyOptions{FieldManager: o.FieldManager, Force: o.ForceConflicts}
+	if dryRunStrategy == DryRunServer {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	return applyOptions
+}
+
+// PrintFlagsWithServerSideApply sets the operation reported at print time for
+// server-side apply requests.
+func PrintFlagsWithServerSideApply(printFlags *genericclioptions.PrintFlags, opts ServerSideApplyOptions) {
+	if opts.ServerSide && printFlags.NamePrintFlags != nil {
+		printFlags.NamePrintFlags.Operation = serverSideApplyOperation
+	}
+}
@@ -16,7 +16,12 @@ limitations under the License.
 
 package cache
 
+import "time"
+
 type AdmissionCheck struct {
 	Active     bool
 	Controller string
+	// ReservationHold is the period, since the quota reservation, during which
+	// the check holds the quota reservation of the workloads while Pending.
+	ReservationHold time.Duration
 }
@@ -20,8 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -61,9 +65,13 @@ const (
 )
 
 type options struct {
-	workloadInfoOptions []workload.InfoOption
-	podsReadyTracking   bool
-	fairSharingEnabled  bool
+	workloadInfoOptions    []workload.InfoOption
+	podsReadyTracking      bool
+	fairSharingEnabled     bool
+	fairSharingWeights     map[corev1.ResourceName]float64
+	fairSharingShareMode   config.FairSharingShareMode
+	backgroundReservations resources.FlavorResourceQuantities
+	namespaceLimits        map[string]resources.Requests
 }
 
 // Option configures the reconciler.
@@ -97,6 +105,48 @@ func WithFairSharing(enabled bool) Option {
 	}
 }
 
+// WithFairSharingResourceWeights sets the weights of the resources in the
+// dominant resource share computation.
+func WithFairSharingResourceWeights(weights map[corev1.ResourceName]float64) Option {
+	return func(o *options) {
+		o.fairSharingWeights = weights
+	}
+}
+
+// WithFairSharingShareMode sets how the weighted shares of the resources
+// are combined into the share of the ClusterQueues and Cohorts.
+func WithFairSharingShareMode(mode config.FairSharingShareMode) Option {
+	return func(o *options) {
+		o.fairSharingShareMode = mode
+	}
+}
+
+// WithBackgroundReservations sets the resources, per flavor, consumed by the
+// workloads not managed by Kueue, which are subtracted from the nominal quota
+// of the ClusterQueues.
+func WithBackgroundReservations(reservations []config.BackgroundReservation) Option {
+	return func(o *options) {
+		o.backgroundReservations = make(resources.FlavorResourceQuantities)
+		for _, reservation := range reservations {
+			for name, quantity := range reservation.Resources {
+				fr := resources.FlavorResource{Flavor: kueue.ResourceFlavorReference(reservation.Flavor), Resource: name}
+				o.backgroundReservations[fr] = resources.ResourceValue(name, quantity)
+			}
+		}
+	}
+}
+
+// WithNamespaceLimits sets the caps of the resources reserved by the
+// workloads of the namespaces, across all the ClusterQueues.
+func WithNamespaceLimits(limits []config.NamespaceResourceLimit) Option {
+	return func(o *options) {
+		o.namespaceLimits = make(map[string]resources.Requests, len(limits))
+		for _, limit := range limits {
+			o.namespaceLimits[limit.Namespace] = resources.NewRequests(limit.Limits)
+		}
+	}
+}
+
 var defaultOptions = options{}
 
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
@@ -111,6 +161,23 @@ type Cache struct {
 	admissionChecks     map[kueue.AdmissionCheckReference]AdmissionCheck
 	workloadInfoOptions []workload.InfoOption
 	fairSharingEnabled  bool
+	// fairSharingWeights are the weights of the resources in the dominant
+	// resource share computation.
+	fairSharingWeights map[corev1.ResourceName]float64
+	// fairSharingShareMode is how the weighted shares of the resources are
+	// combined in the dominant resource share computation.
+	fairSharingShareMode config.FairSharingShareMode
+	// backgroundReservations are subtracted from the nominal quota of the
+	// ClusterQueues.
+	backgroundReservations resources.FlavorResourceQuantities
+	// namespaceLimits cap the resources reserved by the workloads of the
+	// namespaces, across all the ClusterQueues.
+	namespaceLimits map[string]resources.Requests
+	// nodeLabels are the labels of the nodes, by node name.
+	nodeLabels map[string]map[string]string
+	// flavorNodes are the numbers of nodes matching the node labels of the
+	// ResourceFlavors.
+	flavorNodes map[kueue.ResourceFlavorReference]int
 
 	hm hierarchy.Manager[*clusterQueue, *cohort]
 
@@ -123,15 +190,21 @@ func New(client client.Client, opts ...Option) *Cache {
 		opt(&options)
 	}
 	c := &Cache{
-		client:              client,
-		assumedWorkloads:    make(map[string]kueue.ClusterQueueReference),
-		resourceFlavors:     make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
-		admissionChecks:     make(map[kueue.AdmissionCheckReference]AdmissionCheck),
-		podsReadyTracking:   options.podsReadyTracking,
-		workloadInfoOptions: options.workloadInfoOptions,
-		fairSharingEnabled:  options.fairSharingEnabled,
-		hm:                  hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
-		tasCache:            NewTASCache(client),
+		client:                 client,
+		assumedWorkloads:       make(map[string]kueue.ClusterQueueReference),
+		resourceFlavors:        make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
+		admissionChecks:        make(map[kueue.AdmissionCheckReference]AdmissionCheck),
+		podsReadyTracking:      options.podsReadyTracking,
+		workloadInfoOptions:    options.workloadInfoOptions,
+		fairSharingEnabled:     options.fairSharingEnabled,
+		fairSharingWeights:     options.fairSharingWeights,
+		fairSharingShareMode:   options.fairSharingShareMode,
+		backgroundReservations: options.backgroundReservations,
+		namespaceLimits:        options.namespaceLimits,
+		nodeLabels:             make(map[string]map[string]string),
+		flavorNodes:            make(map[kueue.ResourceFlavorReference]int),
+		hm:                     hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
+		tasCache:               NewTASCache(client),
 	}
 	c.podsReadyCond.L = &c.RWMutex
 	return c
@@ -139,15 +212,17 @@ func New(client client.Client, opts ...Option) *Cache {
 
 func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 	cqImpl := &clusterQueue{
-		Name:                kueue.ClusterQueueReference(cq.Name),
-		Workloads:           make(map[string]*workload.Info),
-		WorkloadsNotReady:   sets.New[string](),
-		localQueues:         make(map[queue.LocalQueueReference]*LocalQueue),
-		podsReadyTracking:   c.podsReadyTracking,
-		workloadInfoOptions: c.workloadInfoOptions,
-		AdmittedUsage:       make(resources.FlavorResourceQuantities),
-		resourceNode:        NewResourceNode(),
-		tasCache:            &c.tasCache,
+		Name:                   kueue.ClusterQueueReference(cq.Name),
+		Workloads:              make(map[string]*workload.Info),
+		WorkloadsNotReady:      sets.New[string](),
+		localQueues:            make(map[queue.LocalQueueReference]*LocalQueue),
+		podsReadyTracking:      c.podsReadyTracking,
+		workloadInfoOptions:    c.workloadInfoOptions,
+		AdmittedUsage:          make(resources.FlavorResourceQuantities),
+		resourceNode:           NewResourceNode(),
+		fallbackUsage:          make(resources.FlavorResourceQuantities),
+		tasCache:               &c.tasCache,
+		backgroundReservations: c.backgroundReservations,
 	}
 	c.hm.AddClusterQueue(cqImpl)
 	c.hm.UpdateClusterQueueEdge(kueue.ClusterQueueReference(cq.Name), cq.Spec.Cohort)
@@ -252,22 +327,86 @@ func (c *Cache) TASCache() *TASCache {
 func (c *Cache) AddOrUpdateResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
-	c.resourceFlavors[kueue.ResourceFlavorReference(rf.Name)] = rf
+	flavor := kueue.ResourceFlavorReference(rf.Name)
+	c.resourceFlavors[flavor] = rf
+	nodes := 0
+	for _, nodeLabels := range c.nodeLabels {
+		if flavorMatchesNode(rf, nodeLabels) {
+			nodes++
+		}
+	}
+	c.flavorNodes[flavor] = nodes
+	metrics.ReportResourceFlavorNodes(flavor, nodes)
 	return c.updateClusterQueues()
 }
 
 func (c *Cache) DeleteResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
-	delete(c.resourceFlavors, kueue.ResourceFlavorReference(rf.Name))
+	flavor := kueue.ResourceFlavorReference(rf.Name)
+	delete(c.resourceFlavors, flavor)
+	delete(c.flavorNodes, flavor)
+	metrics.ClearResourceFlavorNodes(flavor)
 	return c.updateClusterQueues()
 }
 
+// AddOrUpdateNode records the labels of the node, and updates the number of
+// nodes matching the node labels of each ResourceFlavor.
+func (c *Cache) AddOrUpdateNode(node *corev1.Node) {
+	c.Lock()
+	defer c.Unlock()
+	oldLabels, found := c.nodeLabels[node.Name]
+	if found && maps.Equal(oldLabels, node.Labels) {
+		return
+	}
+	c.nodeLabels[node.Name] = maps.Clone(node.Labels)
+	for flavor, rf := range c.resourceFlavors {
+		delta := 0
+		if found && flavorMatchesNode(rf, oldLabels) {
+			delta--
+		}
+		if flavorMatchesNode(rf, node.Labels) {
+			delta++
+		}
+		c.updateFlavorNodes(flavor, delta)
+	}
+}
+
+// DeleteNode forgets the node, and updates the number of nodes matching the
+// node labels of each ResourceFlavor.
+func (c *Cache) DeleteNode(node *corev1.Node) {
+	c.Lock()
+	defer c.Unlock()
+	oldLabels, found := c.nodeLabels[node.Name]
+	if !found {
+		return
+	}
+	delete(c.nodeLabels, node.Name)
+	for flavor, rf := range c.resourceFlavors {
+		if flavorMatchesNode(rf, oldLabels) {
+			c.updateFlavorNodes(flavor, -1)
+		}
+	}
+}
+
+func (c *Cache) updateFlavorNodes(flavor kueue.ResourceFlavorReference, delta int) {
+	if delta == 0 {
+		return
+	}
+	c.flavorNodes[flavor] += delta
+	metrics.ReportResourceFlavorNodes(flavor, c.flavorNodes[flavor])
+}
+
+func flavorMatchesNode(rf *kueue.ResourceFlavor, nodeLabels map[string]string) bool {
+	return labels.SelectorFromSet(rf.Spec.NodeLabels).Matches(labels.Set(nodeLabels))
+}
+
 func (c *Cache) AddOrUpdateTopologyForFlavor(topology *kueuealpha.Topology, flv *kueue.ResourceFlavor) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
 	levels := utiltas.Levels(topology)
 	tasInfo := c.tasCache.NewTASFlavorCache(kueue.TopologyReference(topology.Name), levels, flv.Spec.NodeLabels, flv.Spec.Tolerations)
+	tasInfo.DefaultRequiredLevel = flv.Spec.DefaultRequiredTopologyLevel
 	c.tasCache.Set(kueue.ResourceFlavorReference(flv.Name), tasInfo)
 	return c.updateClusterQueues()
 }
@@ -279,6 +418,34 @@ func (c *Cache) DeleteTopologyForFlavor(flv kueue.ResourceFlavorReference) sets.
 	return c.updateClusterQueues()
 }
 
+// WorkloadsOnUnavailableTopologyDomains returns the workloads with quota reserved
+// which are assigned to a topology domain of the TAS flavor without any ready
+// and schedulable node left.
+func (c *Cache) WorkloadsOnUnavailableTopologyDomains(ctx context.Context, flv kueue.ResourceFlavorReference) ([]*workload.Info, error) {
+	tasFlavor := c.tasCache.Get(flv)
+	if tasFlavor == nil {
+		return nil, nil
+	}
+	available, err := tasFlavor.availableDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.RLock()
+	defer c.RUnlock()
+	var result []*workload.Info
+	for _, cq := range c.hm.ClusterQueues() {
+		for _, wi := range cq.Workloads {
+			for _, domainRequests := range wi.TASUsage()[flv] {
+				if !available.Has(utiltas.DomainID(domainRequests.Values)) {
+					result = append(result, wi)
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
@@ -287,6 +454,9 @@ func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[kue
 		Active:     apimeta.IsStatusConditionTrue(ac.Status.Conditions, kueue.AdmissionCheckActive),
 		Controller: ac.Spec.ControllerName,
 	}
+	if ac.Spec.ReservationHoldSeconds != nil {
+		newAC.ReservationHold = time.Duration(*ac.Spec.ReservationHoldSeconds) * time.Second
+	}
 	c.admissionChecks[kueue.AdmissionCheckReference(ac.Name)] = newAC
 
 	return c.updateClusterQueues()
@@ -315,10 +485,39 @@ func (c *Cache) AdmissionChecksForClusterQueue(cqName kueue.ClusterQueueReferenc
 	return acs
 }
 
+// AdmissionCheckController returns the name of the controller of the
+// admission check, or an empty string if the admission check is not known.
+func (c *Cache) AdmissionCheckController(name kueue.AdmissionCheckReference) string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.admissionChecks[name].Controller
+}
+
+// ReservationHolds returns the periods during which the admission checks of
+// the ClusterQueue hold the quota reservation of its workloads.
+func (c *Cache) ReservationHolds(cqName kueue.ClusterQueueReference) map[kueue.AdmissionCheckReference]time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return nil
+	}
+	return maps.Clone(cq.reservationHolds)
+}
+
 func (c *Cache) ClusterQueueActive(name kueue.ClusterQueueReference) bool {
 	return c.clusterQueueInStatus(name, active)
 }
 
+// LimitsConcurrentChecks returns whether the ClusterQueue limits the number
+// of workloads in the admission checks phase at the same time.
+func (c *Cache) LimitsConcurrentChecks(name kueue.ClusterQueueReference) bool {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueue(name)
+	return cq != nil && cq.MaxConcurrentChecks != nil
+}
+
 func (c *Cache) ClusterQueueTerminating(name kueue.ClusterQueueReference) bool {
 	return c.clusterQueueInStatus(name, terminating)
 }
@@ -373,6 +572,7 @@ func (c *Cache) ClusterQueueEmpty(name kueue.ClusterQueueReference) bool {
 func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncFallbackCohorts()
 
 	if oldCq := c.hm.ClusterQueue(kueue.ClusterQueueReference(cq.Name)); oldCq != nil {
 		return errors.New("ClusterQueue already exists")
@@ -399,6 +599,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 			admittedUsage:      make(resources.FlavorResourceQuantities),
 		}
 		qImpl.resetFlavorsAndResources(cqImpl.resourceNode.Usage, cqImpl.AdmittedUsage)
+		qImpl.setResourceLimits(q.Spec.ResourceLimits)
 		cqImpl.localQueues[qKey] = qImpl
 	}
 	var workloads kueue.WorkloadList
@@ -418,6 +619,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncFallbackCohorts()
 	cqImpl := c.hm.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
 	if cqImpl == nil {
 		return ErrCqNotFound
@@ -439,6 +641,7 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 func (c *Cache) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncFallbackCohorts()
 	cqName := kueue.ClusterQueueReference(cq.Name)
 	curCq := c.hm.ClusterQueue(cqName)
 	if curCq == nil {
@@ -460,6 +663,7 @@ func (c *Cache) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncFallbackCohorts()
 	cohortName := kueue.CohortReference(apiCohort.Name)
 	c.hm.AddCohort(cohortName)
 	cohort := c.hm.Cohort(cohortName)
@@ -471,6 +675,7 @@ func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 func (c *Cache) DeleteCohort(cohortName kueue.CohortReference) {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncFallbackCohorts()
 	c.hm.DeleteCohort(cohortName)
 
 	// If the cohort still exists after deletion, it means
@@ -481,6 +686,36 @@ func (c *Cache) DeleteCohort(cohortName kueue.CohortReference) {
 	}
 }
 
+// syncFallbackCohorts resolves the fallback cohorts of the ClusterQueues, and
+// charges them the usage of the workloads admitted using their quota. It needs
+// to be called when the ClusterQueues or the Cohorts change.
+func (c *Cache) syncFallbackCohorts() {
+	affected := sets.New[*cohort]()
+	for _, cohort := range c.hm.Cohorts() {
+		if len(cohort.fallbackUsage) > 0 {
+			clear(cohort.fallbackUsage)
+			affected.Insert(cohort)
+		}
+	}
+	for _, cq := range c.hm.ClusterQueues() {
+		cq.fallbackCohort = nil
+		if cq.FallbackCohort == "" {
+			continue
+		}
+		fallbackCohort := c.hm.Cohort(cq.FallbackCohort)
+		if fallbackCohort == nil || hierarchy.HasCycle(fallbackCohort) {
+			continue
+		}
+		cq.fallbackCohort = fallbackCohort
+		updateFlavorUsage(cq.fallbackUsage, fallbackCohort.fallbackUsage, 1)
+		affected.Insert(fallbackCohort)
+	}
+	for cohort := range affected {
+		// ignore error when the Cohort has cycle.
+		_ = updateCohortTreeResources(cohort)
+	}
+}
+
 func (c *Cache) AddLocalQueue(q *kueue.LocalQueue) error {
 	c.Lock()
 	defer c.Unlock()
@@ -515,11 +750,16 @@ func (c *Cache) GetCacheLocalQueue(cqName kueue.ClusterQueueReference, lq *kueue
 }
 
 func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
+	c.Lock()
+	defer c.Unlock()
 	if oldQ.Spec.ClusterQueue == newQ.Spec.ClusterQueue {
+		if cq := c.hm.ClusterQueue(newQ.Spec.ClusterQueue); cq != nil {
+			if lq, ok := cq.localQueues[queueKey(newQ)]; ok {
+				lq.setResourceLimits(newQ.Spec.ResourceLimits)
+			}
+		}
 		return nil
 	}
-	c.Lock()
-	defer c.Unlock()
 	cq := c.hm.ClusterQueue(oldQ.Spec.ClusterQueue)
 	if cq != nil {
 		cq.deleteLocalQueue(oldQ)
@@ -686,15 +926,17 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		return nil, ErrCqNotFound
 	}
 
+	reserved := maps.Clone(cq.resourceNode.Usage)
+	updateFlavorUsage(cq.fallbackUsage, reserved, 1)
 	stats := &ClusterQueueUsageStats{
-		ReservedResources:  getUsage(cq.resourceNode.Usage, cq),
+		ReservedResources:  getUsage(reserved, cq),
 		ReservingWorkloads: len(cq.Workloads),
 		AdmittedResources:  getUsage(cq.AdmittedUsage, cq),
 		AdmittedWorkloads:  cq.admittedWorkloadsCount,
 	}
 
 	if c.fairSharingEnabled {
-		weightedShare, _ := dominantResourceShare(cq, nil)
+		weightedShare, _ := dominantResourceShare(cq, nil, c.fairSharingWeights, c.fairSharingShareMode)
 		stats.WeightedShare = int64(weightedShare)
 	}
 
@@ -716,13 +958,100 @@ func (c *Cache) CohortStats(cohortObj *kueuealpha.Cohort) (*CohortUsageStats, er
 
 	stats := &CohortUsageStats{}
 	if c.fairSharingEnabled {
-		weightedShare, _ := dominantResourceShare(cohort, nil)
+		weightedShare, _ := dominantResourceShare(cohort, nil, c.fairSharingWeights, c.fairSharingShareMode)
 		stats.WeightedShare = int64(weightedShare)
 	}
 
 	return stats, nil
 }
 
+// CohortResourceStats holds the aggregated resources of the subtree of a Cohort.
+type CohortResourceStats struct {
+	NominalQuota  resources.FlavorResourceQuantities
+	AdmittedUsage resources.FlavorResourceQuantities
+}
+
+// CohortTreeResourceStats returns the CohortResourceStats of the Cohort and of
+// each of its ancestors, keyed by the Cohort name. It returns nil if the Cohort
+// doesn't exist, and ErrCohortHasCycle if the Cohort is part of a cycle.
+func (c *Cache) CohortTreeResourceStats(name kueue.CohortReference) (map[kueue.CohortReference]CohortResourceStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cohort := c.hm.Cohort(name)
+	if cohort == nil {
+		return nil, nil
+	}
+	if hierarchy.HasCycle(cohort) {
+		return nil, ErrCohortHasCycle
+	}
+
+	stats := make(map[kueue.CohortReference]CohortResourceStats)
+	for ancestor := range cohort.PathSelfToRoot() {
+		s := CohortResourceStats{
+			NominalQuota:  make(resources.FlavorResourceQuantities),
+			AdmittedUsage: make(resources.FlavorResourceQuantities),
+		}
+		ancestor.addSubtreeResources(s.NominalQuota, s.AdmittedUsage)
+		stats[ancestor.Name] = s
+	}
+	return stats, nil
+}
+
+// CohortTreeNode is a Cohort in a tree of Cohorts, with the aggregated
+// resources of its subtree.
+type CohortTreeNode struct {
+	Name          kueue.CohortReference
+	Parent        kueue.CohortReference
+	ClusterQueues []kueue.ClusterQueueReference
+	Children      []CohortTreeNode
+	CohortResourceStats
+}
+
+// CohortTree returns the tree of Cohorts which the Cohort belongs to, starting
+// from its root. It returns ErrCohortNotFound if the Cohort doesn't exist, and
+// ErrCohortHasCycle if the Cohort is part of a cycle.
+func (c *Cache) CohortTree(name kueue.CohortReference) (*CohortTreeNode, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cohort := c.hm.Cohort(name)
+	if cohort == nil {
+		return nil, ErrCohortNotFound
+	}
+	if hierarchy.HasCycle(cohort) {
+		return nil, ErrCohortHasCycle
+	}
+	root := newCohortTreeNode(cohort.getRootUnsafe())
+	return &root, nil
+}
+
+func newCohortTreeNode(c *cohort) CohortTreeNode {
+	node := CohortTreeNode{
+		Name: c.Name,
+		CohortResourceStats: CohortResourceStats{
+			NominalQuota:  make(resources.FlavorResourceQuantities),
+			AdmittedUsage: make(resources.FlavorResourceQuantities),
+		},
+	}
+	if c.HasParent() {
+		node.Parent = c.Parent().Name
+	}
+	c.addSubtreeResources(node.NominalQuota, node.AdmittedUsage)
+	for _, cq := range c.ChildCQs() {
+		node.ClusterQueues = append(node.ClusterQueues, cq.Name)
+	}
+	slices.Sort(node.ClusterQueues)
+	children := c.ChildCohorts()
+	slices.SortFunc(children, func(a, b *cohort) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+	for _, child := range children {
+		node.Children = append(node.Children, newCohortTreeNode(child))
+	}
+	return node
+}
+
 // ClusterQueueAncestors returns all ancestors (Cohorts), excluding the root,
 // for a given ClusterQueue. If the ClusterQueue contains a Cohort cycle, it
 // returns ErrCohortHasCycle.
@@ -767,8 +1096,8 @@ func getUsage(frq resources.FlavorResourceQuantities, cq *clusterQueue) []kueue.
 					Name:  rName,
 					Total: resources.ResourceQuantity(rName, used),
 				}
-				// Enforce `borrowed=0` if the clusterQueue doesn't belong to a cohort.
-				if cq.HasParent() {
+				// Enforce `borrowed=0` if the clusterQueue can't borrow from a cohort.
+				if cq.HasParent() || cq.FallbackCohort != "" {
 					borrowed := used - rQuota.Nominal
 					if borrowed > 0 {
 						rUsage.Borrowed = resources.ResourceQuantity(rName, borrowed)
@@ -786,6 +1115,85 @@ func getUsage(frq resources.FlavorResourceQuantities, cq *clusterQueue) []kueue.
 	return usage
 }
 
+// BorrowingWorkload is a workload with quota reserved in a ClusterQueue,
+// which uses quota borrowed from the cohort.
+type BorrowingWorkload struct {
+	Info *workload.Info
+	// Usage is the quota reserved by the workload.
+	Usage resources.FlavorResourceQuantities
+	// Borrowed is the part of the usage which is above the nominal quota
+	// of the ClusterQueue.
+	Borrowed resources.FlavorResourceQuantities
+}
+
+// BorrowingWorkloads returns the workloads with quota reserved in the
+// ClusterQueue which use quota borrowed from the cohort, in the order in
+// which they got the quota reserved.
+// The usage above the nominal quota of a flavor and resource is attributed
+// to the workloads which got the quota reserved last. The workloads admitted
+// using the quota of the fallback cohort borrow all their usage.
+func (c *Cache) BorrowingWorkloads(cqName kueue.ClusterQueueReference) ([]BorrowingWorkload, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return nil, ErrCqNotFound
+	}
+	if !cq.HasParent() && cq.FallbackCohort == "" {
+		return nil, nil
+	}
+
+	infos := slices.Collect(maps.Values(cq.Workloads))
+	slices.SortFunc(infos, func(a, b *workload.Info) int {
+		if c := compareQuotaReservationTime(a.Obj, b.Obj); c != 0 {
+			return c
+		}
+		return strings.Compare(workload.Key(a.Obj), workload.Key(b.Obj))
+	})
+
+	var result []BorrowingWorkload
+	used := make(resources.FlavorResourceQuantities)
+	for _, wi := range infos {
+		usage := wi.FlavorResourceUsage()
+		borrowed := make(resources.FlavorResourceQuantities)
+		for fr, q := range usage {
+			if wi.UsesFallbackCohort() {
+				if q > 0 {
+					borrowed[fr] = q
+				}
+				continue
+			}
+			previous := used[fr]
+			used[fr] += q
+			if b := used[fr] - max(previous, cq.resourceNode.Quotas[fr].Nominal); b > 0 {
+				borrowed[fr] = b
+			}
+		}
+		if len(borrowed) > 0 {
+			result = append(result, BorrowingWorkload{Info: wi, Usage: usage, Borrowed: borrowed})
+		}
+	}
+	return result, nil
+}
+
+// compareQuotaReservationTime compares the times at which the workloads got
+// the quota reserved. The workloads which don't have the QuotaReserved
+// condition yet, like the assumed ones, are considered the most recent.
+func compareQuotaReservationTime(a, b *kueue.Workload) int {
+	condA := apimeta.FindStatusCondition(a.Status.Conditions, kueue.WorkloadQuotaReserved)
+	condB := apimeta.FindStatusCondition(b.Status.Conditions, kueue.WorkloadQuotaReserved)
+	switch {
+	case condA == nil && condB == nil:
+		return 0
+	case condA == nil:
+		return 1
+	case condB == nil:
+		return -1
+	}
+	return condA.LastTransitionTime.Compare(condB.LastTransitionTime.Time)
+}
+
 type LocalQueueUsageStats struct {
 	ReservedResources  []kueue.LocalQueueFlavorUsage
 	ReservingWorkloads int
@@ -918,6 +1326,36 @@ func (c *Cache) ClusterQueuesUsingFlavor(flavor kueue.ResourceFlavorReference) [
 	return cqs
 }
 
+// WorkloadsUsingFlavor returns the workloads with quota reserved using the
+// flavor.
+func (c *Cache) WorkloadsUsingFlavor(flavor kueue.ResourceFlavorReference) []*workload.Info {
+	c.RLock()
+	defer c.RUnlock()
+	var result []*workload.Info
+	for _, cq := range c.hm.ClusterQueues() {
+		for _, wi := range cq.Workloads {
+			if workloadUsesFlavor(wi.Obj, flavor) {
+				result = append(result, wi)
+			}
+		}
+	}
+	return result
+}
+
+func workloadUsesFlavor(wl *kueue.Workload, flavor kueue.ResourceFlavorReference) bool {
+	if wl.Status.Admission == nil {
+		return false
+	}
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, f := range psa.Flavors {
+			if f == flavor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (c *Cache) ClusterQueuesUsingTopology(tName kueue.TopologyReference) []kueue.ClusterQueueReference {
 	c.RLock()
 	defer c.RUnlock()
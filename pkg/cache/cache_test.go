@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -40,9 +41,12 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -3902,3 +3906,466 @@ func TestClusterQueueAncestors(t *testing.T) {
 		})
 	}
 }
+
+func TestCohortTreeResourceStats(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	testCases := map[string]struct {
+		cohorts   []*kueuealpha.Cohort
+		cqs       []*kueue.ClusterQueue
+		workloads []*kueue.Workload
+		cohort    kueue.CohortReference
+		want      map[kueue.CohortReference]CohortResourceStats
+		wantErr   error
+	}{
+		"cohort not found": {
+			cohort: "team",
+		},
+		"two queues in a nested cohort": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("root").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				utiltesting.MakeCohort("team").Parent("root").Obj(),
+			},
+			cqs: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq-a").
+					Cohort("team").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("cq-b").
+					Cohort("team").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+					Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl-a", "ns").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Admitted(true).
+					Obj(),
+				utiltesting.MakeWorkload("wl-b", "ns").
+					Request(corev1.ResourceCPU, "5").
+					ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
+					Admitted(true).
+					Obj(),
+				utiltesting.MakeWorkload("wl-pending-checks", "ns").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+			},
+			cohort: "team",
+			want: map[kueue.CohortReference]CohortResourceStats{
+				"team": {
+					NominalQuota:  resources.FlavorResourceQuantities{cpu: 16_000},
+					AdmittedUsage: resources.FlavorResourceQuantities{cpu: 8_000},
+				},
+				"root": {
+					NominalQuota:  resources.FlavorResourceQuantities{cpu: 20_000},
+					AdmittedUsage: resources.FlavorResourceQuantities{cpu: 8_000},
+				},
+			},
+		},
+		"with cycle": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("root").Parent("team").Obj(),
+				utiltesting.MakeCohort("team").Parent("root").Obj(),
+			},
+			cohort:  "team",
+			wantErr: ErrCohortHasCycle,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			for _, cohort := range tc.cohorts {
+				_ = cache.AddOrUpdateCohort(cohort)
+			}
+			for _, cq := range tc.cqs {
+				if err := cache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue: %v", err)
+				}
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+
+			got, gotErr := cache.CohortTreeResourceStats(tc.cohort)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected stats (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFallbackCohortUsage(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	testCases := map[string]struct {
+		cqs       []*kueue.ClusterQueue
+		workloads []*kueue.Workload
+		cohorts   []*kueuealpha.Cohort
+		// deleteCohorts are deleted after adding the cohorts.
+		deleteCohorts []kueue.CohortReference
+
+		wantCQUsage             resources.FlavorResourceQuantities
+		wantFallbackUsage       resources.FlavorResourceQuantities
+		wantFallbackAvailable   int64
+		wantReservedCPUBorrowed string
+	}{
+		"usage of the workloads admitted using the fallback cohort is charged to it": {
+			cqs: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("primary-a").
+					Cohort("primary").
+					FallbackCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("spare").
+					Cohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl-primary", "ns").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+					Obj(),
+				utiltesting.MakeWorkload("wl-fallback", "ns").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").
+						Assignment(corev1.ResourceCPU, "default", "3").
+						FallbackCohort("fallback").
+						Obj()).
+					Obj(),
+			},
+			wantCQUsage:             resources.FlavorResourceQuantities{cpu: 4_000},
+			wantFallbackUsage:       resources.FlavorResourceQuantities{cpu: 3_000},
+			wantFallbackAvailable:   7_000,
+			wantReservedCPUBorrowed: "3",
+		},
+		"fallback cohort created after the workloads are added": {
+			cqs: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("primary-a").
+					FallbackCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl-fallback", "ns").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").
+						Assignment(corev1.ResourceCPU, "default", "6").
+						FallbackCohort("fallback").
+						Obj()).
+					Obj(),
+			},
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+					Obj(),
+			},
+			wantCQUsage:             resources.FlavorResourceQuantities{},
+			wantFallbackUsage:       resources.FlavorResourceQuantities{cpu: 6_000},
+			wantFallbackAvailable:   2_000,
+			wantReservedCPUBorrowed: "2",
+		},
+		"fallback cohort deleted": {
+			cqs: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("primary-a").
+					FallbackCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl-fallback", "ns").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").
+						Assignment(corev1.ResourceCPU, "default", "6").
+						FallbackCohort("fallback").
+						Obj()).
+					Obj(),
+			},
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+					Obj(),
+			},
+			deleteCohorts:           []kueue.CohortReference{"fallback"},
+			wantCQUsage:             resources.FlavorResourceQuantities{},
+			wantReservedCPUBorrowed: "2",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range tc.cqs {
+				if err := cache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue: %v", err)
+				}
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			for _, cohort := range tc.cohorts {
+				if err := cache.AddOrUpdateCohort(cohort); err != nil {
+					t.Fatalf("Failed adding Cohort: %v", err)
+				}
+			}
+			for _, cohort := range tc.deleteCohorts {
+				cache.DeleteCohort(cohort)
+			}
+
+			snapshot, err := cache.Snapshot(t.Context())
+			if err != nil {
+				t.Fatalf("Failed taking the snapshot: %v", err)
+			}
+			cq := snapshot.ClusterQueue("primary-a")
+			if diff := cmp.Diff(tc.wantCQUsage, cq.ResourceNode.Usage, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected ClusterQueue usage (-want/+got)\n%s", diff)
+			}
+			var gotFallbackUsage resources.FlavorResourceQuantities
+			if cq.FallbackCohort != nil {
+				gotFallbackUsage = cq.FallbackCohort.ResourceNode.Usage
+			}
+			if diff := cmp.Diff(tc.wantFallbackUsage, gotFallbackUsage, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected fallback cohort usage (-want/+got)\n%s", diff)
+			}
+			if got := cq.FallbackAvailable(cpu); got != tc.wantFallbackAvailable {
+				t.Errorf("Unexpected available quota in the fallback cohort, want %d, got %d", tc.wantFallbackAvailable, got)
+			}
+
+			stats, err := cache.Usage(utiltesting.MakeClusterQueue("primary-a").Obj())
+			if err != nil {
+				t.Fatalf("Failed getting the usage: %v", err)
+			}
+			gotBorrowed := stats.ReservedResources[0].Resources[0].Borrowed
+			if diff := cmp.Diff(resource.MustParse(tc.wantReservedCPUBorrowed), gotBorrowed); diff != "" {
+				t.Errorf("Unexpected borrowed quota (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBorrowingWorkloads(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	now := time.Now().Truncate(time.Second)
+	cqs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			Cohort("one").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("one").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("primary").
+			FallbackCohort("one").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("standalone").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("third", "ns").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now.Add(2*time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("first", "ns").
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now).
+			Obj(),
+		utiltesting.MakeWorkload("second", "ns").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now.Add(time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("within-nominal", "ns").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now).
+			Obj(),
+		utiltesting.MakeWorkload("primary", "ns").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuotaAt(utiltesting.MakeAdmission("primary").Assignment(corev1.ResourceCPU, "default", "4").Obj(), now).
+			Obj(),
+		utiltesting.MakeWorkload("fallback", "ns").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltesting.MakeAdmission("primary").
+				Assignment(corev1.ResourceCPU, "default", "1").
+				FallbackCohort("one").
+				Obj(), now.Add(time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("standalone", "ns").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuotaAt(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "4").Obj(), now).
+			Obj(),
+	}
+
+	type borrowingWorkload struct {
+		Key      string
+		Usage    resources.FlavorResourceQuantities
+		Borrowed resources.FlavorResourceQuantities
+	}
+	testCases := map[string]struct {
+		clusterQueue kueue.ClusterQueueReference
+		want         []borrowingWorkload
+		wantErr      error
+	}{
+		"the workloads which got the quota reserved last borrow": {
+			clusterQueue: "cq-a",
+			want: []borrowingWorkload{
+				{
+					Key:      "ns/second",
+					Usage:    resources.FlavorResourceQuantities{cpu: 2_000},
+					Borrowed: resources.FlavorResourceQuantities{cpu: 1_000},
+				},
+				{
+					Key:      "ns/third",
+					Usage:    resources.FlavorResourceQuantities{cpu: 2_000},
+					Borrowed: resources.FlavorResourceQuantities{cpu: 2_000},
+				},
+			},
+		},
+		"workloads within the nominal quota": {
+			clusterQueue: "cq-b",
+		},
+		"workloads admitted using the fallback cohort borrow all their usage": {
+			clusterQueue: "primary",
+			want: []borrowingWorkload{
+				{
+					Key:      "ns/fallback",
+					Usage:    resources.FlavorResourceQuantities{cpu: 1_000},
+					Borrowed: resources.FlavorResourceQuantities{cpu: 1_000},
+				},
+			},
+		},
+		"ClusterQueue without cohort": {
+			clusterQueue: "standalone",
+		},
+		"ClusterQueue not found": {
+			clusterQueue: "missing",
+			wantErr:      ErrCqNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range cqs {
+				if err := cache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue: %v", err)
+				}
+			}
+			for _, wl := range workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+
+			got, err := cache.BorrowingWorkloads(tc.clusterQueue)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			var gotWorkloads []borrowingWorkload
+			for _, bw := range got {
+				gotWorkloads = append(gotWorkloads, borrowingWorkload{
+					Key:      workload.Key(bw.Info.Obj),
+					Usage:    bw.Usage,
+					Borrowed: bw.Borrowed,
+				})
+			}
+			if diff := cmp.Diff(tc.want, gotWorkloads); diff != "" {
+				t.Errorf("Unexpected borrowing workloads (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceFlavorNodesMetric(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	gpuFlavor := utiltesting.MakeResourceFlavor("gpu").NodeLabel("accelerator", "gpu").Obj()
+	defaultFlavor := utiltesting.MakeResourceFlavor("default").Obj()
+	cache.AddOrUpdateResourceFlavor(gpuFlavor)
+	cache.AddOrUpdateResourceFlavor(defaultFlavor)
+
+	steps := []struct {
+		name      string
+		update    func()
+		wantNodes map[kueue.ResourceFlavorReference]float64
+	}{
+		{
+			name:   "no nodes",
+			update: func() {},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"gpu":     0,
+				"default": 0,
+			},
+		},
+		{
+			name: "nodes added",
+			update: func() {
+				cache.AddOrUpdateNode(testingnode.MakeNode("gpu-1").Label("accelerator", "gpu").Obj())
+				cache.AddOrUpdateNode(testingnode.MakeNode("gpu-2").Label("accelerator", "gpu").Obj())
+				cache.AddOrUpdateNode(testingnode.MakeNode("cpu-1").Obj())
+			},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"gpu":     2,
+				"default": 3,
+			},
+		},
+		{
+			name: "node relabeled",
+			update: func() {
+				cache.AddOrUpdateNode(testingnode.MakeNode("gpu-2").Label("accelerator", "tpu").Obj())
+			},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"gpu":     1,
+				"default": 3,
+			},
+		},
+		{
+			name: "node removed",
+			update: func() {
+				cache.DeleteNode(testingnode.MakeNode("gpu-1").Obj())
+			},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"gpu":     0,
+				"default": 2,
+			},
+		},
+		{
+			name: "flavor updated",
+			update: func() {
+				cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("gpu").NodeLabel("accelerator", "tpu").Obj())
+			},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"gpu":     1,
+				"default": 2,
+			},
+		},
+		{
+			name: "flavor removed",
+			update: func() {
+				cache.DeleteResourceFlavor(gpuFlavor)
+			},
+			wantNodes: map[kueue.ResourceFlavorReference]float64{
+				"default": 2,
+			},
+		},
+	}
+	for _, step := range steps {
+		step.update()
+		for _, flavor := range []kueue.ResourceFlavorReference{"gpu", "default"} {
+			var wantDPs []testingmetrics.MetricDataPoint
+			if nodes, found := step.wantNodes[flavor]; found {
+				wantDPs = []testingmetrics.MetricDataPoint{{
+					Labels: map[string]string{"flavor": string(flavor)},
+					Value:  nodes,
+				}}
+			}
+			gotDPs := testingmetrics.CollectFilteredGaugeVec(metrics.ResourceFlavorNodes, map[string]string{"flavor": string(flavor)})
+			if diff := cmp.Diff(wantDPs, gotDPs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s: Unexpected nodes metric for flavor %q (-want,+got):\n%s", step.name, flavor, diff)
+			}
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -58,11 +59,23 @@ type clusterQueue struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	// ResourceOverheads are added to the requests of the pods of the
+	// pending workloads, for quota accounting.
+	ResourceOverheads []kueue.ResourceOverhead
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
 	AdmissionChecks map[kueue.AdmissionCheckReference]sets.Set[kueue.ResourceFlavorReference]
-	Status          metrics.ClusterQueueStatus
+	// MaxConcurrentChecks is the maximum number of workloads which can be in
+	// the admission checks phase at the same time, or nil if not limited.
+	MaxConcurrentChecks *int32
+	// MinAdmissionInterval is the minimum time between two consecutive
+	// quota reservations in the ClusterQueue, or zero if not limited.
+	MinAdmissionInterval time.Duration
+	// BorrowingMinPriority is the minimum priority of the workloads which
+	// can borrow quota from the cohort, or nil if not limited.
+	BorrowingMinPriority *int32
+	Status               metrics.ClusterQueueStatus
 	// AllocatableResourceGeneration will be increased when some admitted workloads are
 	// deleted, or the resource groups are changed.
 	AllocatableResourceGeneration int64
@@ -77,14 +90,27 @@ type clusterQueue struct {
 	multiKueueAdmissionChecks          []kueue.AdmissionCheckReference
 	provisioningAdmissionChecks        []kueue.AdmissionCheckReference
 	perFlavorMultiKueueAdmissionChecks []kueue.AdmissionCheckReference
-	tasFlavors                         map[kueue.ResourceFlavorReference]kueue.TopologyReference
-	admittedWorkloadsCount             int
-	isStopped                          bool
-	workloadInfoOptions                []workload.InfoOption
+	// reservationHolds holds the admission checks of the ClusterQueue which
+	// hold the quota reservation of the workloads, with their hold period.
+	reservationHolds       map[kueue.AdmissionCheckReference]time.Duration
+	tasFlavors             map[kueue.ResourceFlavorReference]kueue.TopologyReference
+	admittedWorkloadsCount int
+	isStopped              bool
+	workloadInfoOptions    []workload.InfoOption
+	// backgroundReservations are subtracted from the nominal quota.
+	backgroundReservations resources.FlavorResourceQuantities
 
 	resourceNode resourceNode
 	hierarchy.ClusterQueue[*cohort]
 
+	// FallbackCohort is the name of the cohort the ClusterQueue can borrow
+	// from, once the quota available in its cohort is exhausted.
+	FallbackCohort kueue.CohortReference
+	// fallbackUsage is the usage of the workloads admitted using the quota
+	// of the fallback cohort. It is charged to fallbackCohort, if it exists.
+	fallbackUsage  resources.FlavorResourceQuantities
+	fallbackCohort *cohort
+
 	tasCache *TASCache
 }
 
@@ -136,10 +162,14 @@ func (c *clusterQueue) updateClusterQueue(in *kueue.ClusterQueue, resourceFlavor
 		return err
 	}
 	c.NamespaceSelector = nsSelector
+	c.ResourceOverheads = in.Spec.ResourceOverheads
 
 	c.isStopped = ptr.Deref(in.Spec.StopPolicy, kueue.None) != kueue.None
 
 	c.AdmissionChecks = admissioncheck.NewAdmissionChecks(in)
+	c.MaxConcurrentChecks = in.Spec.MaxConcurrentChecks
+	c.MinAdmissionInterval = time.Duration(ptr.Deref(in.Spec.MinAdmissionIntervalSeconds, 0)) * time.Second
+	c.BorrowingMinPriority = in.Spec.BorrowingMinPriority
 
 	if in.Spec.Preemption != nil {
 		c.Preemption = *in.Spec.Preemption
@@ -163,6 +193,7 @@ func (c *clusterQueue) updateClusterQueue(in *kueue.ClusterQueue, resourceFlavor
 	}
 
 	c.FairWeight = parseFairWeight(in.Spec.FairSharing)
+	c.FallbackCohort = in.Spec.FallbackCohort
 
 	return nil
 }
@@ -188,6 +219,7 @@ func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) b
 	oldQuotas := c.resourceNode.Quotas
 	c.ResourceGroups = createdResourceGroups(in)
 	c.resourceNode.Quotas = createResourceQuotas(in)
+	applyBackgroundReservations(c.resourceNode.Quotas, c.backgroundReservations)
 
 	// Start at 1, for backwards compatibility.
 	return c.AllocatableResourceGeneration == 0 ||
@@ -336,6 +368,7 @@ func (c *clusterQueue) updateWithAdmissionChecks(checks map[kueue.AdmissionCheck
 	var missing []kueue.AdmissionCheckReference
 	var inactive []kueue.AdmissionCheckReference
 	var perFlavorMultiKueueChecks []kueue.AdmissionCheckReference
+	var reservationHolds map[kueue.AdmissionCheckReference]time.Duration
 	for acName, flavors := range c.AdmissionChecks {
 		if ac, found := checks[acName]; !found {
 			missing = append(missing, acName)
@@ -343,6 +376,12 @@ func (c *clusterQueue) updateWithAdmissionChecks(checks map[kueue.AdmissionCheck
 			if !ac.Active {
 				inactive = append(inactive, acName)
 			}
+			if ac.ReservationHold > 0 {
+				if reservationHolds == nil {
+					reservationHolds = make(map[kueue.AdmissionCheckReference]time.Duration)
+				}
+				reservationHolds[acName] = ac.ReservationHold
+			}
 			checksPerController[ac.Controller] = append(checksPerController[ac.Controller], acName)
 			if ac.Controller == kueue.ProvisioningRequestControllerName {
 				provisioningAdmissionChecks.Insert(acName)
@@ -365,6 +404,7 @@ func (c *clusterQueue) updateWithAdmissionChecks(checks map[kueue.AdmissionCheck
 	slices.Sort(perFlavorMultiKueueChecks)
 	multiKueueChecks := sets.List(multiKueueAdmissionChecks)
 	provisioningChecks := sets.List(provisioningAdmissionChecks)
+	c.reservationHolds = reservationHolds
 
 	update := false
 	if !slices.Equal(c.missingAdmissionChecks, missing) {
@@ -456,12 +496,16 @@ func (q *LocalQueue) reportActiveWorkloads() {
 func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	admitted := workload.IsAdmitted(wi.Obj)
 	frUsage := wi.FlavorResourceUsage()
-	for fr, q := range frUsage {
-		if m == 1 {
-			addUsage(c, fr, q)
-		}
-		if m == -1 {
-			removeUsage(c, fr, q)
+	if wi.UsesFallbackCohort() {
+		c.updateFallbackUsage(frUsage, m)
+	} else {
+		for fr, q := range frUsage {
+			if m == 1 {
+				addUsage(c, fr, q)
+			}
+			if m == -1 {
+				removeUsage(c, fr, q)
+			}
 		}
 	}
 	if features.Enabled(features.TopologyAwareScheduling) && wi.IsUsingTAS() {
@@ -494,6 +538,24 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	}
 }
 
+// updateFallbackUsage updates the usage of the workloads admitted using the
+// quota of the fallback cohort, and charges it to the fallback cohort.
+func (c *clusterQueue) updateFallbackUsage(frUsage resources.FlavorResourceQuantities, m int64) {
+	updateFlavorUsage(frUsage, c.fallbackUsage, m)
+	if c.fallbackCohort == nil {
+		return
+	}
+	updateFlavorUsage(frUsage, c.fallbackCohort.fallbackUsage, m)
+	for fr, q := range frUsage {
+		if m == 1 {
+			addUsage(c.fallbackCohort, fr, q)
+		}
+		if m == -1 {
+			removeUsage(c.fallbackCohort, fr, q)
+		}
+	}
+}
+
 func (c *clusterQueue) tasFlavorCache(flvName kueue.ResourceFlavorReference) *TASFlavorCache {
 	if !features.Enabled(features.TopologyAwareScheduling) {
 		return nil
@@ -523,6 +585,7 @@ func (c *clusterQueue) addLocalQueue(q *kueue.LocalQueue) error {
 		totalReserved:      make(resources.FlavorResourceQuantities),
 	}
 	qImpl.resetFlavorsAndResources(c.resourceNode.Usage, c.AdmittedUsage)
+	qImpl.setResourceLimits(q.Spec.ResourceLimits)
 	for _, wl := range c.Workloads {
 		if workloadBelongsToLocalQueue(wl.Obj, q) {
 			frq := wl.FlavorResourceUsage()
@@ -17,19 +17,25 @@ limitations under the License.
 package cache
 
 import (
+	"fmt"
 	"iter"
+	"maps"
+	"slices"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
-	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -42,6 +48,15 @@ type ClusterQueueSnapshot struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	// fairSharingWeights are the weights of the resources in the dominant
+	// resource share computation.
+	fairSharingWeights map[corev1.ResourceName]float64
+	// fairSharingShareMode is how the weighted shares of the resources are
+	// combined in the dominant resource share computation.
+	fairSharingShareMode config.FairSharingShareMode
+	// ResourceOverheads are added to the requests of the pods of the
+	// pending workloads, for quota accounting.
+	ResourceOverheads []kueue.ResourceOverhead
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -54,8 +69,87 @@ type ClusterQueueSnapshot struct {
 	ResourceNode resourceNode
 	hierarchy.ClusterQueue[*CohortSnapshot]
 
+	// FallbackCohort is the cohort the ClusterQueue can borrow from, once
+	// the quota available in its cohort is exhausted.
+	FallbackCohort *CohortSnapshot
+
 	TASFlavors map[kueue.ResourceFlavorReference]*TASFlavorSnapshot
 	tasOnly    bool
+
+	// LocalQueues holds the LocalQueues of the ClusterQueue which have
+	// resource limits.
+	LocalQueues map[queue.LocalQueueReference]*LocalQueueSnapshot
+
+	// ReservationHolds holds the admission checks of the ClusterQueue which
+	// hold the quota reservation of the workloads, with their hold period.
+	ReservationHolds map[kueue.AdmissionCheckReference]time.Duration
+
+	// MaxConcurrentChecks is the maximum number of workloads which can be in
+	// the admission checks phase at the same time, or nil if not limited.
+	MaxConcurrentChecks *int32
+	// workloadsInChecks is the number of workloads in the admission checks
+	// phase, when MaxConcurrentChecks is set.
+	workloadsInChecks int32
+
+	// MinAdmissionInterval is the minimum time between two consecutive
+	// quota reservations in the ClusterQueue, or zero if not limited.
+	MinAdmissionInterval time.Duration
+
+	// BorrowingMinPriority is the minimum priority of the workloads which
+	// can borrow quota from the cohort, or nil if not limited.
+	BorrowingMinPriority *int32
+
+	// PendingScheduledQuota is the quota scheduled to become available in
+	// the future, which is withheld from the quota of the ClusterQueue and
+	// of its cohort, see Snapshot.WithholdPendingScheduledQuotas.
+	PendingScheduledQuota resources.FlavorResourceQuantities
+}
+
+// UsePendingScheduledQuota gives back the part of the pending scheduled quota
+// used by a workload admitted to start at the start time of the scheduled
+// quota, and returns a function to revert it.
+func (c *ClusterQueueSnapshot) UsePendingScheduledQuota(quota resources.FlavorResourceQuantities) func() {
+	prev := c.PendingScheduledQuota
+	if len(prev) == 0 {
+		return func() {}
+	}
+	pending := maps.Clone(prev)
+	for fr, val := range quota {
+		if p, found := pending[fr]; found {
+			pending[fr] = p - min(p, val)
+		}
+	}
+	c.setPendingScheduledQuota(pending)
+	return func() {
+		c.setPendingScheduledQuota(prev)
+	}
+}
+
+func (c *ClusterQueueSnapshot) setPendingScheduledQuota(pending resources.FlavorResourceQuantities) {
+	c.PendingScheduledQuota = pending
+	if c.HasParent() {
+		withholdPendingScheduledQuotasInCohort(c.Parent().Root())
+	} else {
+		withholdPendingScheduledQuota(c)
+	}
+}
+
+// MayBorrow returns whether the workload may borrow quota from the cohort,
+// according to BorrowingMinPriority.
+func (c *ClusterQueueSnapshot) MayBorrow(wl *kueue.Workload) bool {
+	return c.BorrowingMinPriority == nil || priority.Priority(wl) >= *c.BorrowingMinPriority
+}
+
+// HasAdmissionCheckSlot returns whether another workload can enter the
+// admission checks phase, according to MaxConcurrentChecks.
+func (c *ClusterQueueSnapshot) HasAdmissionCheckSlot() bool {
+	return c.MaxConcurrentChecks == nil || c.workloadsInChecks < *c.MaxConcurrentChecks
+}
+
+// TakeAdmissionCheckSlot accounts for a workload entering the admission
+// checks phase.
+func (c *ClusterQueueSnapshot) TakeAdmissionCheckSlot() {
+	c.workloadsInChecks++
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -69,6 +163,55 @@ func (c *ClusterQueueSnapshot) RGByResource(resource corev1.ResourceName) *Resou
 	return nil
 }
 
+// LocalQueueLimitsMessage returns a message explaining why admitting the
+// requests, on behalf of the given LocalQueue, would go against the resource
+// limits of the LocalQueues of the ClusterQueue, or an empty string if the
+// requests can be admitted.
+func (c *ClusterQueueSnapshot) LocalQueueLimitsMessage(key queue.LocalQueueReference, requests resources.Requests) string {
+	if len(c.LocalQueues) == 0 {
+		return ""
+	}
+	lq := c.LocalQueues[key]
+	for _, r := range slices.Sorted(maps.Keys(requests)) {
+		val := requests[r]
+		if lq != nil {
+			if maxQuota, ok := lq.MaxQuota[r]; ok && lq.Usage[r]+val > maxQuota {
+				return fmt.Sprintf("the LocalQueue would exceed its maxQuota of %s for %s", resources.ResourceQuantityString(r, maxQuota), r)
+			}
+		}
+		var reserved int64
+		for otherKey, other := range c.LocalQueues {
+			if otherKey == key {
+				continue
+			}
+			if nominal, ok := other.NominalQuota[r]; ok {
+				reserved += max(0, nominal-other.Usage[r])
+			}
+		}
+		if reserved == 0 {
+			continue
+		}
+		var free int64
+		if rg := c.RGByResource(r); rg != nil {
+			for _, fName := range rg.Flavors {
+				free += c.Available(resources.FlavorResource{Flavor: fName, Resource: r})
+			}
+		}
+		if val > free-reserved {
+			return fmt.Sprintf("%s of the unused %s is reserved for the nominalQuota of other LocalQueues", resources.ResourceQuantityString(r, reserved), r)
+		}
+	}
+	return ""
+}
+
+// AddLocalQueueUsage adds the requests to the usage of the LocalQueue, if
+// the LocalQueue has resource limits.
+func (c *ClusterQueueSnapshot) AddLocalQueueUsage(key queue.LocalQueueReference, requests resources.Requests) {
+	if lq, ok := c.LocalQueues[key]; ok {
+		lq.Usage.Add(requests)
+	}
+}
+
 // SimulateWorkloadRemoval modifies the snapshot by removing the usage
 // corresponding to the list of workloads. It returns a function which
 // can be used to restore the usage.
@@ -107,6 +250,12 @@ func (c *ClusterQueueSnapshot) SimulateUsageRemoval(usage workload.Usage) func()
 
 func (c *ClusterQueueSnapshot) AddUsage(usage workload.Usage) {
 	for fr, q := range usage.Quota {
+		if usage.Fallback {
+			if c.FallbackCohort != nil {
+				addUsage(c.FallbackCohort, fr, q)
+			}
+			continue
+		}
 		addUsage(c, fr, q)
 	}
 	c.updateTASUsage(usage.TAS, add)
@@ -114,6 +263,12 @@ func (c *ClusterQueueSnapshot) AddUsage(usage workload.Usage) {
 
 func (c *ClusterQueueSnapshot) RemoveUsage(usage workload.Usage) {
 	for fr, q := range usage.Quota {
+		if usage.Fallback {
+			if c.FallbackCohort != nil {
+				removeUsage(c.FallbackCohort, fr, q)
+			}
+			continue
+		}
 		removeUsage(c, fr, q)
 	}
 	c.updateTASUsage(usage.TAS, subtract)
@@ -124,8 +279,7 @@ func (c *ClusterQueueSnapshot) updateTASUsage(usage workload.TASUsage, op usageO
 		for tasFlavor, tasUsage := range usage {
 			if tasFlvCache := c.TASFlavors[tasFlavor]; tasFlvCache != nil {
 				for _, tr := range tasUsage {
-					domainID := utiltas.DomainID(tr.Values)
-					tasFlvCache.updateTASUsage(domainID, tr.TotalRequests(), op, tr.Count)
+					tasFlvCache.updateTASUsage(tr, op)
 				}
 			}
 		}
@@ -134,7 +288,11 @@ func (c *ClusterQueueSnapshot) updateTASUsage(usage workload.TASUsage, op usageO
 
 func (c *ClusterQueueSnapshot) Fits(usage workload.Usage) bool {
 	for fr, q := range usage.Quota {
-		if c.Available(fr) < q {
+		available := c.Available(fr)
+		if usage.Fallback {
+			available = c.FallbackAvailable(fr)
+		}
+		if available < q {
 			return false
 		}
 	}
@@ -157,8 +315,11 @@ func (c *ClusterQueueSnapshot) Borrowing(fr resources.FlavorResource) bool {
 	return c.BorrowingWith(fr, 0)
 }
 
+// BorrowingWith returns whether the ClusterQueue would borrow from its Cohort
+// with val more usage. The overcommitted quota is not borrowed.
 func (c *ClusterQueueSnapshot) BorrowingWith(fr resources.FlavorResource, val int64) bool {
-	return c.ResourceNode.Usage[fr]+val > c.QuotaFor(fr).Nominal
+	quota := c.QuotaFor(fr)
+	return c.ResourceNode.Usage[fr]+val > addSaturating(quota.Nominal, quota.Overcommit)
 }
 
 // Available returns the current capacity available, before preempting
@@ -169,6 +330,26 @@ func (c *ClusterQueueSnapshot) Available(fr resources.FlavorResource) int64 {
 	return max(0, available(c, fr))
 }
 
+// FallbackAvailable returns the current capacity available in the fallback
+// cohort of the ClusterQueue, or 0 if the ClusterQueue has no fallback cohort.
+func (c *ClusterQueueSnapshot) FallbackAvailable(fr resources.FlavorResource) int64 {
+	if c.FallbackCohort == nil {
+		return 0
+	}
+	return max(0, available(c.FallbackCohort, fr))
+}
+
+// FallbackBorrowingHeight returns the borrowing height of the workloads
+// admitted using the quota of the fallback cohort, which is higher than
+// borrowing from any Cohort in the tree of the ClusterQueue.
+func (c *ClusterQueueSnapshot) FallbackBorrowingHeight() int {
+	height := 1
+	for range c.PathParentToRoot() {
+		height++
+	}
+	return height
+}
+
 // PotentialAvailable returns the largest workload this ClusterQueue could
 // possibly admit, accounting for its capacity and capacity borrowed
 // its from Cohort.
@@ -197,7 +378,7 @@ func (c *ClusterQueueSnapshot) parentHRN() hierarchicalResourceNode {
 }
 
 func (c *ClusterQueueSnapshot) DominantResourceShare() int {
-	share, _ := dominantResourceShare(c, nil)
+	share, _ := dominantResourceShare(c, nil, c.fairSharingWeights, c.fairSharingShareMode)
 	return share
 }
 
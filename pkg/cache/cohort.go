@@ -24,6 +24,7 @@ import (
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 // cohort is a set of ClusterQueues that can borrow resources from each other.
@@ -32,15 +33,19 @@ type cohort struct {
 	hierarchy.Cohort[*clusterQueue, *cohort]
 
 	resourceNode resourceNode
+	// fallbackUsage is the usage of the workloads of the ClusterQueues which
+	// use this Cohort as their fallback cohort.
+	fallbackUsage resources.FlavorResourceQuantities
 
 	FairWeight resource.Quantity
 }
 
 func newCohort(name kueue.CohortReference) *cohort {
 	return &cohort{
-		Name:         name,
-		Cohort:       hierarchy.NewCohort[*clusterQueue, *cohort](),
-		resourceNode: NewResourceNode(),
+		Name:          name,
+		Cohort:        hierarchy.NewCohort[*clusterQueue, *cohort](),
+		resourceNode:  NewResourceNode(),
+		fallbackUsage: make(resources.FlavorResourceQuantities),
 	}
 }
 
@@ -100,3 +105,24 @@ func (c *cohort) PathSelfToRoot() iter.Seq[*cohort] {
 		}
 	}
 }
+
+// addSubtreeResources adds the nominal quotas of the Cohort, and of all the
+// ClusterQueues and Cohorts in its subtree, to nominal, and the admitted usage
+// of the ClusterQueues in its subtree to usage. It expects that no cycles exist
+// in the Cohort graph.
+func (c *cohort) addSubtreeResources(nominal, usage resources.FlavorResourceQuantities) {
+	for fr, quota := range c.resourceNode.Quotas {
+		nominal[fr] += quota.Nominal
+	}
+	for _, cq := range c.ChildCQs() {
+		for fr, quota := range cq.resourceNode.Quotas {
+			nominal[fr] += quota.Nominal
+		}
+		for fr, v := range cq.AdmittedUsage {
+			usage[fr] += v
+		}
+	}
+	for _, child := range c.ChildCohorts() {
+		child.addSubtreeResources(nominal, usage)
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package cache
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -31,6 +33,12 @@ type CohortSnapshot struct {
 	hierarchy.Cohort[*ClusterQueueSnapshot, *CohortSnapshot]
 
 	FairWeight resource.Quantity
+	// fairSharingWeights are the weights of the resources in the dominant
+	// resource share computation.
+	fairSharingWeights map[corev1.ResourceName]float64
+	// fairSharingShareMode is how the weighted shares of the resources are
+	// combined in the dominant resource share computation.
+	fairSharingShareMode config.FairSharingShareMode
 }
 
 func (c *CohortSnapshot) GetName() kueue.CohortReference {
@@ -70,7 +78,7 @@ func (c *CohortSnapshot) subtreeClusterQueueCount() int {
 }
 
 func (c *CohortSnapshot) DominantResourceShare() int {
-	share, _ := dominantResourceShare(c, nil)
+	share, _ := dominantResourceShare(c, nil, c.fairSharingWeights, c.fairSharingShareMode)
 	return share
 }
 
@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/resources"
 )
@@ -46,13 +47,21 @@ type dominantResourceShareNode interface {
 // quota.  The function also returns the resource name that yielded
 // this value.  When the FairSharing weight is 0, and the ClusterQueue
 // or Cohort is borrowing, we return math.MaxInt.
-func dominantResourceShare(node dominantResourceShareNode, wlReq resources.FlavorResourceQuantities) (int, corev1.ResourceName) {
+// The ratio of each resource is multiplied by its weight from
+// resourceWeights, defaulting to 1. The resources with the weight 0
+// are ignored. With the WeightedSum shareMode, the sum of the ratios
+// is used instead of the maximum, while the returned resource name is
+// still the one with the highest ratio.
+func dominantResourceShare(node dominantResourceShareNode, wlReq resources.FlavorResourceQuantities, resourceWeights map[corev1.ResourceName]float64, shareMode config.FairSharingShareMode) (int, corev1.ResourceName) {
 	if !node.HasParent() {
 		return 0, ""
 	}
 
 	borrowing := make(map[corev1.ResourceName]int64, len(node.getResourceNode().SubtreeQuota))
 	for fr, quota := range node.getResourceNode().SubtreeQuota {
+		if resourceWeight(resourceWeights, fr.Resource) == 0 {
+			continue
+		}
 		amountBorrowed := wlReq[fr] + node.getResourceNode().Usage[fr] - quota
 		if amountBorrowed > 0 {
 			borrowing[fr.Resource] += amountBorrowed
@@ -64,11 +73,16 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 
 	var drs int64 = -1
 	var dRes corev1.ResourceName
+	var sum int64
 
 	lendable := calculateLendable(node.parentHRN())
 	for rName, b := range borrowing {
 		if lr := lendable[rName]; lr > 0 {
 			ratio := b * 1000 / lr
+			if w := resourceWeight(resourceWeights, rName); w != 1 {
+				ratio = int64(float64(ratio) * w)
+			}
+			sum += ratio
 			// Use alphabetical order to get a deterministic resource name.
 			if ratio > drs || (ratio == drs && rName < dRes) {
 				drs = ratio
@@ -76,6 +90,9 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 			}
 		}
 	}
+	if shareMode == config.FairSharingWeightedSum && drs >= 0 {
+		drs = sum
+	}
 
 	if node.fairWeight().IsZero() {
 		return math.MaxInt, dRes
@@ -85,6 +102,15 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 	return int(dws), dRes
 }
 
+// resourceWeight returns the weight of the resource in the dominant
+// resource share computation, or 1 if not set.
+func resourceWeight(resourceWeights map[corev1.ResourceName]float64, name corev1.ResourceName) float64 {
+	if w, found := resourceWeights[name]; found {
+		return w
+	}
+	return 1
+}
+
 // calculateLendable aggregates capacity for resources across all
 // FlavorResources.
 func calculateLendable(node hierarchicalResourceNode) map[corev1.ResourceName]int64 {
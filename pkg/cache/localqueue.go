@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 )
@@ -32,6 +33,8 @@ type LocalQueue struct {
 	admittedWorkloads  int
 	totalReserved      resources.FlavorResourceQuantities
 	admittedUsage      resources.FlavorResourceQuantities
+	nominalQuota       resources.Requests
+	maxQuota           resources.Requests
 }
 
 func (lq *LocalQueue) GetAdmittedUsage() corev1.ResourceList {
@@ -45,3 +48,28 @@ func (lq *LocalQueue) UpdateAdmittedUsage(usage resources.FlavorResourceQuantiti
 	defer lq.Unlock()
 	updateFlavorUsage(usage, lq.admittedUsage, op)
 }
+
+func (lq *LocalQueue) setResourceLimits(limits []kueue.LocalQueueResourceLimit) {
+	lq.nominalQuota = make(resources.Requests)
+	lq.maxQuota = make(resources.Requests)
+	for _, limit := range limits {
+		if limit.NominalQuota != nil {
+			lq.nominalQuota[limit.Name] = resources.ResourceValue(limit.Name, *limit.NominalQuota)
+		}
+		if limit.MaxQuota != nil {
+			lq.maxQuota[limit.Name] = resources.ResourceValue(limit.Name, *limit.MaxQuota)
+		}
+	}
+}
+
+func (lq *LocalQueue) hasResourceLimits() bool {
+	return len(lq.nominalQuota) > 0 || len(lq.maxQuota) > 0
+}
+
+// LocalQueueSnapshot holds the resource limits of a LocalQueue, along with
+// the usage of the workloads submitted to it, across all the flavors.
+type LocalQueueSnapshot struct {
+	Usage        resources.Requests
+	NominalQuota resources.Requests
+	MaxQuota     resources.Requests
+}
@@ -17,7 +17,12 @@ limitations under the License.
 package cache
 
 import (
+	"math"
+	"math/bits"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
@@ -44,9 +49,29 @@ func (rg *ResourceGroup) Clone() ResourceGroup {
 }
 
 type ResourceQuota struct {
-	Nominal        int64
-	BorrowingLimit *int64
-	LendingLimit   *int64
+	Nominal             int64
+	BorrowingLimit      *int64
+	LendingLimit        *int64
+	PriorityReservation *PriorityReservation
+	ScheduledQuota      *ScheduledQuota
+	// Overcommit is the quota, on top of the Nominal quota, which the
+	// ClusterQueue can admit workloads against due to its overcommit factor.
+	// It's neither lent to nor borrowed from the Cohort.
+	Overcommit int64
+}
+
+// PriorityReservation is the part of the quota which can only be used by
+// the workloads with a priority of at least MinPriority.
+type PriorityReservation struct {
+	Quantity    int64
+	MinPriority int32
+}
+
+// ScheduledQuota is the part of the quota which only becomes available
+// at StartTime.
+type ScheduledQuota struct {
+	Quantity  int64
+	StartTime time.Time
 }
 
 func createResourceQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorResource]ResourceQuota {
@@ -58,18 +83,72 @@ func createResourceQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorRe
 	for _, kueueRg := range kueueRgs {
 		for _, kueueFlavor := range kueueRg.Flavors {
 			for _, kueueQuota := range kueueFlavor.Resources {
+				nominal := resources.ResourceValue(kueueQuota.Name, kueueQuota.NominalQuota)
 				quota := ResourceQuota{
-					Nominal: resources.ResourceValue(kueueQuota.Name, kueueQuota.NominalQuota),
+					Nominal:    nominal,
+					Overcommit: overcommitQuota(nominal, kueueQuota.OvercommitFactor),
 				}
 				if kueueQuota.BorrowingLimit != nil {
 					quota.BorrowingLimit = ptr.To(resources.ResourceValue(kueueQuota.Name, *kueueQuota.BorrowingLimit))
 				}
+				if !ptr.Deref(kueueQuota.Borrowable, true) {
+					// A non-borrowable resource can't exceed the nominal quota.
+					quota.BorrowingLimit = ptr.To[int64](0)
+				}
 				if features.Enabled(features.LendingLimit) && kueueQuota.LendingLimit != nil {
 					quota.LendingLimit = ptr.To(resources.ResourceValue(kueueQuota.Name, *kueueQuota.LendingLimit))
 				}
+				if kueueQuota.PriorityReservation != nil {
+					quota.PriorityReservation = &PriorityReservation{
+						Quantity:    resources.ResourceValue(kueueQuota.Name, kueueQuota.PriorityReservation.Quantity),
+						MinPriority: kueueQuota.PriorityReservation.MinPriority,
+					}
+				}
+				if kueueQuota.ScheduledQuota != nil {
+					quota.ScheduledQuota = &ScheduledQuota{
+						Quantity:  resources.ResourceValue(kueueQuota.Name, kueueQuota.ScheduledQuota.Quantity),
+						StartTime: kueueQuota.ScheduledQuota.StartTime.Time,
+					}
+				}
 				quotas[resources.FlavorResource{Flavor: kueueFlavor.Name, Resource: kueueQuota.Name}] = quota
 			}
 		}
 	}
 	return quotas
 }
+
+// applyBackgroundReservations subtracts the reserved resources from the
+// nominal quotas, without going below zero.
+func applyBackgroundReservations(quotas map[resources.FlavorResource]ResourceQuota, reservations resources.FlavorResourceQuantities) {
+	for fr, reserved := range reservations {
+		if quota, found := quotas[fr]; found {
+			quota.Nominal = max(quota.Nominal-reserved, 0)
+			quotas[fr] = quota
+		}
+	}
+}
+
+// overcommitQuota returns the quota added to the nominal quota by the
+// overcommit factor, that is nominal * (factor - 1), saturating at
+// math.MaxInt64 rather than overflowing.
+func overcommitQuota(nominal int64, factor *resource.Quantity) int64 {
+	if factor == nil || nominal <= 0 {
+		return 0
+	}
+	if factor.CmpInt64(math.MaxInt64/1000) > 0 {
+		return math.MaxInt64
+	}
+	extraMilli := factor.MilliValue() - 1000
+	if extraMilli <= 0 {
+		return 0
+	}
+	hi, lo := bits.Mul64(uint64(nominal), uint64(extraMilli))
+	if hi >= 1000 {
+		return math.MaxInt64
+	}
+	quotient, _ := bits.Div64(hi, lo, 1000)
+	if quotient > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(quotient)
+}
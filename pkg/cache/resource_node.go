@@ -18,6 +18,7 @@ package cache
 
 import (
 	"maps"
+	"math"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -38,7 +39,7 @@ type resourceNode struct {
 	// Usage is the quantity which counts against this node's
 	// SubtreeQuota. For ClusterQueues, this is simply its
 	// usage. For Cohorts, this is the sum of childrens'
-	// usages past childrens' guaranteedQuotas.
+	// usages past childrens' localQuotas.
 	Usage resources.FlavorResourceQuantities
 }
 
@@ -69,6 +70,22 @@ func (r resourceNode) guaranteedQuota(fr resources.FlavorResource) int64 {
 	return 0
 }
 
+// localQuota is the capacity whose usage is not visible in the node's
+// Cohort: its guaranteedQuota, and the quota added by its overcommit
+// factor, which is neither lent to nor borrowed from the Cohort.
+func (r resourceNode) localQuota(fr resources.FlavorResource) int64 {
+	return addSaturating(r.guaranteedQuota(fr), r.Quotas[fr].Overcommit)
+}
+
+// addSaturating returns a + b, saturating at math.MaxInt64 rather than
+// overflowing, as the overcommitted quota may be as large as math.MaxInt64.
+func addSaturating(a, b int64) int64 {
+	if b > 0 && a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
 // hierarchicalResourceNode extends flatResourceNode
 // with the ability to navigate to the parent node.
 type hierarchicalResourceNode interface {
@@ -85,10 +102,10 @@ type flatResourceNode interface {
 }
 
 // LocalAvailable returns, for a given node and resource flavor,
-// how much guaranteed quota in this flavor exceeds usage.
+// how much local quota in this flavor exceeds usage.
 // This quota is available at this node but is not visible at its parent.
 func LocalAvailable(node flatResourceNode, fr resources.FlavorResource) int64 {
-	return max(0, node.getResourceNode().guaranteedQuota(fr)-node.getResourceNode().Usage[fr])
+	return max(0, node.getResourceNode().localQuota(fr)-node.getResourceNode().Usage[fr])
 }
 
 // available determines how much capacity remains for the current
@@ -103,17 +120,17 @@ func LocalAvailable(node flatResourceNode, fr resources.FlavorResource) int64 {
 func available(node hierarchicalResourceNode, fr resources.FlavorResource) int64 {
 	r := node.getResourceNode()
 	if !node.HasParent() {
-		return r.SubtreeQuota[fr] - r.Usage[fr]
+		return addSaturating(r.SubtreeQuota[fr]-r.Usage[fr], r.Quotas[fr].Overcommit)
 	}
 	parentAvailable := available(node.parentHRN(), fr)
 
 	if borrowingLimit := r.Quotas[fr].BorrowingLimit; borrowingLimit != nil {
 		storedInParent := r.SubtreeQuota[fr] - r.guaranteedQuota(fr)
-		usedInParent := max(0, r.Usage[fr]-r.guaranteedQuota(fr))
+		usedInParent := max(0, r.Usage[fr]-r.localQuota(fr))
 		withMaxFromParent := storedInParent - usedInParent + *borrowingLimit
 		parentAvailable = min(withMaxFromParent, parentAvailable)
 	}
-	return LocalAvailable(node, fr) + parentAvailable
+	return addSaturating(parentAvailable, LocalAvailable(node, fr))
 }
 
 // potentialAvailable returns the maximum capacity available to this node,
@@ -121,18 +138,18 @@ func available(node hierarchicalResourceNode, fr resources.FlavorResource) int64
 func potentialAvailable(node hierarchicalResourceNode, fr resources.FlavorResource) int64 {
 	r := node.getResourceNode()
 	if !node.HasParent() {
-		return r.SubtreeQuota[fr]
+		return addSaturating(r.SubtreeQuota[fr], r.Quotas[fr].Overcommit)
 	}
-	available := r.guaranteedQuota(fr) + potentialAvailable(node.parentHRN(), fr)
+	available := addSaturating(potentialAvailable(node.parentHRN(), fr), r.localQuota(fr))
 	if borrowingLimit := r.Quotas[fr].BorrowingLimit; borrowingLimit != nil {
-		maxWithBorrowing := r.SubtreeQuota[fr] + *borrowingLimit
+		maxWithBorrowing := addSaturating(r.SubtreeQuota[fr]+*borrowingLimit, r.Quotas[fr].Overcommit)
 		available = min(maxWithBorrowing, available)
 	}
 	return available
 }
 
 // addUsage adds usage to the current node, and bubbles up usage to
-// its Cohort when usage exceeds guaranteedQuota.
+// its Cohort when usage exceeds localQuota.
 func addUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
 	r := node.getResourceNode()
 	localAvailable := LocalAvailable(node, fr)
@@ -144,10 +161,10 @@ func addUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val in
 }
 
 // removeUsage removes usage from the current node, and removes usage
-// past guaranteedQuota that it was storing in its Cohort.
+// past localQuota that it was storing in its Cohort.
 func removeUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
 	r := node.getResourceNode()
-	usageStoredInParent := r.Usage[fr] - r.guaranteedQuota(fr)
+	usageStoredInParent := r.Usage[fr] - r.localQuota(fr)
 	r.Usage[fr] -= val
 	if usageStoredInParent <= 0 || !node.HasParent() {
 		return
@@ -186,6 +203,9 @@ func updateCohortResourceNode(cohort *cohort) {
 	for fr, quota := range cohort.resourceNode.Quotas {
 		cohort.resourceNode.SubtreeQuota[fr] = quota.Nominal
 	}
+	for fr, usage := range cohort.fallbackUsage {
+		cohort.resourceNode.Usage[fr] += usage
+	}
 	for _, child := range cohort.ChildCohorts() {
 		updateCohortResourceNode(child)
 		accumulateFromChild(cohort, child)
@@ -197,11 +217,15 @@ func updateCohortResourceNode(cohort *cohort) {
 }
 
 func accumulateFromChild(parent *cohort, child flatResourceNode) {
-	for fr, childQuota := range child.getResourceNode().SubtreeQuota {
-		parent.resourceNode.SubtreeQuota[fr] += childQuota - child.getResourceNode().guaranteedQuota(fr)
+	accumulateFromChildNode(&parent.resourceNode, child.getResourceNode())
+}
+
+func accumulateFromChildNode(parent *resourceNode, child resourceNode) {
+	for fr, childQuota := range child.SubtreeQuota {
+		parent.SubtreeQuota[fr] += childQuota - child.guaranteedQuota(fr)
 	}
-	for fr, childUsage := range child.getResourceNode().Usage {
-		parent.resourceNode.Usage[fr] += max(0, childUsage-child.getResourceNode().guaranteedQuota(fr))
+	for fr, childUsage := range child.Usage {
+		parent.Usage[fr] += max(0, childUsage-child.localQuota(fr))
 	}
 }
 
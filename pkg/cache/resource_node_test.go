@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -62,3 +63,40 @@ func TestCohortLendable(t *testing.T) {
 		t.Errorf("Unexpected cohort lendable (-want,+got):\n%s", diff)
 	}
 }
+
+func TestCohortLendableFractionalExtendedResources(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ExtendedResourceMilliUnits, true)
+	cache := New(utiltesting.NewFakeClient())
+
+	cq1 := utiltesting.MakeClusterQueue("cq1").
+		ResourceGroup(
+			utiltesting.MakeFlavorQuotas("default").
+				ResourceQuotaWrapper("example.com/gpu").NominalQuota("3").LendingLimit("3").Append().
+				FlavorQuotas,
+		).Cohort("test-cohort").
+		ClusterQueue
+
+	cq2 := utiltesting.MakeClusterQueue("cq2").
+		ResourceGroup(
+			utiltesting.MakeFlavorQuotas("default").
+				ResourceQuotaWrapper("example.com/gpu").NominalQuota("1500m").LendingLimit("500m").Append().
+				FlavorQuotas,
+		).Cohort("test-cohort").
+		ClusterQueue
+
+	if err := cache.AddClusterQueue(t.Context(), &cq1); err != nil {
+		t.Fatal("Failed to add CQ to cache", err)
+	}
+	if err := cache.AddClusterQueue(t.Context(), &cq2); err != nil {
+		t.Fatal("Failed to add CQ to cache", err)
+	}
+
+	wantLendable := map[corev1.ResourceName]int64{
+		"example.com/gpu": 3_500,
+	}
+
+	lendable := calculateLendable(cache.hm.Cohort("test-cohort"))
+	if diff := cmp.Diff(wantLendable, lendable); diff != "" {
+		t.Errorf("Unexpected cohort lendable (-want,+got):\n%s", diff)
+	}
+}
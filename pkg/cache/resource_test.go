@@ -17,10 +17,15 @@ limitations under the License.
 package cache
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -64,6 +69,104 @@ func TestAvailable(t *testing.T) {
 				"cq2": {{Flavor: "red", Resource: "cpu"}: 5_000, {Flavor: "blue", Resource: "cpu"}: 10_000},
 			},
 		},
+		"cq without overcommit factor": {
+			clusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							ResourceQuotaWrapper("cpu").NominalQuota("10").OvercommitFactor("1").Append().
+							Obj(),
+					).ClusterQueue,
+			},
+			usage: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 4_000},
+			},
+			wantAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 6_000},
+			},
+			wantPotentiallyAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 10_000},
+			},
+		},
+		"cq with overcommit factor": {
+			clusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							ResourceQuotaWrapper("cpu").NominalQuota("10").OvercommitFactor("2").Append().
+							Obj(),
+					).ClusterQueue,
+			},
+			usage: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 4_000},
+			},
+			wantAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 16_000},
+			},
+			wantPotentiallyAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 20_000},
+			},
+		},
+		"cq with overcommit factor in cohort": {
+			clusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							ResourceQuotaWrapper("cpu").NominalQuota("10").OvercommitFactor("1.5").Append().
+							Obj(),
+					).ClusterQueue,
+				utiltesting.MakeClusterQueue("cq2").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							Resource("cpu", "10").
+							Obj(),
+					).ClusterQueue,
+			},
+			usage: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 4_000},
+			},
+			// the usage within the overcommitted quota is not visible in the cohort.
+			wantAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 21_000},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 20_000},
+			},
+			wantPotentiallyAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 25_000},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 20_000},
+			},
+		},
+		"cq in cohort keeps its nominal quota while another cq is overcommitted": {
+			clusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							ResourceQuotaWrapper("cpu").NominalQuota("10").OvercommitFactor("1.5").Append().
+							Obj(),
+					).ClusterQueue,
+				utiltesting.MakeClusterQueue("cq2").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							Resource("cpu", "10").
+							Obj(),
+					).ClusterQueue,
+			},
+			usage: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 15_000},
+			},
+			// cq1 only uses 10k in the cohort, so cq2 can use all its nominal quota.
+			wantAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 10_000},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 10_000},
+			},
+			wantPotentiallyAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 25_000},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 20_000},
+			},
+		},
 		"cqs with cohort": {
 			clusterQueues: []kueue.ClusterQueue{
 				utiltesting.MakeClusterQueue("cq1").
@@ -96,6 +199,37 @@ func TestAvailable(t *testing.T) {
 				"cq2": {{Flavor: "red", Resource: "cpu"}: 30_000},
 			},
 		},
+		"cq can't borrow the non-borrowable resource": {
+			clusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							Resource("cpu", "10").
+							ResourceQuotaWrapper("example.com/license").NominalQuota("2").Borrowable(false).Append().
+							Obj(),
+					).ClusterQueue,
+				utiltesting.MakeClusterQueue("cq2").
+					Cohort("cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("red").
+							Resource("cpu", "10").
+							Resource("example.com/license", "2").
+							Obj(),
+					).ClusterQueue,
+			},
+			usage: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 4_000, {Flavor: "red", Resource: "example.com/license"}: 1},
+			},
+			wantAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 16_000, {Flavor: "red", Resource: "example.com/license"}: 1},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 16_000, {Flavor: "red", Resource: "example.com/license"}: 3},
+			},
+			wantPotentiallyAvailable: map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{
+				"cq1": {{Flavor: "red", Resource: "cpu"}: 20_000, {Flavor: "red", Resource: "example.com/license"}: 2},
+				"cq2": {{Flavor: "red", Resource: "cpu"}: 20_000, {Flavor: "red", Resource: "example.com/license"}: 4},
+			},
+		},
 		"cq borrows from cohort": {
 			clusterQueues: []kueue.ClusterQueue{
 				utiltesting.MakeClusterQueue("cq1").
@@ -387,7 +521,7 @@ func TestAvailable(t *testing.T) {
 					gotPotentiallyAvailable[cq.Name] = make(resources.FlavorResourceQuantities, numFrs)
 					for fr := range cq.ResourceNode.Quotas {
 						gotAvailable[cq.Name][fr] = cq.Available(fr)
-						gotPotentiallyAvailable[cq.Name][fr] = potentialAvailable(cq, fr)
+						gotPotentiallyAvailable[cq.Name][fr] = cq.PotentialAvailable(fr)
 					}
 				}
 				// before adding usage, available == potentiallyAvailable
@@ -413,7 +547,7 @@ func TestAvailable(t *testing.T) {
 					gotPotentiallyAvailable[cq.Name] = make(resources.FlavorResourceQuantities, numFrs)
 					for fr := range cq.ResourceNode.Quotas {
 						gotAvailable[cq.Name][fr] = cq.Available(fr)
-						gotPotentiallyAvailable[cq.Name][fr] = potentialAvailable(cq, fr)
+						gotPotentiallyAvailable[cq.Name][fr] = cq.PotentialAvailable(fr)
 					}
 				}
 				if diff := cmp.Diff(tc.wantAvailable, gotAvailable); diff != "" {
@@ -438,7 +572,7 @@ func TestAvailable(t *testing.T) {
 					gotPotentiallyAvailable[cq.Name] = make(resources.FlavorResourceQuantities, numFrs)
 					for fr := range cq.ResourceNode.Quotas {
 						gotAvailable[cq.Name][fr] = cq.Available(fr)
-						gotPotentiallyAvailable[cq.Name][fr] = potentialAvailable(cq, fr)
+						gotPotentiallyAvailable[cq.Name][fr] = cq.PotentialAvailable(fr)
 					}
 				}
 				// once again, available == potentiallyAvailable
@@ -452,3 +586,119 @@ func TestAvailable(t *testing.T) {
 		})
 	}
 }
+
+func TestAvailableWithBackgroundReservations(t *testing.T) {
+	ctx := t.Context()
+	cache := New(utiltesting.NewFakeClient(), WithBackgroundReservations([]config.BackgroundReservation{
+		{
+			Flavor: "red",
+			Resources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("20Gi"),
+			},
+		},
+	}))
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("red").Obj())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("blue").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("red").Resource("cpu", "10").Resource("memory", "10Gi").Obj(),
+			*utiltesting.MakeFlavorQuotas("blue").Resource("cpu", "10").Resource("memory", "10Gi").Obj(),
+		).Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue: %v", err)
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+	cqSnapshot.AddUsage(workload.Usage{Quota: resources.FlavorResourceQuantities{{Flavor: "red", Resource: "cpu"}: 3_000}})
+
+	gotAvailable := make(resources.FlavorResourceQuantities)
+	for fr := range cqSnapshot.ResourceNode.Quotas {
+		gotAvailable[fr] = cqSnapshot.Available(fr)
+	}
+	wantAvailable := resources.FlavorResourceQuantities{
+		{Flavor: "red", Resource: "cpu"}:     5_000,
+		{Flavor: "red", Resource: "memory"}:  0,
+		{Flavor: "blue", Resource: "cpu"}:    10_000,
+		{Flavor: "blue", Resource: "memory"}: 10 * utiltesting.Gi,
+	}
+	if diff := cmp.Diff(wantAvailable, gotAvailable); diff != "" {
+		t.Errorf("unexpected available (-want/+got):\n%s", diff)
+	}
+}
+
+func TestOvercommitQuota(t *testing.T) {
+	cases := map[string]struct {
+		nominal int64
+		factor  *resource.Quantity
+		want    int64
+	}{
+		"no factor": {
+			nominal: 10_000,
+			want:    0,
+		},
+		"factor of 1": {
+			nominal: 10_000,
+			factor:  ptr.To(resource.MustParse("1")),
+			want:    0,
+		},
+		"fractional factor": {
+			nominal: 10_000,
+			factor:  ptr.To(resource.MustParse("1.25")),
+			want:    2_500,
+		},
+		"product overflows int64": {
+			nominal: math.MaxInt64 / 2,
+			factor:  ptr.To(resource.MustParse("4")),
+			want:    math.MaxInt64,
+		},
+		"factor overflows int64 in milli-units": {
+			nominal: 1,
+			factor:  ptr.To(resource.MustParse("10Ei")),
+			want:    math.MaxInt64,
+		},
+		"largest nominal, doubled": {
+			nominal: math.MaxInt64,
+			factor:  ptr.To(resource.MustParse("2")),
+			want:    math.MaxInt64,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := overcommitQuota(tc.nominal, tc.factor); got != tc.want {
+				t.Errorf("Unexpected overcommit quota, want=%d, got=%d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAvailableWithOvercommitSaturates(t *testing.T) {
+	ctx := t.Context()
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("red").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("red").
+				ResourceQuotaWrapper("cpu").NominalQuota("9000000000000").OvercommitFactor("2000000").Append().
+				Obj(),
+		).Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding the ClusterQueue: %v", err)
+	}
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+	fr := resources.FlavorResource{Flavor: "red", Resource: corev1.ResourceCPU}
+	if got := cqSnapshot.Available(fr); got != math.MaxInt64 {
+		t.Errorf("Unexpected available, want=%d, got=%d", int64(math.MaxInt64), got)
+	}
+	if got := cqSnapshot.PotentialAvailable(fr); got != math.MaxInt64 {
+		t.Errorf("Unexpected potentially available, want=%d, got=%d", int64(math.MaxInt64), got)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -29,6 +30,8 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -37,6 +40,42 @@ type Snapshot struct {
 	hierarchy.Manager[*ClusterQueueSnapshot, *CohortSnapshot]
 	ResourceFlavors          map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	InactiveClusterQueueSets sets.Set[kueue.ClusterQueueReference]
+
+	// Namespaces holds the namespaces which have resource limits, along with
+	// the usage of their workloads across all the ClusterQueues.
+	Namespaces map[string]*NamespaceSnapshot
+}
+
+// NamespaceSnapshot holds the resource limits of a namespace, along with the
+// usage of its workloads, across all the flavors and ClusterQueues.
+type NamespaceSnapshot struct {
+	Usage  resources.Requests
+	Limits resources.Requests
+}
+
+// NamespaceLimitsMessage returns a message explaining why admitting the
+// requests, on behalf of a workload in the given namespace, would make the
+// namespace exceed its resource limits, or an empty string if the requests
+// can be admitted.
+func (s *Snapshot) NamespaceLimitsMessage(namespace string, requests resources.Requests) string {
+	ns, ok := s.Namespaces[namespace]
+	if !ok {
+		return ""
+	}
+	for _, r := range slices.Sorted(maps.Keys(requests)) {
+		if limit, ok := ns.Limits[r]; ok && ns.Usage[r]+requests[r] > limit {
+			return fmt.Sprintf("the namespace would exceed its limit of %s for %s", resources.ResourceQuantityString(r, limit), r)
+		}
+	}
+	return ""
+}
+
+// AddNamespaceUsage adds the requests to the usage of the namespace, if the
+// namespace has resource limits.
+func (s *Snapshot) AddNamespaceUsage(namespace string, requests resources.Requests) {
+	if ns, ok := s.Namespaces[namespace]; ok {
+		ns.Usage.Add(requests)
+	}
 }
 
 // RemoveWorkload removes a workload from its corresponding ClusterQueue and
@@ -117,6 +156,8 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		snap.AddCohort(cohort.Name)
 		snap.Cohort(cohort.Name).ResourceNode = cohort.resourceNode.Clone()
 		snap.Cohort(cohort.Name).FairWeight = cohort.FairWeight
+		snap.Cohort(cohort.Name).fairSharingWeights = c.fairSharingWeights
+		snap.Cohort(cohort.Name).fairSharingShareMode = c.fairSharingShareMode
 		if cohort.HasParent() {
 			snap.UpdateCohortEdge(cohort.Name, cohort.Parent().Name)
 		}
@@ -132,16 +173,37 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 			}
 		}
 	}
+	if len(c.namespaceLimits) > 0 {
+		snap.Namespaces = make(map[string]*NamespaceSnapshot, len(c.namespaceLimits))
+		for namespace, limits := range c.namespaceLimits {
+			snap.Namespaces[namespace] = &NamespaceSnapshot{
+				Usage:  make(resources.Requests),
+				Limits: limits,
+			}
+		}
+		// The workloads of the inactive ClusterQueues still hold their
+		// quota reservation, so they count towards the limits.
+		for _, cq := range c.hm.ClusterQueues() {
+			for _, wl := range cq.Workloads {
+				snap.AddNamespaceUsage(wl.Obj.Namespace, wl.FlavorResourceUsage().FlattenFlavors())
+			}
+		}
+	}
 	for _, cq := range c.hm.ClusterQueues() {
 		if !cq.Active() || (cq.HasParent() && hierarchy.HasCycle(cq.Parent())) {
 			snap.InactiveClusterQueueSets.Insert(cq.Name)
 			continue
 		}
 		cqSnapshot := snapshotClusterQueue(cq)
+		cqSnapshot.fairSharingWeights = c.fairSharingWeights
+		cqSnapshot.fairSharingShareMode = c.fairSharingShareMode
 		snap.AddClusterQueue(cqSnapshot)
 		if cq.HasParent() {
 			snap.UpdateClusterQueueEdge(cq.Name, cq.Parent().Name)
 		}
+		if cq.fallbackCohort != nil {
+			cqSnapshot.FallbackCohort = snap.Cohort(cq.fallbackCohort.Name)
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			for tasFlv, s := range tasSnapshots {
 				if cq.flavorInUse(tasFlv) {
@@ -155,6 +217,101 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 	return &snap, nil
 }
 
+// WithholdPendingScheduledQuotas withholds from the quota of the ClusterQueues,
+// and of their cohorts, the part of their scheduled quota which only becomes
+// available after now and is not used yet by the workloads admitted to start
+// at that time, so that it is neither used by other workloads of the
+// ClusterQueue, nor borrowed by the other ClusterQueues of the cohort.
+// The withheld quota is recorded in the PendingScheduledQuota of the
+// ClusterQueues.
+func (s *Snapshot) WithholdPendingScheduledQuotas(now time.Time) {
+	roots := sets.New[*CohortSnapshot]()
+	for _, cq := range s.ClusterQueues() {
+		cq.PendingScheduledQuota = pendingScheduledQuotas(cq, now)
+		if len(cq.PendingScheduledQuota) == 0 {
+			continue
+		}
+		if cq.HasParent() {
+			roots.Insert(cq.Parent().Root())
+		} else {
+			withholdPendingScheduledQuota(cq)
+		}
+	}
+	for root := range roots {
+		withholdPendingScheduledQuotasInCohort(root)
+	}
+}
+
+// pendingScheduledQuotas returns, for the flavor/resources with a scheduled
+// quota which becomes available after now, the part of the scheduled quota
+// which is not used by the workloads admitted to start at that time.
+func pendingScheduledQuotas(cq *ClusterQueueSnapshot, now time.Time) resources.FlavorResourceQuantities {
+	var pending resources.FlavorResourceQuantities
+	for fr, quota := range cq.ResourceNode.Quotas {
+		scheduled := quota.ScheduledQuota
+		if scheduled == nil || !now.Before(scheduled.StartTime) {
+			continue
+		}
+		var used int64
+		for _, wl := range cq.Workloads {
+			if startTime := workload.AdmissionStartTime(wl.Obj); startTime != nil && now.Before(startTime.Time) {
+				used += wl.FlavorResourceUsage()[fr]
+			}
+		}
+		if scheduled.Quantity > used {
+			if pending == nil {
+				pending = make(resources.FlavorResourceQuantities)
+			}
+			pending[fr] = scheduled.Quantity - used
+		}
+	}
+	return pending
+}
+
+func withholdPendingScheduledQuota(cq *ClusterQueueSnapshot) {
+	// The SubtreeQuota is shared with the cache, so it's replaced.
+	cq.ResourceNode.SubtreeQuota = make(resources.FlavorResourceQuantities, len(cq.ResourceNode.Quotas))
+	for fr, quota := range cq.ResourceNode.Quotas {
+		cq.ResourceNode.SubtreeQuota[fr] = quota.Nominal - cq.PendingScheduledQuota[fr]
+	}
+}
+
+// withholdPendingScheduledQuotasInCohort withholds the pending scheduled
+// quotas of the ClusterQueues in the subtree of the cohort, and accumulates
+// again the SubtreeQuota and the Usage of the cohorts.
+func withholdPendingScheduledQuotasInCohort(cohort *CohortSnapshot) {
+	node := &cohort.ResourceNode
+	// The quota and the usage which don't come from the children in the
+	// snapshot, like the quota of the inactive ClusterQueues or the usage of
+	// the workloads using the cohort as their fallback cohort, are kept.
+	// The SubtreeQuota is shared with the cache, so it's replaced.
+	node.SubtreeQuota = maps.Clone(node.SubtreeQuota)
+	for _, child := range cohort.ChildCQs() {
+		removeFromParentNode(node, child.ResourceNode)
+	}
+	for _, child := range cohort.ChildCohorts() {
+		removeFromParentNode(node, child.ResourceNode)
+	}
+	for _, child := range cohort.ChildCohorts() {
+		withholdPendingScheduledQuotasInCohort(child)
+		accumulateFromChildNode(node, child.ResourceNode)
+	}
+	for _, child := range cohort.ChildCQs() {
+		withholdPendingScheduledQuota(child)
+		accumulateFromChildNode(node, child.ResourceNode)
+	}
+}
+
+// removeFromParentNode is the reverse of accumulateFromChildNode.
+func removeFromParentNode(parent *resourceNode, child resourceNode) {
+	for fr, childQuota := range child.SubtreeQuota {
+		parent.SubtreeQuota[fr] -= childQuota - child.guaranteedQuota(fr)
+	}
+	for fr, childUsage := range child.Usage {
+		parent.Usage[fr] -= max(0, childUsage-child.localQuota(fr))
+	}
+}
+
 // snapshotClusterQueue creates a copy of ClusterQueue that includes
 // references to immutable objects and deep copies of changing ones.
 func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
@@ -167,8 +324,13 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		Workloads:                     maps.Clone(c.Workloads),
 		Preemption:                    c.Preemption,
 		NamespaceSelector:             c.NamespaceSelector,
+		ResourceOverheads:             c.ResourceOverheads,
 		Status:                        c.Status,
 		AdmissionChecks:               utilmaps.DeepCopySets(c.AdmissionChecks),
+		ReservationHolds:              maps.Clone(c.reservationHolds),
+		MaxConcurrentChecks:           c.MaxConcurrentChecks,
+		MinAdmissionInterval:          c.MinAdmissionInterval,
+		BorrowingMinPriority:          c.BorrowingMinPriority,
 		ResourceNode:                  c.resourceNode.Clone(),
 		TASFlavors:                    make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot),
 		tasOnly:                       c.isTASOnly(),
@@ -176,6 +338,26 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 	for i, rg := range c.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
 	}
+	if c.MaxConcurrentChecks != nil {
+		for _, wl := range c.Workloads {
+			if !workload.IsAdmitted(wl.Obj) {
+				cc.workloadsInChecks++
+			}
+		}
+	}
+	for key, lq := range c.localQueues {
+		if !lq.hasResourceLimits() {
+			continue
+		}
+		if cc.LocalQueues == nil {
+			cc.LocalQueues = make(map[queue.LocalQueueReference]*LocalQueueSnapshot)
+		}
+		cc.LocalQueues[key] = &LocalQueueSnapshot{
+			Usage:        lq.totalReserved.FlattenFlavors(),
+			NominalQuota: lq.nominalQuota,
+			MaxQuota:     lq.maxQuota,
+		}
+	}
 	return cc
 }
 
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -28,10 +29,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -1717,3 +1720,267 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotLocalQueueLimits(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	lqA := utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").ResourceLimit(corev1.ResourceCPU, "4", "6").Obj()
+	lqB := utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj()
+	for _, lq := range []*kueue.LocalQueue{lqA, lqB} {
+		if err := cqCache.AddLocalQueue(lq); err != nil {
+			t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+		}
+	}
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq-a").
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		Obj()
+	if !cqCache.AddOrUpdateWorkload(wl) {
+		t.Fatalf("Couldn't add Workload to cache")
+	}
+
+	snap, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnap := snap.ClusterQueue("cq")
+	wantLocalQueues := map[queue.LocalQueueReference]*LocalQueueSnapshot{
+		"ns/lq-a": {
+			Usage:        resources.Requests{corev1.ResourceCPU: 3_000},
+			NominalQuota: resources.Requests{corev1.ResourceCPU: 4_000},
+			MaxQuota:     resources.Requests{corev1.ResourceCPU: 6_000},
+		},
+	}
+	if diff := cmp.Diff(wantLocalQueues, cqSnap.LocalQueues); diff != "" {
+		t.Errorf("Unexpected LocalQueues in snapshot (-want,+got):\n%s", diff)
+	}
+
+	cases := map[string]struct {
+		lq       queue.LocalQueueReference
+		requests resources.Requests
+		wantMsg  string
+	}{
+		"fits the maxQuota": {
+			lq:       "ns/lq-a",
+			requests: resources.Requests{corev1.ResourceCPU: 3_000},
+		},
+		"exceeds the maxQuota": {
+			lq:       "ns/lq-a",
+			requests: resources.Requests{corev1.ResourceCPU: 4_000},
+			wantMsg:  "the LocalQueue would exceed its maxQuota of 6 for cpu",
+		},
+		"fits outside of the reserved nominalQuota": {
+			lq:       "ns/lq-b",
+			requests: resources.Requests{corev1.ResourceCPU: 6_000},
+		},
+		"uses the reserved nominalQuota": {
+			lq:       "ns/lq-b",
+			requests: resources.Requests{corev1.ResourceCPU: 7_000},
+			wantMsg:  "1 of the unused cpu is reserved for the nominalQuota of other LocalQueues",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.wantMsg, cqSnap.LocalQueueLimitsMessage(tc.lq, tc.requests)); diff != "" {
+				t.Errorf("Unexpected message (-want,+got):\n%s", diff)
+			}
+		})
+	}
+
+	newLQA := lqA.DeepCopy()
+	newLQA.Spec.ResourceLimits = nil
+	if err := cqCache.UpdateLocalQueue(lqA, newLQA); err != nil {
+		t.Fatalf("Couldn't update LocalQueue in cache: %v", err)
+	}
+	snap, err = cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	if got := snap.ClusterQueue("cq").LocalQueues; len(got) != 0 {
+		t.Errorf("Unexpected LocalQueues in snapshot after removing the limits: %v", got)
+	}
+}
+
+func TestSnapshotNamespaceLimits(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := New(utiltesting.NewFakeClient(), WithNamespaceLimits([]config.NamespaceResourceLimit{
+		{
+			Namespace: "ns",
+			Limits:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+		},
+	}))
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, name := range []string{"cq-a", "cq-b"} {
+		cq := utiltesting.MakeClusterQueue(name).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj()
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("wl-a", "ns").
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("wl-b", "ns").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("wl-other", "other").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+	}
+	for _, wl := range workloads {
+		if !cqCache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Couldn't add Workload %s to cache", wl.Name)
+		}
+	}
+
+	snap, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	wantNamespaces := map[string]*NamespaceSnapshot{
+		"ns": {
+			Usage:  resources.Requests{corev1.ResourceCPU: 5_000},
+			Limits: resources.Requests{corev1.ResourceCPU: 8_000},
+		},
+	}
+	if diff := cmp.Diff(wantNamespaces, snap.Namespaces); diff != "" {
+		t.Errorf("Unexpected namespaces in snapshot (-want,+got):\n%s", diff)
+	}
+
+	cases := map[string]struct {
+		namespace string
+		requests  resources.Requests
+		wantMsg   string
+	}{
+		"fits the limit": {
+			namespace: "ns",
+			requests:  resources.Requests{corev1.ResourceCPU: 3_000},
+		},
+		"exceeds the limit": {
+			namespace: "ns",
+			requests:  resources.Requests{corev1.ResourceCPU: 4_000},
+			wantMsg:   "the namespace would exceed its limit of 8 for cpu",
+		},
+		"namespace without limits": {
+			namespace: "other",
+			requests:  resources.Requests{corev1.ResourceCPU: 20_000},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.wantMsg, snap.NamespaceLimitsMessage(tc.namespace, tc.requests)); diff != "" {
+				t.Errorf("Unexpected message (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotWithholdPendingScheduledQuotas(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	startTime := now.Add(time.Hour)
+	fr := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("lender").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").ScheduledQuota("4", startTime).Append().
+				Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("borrower").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("later", "ns").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("lender").
+				Assignment(corev1.ResourceCPU, "default", "1").
+				StartTime(startTime).
+				Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("borrowing", "ns").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("borrower").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+	}
+	for _, wl := range workloads {
+		if !cqCache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Couldn't add Workload %q to cache", wl.Name)
+		}
+	}
+
+	cases := map[string]struct {
+		now                   time.Time
+		use                   int64
+		wantPending           resources.FlavorResourceQuantities
+		wantLenderAvailable   int64
+		wantBorrowerAvailable int64
+	}{
+		"the pending scheduled quota is neither used nor lent before the start time": {
+			now:                   now,
+			wantPending:           resources.FlavorResourceQuantities{fr: 3_000},
+			wantLenderAvailable:   4_000,
+			wantBorrowerAvailable: 4_000,
+		},
+		"the pending scheduled quota used by a workload admitted to start at the start time is given back": {
+			now:                   now,
+			use:                   2_000,
+			wantPending:           resources.FlavorResourceQuantities{fr: 1_000},
+			wantLenderAvailable:   6_000,
+			wantBorrowerAvailable: 6_000,
+		},
+		"the scheduled quota is not withheld after the start time": {
+			now:                   startTime,
+			wantLenderAvailable:   7_000,
+			wantBorrowerAvailable: 7_000,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			snap, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			snap.WithholdPendingScheduledQuotas(tc.now)
+			lender := snap.ClusterQueue("lender")
+			borrower := snap.ClusterQueue("borrower")
+			revert := lender.UsePendingScheduledQuota(resources.FlavorResourceQuantities{fr: tc.use})
+			if diff := cmp.Diff(tc.wantPending, lender.PendingScheduledQuota, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected pending scheduled quota (-want,+got):\n%s", diff)
+			}
+			if got := lender.Available(fr); got != tc.wantLenderAvailable {
+				t.Errorf("Unexpected available quota of the lender, want=%d, got=%d", tc.wantLenderAvailable, got)
+			}
+			if got := borrower.Available(fr); got != tc.wantBorrowerAvailable {
+				t.Errorf("Unexpected available quota of the borrower, want=%d, got=%d", tc.wantBorrowerAvailable, got)
+			}
+			revert()
+			if got := borrower.Available(fr); tc.use > 0 && got != 4_000 {
+				t.Errorf("Unexpected available quota of the borrower after the revert, want=%d, got=%d", 4_000, got)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/component-base/featuregate"
@@ -301,6 +302,7 @@ func TestFindTopologyAssignment(t *testing.T) {
 		requests           resources.Requests
 		count              int32
 		tolerations        []corev1.Toleration
+		previousAssignment *kueue.TopologyAssignment
 		wantAssignment     *kueue.TopologyAssignment
 	}{
 		// TODO: remove suffixes MostFreeCapacity/BestFit after dropping the TASMostFreeCapacity feature gate
@@ -822,6 +824,145 @@ func TestFindTopologyAssignment(t *testing.T) {
 				},
 			},
 		},
+		"rack spread; 4 pods are spread across all the racks": {
+			nodes: binaryTreesNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Spread: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 500,
+			},
+			count: 4,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x3",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x5",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x7",
+						},
+					},
+				},
+			},
+		},
+		"block spread; 6 pods are spread evenly across the blocks, and packed within each block": {
+			nodes: binaryTreesNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Spread: ptr.To(tasBlockLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 500,
+			},
+			count: 6,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 2,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 2,
+						Values: []string{
+							"x5",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x6",
+						},
+					},
+				},
+			},
+		},
+		"rack spread; the pods are spread across the racks with free capacity": {
+			// b1-r1: 1 pod, b1-r2: 3 pods, b2-r1: 1 pod, b2-r2: 2 pods of capacity
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Spread: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count: 6,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x3",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x5",
+						},
+					},
+					{
+						Count: 2,
+						Values: []string{
+							"x6",
+						},
+					},
+				},
+			},
+		},
+		"rack spread; doesn't fit": {
+			nodes: binaryTreesNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Spread: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count:      9,
+			wantReason: `topology "default" allows to fit only 8 out of 9 pod(s)`,
+		},
 		"host required; single Pod fits in the host; MostFreeCapacity": {
 			// TODO: remove after dropping the TASMostFreeCapacity feature gate
 			nodes: defaultNodes,
@@ -1481,7 +1622,7 @@ func TestFindTopologyAssignment(t *testing.T) {
 			},
 			enableFeatureGates: []featuregate.Feature{features.TASProfileMostFreeCapacity},
 		},
-		"block preferred; but the workload cannot be accommodate in entire topology; MostFreeCapacity": {
+		"block preferred; but the workload cannot be accommodate in entire topology; admitted without topology assignment; MostFreeCapacity": {
 			nodes: defaultNodes,
 			topologyRequest: &kueue.PodSetTopologyRequest{
 				Preferred: ptr.To(tasBlockLabel),
@@ -1491,7 +1632,19 @@ func TestFindTopologyAssignment(t *testing.T) {
 				corev1.ResourceCPU: 1000,
 			},
 			count:              10,
-			wantReason:         `topology "default" allows to fit only 7 out of 10 pod(s)`,
+			enableFeatureGates: []featuregate.Feature{features.TASProfileMostFreeCapacity},
+		},
+		"block required; but the workload cannot be accommodate in entire topology; MostFreeCapacity": {
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required: ptr.To(tasBlockLabel),
+			},
+			levels: defaultTwoLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count:              10,
+			wantReason:         `topology "default" allows to fit only 4 out of 10 pod(s)`,
 			enableFeatureGates: []featuregate.Feature{features.TASProfileMostFreeCapacity},
 		},
 		"only nodes with matching labels are considered; no matching node; MostFreeCapacity": {
@@ -1693,6 +1846,33 @@ func TestFindTopologyAssignment(t *testing.T) {
 			count:      1,
 			wantReason: `topology "default" doesn't allow to fit any of 1 pod(s)`,
 		},
+		"hostname preferred; the only node is saturated by non-TAS pods; admitted without topology assignment": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*testingpod.MakePod("test-running", "test-ns").NodeName("x1").
+					StatusPhase(corev1.PodRunning).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Preferred: ptr.To(corev1.LabelHostname),
+			},
+			levels: defaultOneLevel,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 600,
+			},
+			count: 1,
+		},
 		"include usage from running non-TAS pods, blocked assignment; MostFreeCapacity": {
 			// there is not enough free capacity on the only node x1
 			nodes: []corev1.Node{
@@ -2055,6 +2235,303 @@ func TestFindTopologyAssignment(t *testing.T) {
 				},
 			},
 		},
+		"whole node requested on nodes of varying sizes": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x2").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x3").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Preferred:              ptr.To(corev1.LabelHostname),
+				NodeCapacityPercentage: ptr.To[int32](100),
+			},
+			levels: defaultOneLevel,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 100,
+			},
+			count: 3,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x3",
+						},
+					},
+				},
+			},
+		},
+		"whole node requested for more pods than nodes": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x2").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x3").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required:               ptr.To(corev1.LabelHostname),
+				NodeCapacityPercentage: ptr.To[int32](100),
+			},
+			levels: defaultOneLevel,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 100,
+			},
+			count:      4,
+			wantReason: `topology "default" allows to fit only 1 out of 4 pod(s)`,
+		},
+		"half of the node requested on nodes of varying sizes": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x2").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x3").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourcePods:   resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Preferred:              ptr.To(corev1.LabelHostname),
+				NodeCapacityPercentage: ptr.To[int32](50),
+			},
+			levels: defaultOneLevel,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 100,
+			},
+			count: 6,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 2,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 2,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 2,
+						Values: []string{
+							"x3",
+						},
+					},
+				},
+			},
+		},
+		"percentage of the node capacity requested when the lowest level is not node": {
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Preferred:              ptr.To(tasRackLabel),
+				NodeCapacityPercentage: ptr.To[int32](100),
+			},
+			levels: defaultTwoLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 100,
+			},
+			count:      1,
+			wantReason: "the percentage of the node capacity can only be requested when the lowest topology level is kubernetes.io/hostname",
+		},
+		"rack required; previous assignment still fits": {
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count: 2,
+			previousAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x3",
+						},
+					},
+				},
+			},
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x3",
+						},
+					},
+				},
+			},
+		},
+		"rack required; previous assignment doesn't fit anymore": {
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count: 2,
+			previousAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 2,
+						Values: []string{
+							"x5",
+						},
+					},
+				},
+			},
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 2,
+						Values: []string{
+							"x6",
+						},
+					},
+				},
+			},
+		},
+		"rack required; previous assignment spans multiple racks": {
+			nodes: defaultNodes,
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required: ptr.To(tasRackLabel),
+			},
+			levels: defaultThreeLevels,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count: 2,
+			previousAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x1",
+						},
+					},
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+				},
+			},
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 2,
+						Values: []string{
+							"x6",
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -2094,8 +2571,9 @@ func TestFindTopologyAssignment(t *testing.T) {
 						},
 					},
 				},
-				SinglePodRequests: tc.requests,
-				Count:             tc.count,
+				SinglePodRequests:  tc.requests,
+				Count:              tc.count,
+				PreviousAssignment: tc.previousAssignment,
 			}
 			if tc.topologyRequest == nil {
 				tasInput.Implied = true
@@ -2119,3 +2597,97 @@ func TestFindTopologyAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadsOnUnavailableTopologyDomains(t *testing.T) {
+	const (
+		tasBlockLabel = "cloud.com/topology-block"
+		tasRackLabel  = "cloud.com/topology-rack"
+	)
+	baseNode := testingnode.MakeNode("").
+		StatusAllocatable(corev1.ResourceList{
+			corev1.ResourceCPU:  resource.MustParse("4"),
+			corev1.ResourcePods: resource.MustParse("10"),
+		})
+	nodeR1 := baseNode.Clone().Name("b1-r1").Label(tasBlockLabel, "b1").Label(tasRackLabel, "r1")
+	nodeR2 := baseNode.Clone().Name("b1-r2").Label(tasBlockLabel, "b1").Label(tasRackLabel, "r2")
+
+	topology := utiltesting.MakeTopology("default").Levels(tasBlockLabel, tasRackLabel).Obj()
+	flavor := utiltesting.MakeResourceFlavor("tas-flavor").TopologyName(topology.Name).Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-flavor").Resource(corev1.ResourceCPU, "8").Obj()).
+		Obj()
+	workloadInRack := func(name, rack string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "default").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+				AssignmentPodCount(1).
+				TopologyAssignment(&kueue.TopologyAssignment{
+					Levels: []string{tasBlockLabel, tasRackLabel},
+					Domains: []kueue.TopologyDomainAssignment{
+						{Values: []string{"b1", rack}, Count: 1},
+					},
+				}).
+				Obj()).
+			Obj()
+	}
+
+	cases := map[string]struct {
+		nodes         []corev1.Node
+		wantWorkloads []string
+	}{
+		"all the assigned domains have nodes": {
+			nodes: []corev1.Node{*nodeR1.Clone().Ready().Obj(), *nodeR2.Clone().Ready().Obj()},
+		},
+		"the node of an assigned domain is removed": {
+			nodes:         []corev1.Node{*nodeR2.Clone().Ready().Obj()},
+			wantWorkloads: []string{"wl-r1"},
+		},
+		"the node of an assigned domain is cordoned": {
+			nodes:         []corev1.Node{*nodeR1.Clone().Ready().Unschedulable().Obj(), *nodeR2.Clone().Ready().Obj()},
+			wantWorkloads: []string{"wl-r1"},
+		},
+		"the node of an assigned domain is not ready": {
+			nodes:         []corev1.Node{*nodeR1.Clone().Ready().Obj(), *nodeR2.Clone().NotReady().Obj()},
+			wantWorkloads: []string{"wl-r2"},
+		},
+		"all the nodes are removed": {
+			wantWorkloads: []string{"wl-r1", "wl-r2"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			initialObjects := make([]client.Object, 0, len(tc.nodes))
+			for i := range tc.nodes {
+				initialObjects = append(initialObjects, &tc.nodes[i])
+			}
+			clientBuilder := utiltesting.NewClientBuilder().WithObjects(initialObjects...)
+			_ = tasindexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder))
+			cqCache := New(clientBuilder.Build())
+
+			cqCache.AddOrUpdateResourceFlavor(flavor)
+			cqCache.AddOrUpdateTopologyForFlavor(topology, flavor)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+			}
+			cqCache.AddOrUpdateWorkload(workloadInRack("wl-r1", "r1"))
+			cqCache.AddOrUpdateWorkload(workloadInRack("wl-r2", "r2"))
+
+			wlInfos, err := cqCache.WorkloadsOnUnavailableTopologyDomains(ctx, "tas-flavor")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gotWorkloads := make([]string, 0, len(wlInfos))
+			for _, wlInfo := range wlInfos {
+				gotWorkloads = append(gotWorkloads, wlInfo.Obj.Name)
+			}
+			sort.Strings(gotWorkloads)
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
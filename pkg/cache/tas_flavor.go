@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	resourcehelpers "k8s.io/component-helpers/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -68,8 +69,17 @@ type TASFlavorCache struct {
 	// flavor
 	Tolerations []corev1.Toleration
 
+	// DefaultRequiredLevel is the topology level required for the podsets
+	// which don't request a topology explicitly, as specified in the
+	// ResourceFlavor spec.defaultRequiredTopologyLevel field.
+	DefaultRequiredLevel *string
+
 	// usage maintains the usage per topology domain
 	usage map[utiltas.TopologyDomainID]resources.Requests
+
+	// nodeCapacityUsage maintains the requests for a percentage of the node
+	// capacity per topology domain
+	nodeCapacityUsage map[utiltas.TopologyDomainID][]workload.TopologyDomainRequests
 }
 
 func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, levels []string, nodeLabels map[string]string,
@@ -81,11 +91,13 @@ func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, level
 		NodeLabels:   maps.Clone(nodeLabels),
 		Tolerations:  slices.Clone(tolerations),
 		usage:        make(map[utiltas.TopologyDomainID]resources.Requests),
+
+		nodeCapacityUsage: make(map[utiltas.TopologyDomainID][]workload.TopologyDomainRequests),
 	}
 }
 
-func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, error) {
-	log := ctrl.LoggerFrom(ctx)
+// listNodes lists the ready and schedulable nodes of the flavor.
+func (c *TASFlavorCache) listNodes(ctx context.Context) ([]corev1.Node, error) {
 	nodes := &corev1.NodeList{}
 
 	var requiredLabels client.MatchingLabels = maps.Clone(c.NodeLabels)
@@ -98,6 +110,34 @@ func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes for TAS: %w", err)
 	}
+	return nodes.Items, nil
+}
+
+// availableDomains returns the IDs of the lowest level topology domains
+// with at least one ready and schedulable node.
+func (c *TASFlavorCache) availableDomains(ctx context.Context) (sets.Set[utiltas.TopologyDomainID], error) {
+	nodes, err := c.listNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domains := sets.New[utiltas.TopologyDomainID]()
+	for _, node := range nodes {
+		levelValues := utiltas.LevelValues(c.Levels, node.Labels)
+		if c.Levels[len(c.Levels)-1] == corev1.LabelHostname {
+			// the topology assignments only hold the hostname in that case
+			levelValues = levelValues[len(levelValues)-1:]
+		}
+		domains.Insert(utiltas.DomainID(levelValues))
+	}
+	return domains, nil
+}
+
+func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, error) {
+	log := ctrl.LoggerFrom(ctx)
+	nodes, err := c.listNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
 	r, err := labels.NewRequirement(kueuealpha.TASLabel, selection.DoesNotExist, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build requirement for non-TAS pods: %w", err)
@@ -110,7 +150,7 @@ func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to list non-TAS pods which are bound to nodes: %w", err)
 	}
-	return c.snapshotForNodes(log, nodes.Items, pods.Items), nil
+	return c.snapshotForNodes(log, nodes, pods.Items), nil
 }
 
 func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node, pods []corev1.Pod) *TASFlavorSnapshot {
@@ -120,14 +160,12 @@ func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node,
 	log.V(3).Info("Constructing TAS snapshot", "nodeLabels", c.NodeLabels,
 		"levels", c.Levels, "nodeCount", len(nodes), "podCount", len(pods))
 	snapshot := newTASFlavorSnapshot(log, c.TopologyName, c.Levels, c.Tolerations)
+	snapshot.defaultRequiredLevel = c.DefaultRequiredLevel
 	nodeToDomain := make(map[string]utiltas.TopologyDomainID)
 	for _, node := range nodes {
 		nodeToDomain[node.Name] = snapshot.addNode(node)
 	}
 	snapshot.initialize()
-	for domainID, usage := range c.usage {
-		snapshot.addTASUsage(domainID, usage)
-	}
 	for _, pod := range pods {
 		// skip unscheduled or terminal pods as they don't use any capacity
 		if len(pod.Spec.NodeName) == 0 || utilpod.IsTerminated(&pod) {
@@ -139,6 +177,16 @@ func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node,
 			snapshot.addNonTASUsage(domainID, usage)
 		}
 	}
+	for domainID, usage := range c.usage {
+		snapshot.addTASUsage(domainID, usage)
+	}
+	// The requests for a percentage of the node capacity are resolved against
+	// the capacity not used by non-TAS pods, so they are added last.
+	for _, domainRequests := range c.nodeCapacityUsage {
+		for _, tr := range domainRequests {
+			snapshot.updateTASUsage(tr, add)
+		}
+	}
 	return snapshot
 }
 
@@ -155,6 +203,10 @@ func (c *TASFlavorCache) updateUsage(topologyRequests []workload.TopologyDomainR
 	defer c.Unlock()
 	for _, tr := range topologyRequests {
 		domainID := utiltas.DomainID(tr.Values)
+		if tr.NodeCapacityPercentage > 0 {
+			c.updateNodeCapacityUsage(domainID, tr, op)
+			continue
+		}
 		_, found := c.usage[domainID]
 		if !found {
 			c.usage[domainID] = resources.Requests{}
@@ -168,3 +220,24 @@ func (c *TASFlavorCache) updateUsage(topologyRequests []workload.TopologyDomainR
 		}
 	}
 }
+
+// updateNodeCapacityUsage keeps the requests for a percentage of the node
+// capacity, which are resolved against the capacity of the nodes when taking
+// the snapshot.
+func (c *TASFlavorCache) updateNodeCapacityUsage(domainID utiltas.TopologyDomainID, tr workload.TopologyDomainRequests, op usageOp) {
+	if op == add {
+		c.nodeCapacityUsage[domainID] = append(c.nodeCapacityUsage[domainID], tr)
+		return
+	}
+	idx := slices.IndexFunc(c.nodeCapacityUsage[domainID], func(usage workload.TopologyDomainRequests) bool {
+		return usage.Count == tr.Count && usage.NodeCapacityPercentage == tr.NodeCapacityPercentage &&
+			maps.Equal(usage.SinglePodRequests, tr.SinglePodRequests)
+	})
+	if idx == -1 {
+		return
+	}
+	c.nodeCapacityUsage[domainID] = slices.Delete(c.nodeCapacityUsage[domainID], idx, idx+1)
+	if len(c.nodeCapacityUsage[domainID]) == 0 {
+		delete(c.nodeCapacityUsage, domainID)
+	}
+}
@@ -90,6 +90,24 @@ type leafDomain struct {
 	nodeLabels map[string]string
 }
 
+// resolvedRequests returns the requests of a single pod which requests the
+// given percentage of the free capacity of the leaf domain. Each resource is
+// requested in the amount which is the larger of the pod request and the
+// percentage of the free capacity. The pods resource is not resolved.
+func (l *leafDomain) resolvedRequests(requests resources.Requests, nodeCapacityPercentage int32) resources.Requests {
+	if nodeCapacityPercentage == 0 {
+		return requests
+	}
+	result := requests.Clone()
+	for name, capacity := range l.freeCapacity {
+		if name == corev1.ResourcePods {
+			continue
+		}
+		result[name] = max(result[name], capacity*int64(nodeCapacityPercentage)/100)
+	}
+	return result
+}
+
 type domainByID map[utiltas.TopologyDomainID]*domain
 type leafDomainByID map[utiltas.TopologyDomainID]*leafDomain
 
@@ -118,6 +136,10 @@ type TASFlavorSnapshot struct {
 
 	// tolerations represents the list of tolerations defined for the resource flavor
 	tolerations []corev1.Toleration
+
+	// defaultRequiredLevel is the topology level required for the podsets
+	// which don't request a topology explicitly.
+	defaultRequiredLevel *string
 }
 
 func newTASFlavorSnapshot(log logr.Logger, topologyName kueue.TopologyReference,
@@ -225,9 +247,14 @@ func (s *TASFlavorSnapshot) addNonTASUsage(domainID utiltas.TopologyDomainID, us
 	s.leaves[domainID].freeCapacity.Sub(resources.Requests{corev1.ResourcePods: 1})
 }
 
-func (s *TASFlavorSnapshot) updateTASUsage(domainID utiltas.TopologyDomainID, usage resources.Requests, op usageOp, count int32) {
-	u := usage.Clone()
-	u.Add(resources.Requests{corev1.ResourcePods: int64(count)})
+func (s *TASFlavorSnapshot) updateTASUsage(tr workload.TopologyDomainRequests, op usageOp) {
+	domainID := utiltas.DomainID(tr.Values)
+	u := tr.SinglePodRequests
+	if leaf := s.leaves[domainID]; leaf != nil {
+		u = leaf.resolvedRequests(u, tr.NodeCapacityPercentage)
+	}
+	u = u.ScaledUp(int64(tr.Count))
+	u.Add(resources.Requests{corev1.ResourcePods: int64(tr.Count)})
 	if op == add {
 		s.addTASUsage(domainID, u)
 	} else {
@@ -323,6 +350,10 @@ type TASPodSetRequests struct {
 	Count             int32
 	Flavor            kueue.ResourceFlavorReference
 	Implied           bool
+
+	// PreviousAssignment is the topology assignment of the PodSet in its last
+	// placement, which is preferred if it still fits.
+	PreviousAssignment *kueue.TopologyAssignment
 }
 
 func (t *TASPodSetRequests) TotalRequests() resources.Requests {
@@ -366,7 +397,8 @@ func (s *TASFlavorSnapshot) Fits(flavorUsage workload.TASFlavorUsage) bool {
 		}
 		remainingCapacity := leaf.freeCapacity.Clone()
 		remainingCapacity.Sub(leaf.tasUsage)
-		if domainUsage.SinglePodRequests.CountIn(remainingCapacity) < domainUsage.Count {
+		requests := leaf.resolvedRequests(domainUsage.SinglePodRequests, domainUsage.NodeCapacityPercentage)
+		if requests.CountIn(remainingCapacity) < domainUsage.Count {
 			return false
 		}
 	}
@@ -386,12 +418,22 @@ func (s *TASFlavorSnapshot) FindTopologyAssignmentsForFlavor(flavorTASRequests F
 		if reason != "" {
 			return result
 		}
+		if assignment == nil {
+			// the PodSet is admitted without a topology assignment
+			continue
+		}
+		nodeCapacityPercentage := workload.NodeCapacityPercentage(tr.PodSet.TopologyRequest)
 		for _, domain := range assignment.Domains {
 			domainID := utiltas.DomainID(domain.Values)
 			if assumedUsage[domainID] == nil {
 				assumedUsage[domainID] = resources.Requests{}
 			}
-			assumedUsage[domainID].Add(tr.TotalRequests())
+			if nodeCapacityPercentage > 0 {
+				requests := s.leaves[domainID].resolvedRequests(tr.SinglePodRequests, nodeCapacityPercentage)
+				assumedUsage[domainID].Add(requests.ScaledUp(int64(domain.Count)))
+			} else {
+				assumedUsage[domainID].Add(tr.TotalRequests())
+			}
 		}
 	}
 	return result
@@ -418,9 +460,15 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	podSetTolerations := tasPodSetRequests.PodSet.Template.Spec.Tolerations
 	podSetNodeSelectors := tasPodSetRequests.PodSet.Template.Spec.NodeSelector
 	count := tasPodSetRequests.Count
-	required := isRequired(tasPodSetRequests.PodSet.TopologyRequest)
-	key := s.levelKeyWithImpliedFallback(&tasPodSetRequests)
-	unconstrained := isUnconstrained(tasPodSetRequests.PodSet.TopologyRequest, &tasPodSetRequests)
+	topologyRequest := s.topologyRequestWithDefault(tasPodSetRequests.PodSet.TopologyRequest)
+	if topologyRequest != tasPodSetRequests.PodSet.TopologyRequest {
+		// the default level of the flavor takes precedence over the implied
+		// TAS of the ClusterQueue
+		tasPodSetRequests.Implied = false
+	}
+	required := isRequired(topologyRequest)
+	key := s.levelKeyWithImpliedFallback(topologyRequest, &tasPodSetRequests)
+	unconstrained := isUnconstrained(topologyRequest, &tasPodSetRequests)
 	if key == nil {
 		return nil, "topology level not specified"
 	}
@@ -428,6 +476,10 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	if !found {
 		return nil, fmt.Sprintf("no requested topology level: %s", *key)
 	}
+	nodeCapacityPercentage := workload.NodeCapacityPercentage(tasPodSetRequests.PodSet.TopologyRequest)
+	if nodeCapacityPercentage > 0 && !s.isLowestLevelNode() {
+		return nil, fmt.Sprintf("the percentage of the node capacity can only be requested when the lowest topology level is %s", corev1.LabelHostname)
+	}
 	var selector labels.Selector
 	if s.isLowestLevelNode() {
 		sel, err := labels.ValidatedSelectorFromSet(podSetNodeSelectors)
@@ -441,16 +493,35 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	// phase 1 - determine the number of pods which can fit in each topology domain
 	s.fillInCounts(
 		requests,
+		nodeCapacityPercentage,
 		assumedUsage,
 		simulateEmpty,
 		append(podSetTolerations, s.tolerations...),
 		selector,
 	)
 
+	if isSpread(topologyRequest) {
+		return s.findSpreadTopologyAssignment(levelIdx, count)
+	}
+
+	if prev := tasPodSetRequests.PreviousAssignment; prev != nil {
+		if assignment := s.previousAssignmentIfFits(prev, count, levelIdx, required); assignment != nil {
+			return assignment, ""
+		}
+	}
+
 	// phase 2a: determine the level at which the assignment is done along with
 	// the domains which can accommodate all pods
 	fitLevelIdx, currFitDomain, reason := s.findLevelWithFitDomains(levelIdx, required, count, unconstrained)
 	if len(reason) > 0 {
+		if isPreferred(topologyRequest) {
+			// The preferred topology is a best-effort optimization, so the
+			// PodSet which doesn't fit in the topology domains is admitted
+			// without a topology assignment, rather than blocked or
+			// preempting other workloads.
+			s.log.V(3).Info("Admitting the PodSet without a topology assignment", "podSet", tasPodSetRequests.PodSet.Name, "reason", reason)
+			return nil, ""
+		}
 		return nil, reason
 	}
 
@@ -466,6 +537,22 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	return s.buildAssignment(currFitDomain), ""
 }
 
+// topologyRequestWithDefault returns the topology request of the PodSet, or the request
+// for the default required level of the flavor if the PodSet doesn't request
+// a topology explicitly.
+func (s *TASFlavorSnapshot) topologyRequestWithDefault(tr *kueue.PodSetTopologyRequest) *kueue.PodSetTopologyRequest {
+	if tr == nil && s.defaultRequiredLevel != nil {
+		return &kueue.PodSetTopologyRequest{Required: s.defaultRequiredLevel}
+	}
+	return tr
+}
+
+// HasDefaultRequiredLevel returns true if the flavor requires a topology
+// level for the PodSets which don't request a topology explicitly.
+func (s *TASFlavorSnapshot) HasDefaultRequiredLevel() bool {
+	return s.defaultRequiredLevel != nil
+}
+
 func (s *TASFlavorSnapshot) HasLevel(r *kueue.PodSetTopologyRequest) bool {
 	key := s.levelKey(r)
 	if key == nil {
@@ -475,6 +562,37 @@ func (s *TASFlavorSnapshot) HasLevel(r *kueue.PodSetTopologyRequest) bool {
 	return found
 }
 
+// RequiresDomain returns true if the PodSet requires all its pods to be
+// placed within a single topology domain.
+func (s *TASFlavorSnapshot) RequiresDomain(tasRequests *TASPodSetRequests) bool {
+	topologyRequest := s.topologyRequestWithDefault(tasRequests.PodSet.TopologyRequest)
+	return isRequired(topologyRequest) && s.HasLevel(topologyRequest)
+}
+
+// RequiredDomainID returns the ID of the domain, at the topology level
+// required by the PodSet, which contains the lowest-level domain with the
+// given values. It returns false if the PodSet doesn't require a topology
+// domain, or the lowest-level domain isn't found.
+func (s *TASFlavorSnapshot) RequiredDomainID(tasRequests *TASPodSetRequests, values []string) (utiltas.TopologyDomainID, bool) {
+	if !s.RequiresDomain(tasRequests) {
+		return "", false
+	}
+	topologyRequest := s.topologyRequestWithDefault(tasRequests.PodSet.TopologyRequest)
+	levelIdx, _ := s.resolveLevelIdx(*topologyRequest.Required)
+	leaf, found := s.leaves[utiltas.DomainID(values)]
+	if !found {
+		return "", false
+	}
+	d := &leaf.domain
+	for d != nil && len(d.levelValues) > levelIdx+1 {
+		d = d.parent
+	}
+	if d == nil {
+		return "", false
+	}
+	return d.id, true
+}
+
 func (s *TASFlavorSnapshot) resolveLevelIdx(levelKey string) (int, bool) {
 	levelIdx := slices.Index(s.levelKeys, levelKey)
 	if levelIdx == -1 {
@@ -487,8 +605,16 @@ func isRequired(tr *kueue.PodSetTopologyRequest) bool {
 	return tr != nil && tr.Required != nil
 }
 
-func (s *TASFlavorSnapshot) levelKeyWithImpliedFallback(tasRequests *TASPodSetRequests) *string {
-	if key := s.levelKey(tasRequests.PodSet.TopologyRequest); key != nil {
+func isPreferred(tr *kueue.PodSetTopologyRequest) bool {
+	return tr != nil && tr.Preferred != nil
+}
+
+func isSpread(tr *kueue.PodSetTopologyRequest) bool {
+	return tr != nil && tr.Spread != nil
+}
+
+func (s *TASFlavorSnapshot) levelKeyWithImpliedFallback(topologyRequest *kueue.PodSetTopologyRequest, tasRequests *TASPodSetRequests) *string {
+	if key := s.levelKey(topologyRequest); key != nil {
 		return key
 	}
 	if tasRequests.Implied {
@@ -506,6 +632,8 @@ func (s *TASFlavorSnapshot) levelKey(topologyRequest *kueue.PodSetTopologyReques
 		return topologyRequest.Required
 	case topologyRequest.Preferred != nil:
 		return topologyRequest.Preferred
+	case topologyRequest.Spread != nil:
+		return topologyRequest.Spread
 	case ptr.Deref(topologyRequest.Unconstrained, false):
 		return ptr.To(s.lowestLevel())
 	default:
@@ -571,6 +699,48 @@ func (s *TASFlavorSnapshot) findLevelWithFitDomains(levelIdx int, required bool,
 	return levelIdx, []*domain{topDomain}, ""
 }
 
+// findSpreadTopologyAssignment builds the assignment for a PodSet which
+// requests spreading its pods across the domains at the requested level.
+// The pods are distributed to the domains at the level one by one, in a
+// round-robin fashion, to maximize the number of domains used. Within each of
+// the domains the pods are then packed, level-by-level, as for the other
+// PodSets.
+func (s *TASFlavorSnapshot) findSpreadTopologyAssignment(levelIdx int, count int32) (*kueue.TopologyAssignment, string) {
+	domains := s.domainsPerLevel[levelIdx]
+	if len(domains) == 0 {
+		return nil, fmt.Sprintf("no topology domains at level: %s", s.levelKeys[levelIdx])
+	}
+	sortedDomains := s.sortedDomains(slices.Collect(maps.Values(domains)), false)
+	capacities := make([]int32, len(sortedDomains))
+	for i, domain := range sortedDomains {
+		capacities[i] = domain.state
+		domain.state = 0
+	}
+	for remainingCount := count; remainingCount > 0; {
+		assigned := false
+		for i, domain := range sortedDomains {
+			if remainingCount > 0 && domain.state < capacities[i] {
+				domain.state++
+				remainingCount--
+				assigned = true
+			}
+		}
+		if !assigned {
+			return nil, s.notFitMessage(count-remainingCount, count)
+		}
+	}
+	currFitDomain := slices.DeleteFunc(sortedDomains, func(d *domain) bool { return d.state == 0 })
+	for ; levelIdx+1 < len(s.domainsPerLevel); levelIdx++ {
+		lowerFitDomains := make([]*domain, 0, len(currFitDomain))
+		for _, domain := range currFitDomain {
+			sortedLowerDomains := s.sortedDomains(domain.children, false)
+			lowerFitDomains = append(lowerFitDomains, s.updateCountsToMinimum(sortedLowerDomains, domain.state, false)...)
+		}
+		currFitDomain = lowerFitDomains
+	}
+	return s.buildAssignment(currFitDomain), ""
+}
+
 func useBestFitAlgorithm(unconstrained bool) bool {
 	if features.Enabled(features.TASProfileMostFreeCapacity) ||
 		features.Enabled(features.TASProfileLeastFreeCapacity) ||
@@ -630,6 +800,44 @@ func (s *TASFlavorSnapshot) buildTopologyAssignmentForLevels(domains []*domain,
 	return assignment
 }
 
+// previousAssignmentIfFits returns a copy of the previous topology assignment
+// of the PodSet if all its pods still fit in the same domains, and the
+// domains still satisfy the required topology level. It returns nil
+// otherwise. It relies on the counts computed by fillInCounts.
+func (s *TASFlavorSnapshot) previousAssignmentIfFits(prev *kueue.TopologyAssignment, count int32, levelIdx int, required bool) *kueue.TopologyAssignment {
+	levels := s.levelKeys
+	if s.isLowestLevelNode() {
+		levels = s.levelKeys[len(s.levelKeys)-1:]
+	}
+	if !slices.Equal(prev.Levels, levels) {
+		return nil
+	}
+	var total int32
+	var requiredDomain *domain
+	for _, d := range prev.Domains {
+		leaf, found := s.leaves[utiltas.DomainID(d.Values)]
+		if !found || leaf.state < d.Count {
+			return nil
+		}
+		total += d.Count
+		if !required {
+			continue
+		}
+		ancestor := &leaf.domain
+		for ancestor != nil && len(ancestor.levelValues) > levelIdx+1 {
+			ancestor = ancestor.parent
+		}
+		if ancestor == nil || (requiredDomain != nil && ancestor != requiredDomain) {
+			return nil
+		}
+		requiredDomain = ancestor
+	}
+	if total != count {
+		return nil
+	}
+	return prev.DeepCopy()
+}
+
 func (s *TASFlavorSnapshot) buildAssignment(domains []*domain) *kueue.TopologyAssignment {
 	// lex sort domains by their levelValues instead of IDs, as leaves' IDs can only contain the hostname
 	slices.SortFunc(domains, func(a, b *domain) int {
@@ -668,6 +876,7 @@ func (s *TASFlavorSnapshot) sortedDomains(domains []*domain, unconstrained bool)
 }
 
 func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests,
+	nodeCapacityPercentage int32,
 	assumedUsage map[utiltas.TopologyDomainID]resources.Requests,
 	simulateEmpty bool,
 	tolerations []corev1.Toleration,
@@ -704,7 +913,7 @@ func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests,
 		if leafAssumedUsage, found := assumedUsage[leaf.id]; found {
 			remainingCapacity.Sub(leafAssumedUsage)
 		}
-		leaf.state = requests.CountIn(remainingCapacity)
+		leaf.state = leaf.resolvedRequests(requests, nodeCapacityPercentage).CountIn(remainingCapacity)
 	}
 	for _, root := range s.roots {
 		root.state = s.fillInCountsHelper(root)
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestFreeCapacityPerDomain(t *testing.T) {
@@ -65,3 +66,61 @@ func TestFreeCapacityPerDomain(t *testing.T) {
 		t.Errorf("SerializeFreeCapacityPerDomain() mismatch (-expected +got):\n%s", diff)
 	}
 }
+
+func TestUpdateTASUsageForNodeCapacityPercentage(t *testing.T) {
+	cases := map[string]struct {
+		nodeCapacityPercentage int32
+		wantUsage              map[string]resources.Requests
+	}{
+		"whole node": {
+			nodeCapacityPercentage: 100,
+			wantUsage: map[string]resources.Requests{
+				"small": {corev1.ResourceCPU: 1000, corev1.ResourceMemory: 1024, corev1.ResourcePods: 1},
+				"large": {corev1.ResourceCPU: 4000, corev1.ResourceMemory: 4096, corev1.ResourcePods: 1},
+			},
+		},
+		"half of the node": {
+			nodeCapacityPercentage: 50,
+			wantUsage: map[string]resources.Requests{
+				"small": {corev1.ResourceCPU: 500, corev1.ResourceMemory: 512, corev1.ResourcePods: 1},
+				"large": {corev1.ResourceCPU: 2000, corev1.ResourceMemory: 2048, corev1.ResourcePods: 1},
+			},
+		},
+		"the pod requests more than the percentage of the small node": {
+			nodeCapacityPercentage: 10,
+			wantUsage: map[string]resources.Requests{
+				"small": {corev1.ResourceCPU: 200, corev1.ResourceMemory: 102, corev1.ResourcePods: 1},
+				"large": {corev1.ResourceCPU: 400, corev1.ResourceMemory: 409, corev1.ResourcePods: 1},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			snapshot := &TASFlavorSnapshot{
+				leaves: leafDomainByID{
+					"small": &leafDomain{
+						freeCapacity: resources.Requests{corev1.ResourceCPU: 1000, corev1.ResourceMemory: 1024, corev1.ResourcePods: 10},
+					},
+					"large": &leafDomain{
+						freeCapacity: resources.Requests{corev1.ResourceCPU: 4000, corev1.ResourceMemory: 4096, corev1.ResourcePods: 10},
+					},
+				},
+			}
+			for domainID := range snapshot.leaves {
+				snapshot.updateTASUsage(workload.TopologyDomainRequests{
+					Values:                 []string{string(domainID)},
+					SinglePodRequests:      resources.Requests{corev1.ResourceCPU: 200},
+					Count:                  1,
+					NodeCapacityPercentage: tc.nodeCapacityPercentage,
+				}, add)
+			}
+			gotUsage := make(map[string]resources.Requests, len(snapshot.leaves))
+			for domainID, leaf := range snapshot.leaves {
+				gotUsage[string(domainID)] = leaf.tasUsage
+			}
+			if diff := cmp.Diff(tc.wantUsage, gotUsage); diff != "" {
+				t.Errorf("Unexpected TAS usage (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podworkload "sigs.k8s.io/kueue/pkg/controller/jobs/pod"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
 	stringsutils "sigs.k8s.io/kueue/pkg/util/strings"
 )
 
@@ -51,16 +53,33 @@ var (
 	integrationsPath                  = field.NewPath("integrations")
 	integrationsFrameworksPath        = integrationsPath.Child("frameworks")
 	integrationsExternalFrameworkPath = integrationsPath.Child("externalFrameworks")
+	integrationsPriorityResolverPath  = integrationsPath.Child("priorityResolver")
+	integrationsUpdatePolicyPath      = integrationsPath.Child("admittedJobUpdatePolicy")
+	integrationsMissingQueuePath      = integrationsPath.Child("missingQueueNamePolicy")
+	integrationsDefaultQueuePath      = integrationsPath.Child("defaultQueueName")
 	podOptionsPath                    = integrationsPath.Child("podOptions")
 	podOptionsNamespaceSelectorPath   = podOptionsPath.Child("namespaceSelector")
 	managedJobsNamespaceSelectorPath  = field.NewPath("managedJobsNamespaceSelector")
 	waitForPodsReadyPath              = field.NewPath("waitForPodsReady")
 	requeuingStrategyPath             = waitForPodsReadyPath.Child("requeuingStrategy")
+	podsReadyConditionsPath           = waitForPodsReadyPath.Child("podsReadyConditions")
 	multiKueuePath                    = field.NewPath("multiKueue")
 	fsPreemptionStrategiesPath        = field.NewPath("fairSharing", "preemptionStrategies")
+	fsResourceWeightsPath             = field.NewPath("fairSharing", "resourceWeights")
+	fsShareModePath                   = field.NewPath("fairSharing", "shareMode")
 	internalCertManagementPath        = field.NewPath("internalCertManagement")
 	queueVisibilityPath               = field.NewPath("queueVisibility")
 	resourceTransformationPath        = field.NewPath("resources", "transformations")
+	backgroundReservationsPath        = field.NewPath("resources", "backgroundReservations")
+	namespaceLimitsPath               = field.NewPath("resources", "namespaceLimits")
+	schedulerPath                     = field.NewPath("scheduler")
+	localQueuesPath                   = field.NewPath("localQueues")
+	clusterQueuesPath                 = field.NewPath("clusterQueues")
+	workloadRetentionPath             = field.NewPath("objectRetentionPolicies", "workloads")
+	workloadNotificationsPath         = field.NewPath("workloadNotifications")
+	requeuingPath                     = field.NewPath("requeuing")
+	auditLogPath                      = field.NewPath("auditLog")
+	topologyAwareSchedulingPath       = field.NewPath("topologyAwareScheduling")
 )
 
 func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorList {
@@ -72,7 +91,17 @@ func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateFairSharing(c)...)
 	allErrs = append(allErrs, validateInternalCertManagement(c)...)
 	allErrs = append(allErrs, validateResourceTransformations(c)...)
+	allErrs = append(allErrs, validateBackgroundReservations(c)...)
+	allErrs = append(allErrs, validateNamespaceLimits(c)...)
 	allErrs = append(allErrs, validateManagedJobsNamespaceSelector(c)...)
+	allErrs = append(allErrs, validateScheduler(c)...)
+	allErrs = append(allErrs, validateLocalQueues(c)...)
+	allErrs = append(allErrs, validateClusterQueues(c)...)
+	allErrs = append(allErrs, validateObjectRetentionPolicies(c)...)
+	allErrs = append(allErrs, validateWorkloadNotifications(c)...)
+	allErrs = append(allErrs, validateRequeuing(c)...)
+	allErrs = append(allErrs, validateAuditLog(c)...)
+	allErrs = append(allErrs, validateTopologyAwareScheduling(c)...)
 	return allErrs
 }
 
@@ -105,11 +134,86 @@ func validateMultiKueue(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("workerLostTimeout"),
 				c.MultiKueue.WorkerLostTimeout.Duration, apimachineryvalidation.IsNegativeErrorMsg))
 		}
+		if c.MultiKueue.WorkerReconnectGracePeriod != nil && c.MultiKueue.WorkerReconnectGracePeriod.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("workerReconnectGracePeriod"),
+				c.MultiKueue.WorkerReconnectGracePeriod.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+		}
 		if c.MultiKueue.Origin != nil {
 			if errs := apimachineryutilvalidation.IsValidLabelValue(*c.MultiKueue.Origin); len(errs) != 0 {
 				allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("origin"), *c.MultiKueue.Origin, strings.Join(errs, ",")))
 			}
 		}
+		var enabledFrameworks []string
+		if c.Integrations != nil {
+			enabledFrameworks = c.Integrations.Frameworks
+		}
+		for idx, framework := range c.MultiKueue.DisabledIntegrations {
+			if !slices.Contains(enabledFrameworks, framework) {
+				allErrs = append(allErrs, field.NotSupported(multiKueuePath.Child("disabledIntegrations").Index(idx), framework, enabledFrameworks))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateScheduler(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.Scheduler != nil {
+		if c.Scheduler.MaxAdmissionsPerCycle != nil && *c.Scheduler.MaxAdmissionsPerCycle < 1 {
+			allErrs = append(allErrs, field.Invalid(schedulerPath.Child("maxAdmissionsPerCycle"),
+				*c.Scheduler.MaxAdmissionsPerCycle, "must be greater than or equal to 1"))
+		}
+		if c.Scheduler.AdmissionCooldown != nil && c.Scheduler.AdmissionCooldown.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(schedulerPath.Child("admissionCooldown"),
+				c.Scheduler.AdmissionCooldown.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+		}
+		if c.Scheduler.PreemptionObjective != nil {
+			objectives := []configapi.PreemptionObjective{configapi.PreemptionObjectiveCandidateOrder, configapi.PreemptionObjectiveFewestWorkloads, configapi.PreemptionObjectiveFewestResources}
+			if !slices.Contains(objectives, *c.Scheduler.PreemptionObjective) {
+				allErrs = append(allErrs, field.NotSupported(schedulerPath.Child("preemptionObjective"),
+					*c.Scheduler.PreemptionObjective, objectives))
+			}
+		}
+		if c.Scheduler.WorkloadOrdering != nil && !queue.OrderingRegistered(*c.Scheduler.WorkloadOrdering) {
+			allErrs = append(allErrs, field.Invalid(schedulerPath.Child("workloadOrdering"),
+				*c.Scheduler.WorkloadOrdering, "must be the name of a registered ordering"))
+		}
+	}
+	return allErrs
+}
+
+func validateLocalQueues(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.LocalQueues != nil && c.LocalQueues.MissingClusterQueuePolicy != nil {
+		policy := *c.LocalQueues.MissingClusterQueuePolicy
+		if policy != configapi.MissingClusterQueueWarn && policy != configapi.MissingClusterQueueReject {
+			allErrs = append(allErrs, field.NotSupported(localQueuesPath.Child("missingClusterQueuePolicy"),
+				policy, []configapi.MissingClusterQueuePolicy{configapi.MissingClusterQueueWarn, configapi.MissingClusterQueueReject}))
+		}
+	}
+	return allErrs
+}
+
+func validateClusterQueues(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ClusterQueues != nil && c.ClusterQueues.CohortChangePolicy != nil {
+		policy := *c.ClusterQueues.CohortChangePolicy
+		if policy != configapi.CohortChangeAllow && policy != configapi.CohortChangeReject {
+			allErrs = append(allErrs, field.NotSupported(clusterQueuesPath.Child("cohortChangePolicy"),
+				policy, []configapi.CohortChangePolicy{configapi.CohortChangeAllow, configapi.CohortChangeReject}))
+		}
+	}
+	return allErrs
+}
+
+func validateObjectRetentionPolicies(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ObjectRetentionPolicies != nil && c.ObjectRetentionPolicies.Workloads != nil {
+		afterDeactivated := c.ObjectRetentionPolicies.Workloads.AfterDeactivated
+		if afterDeactivated != nil && afterDeactivated.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(workloadRetentionPath.Child("afterDeactivated"),
+				afterDeactivated.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+		}
 	}
 	return allErrs
 }
@@ -127,6 +231,10 @@ func validateWaitForPodsReady(c *configapi.Configuration) field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(waitForPodsReadyPath.Child("recoveryTimeout"),
 			c.WaitForPodsReady.RecoveryTimeout, apimachineryvalidation.IsNegativeErrorMsg))
 	}
+	if c.WaitForPodsReady.ReservationTimeout != nil && c.WaitForPodsReady.ReservationTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(waitForPodsReadyPath.Child("reservationTimeout"),
+			c.WaitForPodsReady.ReservationTimeout, apimachineryvalidation.IsNegativeErrorMsg))
+	}
 	if strategy := c.WaitForPodsReady.RequeuingStrategy; strategy != nil {
 		if strategy.Timestamp != nil &&
 			*strategy.Timestamp != configapi.CreationTimestamp && *strategy.Timestamp != configapi.EvictionTimestamp {
@@ -146,6 +254,25 @@ func validateWaitForPodsReady(c *configapi.Configuration) field.ErrorList {
 				*strategy.BackoffMaxSeconds, apimachineryvalidation.IsNegativeErrorMsg))
 		}
 	}
+	seenFrameworks := sets.New[string]()
+	for idx, condition := range c.WaitForPodsReady.PodsReadyConditions {
+		path := podsReadyConditionsPath.Index(idx)
+		if _, found := jobframework.GetIntegration(condition.Framework); !found {
+			allErrs = append(allErrs, field.NotSupported(path.Child("framework"), condition.Framework, jobframework.GetIntegrationsList()))
+		} else if seenFrameworks.Has(condition.Framework) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("framework"), condition.Framework))
+		}
+		seenFrameworks.Insert(condition.Framework)
+		if condition.ConditionType == "" {
+			allErrs = append(allErrs, field.Required(path.Child("conditionType"), ""))
+		}
+	}
+	if policy := c.WaitForPodsReady.TimeoutStartPolicy; policy != nil {
+		supported := []configapi.PodsReadyTimeoutStartPolicy{configapi.PodsReadyTimeoutStartAdmission, configapi.PodsReadyTimeoutStartFirstPodReady, configapi.PodsReadyTimeoutStartQuorum}
+		if !slices.Contains(supported, *policy) {
+			allErrs = append(allErrs, field.NotSupported(waitForPodsReadyPath.Child("timeoutStartPolicy"), *policy, supported))
+		}
+	}
 	return allErrs
 }
 
@@ -202,6 +329,31 @@ func validateIntegrations(c *configapi.Configuration, scheme *runtime.Scheme) fi
 		}
 	}
 
+	if _, found := jobframework.GetPriorityResolver(c.Integrations.PriorityResolver); !found {
+		allErrs = append(allErrs, field.NotSupported(integrationsPriorityResolverPath, c.Integrations.PriorityResolver, jobframework.GetPriorityResolversList()))
+	}
+
+	if c.Integrations.AdmittedJobUpdatePolicy != nil {
+		policies := []configapi.AdmittedJobUpdatePolicy{configapi.AdmittedJobUpdatePolicyReadmit, configapi.AdmittedJobUpdatePolicyReject}
+		if !slices.Contains(policies, *c.Integrations.AdmittedJobUpdatePolicy) {
+			allErrs = append(allErrs, field.NotSupported(integrationsUpdatePolicyPath, *c.Integrations.AdmittedJobUpdatePolicy, policies))
+		}
+	}
+
+	if c.Integrations.MissingQueueNamePolicy != nil {
+		policies := []configapi.MissingQueueNamePolicy{configapi.MissingQueueNameIgnore, configapi.MissingQueueNameReject, configapi.MissingQueueNameAssignDefaultQueue}
+		if !slices.Contains(policies, *c.Integrations.MissingQueueNamePolicy) {
+			allErrs = append(allErrs, field.NotSupported(integrationsMissingQueuePath, *c.Integrations.MissingQueueNamePolicy, policies))
+		}
+	}
+	if c.Integrations.DefaultQueueName != nil {
+		if errs := apimachineryutilvalidation.IsDNS1123Subdomain(*c.Integrations.DefaultQueueName); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(integrationsDefaultQueuePath, *c.Integrations.DefaultQueueName, strings.Join(errs, ",")))
+		}
+	} else if ptr.Deref(c.Integrations.MissingQueueNamePolicy, "") == configapi.MissingQueueNameAssignDefaultQueue {
+		allErrs = append(allErrs, field.Required(integrationsDefaultQueuePath, "required when the missingQueueNamePolicy is AssignDefaultQueue"))
+	}
+
 	allErrs = append(allErrs, validatePodIntegrationOptions(c)...)
 	return allErrs
 }
@@ -296,6 +448,17 @@ func validateFairSharing(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.NotSupported(fsPreemptionStrategiesPath, fs.PreemptionStrategies, validStrategySetsStr))
 		}
 	}
+	for name, weight := range fs.ResourceWeights {
+		if weight < 0 {
+			allErrs = append(allErrs, field.Invalid(fsResourceWeightsPath.Key(string(name)), weight, apimachineryvalidation.IsNegativeErrorMsg))
+		}
+	}
+	if fs.ShareMode != nil {
+		modes := []configapi.FairSharingShareMode{configapi.FairSharingDominantResource, configapi.FairSharingWeightedSum}
+		if !slices.Contains(modes, *fs.ShareMode) {
+			allErrs = append(allErrs, field.NotSupported(fsShareModePath, *fs.ShareMode, modes))
+		}
+	}
 	return allErrs
 }
 
@@ -318,6 +481,63 @@ func validateResourceTransformations(c *configapi.Configuration) field.ErrorList
 			seenKeys.Insert(transform.Input)
 		}
 	}
+	if cmName := res.TransformationsConfigMapName; cmName != nil {
+		if errs := apimachineryutilvalidation.IsDNS1123Subdomain(*cmName); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("resources", "transformationsConfigMapName"), cmName, strings.Join(errs, ",")))
+		}
+	}
+	return allErrs
+}
+
+func validateBackgroundReservations(c *configapi.Configuration) field.ErrorList {
+	if c.Resources == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	seenFlavors := sets.New[string]()
+	for idx, reservation := range c.Resources.BackgroundReservations {
+		path := backgroundReservationsPath.Index(idx)
+		if reservation.Flavor == "" {
+			allErrs = append(allErrs, field.Required(path.Child("flavor"), ""))
+		} else if errs := apimachineryutilvalidation.IsDNS1123Subdomain(reservation.Flavor); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("flavor"), reservation.Flavor, strings.Join(errs, ",")))
+		} else if seenFlavors.Has(reservation.Flavor) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("flavor"), reservation.Flavor))
+		} else {
+			seenFlavors.Insert(reservation.Flavor)
+		}
+		for name, quantity := range reservation.Resources {
+			if quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("resources").Key(string(name)), quantity.String(), apimachineryvalidation.IsNegativeErrorMsg))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateNamespaceLimits(c *configapi.Configuration) field.ErrorList {
+	if c.Resources == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	seenNamespaces := sets.New[string]()
+	for idx, limit := range c.Resources.NamespaceLimits {
+		path := namespaceLimitsPath.Index(idx)
+		if limit.Namespace == "" {
+			allErrs = append(allErrs, field.Required(path.Child("namespace"), ""))
+		} else if errs := apimachineryutilvalidation.IsDNS1123Label(limit.Namespace); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), limit.Namespace, strings.Join(errs, ",")))
+		} else if seenNamespaces.Has(limit.Namespace) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("namespace"), limit.Namespace))
+		} else {
+			seenNamespaces.Insert(limit.Namespace)
+		}
+		for name, quantity := range limit.Limits {
+			if quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("limits").Key(string(name)), quantity.String(), apimachineryvalidation.IsNegativeErrorMsg))
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -377,3 +597,52 @@ func ValidateFeatureGates(featureGateCLI string, featureGateMap map[string]bool)
 
 	return nil
 }
+
+func validateWorkloadNotifications(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	wn := c.WorkloadNotifications
+	if wn == nil {
+		return allErrs
+	}
+	if u, err := url.Parse(wn.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(workloadNotificationsPath.Child("url"), wn.URL, "must be an absolute http or https URL"))
+	}
+	if wn.MaxRetries != nil && *wn.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(workloadNotificationsPath.Child("maxRetries"),
+			*wn.MaxRetries, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	if wn.RetryBackoff != nil && wn.RetryBackoff.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(workloadNotificationsPath.Child("retryBackoff"),
+			wn.RetryBackoff.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateRequeuing(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.Requeuing != nil && c.Requeuing.MaxCount != nil && *c.Requeuing.MaxCount < 0 {
+		allErrs = append(allErrs, field.Invalid(requeuingPath.Child("maxCount"),
+			*c.Requeuing.MaxCount, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateAuditLog(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.AuditLog != nil && c.AuditLog.Path == "" {
+		allErrs = append(allErrs, field.Required(auditLogPath.Child("path"), "must be a file path or \"-\" for the standard output"))
+	}
+	return allErrs
+}
+
+func validateTopologyAwareScheduling(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.TopologyAwareScheduling != nil && c.TopologyAwareScheduling.NodeAffinityMode != nil {
+		modes := []configapi.TASNodeAffinityMode{configapi.TASNodeAffinityRequired, configapi.TASNodeAffinityPreferred}
+		if !slices.Contains(modes, *c.TopologyAwareScheduling.NodeAffinityMode) {
+			allErrs = append(allErrs, field.NotSupported(topologyAwareSchedulingPath.Child("nodeAffinityMode"),
+				*c.TopologyAwareScheduling.NodeAffinityMode, modes))
+		}
+	}
+	return allErrs
+}
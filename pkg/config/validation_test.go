@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -163,6 +164,77 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"unregistered integrations.priorityResolver": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks:       []string{"batch/job"},
+					PriorityResolver: "unregistered",
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "integrations.priorityResolver",
+				},
+			},
+		},
+		"unsupported integrations.admittedJobUpdatePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks:              []string{"batch/job"},
+					AdmittedJobUpdatePolicy: ptr.To[configapi.AdmittedJobUpdatePolicy]("Ignore"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "integrations.admittedJobUpdatePolicy",
+				},
+			},
+		},
+		"unsupported integrations.missingQueueNamePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks:             []string{"batch/job"},
+					MissingQueueNamePolicy: ptr.To[configapi.MissingQueueNamePolicy]("Suspend"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "integrations.missingQueueNamePolicy",
+				},
+			},
+		},
+		"missing integrations.defaultQueueName with the AssignDefaultQueue policy": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks:             []string{"batch/job"},
+					MissingQueueNamePolicy: ptr.To(configapi.MissingQueueNameAssignDefaultQueue),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.defaultQueueName",
+				},
+			},
+		},
+		"invalid integrations.defaultQueueName": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks:             []string{"batch/job"},
+					MissingQueueNamePolicy: ptr.To(configapi.MissingQueueNameAssignDefaultQueue),
+					DefaultQueueName:       ptr.To("Default_Queue"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.defaultQueueName",
+				},
+			},
+		},
 		"duplicate frameworks between integrations.frameworks and integrations.externalFrameworks": {
 			cfg: &configapi.Configuration{
 				Integrations: &configapi.Integrations{
@@ -468,6 +540,23 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"negative waitForPodsReady.reservationTimeout": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				WaitForPodsReady: &configapi.WaitForPodsReady{
+					Enable: true,
+					ReservationTimeout: &metav1.Duration{
+						Duration: -1,
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "waitForPodsReady.reservationTimeout",
+				},
+			},
+		},
 		"valid waitForPodsReady": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -479,6 +568,9 @@ func TestValidate(t *testing.T) {
 					RecoveryTimeout: &metav1.Duration{
 						Duration: 3,
 					},
+					ReservationTimeout: &metav1.Duration{
+						Duration: 60,
+					},
 					BlockAdmission: ptr.To(false),
 					RequeuingStrategy: &configapi.RequeuingStrategy{
 						Timestamp:          ptr.To(configapi.CreationTimestamp),
@@ -486,6 +578,52 @@ func TestValidate(t *testing.T) {
 						BackoffBaseSeconds: ptr.To[int32](30),
 						BackoffMaxSeconds:  ptr.To[int32](1800),
 					},
+					PodsReadyConditions: []configapi.PodsReadyCondition{
+						{Framework: "jobset.x-k8s.io/jobset", ConditionType: "StartupPolicyCompleted"},
+					},
+				},
+			},
+		},
+		"invalid waitForPodsReady.podsReadyConditions": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				WaitForPodsReady: &configapi.WaitForPodsReady{
+					Enable: true,
+					PodsReadyConditions: []configapi.PodsReadyCondition{
+						{Framework: "jobset.x-k8s.io/jobset", ConditionType: "StartupPolicyCompleted"},
+						{Framework: "jobset.x-k8s.io/jobset", ConditionType: "Ready"},
+						{Framework: "unknown", ConditionType: "Ready"},
+						{Framework: "batch/job"},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "waitForPodsReady.podsReadyConditions[1].framework",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "waitForPodsReady.podsReadyConditions[2].framework",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "waitForPodsReady.podsReadyConditions[3].conditionType",
+				},
+			},
+		},
+		"unsupported waitForPodsReady.timeoutStartPolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				WaitForPodsReady: &configapi.WaitForPodsReady{
+					Enable:             true,
+					TimeoutStartPolicy: ptr.To[configapi.PodsReadyTimeoutStartPolicy]("LastPodReady"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "waitForPodsReady.timeoutStartPolicy",
 				},
 			},
 		},
@@ -572,6 +710,22 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"negative multiKueue.workerReconnectGracePeriod": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					WorkerReconnectGracePeriod: &metav1.Duration{
+						Duration: -time.Second,
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.workerReconnectGracePeriod",
+				},
+			},
+		},
 		"invalid .multiKueue.origin label value": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -597,6 +751,262 @@ func TestValidate(t *testing.T) {
 					WorkerLostTimeout: &metav1.Duration{
 						Duration: 2 * time.Second,
 					},
+					WorkerReconnectGracePeriod: &metav1.Duration{
+						Duration: time.Second,
+					},
+					DisabledIntegrations: []string{"batch/job"},
+				},
+			},
+		},
+		"not enabled framework in multiKueue.disabledIntegrations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					DisabledIntegrations: []string{"jobset.x-k8s.io/jobset"},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "multiKueue.disabledIntegrations[0]",
+				},
+			},
+		},
+		"zero scheduler.maxAdmissionsPerCycle": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					MaxAdmissionsPerCycle: ptr.To[int32](0),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.maxAdmissionsPerCycle",
+				},
+			},
+		},
+		"valid scheduler.maxAdmissionsPerCycle": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					MaxAdmissionsPerCycle: ptr.To[int32](5),
+				},
+			},
+		},
+		"negative scheduler.admissionCooldown": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					AdmissionCooldown: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.admissionCooldown",
+				},
+			},
+		},
+		"valid scheduler.admissionCooldown": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					AdmissionCooldown: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		"unsupported scheduler.preemptionObjective": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					PreemptionObjective: ptr.To[configapi.PreemptionObjective]("FewestPriorities"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "scheduler.preemptionObjective",
+				},
+			},
+		},
+		"valid scheduler.preemptionObjective": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					PreemptionObjective: ptr.To(configapi.PreemptionObjectiveFewestWorkloads),
+				},
+			},
+		},
+		"unregistered scheduler.workloadOrdering": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					WorkloadOrdering: ptr.To("by-team"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.workloadOrdering",
+				},
+			},
+		},
+		"invalid workloadNotifications": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				WorkloadNotifications: &configapi.WorkloadNotifications{
+					URL:          "example.com/events",
+					MaxRetries:   ptr.To[int32](-1),
+					RetryBackoff: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "workloadNotifications.url",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "workloadNotifications.maxRetries",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "workloadNotifications.retryBackoff",
+				},
+			},
+		},
+		"valid workloadNotifications": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				WorkloadNotifications: &configapi.WorkloadNotifications{
+					URL:          "https://example.com/events",
+					MaxRetries:   ptr.To[int32](3),
+					RetryBackoff: &metav1.Duration{Duration: time.Second},
+				},
+			},
+		},
+		"negative requeuing.maxCount": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Requeuing: &configapi.Requeuing{
+					MaxCount: ptr.To[int32](-1),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "requeuing.maxCount",
+				},
+			},
+		},
+		"valid requeuing.maxCount": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Requeuing: &configapi.Requeuing{
+					MaxCount: ptr.To[int32](3),
+				},
+			},
+		},
+		"empty auditLog.path": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				AuditLog:     &configapi.AuditLog{},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "auditLog.path",
+				},
+			},
+		},
+		"valid auditLog.path": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				AuditLog: &configapi.AuditLog{
+					Path: "-",
+				},
+			},
+		},
+		"unsupported topologyAwareScheduling.nodeAffinityMode": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				TopologyAwareScheduling: &configapi.TopologyAwareScheduling{
+					NodeAffinityMode: ptr.To[configapi.TASNodeAffinityMode]("Ignored"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "topologyAwareScheduling.nodeAffinityMode",
+				},
+			},
+		},
+		"valid topologyAwareScheduling.nodeAffinityMode": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				TopologyAwareScheduling: &configapi.TopologyAwareScheduling{
+					NodeAffinityMode: ptr.To(configapi.TASNodeAffinityPreferred),
+				},
+			},
+		},
+		"unsupported localQueues.missingClusterQueuePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				LocalQueues: &configapi.LocalQueues{
+					MissingClusterQueuePolicy: ptr.To[configapi.MissingClusterQueuePolicy]("Ignore"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "localQueues.missingClusterQueuePolicy",
+				},
+			},
+		},
+		"valid localQueues.missingClusterQueuePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				LocalQueues: &configapi.LocalQueues{
+					MissingClusterQueuePolicy: ptr.To(configapi.MissingClusterQueueReject),
+				},
+			},
+		},
+		"unsupported clusterQueues.cohortChangePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ClusterQueues: &configapi.ClusterQueues{
+					CohortChangePolicy: ptr.To[configapi.CohortChangePolicy]("Warn"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "clusterQueues.cohortChangePolicy",
+				},
+			},
+		},
+		"valid clusterQueues.cohortChangePolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ClusterQueues: &configapi.ClusterQueues{
+					CohortChangePolicy: ptr.To(configapi.CohortChangeReject),
+				},
+			},
+		},
+		"negative objectRetentionPolicies.workloads.afterDeactivated": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ObjectRetentionPolicies: &configapi.ObjectRetentionPolicies{
+					Workloads: &configapi.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "objectRetentionPolicies.workloads.afterDeactivated",
 				},
 			},
 		},
@@ -624,6 +1034,45 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"negative fairSharing.resourceWeights": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					Enable:          true,
+					ResourceWeights: map[corev1.ResourceName]float64{corev1.ResourceCPU: 1, "example.com/gpu": -1},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "fairSharing.resourceWeights[example.com/gpu]",
+				},
+			},
+		},
+		"unsupported fairSharing.shareMode": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					Enable:    true,
+					ShareMode: ptr.To[configapi.FairSharingShareMode]("Average"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "fairSharing.shareMode",
+				},
+			},
+		},
+		"valid fairSharing.shareMode": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					Enable:    true,
+					ShareMode: ptr.To(configapi.FairSharingWeightedSum),
+				},
+			},
+		},
 		"invalid .internalCertManagement.webhookSecretName": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -735,6 +1184,114 @@ func TestValidate(t *testing.T) {
 							Strategy: ptr.To(configapi.Replace),
 						},
 					},
+					TransformationsConfigMapName: ptr.To("resource-transformations"),
+				},
+			},
+		},
+		"invalid .resources.transformationsConfigMapName": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					TransformationsConfigMapName: ptr.To("Invalid_Name"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformationsConfigMapName",
+				},
+			},
+		},
+		"valid .resources.backgroundReservations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					BackgroundReservations: []configapi.BackgroundReservation{
+						{
+							Flavor:    "default",
+							Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+						},
+					},
+				},
+			},
+		},
+		"invalid .resources.backgroundReservations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					BackgroundReservations: []configapi.BackgroundReservation{
+						{
+							Flavor:    "default",
+							Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+						},
+						{
+							Flavor: "default",
+						},
+						{
+							Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("-1Gi")},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.backgroundReservations[1].flavor",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.backgroundReservations[2].flavor",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.backgroundReservations[2].resources[memory]",
+				},
+			},
+		},
+		"valid .resources.namespaceLimits": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					NamespaceLimits: []configapi.NamespaceResourceLimit{
+						{
+							Namespace: "team-a",
+							Limits:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")},
+						},
+					},
+				},
+			},
+		},
+		"invalid .resources.namespaceLimits": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					NamespaceLimits: []configapi.NamespaceResourceLimit{
+						{
+							Namespace: "team-a",
+							Limits:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")},
+						},
+						{
+							Namespace: "team-a",
+						},
+						{
+							Namespace: "Team_B",
+							Limits:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("-1Gi")},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.namespaceLimits[1].namespace",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.namespaceLimits[2].namespace",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.namespaceLimits[2].limits[memory]",
 				},
 			},
 		},
@@ -21,11 +21,13 @@ import (
 )
 
 const (
-	KueueName              = "kueue"
-	JobControllerName      = KueueName + "-job-controller"
-	WorkloadControllerName = KueueName + "-workload-controller"
-	AdmissionName          = KueueName + "-admission"
-	ReclaimablePodsMgr     = KueueName + "-reclaimable-pods"
+	KueueName                  = "kueue"
+	JobControllerName          = KueueName + "-job-controller"
+	WorkloadControllerName     = KueueName + "-workload-controller"
+	ClusterQueueControllerName = KueueName + "-cluster-queue-controller"
+	AdmissionName              = KueueName + "-admission"
+	ReclaimablePodsMgr         = KueueName + "-reclaimable-pods"
+	PreemptedWorkloadsMgr      = KueueName + "-preempted-workloads"
 
 	// UpdatesBatchPeriod is the batch period to hold workload updates
 	// before syncing a Queue and ClusterQueue objects.
@@ -0,0 +1,143 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReconcileAdmissionCheck(t *testing.T) {
+	cases := map[string]struct {
+		configs       []kueue.CELAdmissionCheckConfig
+		check         *kueue.AdmissionCheck
+		wantCondition *metav1.Condition
+	}{
+		"unrelated check": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				ControllerName("other-controller").
+				Obj(),
+		},
+		"no parameters specified": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				ControllerName(kueue.CELAdmissionCheckControllerName).
+				Generation(1).
+				Obj(),
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "BadParametersRef",
+				Message:            "missing parameters reference",
+				ObservedGeneration: 1,
+			},
+		},
+		"config missing": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				Parameters(kueue.GroupVersion.Group, ConfigKind, "config1").
+				ControllerName(kueue.CELAdmissionCheckControllerName).
+				Generation(1).
+				Obj(),
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "BadParametersRef",
+				Message:            "celadmissioncheckconfigs.kueue.x-k8s.io \"config1\" not found",
+				ObservedGeneration: 1,
+			},
+		},
+		"invalid expression": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				Parameters(kueue.GroupVersion.Group, ConfigKind, "config1").
+				ControllerName(kueue.CELAdmissionCheckControllerName).
+				Generation(1).
+				Obj(),
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config1").Expression("'a' + 'b'").Obj(),
+			},
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "InvalidExpression",
+				Message:            "Invalid expression: the expression must evaluate to a bool, got string",
+				ObservedGeneration: 1,
+			},
+		},
+		"valid expression": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				Parameters(kueue.GroupVersion.Group, ConfigKind, "config1").
+				ControllerName(kueue.CELAdmissionCheckControllerName).
+				Generation(1).
+				Obj(),
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config1").Expression("object.spec.podSets.size() == 1").Obj(),
+			},
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Active",
+				Message:            "The admission check is active",
+				ObservedGeneration: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder, ctx := getClientBuilder(t.Context())
+			builder = builder.WithObjects(tc.check)
+			builder = builder.WithStatusSubresource(tc.check)
+			builder = builder.WithLists(&kueue.CELAdmissionCheckConfigList{Items: tc.configs})
+			k8sclient := builder.Build()
+
+			helper, err := newCELConfigHelper(k8sclient)
+			if err != nil {
+				t.Fatalf("Unable to create the config helper: %v", err)
+			}
+			reconciler := acReconciler{
+				client: k8sclient,
+				helper: helper,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: tc.check.Name,
+				},
+			}
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Errorf("Unexpected reconcile error: %v", err)
+			}
+
+			gotAc := &kueue.AdmissionCheck{}
+			if err := k8sclient.Get(ctx, types.NamespacedName{Name: tc.check.Name}, gotAc); err != nil {
+				t.Fatalf("Unexpected error getting check %q: %v", tc.check.Name, err)
+			}
+
+			gotCondition := apimeta.FindStatusCondition(gotAc.Status.Conditions, kueue.AdmissionCheckActive)
+			if diff := cmp.Diff(tc.wantCondition, gotCondition, acCmpOptions...); diff != "" {
+				t.Errorf("Unexpected check %q (-want/+got):\n%s", tc.check.Name, diff)
+			}
+		})
+	}
+}
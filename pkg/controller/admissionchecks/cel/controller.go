@@ -0,0 +1,304 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	CheckInactiveMessage = "the check is not active"
+	ExpressionTrue       = "the expression evaluated to true"
+	ExpressionFalse      = "the expression evaluated to false"
+)
+
+var (
+	realClock = clock.RealClock{}
+)
+
+type celConfigHelper = admissioncheck.ConfigHelper[*kueue.CELAdmissionCheckConfig, kueue.CELAdmissionCheckConfig]
+
+func newCELConfigHelper(c client.Client) (*celConfigHelper, error) {
+	return admissioncheck.NewConfigHelper[*kueue.CELAdmissionCheckConfig](c)
+}
+
+// Controller sets the state of the admission checks managed by the CEL
+// admission check controller, by evaluating the expression of their
+// CELAdmissionCheckConfig against the Workloads.
+type Controller struct {
+	client client.Client
+	record record.EventRecorder
+	helper *celConfigHelper
+	clock  clock.Clock
+}
+
+var _ reconcile.Reconciler = (*Controller)(nil)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=admissionchecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=celadmissioncheckconfigs,verbs=get;list;watch
+
+func NewController(client client.Client, record record.EventRecorder) (*Controller, error) {
+	helper, err := newCELConfigHelper(client)
+	if err != nil {
+		return nil, err
+	}
+	return &Controller{
+		client: client,
+		record: record,
+		helper: helper,
+		clock:  realClock,
+	}, nil
+}
+
+// Reconcile evaluates the expressions of the pending admission checks of the
+// Workload, once it has quota reserved.
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	wl := &kueue.Workload{}
+	if err := c.client.Get(ctx, req.NamespacedName, wl); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile Workload")
+
+	if !workload.HasQuotaReservation(wl) || workload.IsFinished(wl) || workload.IsEvicted(wl) {
+		return reconcile.Result{}, nil
+	}
+
+	relevantChecks, err := admissioncheck.FilterForController(ctx, c.client, wl.Status.AdmissionChecks, kueue.CELAdmissionCheckControllerName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	wlPatch := workload.BaseSSAWorkload(wl)
+	recorderMessages := make([]string, 0, len(relevantChecks))
+	updated := false
+	for _, check := range relevantChecks {
+		checkState := *workload.FindAdmissionCheck(wl.Status.AdmissionChecks, check)
+		if checkState.State != kueue.CheckStatePending {
+			continue
+		}
+		state, message, err := c.evaluateCheck(ctx, wl, check)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if checkState.State == state && checkState.Message == message {
+			continue
+		}
+		updated = true
+		if checkState.State != state {
+			recorderMessages = append(recorderMessages, fmt.Sprintf("Admission check %s updated state from %s to %s with message %s", check, checkState.State, state, message))
+		}
+		checkState.State = state
+		checkState.Message = message
+		workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, checkState, c.clock)
+	}
+	if !updated {
+		return reconcile.Result{}, nil
+	}
+	if err := c.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.CELAdmissionCheckControllerName), client.ForceOwnership); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range recorderMessages {
+		c.record.Event(wl, corev1.EventTypeNormal, "AdmissionCheckUpdated", api.TruncateEventMessage(recorderMessages[i]))
+	}
+	return reconcile.Result{}, nil
+}
+
+// evaluateCheck returns the state and message of the admission check for the
+// Workload. The check stays pending while its configuration is not usable.
+func (c *Controller) evaluateCheck(ctx context.Context, wl *kueue.Workload, check kueue.AdmissionCheckReference) (kueue.CheckState, string, error) {
+	config, err := c.helper.ConfigForAdmissionCheck(ctx, check)
+	if err != nil {
+		if apierrors.IsNotFound(err) || errors.Is(err, admissioncheck.ErrNilParametersRef) || errors.Is(err, admissioncheck.ErrBadParametersRef) {
+			return kueue.CheckStatePending, CheckInactiveMessage, nil
+		}
+		return "", "", err
+	}
+	prg, err := compile(config.Spec.Expression)
+	if err != nil {
+		return kueue.CheckStatePending, CheckInactiveMessage, nil
+	}
+	// The evaluation is deterministic, so an error wouldn't be fixed by
+	// retrying it.
+	passed, err := prg.evaluate(wl)
+	if err != nil {
+		return kueue.CheckStateRejected, fmt.Sprintf("Failed to evaluate the expression: %v", err), nil
+	}
+	if passed {
+		return kueue.CheckStateReady, ExpressionTrue, nil
+	}
+	if config.Spec.Message != "" {
+		return kueue.CheckStateRejected, config.Spec.Message, nil
+	}
+	return kueue.CheckStateRejected, ExpressionFalse, nil
+}
+
+type acHandler struct {
+	client client.Client
+}
+
+var _ handler.EventHandler = (*acHandler)(nil)
+
+func (a *acHandler) Create(ctx context.Context, event event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	ac, isAc := event.Object.(*kueue.AdmissionCheck)
+	if !isAc || ac.Spec.ControllerName != kueue.CELAdmissionCheckControllerName {
+		return
+	}
+	if err := a.reconcileWorkloadsUsing(ctx, ac.Name, q); err != nil {
+		ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on create event", "admissionCheck", klog.KObj(ac))
+	}
+}
+
+func (a *acHandler) Update(ctx context.Context, event event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldAc, isOldAc := event.ObjectOld.(*kueue.AdmissionCheck)
+	newAc, isNewAc := event.ObjectNew.(*kueue.AdmissionCheck)
+	if !isNewAc || !isOldAc {
+		return
+	}
+	if oldAc.Spec.ControllerName == kueue.CELAdmissionCheckControllerName || newAc.Spec.ControllerName == kueue.CELAdmissionCheckControllerName {
+		if err := a.reconcileWorkloadsUsing(ctx, oldAc.Name, q); err != nil {
+			ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on update event", "admissionCheck", klog.KObj(oldAc))
+		}
+	}
+}
+
+func (a *acHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do, the pending checks stay pending
+}
+
+func (a *acHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do for now
+}
+
+func (a *acHandler) reconcileWorkloadsUsing(ctx context.Context, check string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	list := &kueue.WorkloadList{}
+	if err := a.client.List(ctx, list, client.MatchingFields{WorkloadsWithAdmissionCheckKey: check}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+	}
+	return nil
+}
+
+type configHandler struct {
+	client client.Client
+	// acHandlerOverride, when set, is called for each of the admission
+	// checks using the config, instead of enqueuing them.
+	acHandlerOverride func(ctx context.Context, check string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error
+}
+
+var _ handler.EventHandler = (*configHandler)(nil)
+
+func (h *configHandler) Create(ctx context.Context, event event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.handle(ctx, event.Object, q)
+}
+
+func (h *configHandler) Update(ctx context.Context, event event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldConfig, isOldConfig := event.ObjectOld.(*kueue.CELAdmissionCheckConfig)
+	newConfig, isNewConfig := event.ObjectNew.(*kueue.CELAdmissionCheckConfig)
+	if !isOldConfig || !isNewConfig || oldConfig.Spec == newConfig.Spec {
+		return
+	}
+	h.handle(ctx, newConfig, q)
+}
+
+func (h *configHandler) Delete(ctx context.Context, event event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.handle(ctx, event.Object, q)
+}
+
+func (h *configHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do for now
+}
+
+func (h *configHandler) handle(ctx context.Context, obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	config, isConfig := obj.(*kueue.CELAdmissionCheckConfig)
+	if !isConfig {
+		return
+	}
+	if err := h.reconcileChecksUsing(ctx, config.Name, q); err != nil {
+		ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on event", "celAdmissionCheckConfig", klog.KObj(config))
+	}
+}
+
+func (h *configHandler) reconcileChecksUsing(ctx context.Context, config string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	list := &kueue.AdmissionCheckList{}
+	if err := h.client.List(ctx, list, client.MatchingFields{AdmissionCheckUsingConfigKey: config}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if h.acHandlerOverride != nil {
+			if err := h.acHandlerOverride(ctx, list.Items[i].Name, q); err != nil {
+				return err
+			}
+			continue
+		}
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: list.Items[i].Name}})
+	}
+	return nil
+}
+
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	ach := &acHandler{
+		client: c.client,
+	}
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("cel_workload").
+		For(&kueue.Workload{}).
+		Watches(&kueue.AdmissionCheck{}, ach).
+		Watches(&kueue.CELAdmissionCheckConfig{}, &configHandler{
+			client:            c.client,
+			acHandlerOverride: ach.reconcileWorkloadsUsing,
+		}).
+		Complete(c)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cel_admissioncheck").
+		For(&kueue.AdmissionCheck{}).
+		Watches(&kueue.CELAdmissionCheckConfig{}, &configHandler{client: c.client}).
+		Complete(&acReconciler{
+			client: c.client,
+			helper: c.helper,
+		})
+}
@@ -0,0 +1,262 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const testNamespace = "ns"
+
+var (
+	wlCmpOptions = cmp.Options{
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreTypes(metav1.ObjectMeta{}, metav1.TypeMeta{}),
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+		cmpopts.IgnoreFields(kueue.AdmissionCheckState{}, "LastTransitionTime"),
+	}
+	acCmpOptions = cmp.Options{
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+	}
+)
+
+func getClientBuilder(ctx context.Context) (*fake.ClientBuilder, context.Context) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kueue.AddToScheme(scheme))
+
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(utiltesting.MakeNamespace(testNamespace))
+	_ = SetupIndexer(ctx, utiltesting.AsIndexer(builder))
+	return builder, ctx
+}
+
+func TestReconcile(t *testing.T) {
+	baseWorkload := utiltesting.MakeWorkload("wl", testNamespace).
+		PodSets(*utiltesting.MakePodSet("main", 4).Request(corev1.ResourceCPU, "1").Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj())
+	pendingWorkload := baseWorkload.Clone().
+		AdmissionCheck(kueue.AdmissionCheckState{
+			Name:  "check",
+			State: kueue.CheckStatePending,
+		})
+	baseCheck := utiltesting.MakeAdmissionCheck("check").
+		ControllerName(kueue.CELAdmissionCheckControllerName).
+		Parameters(kueue.GroupVersion.Group, ConfigKind, "config").
+		Obj()
+
+	cases := map[string]struct {
+		workload     *kueue.Workload
+		checks       []kueue.AdmissionCheck
+		configs      []kueue.CELAdmissionCheckConfig
+		wantWorkload *kueue.Workload
+	}{
+		"expression evaluating to true": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("object.spec.podSets.all(ps, ps.count <= 8)").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStateReady,
+					Message: ExpressionTrue,
+				}).
+				Obj(),
+		},
+		"expression evaluating to false": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("object.spec.podSets.all(ps, ps.count <= 2)").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStateRejected,
+					Message: ExpressionFalse,
+				}).
+				Obj(),
+		},
+		"expression evaluating to false with a custom message": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("object.spec.podSets.all(ps, ps.count <= 2)").
+					Message("pod sets can have up to 2 pods").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStateRejected,
+					Message: "pod sets can have up to 2 pods",
+				}).
+				Obj(),
+		},
+		"expression failing to evaluate": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("object.metadata.annotations['owner'] == 'someone'").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStateRejected,
+					Message: "Failed to evaluate the expression: no such key: annotations",
+				}).
+				Obj(),
+		},
+		"missing config": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStatePending,
+					Message: CheckInactiveMessage,
+				}).
+				Obj(),
+		},
+		"invalid expression": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks:   []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("object.spec.podSets.size()").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStatePending,
+					Message: CheckInactiveMessage,
+				}).
+				Obj(),
+		},
+		"workload without quota reservation": {
+			workload: utiltesting.MakeWorkload("wl", testNamespace).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			checks: []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("false").
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", testNamespace).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+		},
+		"check which is already ready": {
+			workload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Obj(),
+			checks: []kueue.AdmissionCheck{*baseCheck},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("false").
+					Obj(),
+			},
+			wantWorkload: baseWorkload.Clone().
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Obj(),
+		},
+		"check managed by another controller": {
+			workload: pendingWorkload.Clone().Obj(),
+			checks: []kueue.AdmissionCheck{
+				*utiltesting.MakeAdmissionCheck("check").
+					ControllerName("other-controller").
+					Parameters(kueue.GroupVersion.Group, ConfigKind, "config").
+					Obj(),
+			},
+			configs: []kueue.CELAdmissionCheckConfig{
+				*utiltesting.MakeCELAdmissionCheckConfig("config").
+					Expression("false").
+					Obj(),
+			},
+			wantWorkload: pendingWorkload.Clone().Obj(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder, ctx := getClientBuilder(t.Context())
+			builder = builder.WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			builder = builder.WithObjects(tc.workload)
+			builder = builder.WithStatusSubresource(tc.workload)
+			builder = builder.WithLists(
+				&kueue.AdmissionCheckList{Items: tc.checks},
+				&kueue.CELAdmissionCheckConfigList{Items: tc.configs},
+			)
+			k8sclient := builder.Build()
+
+			controller, err := NewController(k8sclient, &utiltesting.EventRecorder{})
+			if err != nil {
+				t.Fatalf("Setting up the CEL admission check controller: %v", err)
+			}
+
+			if _, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)}); err != nil {
+				t.Errorf("Unexpected reconcile error: %v", err)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := k8sclient.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload); err != nil {
+				t.Fatalf("Unexpected error getting the workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload, wlCmpOptions...); diff != "" {
+				t.Errorf("Unexpected workload (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	// objectVariable is the name of the variable holding the Workload in
+	// the expressions.
+	objectVariable = "object"
+
+	// costLimit bounds the cost of evaluating an expression, so that a
+	// costly expression can't stall the controller.
+	costLimit = 1_000_000
+)
+
+var errNotBool = errors.New("the expression must evaluate to a bool")
+
+// program is a compiled expression.
+type program struct {
+	prg cel.Program
+}
+
+// compile parses and checks the expression, which must evaluate to a bool.
+func compile(expression string) (*program, error) {
+	env, err := cel.NewEnv(cel.Variable(objectVariable, cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	// The fields of the object are dynamically typed, so the output type
+	// can only be verified during the evaluation in that case.
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("%w, got %s", errNotBool, outputType)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+	return &program{prg: prg}, nil
+}
+
+// evaluate evaluates the expression against the Workload.
+func (p *program) evaluate(wl *kueue.Workload) (bool, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(wl)
+	if err != nil {
+		return false, err
+	}
+	val, _, err := p.prg.Eval(map[string]any{objectVariable: object})
+	if err != nil {
+		return false, err
+	}
+	result, isBool := val.Value().(bool)
+	if !isBool {
+		return false, fmt.Errorf("%w, got %s", errNotBool, val.Type().TypeName())
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestCompile(t *testing.T) {
+	cases := map[string]struct {
+		expression string
+		wantErr    bool
+	}{
+		"bool expression": {
+			expression: "object.spec.podSets.size() <= 2",
+		},
+		"dynamically typed field": {
+			expression: "object.spec.active",
+		},
+		"syntax error": {
+			expression: "object.spec.podSets.size() <=",
+			wantErr:    true,
+		},
+		"undeclared variable": {
+			expression: "workload.spec.active",
+			wantErr:    true,
+		},
+		"not a bool": {
+			expression: "object.spec.podSets.size()",
+			wantErr:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := compile(tc.expression)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error, want error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("team-a").
+		Label("tier", "batch").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).Request(corev1.ResourceCPU, "1").Obj(),
+			*utiltesting.MakePodSet("workers", 4).Request(corev1.ResourceCPU, "2").Obj(),
+		).
+		Obj()
+
+	cases := map[string]struct {
+		expression string
+		workload   *kueue.Workload
+		want       bool
+		wantErr    bool
+	}{
+		"pod sets under the limit": {
+			expression: "object.spec.podSets.all(ps, ps.count <= 8)",
+			workload:   wl,
+			want:       true,
+		},
+		"pod sets over the limit": {
+			expression: "object.spec.podSets.all(ps, ps.count <= 2)",
+			workload:   wl,
+		},
+		"matching label": {
+			expression: "has(object.metadata.labels) && object.metadata.labels['tier'] == 'batch'",
+			workload:   wl,
+			want:       true,
+		},
+		"queue not allowed": {
+			expression: "object.spec.queueName in ['team-b', 'team-c']",
+			workload:   wl,
+		},
+		"missing field": {
+			expression: "object.metadata.annotations['owner'] == 'someone'",
+			workload:   wl,
+			wantErr:    true,
+		},
+		"dynamically typed field which is not a bool": {
+			expression: "object.spec.queueName",
+			workload:   wl,
+			wantErr:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			prg, err := compile(tc.expression)
+			if err != nil {
+				t.Fatalf("Failed to compile the expression: %v", err)
+			}
+			got, err := prg.evaluate(tc.workload)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error, want error %t, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Unexpected result, want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -35,8 +35,12 @@ type SetupOptions struct {
 	gcInterval        time.Duration
 	origin            string
 	workerLostTimeout time.Duration
-	eventsBatchPeriod time.Duration
-	adapters          map[string]jobframework.MultiKueueAdapter
+	// workerReconnectGracePeriod is the time for which the admission of the
+	// workloads is kept while reconnecting to a worker cluster.
+	workerReconnectGracePeriod time.Duration
+	eventsBatchPeriod          time.Duration
+	adapters                   map[string]jobframework.MultiKueueAdapter
+	disabledAdapters           map[string]jobframework.MultiKueueAdapter
 }
 
 type SetupOption func(o *SetupOptions)
@@ -65,6 +69,15 @@ func WithWorkerLostTimeout(d time.Duration) SetupOption {
 	}
 }
 
+// WithWorkerReconnectGracePeriod - sets the time, since the connection to
+// a worker cluster was lost, for which the multikueue admission check is
+// kept in Ready state while reconnecting.
+func WithWorkerReconnectGracePeriod(d time.Duration) SetupOption {
+	return func(o *SetupOptions) {
+		o.workerReconnectGracePeriod = d
+	}
+}
+
 // WithEventsBatchPeriod - sets the delay used when adding remote triggered
 // events to the workload's reconcile queue.
 func WithEventsBatchPeriod(d time.Duration) SetupOption {
@@ -80,6 +93,15 @@ func WithAdapters(adapters map[string]jobframework.MultiKueueAdapter) SetupOptio
 	}
 }
 
+// WithDisabledAdapters sets the adapters of the integrations for which
+// MultiKueue is disabled. The workloads of such integrations are admitted
+// in the manager cluster, without being dispatched to the worker clusters.
+func WithDisabledAdapters(adapters map[string]jobframework.MultiKueueAdapter) SetupOption {
+	return func(o *SetupOptions) {
+		o.disabledAdapters = adapters
+	}
+}
+
 func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) error {
 	options := &SetupOptions{
 		gcInterval:        defaultGCInterval,
@@ -116,6 +138,6 @@ func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) e
 		return err
 	}
 
-	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, mgr.GetEventRecorderFor(constants.WorkloadControllerName), options.workerLostTimeout, options.eventsBatchPeriod, options.adapters)
+	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, mgr.GetEventRecorderFor(constants.WorkloadControllerName), options.workerLostTimeout, options.workerReconnectGracePeriod, options.eventsBatchPeriod, options.adapters, options.disabledAdapters)
 	return wlRec.setupWithManager(mgr)
 }
@@ -18,6 +18,8 @@ package multikueue
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"maps"
@@ -37,7 +39,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -86,6 +90,9 @@ type remoteClient struct {
 
 	connecting         atomic.Bool
 	failedConnAttempts uint
+	// disconnectedAt is the time the connection was lost, nil if connected
+	// or not yet connected.
+	disconnectedAt atomic.Pointer[time.Time]
 
 	// For unit testing only. There is now need of creating fully functional remote clients in the unit tests
 	// and creating valid kubeconfig content is not trivial.
@@ -111,9 +118,27 @@ func newClientWithWatch(kubeconfig []byte, options client.Options) (client.WithW
 	if err != nil {
 		return nil, err
 	}
+	if err := validateTLSClientConfig(&restConfig.TLSClientConfig); err != nil {
+		return nil, err
+	}
 	return client.NewWithWatch(restConfig, options)
 }
 
+// validateTLSClientConfig checks that the inline client certificate, key and CA
+// bundle of the config can be parsed, in order to report a bad config instead of
+// failing on every request to the worker cluster.
+func validateTLSClientConfig(cfg *rest.TLSClientConfig) error {
+	if len(cfg.CertData) > 0 || len(cfg.KeyData) > 0 {
+		if _, err := tls.X509KeyPair(cfg.CertData, cfg.KeyData); err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+	}
+	if len(cfg.CAData) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CAData) {
+		return errors.New("invalid CA bundle: no PEM encoded certificate found")
+	}
+	return nil
+}
+
 type workloadKueueWatcher struct{}
 
 var _ jobframework.MultiKueueWatcher = (*workloadKueueWatcher)(nil)
@@ -180,6 +205,7 @@ func (rc *remoteClient) setConfig(watchCtx context.Context, kubeconfig []byte) (
 	}
 
 	rc.connecting.Store(false)
+	rc.disconnectedAt.Store(nil)
 	rc.failedConnAttempts = 0
 	return nil, nil
 }
@@ -217,6 +243,7 @@ func (rc *remoteClient) startWatcher(ctx context.Context, kind string, w jobfram
 			oldConnecting := rc.connecting.Swap(true)
 			// reconnect if this is the first watch failing.
 			if !oldConnecting {
+				rc.disconnectedAt.Store(ptr.To(time.Now()))
 				log.V(2).Info("Queue reconcile for reconnect", "cluster", rc.clusterName)
 				rc.queueWatchEndedEvent(ctx)
 			}
@@ -449,9 +476,62 @@ func (c *clustersReconciler) getKubeConfigFromSecret(ctx context.Context, secret
 		return nil, false, fmt.Errorf("key %q not found in secret %q", kueue.MultiKueueConfigSecretKey, secretName)
 	}
 
+	kconfigBytes, err = kubeConfigWithSecretTLS(kconfigBytes, sec.Data)
+	if err != nil {
+		return nil, false, fmt.Errorf("secret %q: %w", secretName, err)
+	}
 	return kconfigBytes, false, nil
 }
 
+// kubeConfigWithSecretTLS overrides the TLS settings of the current context of
+// the kubeconfig with the client certificate, key, CA bundle and server name
+// stored in the secret, if any.
+func kubeConfigWithSecretTLS(kubeconfig []byte, data map[string][]byte) ([]byte, error) {
+	cert, hasCert := data[kueue.MultiKueueClientCertificateSecretKey]
+	key, hasKey := data[kueue.MultiKueueClientKeySecretKey]
+	ca, hasCA := data[kueue.MultiKueueCASecretKey]
+	serverName, hasServerName := data[kueue.MultiKueueTLSServerNameSecretKey]
+	if !hasCert && !hasKey && !hasCA && !hasServerName {
+		return kubeconfig, nil
+	}
+	if hasCert != hasKey {
+		return nil, fmt.Errorf("keys %q and %q must be set together", kueue.MultiKueueClientCertificateSecretKey, kueue.MultiKueueClientKeySecretKey)
+	}
+
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeContext, found := cfg.Contexts[cfg.CurrentContext]
+	if !found {
+		return nil, fmt.Errorf("current context %q not found in kubeconfig", cfg.CurrentContext)
+	}
+	cluster, found := cfg.Clusters[kubeContext.Cluster]
+	if !found {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig", kubeContext.Cluster)
+	}
+	if hasCert {
+		authInfo, found := cfg.AuthInfos[kubeContext.AuthInfo]
+		if !found {
+			authInfo = clientcmdapi.NewAuthInfo()
+			cfg.AuthInfos[kubeContext.AuthInfo] = authInfo
+		}
+		authInfo.ClientCertificate = ""
+		authInfo.ClientCertificateData = cert
+		authInfo.ClientKey = ""
+		authInfo.ClientKeyData = key
+	}
+	if hasCA {
+		cluster.CertificateAuthority = ""
+		cluster.CertificateAuthorityData = ca
+		cluster.InsecureSkipTLSVerify = false
+	}
+	if hasServerName {
+		cluster.TLSServerName = string(serverName)
+	}
+	return clientcmd.Write(*cfg)
+}
+
 func (c *clustersReconciler) getKubeConfigFromPath(path string) ([]byte, bool, error) {
 	content, err := os.ReadFile(path)
 	return content, false, err
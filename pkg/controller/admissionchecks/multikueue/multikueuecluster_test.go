@@ -18,7 +18,14 @@ package multikueue
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
@@ -30,6 +37,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -235,6 +244,33 @@ func TestUpdateConfig(t *testing.T) {
 			},
 			wantCancelCalled: 1,
 		},
+		"update with a client certificate without key in the secret": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				func() corev1.Secret {
+					s := makeTestSecret("worker1", "worker1 kubeconfig")
+					s.Data[kueue.MultiKueueClientCertificateSecretKey] = []byte("cert")
+					return s
+				}(),
+			},
+			remoteClients: map[string]*remoteClient{
+				"worker1": newTestClient(t.Context(), "worker1 old kubeconfig", cancelCalled),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionFalse, "BadConfig", `secret "worker1": keys "tls.crt" and "tls.key" must be set together`, 1).
+					Generation(1).
+					Obj(),
+			},
+			wantCancelCalled: 1,
+		},
 		"missing cluster is removed": {
 			reconcileFor: "worker2",
 			clusters: []kueue.MultiKueueCluster{
@@ -569,3 +605,190 @@ func TestRemoteClientGC(t *testing.T) {
 		})
 	}
 }
+
+// makeTestCertificate returns a PEM encoded self-signed certificate and its key.
+func makeTestCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kueue-manager"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed creating certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed marshaling key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func makeTestKubeConfig(t *testing.T, modify func(*clientcmdapi.Config)) []byte {
+	t.Helper()
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["worker1"] = &clientcmdapi.Cluster{Server: "https://worker1.example.com:6443"}
+	cfg.AuthInfos["kueue"] = &clientcmdapi.AuthInfo{Token: "token"}
+	cfg.Contexts["worker1"] = &clientcmdapi.Context{Cluster: "worker1", AuthInfo: "kueue"}
+	cfg.CurrentContext = "worker1"
+	if modify != nil {
+		modify(cfg)
+	}
+	kubeconfig, err := clientcmd.Write(*cfg)
+	if err != nil {
+		t.Fatalf("Failed writing kubeconfig: %v", err)
+	}
+	return kubeconfig
+}
+
+func TestKubeConfigWithSecretTLS(t *testing.T) {
+	cert, key := makeTestCertificate(t)
+	kubeconfig := makeTestKubeConfig(t, nil)
+
+	cases := map[string]struct {
+		kubeconfig  []byte
+		data        map[string][]byte
+		wantCluster *clientcmdapi.Cluster
+		wantAuth    *clientcmdapi.AuthInfo
+		wantErr     string
+	}{
+		"no TLS settings in the secret": {
+			kubeconfig:  kubeconfig,
+			wantCluster: &clientcmdapi.Cluster{Server: "https://worker1.example.com:6443"},
+			wantAuth:    &clientcmdapi.AuthInfo{Token: "token"},
+		},
+		"client certificate, CA bundle and server name": {
+			kubeconfig: kubeconfig,
+			data: map[string][]byte{
+				kueue.MultiKueueClientCertificateSecretKey: cert,
+				kueue.MultiKueueClientKeySecretKey:         key,
+				kueue.MultiKueueCASecretKey:                cert,
+				kueue.MultiKueueTLSServerNameSecretKey:     []byte("worker1.internal"),
+			},
+			wantCluster: &clientcmdapi.Cluster{
+				Server:                   "https://worker1.example.com:6443",
+				CertificateAuthorityData: cert,
+				TLSServerName:            "worker1.internal",
+			},
+			wantAuth: &clientcmdapi.AuthInfo{
+				Token:                 "token",
+				ClientCertificateData: cert,
+				ClientKeyData:         key,
+			},
+		},
+		"the CA bundle replaces the insecure setting of the kubeconfig": {
+			kubeconfig: makeTestKubeConfig(t, func(cfg *clientcmdapi.Config) {
+				cfg.Clusters["worker1"].InsecureSkipTLSVerify = true
+			}),
+			data: map[string][]byte{
+				kueue.MultiKueueCASecretKey: cert,
+			},
+			wantCluster: &clientcmdapi.Cluster{
+				Server:                   "https://worker1.example.com:6443",
+				CertificateAuthorityData: cert,
+			},
+			wantAuth: &clientcmdapi.AuthInfo{Token: "token"},
+		},
+		"client certificate without key": {
+			kubeconfig: kubeconfig,
+			data: map[string][]byte{
+				kueue.MultiKueueClientCertificateSecretKey: cert,
+			},
+			wantErr: `keys "tls.crt" and "tls.key" must be set together`,
+		},
+		"current context not found": {
+			kubeconfig: makeTestKubeConfig(t, func(cfg *clientcmdapi.Config) {
+				cfg.CurrentContext = "worker2"
+			}),
+			data: map[string][]byte{
+				kueue.MultiKueueTLSServerNameSecretKey: []byte("worker1.internal"),
+			},
+			wantErr: `current context "worker2" not found in kubeconfig`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := kubeConfigWithSecretTLS(tc.kubeconfig, tc.data)
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Fatalf("Unexpected error (-want,+got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			cfg, err := clientcmd.Load(got)
+			if err != nil {
+				t.Fatalf("Failed loading the kubeconfig: %v", err)
+			}
+			ignoreExtensions := cmpopts.IgnoreFields(clientcmdapi.Cluster{}, "LocationOfOrigin", "Extensions")
+			if diff := cmp.Diff(tc.wantCluster, cfg.Clusters["worker1"], ignoreExtensions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected cluster (-want,+got):\n%s", diff)
+			}
+			ignoreAuthExtensions := cmpopts.IgnoreFields(clientcmdapi.AuthInfo{}, "LocationOfOrigin", "Extensions")
+			if diff := cmp.Diff(tc.wantAuth, cfg.AuthInfos["kueue"], ignoreAuthExtensions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected user (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewClientWithWatchTLS(t *testing.T) {
+	cert, key := makeTestCertificate(t)
+
+	cases := map[string]struct {
+		modify  func(*clientcmdapi.Config)
+		wantErr string
+	}{
+		"mTLS credentials": {
+			modify: func(cfg *clientcmdapi.Config) {
+				cfg.Clusters["worker1"].CertificateAuthorityData = cert
+				cfg.AuthInfos["kueue"] = &clientcmdapi.AuthInfo{
+					ClientCertificateData: cert,
+					ClientKeyData:         key,
+				}
+			},
+		},
+		"client key not matching the certificate": {
+			modify: func(cfg *clientcmdapi.Config) {
+				_, otherKey := makeTestCertificate(t)
+				cfg.AuthInfos["kueue"] = &clientcmdapi.AuthInfo{
+					ClientCertificateData: cert,
+					ClientKeyData:         otherKey,
+				}
+			},
+			wantErr: "invalid client certificate: tls: private key does not match public key",
+		},
+		"invalid CA bundle": {
+			modify: func(cfg *clientcmdapi.Config) {
+				cfg.Clusters["worker1"].CertificateAuthorityData = []byte("not a certificate")
+			},
+			wantErr: "invalid CA bundle: no PEM encoded certificate found",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := newClientWithWatch(makeTestKubeConfig(t, tc.modify), client.Options{})
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -59,11 +59,15 @@ type wlReconciler struct {
 	clusters          *clustersReconciler
 	origin            string
 	workerLostTimeout time.Duration
-	deletedWlCache    *utilmaps.SyncMap[string, *kueue.Workload]
-	eventsBatchPeriod time.Duration
-	adapters          map[string]jobframework.MultiKueueAdapter
-	recorder          record.EventRecorder
-	clock             clock.Clock
+	// workerReconnectGracePeriod is the time, since the connection to a worker
+	// cluster was lost, for which the Ready admission check state is kept.
+	workerReconnectGracePeriod time.Duration
+	deletedWlCache             *utilmaps.SyncMap[string, *kueue.Workload]
+	eventsBatchPeriod          time.Duration
+	adapters                   map[string]jobframework.MultiKueueAdapter
+	disabledAdapters           map[string]jobframework.MultiKueueAdapter
+	recorder                   record.EventRecorder
+	clock                      clock.Clock
 }
 
 var _ reconcile.Reconciler = (*wlReconciler)(nil)
@@ -72,6 +76,8 @@ type wlGroup struct {
 	local         *kueue.Workload
 	remotes       map[string]*kueue.Workload
 	remoteClients map[string]*remoteClient
+	// reconnecting are the clients of the worker clusters whose reconnect is ongoing.
+	reconnecting  map[string]*remoteClient
 	acName        kueue.AdmissionCheckReference
 	jobAdapter    jobframework.MultiKueueAdapter
 	controllerKey types.NamespacedName
@@ -209,6 +215,20 @@ func (w *wlReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 		return reconcile.Result{}, w.updateACS(ctx, wl, mkAc, kueue.CheckStateRejected, rejectionMessage)
 	}
 
+	if gvk := adapter.GVK().String(); w.disabledAdapters[gvk] != nil {
+		// The workloads of the integrations for which MultiKueue is disabled
+		// are admitted locally, once they get the quota reserved.
+		if isDeleted {
+			w.deletedWlCache.Delete(req.String())
+			return reconcile.Result{}, nil
+		}
+		if !workload.HasQuotaReservation(wl) || mkAc.State == kueue.CheckStateReady {
+			return reconcile.Result{}, nil
+		}
+		log.V(2).Info("MultiKueue is disabled for the integration, admitting the workload locally", "gvk", gvk)
+		return reconcile.Result{}, w.updateACS(ctx, wl, mkAc, kueue.CheckStateReady, fmt.Sprintf("MultiKueue is disabled for %q, the workload is admitted locally", gvk))
+	}
+
 	// If the workload is deleted there is a chance that it's owner is also deleted. In that case
 	// we skip calling `IsJobManagedByKueue` as its output would not be reliable.
 	if !isDeleted {
@@ -250,24 +270,42 @@ func (w *wlReconciler) updateACS(ctx context.Context, wl *kueue.Workload, acs *k
 	return w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
 }
 
-func (w *wlReconciler) remoteClientsForAC(ctx context.Context, acName kueue.AdmissionCheckReference) (map[string]*remoteClient, error) {
+// remoteClientsForAC returns the clients of the connected worker clusters of
+// the admission check, and the ones whose reconnect is ongoing.
+func (w *wlReconciler) remoteClientsForAC(ctx context.Context, acName kueue.AdmissionCheckReference) (map[string]*remoteClient, map[string]*remoteClient, error) {
 	cfg, err := w.helper.ConfigForAdmissionCheck(ctx, acName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	clients := make(map[string]*remoteClient, len(cfg.Spec.Clusters))
+	reconnecting := make(map[string]*remoteClient)
 	for _, clusterName := range cfg.Spec.Clusters {
 		if client, found := w.clusters.controllerFor(clusterName); found {
 			// Skip the client if its reconnect is ongoing.
 			if !client.connecting.Load() {
 				clients[clusterName] = client
+			} else {
+				reconnecting[clusterName] = client
 			}
 		}
 	}
 	if len(clients) == 0 {
-		return nil, errNoActiveClusters
+		return nil, nil, errNoActiveClusters
+	}
+	return clients, reconnecting, nil
+}
+
+// reconnectGraceRemaining returns the time left until the reconnect grace
+// period expires for all the worker clusters of the group which lost their
+// connection, or 0 if it already expired.
+func (w *wlReconciler) reconnectGraceRemaining(group *wlGroup) time.Duration {
+	var remaining time.Duration
+	for _, rc := range group.reconnecting {
+		if disconnectedAt := rc.disconnectedAt.Load(); disconnectedAt != nil {
+			remaining = max(remaining, w.workerReconnectGracePeriod-w.clock.Since(*disconnectedAt))
+		}
 	}
-	return clients, nil
+	return remaining
 }
 
 func (w *wlReconciler) multikueueAC(ctx context.Context, local *kueue.Workload) (*kueue.AdmissionCheckState, error) {
@@ -296,7 +334,7 @@ func (w *wlReconciler) adapter(local *kueue.Workload) (jobframework.MultiKueueAd
 }
 
 func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acName kueue.AdmissionCheckReference, adapter jobframework.MultiKueueAdapter, controllerName string) (*wlGroup, error) {
-	rClients, err := w.remoteClientsForAC(ctx, acName)
+	rClients, reconnecting, err := w.remoteClientsForAC(ctx, acName)
 	if err != nil {
 		return nil, fmt.Errorf("admission check %q: %w", acName, err)
 	}
@@ -305,6 +343,7 @@ func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acN
 		local:         local,
 		remotes:       make(map[string]*kueue.Workload, len(rClients)),
 		remoteClients: rClients,
+		reconnecting:  reconnecting,
 		acName:        acName,
 		jobAdapter:    adapter,
 		controllerKey: types.NamespacedName{Name: controllerName, Namespace: local.Namespace},
@@ -394,6 +433,17 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 
 		acs := workload.FindAdmissionCheck(group.local.Status.AdmissionChecks, group.acName)
 		if err := group.jobAdapter.SyncJob(ctx, w.client, group.remoteClients[reservingRemote].client, group.controllerKey, group.local.Name, w.origin); err != nil {
+			if errors.Is(err, jobframework.ErrPrebuiltWorkloadMismatch) {
+				// The remote controller object belongs to another workload, retrying the sync can't
+				// succeed. Put the workload back in the queue, the remote objects are removed on eviction.
+				log.V(2).Info("Remote controller object belongs to another workload", "remote", reservingRemote, "error", err.Error())
+				msg := api.TruncateConditionMessage(fmt.Sprintf("Failed to sync the job with %q: %v", reservingRemote, err))
+				if err := w.updateACS(ctx, group.local, acs, kueue.CheckStateRetry, msg); err != nil {
+					return reconcile.Result{}, err
+				}
+				w.recorder.Eventf(group.local, corev1.EventTypeWarning, "MultiKueue", msg)
+				return reconcile.Result{}, nil
+			}
 			log.V(2).Error(err, "creating remote controller object", "remote", reservingRemote)
 			// We'll retry this in the next reconcile.
 			return reconcile.Result{}, err
@@ -422,6 +472,12 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 	} else if acs.State == kueue.CheckStateReady {
 		// If there is no reserving and the AC is ready, the connection with the reserving remote might
 		// be lost, keep the workload admitted for keepReadyTimeout and put it back in the queue after that.
+		// While reconnecting to the worker clusters, the workload is kept admitted until the reconnect
+		// grace period expires.
+		if graceRemaining := w.reconnectGraceRemaining(group); graceRemaining > 0 {
+			log.V(3).Info("Reconnecting to the worker clusters, retry", "retryAfter", graceRemaining)
+			return reconcile.Result{RequeueAfter: graceRemaining}, nil
+		}
 		remainingWaitTime := w.workerLostTimeout - time.Since(acs.LastTransitionTime.Time)
 		if remainingWaitTime > 0 {
 			log.V(3).Info("Reserving remote lost, retry", "retryAfter", remainingWaitTime)
@@ -471,7 +527,7 @@ func (w *wlReconciler) Generic(_ event.GenericEvent) bool {
 	return true
 }
 
-func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clustersReconciler, origin string, recorder record.EventRecorder, workerLostTimeout, eventsBatchPeriod time.Duration, adapters map[string]jobframework.MultiKueueAdapter, opts ...Option) *wlReconciler {
+func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clustersReconciler, origin string, recorder record.EventRecorder, workerLostTimeout, workerReconnectGracePeriod, eventsBatchPeriod time.Duration, adapters, disabledAdapters map[string]jobframework.MultiKueueAdapter, opts ...Option) *wlReconciler {
 	options := defaultOptions
 
 	for _, opt := range opts {
@@ -479,16 +535,18 @@ func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clust
 	}
 
 	return &wlReconciler{
-		client:            c,
-		helper:            helper,
-		clusters:          cRec,
-		origin:            origin,
-		workerLostTimeout: workerLostTimeout,
-		deletedWlCache:    utilmaps.NewSyncMap[string, *kueue.Workload](0),
-		eventsBatchPeriod: eventsBatchPeriod,
-		adapters:          adapters,
-		recorder:          recorder,
-		clock:             options.clock,
+		client:                     c,
+		helper:                     helper,
+		clusters:                   cRec,
+		origin:                     origin,
+		workerLostTimeout:          workerLostTimeout,
+		workerReconnectGracePeriod: workerReconnectGracePeriod,
+		deletedWlCache:             utilmaps.NewSyncMap[string, *kueue.Workload](0),
+		eventsBatchPeriod:          eventsBatchPeriod,
+		adapters:                   adapters,
+		disabledAdapters:           disabledAdapters,
+		recorder:                   recorder,
+		clock:                      options.clock,
 	}
 }
 
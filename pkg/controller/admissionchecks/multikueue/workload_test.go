@@ -19,6 +19,7 @@ package multikueue
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -51,6 +53,16 @@ var (
 	errFake = errors.New("fake error")
 )
 
+// failingSyncJobAdapter is a MultiKueueAdapter failing to sync the job with the workers.
+type failingSyncJobAdapter struct {
+	jobframework.MultiKueueAdapter
+	err error
+}
+
+func (a *failingSyncJobAdapter) SyncJob(context.Context, client.Client, client.Client, types.NamespacedName, string, string) error {
+	return a.err
+}
+
 func TestWlReconcile(t *testing.T) {
 	now := time.Now()
 	fakeClock := testingclock.NewFakeClock(now)
@@ -75,15 +87,22 @@ func TestWlReconcile(t *testing.T) {
 		worker1Workloads         []kueue.Workload
 		worker1Jobs              []batchv1.Job
 		withoutJobManagedBy      bool
+		disabledIntegrations     []string
+		// workerReconnectGracePeriod is the reconnect grace period of the reconciler.
+		workerReconnectGracePeriod time.Duration
+		// syncJobError is returned by the job adapter when syncing the job with the workers.
+		syncJobError error
 
 		// second worker
-		useSecondWorker      bool
-		worker2Reconnecting  bool
-		worker2OnDeleteError error
-		worker2OnGetError    error
-		worker2OnCreateError error
-		worker2Workloads     []kueue.Workload
-		worker2Jobs          []batchv1.Job
+		useSecondWorker     bool
+		worker2Reconnecting bool
+		// worker2DisconnectedAt is the time the reconnecting worker2 lost its connection.
+		worker2DisconnectedAt *time.Time
+		worker2OnDeleteError  error
+		worker2OnGetError     error
+		worker2OnCreateError  error
+		worker2Workloads      []kueue.Workload
+		worker2Jobs           []batchv1.Job
 
 		wantError             error
 		wantEvents            []utiltesting.EventRecord
@@ -377,6 +396,51 @@ func TestWlReconcile(t *testing.T) {
 					Obj(),
 			},
 		},
+		"wl with reservation of a disabled integration is admitted locally": {
+			reconcileFor: "wl1",
+			managersJobs: []batchv1.Job{*baseJobBuilder.Clone().Obj()},
+			managersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			disabledIntegrations: []string{"batch/job"},
+			useSecondWorker:      true,
+
+			wantManagersJobs: []batchv1.Job{*baseJobBuilder.Clone().Obj()},
+			wantManagersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `MultiKueue is disabled for "batch/v1, Kind=Job", the workload is admitted locally`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+		},
+		"wl without reservation of a disabled integration is not admitted": {
+			reconcileFor: "wl1",
+			managersJobs: []batchv1.Job{*baseJobBuilder.Clone().Obj()},
+			managersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					Obj(),
+			},
+			disabledIntegrations: []string{"batch/job"},
+
+			wantManagersJobs: []batchv1.Job{*baseJobBuilder.Clone().Obj()},
+			wantManagersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					Obj(),
+			},
+		},
 		"remote wl with reservation, unable to delete the second worker's workload": {
 			reconcileFor: "wl1",
 			managersWorkloads: []kueue.Workload{
@@ -552,6 +616,55 @@ func TestWlReconcile(t *testing.T) {
 				},
 			},
 		},
+		"remote wl with reservation, the remote job belongs to another workload": {
+			reconcileFor: "wl1",
+			managersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			managersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			worker1Workloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+			syncJobError: fmt.Errorf(`%w: the remote Job ns/job1 belongs to the workload "wl0", expected "wl1"`, jobframework.ErrPrebuiltWorkloadMismatch),
+
+			wantManagersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateRetry,
+						Message: `Failed to sync the job with "worker1": prebuilt workload mismatch: the remote Job ns/job1 belongs to the workload "wl0", expected "wl1"`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			wantManagersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			wantWorker1Workloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       client.ObjectKeyFromObject(baseWorkloadBuilder.Clone().Obj()),
+					EventType: "Warning",
+					Reason:    "MultiKueue",
+					Message:   `Failed to sync the job with "worker1": prebuilt workload mismatch: the remote Job ns/job1 belongs to the workload "wl0", expected "wl1"`,
+				},
+			},
+		},
 		"remote job is changing status the local Job is updated ": {
 			reconcileFor: "wl1",
 			managersWorkloads: []kueue.Workload{
@@ -929,6 +1042,72 @@ func TestWlReconcile(t *testing.T) {
 					Obj(),
 			},
 		},
+		"the local workload's admission check is kept Ready while reconnecting to the worker within the grace period": {
+			reconcileFor: "wl1",
+			managersJobs: []batchv1.Job{*baseJobManagedByKueueBuilder.Clone().Obj()},
+			managersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStateReady,
+						LastTransitionTime: metav1.NewTime(now.Add(-defaultWorkerLostTimeout * 3 / 2)), // 150% of the timeout
+						Message:            `The workload got reservation on "worker2"`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			useSecondWorker:            true,
+			worker2Reconnecting:        true,
+			worker2DisconnectedAt:      ptr.To(now.Add(-time.Minute)),
+			workerReconnectGracePeriod: 5 * time.Minute,
+
+			wantManagersJobs: []batchv1.Job{*baseJobManagedByKueueBuilder.Clone().Obj()},
+			wantManagersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `The workload got reservation on "worker2"`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+		},
+		"the local workload's admission check is set to Retry once the reconnect grace period is exceeded": {
+			reconcileFor: "wl1",
+			managersJobs: []batchv1.Job{*baseJobManagedByKueueBuilder.Clone().Obj()},
+			managersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStateReady,
+						LastTransitionTime: metav1.NewTime(now.Add(-defaultWorkerLostTimeout * 3 / 2)), // 150% of the timeout
+						Message:            `The workload got reservation on "worker2"`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			useSecondWorker:            true,
+			worker2Reconnecting:        true,
+			worker2DisconnectedAt:      ptr.To(now.Add(-10 * time.Minute)),
+			workerReconnectGracePeriod: 5 * time.Minute,
+
+			wantManagersJobs: []batchv1.Job{*baseJobManagedByKueueBuilder.Clone().Obj()},
+			wantManagersWorkloads: []kueue.Workload{
+				*baseWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateRetry,
+						Message: `Reserving remote lost`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+		},
 		"worker reconnects after the local workload is requeued, remote objects are deleted": {
 			reconcileFor: "wl1",
 			managersJobs: []batchv1.Job{*baseJobManagedByKueueBuilder.Clone().Obj()},
@@ -1068,6 +1247,7 @@ func TestWlReconcile(t *testing.T) {
 
 			managerClient := managerBuilder.Build()
 			adapters, _ := jobframework.GetMultiKueueAdapters(sets.New("batch/job"))
+			disabledAdapters, _ := jobframework.GetMultiKueueAdapters(sets.New(tc.disabledIntegrations...))
 			cRec := newClustersReconciler(managerClient, TestNamespace, 0, defaultOrigin, nil, adapters)
 
 			worker1Builder := getClientBuilder(t.Context())
@@ -1110,12 +1290,20 @@ func TestWlReconcile(t *testing.T) {
 				if !tc.worker2Reconnecting {
 					w2remoteClient.connecting.Store(false)
 				}
+				w2remoteClient.disconnectedAt.Store(tc.worker2DisconnectedAt)
 				cRec.remoteClients["worker2"] = w2remoteClient
 			}
 
 			helper, _ := newMultiKueueStoreHelper(managerClient)
 			recorder := &utiltesting.EventRecorder{}
-			reconciler := newWlReconciler(managerClient, helper, cRec, defaultOrigin, recorder, defaultWorkerLostTimeout, time.Second, adapters, WithClock(t, fakeClock))
+			wlAdapters := adapters
+			if tc.syncJobError != nil {
+				wlAdapters = make(map[string]jobframework.MultiKueueAdapter, len(adapters))
+				for key, adapter := range adapters {
+					wlAdapters[key] = &failingSyncJobAdapter{MultiKueueAdapter: adapter, err: tc.syncJobError}
+				}
+			}
+			reconciler := newWlReconciler(managerClient, helper, cRec, defaultOrigin, recorder, defaultWorkerLostTimeout, tc.workerReconnectGracePeriod, time.Second, wlAdapters, disabledAdapters, WithClock(t, fakeClock))
 
 			for _, val := range tc.managersDeletedWorkloads {
 				reconciler.Delete(event.DeleteEvent{
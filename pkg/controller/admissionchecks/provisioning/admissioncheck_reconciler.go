@@ -50,13 +50,17 @@ func (a *acReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 		ObservedGeneration: ac.Generation,
 	}
 
-	if _, err := a.helper.ConfigFromRef(ctx, ac.Spec.Parameters); err != nil {
+	if prc, err := a.helper.ConfigFromRef(ctx, ac.Spec.Parameters); err != nil {
 		newCondition.Status = metav1.ConditionFalse
 		newCondition.Reason = "BadParametersRef"
 		newCondition.Message = err.Error()
+	} else if err := validateConfig(prc); err != nil {
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = "InvalidParameters"
+		newCondition.Message = err.Error()
 	}
 
-	if currentCondition.Status != newCondition.Status {
+	if currentCondition.Status != newCondition.Status || currentCondition.Reason != newCondition.Reason || currentCondition.Message != newCondition.Message {
 		apimeta.SetStatusCondition(&ac.Status.Conditions, newCondition)
 		return reconcile.Result{}, a.client.Status().Update(ctx, ac)
 	}
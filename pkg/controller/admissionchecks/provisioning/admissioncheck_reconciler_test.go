@@ -95,6 +95,21 @@ func TestReconcileAdmissionCheck(t *testing.T) {
 				ObservedGeneration: 1,
 			},
 		},
+		"config invalid": {
+			check: utiltesting.MakeAdmissionCheck("check1").
+				Parameters(kueue.GroupVersion.Group, ConfigKind, "config1").
+				ControllerName(kueue.ProvisioningRequestControllerName).
+				Generation(1).
+				Obj(),
+			configs: []kueue.ProvisioningRequestConfig{*utiltesting.MakeProvisioningRequestConfig("config1").BaseBackoff(60).MaxBackoff(30).Obj()},
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "InvalidParameters",
+				Message:            "invalid ProvisioningRequestConfig \"config1\": spec.retryStrategy.backoffMaxSeconds: Invalid value: 30: must be greater than or equal to backoffBaseSeconds",
+				ObservedGeneration: 1,
+			},
+		},
 		"config found": {
 			check: utiltesting.MakeAdmissionCheck("check1").
 				Parameters(kueue.GroupVersion.Group, ConfigKind, "config1").
@@ -143,6 +143,13 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		if client.IgnoreNotFound(err) != nil {
 			return reconcile.Result{}, err
 		}
+		if prc != nil {
+			if err := validateConfig(prc); err != nil {
+				// the check is not active until the config is fixed
+				log.V(2).Info("Ignoring the invalid config of the admission check", "check", checkName, "err", err)
+				prc = nil
+			}
+		}
 		checkConfig[checkName] = prc
 	}
 
@@ -0,0 +1,82 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+var (
+	specPath             = field.NewPath("spec")
+	parametersPath       = specPath.Child("parameters")
+	managedResourcesPath = specPath.Child("managedResources")
+	retryStrategyPath    = specPath.Child("retryStrategy")
+)
+
+// validateConfig validates the ProvisioningRequestConfig referenced by the
+// admission check, beyond its CRD schema, so that the ProvisioningRequests
+// aren't created from an invalid config.
+func validateConfig(prc *kueue.ProvisioningRequestConfig) error {
+	var allErrs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(prc.Spec.Parameters)) {
+		for _, msg := range validation.IsQualifiedName(name) {
+			allErrs = append(allErrs, field.Invalid(parametersPath.Key(name), name, msg))
+		}
+	}
+	for i, name := range prc.Spec.ManagedResources {
+		for _, msg := range validation.IsQualifiedName(string(name)) {
+			allErrs = append(allErrs, field.Invalid(managedResourcesPath.Index(i), string(name), msg))
+		}
+	}
+	allErrs = append(allErrs, validateRetryStrategy(prc.Spec.RetryStrategy)...)
+	if len(allErrs) > 0 {
+		return fmt.Errorf("invalid %s %q: %w", ConfigKind, prc.Name, allErrs.ToAggregate())
+	}
+	return nil
+}
+
+func validateRetryStrategy(rs *kueue.ProvisioningRequestRetryStrategy) field.ErrorList {
+	if rs == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, f := range []struct {
+		name  string
+		value *int32
+	}{
+		{name: "backoffLimitCount", value: rs.BackoffLimitCount},
+		{name: "backoffBaseSeconds", value: rs.BackoffBaseSeconds},
+		{name: "backoffMaxSeconds", value: rs.BackoffMaxSeconds},
+	} {
+		if f.value != nil {
+			allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*f.value), retryStrategyPath.Child(f.name))...)
+		}
+	}
+	if rs.BackoffBaseSeconds != nil && rs.BackoffMaxSeconds != nil && *rs.BackoffMaxSeconds < *rs.BackoffBaseSeconds {
+		allErrs = append(allErrs, field.Invalid(retryStrategyPath.Child("backoffMaxSeconds"), *rs.BackoffMaxSeconds,
+			"must be greater than or equal to backoffBaseSeconds"))
+	}
+	return allErrs
+}
@@ -0,0 +1,81 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	cases := map[string]struct {
+		config  *kueue.ProvisioningRequestConfig
+		wantErr string
+	}{
+		"valid config": {
+			config: utiltesting.MakeProvisioningRequestConfig("config1").
+				ProvisioningClass("provisioning-class").
+				WithParameter("ValidUntilSeconds", "0").
+				WithManagedResource("example.com/gpu").
+				RetryLimit(3).
+				BaseBackoff(60).
+				MaxBackoff(1800).
+				Obj(),
+		},
+		"invalid parameter name": {
+			config: utiltesting.MakeProvisioningRequestConfig("config1").
+				WithParameter("Valid Until", "0").
+				Obj(),
+			wantErr: `invalid ProvisioningRequestConfig "config1": spec.parameters[Valid Until]: Invalid value: "Valid Until": ` +
+				`name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character ` +
+				`(e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')`,
+		},
+		"invalid managed resource": {
+			config: utiltesting.MakeProvisioningRequestConfig("config1").
+				ManagedResources([]corev1.ResourceName{"example.com/gpu", "example.com/-gpu"}).
+				Obj(),
+			wantErr: `invalid ProvisioningRequestConfig "config1": spec.managedResources[1]: Invalid value: "example.com/-gpu": ` +
+				`name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character ` +
+				`(e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')`,
+		},
+		"invalid retry strategy": {
+			config: utiltesting.MakeProvisioningRequestConfig("config1").
+				RetryLimit(-1).
+				BaseBackoff(60).
+				MaxBackoff(30).
+				Obj(),
+			wantErr: `invalid ProvisioningRequestConfig "config1": [spec.retryStrategy.backoffLimitCount: Invalid value: -1: must be greater than or equal to 0, ` +
+				`spec.retryStrategy.backoffMaxSeconds: Invalid value: 30: must be greater than or equal to backoffBaseSeconds]`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotErr string
+			if err := validateConfig(tc.config); err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
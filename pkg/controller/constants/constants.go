@@ -48,4 +48,26 @@ const (
 
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
+
+	// PodSetPreferredFlavorsAnnotation is the annotation key in the pod template
+	// of a podSet that holds the comma-separated list of the ResourceFlavors
+	// the podSet accepts, in the order of preference.
+	PodSetPreferredFlavorsAnnotation = "kueue.x-k8s.io/podset-preferred-flavors"
+
+	// AdmitDeadlineSecondsAnnotation is the annotation key in the workload, or in
+	// the job, that holds the number of seconds, since the creation of the workload,
+	// within which the workload should be admitted. Past the deadline, the workload
+	// is deactivated and declared finished.
+	AdmitDeadlineSecondsAnnotation = "kueue.x-k8s.io/admit-deadline-seconds"
+
+	// MayPreemptAnnotation is the annotation key in the workload, or in the job,
+	// which, when set to "false", indicates that the workload is only admitted
+	// if it fits without preempting other workloads.
+	MayPreemptAnnotation = "kueue.x-k8s.io/may-preempt"
+
+	// AdoptAnnotation is the annotation key in the job which, when set to "true"
+	// on a running job, indicates that Kueue creates an admitted workload for the
+	// job, instead of suspending it. It allows to set the queue name of the job
+	// while it is running.
+	AdoptAnnotation = "kueue.x-k8s.io/adopt"
 )
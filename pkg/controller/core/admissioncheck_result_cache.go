@@ -0,0 +1,216 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	cachedAdmissionCheckResultMessage = "Reused the result of the admission check from a previous quota reservation"
+
+	// admissionCheckResultTTL is the period, since an admission check turned
+	// Ready, during which its result can be reused.
+	admissionCheckResultTTL = 10 * time.Minute
+)
+
+// reservationTiedControllers are the admission check controllers whose
+// results are tied to the quota reservation they were evaluated for, like a
+// ProvisioningRequest or the workload dispatched to a worker cluster, which
+// are deleted when the quota reservation is released.
+var reservationTiedControllers = sets.New(
+	kueue.ProvisioningRequestControllerName,
+	kueue.MultiKueueControllerName,
+)
+
+// admissionCheckResultCache keeps the Ready results of the admission checks
+// of the workloads, so that the admission check controllers don't need to
+// evaluate them again when a workload gets the quota reserved again after an
+// eviction.
+// The results are only reused for a limited time, and while the PodSets of the
+// workload, its ClusterQueue and the flavors assigned to it don't change.
+type admissionCheckResultCache struct {
+	sync.Mutex
+	workloads map[string]*cachedAdmissionCheckResults
+}
+
+type cachedAdmissionCheckResults struct {
+	hash   string
+	checks map[kueue.AdmissionCheckReference]cachedAdmissionCheckResult
+}
+
+type cachedAdmissionCheckResult struct {
+	state   kueue.AdmissionCheckState
+	readyAt time.Time
+}
+
+func newAdmissionCheckResultCache() *admissionCheckResultCache {
+	return &admissionCheckResultCache{
+		workloads: make(map[string]*cachedAdmissionCheckResults),
+	}
+}
+
+// record updates the cached results with the admission check states of the
+// workload. The Ready checks are stored, unless their controller, given by
+// controllerOf, is unknown or tied to the quota reservation, while the Retry
+// and Rejected checks drop the previously stored results. The results stored
+// for another version of the workload or another admission are invalidated.
+func (c *admissionCheckResultCache) record(wl *kueue.Workload, now time.Time, controllerOf func(kueue.AdmissionCheckReference) string) {
+	if wl.Status.Admission == nil {
+		return
+	}
+	hash, err := admissionCheckResultsHash(wl)
+	if err != nil {
+		return
+	}
+	key := workload.Key(wl)
+
+	c.Lock()
+	defer c.Unlock()
+	results, found := c.workloads[key]
+	if !found || results.hash != hash {
+		results = &cachedAdmissionCheckResults{
+			hash:   hash,
+			checks: make(map[kueue.AdmissionCheckReference]cachedAdmissionCheckResult),
+		}
+	}
+	for _, check := range wl.Status.AdmissionChecks {
+		switch check.State {
+		case kueue.CheckStateReady:
+			if controller := controllerOf(check.Name); controller == "" || reservationTiedControllers.Has(controller) {
+				continue
+			}
+			result := cachedAdmissionCheckResult{state: *check.DeepCopy(), readyAt: now}
+			if cached, found := results.checks[check.Name]; found {
+				// Keep the time of the first Ready result, so that reusing
+				// the result doesn't extend its lifetime.
+				result.readyAt = cached.readyAt
+			}
+			results.checks[check.Name] = result
+		case kueue.CheckStateRetry, kueue.CheckStateRejected:
+			delete(results.checks, check.Name)
+		}
+	}
+	maps.DeleteFunc(results.checks, func(_ kueue.AdmissionCheckReference, r cachedAdmissionCheckResult) bool {
+		return r.expired(now)
+	})
+	if len(results.checks) == 0 {
+		delete(c.workloads, key)
+		return
+	}
+	c.workloads[key] = results
+}
+
+// restore sets the Pending admission checks of the workload, which were reset
+// before its current quota reservation, to their cached Ready results.
+// It returns whether any admission check was updated.
+func (c *admissionCheckResultCache) restore(wl *kueue.Workload, now time.Time) bool {
+	reserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if reserved == nil || reserved.Status != metav1.ConditionTrue || wl.Status.Admission == nil {
+		return false
+	}
+	hash, err := admissionCheckResultsHash(wl)
+	if err != nil {
+		return false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	results, found := c.workloads[workload.Key(wl)]
+	if !found {
+		return false
+	}
+	if results.hash != hash {
+		delete(c.workloads, workload.Key(wl))
+		return false
+	}
+	updated := false
+	for i := range wl.Status.AdmissionChecks {
+		check := &wl.Status.AdmissionChecks[i]
+		if check.State != kueue.CheckStatePending || check.LastTransitionTime.After(reserved.LastTransitionTime.Time) {
+			continue
+		}
+		cached, found := results.checks[check.Name]
+		if !found || cached.expired(now) {
+			continue
+		}
+		*check = *cached.state.DeepCopy()
+		check.LastTransitionTime = metav1.NewTime(now)
+		check.Message = cachedAdmissionCheckResultMessage
+		updated = true
+	}
+	return updated
+}
+
+// delete drops the cached results of the workload.
+func (c *admissionCheckResultCache) delete(wl *kueue.Workload) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.workloads, workload.Key(wl))
+}
+
+func (r *cachedAdmissionCheckResult) expired(now time.Time) bool {
+	return now.Sub(r.readyAt) > admissionCheckResultTTL
+}
+
+// admissionCheckResultsKey identifies the version of the workload spec and
+// the admission the admission check results apply to.
+type admissionCheckResultsKey struct {
+	PodSets      []kueue.PodSet                 `json:"podSets"`
+	ClusterQueue kueue.ClusterQueueReference    `json:"clusterQueue"`
+	Assignments  []admissionCheckResultsFlavors `json:"assignments"`
+}
+
+type admissionCheckResultsFlavors struct {
+	Name    kueue.PodSetReference                                 `json:"name"`
+	Flavors map[corev1.ResourceName]kueue.ResourceFlavorReference `json:"flavors"`
+	Count   *int32                                                `json:"count"`
+}
+
+// admissionCheckResultsHash returns the hash of the PodSets of the workload,
+// its ClusterQueue and the flavors assigned to its PodSets.
+func admissionCheckResultsHash(wl *kueue.Workload) (string, error) {
+	key := admissionCheckResultsKey{
+		PodSets:      wl.Spec.PodSets,
+		ClusterQueue: wl.Status.Admission.ClusterQueue,
+	}
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		key.Assignments = append(key.Assignments, admissionCheckResultsFlavors{
+			Name:    psa.Name,
+			Flavors: psa.Flavors,
+			Count:   psa.Count,
+		})
+	}
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(keyJSON)), nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAdmissionCheckResultCache(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	readyAt := now.Add(-3 * time.Minute)
+	evictedAt := now.Add(-2 * time.Minute)
+	reservedAt := now.Add(-time.Minute)
+
+	podSetUpdates := []kueue.PodSetUpdate{{
+		Name:        "main",
+		Annotations: map[string]string{"example.com/reservation": "res-1"},
+	}}
+	readyCheck := kueue.AdmissionCheckState{
+		Name:          "check",
+		State:         kueue.CheckStateReady,
+		Message:       "Approved",
+		PodSetUpdates: podSetUpdates,
+	}
+	resetCheck := kueue.AdmissionCheckState{
+		Name:               "check",
+		State:              kueue.CheckStatePending,
+		LastTransitionTime: metav1.NewTime(evictedAt),
+		Message:            "Reset to Pending after eviction. Previously: Ready",
+	}
+	resetProvCheck := kueue.AdmissionCheckState{
+		Name:               "prov-check",
+		State:              kueue.CheckStatePending,
+		LastTransitionTime: metav1.NewTime(evictedAt),
+		Message:            "Reset to Pending after eviction. Previously: Ready",
+	}
+	controllers := map[kueue.AdmissionCheckReference]string{
+		"check":      "example.com/check",
+		"prov-check": kueue.ProvisioningRequestControllerName,
+	}
+	admission := utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "flavor1", "1").Obj()
+	baseWorkload := utiltesting.MakeWorkload("wl", "ns").
+		PodSets(*utiltesting.MakePodSet("main", 2).Obj())
+	admittedWorkload := baseWorkload.Clone().ReserveQuotaAt(admission, readyAt)
+	reservedWorkload := baseWorkload.Clone().
+		ReserveQuotaAt(admission, reservedAt).
+		AdmissionCheck(resetCheck).
+		Obj()
+
+	cases := map[string]struct {
+		recorded   []*kueue.Workload
+		deleted    bool
+		workload   *kueue.Workload
+		restoreAt  time.Time
+		wantUpdate bool
+		wantChecks []kueue.AdmissionCheckState
+	}{
+		"the cached Ready result is reused": {
+			recorded:   []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload:   reservedWorkload.DeepCopy(),
+			wantUpdate: true,
+			wantChecks: []kueue.AdmissionCheckState{{
+				Name:               "check",
+				State:              kueue.CheckStateReady,
+				LastTransitionTime: metav1.NewTime(now),
+				Message:            cachedAdmissionCheckResultMessage,
+				PodSetUpdates:      podSetUpdates,
+			}},
+		},
+		"the cached result is invalidated when the PodSets change": {
+			recorded: []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 3).Obj()).
+				ReserveQuotaAt(admission, reservedAt).
+				AdmissionCheck(resetCheck).
+				Obj(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the cached result is invalidated when the assigned flavors change": {
+			recorded: []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload: baseWorkload.Clone().
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "flavor2", "1").Obj(), reservedAt).
+				AdmissionCheck(resetCheck).
+				Obj(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the cached result is invalidated when the ClusterQueue changes": {
+			recorded: []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload: baseWorkload.Clone().
+				ReserveQuotaAt(utiltesting.MakeAdmission("other-cq").Assignment(corev1.ResourceCPU, "flavor1", "1").Obj(), reservedAt).
+				AdmissionCheck(resetCheck).
+				Obj(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the cached result expires": {
+			recorded:   []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload:   reservedWorkload.DeepCopy(),
+			restoreAt:  readyAt.Add(admissionCheckResultTTL + time.Second),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the result of a check tied to the quota reservation is not cached": {
+			recorded: []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(kueue.AdmissionCheckState{
+				Name:  "prov-check",
+				State: kueue.CheckStateReady,
+			}).Obj()},
+			workload:   baseWorkload.Clone().ReserveQuotaAt(admission, reservedAt).AdmissionCheck(resetProvCheck).Obj(),
+			wantChecks: []kueue.AdmissionCheckState{resetProvCheck},
+		},
+		"the cached result is dropped when the check needs a retry": {
+			recorded: []*kueue.Workload{
+				admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj(),
+				admittedWorkload.Clone().AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateRetry,
+				}).Obj(),
+			},
+			workload:   reservedWorkload.DeepCopy(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the cached result is dropped when the workload is deleted": {
+			recorded:   []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			deleted:    true,
+			workload:   reservedWorkload.DeepCopy(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+		"the check turned Pending during the current quota reservation is not restored": {
+			recorded: []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload: baseWorkload.Clone().
+				ReserveQuotaAt(admission, reservedAt).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:               "check",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: metav1.NewTime(now),
+				}).
+				Obj(),
+			wantChecks: []kueue.AdmissionCheckState{{
+				Name:               "check",
+				State:              kueue.CheckStatePending,
+				LastTransitionTime: metav1.NewTime(now),
+			}},
+		},
+		"the cached result is not reused without quota reservation": {
+			recorded:   []*kueue.Workload{admittedWorkload.Clone().AdmissionCheck(readyCheck).Obj()},
+			workload:   baseWorkload.Clone().AdmissionCheck(resetCheck).Obj(),
+			wantChecks: []kueue.AdmissionCheckState{resetCheck},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newAdmissionCheckResultCache()
+			for _, wl := range tc.recorded {
+				c.record(wl, readyAt, func(name kueue.AdmissionCheckReference) string { return controllers[name] })
+			}
+			if tc.deleted {
+				c.delete(tc.workload)
+			}
+			restoreAt := now
+			if !tc.restoreAt.IsZero() {
+				restoreAt = tc.restoreAt
+			}
+			gotUpdate := c.restore(tc.workload, restoreAt)
+			if gotUpdate != tc.wantUpdate {
+				t.Errorf("Unexpected update, want=%v, got=%v", tc.wantUpdate, gotUpdate)
+			}
+			if diff := cmp.Diff(tc.wantChecks, tc.workload.Status.AdmissionChecks); diff != "" {
+				t.Errorf("Unexpected admission checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
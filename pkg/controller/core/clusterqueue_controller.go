@@ -24,12 +24,16 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -53,8 +57,10 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/resource"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -79,6 +85,7 @@ type ClusterQueueReconciler struct {
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
+	recorder                             record.EventRecorder
 }
 
 var _ reconcile.Reconciler = (*ClusterQueueReconciler)(nil)
@@ -90,6 +97,7 @@ type ClusterQueueReconcilerOptions struct {
 	FairSharingEnabled                   bool
 	QueueVisibilityUpdateInterval        time.Duration
 	QueueVisibilityClusterQueuesMaxCount int32
+	EventRecorder                        record.EventRecorder
 	clock                                clock.Clock
 }
 
@@ -130,6 +138,14 @@ func WithQueueVisibilityClusterQueuesMaxCount(value int32) ClusterQueueReconcile
 	}
 }
 
+// WithEventRecorder sets the recorder for the events emitted for the
+// ClusterQueues, such as when they are near their capacity.
+func WithEventRecorder(recorder record.EventRecorder) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.EventRecorder = recorder
+	}
+}
+
 var defaultCQOptions = ClusterQueueReconcilerOptions{
 	clock: realClock,
 }
@@ -158,10 +174,13 @@ func NewClusterQueueReconciler(
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
 		clock:                                options.clock,
+		recorder:                             options.EventRecorder,
 	}
 }
 
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues/status,verbs=get;update;patch
@@ -186,6 +205,11 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				return ctrl.Result{}, client.IgnoreNotFound(err)
 			}
 		}
+		if features.Enabled(features.ElasticClusterQueueQuota) {
+			if err := r.updateElasticQuotas(ctx, &cqObj); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+		}
 	} else {
 		if !r.cache.ClusterQueueTerminating(kueue.ClusterQueueReference(cqObj.Name)) {
 			r.cache.TerminateClusterQueue(kueue.ClusterQueueReference(cqObj.Name))
@@ -212,6 +236,57 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
+// updateElasticQuotas sets the nominalQuota of the resources with an
+// elasticQuota to the allocatable capacity of their pool of nodes.
+func (r *ClusterQueueReconciler) updateElasticQuotas(ctx context.Context, cq *kueue.ClusterQueue) error {
+	changed := false
+	for i := range cq.Spec.ResourceGroups {
+		for j := range cq.Spec.ResourceGroups[i].Flavors {
+			fq := &cq.Spec.ResourceGroups[i].Flavors[j]
+			for k := range fq.Resources {
+				rq := &fq.Resources[k]
+				if rq.ElasticQuota == nil {
+					continue
+				}
+				capacity, err := r.nodePoolCapacity(ctx, rq.ElasticQuota.NodeLabels, rq.Name)
+				if err != nil {
+					return err
+				}
+				if capacity.Cmp(rq.NominalQuota) != 0 {
+					ctrl.LoggerFrom(ctx).V(2).Info("Updating the elastic quota", "flavor", fq.Name, "resource", rq.Name,
+						"oldNominalQuota", rq.NominalQuota.String(), "newNominalQuota", capacity.String())
+					rq.NominalQuota = capacity
+					changed = true
+				}
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.client.Update(ctx, cq)
+}
+
+// nodePoolCapacity returns the sum of the allocatable quantities of the
+// resource on the ready and schedulable nodes with the given labels.
+func (r *ClusterQueueReconciler) nodePoolCapacity(ctx context.Context, nodeLabels map[string]string, name corev1.ResourceName) (apiresource.Quantity, error) {
+	var nodes corev1.NodeList
+	if err := r.client.List(ctx, &nodes, client.MatchingLabels(nodeLabels)); err != nil {
+		return apiresource.Quantity{}, err
+	}
+	capacity := apiresource.MustParse("0")
+	for i := range nodes.Items {
+		if isNodeInElasticPool(&nodes.Items[i]) {
+			capacity.Add(nodes.Items[i].Status.Allocatable[name])
+		}
+	}
+	return capacity, nil
+}
+
+func isNodeInElasticPool(node *corev1.Node) bool {
+	return !node.Spec.Unschedulable && utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady)
+}
+
 // NotifyTopologyUpdate triggers a topology update event only on creation or deletion,
 // as these are the only changes affecting the ClusterQueue's active state.
 func (r *ClusterQueueReconciler) NotifyTopologyUpdate(oldTopology, newTopology *kueuealpha.Topology) {
@@ -346,6 +421,10 @@ func (r *ClusterQueueReconciler) Delete(e event.TypedDeleteEvent[*kueue.ClusterQ
 	r.qManager.DeleteSnapshot(e.Object)
 
 	metrics.ClearClusterQueueResourceMetrics(e.Object.Name)
+	metrics.ClearClusterQueueNearCapacity(e.Object.Name)
+	if r.reportResourceMetrics {
+		r.reportCohortResourceMetrics(e.Object.Spec.Cohort)
+	}
 	r.log.V(2).Info("Cleared resource metrics for deleted ClusterQueue.", "clusterQueue", klog.KObj(e.Object))
 
 	return true
@@ -370,6 +449,9 @@ func (r *ClusterQueueReconciler) Update(e event.TypedUpdateEvent[*kueue.ClusterQ
 
 	if r.reportResourceMetrics {
 		updateResourceMetrics(e.ObjectOld, e.ObjectNew)
+		if e.ObjectOld.Spec.Cohort != e.ObjectNew.Spec.Cohort {
+			r.reportCohortResourceMetrics(e.ObjectOld.Spec.Cohort)
+		}
 	}
 	return true
 }
@@ -411,6 +493,32 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 	}
 }
 
+// reportCohortResourceMetrics reports the aggregated nominal quota and usage of
+// the cohort and of each of its ancestors. The metrics of a cohort which no
+// longer exists are cleared.
+func (r *ClusterQueueReconciler) reportCohortResourceMetrics(cohortName kueue.CohortReference) {
+	if cohortName == "" {
+		return
+	}
+	stats, err := r.cache.CohortTreeResourceStats(cohortName)
+	if err != nil {
+		r.log.V(2).Info("Skipped reporting the cohort resource metrics", "cohort", cohortName, "error", err)
+		return
+	}
+	if stats == nil {
+		metrics.ClearCohortResourceMetrics(cohortName)
+		return
+	}
+	for name, cohortStats := range stats {
+		metrics.ClearCohortResourceMetrics(name)
+		for fr, v := range cohortStats.NominalQuota {
+			nominal := resources.ResourceQuantity(fr.Resource, v)
+			usage := resources.ResourceQuantity(fr.Resource, cohortStats.AdmittedUsage[fr])
+			metrics.ReportCohortResourceMetrics(name, string(fr.Flavor), string(fr.Resource), resource.QuantityToFloat(&nominal), resource.QuantityToFloat(&usage))
+		}
+	}
+}
+
 func updateResourceMetrics(oldCq, newCq *kueue.ClusterQueue) {
 	// if the cohort changed, drop all the old metrics
 	if oldCq.Spec.Cohort != newCq.Spec.Cohort {
@@ -521,6 +629,95 @@ func (h *cqNamespaceHandler) Delete(context.Context, event.DeleteEvent, workqueu
 func (h *cqNamespaceHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 }
 
+// cqPodDisruptionBudgetHandler handles PodDisruptionBudget events, to retry the
+// preemptions which were deferred because they would violate the budget.
+type cqPodDisruptionBudgetHandler struct {
+	qManager *queue.Manager
+	cache    *cache.Cache
+}
+
+func (h *cqPodDisruptionBudgetHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cqPodDisruptionBudgetHandler) Update(ctx context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldPDB := e.ObjectOld.(*policyv1.PodDisruptionBudget)
+	newPDB := e.ObjectNew.(*policyv1.PodDisruptionBudget)
+	if newPDB.Status.DisruptionsAllowed > oldPDB.Status.DisruptionsAllowed {
+		h.qManager.QueueInadmissibleWorkloads(ctx, h.cache.ActiveClusterQueues())
+	}
+}
+
+func (h *cqPodDisruptionBudgetHandler) Delete(ctx context.Context, _ event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.qManager.QueueInadmissibleWorkloads(ctx, h.cache.ActiveClusterQueues())
+}
+
+func (h *cqPodDisruptionBudgetHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// cqNodeHandler handles Node events, to update the elastic quotas of the
+// ClusterQueues following the pools of the nodes.
+type cqNodeHandler struct {
+	client client.Client
+}
+
+func (h *cqNodeHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueElasticClusterQueues(ctx, q, e.Object.(*corev1.Node))
+}
+
+func (h *cqNodeHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldNode := e.ObjectOld.(*corev1.Node)
+	newNode := e.ObjectNew.(*corev1.Node)
+	if equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) &&
+		equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable) &&
+		isNodeInElasticPool(oldNode) == isNodeInElasticPool(newNode) {
+		return
+	}
+	h.queueElasticClusterQueues(ctx, q, oldNode, newNode)
+}
+
+func (h *cqNodeHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueElasticClusterQueues(ctx, q, e.Object.(*corev1.Node))
+}
+
+func (h *cqNodeHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// queueElasticClusterQueues queues the ClusterQueues with an elastic quota
+// following a pool which any of the nodes belongs to.
+func (h *cqNodeHandler) queueElasticClusterQueues(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], nodes ...*corev1.Node) {
+	var cqs kueue.ClusterQueueList
+	if err := h.client.List(ctx, &cqs); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list the ClusterQueues for the node event")
+		return
+	}
+	for i := range cqs.Items {
+		if elasticQuotaMatchesAnyNode(&cqs.Items[i], nodes) {
+			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
+				Name: cqs.Items[i].Name,
+			}}, constants.UpdatesBatchPeriod)
+		}
+	}
+}
+
+func elasticQuotaMatchesAnyNode(cq *kueue.ClusterQueue, nodes []*corev1.Node) bool {
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				if rq.ElasticQuota == nil {
+					continue
+				}
+				selector := labels.SelectorFromSet(rq.ElasticQuota.NodeLabels)
+				if slices.ContainsFunc(nodes, func(node *corev1.Node) bool {
+					return selector.Matches(labels.Set(node.Labels))
+				}) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 type nonCQObjectHandler struct{}
 
 var _ handler.TypedEventHandler[iter.Seq[kueue.ClusterQueueReference], reconcile.Request] = (*nonCQObjectHandler)(nil)
@@ -579,7 +776,7 @@ func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.
 	snapHandler := cqSnapshotHandler{
 		queueVisibilityUpdateInterval: r.queueVisibilityUpdateInterval,
 	}
-	return builder.TypedControllerManagedBy[reconcile.Request](mgr).
+	b := builder.TypedControllerManagedBy[reconcile.Request](mgr).
 		Named("clusterqueue_controller").
 		WatchesRawSource(source.TypedKind(
 			mgr.GetCache(),
@@ -590,8 +787,17 @@ func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		Watches(&corev1.Namespace{}, &nsHandler).
 		WatchesRawSource(source.Channel(r.snapUpdateCh, &snapHandler)).
-		WatchesRawSource(source.Channel(r.nonCQObjectUpdateCh, &nonCQObjectHandler{})).
-		Complete(WithLeadingManager(mgr, r, &kueue.ClusterQueue{}, cfg))
+		WatchesRawSource(source.Channel(r.nonCQObjectUpdateCh, &nonCQObjectHandler{}))
+	if features.Enabled(features.PreemptionRespectsPodDisruptionBudgets) {
+		b = b.Watches(&policyv1.PodDisruptionBudget{}, &cqPodDisruptionBudgetHandler{
+			qManager: r.qManager,
+			cache:    r.cache,
+		})
+	}
+	if features.Enabled(features.ElasticClusterQueueQuota) {
+		b = b.Watches(&corev1.Node{}, &cqNodeHandler{client: r.client})
+	}
+	return b.Complete(WithLeadingManager(mgr, r, &kueue.ClusterQueue{}, cfg))
 }
 
 func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
@@ -614,6 +820,10 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 		return err
 	}
 	cq.Status.FlavorsReservation = stats.ReservedResources
+	nearCapacityCrossed := r.reportNearCapacity(cq, oldStatus)
+	if r.reportResourceMetrics {
+		r.reportCohortResourceMetrics(cq.Spec.Cohort)
+	}
 	cq.Status.FlavorsUsage = stats.AdmittedResources
 	cq.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
 	cq.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
@@ -638,11 +848,78 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 		cq.Status.FairSharing = nil
 	}
 	if !equality.Semantic.DeepEqual(cq.Status, oldStatus) {
-		return r.client.Status().Update(ctx, cq)
+		if err := r.client.Status().Update(ctx, cq); err != nil {
+			return err
+		}
+		if nearCapacityCrossed {
+			r.recordNearCapacityEvent(cq)
+		}
 	}
 	return nil
 }
 
+// reportNearCapacity updates the near capacity metric of the ClusterQueue and
+// returns whether its quota reservation crossed the near capacity threshold.
+func (r *ClusterQueueReconciler) reportNearCapacity(cq *kueue.ClusterQueue, oldStatus *kueue.ClusterQueueStatus) bool {
+	threshold := cq.Spec.NearCapacityThresholdPercentage
+	if threshold == nil {
+		metrics.ClearClusterQueueNearCapacity(cq.Name)
+		return false
+	}
+	wasNearCapacity := isNearCapacity(cq, oldStatus.FlavorsReservation, *threshold)
+	nearCapacity := isNearCapacity(cq, cq.Status.FlavorsReservation, *threshold)
+	metrics.ReportClusterQueueNearCapacity(cq.Name, nearCapacity)
+	return nearCapacity != wasNearCapacity
+}
+
+// recordNearCapacityEvent emits an event for the crossing of the near capacity
+// threshold, once the new quota reservation is recorded in the status.
+func (r *ClusterQueueReconciler) recordNearCapacityEvent(cq *kueue.ClusterQueue) {
+	threshold := cq.Spec.NearCapacityThresholdPercentage
+	if r.recorder == nil || threshold == nil {
+		return
+	}
+	if isNearCapacity(cq, cq.Status.FlavorsReservation, *threshold) {
+		r.recorder.Eventf(cq, corev1.EventTypeWarning, kueue.ClusterQueueNearCapacityReason, "The quota reservation is above %d%% of the nominal quota", *threshold)
+	} else {
+		r.recorder.Eventf(cq, corev1.EventTypeNormal, kueue.ClusterQueueBelowNearCapacityReason, "The quota reservation is below %d%% of the nominal quota", *threshold)
+	}
+}
+
+// isNearCapacity returns whether the reservation is at or above the threshold
+// percentage of the nominal quota, for any of the flavors and resources of the
+// ClusterQueue.
+func isNearCapacity(cq *kueue.ClusterQueue, reservation []kueue.FlavorUsage, threshold int32) bool {
+	for _, fu := range reservation {
+		for _, ru := range fu.Resources {
+			nominal, found := nominalQuota(cq, fu.Name, ru.Name)
+			if !found || nominal.IsZero() {
+				continue
+			}
+			if resource.QuantityToFloat(&ru.Total)*100 >= resource.QuantityToFloat(&nominal)*float64(threshold) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func nominalQuota(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, res corev1.ResourceName) (apiresource.Quantity, bool) {
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			if fq.Name != flavor {
+				continue
+			}
+			for _, rq := range fq.Resources {
+				if rq.Name == res {
+					return rq.NominalQuota, true
+				}
+			}
+		}
+	}
+	return apiresource.Quantity{}, false
+}
+
 // Taking snapshot of cluster queue is enabled when maxcount non-zero
 func (r *ClusterQueueReconciler) isVisibilityEnabled() bool {
 	return features.Enabled(features.QueueVisibility) && r.queueVisibilityClusterQueuesMaxCount > 0
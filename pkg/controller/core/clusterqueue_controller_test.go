@@ -18,16 +18,22 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
@@ -36,6 +42,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 )
 
 func TestUpdateCqStatusIfChanged(t *testing.T) {
@@ -249,6 +256,131 @@ func TestUpdateCqStatusIfChanged(t *testing.T) {
 	}
 }
 
+func TestUpdateCqStatusIfChangedNearCapacity(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "10").
+			Obj()).
+		NearCapacityThresholdPercentage(90).
+		Obj()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "ns").
+			Request(corev1.ResourceCPU, "5").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("b", "ns").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+	}
+
+	ctx, log := utiltesting.ContextWithLog(t)
+	var failStatusUpdate bool
+	cl := utiltesting.NewClientBuilder().WithObjects(cq).WithStatusSubresource(cq).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failStatusUpdate {
+					return errors.New("status update failed")
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in manager: %v", err)
+	}
+	recorder := &utiltesting.EventRecorder{}
+	r := &ClusterQueueReconciler{
+		client:   cl,
+		log:      log,
+		cache:    cqCache,
+		qManager: qManager,
+		recorder: recorder,
+	}
+	t.Cleanup(func() { metrics.ClearClusterQueueNearCapacity(cq.Name) })
+
+	steps := []struct {
+		name             string
+		update           func()
+		failStatusUpdate bool
+		wantErr          bool
+		wantEvents       []utiltesting.EventRecord
+		wantMetric       float64
+	}{
+		{
+			name:       "below the threshold",
+			update:     func() { cqCache.AddOrUpdateWorkload(workloads[0]) },
+			wantMetric: 0,
+		},
+		{
+			name:             "crossing above the threshold, the status update fails",
+			update:           func() { cqCache.AddOrUpdateWorkload(workloads[1]) },
+			failStatusUpdate: true,
+			wantErr:          true,
+			wantMetric:       1,
+		},
+		{
+			name:   "crossing above the threshold",
+			update: func() {},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       client.ObjectKeyFromObject(cq),
+				EventType: corev1.EventTypeWarning,
+				Reason:    kueue.ClusterQueueNearCapacityReason,
+				Message:   "The quota reservation is above 90% of the nominal quota",
+			}},
+			wantMetric: 1,
+		},
+		{
+			name:       "still above the threshold",
+			update:     func() {},
+			wantMetric: 1,
+		},
+		{
+			name: "crossing back below the threshold",
+			update: func() {
+				if err := cqCache.DeleteWorkload(workloads[0]); err != nil {
+					t.Fatalf("Deleting workload from cache: %v", err)
+				}
+			},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       client.ObjectKeyFromObject(cq),
+				EventType: corev1.EventTypeNormal,
+				Reason:    kueue.ClusterQueueBelowNearCapacityReason,
+				Message:   "The quota reservation is below 90% of the nominal quota",
+			}},
+			wantMetric: 0,
+		},
+	}
+	for _, step := range steps {
+		recorder.RecordedEvents = nil
+		step.update()
+		var gotCq kueue.ClusterQueue
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(cq), &gotCq); err != nil {
+			t.Fatalf("%s: Getting clusterQueue: %v", step.name, err)
+		}
+		failStatusUpdate = step.failStatusUpdate
+		if err := r.updateCqStatusIfChanged(ctx, &gotCq, metav1.ConditionTrue, "Ready", "Can admit new workloads"); (err != nil) != step.wantErr {
+			t.Fatalf("%s: Updating clusterQueue status: %v, want error %v", step.name, err, step.wantErr)
+		}
+		if diff := cmp.Diff(step.wantEvents, recorder.RecordedEvents, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("%s: Unexpected events (-want,+got):\n%s", step.name, diff)
+		}
+		wantDPs := []testingmetrics.MetricDataPoint{{
+			Labels: map[string]string{"cluster_queue": cq.Name},
+			Value:  step.wantMetric,
+		}}
+		gotDPs := testingmetrics.CollectFilteredGaugeVec(metrics.ClusterQueueNearCapacity, map[string]string{"cluster_queue": cq.Name})
+		if diff := cmp.Diff(wantDPs, gotDPs); diff != "" {
+			t.Errorf("%s: Unexpected near capacity metric (-want,+got):\n%s", step.name, diff)
+		}
+	}
+}
+
 type cqMetrics struct {
 	NominalDPs   []testingmetrics.MetricDataPoint
 	BorrowingDPs []testingmetrics.MetricDataPoint
@@ -605,3 +737,168 @@ func TestClusterQueuePendingWorkloadsStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestPodDisruptionBudgetHandler(t *testing.T) {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "default"},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+	pdbWithMoreDisruptions := pdb.DeepCopy()
+	pdbWithMoreDisruptions.Status.DisruptionsAllowed = 2
+
+	testCases := map[string]struct {
+		update           *event.UpdateEvent
+		delete           *event.DeleteEvent
+		wantInadmissible map[kueue.ClusterQueueReference][]string
+		wantPending      map[kueue.ClusterQueueReference][]string
+	}{
+		"disruptions allowed unchanged": {
+			update: &event.UpdateEvent{ObjectOld: pdb, ObjectNew: pdb.DeepCopy()},
+			wantInadmissible: map[kueue.ClusterQueueReference][]string{
+				"cq": {"default/wl"},
+			},
+		},
+		"disruptions allowed increased": {
+			update: &event.UpdateEvent{ObjectOld: pdb, ObjectNew: pdbWithMoreDisruptions},
+			wantPending: map[kueue.ClusterQueueReference][]string{
+				"cq": {"default/wl"},
+			},
+		},
+		"PodDisruptionBudget deleted": {
+			delete: &event.DeleteEvent{Object: pdb},
+			wantPending: map[kueue.ClusterQueueReference][]string{
+				"cq": {"default/wl"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").Obj()
+			lq := utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+			wl := utiltesting.MakeWorkload("wl", "default").Queue("lq").Obj()
+			cl := utiltesting.NewClientBuilder().WithObjects(utiltesting.MakeNamespace("default"), lq, cq, wl).Build()
+			cCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cCache)
+			if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting localQueue in manager: %v", err)
+			}
+			info := qManager.Heads(ctx)[0]
+			qManager.RequeueWorkload(ctx, &info, queue.RequeueReasonGeneric)
+
+			h := &cqPodDisruptionBudgetHandler{qManager: qManager, cache: cCache}
+			if tc.update != nil {
+				h.Update(ctx, *tc.update, nil)
+			}
+			if tc.delete != nil {
+				h.Delete(ctx, *tc.delete, nil)
+			}
+
+			if diff := cmp.Diff(tc.wantInadmissible, qManager.DumpInadmissible()); diff != "" {
+				t.Errorf("Unexpected inadmissible workloads (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPending, qManager.Dump()); diff != "" {
+				t.Errorf("Unexpected pending workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileElasticQuota(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ElasticClusterQueueQuota, true)
+
+	poolNode := func(name, cpu string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).
+			Label("node-pool", "x86").
+			StatusAllocatable(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)})
+	}
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("x86").
+			ResourceQuotaWrapper(corev1.ResourceCPU).ElasticQuota(map[string]string{"node-pool": "x86"}).Append().
+			ResourceQuotaWrapper(corev1.ResourceMemory).NominalQuota("10Gi").Append().
+			Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(
+			cq,
+			poolNode("node1", "4").Ready().Obj(),
+			poolNode("node2", "4").Ready().Obj(),
+			poolNode("not-ready", "4").NotReady().Obj(),
+			poolNode("unschedulable", "4").Unschedulable().Ready().Obj(),
+			poolNode("other-pool", "4").Label("node-pool", "arm").Ready().Obj(),
+		).
+		WithStatusSubresource(cq).
+		Build()
+	cCache := cache.New(cl)
+	if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	qManager := queue.NewManager(cl, cCache)
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in manager: %v", err)
+	}
+	r := NewClusterQueueReconciler(cl, qManager, cCache)
+
+	steps := []struct {
+		name             string
+		update           func() error
+		wantNominalQuota string
+	}{
+		{
+			name:             "ready and schedulable nodes of the pool",
+			wantNominalQuota: "8",
+		},
+		{
+			name: "node added to the pool",
+			update: func() error {
+				return cl.Create(ctx, poolNode("node3", "8").Ready().Obj())
+			},
+			wantNominalQuota: "16",
+		},
+		{
+			name: "node removed from the pool",
+			update: func() error {
+				return cl.Delete(ctx, poolNode("node1", "4").Obj())
+			},
+			wantNominalQuota: "12",
+		},
+		{
+			name: "node cordoned",
+			update: func() error {
+				return cl.Update(ctx, poolNode("node2", "4").Ready().Unschedulable().Obj())
+			},
+			wantNominalQuota: "8",
+		},
+	}
+	for _, step := range steps {
+		if step.update != nil {
+			if err := step.update(); err != nil {
+				t.Fatalf("%s: failed to update the nodes: %v", step.name, err)
+			}
+		}
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cq)}); err != nil {
+			t.Fatalf("%s: unexpected reconcile error: %v", step.name, err)
+		}
+		var gotCQ kueue.ClusterQueue
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(cq), &gotCQ); err != nil {
+			t.Fatalf("%s: failed to get the ClusterQueue: %v", step.name, err)
+		}
+		gotQuotas := gotCQ.Spec.ResourceGroups[0].Flavors[0].Resources
+		wantNominalQuotas := map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    resource.MustParse(step.wantNominalQuota),
+			corev1.ResourceMemory: resource.MustParse("10Gi"),
+		}
+		for _, rq := range gotQuotas {
+			if wantQuota := wantNominalQuotas[rq.Name]; rq.NominalQuota.Cmp(wantQuota) != 0 {
+				t.Errorf("%s: unexpected nominalQuota of %s, want=%s, got=%s", step.name, rq.Name, wantQuota.String(), rq.NominalQuota.String())
+			}
+		}
+	}
+}
@@ -19,6 +19,9 @@ package core
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
@@ -35,7 +38,7 @@ const (
 // SetupControllers sets up the core controllers. It returns the name of the
 // controller that failed to create and an error, if any.
 func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache, cfg *configapi.Configuration) (string, error) {
-	rfRec := NewResourceFlavorReconciler(mgr.GetClient(), qManager, cc)
+	rfRec := NewResourceFlavorReconciler(mgr.GetClient(), qManager, cc, mgr.GetEventRecorderFor(constants.WorkloadControllerName))
 	if err := rfRec.SetupWithManager(mgr, cfg); err != nil {
 		return "ResourceFlavor", err
 	}
@@ -72,6 +75,7 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		WithQueueVisibilityClusterQueuesMaxCount(queueVisibilityClusterQueuesMaxCount(cfg)),
 		WithFairSharing(fairSharingEnabled),
 		WithWatchers(watchers...),
+		WithEventRecorder(mgr.GetEventRecorderFor(constants.ClusterQueueControllerName)),
 	)
 	if err := mgr.Add(cqRec); err != nil {
 		return "Unable to add ClusterQueue to manager", err
@@ -82,14 +86,40 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "ClusterQueue", err
 	}
 
+	workloadWatchers := []WorkloadUpdateWatcher{qRec, cqRec, rfRec}
+	if cfg.WorkloadNotifications != nil {
+		notifier := NewWorkloadNotifier(cfg.WorkloadNotifications, clock.RealClock{})
+		if err := mgr.Add(notifier); err != nil {
+			return "Unable to add WorkloadNotifier to manager", err
+		}
+		workloadWatchers = append(workloadWatchers, notifier)
+	}
+	if cfg.AuditLog != nil {
+		auditor, err := NewWorkloadAuditor(cfg.AuditLog, clock.RealClock{})
+		if err != nil {
+			return "WorkloadAuditor", err
+		}
+		workloadWatchers = append(workloadWatchers, auditor)
+	}
+
 	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
 		mgr.GetEventRecorderFor(constants.WorkloadControllerName),
-		WithWorkloadUpdateWatchers(qRec, cqRec),
+		WithWorkloadUpdateWatchers(workloadWatchers...),
 		WithWaitForPodsReady(waitForPodsReady(cfg.WaitForPodsReady)),
+		WithWorkloadRetention(cfg.ObjectRetentionPolicies),
+		WithRequeuing(cfg.Requeuing),
+		WithAdmissionCooldown(cfg.Scheduler),
 	).SetupWithManager(mgr, cfg); err != nil {
 		return "Workload", err
 	}
 	qManager.AddTopologyUpdateWatcher(cqRec)
+
+	if res := cfg.Resources; res != nil && res.TransformationsConfigMapName != nil && features.Enabled(features.ConfigurableResourceTransformations) {
+		configMap := types.NamespacedName{Namespace: ptr.Deref(cfg.Namespace, ""), Name: *res.TransformationsConfigMapName}
+		if err := NewResourceTransformationsReconciler(mgr.GetClient(), qManager, configMap, res.Transformations).SetupWithManager(mgr); err != nil {
+			return "ResourceTransformations", err
+		}
+	}
 	return "", nil
 }
 
@@ -98,11 +128,15 @@ func waitForPodsReady(cfg *configapi.WaitForPodsReady) *waitForPodsReadyConfig {
 		return nil
 	}
 	result := waitForPodsReadyConfig{
-		timeout: cfg.Timeout.Duration,
+		timeout:            cfg.Timeout.Duration,
+		timeoutStartPolicy: ptr.Deref(cfg.TimeoutStartPolicy, configapi.PodsReadyTimeoutStartAdmission),
 	}
 	if cfg.RecoveryTimeout != nil {
 		result.recoveryTimeout = &cfg.RecoveryTimeout.Duration
 	}
+	if cfg.ReservationTimeout != nil {
+		result.reservationTimeout = &cfg.ReservationTimeout.Duration
+	}
 	if cfg.RequeuingStrategy != nil {
 		result.requeuingBackoffBaseSeconds = *cfg.RequeuingStrategy.BackoffBaseSeconds
 		result.requeuingBackoffLimitCount = cfg.RequeuingStrategy.BackoffLimitCount
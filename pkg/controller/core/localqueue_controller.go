@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -241,6 +242,10 @@ func (r *LocalQueueReconciler) Update(e event.TypedUpdateEvent[*kueue.LocalQueue
 		if err := r.cache.UpdateLocalQueue(e.ObjectOld, e.ObjectNew); err != nil {
 			log.Error(err, "Failed to update localQueue in the cache")
 		}
+		if !equality.Semantic.DeepEqual(e.ObjectOld.Spec.ResourceLimits, e.ObjectNew.Spec.ResourceLimits) {
+			ctx := logr.NewContext(context.Background(), log)
+			r.queues.QueueInadmissibleWorkloads(ctx, sets.New(e.ObjectNew.Spec.ClusterQueue))
+		}
 		return true
 	}
 
@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"sync"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// pendingRequeues keeps the workloads whose quota reservation was released
+// after an eviction, until their requeue is recorded in the
+// .status.requeueCount field.
+type pendingRequeues struct {
+	sync.Mutex
+	workloads map[string]types.UID
+}
+
+func newPendingRequeues() *pendingRequeues {
+	return &pendingRequeues{
+		workloads: make(map[string]types.UID),
+	}
+}
+
+// observe records the requeue of the workload when the update releases its
+// quota reservation after an eviction which counts as a requeue.
+func (p *pendingRequeues) observe(oldWl, newWl *kueue.Workload) {
+	if !workload.HasQuotaReservation(oldWl) || workload.HasQuotaReservation(newWl) || !workload.IsActive(newWl) {
+		return
+	}
+	evCond := apimeta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadEvicted)
+	if evCond == nil || evCond.Status != metav1.ConditionTrue || !countsAsRequeue(evCond.Reason) {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.workloads[workload.Key(newWl)] = newWl.UID
+}
+
+// has returns whether the requeue of the workload is yet to be recorded.
+func (p *pendingRequeues) has(wl *kueue.Workload) bool {
+	p.Lock()
+	defer p.Unlock()
+	uid, found := p.workloads[workload.Key(wl)]
+	return found && uid == wl.UID
+}
+
+// delete drops the requeue of the workload.
+func (p *pendingRequeues) delete(wl *kueue.Workload) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.workloads, workload.Key(wl))
+}
+
+// countsAsRequeue returns whether the eviction with the given reason counts
+// towards the maximum number of requeues. The deactivation of the workload,
+// the drain of its nodes, or the stop of its queue, doesn't count.
+func countsAsRequeue(reason string) bool {
+	switch reason {
+	case kueue.WorkloadDeactivated, kueue.WorkloadEvictedByClusterQueueStopped, kueue.WorkloadEvictedByLocalQueueStopped,
+		kueue.WorkloadEvictedByNodeDrain:
+		return false
+	}
+	return true
+}
@@ -18,12 +18,19 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -40,6 +47,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 type ResourceFlavorUpdateWatcher interface {
@@ -53,7 +61,15 @@ type ResourceFlavorReconciler struct {
 	cache      *cache.Cache
 	client     client.Client
 	cqUpdateCh chan event.GenericEvent
+	wlUpdateCh chan event.GenericEvent
 	watchers   []ResourceFlavorUpdateWatcher
+	recorder   record.EventRecorder
+	clock      clock.Clock
+
+	// deletingFlavors are the flavors whose deletion is blocked by the
+	// workloads with quota reserved using them.
+	deletingFlavorsLock sync.Mutex
+	deletingFlavors     sets.Set[kueue.ResourceFlavorReference]
 }
 
 var _ reconcile.Reconciler = (*ResourceFlavorReconciler)(nil)
@@ -63,18 +79,25 @@ func NewResourceFlavorReconciler(
 	client client.Client,
 	qMgr *queue.Manager,
 	cache *cache.Cache,
+	recorder record.EventRecorder,
 ) *ResourceFlavorReconciler {
 	return &ResourceFlavorReconciler{
-		log:        ctrl.Log.WithName("resourceflavor-reconciler"),
-		cache:      cache,
-		client:     client,
-		qManager:   qMgr,
-		cqUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		log:             ctrl.Log.WithName("resourceflavor-reconciler"),
+		cache:           cache,
+		client:          client,
+		qManager:        qMgr,
+		cqUpdateCh:      make(chan event.GenericEvent, updateChBuffer),
+		wlUpdateCh:      make(chan event.GenericEvent, updateChBuffer),
+		recorder:        recorder,
+		clock:           realClock,
+		deletingFlavors: sets.New[kueue.ResourceFlavorReference](),
 	}
 }
 
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch;update;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 
 func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var flavor kueue.ResourceFlavor
@@ -97,7 +120,8 @@ func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	} else {
 		if controllerutil.ContainsFinalizer(&flavor, kueue.ResourceInUseFinalizerName) {
-			if cqs := r.cache.ClusterQueuesUsingFlavor(kueue.ResourceFlavorReference(flavor.Name)); len(cqs) != 0 {
+			flavorRef := kueue.ResourceFlavorReference(flavor.Name)
+			if cqs := r.cache.ClusterQueuesUsingFlavor(flavorRef); len(cqs) != 0 {
 				log.V(3).Info("resourceFlavor is still in use", "ClusterQueues", cqs)
 				// We avoid to return error here to prevent backoff requeue, which is passive and wasteful.
 				// Instead, we drive the removal of finalizer by ClusterQueue Update/Delete events
@@ -105,10 +129,24 @@ func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				return ctrl.Result{}, nil
 			}
 
+			r.setDeleting(flavorRef, true)
+			if wls := r.cache.WorkloadsUsingFlavor(flavorRef); len(wls) != 0 {
+				if ptr.Deref(flavor.Spec.DeletionPolicy, kueue.ResourceFlavorDeletionBlock) == kueue.ResourceFlavorDeletionEvictWorkloads {
+					if err := r.evictWorkloads(ctx, flavorRef, wls); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
+				log.V(3).Info("resourceFlavor is still used by workloads", "workloads", workload.References(wls))
+				// Similarly, we drive the removal of finalizer by Workload Update/Delete events
+				// when the workloads release the quota reservation.
+				return ctrl.Result{}, nil
+			}
+
 			controllerutil.RemoveFinalizer(&flavor, kueue.ResourceInUseFinalizerName)
 			if err := r.client.Update(ctx, &flavor); err != nil {
 				return ctrl.Result{}, err
 			}
+			r.setDeleting(flavorRef, false)
 			log.V(5).Info("Removed finalizer")
 		}
 	}
@@ -116,6 +154,75 @@ func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return ctrl.Result{}, nil
 }
 
+// evictWorkloads evicts the workloads with quota reserved using the flavor
+// being deleted.
+func (r *ResourceFlavorReconciler) evictWorkloads(ctx context.Context, flavor kueue.ResourceFlavorReference, wls []*workload.Info) error {
+	log := ctrl.LoggerFrom(ctx)
+	message := fmt.Sprintf("The ResourceFlavor %s assigned to the workload is being deleted", flavor)
+	for _, wlInfo := range wls {
+		if apimeta.IsStatusConditionTrue(wlInfo.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		wl := wlInfo.Obj.DeepCopy()
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByResourceFlavorDeletion, message)
+		workload.ResetChecksOnEviction(wl, r.clock.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+		log.V(3).Info("Workload is evicted due to the deletion of the ResourceFlavor", "workload", klog.KObj(wl))
+		workload.ReportEvictedWorkload(r.recorder, wl, wlInfo.ClusterQueue, kueue.WorkloadEvictedByResourceFlavorDeletion, message)
+	}
+	return nil
+}
+
+func (r *ResourceFlavorReconciler) setDeleting(flavor kueue.ResourceFlavorReference, deleting bool) {
+	r.deletingFlavorsLock.Lock()
+	defer r.deletingFlavorsLock.Unlock()
+	if deleting {
+		r.deletingFlavors.Insert(flavor)
+	} else {
+		r.deletingFlavors.Delete(flavor)
+	}
+}
+
+// NotifyWorkloadUpdate triggers the reconciliation of the flavors being
+// deleted, when a workload using them releases the quota reservation.
+func (r *ResourceFlavorReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl == nil || oldWl.Status.Admission == nil || !workload.HasQuotaReservation(oldWl) {
+		return
+	}
+	if newWl != nil && workload.HasQuotaReservation(newWl) {
+		return
+	}
+	flavors := r.deletingFlavorsUsedBy(oldWl)
+	// Send the events after releasing the lock, so that a full channel
+	// doesn't block the reconciler from updating the deleting flavors.
+	for flavor := range flavors {
+		r.wlUpdateCh <- event.GenericEvent{Object: &kueue.ResourceFlavor{ObjectMeta: metav1.ObjectMeta{Name: string(flavor)}}}
+	}
+}
+
+// deletingFlavorsUsedBy returns the deleting flavors assigned to the workload.
+func (r *ResourceFlavorReconciler) deletingFlavorsUsedBy(wl *kueue.Workload) sets.Set[kueue.ResourceFlavorReference] {
+	r.deletingFlavorsLock.Lock()
+	defer r.deletingFlavorsLock.Unlock()
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	if r.deletingFlavors.Len() == 0 {
+		return flavors
+	}
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, flavor := range psa.Flavors {
+			if r.deletingFlavors.Has(flavor) {
+				flavors.Insert(flavor)
+			}
+		}
+	}
+	return flavors
+}
+
 func (r *ResourceFlavorReconciler) AddUpdateWatcher(watchers ...ResourceFlavorUpdateWatcher) {
 	r.watchers = append(r.watchers, watchers...)
 }
@@ -241,6 +348,34 @@ func (h *cqHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue
 	}
 }
 
+// rfNodeHandler keeps the labels of the nodes in the cache, to track the
+// number of nodes matching the ResourceFlavors. It doesn't queue any
+// reconcile request.
+type rfNodeHandler struct {
+	cache *cache.Cache
+}
+
+func (h *rfNodeHandler) Create(_ context.Context, e event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if node, ok := e.Object.(*corev1.Node); ok {
+		h.cache.AddOrUpdateNode(node)
+	}
+}
+
+func (h *rfNodeHandler) Update(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if node, ok := e.ObjectNew.(*corev1.Node); ok {
+		h.cache.AddOrUpdateNode(node)
+	}
+}
+
+func (h *rfNodeHandler) Delete(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if node, ok := e.Object.(*corev1.Node); ok {
+		h.cache.DeleteNode(node)
+	}
+}
+
+func (h *rfNodeHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ResourceFlavorReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	h := cqHandler{
@@ -256,6 +391,8 @@ func (r *ResourceFlavorReconciler) SetupWithManager(mgr ctrl.Manager, cfg *confi
 		)).
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		WatchesRawSource(source.Channel(r.cqUpdateCh, &h)).
+		WatchesRawSource(source.Channel(r.wlUpdateCh, &handler.EnqueueRequestForObject{})).
+		Watches(&corev1.Node{}, &rfNodeHandler{cache: r.cache}).
 		Complete(WithLeadingManager(mgr, r, &kueue.ResourceFlavor{}, cfg))
 }
 
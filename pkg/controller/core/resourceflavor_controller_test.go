@@ -0,0 +1,155 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestResourceFlavorReconcileDeletion(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+
+	// The ClusterQueue no longer references the flavor being deleted, but
+	// the workload admitted before the update still uses it.
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("other").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	admittedWorkload := utiltesting.MakeWorkload("wl", "ns").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "deleted", "1").Obj(), now).
+		Admitted(true).
+		Obj()
+	evictionMessage := "The ResourceFlavor deleted assigned to the workload is being deleted"
+
+	cases := map[string]struct {
+		flavor       *kueue.ResourceFlavor
+		workload     *kueue.Workload
+		wantFinalize bool
+		wantWorkload *kueue.Workload
+		wantEvents   []utiltesting.EventRecord
+	}{
+		"the finalizer is removed when no workload uses the flavor": {
+			flavor:       utiltesting.MakeResourceFlavor("deleted").Obj(),
+			wantFinalize: true,
+		},
+		"the deletion is blocked while a workload uses the flavor": {
+			flavor:       utiltesting.MakeResourceFlavor("deleted").Obj(),
+			workload:     admittedWorkload.DeepCopy(),
+			wantWorkload: admittedWorkload.DeepCopy(),
+		},
+		"the deletion is blocked while a workload uses the flavor, with the Block policy": {
+			flavor:       utiltesting.MakeResourceFlavor("deleted").DeletionPolicy(kueue.ResourceFlavorDeletionBlock).Obj(),
+			workload:     admittedWorkload.DeepCopy(),
+			wantWorkload: admittedWorkload.DeepCopy(),
+		},
+		"the workloads using the flavor are evicted, with the EvictWorkloads policy": {
+			flavor:   utiltesting.MakeResourceFlavor("deleted").DeletionPolicy(kueue.ResourceFlavorDeletionEvictWorkloads).Obj(),
+			workload: admittedWorkload.DeepCopy(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "deleted", "1").Obj(), now).
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByResourceFlavorDeletion,
+					Message: evictionMessage,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       client.ObjectKeyFromObject(admittedWorkload),
+				EventType: corev1.EventTypeNormal,
+				Reason:    "EvictedDueToResourceFlavorDeleted",
+				Message:   evictionMessage,
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.flavor.Finalizers = []string{kueue.ResourceInUseFinalizerName}
+			tc.flavor.DeletionTimestamp = ptr.To(metav1.NewTime(now))
+			objs := []client.Object{tc.flavor, cq}
+			if tc.workload != nil {
+				objs = append(objs, tc.workload)
+			}
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(objs...).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			recorder := &utiltesting.EventRecorder{}
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewResourceFlavorReconciler(cl, qManager, cqCache, recorder)
+			reconciler.clock = fakeClock
+
+			ctx, _ := utiltesting.ContextWithLog(t)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if tc.workload != nil && !cqCache.AddOrUpdateWorkload(tc.workload) {
+				t.Fatalf("Inserting workload in cache failed")
+			}
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.flavor)}); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+
+			var gotFlavor kueue.ResourceFlavor
+			err := cl.Get(ctx, client.ObjectKeyFromObject(tc.flavor), &gotFlavor)
+			if gotFinalized := errors.IsNotFound(err); gotFinalized != tc.wantFinalize {
+				t.Errorf("Unexpected finalization of the flavor, want=%v, got=%v (error: %v)", tc.wantFinalize, gotFinalized, err)
+			}
+
+			if tc.workload != nil {
+				var gotWorkload kueue.Workload
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), &gotWorkload); err != nil {
+					t.Fatalf("Could not get the workload after reconcile: %v", err)
+				}
+				if diff := cmp.Diff(tc.wantWorkload, &gotWorkload, workloadCmpOpts...); diff != "" {
+					t.Errorf("Unexpected workload after reconcile (-want,+got):\n%s", diff)
+				}
+
+				// Releasing the quota reservation triggers the reconciliation of the flavor.
+				reconciler.NotifyWorkloadUpdate(tc.workload, nil)
+				if len(reconciler.wlUpdateCh) != 1 {
+					t.Errorf("Expected the flavor to be requeued after the workload released the quota reservation")
+				}
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+)
+
+const (
+	// ResourceTransformationsKey is the key of the ConfigMap, referenced by
+	// .resources.transformationsConfigMapName, holding the resource transformations.
+	ResourceTransformationsKey = "transformations"
+)
+
+// ResourceTransformationsReconciler watches the ConfigMap holding the
+// resource transformations, and recomputes the requests of the pending
+// workloads when it changes.
+type ResourceTransformationsReconciler struct {
+	client    client.Client
+	qManager  *queue.Manager
+	configMap types.NamespacedName
+	// transformations are the resource transformations from the configuration,
+	// which are overridden by the ones from the ConfigMap with the same input.
+	transformations []config.ResourceTransformation
+}
+
+var _ reconcile.Reconciler = (*ResourceTransformationsReconciler)(nil)
+
+func NewResourceTransformationsReconciler(client client.Client, qMgr *queue.Manager, configMap types.NamespacedName, transformations []config.ResourceTransformation) *ResourceTransformationsReconciler {
+	return &ResourceTransformationsReconciler{
+		client:          client,
+		qManager:        qMgr,
+		configMap:       configMap,
+		transformations: transformations,
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+func (r *ResourceTransformationsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	var cm corev1.ConfigMap
+	var overrides []config.ResourceTransformation
+	if err := r.client.Get(ctx, req.NamespacedName, &cm); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	} else if err == nil {
+		if overrides, err = parseResourceTransformations(cm.Data[ResourceTransformationsKey]); err != nil {
+			// Retrying doesn't help, the ConfigMap needs to be fixed.
+			log.Error(err, "Invalid resource transformations, keeping the previous ones")
+			return ctrl.Result{}, nil
+		}
+	}
+	transforms := mergeResourceTransformations(r.transformations, overrides)
+	log.V(2).Info("Recomputing the requests of the pending workloads", "transformations", len(transforms))
+	r.qManager.UpdateResourceTransformations(ctx, transforms)
+	return ctrl.Result{}, nil
+}
+
+// parseResourceTransformations parses the resource transformations, in the
+// format of .resources.transformations, from the ConfigMap data.
+func parseResourceTransformations(data string) ([]config.ResourceTransformation, error) {
+	var transforms []config.ResourceTransformation
+	if err := yaml.UnmarshalStrict([]byte(data), &transforms); err != nil {
+		return nil, err
+	}
+	seen := sets.New[corev1.ResourceName]()
+	for i := range transforms {
+		if seen.Has(transforms[i].Input) {
+			return nil, fmt.Errorf("duplicate input %q", transforms[i].Input)
+		}
+		seen.Insert(transforms[i].Input)
+		strategy := ptr.Deref(transforms[i].Strategy, config.Retain)
+		if strategy != config.Retain && strategy != config.Replace {
+			return nil, fmt.Errorf("unsupported strategy %q for input %q", strategy, transforms[i].Input)
+		}
+		transforms[i].Strategy = ptr.To(strategy)
+	}
+	return transforms, nil
+}
+
+// mergeResourceTransformations returns the base transformations, with the
+// ones having the same input replaced by the overrides, followed by the
+// remaining overrides.
+func mergeResourceTransformations(base, overrides []config.ResourceTransformation) []config.ResourceTransformation {
+	result := make([]config.ResourceTransformation, 0, len(base)+len(overrides))
+	overridesByInput := utilslices.ToRefMap(overrides, func(t *config.ResourceTransformation) corev1.ResourceName { return t.Input })
+	baseInputs := sets.New[corev1.ResourceName]()
+	for _, t := range base {
+		baseInputs.Insert(t.Input)
+		if o, found := overridesByInput[t.Input]; found {
+			t = *o
+		}
+		result = append(result, t)
+	}
+	for _, o := range overrides {
+		if !baseInputs.Has(o.Input) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceTransformationsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return builder.ControllerManagedBy(mgr).
+		Named("resourcetransformations_controller").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.configMap.Namespace && obj.GetName() == r.configMap.Name
+		}))).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestResourceTransformationsReconcile(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ConfigurableResourceTransformations, true)
+	configMapKey := types.NamespacedName{Namespace: "kueue-system", Name: "resource-transformations"}
+	staticTransformations := []config.ResourceTransformation{
+		{
+			Input:    "example.com/gpu",
+			Strategy: ptr.To(config.Replace),
+			Outputs:  corev1.ResourceList{"example.com/credits": resource.MustParse("2")},
+		},
+		{
+			Input:    "example.com/fpga",
+			Strategy: ptr.To(config.Replace),
+			Outputs:  corev1.ResourceList{"example.com/credits": resource.MustParse("1")},
+		},
+	}
+	configMap := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: configMapKey.Namespace, Name: configMapKey.Name},
+			Data:       map[string]string{ResourceTransformationsKey: data},
+		}
+	}
+
+	cases := map[string]struct {
+		configMap    *corev1.ConfigMap
+		wantRequests map[string]resources.Requests
+	}{
+		"the requests are recomputed with the transformations from the ConfigMap": {
+			configMap: configMap(`
+- input: example.com/gpu
+  strategy: Replace
+  outputs:
+    example.com/credits: "5"
+- input: example.com/tpu
+  outputs:
+    example.com/credits: "7"
+`),
+			wantRequests: map[string]resources.Requests{
+				"gpu":  {"example.com/credits": 5},
+				"fpga": {"example.com/credits": 1},
+				"tpu":  {"example.com/credits": 7, "example.com/tpu": 1},
+			},
+		},
+		"the transformations from the configuration are restored when the ConfigMap is deleted": {
+			wantRequests: map[string]resources.Requests{
+				"gpu":  {"example.com/credits": 2},
+				"fpga": {"example.com/credits": 1},
+				"tpu":  {"example.com/tpu": 1},
+			},
+		},
+		"the previous transformations are kept when the ConfigMap is invalid": {
+			configMap: configMap(`
+- input: example.com/gpu
+  strategy: Drop
+`),
+			wantRequests: map[string]resources.Requests{
+				"gpu":  {"example.com/credits": 3},
+				"fpga": {"example.com/credits": 1},
+				"tpu":  {"example.com/tpu": 1},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			objs := []client.Object{utiltesting.MakeNamespace("ns")}
+			if tc.configMap != nil {
+				objs = append(objs, tc.configMap)
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			qManager := queue.NewManager(cl, cache.New(cl), queue.WithResourceTransformations(staticTransformations))
+			if err := qManager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding clusterQueue: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding localQueue: %v", err)
+			}
+			// The requests before the reconciliation are computed with a different gpu transformation.
+			qManager.UpdateResourceTransformations(ctx, []config.ResourceTransformation{
+				{
+					Input:    "example.com/gpu",
+					Strategy: ptr.To(config.Replace),
+					Outputs:  corev1.ResourceList{"example.com/credits": resource.MustParse("3")},
+				},
+				staticTransformations[1],
+			})
+			for wlName, resourceName := range map[string]corev1.ResourceName{"gpu": "example.com/gpu", "fpga": "example.com/fpga", "tpu": "example.com/tpu"} {
+				wl := utiltesting.MakeWorkload(wlName, "ns").Queue("lq").Request(resourceName, "1").Obj()
+				if err := qManager.AddOrUpdateWorkload(wl); err != nil {
+					t.Fatalf("Failed adding workload %s: %v", wlName, err)
+				}
+			}
+
+			reconciler := NewResourceTransformationsReconciler(cl, qManager, configMapKey, staticTransformations)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configMapKey}); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+
+			gotRequests := make(map[string]resources.Requests)
+			for _, info := range qManager.PendingWorkloadsInfo("cq") {
+				gotRequests[info.Obj.Name] = info.TotalRequests[0].Requests
+			}
+			if diff := cmp.Diff(tc.wantRequests, gotRequests); diff != "" {
+				t.Errorf("Unexpected requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeResourceTransformations(t *testing.T) {
+	base := []config.ResourceTransformation{
+		{Input: "example.com/gpu", Strategy: ptr.To(config.Retain)},
+		{Input: "example.com/fpga", Strategy: ptr.To(config.Retain)},
+	}
+	overrides := []config.ResourceTransformation{
+		{Input: "example.com/tpu", Strategy: ptr.To(config.Replace)},
+		{Input: "example.com/fpga", Strategy: ptr.To(config.Replace)},
+	}
+	want := []config.ResourceTransformation{
+		{Input: "example.com/gpu", Strategy: ptr.To(config.Retain)},
+		{Input: "example.com/fpga", Strategy: ptr.To(config.Replace)},
+		{Input: "example.com/tpu", Strategy: ptr.To(config.Replace)},
+	}
+	if diff := cmp.Diff(want, mergeResourceTransformations(base, overrides)); diff != "" {
+		t.Errorf("Unexpected transformations (-want,+got):\n%s", diff)
+	}
+}
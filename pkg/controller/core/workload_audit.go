@@ -0,0 +1,105 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// auditLogStdout is the audit log path which stands for the standard output.
+const auditLogStdout = "-"
+
+// WorkloadEventPreempted is only recorded in the audit log. The workload
+// notifications report the preemptions as evictions.
+const WorkloadEventPreempted WorkloadEventType = "Preempted"
+
+// WorkloadAuditor records the lifecycle transitions of the workloads as JSON
+// lines in an audit log. Unlike the Kubernetes Events, the records don't
+// expire.
+type WorkloadAuditor struct {
+	log   logr.Logger
+	clock clock.Clock
+
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+var _ WorkloadUpdateWatcher = (*WorkloadAuditor)(nil)
+
+// NewWorkloadAuditor returns an auditor appending the records to the file
+// configured in the audit log, or writing them to the standard output.
+func NewWorkloadAuditor(cfg *config.AuditLog, clock clock.Clock) (*WorkloadAuditor, error) {
+	if cfg.Path == auditLogStdout {
+		return newWorkloadAuditor(os.Stdout, clock), nil
+	}
+	f, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening the audit log: %w", err)
+	}
+	return newWorkloadAuditor(f, clock), nil
+}
+
+func newWorkloadAuditor(w io.Writer, clock clock.Clock) *WorkloadAuditor {
+	return &WorkloadAuditor{
+		log:     ctrl.Log.WithName("workload-auditor"),
+		clock:   clock,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// NotifyWorkloadUpdate implements WorkloadUpdateWatcher.
+func (a *WorkloadAuditor) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl == nil || newWl == nil {
+		return
+	}
+	now := a.clock.Now()
+	if !workload.IsAdmitted(oldWl) && workload.IsAdmitted(newWl) {
+		a.record(newWorkloadEvent(WorkloadEventAdmitted, newWl, kueue.WorkloadAdmitted, now))
+	}
+	if !workload.IsEvicted(oldWl) && workload.IsEvicted(newWl) {
+		eventType := WorkloadEventEvicted
+		if cond := meta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadEvicted); cond.Reason == kueue.WorkloadEvictedByPreemption {
+			eventType = WorkloadEventPreempted
+		}
+		a.record(newWorkloadEvent(eventType, newWl, kueue.WorkloadEvicted, now))
+	}
+	if !workload.IsFinished(oldWl) && workload.IsFinished(newWl) {
+		a.record(newWorkloadEvent(WorkloadEventFinished, newWl, kueue.WorkloadFinished, now))
+	}
+}
+
+func (a *WorkloadAuditor) record(event WorkloadEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.encoder.Encode(event); err != nil {
+		a.log.Error(err, "Failed to write the audit record", "type", event.Type, "workload", klog.KRef(event.Namespace, event.Name))
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadAuditor(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltesting.MakeAdmission("cq").Obj()
+	pending := utiltesting.MakeWorkload("wl", "ns").UID("uid").Queue("lq")
+	admitted := pending.Clone().ReserveQuotaAt(admission, now).AdmittedAt(true, now)
+	preempted := admitted.Clone().Condition(metav1.Condition{
+		Type:               kueue.WorkloadEvicted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadEvictedByPreemption,
+		Message:            "Preempted to accommodate a higher priority Workload",
+		LastTransitionTime: metav1.NewTime(now),
+	})
+	evicted := admitted.Clone().Condition(metav1.Condition{
+		Type:               kueue.WorkloadEvicted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadEvictedByPodsReadyTimeout,
+		Message:            "Exceeded the PodsReady timeout",
+		LastTransitionTime: metav1.NewTime(now),
+	})
+	finished := admitted.Clone().Condition(metav1.Condition{
+		Type:               kueue.WorkloadFinished,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadFinishedReasonSucceeded,
+		Message:            "Job finished successfully",
+		LastTransitionTime: metav1.NewTime(now),
+	})
+
+	newRecord := func(eventType WorkloadEventType, reason, message string) WorkloadEvent {
+		return WorkloadEvent{
+			Type:         eventType,
+			Namespace:    "ns",
+			Name:         "wl",
+			UID:          "uid",
+			LocalQueue:   "lq",
+			ClusterQueue: "cq",
+			Reason:       reason,
+			Message:      message,
+			Time:         metav1.NewTime(now),
+		}
+	}
+
+	cases := map[string]struct {
+		updates     [][2]*kueue.Workload
+		wantRecords []WorkloadEvent
+	}{
+		"lifecycle with a preemption": {
+			updates: [][2]*kueue.Workload{
+				{pending.Clone().Obj(), admitted.Clone().Obj()},
+				{admitted.Clone().Obj(), preempted.Clone().Obj()},
+				{pending.Clone().Obj(), admitted.Clone().Obj()},
+				{admitted.Clone().Obj(), finished.Clone().Obj()},
+			},
+			wantRecords: []WorkloadEvent{
+				newRecord(WorkloadEventAdmitted, "ByTest", "Admitted by ClusterQueue cq"),
+				newRecord(WorkloadEventPreempted, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload"),
+				newRecord(WorkloadEventAdmitted, "ByTest", "Admitted by ClusterQueue cq"),
+				newRecord(WorkloadEventFinished, kueue.WorkloadFinishedReasonSucceeded, "Job finished successfully"),
+			},
+		},
+		"eviction": {
+			updates: [][2]*kueue.Workload{
+				{admitted.Clone().Obj(), evicted.Clone().Obj()},
+			},
+			wantRecords: []WorkloadEvent{
+				newRecord(WorkloadEventEvicted, kueue.WorkloadEvictedByPodsReadyTimeout, "Exceeded the PodsReady timeout"),
+			},
+		},
+		"no transition": {
+			updates: [][2]*kueue.Workload{
+				{admitted.Clone().Obj(), admitted.Clone().Obj()},
+				{nil, admitted.Clone().Obj()},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			auditor := newWorkloadAuditor(&out, testingclock.NewFakeClock(now))
+			for _, update := range tc.updates {
+				auditor.NotifyWorkloadUpdate(update[0], update[1])
+			}
+
+			var gotRecords []WorkloadEvent
+			decoder := json.NewDecoder(&out)
+			for decoder.More() {
+				var record WorkloadEvent
+				if err := decoder.Decode(&record); err != nil {
+					t.Fatalf("Failed to decode the audit record: %v", err)
+				}
+				gotRecords = append(gotRecords, record)
+			}
+			if diff := cmp.Diff(tc.wantRecords, gotRecords); diff != "" {
+				t.Errorf("Unexpected audit records (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadAuditorFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write the audit log: %v", err)
+	}
+	auditor, err := NewWorkloadAuditor(&config.AuditLog{Path: path}, clock.RealClock{})
+	if err != nil {
+		t.Fatalf("Failed to create the auditor: %v", err)
+	}
+	pending := utiltesting.MakeWorkload("wl", "ns").Queue("lq")
+	admitted := pending.Clone().ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Admitted(true)
+	auditor.NotifyWorkloadUpdate(pending.Obj(), admitted.Obj())
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the audit log: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Expected the record to be appended to the audit log, got:\n%s", content)
+	}
+	var record WorkloadEvent
+	if err := json.Unmarshal(lines[1], &record); err != nil {
+		t.Fatalf("Failed to decode the audit record: %v", err)
+	}
+	if record.Type != WorkloadEventAdmitted || record.Name != "wl" || record.ClusterQueue != "cq" {
+		t.Errorf("Unexpected audit record: %+v", record)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
@@ -50,6 +52,8 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
@@ -60,13 +64,21 @@ import (
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
+const (
+	// quotaReleaseCheckInterval is how often the reconciler checks whether
+	// the quota of a finished workload is released, before removing its finalizer.
+	quotaReleaseCheckInterval = time.Second
+)
+
 var (
 	realClock = clock.RealClock{}
 )
 
 type waitForPodsReadyConfig struct {
 	timeout                     time.Duration
+	timeoutStartPolicy          config.PodsReadyTimeoutStartPolicy
 	recoveryTimeout             *time.Duration
+	reservationTimeout          *time.Duration
 	requeuingBackoffLimitCount  *int32
 	requeuingBackoffBaseSeconds int32
 	requeuingBackoffMaxDuration time.Duration
@@ -76,6 +88,10 @@ type waitForPodsReadyConfig struct {
 type options struct {
 	watchers               []WorkloadUpdateWatcher
 	waitForPodsReadyConfig *waitForPodsReadyConfig
+	deactivatedRetention   *time.Duration
+	deleteDeactivatedOwner bool
+	maxRequeues            *int32
+	admissionCooldown      time.Duration
 }
 
 // Option configures the reconciler.
@@ -88,6 +104,42 @@ func WithWaitForPodsReady(value *waitForPodsReadyConfig) Option {
 	}
 }
 
+// WithWorkloadRetention indicates the retention policies for the Workloads.
+func WithWorkloadRetention(value *config.ObjectRetentionPolicies) Option {
+	return func(o *options) {
+		if value == nil || value.Workloads == nil || value.Workloads.AfterDeactivated == nil {
+			o.deactivatedRetention = nil
+			o.deleteDeactivatedOwner = false
+			return
+		}
+		o.deactivatedRetention = &value.Workloads.AfterDeactivated.Duration
+		o.deleteDeactivatedOwner = ptr.Deref(value.Workloads.DeleteOwners, false)
+	}
+}
+
+// WithRequeuing indicates the configuration for the requeuing of the evicted Workloads.
+func WithRequeuing(value *config.Requeuing) Option {
+	return func(o *options) {
+		if value == nil {
+			o.maxRequeues = nil
+			return
+		}
+		o.maxRequeues = value.MaxCount
+	}
+}
+
+// WithAdmissionCooldown indicates the period after the quota reservation during
+// which a workload can't be preempted.
+func WithAdmissionCooldown(value *config.Scheduler) Option {
+	return func(o *options) {
+		if value == nil || value.AdmissionCooldown == nil {
+			o.admissionCooldown = 0
+			return
+		}
+		o.admissionCooldown = value.AdmissionCooldown.Duration
+	}
+}
+
 // WithWorkloadUpdateWatchers allows to specify the workload update watchers
 func WithWorkloadUpdateWatchers(value ...WorkloadUpdateWatcher) Option {
 	return func(o *options) {
@@ -109,8 +161,26 @@ type WorkloadReconciler struct {
 	client           client.Client
 	watchers         []WorkloadUpdateWatcher
 	waitForPodsReady *waitForPodsReadyConfig
-	recorder         record.EventRecorder
-	clock            clock.Clock
+	// deactivatedRetention is the period after which deactivated workloads
+	// are deleted. Nil means they are kept.
+	deactivatedRetention *time.Duration
+	// deleteDeactivatedOwner indicates whether the job owning a deactivated
+	// workload is deleted along with it.
+	deleteDeactivatedOwner bool
+	// maxRequeues is the number of requeues after which the workloads are
+	// deactivated. Nil means they are requeued without a limit.
+	maxRequeues *int32
+	// admissionCooldown is the period after the quota reservation during
+	// which a workload can't be preempted.
+	admissionCooldown time.Duration
+	// admissionCheckResults keeps the Ready results of the admission checks
+	// to reuse them when the workloads get the quota reserved again.
+	admissionCheckResults *admissionCheckResultCache
+	// pendingRequeues keeps the workloads whose requeue after an eviction
+	// is yet to be recorded in their status.
+	pendingRequeues *pendingRequeues
+	recorder        record.EventRecorder
+	clock           clock.Clock
 }
 
 var _ reconcile.Reconciler = (*WorkloadReconciler)(nil)
@@ -123,14 +193,20 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 	}
 
 	return &WorkloadReconciler{
-		log:              ctrl.Log.WithName("workload-reconciler"),
-		client:           client,
-		queues:           queues,
-		cache:            cache,
-		watchers:         options.watchers,
-		waitForPodsReady: options.waitForPodsReadyConfig,
-		recorder:         recorder,
-		clock:            realClock,
+		log:                    ctrl.Log.WithName("workload-reconciler"),
+		client:                 client,
+		queues:                 queues,
+		cache:                  cache,
+		watchers:               options.watchers,
+		waitForPodsReady:       options.waitForPodsReadyConfig,
+		deactivatedRetention:   options.deactivatedRetention,
+		deleteDeactivatedOwner: options.deleteDeactivatedOwner,
+		maxRequeues:            options.maxRequeues,
+		admissionCooldown:      options.admissionCooldown,
+		admissionCheckResults:  newAdmissionCheckResultCache(),
+		pendingRequeues:        newPendingRequeues(),
+		recorder:               recorder,
+		clock:                  realClock,
 	}
 }
 
@@ -152,13 +228,21 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	log.V(2).Info("Reconcile Workload")
 
 	if len(wl.OwnerReferences) == 0 && !wl.DeletionTimestamp.IsZero() {
+		// The quota of a finished workload is released when the cache processes
+		// its update event, so keep the finalizer until that happens.
+		if workload.IsFinished(&wl) && r.cache.IsAssumedOrAdmittedWorkload(*workload.NewInfo(&wl)) {
+			log.V(2).Info("Delaying the finalizer removal until the quota of the finished workload is released")
+			return ctrl.Result{RequeueAfter: quotaReleaseCheckInterval}, nil
+		}
 		return ctrl.Result{}, workload.RemoveFinalizer(ctx, r.client, &wl)
 	}
 
 	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
+		r.pendingRequeues.delete(&wl)
 		return ctrl.Result{}, nil
 	}
 
+	var admitDeadlineRecheckAfter time.Duration
 	if workload.IsActive(&wl) {
 		if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDeactivationTarget) {
 			wl.Spec.Active = ptr.To(false)
@@ -166,13 +250,30 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 
+		if r.pendingRequeues.has(&wl) {
+			workload.IncrementRequeueCount(&wl)
+			log.V(3).Info("Recording the requeue of the evicted workload", "requeueCount", *wl.Status.RequeueCount)
+			if err := workload.ApplyAdmissionStatus(ctx, r.client, &wl, true, r.clock); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+			r.pendingRequeues.delete(&wl)
+			return ctrl.Result{}, nil
+		}
+
+		if r.maxRequeues != nil && ptr.Deref(wl.Status.RequeueCount, 0) > *r.maxRequeues {
+			log.V(2).Info("Deactivating the workload exceeding the maximum number of requeues", "requeueCount", *wl.Status.RequeueCount)
+			workload.SetDeactivationTarget(&wl, kueue.WorkloadMaxRequeuesExceeded,
+				fmt.Sprintf("exceeding the maximum number of requeues (%d)", *r.maxRequeues))
+			return ctrl.Result{}, workload.ApplyAdmissionStatus(ctx, r.client, &wl, true, r.clock)
+		}
+
 		var updated bool
 		if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadRequeued); cond != nil && cond.Status == metav1.ConditionFalse {
 			switch cond.Reason {
 			case kueue.WorkloadDeactivated:
 				workload.SetRequeuedCondition(&wl, kueue.WorkloadReactivated, "The workload was reactivated", true)
 				updated = true
-			case kueue.WorkloadEvictedByPodsReadyTimeout, kueue.WorkloadEvictedByAdmissionCheck:
+			case kueue.WorkloadEvictedByPodsReadyTimeout, kueue.WorkloadEvictedByReservationNotReady, kueue.WorkloadEvictedByAdmissionCheck:
 				var requeueAfter time.Duration
 				if wl.Status.RequeueState != nil && wl.Status.RequeueState.RequeueAt != nil {
 					requeueAfter = wl.Status.RequeueState.RequeueAt.Sub(r.clock.Now())
@@ -191,6 +292,12 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if updated {
 			return ctrl.Result{}, workload.ApplyAdmissionStatus(ctx, r.client, &wl, true, r.clock)
 		}
+
+		recheckAfter, deactivated, err := r.reconcileAdmitDeadline(ctx, &wl)
+		if deactivated || err != nil {
+			return ctrl.Result{}, err
+		}
+		admitDeadlineRecheckAfter = recheckAfter
 	} else {
 		var updated, evicted bool
 		reason := kueue.WorkloadDeactivated
@@ -212,6 +319,16 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			wl.Status.RequeueState = nil
 			updated = true
 		}
+		r.pendingRequeues.delete(&wl)
+		if wl.Status.RequeueCount != nil {
+			wl.Status.RequeueCount = nil
+			updated = true
+		}
+		if wl.Status.AdmissionPath != "" && !workload.HasQuotaReservation(&wl) {
+			// The deactivated workload no longer waits for the preemptions it issued.
+			wl.Status.AdmissionPath = ""
+			updated = true
+		}
 		updated = workload.ResetChecksOnEviction(&wl, r.clock.Now()) || updated
 		if updated {
 			if err := workload.ApplyAdmissionStatus(ctx, r.client, &wl, true, r.clock); err != nil {
@@ -222,6 +339,12 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			}
 			return ctrl.Result{}, nil
 		}
+		if isEvictedByAdmitDeadline(&wl) && !workload.HasQuotaReservation(&wl) {
+			return ctrl.Result{}, r.finishOnAdmitDeadline(ctx, &wl)
+		}
+		if r.deactivatedRetention != nil && workload.IsEvictedByDeactivation(&wl) && !workload.HasQuotaReservation(&wl) {
+			return r.reconcileDeactivatedRetention(ctx, &wl)
+		}
 	}
 
 	lq := kueue.LocalQueue{}
@@ -252,6 +375,15 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if updated, err := r.reconcileSyncAdmissionChecks(ctx, &wl, &cq); updated || err != nil {
 			return ctrl.Result{}, err
 		}
+		if updated, err := r.reconcileCachedAdmissionCheckResults(ctx, &wl); updated || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if features.Enabled(features.AdmissionCheckPodSetResources) && workload.HasQuotaReservation(&wl) {
+		if evictionTriggered, err := r.reconcileAdmissionCheckResources(ctx, &wl); evictionTriggered || err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	// If the workload is admitted, updating the status here would set the Admitted condition to
@@ -265,7 +397,7 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			quotaReservedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
 			quotaReservedWaitTime := r.clock.Since(quotaReservedCondition.LastTransitionTime.Time)
 			r.recorder.Eventf(&wl, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was %.0fs", wl.Status.Admission.ClusterQueue, quotaReservedWaitTime.Seconds())
-			metrics.AdmittedWorkload(cqName, queuedWaitTime)
+			metrics.AdmittedWorkload(cqName, wl.Spec.PriorityClassName, queuedWaitTime)
 			metrics.AdmissionChecksWaitTime(cqName, quotaReservedWaitTime)
 			if features.Enabled(features.LocalQueueMetrics) {
 				metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(&wl), queuedWaitTime)
@@ -288,19 +420,34 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
+		holdRecheckAfter, err := r.reconcileReservationHold(ctx, &wl)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 		podsReadyRecheckAfter, err := r.reconcileNotReadyTimeout(ctx, req, &wl)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		reservationRecheckAfter, err := r.reconcileReservationNotReadyTimeout(ctx, &wl)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 		maxExecRecheckAfter, err := r.reconcileMaxExecutionTime(ctx, &wl)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		// recheck the Admitted condition once the scheduled quota becomes available
+		var startTimeRecheckAfter time.Duration
+		if startTime := workload.AdmissionStartTime(&wl); startTime != nil && !workload.IsAdmitted(&wl) {
+			startTimeRecheckAfter = startTime.Sub(r.clock.Now())
+		}
 
 		// get the minimun non-zero value
-		recheckAfter := min(podsReadyRecheckAfter, maxExecRecheckAfter)
-		if recheckAfter == 0 {
-			recheckAfter = max(podsReadyRecheckAfter, maxExecRecheckAfter)
+		var recheckAfter time.Duration
+		for _, d := range []time.Duration{holdRecheckAfter, podsReadyRecheckAfter, reservationRecheckAfter, maxExecRecheckAfter, startTimeRecheckAfter, admitDeadlineRecheckAfter} {
+			if d > 0 && (recheckAfter == 0 || d < recheckAfter) {
+				recheckAfter = d
+			}
 		}
 		return ctrl.Result{RequeueAfter: recheckAfter}, nil
 	}
@@ -332,7 +479,7 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: admitDeadlineRecheckAfter}, nil
 }
 
 // isDisabledRequeuedByClusterQueueStopped returns true if the workload is unset requeued by cluster queue stopped.
@@ -351,6 +498,59 @@ func isDisabledRequeuedByReason(w *kueue.Workload, reason string) bool {
 	return cond != nil && cond.Status == metav1.ConditionFalse && cond.Reason == reason
 }
 
+// admitDeadline returns the time by which the workload should be admitted, based on
+// the kueue.x-k8s.io/admit-deadline-seconds annotation. Invalid values are ignored.
+func admitDeadline(wl *kueue.Workload) (time.Time, bool) {
+	strVal, found := wl.Annotations[controllerconsts.AdmitDeadlineSecondsAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+	v, err := strconv.ParseInt(strVal, 10, 32)
+	if err != nil || v <= 0 {
+		return time.Time{}, false
+	}
+	return wl.CreationTimestamp.Add(time.Duration(v) * time.Second), true
+}
+
+// reconcileAdmitDeadline deactivates the workload if it wasn't admitted before its admit deadline
+// or returns a retry after value.
+func (r *WorkloadReconciler) reconcileAdmitDeadline(ctx context.Context, wl *kueue.Workload) (time.Duration, bool, error) {
+	if workload.IsAdmitted(wl) {
+		return 0, false, nil
+	}
+	deadline, found := admitDeadline(wl)
+	if !found {
+		return 0, false, nil
+	}
+	if remainingTime := deadline.Sub(r.clock.Now()); remainingTime > 0 {
+		return remainingTime, false, nil
+	}
+
+	ctrl.LoggerFrom(ctx).V(2).Info("Deactivating the workload which wasn't admitted before its admit deadline")
+	workload.SetDeactivationTarget(wl, kueue.WorkloadAdmitDeadlineExceeded, "exceeding the admit deadline")
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+		return 0, false, client.IgnoreNotFound(err)
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeWarning, kueue.WorkloadAdmitDeadlineExceeded, "The workload wasn't admitted within %ss", wl.Annotations[controllerconsts.AdmitDeadlineSecondsAnnotation])
+	return 0, true, nil
+}
+
+// isEvictedByAdmitDeadline returns true if the workload was deactivated because it exceeded its admit deadline.
+func isEvictedByAdmitDeadline(wl *kueue.Workload) bool {
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+	return cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.Reason == fmt.Sprintf("%sDueTo%s", kueue.WorkloadDeactivated, kueue.WorkloadAdmitDeadlineExceeded)
+}
+
+// finishOnAdmitDeadline declares the workload finished with a failure, once it's been
+// deactivated for exceeding its admit deadline, so that its job is finalized.
+func (r *WorkloadReconciler) finishOnAdmitDeadline(ctx context.Context, wl *kueue.Workload) error {
+	ctrl.LoggerFrom(ctx).V(2).Info("Declaring the workload finished since it exceeded its admit deadline")
+	err := workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadFinished, metav1.ConditionTrue, kueue.WorkloadAdmitDeadlineExceeded,
+		"The workload wasn't admitted before its admit deadline", constants.WorkloadControllerName, r.clock)
+	return client.IgnoreNotFound(err)
+}
+
 // reconcileMaxExecutionTime deactivates the workload if its MaximumExecutionTimeSeconds is exceeded or returns a retry after value.
 func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
 	admittedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
@@ -374,6 +574,40 @@ func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *
 	return 0, nil
 }
 
+// reconcileDeactivatedRetention deletes the deactivated workload once the
+// retention period since its deactivation expired. Otherwise, it requeues the
+// workload for when the period expires.
+// A workload owned by a job is only deleted, along with the job, when the
+// deletion of the owners is enabled, as the job would recreate it.
+func (r *WorkloadReconciler) reconcileDeactivatedRetention(ctx context.Context, wl *kueue.Workload) (ctrl.Result, error) {
+	owner := metav1.GetControllerOf(wl)
+	if owner != nil && !r.deleteDeactivatedOwner {
+		return ctrl.Result{}, nil
+	}
+	evictedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+	remainingTime := *r.deactivatedRetention - r.clock.Since(evictedCondition.LastTransitionTime.Time)
+	if remainingTime > 0 {
+		return ctrl.Result{RequeueAfter: remainingTime}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	if owner != nil {
+		ownerObj := &metav1.PartialObjectMetadata{}
+		ownerObj.SetGroupVersionKind(schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind))
+		ownerObj.SetNamespace(wl.Namespace)
+		ownerObj.SetName(owner.Name)
+		if err := r.client.Delete(ctx, ownerObj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(2).Info("Deleted the owner of the deactivated workload", "owner", klog.KRef(wl.Namespace, owner.Name), "kind", owner.Kind)
+	}
+	if err := r.client.Delete(ctx, wl); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(2).Info("Deleted the deactivated workload after the retention period")
+	return ctrl.Result{}, nil
+}
+
 // reconcileCheckBasedEviction returns true if Workload has been deactivated or evicted
 func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
 	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) || (!workload.HasRetryChecks(wl) && !workload.HasRejectedChecks(wl)) {
@@ -381,6 +615,22 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 	}
 	log := ctrl.LoggerFrom(ctx)
 	log.V(3).Info("Workload is evicted due to admission checks")
+	if workload.HasRejectedChecks(wl) && workload.IsAdmitted(wl) {
+		// The check was rejected after it let the workload in, for example because
+		// an external policy changed. Evict and requeue the workload so that the
+		// checks are evaluated again.
+		rejectedCheck := workload.RejectedChecks(wl)[0]
+		message := fmt.Sprintf("Admission check %s was rejected after the workload was admitted: %s", rejectedCheck.Name, rejectedCheck.Message)
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheckRejectedPostAdmission, message)
+		workload.ResetChecksOnEviction(wl, r.clock.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		log.V(3).Info("Admitted workload is evicted due to a rejected admission check", "workload", klog.KObj(wl), "admissionCheck", rejectedCheck.Name)
+		cqName, _ := r.queues.ClusterQueueForWorkload(wl)
+		workload.ReportEvictedWorkload(r.recorder, wl, cqName, kueue.WorkloadEvictedByAdmissionCheckRejectedPostAdmission, message)
+		return true, nil
+	}
 	if workload.HasRejectedChecks(wl) {
 		var rejectedCheckNames []kueue.AdmissionCheckReference
 		for _, check := range workload.RejectedChecks(wl) {
@@ -407,6 +657,26 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 	return true, nil
 }
 
+// reconcileAdmissionCheckResources evicts the workload with quota reserved
+// when the reservation doesn't account for the resources requested by its
+// admission checks, so that the quota is reserved again including them.
+func (r *WorkloadReconciler) reconcileAdmissionCheckResources(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if workload.IsAdmitted(wl) || workload.IsEvicted(wl) || workload.AdmissionCheckResourcesReserved(wl) {
+		return false, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	message := "The reserved quota doesn't account for the resources requested by the admission checks"
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, message)
+	workload.ResetChecksOnEviction(wl, r.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	log.V(3).Info("Workload is evicted to account for the resources requested by the admission checks")
+	cqName, _ := r.queues.ClusterQueueForWorkload(wl)
+	workload.ReportEvictedWorkload(r.recorder, wl, cqName, kueue.WorkloadEvictedByAdmissionCheck, message)
+	return true, nil
+}
+
 func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, wl *kueue.Workload, cq *kueue.ClusterQueue) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 	admissionChecks := workload.AdmissionChecksForWorkload(log, wl, admissioncheck.NewAdmissionChecks(cq))
@@ -420,6 +690,20 @@ func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, w
 	return false, nil
 }
 
+// reconcileCachedAdmissionCheckResults reuses the Ready results of the
+// admission checks from a previous quota reservation of the workload, if its
+// PodSets and its admission didn't change since.
+func (r *WorkloadReconciler) reconcileCachedAdmissionCheckResults(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if !features.Enabled(features.AdmissionCheckResultCaching) || !workload.HasQuotaReservation(wl) || workload.IsAdmitted(wl) || workload.IsEvicted(wl) {
+		return false, nil
+	}
+	if !r.admissionCheckResults.restore(wl, r.clock.Now()) {
+		return false, nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Reusing the cached results of the admission checks")
+	return true, client.IgnoreNotFound(workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock))
+}
+
 func (r *WorkloadReconciler) reconcileOnLocalQueueActiveState(ctx context.Context, wl *kueue.Workload, lqExists bool, lq *kueue.LocalQueue) (bool, error) {
 	queueStopPolicy := ptr.Deref(lq.Spec.StopPolicy, kueue.None)
 
@@ -574,6 +858,66 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 	return 0, client.IgnoreNotFound(err)
 }
 
+// reconcileReservationNotReadyTimeout evicts the workload when it didn't become
+// ready within the reservation timeout since its quota was reserved. Otherwise,
+// it returns the time left until the timeout is exceeded.
+func (r *WorkloadReconciler) reconcileReservationNotReadyTimeout(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
+	if !workload.IsActive(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return 0, nil
+	}
+	countingTowardsTimeout, recheckAfter := r.reservedNotReadyWorkload(wl)
+	if !countingTowardsTimeout {
+		return 0, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	if recheckAfter > 0 {
+		log.V(4).Info("Workload not yet ready and did not exceed its reservation timeout", "recheckAfter", recheckAfter)
+		return recheckAfter, nil
+	}
+	log.V(2).Info("Start the eviction of the workload due to exceeding the reservation timeout")
+	if deactivated, err := r.triggerDeactivationOrBackoffRequeue(ctx, wl); deactivated || err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	message := fmt.Sprintf("Not ready within the reservation timeout %s", *r.waitForPodsReady.reservationTimeout)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByReservationNotReady, message)
+	workload.ResetChecksOnEviction(wl, r.clock.Now())
+	err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
+	if err == nil {
+		cqName, _ := r.queues.ClusterQueueForWorkload(wl)
+		workload.ReportEvictedWorkload(r.recorder, wl, cqName, kueue.WorkloadEvictedByReservationNotReady, message)
+	}
+	return 0, client.IgnoreNotFound(err)
+}
+
+// reconcileReservationHold evicts the workload, releasing its quota reservation,
+// when the reservation hold of its Pending admission checks expired. Otherwise,
+// it returns the time left until the hold expires.
+func (r *WorkloadReconciler) reconcileReservationHold(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
+	if !workload.IsActive(wl) || workload.IsAdmitted(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return 0, nil
+	}
+	holds := r.cache.ReservationHolds(wl.Status.Admission.ClusterQueue)
+	remaining, held := workload.ReservationHoldRemaining(wl, holds, r.clock.Now())
+	if !held {
+		return 0, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	if remaining > 0 {
+		log.V(4).Info("Workload quota reservation held by its admission checks", "recheckAfter", remaining)
+		return remaining, nil
+	}
+	log.V(2).Info("Start the eviction of the workload due to the expired quota reservation hold")
+	message := "The quota reservation hold of the pending admission checks expired"
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, message)
+	workload.ResetChecksOnEviction(wl, r.clock.Now())
+	err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
+	if err == nil {
+		cqName, _ := r.queues.ClusterQueueForWorkload(wl)
+		workload.ReportEvictedWorkload(r.recorder, wl, cqName, kueue.WorkloadEvictedByAdmissionCheck, message)
+	}
+	return 0, client.IgnoreNotFound(err)
+}
+
 // triggerDeactivationOrBackoffRequeue trigger deactivation of workload
 // if a re-queued number has already exceeded the limit of re-queuing backoff.
 // Otherwise, it increments a re-queueing count and update a time to be re-queued.
@@ -652,10 +996,27 @@ func (r *WorkloadReconciler) Delete(e event.TypedDeleteEvent[*kueue.Workload]) b
 	// Even if the state is unknown, the last cached state tells us whether the
 	// workload was in the queues and should be cleared from them.
 	r.queues.DeleteWorkload(e.Object)
+	r.admissionCheckResults.delete(e.Object)
+	r.pendingRequeues.delete(e.Object)
 
 	return true
 }
 
+// reportAdmissionCheckDurations records how long the admission checks which
+// turned Ready or Rejected stayed Pending.
+func (r *WorkloadReconciler) reportAdmissionCheckDurations(oldWl, newWl *kueue.Workload) {
+	for _, newCheck := range newWl.Status.AdmissionChecks {
+		if newCheck.State != kueue.CheckStateReady && newCheck.State != kueue.CheckStateRejected {
+			continue
+		}
+		oldCheck := workload.FindAdmissionCheck(oldWl.Status.AdmissionChecks, newCheck.Name)
+		if oldCheck == nil || oldCheck.State != kueue.CheckStatePending {
+			continue
+		}
+		metrics.ReportAdmissionCheckDuration(newCheck.Name, r.clock.Since(oldCheck.LastTransitionTime.Time))
+	}
+}
+
 func (r *WorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) bool {
 	defer r.notifyWatchers(e.ObjectOld, e.ObjectNew)
 
@@ -680,6 +1041,12 @@ func (r *WorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) b
 	}
 	log.V(2).Info("Workload update event")
 
+	r.reportAdmissionCheckDurations(e.ObjectOld, e.ObjectNew)
+	if features.Enabled(features.AdmissionCheckResultCaching) {
+		r.admissionCheckResults.record(e.ObjectNew, r.clock.Now(), r.cache.AdmissionCheckController)
+	}
+	r.pendingRequeues.observe(e.ObjectOld, e.ObjectNew)
+
 	wlCopy := e.ObjectNew.DeepCopy()
 	// We do not handle old workload here as it will be deleted or replaced by new one anyway.
 	workload.AdjustResources(ctrl.LoggerInto(ctx, log), r.client, wlCopy)
@@ -712,6 +1079,14 @@ func (r *WorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) b
 		if !r.cache.AddOrUpdateWorkload(wlCopy) {
 			log.V(2).Info("ClusterQueue for workload didn't exist; ignored for now")
 		}
+		if r.admissionCooldown > 0 {
+			// The workload becomes a candidate for preemption once the admission
+			// cooldown expires, so retry the workloads which couldn't preempt it.
+			time.AfterFunc(r.admissionCooldown, func() {
+				r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wlCopy, nil)
+				log.V(3).Info("Requeued the inadmissible workloads after the admission cooldown")
+			})
+		}
 	case (prevStatus == workload.StatusQuotaReserved || prevStatus == workload.StatusAdmitted) && status == workload.StatusPending:
 		var backoff time.Duration
 		if wlCopy.Status.RequeueState != nil && wlCopy.Status.RequeueState.RequeueAt != nil {
@@ -760,6 +1135,18 @@ func (r *WorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) b
 			}
 		})
 
+	case prevStatus == workload.StatusQuotaReserved && status == workload.StatusAdmitted && r.cache.LimitsConcurrentChecks(e.ObjectNew.Status.Admission.ClusterQueue):
+		// The workload passed the admission checks, which frees a slot for the
+		// workloads waiting to enter the admission checks phase.
+		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, e.ObjectNew, func() {
+			// Update the workload in cache while holding the queues lock
+			// to guarantee that requeued workloads are taken into account before
+			// the next scheduling cycle.
+			if err := r.cache.UpdateWorkload(e.ObjectOld, wlCopy); err != nil {
+				log.Error(err, "Updating workload in cache")
+			}
+		})
+
 	default:
 		// Workload update in the cache is handled here; however, some fields are immutable
 		// and are not supposed to actually change anything.
@@ -806,8 +1193,10 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Conf
 // if the workload is currently counting towards the timeout for PodsReady, i.e.
 // it has the Admitted condition True and the PodsReady condition not equal
 // True (False or not set). The second value is the remaining time to exceed the
-// specified timeout counted since max of the LastTransitionTime's for the
-// Admitted and PodsReady conditions.
+// specified timeout counted since the LastTransitionTime of the Admitted
+// condition, or of the PodsReady condition when it is waiting for recovery, or
+// when enough pods are ready to start the timeout again according to the
+// timeout start policy.
 func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload) (bool, time.Duration) {
 	if r.waitForPodsReady == nil {
 		// the timeout is not configured for the workload controller
@@ -823,9 +1212,16 @@ func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload) (bool,
 		return false, 0
 	}
 
-	if podsReadyCond == nil || podsReadyCond.Reason == kueue.WorkloadWaitForStart || podsReadyCond.Reason == "PodsReady" {
+	if podsReadyCond == nil || podsReadyCond.Reason == kueue.WorkloadWaitForStart || podsReadyCond.Reason == "PodsReady" || podsReadyCond.Reason == kueue.WorkloadPartiallyReady {
 		admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
-		elapsedTime := r.clock.Since(admittedCond.LastTransitionTime.Time)
+		timeoutStart := admittedCond.LastTransitionTime.Time
+		if podsReadyCond != nil && podsReadyCond.Reason == kueue.WorkloadPartiallyReady &&
+			r.waitForPodsReady.timeoutStartPolicy != config.PodsReadyTimeoutStartAdmission &&
+			podsReadyCond.LastTransitionTime.After(timeoutStart) {
+			// Enough pods are ready, so the timeout starts again.
+			timeoutStart = podsReadyCond.LastTransitionTime.Time
+		}
+		elapsedTime := r.clock.Since(timeoutStart)
 		return true, max(r.waitForPodsReady.timeout-elapsedTime, 0)
 	} else if podsReadyCond.Reason == kueue.WorkloadWaitForRecovery && r.waitForPodsReady.recoveryTimeout != nil {
 		// A pod has failed and the workload is waiting for recovery
@@ -835,6 +1231,29 @@ func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload) (bool,
 	return false, 0
 }
 
+// reservedNotReadyWorkload returns whether the workload with the quota reserved
+// is counting towards the reservation timeout, i.e. its pods never reached
+// readiness since the reservation. The second value is the remaining time to
+// exceed the timeout, counted since the LastTransitionTime of the QuotaReserved
+// condition.
+func (r *WorkloadReconciler) reservedNotReadyWorkload(wl *kueue.Workload) (bool, time.Duration) {
+	if r.waitForPodsReady == nil || r.waitForPodsReady.reservationTimeout == nil {
+		return false, 0
+	}
+	quotaReservedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if quotaReservedCond == nil || quotaReservedCond.Status != metav1.ConditionTrue {
+		return false, 0
+	}
+	podsReadyCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPodsReady)
+	if podsReadyCond != nil && (podsReadyCond.Status == metav1.ConditionTrue ||
+		podsReadyCond.Reason == kueue.WorkloadWaitForRecovery || podsReadyCond.Reason == kueue.WorkloadRecovered) {
+		// the pods were ready since the reservation
+		return false, 0
+	}
+	elapsedTime := r.clock.Since(quotaReservedCond.LastTransitionTime.Time)
+	return true, max(*r.waitForPodsReady.reservationTimeout-elapsedTime, 0)
+}
+
 type resourceUpdatesHandler struct {
 	r *WorkloadReconciler
 }
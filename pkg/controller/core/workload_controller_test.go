@@ -27,19 +27,28 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestAdmittedNotReadyWorkload(t *testing.T) {
@@ -150,6 +159,75 @@ func TestAdmittedNotReadyWorkload(t *testing.T) {
 			wantCountingTowardsTimeout: true,
 			wantRecheckAfter:           4 * time.Minute,
 		},
+		"with reason PartiallyReady and the FirstPodReady policy; counting since podsReady.LastTransitionTime": {
+			workload: kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{},
+					Conditions: []metav1.Condition{
+						{
+							Type:               kueue.WorkloadAdmitted,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute)),
+						},
+						{
+							Type:               kueue.WorkloadPodsReady,
+							Status:             metav1.ConditionFalse,
+							Reason:             kueue.WorkloadPartiallyReady,
+							LastTransitionTime: metav1.NewTime(minuteAgo),
+						},
+					},
+				},
+			},
+			waitForPodsReady:           &waitForPodsReadyConfig{timeout: 5 * time.Minute, timeoutStartPolicy: config.PodsReadyTimeoutStartFirstPodReady},
+			wantCountingTowardsTimeout: true,
+			wantRecheckAfter:           4 * time.Minute,
+		},
+		"with reason PartiallyReady and the Quorum policy; counting since podsReady.LastTransitionTime": {
+			workload: kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{},
+					Conditions: []metav1.Condition{
+						{
+							Type:               kueue.WorkloadAdmitted,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute)),
+						},
+						{
+							Type:               kueue.WorkloadPodsReady,
+							Status:             metav1.ConditionFalse,
+							Reason:             kueue.WorkloadPartiallyReady,
+							LastTransitionTime: metav1.NewTime(minuteAgo),
+						},
+					},
+				},
+			},
+			waitForPodsReady:           &waitForPodsReadyConfig{timeout: 5 * time.Minute, timeoutStartPolicy: config.PodsReadyTimeoutStartQuorum},
+			wantCountingTowardsTimeout: true,
+			wantRecheckAfter:           4 * time.Minute,
+		},
+		"with reason PartiallyReady and the Admission policy; counting since admitted.LastTransitionTime": {
+			workload: kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{},
+					Conditions: []metav1.Condition{
+						{
+							Type:               kueue.WorkloadAdmitted,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute)),
+						},
+						{
+							Type:               kueue.WorkloadPodsReady,
+							Status:             metav1.ConditionFalse,
+							Reason:             kueue.WorkloadPartiallyReady,
+							LastTransitionTime: metav1.NewTime(minuteAgo),
+						},
+					},
+				},
+			},
+			waitForPodsReady:           &waitForPodsReadyConfig{timeout: 5 * time.Minute, timeoutStartPolicy: config.PodsReadyTimeoutStartAdmission},
+			wantCountingTowardsTimeout: true,
+			wantRecheckAfter:           2 * time.Minute,
+		},
 		"workload with Admitted=True, PodsReady=False, Reason=WorkloadWaitForPodsReadyRecovery": {
 			workload: kueue.Workload{
 				Status: kueue.WorkloadStatus{
@@ -262,6 +340,116 @@ func TestAdmittedNotReadyWorkload(t *testing.T) {
 	}
 }
 
+func TestReportAdmissionCheckDurations(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+
+	cases := map[string]struct {
+		oldWl         *kueue.Workload
+		newWl         *kueue.Workload
+		wantDurations []testingmetrics.MetricDataPoint
+	}{
+		"two checks turned ready and rejected": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+					kueue.AdmissionCheckState{
+						Name:               "ac2",
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+					},
+				).
+				Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStateReady,
+						LastTransitionTime: metav1.NewTime(now),
+					},
+					kueue.AdmissionCheckState{
+						Name:               "ac2",
+						State:              kueue.CheckStateRejected,
+						LastTransitionTime: metav1.NewTime(now),
+					},
+				).
+				Obj(),
+			wantDurations: []testingmetrics.MetricDataPoint{
+				{Labels: map[string]string{"check": "ac1"}, Value: 30},
+				{Labels: map[string]string{"check": "ac2"}, Value: 120},
+			},
+		},
+		"checks still pending or retrying are not recorded": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+					kueue.AdmissionCheckState{
+						Name:               "ac2",
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+					},
+				).
+				Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+					kueue.AdmissionCheckState{
+						Name:               "ac2",
+						State:              kueue.CheckStateRetry,
+						LastTransitionTime: metav1.NewTime(now),
+					},
+				).
+				Obj(),
+			wantDurations: []testingmetrics.MetricDataPoint{},
+		},
+		"checks already ready are not recorded again": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStateReady,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+				).
+				Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionChecks(
+					kueue.AdmissionCheckState{
+						Name:               "ac1",
+						State:              kueue.CheckStateReady,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+				).
+				Obj(),
+			wantDurations: []testingmetrics.MetricDataPoint{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			metrics.AdmissionCheckDuration.Reset()
+			reconciler := &WorkloadReconciler{clock: fakeClock}
+			reconciler.reportAdmissionCheckDurations(tc.oldWl, tc.newWl)
+
+			gotDurations := testingmetrics.CollectFilteredGaugeVec(metrics.AdmissionCheckDuration, map[string]string{})
+			if diff := cmp.Diff(tc.wantDurations, gotDurations, cmpopts.SortSlices(func(a, b testingmetrics.MetricDataPoint) bool { return a.Less(&b) })); diff != "" {
+				t.Errorf("Unexpected admission check durations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSyncCheckStates(t *testing.T) {
 	now := time.Now()
 	fakeClock := testingclock.NewFakeClock(now)
@@ -384,6 +572,8 @@ var (
 		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
 		cmpopts.IgnoreFields(kueue.AdmissionCheckState{}, "LastTransitionTime"),
 		cmpopts.IgnoreFields(kueue.RequeueState{}, "RequeueAt"),
+		cmpopts.IgnoreFields(kueue.AdmissionHistoryEntry{}, "Time"),
+		cmpopts.IgnoreFields(kueue.WorkloadStatus{}, "LastEvictionTime"),
 		cmpopts.SortSlices(func(a, b metav1.Condition) bool { return a.Type < b.Type }),
 	}
 )
@@ -403,7 +593,145 @@ func TestReconcile(t *testing.T) {
 		wantEvents     []utiltesting.EventRecord
 		wantResult     reconcile.Result
 		reconcilerOpts []Option
+
+		admissionChecks []*kueue.AdmissionCheck
+
+		enableWorkloadAdmissionHistory bool
+		enableWorkloadResumeHints      bool
+		enableWorkloadEvictionStatus   bool
+
+		enableAdmissionCheckPodSetResources bool
 	}{
+		"admission is recorded in the admission history": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment("cpu", "flavor1", "1").Obj(), testStartTime).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				AdmissionHistory(kueue.AdmissionHistoryEntry{
+					Type:   kueue.WorkloadEvicted,
+					Time:   metav1.NewTime(testStartTime.Add(-time.Minute)),
+					Reason: kueue.WorkloadEvictedByPreemption,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("cpu", "flavor1", "1").Obj()).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Condition(metav1.Condition{
+					Type:    "Admitted",
+					Status:  "True",
+					Reason:  "Admitted",
+					Message: "The workload is admitted",
+				}).
+				AdmissionHistory(
+					kueue.AdmissionHistoryEntry{
+						Type:   kueue.WorkloadEvicted,
+						Time:   metav1.NewTime(testStartTime.Add(-time.Minute)),
+						Reason: kueue.WorkloadEvictedByPreemption,
+					},
+					kueue.AdmissionHistoryEntry{
+						Type:         kueue.WorkloadAdmitted,
+						Time:         metav1.NewTime(testStartTime),
+						Reason:       "Admitted",
+						ClusterQueue: "cq",
+						Flavors:      []kueue.ResourceFlavorReference{"flavor1"},
+					},
+				).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Admitted",
+					Message:   "Admitted by ClusterQueue cq, wait time since reservation was 0s",
+				},
+			},
+			enableWorkloadAdmissionHistory: true,
+		},
+		"placement is recorded in the last placement on admission": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment("cpu", "flavor1", "1").Obj(), testStartTime).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("cpu", "flavor1", "1").Obj()).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Condition(metav1.Condition{
+					Type:    "Admitted",
+					Status:  "True",
+					Reason:  "Admitted",
+					Message: "The workload is admitted",
+				}).
+				LastPlacement(&kueue.WorkloadPlacement{
+					ClusterQueue: "cq",
+					PodSets: []kueue.PodSetPlacement{{
+						Name:    kueue.DefaultPodSetName,
+						Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "flavor1"},
+					}},
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Admitted",
+					Message:   "Admitted by ClusterQueue cq, wait time since reservation was 0s",
+				},
+			},
+			enableWorkloadResumeHints: true,
+		},
+		"eviction is recorded in the eviction status": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				EvictionStatus(1, kueue.WorkloadEvictedByPodsReadyTimeout, testStartTime.Add(-time.Hour)).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeactivated,
+					Message: "The workload is deactivated",
+				}).
+				EvictionStatus(2, kueue.WorkloadDeactivated, testStartTime).
+				Obj(),
+			enableWorkloadEvictionStatus: true,
+		},
+		"eviction is not recorded when the WorkloadEvictionStatus feature is disabled": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadEvictedByPreemption,
+					LastTransitionTime: metav1.NewTime(testStartTime),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByPreemption,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Inadmissible",
+					Message: "LocalQueue queue doesn't exist",
+				}).
+				Obj(),
+		},
 		"assign Admission Checks from ClusterQueue.spec.AdmissionCheckStrategy": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("cpu", "flavor1", "1").Obj()).
@@ -569,23 +897,32 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
-		"admitted workload with rejected checks gets deactivated": {
+		"admitted workload with a check rejected after the admission gets evicted and checks should be pending": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
 				Admitted(true).
 				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
-				AdmissionCheck(kueue.AdmissionCheckState{
-					Name:  "check",
-					State: kueue.CheckStateRejected,
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check-1",
+					State:   kueue.CheckStateRejected,
+					Message: "policy changed",
+				}, kueue.AdmissionCheckState{
+					Name:  "check-2",
+					State: kueue.CheckStateReady,
 				}).
 				Obj(),
 			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
 				Admitted(true).
 				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
-				AdmissionCheck(kueue.AdmissionCheckState{
-					Name:  "check",
-					State: kueue.CheckStateRejected,
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check-1",
+					State:   kueue.CheckStatePending,
+					Message: "Reset to Pending after eviction. Previously: Rejected",
+				}, kueue.AdmissionCheckState{
+					Name:    "check-2",
+					State:   kueue.CheckStatePending,
+					Message: "Reset to Pending after eviction. Previously: Ready",
 				}).
 				Conditions(
 					metav1.Condition{
@@ -601,22 +938,54 @@ func TestReconcile(t *testing.T) {
 						Message: "Admitted by ClusterQueue q1",
 					},
 					metav1.Condition{
-						Type:    kueue.WorkloadDeactivationTarget,
+						Type:    kueue.WorkloadEvicted,
 						Status:  metav1.ConditionTrue,
-						Reason:  "AdmissionCheck",
-						Message: "Admission check(s): check, were rejected",
+						Reason:  kueue.WorkloadEvictedByAdmissionCheckRejectedPostAdmission,
+						Message: "Admission check check-1 was rejected after the workload was admitted: policy changed",
 					},
 				).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
 					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
-					EventType: "Warning",
-					Reason:    "AdmissionCheckRejected",
-					Message:   "Deactivating workload because AdmissionCheck for check was Rejected: ",
+					EventType: "Normal",
+					Reason:    "EvictedDueToAdmissionCheckRejectedPostAdmission",
+					Message:   "Admission check check-1 was rejected after the workload was admitted: policy changed",
 				},
 			},
 		},
+		"evicted admitted workload with a check rejected after the admission is not evicted again": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				Admitted(true).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateRejected,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheckRejectedPostAdmission,
+					Message: "Admission check check was rejected after the workload was admitted: ",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				Admitted(true).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateRejected,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheckRejectedPostAdmission,
+					Message: "Admission check check was rejected after the workload was admitted: ",
+				}).
+				Obj(),
+		},
 		"workload with deactivation target condition should be deactivated and admission checks reset": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
@@ -722,6 +1091,154 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"workload should be evicted when its quota reservation doesn't account for the resources requested by the admission checks": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "flavor1", "1").Obj()).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+					PodSetResources: []kueue.PodSetResources{{
+						Name:      kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					}},
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "flavor1", "1").Obj()).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStatePending,
+					Message: "Reset to Pending after eviction. Previously: Ready",
+					PodSetResources: []kueue.PodSetResources{{
+						Name:      kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					}},
+				}).
+				Condition(metav1.Condition{
+					Type:    "Evicted",
+					Status:  "True",
+					Reason:  "AdmissionCheck",
+					Message: "The reserved quota doesn't account for the resources requested by the admission checks",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToAdmissionCheck",
+					Message:   "The reserved quota doesn't account for the resources requested by the admission checks",
+				},
+			},
+			enableAdmissionCheckPodSetResources: true,
+		},
+		"workload is admitted when its quota reservation accounts for the resources requested by the admission checks": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "flavor1", "1500m").Obj()).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+					PodSetResources: []kueue.PodSetResources{{
+						Name:      kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					}},
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "flavor1", "1500m").Obj()).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+					PodSetResources: []kueue.PodSetResources{{
+						Name:      kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					}},
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadAdmitted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "Admitted",
+					Message: "The workload is admitted",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Admitted",
+					Message:   "Admitted by ClusterQueue q1, wait time since reservation was 0s",
+				},
+			},
+			enableAdmissionCheckPodSetResources: true,
+		},
+		"workload with the quota reservation held by a pending admission check": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").AdmissionChecks("check").Obj(),
+			lq: utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj(),
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").ReservationHoldSeconds(600).Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 9 * time.Minute},
+		},
+		"workload should be evicted when the quota reservation hold of its pending admission check expired": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").AdmissionChecks("check").Obj(),
+			lq: utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj(),
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").ReservationHoldSeconds(600).Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheck,
+					Message: "The quota reservation hold of the pending admission checks expired",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToAdmissionCheck",
+					Message:   "The quota reservation hold of the pending admission checks expired",
+				},
+			},
+		},
 		"increment re-queue count": {
 			reconcilerOpts: []Option{
 				WithWaitForPodsReady(&waitForPodsReadyConfig{
@@ -777,6 +1294,110 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"workload should be evicted when not ready within the reservation timeout": {
+			reconcilerOpts: []Option{
+				WithWaitForPodsReady(&waitForPodsReadyConfig{
+					timeout:                     5 * time.Minute,
+					reservationTimeout:          ptr.To(10 * time.Minute),
+					requeuingBackoffBaseSeconds: 10,
+					requeuingBackoffJitter:      0,
+					requeuingBackoffMaxDuration: time.Duration(3600) * time.Second,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").AdmissionChecks("check").Obj(),
+			lq: utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj(),
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByReservationNotReady,
+					Message: "Not ready within the reservation timeout 10m0s",
+				}).
+				// 10s * 2^(1-1) = 10s
+				RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(testStartTime.Add(10*time.Second).Truncate(time.Second)))).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: corev1.EventTypeNormal,
+					Reason:    "EvictedDueToReservationNotReady",
+					Message:   "Not ready within the reservation timeout 10m0s",
+				},
+			},
+		},
+		"workload not ready within the reservation timeout yet should be rechecked": {
+			reconcilerOpts: []Option{
+				WithWaitForPodsReady(&waitForPodsReadyConfig{
+					timeout:            5 * time.Minute,
+					reservationTimeout: ptr.To(10 * time.Minute),
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").AdmissionChecks("check").Obj(),
+			lq: utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj(),
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 9 * time.Minute},
+		},
+		"workload which became ready should not be evicted by the reservation timeout": {
+			reconcilerOpts: []Option{
+				WithWaitForPodsReady(&waitForPodsReadyConfig{
+					timeout:            5 * time.Minute,
+					reservationTimeout: ptr.To(10 * time.Minute),
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmittedAt(true, testStartTime.Add(-15*time.Minute)).
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadPodsReady,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadStarted,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-15*time.Minute)).
+				AdmittedAt(true, testStartTime.Add(-15*time.Minute)).
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadPodsReady,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadStarted,
+				}).
+				Obj(),
+		},
 		"trigger deactivation of workload when reaching backoffLimitCount": {
 			reconcilerOpts: []Option{
 				WithWaitForPodsReady(&waitForPodsReadyConfig{
@@ -798,23 +1419,83 @@ func TestReconcile(t *testing.T) {
 					Reason:             "ByTest",
 					Message:            "Admitted by ClusterQueue q1",
 				}).
-				Admitted(true).
-				RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(testStartTime.Add(1*time.Second).Truncate(time.Second)))).
+				Admitted(true).
+				RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(testStartTime.Add(1*time.Second).Truncate(time.Second)))).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				Admitted(true).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStateReady,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadRequeuingLimitExceeded,
+					Message: "exceeding the maximum number of re-queuing retries",
+				}).
+				RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(testStartTime.Add(1*time.Second).Truncate(time.Second)))).
+				Obj(),
+		},
+		"should set the DeactivationTarget condition when the maximum number of requeues is exceeded": {
+			reconcilerOpts: []Option{
+				WithRequeuing(&config.Requeuing{MaxCount: ptr.To[int32](2)}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				RequeueCount(3).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadMaxRequeuesExceeded,
+					Message: "exceeding the maximum number of requeues (2)",
+				}).
+				RequeueCount(3).
+				Obj(),
+		},
+		"shouldn't set the DeactivationTarget condition when the maximum number of requeues is reached": {
+			reconcilerOpts: []Option{
+				WithRequeuing(&config.Requeuing{MaxCount: ptr.To[int32](2)}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				RequeueCount(2).
 				Obj(),
 			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
-				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
-				Admitted(true).
-				AdmissionCheck(kueue.AdmissionCheckState{
-					Name:  "check",
-					State: kueue.CheckStateReady,
-				}).
+				Queue("lq").
 				Condition(metav1.Condition{
-					Type:    kueue.WorkloadDeactivationTarget,
+					Type:    kueue.WorkloadEvicted,
 					Status:  metav1.ConditionTrue,
-					Reason:  kueue.WorkloadRequeuingLimitExceeded,
-					Message: "exceeding the maximum number of re-queuing retries",
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
 				}).
-				RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(testStartTime.Add(1*time.Second).Truncate(time.Second)))).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "LocalQueue lq doesn't exist",
+				}).
+				RequeueCount(2).
 				Obj(),
 		},
 		"wait time should be limited to backoffMaxSeconds": {
@@ -1696,15 +2377,276 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"pending workload with admit deadline": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-20 * time.Second)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "LocalQueue lq doesn't exist",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-20 * time.Second)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "LocalQueue lq doesn't exist",
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 40 * time.Second},
+		},
+		"pending workload with admit deadline - expired": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-2 * time.Minute)).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-2 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadAdmitDeadlineExceeded,
+					Message: "exceeding the admit deadline",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "AdmitDeadlineExceeded",
+					Message:   "The workload wasn't admitted within 60s",
+				},
+			},
+		},
+		"admitted workload with admit deadline - expired": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-2*time.Minute)).
+				AdmittedAt(true, testStartTime.Add(-time.Minute)).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Creation(testStartTime.Add(-2*time.Minute)).
+				AdmittedAt(true, testStartTime.Add(-time.Minute)).
+				Obj(),
+		},
+		"workload deactivated due to the admit deadline is declared finished": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToAdmitDeadlineExceeded",
+					Message: "The workload is deactivated due to exceeding the admit deadline",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Annotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToAdmitDeadlineExceeded",
+					Message: "The workload is deactivated due to exceeding the admit deadline",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadFinished,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadAdmitDeadlineExceeded,
+					Message: "The workload wasn't admitted before its admit deadline",
+				}).
+				Obj(),
+		},
+		"deactivated workload is deleted after the retention period": {
+			reconcilerOpts: []Option{
+				WithWorkloadRetention(&config.ObjectRetentionPolicies{
+					Workloads: &config.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: time.Hour},
+					},
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadDeactivated,
+					Message:            "The workload is deactivated",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-2 * time.Hour)),
+				}).
+				Obj(),
+		},
+		"deactivated workload owned by a job is deleted with its owner after the retention period": {
+			reconcilerOpts: []Option{
+				WithWorkloadRetention(&config.ObjectRetentionPolicies{
+					Workloads: &config.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: time.Hour},
+						DeleteOwners:     ptr.To(true),
+					},
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "test-uid").
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadDeactivated,
+					Message:            "The workload is deactivated",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-2 * time.Hour)),
+				}).
+				Obj(),
+		},
+		"deactivated workload owned by a job is kept when the deletion of the owners is not enabled": {
+			reconcilerOpts: []Option{
+				WithWorkloadRetention(&config.ObjectRetentionPolicies{
+					Workloads: &config.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: time.Hour},
+					},
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "test-uid").
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadDeactivated,
+					Message:            "The workload is deactivated",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-2 * time.Hour)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "test-uid").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeactivated,
+					Message: "The workload is deactivated",
+				}).
+				Obj(),
+		},
+		"recently deactivated workload is kept until the retention period expires": {
+			reconcilerOpts: []Option{
+				WithWorkloadRetention(&config.ObjectRetentionPolicies{
+					Workloads: &config.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: time.Hour},
+					},
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadDeactivated,
+					Message:            "The workload is deactivated",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-20 * time.Minute)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeactivated,
+					Message: "The workload is deactivated",
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 40 * time.Minute},
+		},
+		"deactivated workload is kept without a retention policy": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadDeactivated,
+					Message:            "The workload is deactivated",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-2 * time.Hour)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeactivated,
+					Message: "The workload is deactivated",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Inadmissible",
+					Message: "LocalQueue  doesn't exist",
+				}).
+				Obj(),
+		},
+		"active workload evicted for another reason is not deleted": {
+			reconcilerOpts: []Option{
+				WithWorkloadRetention(&config.ObjectRetentionPolicies{
+					Workloads: &config.WorkloadRetentionPolicy{
+						AfterDeactivated: &metav1.Duration{Duration: time.Hour},
+					},
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadEvictedByPreemption,
+					Message:            "Preempted to accommodate a higher priority Workload",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-2 * time.Hour)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Inadmissible",
+					Message: "LocalQueue  doesn't exist",
+				}).
+				Obj(),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadAdmissionHistory, tc.enableWorkloadAdmissionHistory)
+			features.SetFeatureGateDuringTest(t, features.WorkloadResumeHints, tc.enableWorkloadResumeHints)
+			features.SetFeatureGateDuringTest(t, features.WorkloadEvictionStatus, tc.enableWorkloadEvictionStatus)
+			features.SetFeatureGateDuringTest(t, features.AdmissionCheckPodSetResources, tc.enableAdmissionCheckPodSetResources)
 			objs := []client.Object{tc.workload}
+			for _, ac := range tc.admissionChecks {
+				objs = append(objs, ac)
+			}
 			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
 
 			cqCache := cache.New(cl)
+			for _, ac := range tc.admissionChecks {
+				cqCache.AddOrUpdateAdmissionCheck(ac)
+			}
 			qManager := queue.NewManager(cl, cqCache)
 			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder, tc.reconcilerOpts...)
 			// use a fake clock with jitter = 0 to be able to assert on the requeueAt.
@@ -1719,6 +2661,9 @@ func TestReconcile(t *testing.T) {
 					t.Errorf("couldn't create the cluster queue: %v", err)
 				}
 				if err := qManager.AddClusterQueue(ctx, tc.cq); err != nil {
+					t.Errorf("couldn't add the cluster queue to the queue manager: %v", err)
+				}
+				if err := cqCache.AddClusterQueue(ctx, tc.cq); err != nil {
 					t.Errorf("couldn't add the cluster queue to the cache: %v", err)
 				}
 			}
@@ -1758,3 +2703,198 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileFinishedWorkloadWaitsForQuotaRelease(t *testing.T) {
+	testStartTime := time.Now().Truncate(time.Second)
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+		Obj()
+	admittedWl := utiltesting.MakeWorkload("wl", "ns").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Admitted(true).
+		Obj()
+	finishedWl := admittedWl.DeepCopy()
+	finishedWl.Status.Conditions = append(finishedWl.Status.Conditions, metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadFinishedReasonSucceeded,
+	})
+	finishedWl.DeletionTimestamp = ptr.To(metav1.NewTime(testStartTime))
+
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().WithObjects(finishedWl).WithStatusSubresource(finishedWl).Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	// The cache didn't process the update of the workload to finished yet.
+	if !cqCache.AddOrUpdateWorkload(admittedWl) {
+		t.Fatalf("Inserting workload in cache failed")
+	}
+	reconciler := NewWorkloadReconciler(cl, qManager, cqCache, &utiltesting.EventRecorder{})
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(finishedWl)}
+
+	gotResult, err := reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{RequeueAfter: quotaReleaseCheckInterval}, gotResult); diff != "" {
+		t.Errorf("Unexpected reconcile result (-want,+got):\n%s", diff)
+	}
+	var gotWl kueue.Workload
+	if err := cl.Get(ctx, req.NamespacedName, &gotWl); err != nil {
+		t.Fatalf("Workload was removed before its quota was released: %v", err)
+	}
+	if !cqCache.IsAssumedOrAdmittedWorkload(*workload.NewInfo(&gotWl)) {
+		t.Errorf("Expected the quota of the workload to be still accounted in the cache")
+	}
+
+	// The delayed update is processed by the cache, which releases the quota.
+	if err := cqCache.DeleteWorkload(finishedWl); err != nil {
+		t.Fatalf("Deleting workload from cache: %v", err)
+	}
+	gotResult, err = reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{}, gotResult); diff != "" {
+		t.Errorf("Unexpected reconcile result (-want,+got):\n%s", diff)
+	}
+	if err := cl.Get(ctx, req.NamespacedName, &gotWl); !errors.IsNotFound(err) {
+		t.Errorf("Expected the workload to be removed after its quota was released, got error: %v", err)
+	}
+}
+
+func TestRequeueCountAfterEviction(t *testing.T) {
+	cases := map[string]struct {
+		evictionReason string
+		inactive       bool
+		requeueCount   *int32
+		wantCount      *int32
+	}{
+		"the requeue after a preemption is counted": {
+			evictionReason: kueue.WorkloadEvictedByPreemption,
+			wantCount:      ptr.To[int32](1),
+		},
+		"the requeue is added to the previous ones": {
+			evictionReason: kueue.WorkloadEvictedByPodsReadyTimeout,
+			requeueCount:   ptr.To[int32](2),
+			wantCount:      ptr.To[int32](3),
+		},
+		"the requeue after the stop of the ClusterQueue isn't counted": {
+			evictionReason: kueue.WorkloadEvictedByClusterQueueStopped,
+		},
+		"the deactivated workload isn't counted": {
+			evictionReason: kueue.WorkloadEvictedByPreemption,
+			inactive:       true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			evictedCond := metav1.Condition{
+				Type:   kueue.WorkloadEvicted,
+				Status: metav1.ConditionTrue,
+				Reason: tc.evictionReason,
+			}
+			evictedWl := utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Condition(evictedCond).
+				Obj()
+			evictedWl.Status.RequeueCount = tc.requeueCount
+			// A plain workload, without an owner, gets its quota reservation released.
+			releasedWl := evictedWl.DeepCopy()
+			_ = workload.UnsetQuotaReservationWithCondition(releasedWl, "Pending", "The workload was evicted", time.Now())
+			if tc.inactive {
+				releasedWl.Spec.Active = ptr.To(false)
+			}
+
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(releasedWl).
+				WithStatusSubresource(releasedWl).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, &utiltesting.EventRecorder{})
+			reconciler.Update(event.TypedUpdateEvent[*kueue.Workload]{ObjectOld: evictedWl, ObjectNew: releasedWl})
+
+			// The requeue is counted once, however many times the workload is reconciled.
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(releasedWl)}
+			for range 2 {
+				if _, err := reconciler.Reconcile(ctx, req); err != nil {
+					t.Fatalf("Unexpected reconcile error: %v", err)
+				}
+			}
+			var gotWl kueue.Workload
+			if err := cl.Get(ctx, req.NamespacedName, &gotWl); err != nil {
+				t.Fatalf("Could not get the workload after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantCount, gotWl.Status.RequeueCount); diff != "" {
+				t.Errorf("Unexpected requeue count (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdmissionCooldownRequeuesInadmissibleWorkloads(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	pendingWl := utiltesting.MakeWorkload("pending", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "1").
+		Obj()
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "1").
+		Obj()
+	reservedWl := wl.DeepCopy()
+	workload.SetQuotaReservation(reservedWl, utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj(), testingclock.NewFakeClock(time.Now()))
+
+	cl := utiltesting.NewClientBuilder().WithObjects(utiltesting.MakeNamespace("ns"), pendingWl).Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in manager: %v", err)
+	}
+	if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Inserting localQueue in manager: %v", err)
+	}
+	if err := qManager.AddOrUpdateWorkload(pendingWl); err != nil {
+		t.Fatalf("Inserting workload in manager: %v", err)
+	}
+	// The pending workload can't preempt the workload in its admission cooldown.
+	headsCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	heads := qManager.Heads(headsCtx)
+	if len(heads) != 1 {
+		t.Fatalf("Expected one head, got %d", len(heads))
+	}
+	if !qManager.RequeueWorkload(ctx, &heads[0], queue.RequeueReasonGeneric) {
+		t.Fatalf("Failed to requeue the pending workload")
+	}
+	if heads := qManager.PeekHeads(); len(heads) != 0 {
+		t.Fatalf("Expected the pending workload to be inadmissible, got %d heads", len(heads))
+	}
+
+	reconciler := NewWorkloadReconciler(cl, qManager, cqCache, &utiltesting.EventRecorder{},
+		WithAdmissionCooldown(&config.Scheduler{AdmissionCooldown: &metav1.Duration{Duration: 10 * time.Millisecond}}))
+	reconciler.Update(event.TypedUpdateEvent[*kueue.Workload]{ObjectOld: wl, ObjectNew: reservedWl})
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return len(qManager.PeekHeads()) == 1, nil
+	})
+	if err != nil {
+		t.Errorf("The inadmissible workload wasn't requeued after the admission cooldown: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	workloadNotificationsBuffer  = 1000
+	workloadNotificationsTimeout = 10 * time.Second
+)
+
+// WorkloadEventType is the lifecycle transition of a workload reported to
+// the workload notifications webhook.
+type WorkloadEventType string
+
+const (
+	WorkloadEventAdmitted WorkloadEventType = "Admitted"
+	WorkloadEventEvicted  WorkloadEventType = "Evicted"
+	WorkloadEventFinished WorkloadEventType = "Finished"
+)
+
+// WorkloadEvent is the payload POSTed to the workload notifications webhook.
+type WorkloadEvent struct {
+	Type         WorkloadEventType           `json:"type"`
+	Namespace    string                      `json:"namespace"`
+	Name         string                      `json:"name"`
+	UID          types.UID                   `json:"uid"`
+	LocalQueue   kueue.LocalQueueName        `json:"localQueue,omitempty"`
+	ClusterQueue kueue.ClusterQueueReference `json:"clusterQueue,omitempty"`
+	Reason       string                      `json:"reason,omitempty"`
+	Message      string                      `json:"message,omitempty"`
+	Time         metav1.Time                 `json:"time"`
+}
+
+// WorkloadNotifier POSTs the lifecycle transitions of the workloads to an
+// outgoing webhook. The events are delivered in order, by a single worker,
+// and retried with an exponential backoff.
+type WorkloadNotifier struct {
+	log          logr.Logger
+	url          string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	clock        clock.Clock
+	events       chan WorkloadEvent
+}
+
+var _ WorkloadUpdateWatcher = (*WorkloadNotifier)(nil)
+
+func NewWorkloadNotifier(cfg *config.WorkloadNotifications, clock clock.Clock) *WorkloadNotifier {
+	n := &WorkloadNotifier{
+		log:          ctrl.Log.WithName("workload-notifier"),
+		url:          cfg.URL,
+		httpClient:   &http.Client{Timeout: workloadNotificationsTimeout},
+		maxRetries:   int(ptr.Deref(cfg.MaxRetries, config.DefaultWorkloadNotificationsMaxRetries)),
+		retryBackoff: config.DefaultWorkloadNotificationsRetryBackoff,
+		clock:        clock,
+		events:       make(chan WorkloadEvent, workloadNotificationsBuffer),
+	}
+	if cfg.RetryBackoff != nil {
+		n.retryBackoff = cfg.RetryBackoff.Duration
+	}
+	return n
+}
+
+// NotifyWorkloadUpdate implements WorkloadUpdateWatcher.
+func (n *WorkloadNotifier) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl == nil || newWl == nil {
+		return
+	}
+	if !workload.IsAdmitted(oldWl) && workload.IsAdmitted(newWl) {
+		n.enqueue(n.newEvent(WorkloadEventAdmitted, newWl, kueue.WorkloadAdmitted))
+	}
+	if !workload.IsEvicted(oldWl) && workload.IsEvicted(newWl) {
+		n.enqueue(n.newEvent(WorkloadEventEvicted, newWl, kueue.WorkloadEvicted))
+	}
+	if !workload.IsFinished(oldWl) && workload.IsFinished(newWl) {
+		n.enqueue(n.newEvent(WorkloadEventFinished, newWl, kueue.WorkloadFinished))
+	}
+}
+
+func (n *WorkloadNotifier) newEvent(eventType WorkloadEventType, wl *kueue.Workload, conditionType string) WorkloadEvent {
+	return newWorkloadEvent(eventType, wl, conditionType, n.clock.Now())
+}
+
+// newWorkloadEvent returns the event for the transition of the workload,
+// taking the reason, message and time from the condition of the given type.
+func newWorkloadEvent(eventType WorkloadEventType, wl *kueue.Workload, conditionType string, now time.Time) WorkloadEvent {
+	event := WorkloadEvent{
+		Type:       eventType,
+		Namespace:  wl.Namespace,
+		Name:       wl.Name,
+		UID:        wl.UID,
+		LocalQueue: wl.Spec.QueueName,
+		Time:       metav1.NewTime(now),
+	}
+	if wl.Status.Admission != nil {
+		event.ClusterQueue = wl.Status.Admission.ClusterQueue
+	}
+	if cond := meta.FindStatusCondition(wl.Status.Conditions, conditionType); cond != nil {
+		event.Reason = cond.Reason
+		event.Message = cond.Message
+		event.Time = cond.LastTransitionTime
+	}
+	return event
+}
+
+func (n *WorkloadNotifier) enqueue(event WorkloadEvent) {
+	select {
+	case n.events <- event:
+	default:
+		n.log.Info("Dropping the workload event as the buffer is full", "type", event.Type, "workload", klog.KRef(event.Namespace, event.Name))
+	}
+}
+
+// Start implements the Runnable interface, delivering the events until the
+// context is done.
+func (n *WorkloadNotifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-n.events:
+			n.deliver(ctx, event)
+		}
+	}
+}
+
+func (n *WorkloadNotifier) deliver(ctx context.Context, event WorkloadEvent) {
+	log := n.log.WithValues("type", event.Type, "workload", klog.KRef(event.Namespace, event.Name))
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Error(err, "Failed to encode the workload event")
+		return
+	}
+	backoff := n.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := n.post(ctx, body)
+		if err == nil {
+			log.V(3).Info("Delivered the workload event")
+			return
+		}
+		if attempt >= n.maxRetries {
+			log.Error(err, "Failed to deliver the workload event, dropping it", "attempts", attempt+1)
+			return
+		}
+		log.V(2).Info("Failed to deliver the workload event, retrying", "error", err, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.clock.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (n *WorkloadNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadNotifier(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltesting.MakeAdmission("cq").Obj()
+	pending := utiltesting.MakeWorkload("wl", "ns").UID("uid").Queue("lq")
+	admitted := pending.Clone().ReserveQuotaAt(admission, now).AdmittedAt(true, now)
+
+	cases := map[string]struct {
+		oldWl        *kueue.Workload
+		newWl        *kueue.Workload
+		failures     int
+		maxRetries   int32
+		wantEvents   []WorkloadEvent
+		wantAttempts int
+	}{
+		"admitted": {
+			oldWl:      pending.Clone().Obj(),
+			newWl:      admitted.Clone().Obj(),
+			maxRetries: 3,
+			wantEvents: []WorkloadEvent{{
+				Type:         WorkloadEventAdmitted,
+				Namespace:    "ns",
+				Name:         "wl",
+				UID:          "uid",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Reason:       "ByTest",
+				Message:      "Admitted by ClusterQueue cq",
+				Time:         metav1.NewTime(now),
+			}},
+			wantAttempts: 1,
+		},
+		"evicted": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:               kueue.WorkloadEvicted,
+				Status:             metav1.ConditionTrue,
+				Reason:             kueue.WorkloadEvictedByPreemption,
+				Message:            "Preempted to accommodate a higher priority Workload",
+				LastTransitionTime: metav1.NewTime(now),
+			}).Obj(),
+			maxRetries: 3,
+			wantEvents: []WorkloadEvent{{
+				Type:         WorkloadEventEvicted,
+				Namespace:    "ns",
+				Name:         "wl",
+				UID:          "uid",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadEvictedByPreemption,
+				Message:      "Preempted to accommodate a higher priority Workload",
+				Time:         metav1.NewTime(now),
+			}},
+			wantAttempts: 1,
+		},
+		"finished": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:               kueue.WorkloadFinished,
+				Status:             metav1.ConditionTrue,
+				Reason:             kueue.WorkloadFinishedReasonSucceeded,
+				Message:            "Job finished successfully",
+				LastTransitionTime: metav1.NewTime(now),
+			}).Obj(),
+			maxRetries: 3,
+			wantEvents: []WorkloadEvent{{
+				Type:         WorkloadEventFinished,
+				Namespace:    "ns",
+				Name:         "wl",
+				UID:          "uid",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadFinishedReasonSucceeded,
+				Message:      "Job finished successfully",
+				Time:         metav1.NewTime(now),
+			}},
+			wantAttempts: 1,
+		},
+		"no transition": {
+			oldWl:      admitted.Clone().Obj(),
+			newWl:      admitted.Clone().Obj(),
+			maxRetries: 3,
+		},
+		"retried after failures": {
+			oldWl:      pending.Clone().Obj(),
+			newWl:      admitted.Clone().Obj(),
+			failures:   2,
+			maxRetries: 3,
+			wantEvents: []WorkloadEvent{{
+				Type:         WorkloadEventAdmitted,
+				Namespace:    "ns",
+				Name:         "wl",
+				UID:          "uid",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Reason:       "ByTest",
+				Message:      "Admitted by ClusterQueue cq",
+				Time:         metav1.NewTime(now),
+			}},
+			wantAttempts: 3,
+		},
+		"dropped after the max retries": {
+			oldWl:        pending.Clone().Obj(),
+			newWl:        admitted.Clone().Obj(),
+			failures:     5,
+			maxRetries:   1,
+			wantAttempts: 2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			var (
+				mu        sync.Mutex
+				attempts  int
+				gotEvents []WorkloadEvent
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				attempts++
+				if attempts <= tc.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				var event WorkloadEvent
+				if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+					t.Errorf("Failed to decode the event: %v", err)
+				}
+				gotEvents = append(gotEvents, event)
+			}))
+			defer server.Close()
+
+			notifier := NewWorkloadNotifier(&config.WorkloadNotifications{
+				URL:          server.URL,
+				MaxRetries:   ptr.To(tc.maxRetries),
+				RetryBackoff: &metav1.Duration{Duration: time.Millisecond},
+			}, clock.RealClock{})
+			notifier.NotifyWorkloadUpdate(tc.oldWl, tc.newWl)
+			for len(notifier.events) > 0 {
+				notifier.deliver(ctx, <-notifier.events)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if diff := cmp.Diff(tc.wantEvents, gotEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("Unexpected number of attempts, want %d, got %d", tc.wantAttempts, attempts)
+			}
+		})
+	}
+}
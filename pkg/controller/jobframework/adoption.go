@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// AdoptionRequested returns whether the job is annotated to be adopted by
+// Kueue while running, with the kueue.x-k8s.io/adopt annotation.
+func AdoptionRequested(obj client.Object) bool {
+	return obj.GetAnnotations()[constants.AdoptAnnotation] == "true"
+}
+
+// adoptJob creates the Workload for the running job, with the quota already
+// reserved in the ClusterQueue of its LocalQueue, so that the job keeps
+// running. The usage of the job is accounted regardless of the available quota.
+func (r *JobReconciler) adoptJob(ctx context.Context, job GenericJob, object client.Object) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	wl, err := r.constructWorkload(ctx, job)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareWorkload(ctx, job, wl); err != nil {
+		return err
+	}
+	admission, err := adoptionAdmission(ctx, r.client, wl)
+	if err != nil {
+		r.record.Eventf(object, corev1.EventTypeWarning, ReasonErrAdoptJob, "Unable to adopt the job: %v", err)
+		return err
+	}
+	if err := r.client.Create(ctx, wl); err != nil {
+		return err
+	}
+	workload.SetQuotaReservation(wl, admission, r.clock)
+	_ = workload.SyncAdmittedCondition(wl, r.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, false, r.clock); err != nil {
+		return err
+	}
+	log.V(2).Info("Adopted the running job", "workload", klog.KObj(wl), "clusterQueue", admission.ClusterQueue)
+	r.record.Eventf(object, corev1.EventTypeNormal, ReasonCreatedWorkload,
+		"Created admitted Workload for the adopted job: %v", workload.Key(wl))
+	return nil
+}
+
+// adoptionAdmission builds the admission of the Workload in the ClusterQueue
+// of its LocalQueue. Each resource is assigned the first flavor of the
+// resource group covering it.
+func adoptionAdmission(ctx context.Context, c client.Client, wl *kueue.Workload) (*kueue.Admission, error) {
+	var lq kueue.LocalQueue
+	if err := c.Get(ctx, types.NamespacedName{Namespace: wl.Namespace, Name: string(wl.Spec.QueueName)}, &lq); err != nil {
+		return nil, err
+	}
+	var cq kueue.ClusterQueue
+	if err := c.Get(ctx, types.NamespacedName{Name: string(lq.Spec.ClusterQueue)}, &cq); err != nil {
+		return nil, err
+	}
+	if len(cq.Spec.AdmissionChecks) > 0 || cq.Spec.AdmissionChecksStrategy != nil {
+		return nil, UnretryableError(fmt.Sprintf("ClusterQueue %s uses admission checks", cq.Name))
+	}
+
+	flavors := make(map[corev1.ResourceName]kueue.ResourceFlavorReference)
+	for _, rg := range cq.Spec.ResourceGroups {
+		if len(rg.Flavors) == 0 {
+			continue
+		}
+		for _, res := range rg.CoveredResources {
+			flavors[res] = rg.Flavors[0].Name
+		}
+	}
+
+	admission := &kueue.Admission{
+		ClusterQueue:      lq.Spec.ClusterQueue,
+		PodSetAssignments: make([]kueue.PodSetAssignment, len(wl.Spec.PodSets)),
+	}
+	for i, psr := range workload.NewInfo(wl).TotalRequests {
+		if wl.Spec.PodSets[i].TopologyRequest != nil {
+			return nil, UnretryableError(fmt.Sprintf("podSet %s requests Topology Aware Scheduling", psr.Name))
+		}
+		psa := kueue.PodSetAssignment{
+			Name:          psr.Name,
+			Flavors:       make(map[corev1.ResourceName]kueue.ResourceFlavorReference, len(psr.Requests)),
+			ResourceUsage: psr.Requests.ToResourceList(),
+			Count:         ptr.To(psr.Count),
+		}
+		for res := range psr.Requests {
+			flavor, found := flavors[res]
+			if !found {
+				return nil, UnretryableError(fmt.Sprintf("resource %s is not covered by ClusterQueue %s", res, cq.Name))
+			}
+			psa.Flavors[res] = flavor
+		}
+		admission.PodSetAssignments[i] = psa
+	}
+	return admission, nil
+}
@@ -21,10 +21,12 @@ import (
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/queue"
@@ -32,24 +34,32 @@ import (
 
 // BaseWebhook applies basic defaulting and validation for jobs.
 type BaseWebhook struct {
-	Client                       client.Client
-	ManageJobsWithoutQueueName   bool
-	ManagedJobsNamespaceSelector labels.Selector
-	FromObject                   func(runtime.Object) GenericJob
-	Queues                       *queue.Manager
-	Cache                        *cache.Cache
+	Client                         client.Client
+	ManageJobsWithoutQueueName     bool
+	ManagedJobsNamespaceSelector   labels.Selector
+	FromObject                     func(runtime.Object) GenericJob
+	Queues                         *queue.Manager
+	Cache                          *cache.Cache
+	AdmittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	MultiKueueDisabledIntegrations sets.Set[string]
+	MissingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	DefaultQueueName               string
 }
 
 func BaseWebhookFactory(job GenericJob, fromObject func(runtime.Object) GenericJob) func(ctrl.Manager, ...Option) error {
 	return func(mgr ctrl.Manager, opts ...Option) error {
 		options := ProcessOptions(opts...)
 		wh := &BaseWebhook{
-			Client:                       mgr.GetClient(),
-			ManageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-			ManagedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-			FromObject:                   fromObject,
-			Queues:                       options.Queues,
-			Cache:                        options.Cache,
+			Client:                         mgr.GetClient(),
+			ManageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+			ManagedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+			FromObject:                     fromObject,
+			Queues:                         options.Queues,
+			Cache:                          options.Cache,
+			AdmittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+			MultiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+			MissingQueueNamePolicy:         options.MissingQueueNamePolicy,
+			DefaultQueueName:               options.DefaultQueueName,
 		}
 		return webhook.WebhookManagedBy(mgr).
 			For(job.Object()).
@@ -67,10 +77,11 @@ func (w *BaseWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(5).Info("Applying defaults")
 	ApplyDefaultLocalQueue(job.Object(), w.Queues.DefaultLocalQueueExist)
+	ApplyDefaultQueueName(job.Object(), w.MissingQueueNamePolicy, w.DefaultQueueName)
 	if err := ApplyDefaultForSuspend(ctx, job, w.Client, w.ManageJobsWithoutQueueName, w.ManagedJobsNamespaceSelector); err != nil {
 		return err
 	}
-	ApplyDefaultForManagedBy(job, w.Queues, w.Cache, log)
+	ApplyDefaultForManagedBy(job, w.Queues, w.Cache, w.MultiKueueDisabledIntegrations, log)
 	return nil
 }
 
@@ -82,6 +93,7 @@ func (w *BaseWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (a
 	log := ctrl.LoggerFrom(ctx)
 	log.V(5).Info("Validating create")
 	allErrs := ValidateJobOnCreate(job)
+	allErrs = append(allErrs, ValidateMissingQueueNameOnCreate(job.Object(), w.MissingQueueNamePolicy)...)
 	if jobWithValidation, ok := job.(JobWithCustomValidation); ok {
 		allErrs = append(allErrs, jobWithValidation.ValidateOnCreate()...)
 	}
@@ -94,7 +106,7 @@ func (w *BaseWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime
 	newJob := w.FromObject(newObj)
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Validating update")
-	allErrs := ValidateJobOnUpdate(oldJob, newJob)
+	allErrs := ValidateJobOnUpdate(oldJob, newJob, w.AdmittedJobUpdatePolicy)
 	if jobWithValidation, ok := newJob.(JobWithCustomValidation); ok {
 		allErrs = append(allErrs, jobWithValidation.ValidateOnUpdate(oldJob)...)
 	}
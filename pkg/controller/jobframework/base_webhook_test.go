@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
@@ -76,7 +78,11 @@ func (j *testGenericJob) Finished() (string, bool, bool) {
 }
 
 func (j *testGenericJob) PodSets() ([]kueue.PodSet, error) {
-	panic("not implemented")
+	return []kueue.PodSet{{
+		Name:     kueue.DefaultPodSetName,
+		Count:    ptr.Deref(j.Spec.Parallelism, 1),
+		Template: *j.Spec.Template.DeepCopy(),
+	}}, nil
 }
 
 func (j *testGenericJob) IsActive() bool {
@@ -133,8 +139,11 @@ func (j *testGenericJob) fromObject(o runtime.Object) jobframework.GenericJob {
 	if o == nil {
 		return nil
 	}
-	j.Job = o.(*batchv1.Job)
-	return j
+	return &testGenericJob{
+		Job:              o.(*batchv1.Job),
+		validateOnCreate: j.validateOnCreate,
+		validateOnUpdate: j.validateOnUpdate,
+	}
 }
 
 func makeTestGenericJob() *testGenericJob {
@@ -147,6 +156,7 @@ func TestBaseWebhookDefault(t *testing.T) {
 		localQueueDefaulting       bool
 		defaultLqExist             bool
 		enableMultiKueue           bool
+		missingQueueNamePolicy     configapi.MissingQueueNamePolicy
 		job                        *batchv1.Job
 		want                       *batchv1.Job
 	}{
@@ -212,6 +222,33 @@ func TestBaseWebhookDefault(t *testing.T) {
 			want: utiljob.MakeJob("job", "default").
 				Obj(),
 		},
+		"job without queue label, Ignore missing queue name policy": {
+			missingQueueNamePolicy: configapi.MissingQueueNameIgnore,
+			job: utiljob.MakeJob("job", "default").
+				Suspend(false).
+				Obj(),
+			want: utiljob.MakeJob("job", "default").
+				Suspend(false).
+				Obj(),
+		},
+		"job without queue label, AssignDefaultQueue missing queue name policy": {
+			missingQueueNamePolicy: configapi.MissingQueueNameAssignDefaultQueue,
+			job: utiljob.MakeJob("job", "default").
+				Suspend(false).
+				Obj(),
+			want: utiljob.MakeJob("job", "default").
+				Queue("fallback").
+				Obj(),
+		},
+		"job with queue label, AssignDefaultQueue missing queue name policy": {
+			missingQueueNamePolicy: configapi.MissingQueueNameAssignDefaultQueue,
+			job: utiljob.MakeJob("job", "default").
+				Queue("queue").
+				Obj(),
+			want: utiljob.MakeJob("job", "default").
+				Queue("queue").
+				Obj(),
+		},
 		"ManagedByDefaulting, targeting multikueue local queue": {
 			job: utiljob.MakeJob("job", "default").
 				Queue("multikueue").
@@ -287,6 +324,8 @@ func TestBaseWebhookDefault(t *testing.T) {
 				FromObject:                 makeTestGenericJob().fromObject,
 				Queues:                     queueManager,
 				Cache:                      cqCache,
+				MissingQueueNamePolicy:     tc.missingQueueNamePolicy,
+				DefaultQueueName:           "fallback",
 			}
 			if err := w.Default(t.Context(), tc.job); err != nil {
 				t.Errorf("set defaults by base webhook")
@@ -300,11 +339,12 @@ func TestBaseWebhookDefault(t *testing.T) {
 
 func TestValidateOnCreate(t *testing.T) {
 	testcases := []struct {
-		name             string
-		job              *batchv1.Job
-		validateOnCreate func() field.ErrorList
-		wantErr          error
-		wantWarn         admission.Warnings
+		name                   string
+		job                    *batchv1.Job
+		validateOnCreate       func() field.ErrorList
+		missingQueueNamePolicy configapi.MissingQueueNamePolicy
+		wantErr                error
+		wantWarn               admission.Warnings
 	}{
 		{
 			name: "valid request",
@@ -342,12 +382,36 @@ func TestValidateOnCreate(t *testing.T) {
 				),
 			}.ToAggregate(),
 		},
+		{
+			name:                   "job without queue label with the Ignore missing queue name policy",
+			job:                    utiljob.MakeJob("job", "default").Obj(),
+			missingQueueNamePolicy: configapi.MissingQueueNameIgnore,
+		},
+		{
+			name:                   "job without queue label with the AssignDefaultQueue missing queue name policy",
+			job:                    utiljob.MakeJob("job", "default").Obj(),
+			missingQueueNamePolicy: configapi.MissingQueueNameAssignDefaultQueue,
+		},
+		{
+			name:                   "job without queue label with the Reject missing queue name policy",
+			job:                    utiljob.MakeJob("job", "default").Obj(),
+			missingQueueNamePolicy: configapi.MissingQueueNameReject,
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("metadata", "labels").Key(constants.QueueLabel), "the queue name is required"),
+			}.ToAggregate(),
+		},
+		{
+			name:                   "job with queue label with the Reject missing queue name policy",
+			job:                    utiljob.MakeJob("job", "default").Queue("queue").Obj(),
+			missingQueueNamePolicy: configapi.MissingQueueNameReject,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			w := &jobframework.BaseWebhook{
-				FromObject: makeTestGenericJob().withValidateOnCreate(tc.validateOnCreate).fromObject,
+				FromObject:             makeTestGenericJob().withValidateOnCreate(tc.validateOnCreate).fromObject,
+				MissingQueueNamePolicy: tc.missingQueueNamePolicy,
 			}
 			gotWarn, gotErr := w.ValidateCreate(t.Context(), tc.job)
 			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
@@ -366,6 +430,7 @@ func TestValidateOnUpdate(t *testing.T) {
 		oldJob           *batchv1.Job
 		job              *batchv1.Job
 		validateOnUpdate func(jobframework.GenericJob) field.ErrorList
+		updatePolicy     configapi.AdmittedJobUpdatePolicy
 		wantErr          error
 		wantWarn         admission.Warnings
 	}{
@@ -419,12 +484,40 @@ func TestValidateOnUpdate(t *testing.T) {
 				),
 			}.ToAggregate(),
 		},
+		{
+			name:         "resource change of an admitted job with the Readmit policy",
+			oldJob:       utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "1").Obj(),
+			job:          utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "2").Obj(),
+			updatePolicy: configapi.AdmittedJobUpdatePolicyReadmit,
+		},
+		{
+			name:         "resource change of an admitted job with the Reject policy",
+			oldJob:       utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "1").Obj(),
+			job:          utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "2").Obj(),
+			updatePolicy: configapi.AdmittedJobUpdatePolicyReject,
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), `the resource requests of the pod set "main" of an admitted job can't be changed`),
+			}.ToAggregate(),
+		},
+		{
+			name:         "resource change of a suspended job with the Reject policy",
+			oldJob:       utiljob.MakeJob("job", "default").Queue("queue").Request(corev1.ResourceCPU, "1").Obj(),
+			job:          utiljob.MakeJob("job", "default").Queue("queue").Request(corev1.ResourceCPU, "2").Obj(),
+			updatePolicy: configapi.AdmittedJobUpdatePolicyReject,
+		},
+		{
+			name:         "equivalent requests of an admitted job with the Reject policy",
+			oldJob:       utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "1").Obj(),
+			job:          utiljob.MakeJob("job", "default").Queue("queue").Suspend(false).Request(corev1.ResourceCPU, "1000m").Obj(),
+			updatePolicy: configapi.AdmittedJobUpdatePolicyReject,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			w := &jobframework.BaseWebhook{
-				FromObject: makeTestGenericJob().withValidateOnUpdate(tc.validateOnUpdate).fromObject,
+				FromObject:              makeTestGenericJob().withValidateOnUpdate(tc.validateOnUpdate).fromObject,
+				AdmittedJobUpdatePolicy: tc.updatePolicy,
 			}
 			gotWarn, gotErr := w.ValidateUpdate(t.Context(), tc.oldJob, tc.job)
 			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
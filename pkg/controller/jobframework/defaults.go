@@ -23,9 +23,11 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
@@ -95,9 +97,27 @@ func ApplyDefaultLocalQueue(jobObj client.Object, defaultQueueExist func(string)
 	}
 }
 
-func ApplyDefaultForManagedBy(job GenericJob, queues *queue.Manager, cache *cache.Cache, log logr.Logger) {
+// ApplyDefaultQueueName assigns the default LocalQueue to the job without a
+// queue name, when the missing queue name policy is AssignDefaultQueue.
+func ApplyDefaultQueueName(jobObj client.Object, policy configapi.MissingQueueNamePolicy, defaultQueueName string) {
+	if policy != configapi.MissingQueueNameAssignDefaultQueue || QueueNameForObject(jobObj) != "" {
+		return
+	}
+	// Do not default the queue-name for a job whose owner is already managed by Kueue
+	if IsOwnerManagedByKueueForObject(jobObj) {
+		return
+	}
+	labels := jobObj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[constants.QueueLabel] = defaultQueueName
+	jobObj.SetLabels(labels)
+}
+
+func ApplyDefaultForManagedBy(job GenericJob, queues *queue.Manager, cache *cache.Cache, multiKueueDisabledIntegrations sets.Set[string], log logr.Logger) {
 	if managedJob, ok := job.(JobWithManagedBy); ok {
-		if managedJob.CanDefaultManagedBy() {
+		if managedJob.CanDefaultManagedBy() && !isMultiKueueDisabledFor(job, multiKueueDisabledIntegrations) {
 			localQueueName, found := job.Object().GetLabels()[constants.QueueLabel]
 			if !found {
 				return
@@ -117,3 +137,18 @@ func ApplyDefaultForManagedBy(job GenericJob, queues *queue.Manager, cache *cach
 		}
 	}
 }
+
+// isMultiKueueDisabledFor returns true if the job belongs to one of the
+// integrations for which MultiKueue is disabled.
+func isMultiKueueDisabledFor(job GenericJob, disabledIntegrations sets.Set[string]) bool {
+	if len(disabledIntegrations) == 0 {
+		return false
+	}
+	gvk := job.GVK()
+	for name := range disabledIntegrations {
+		if cb, found := GetIntegration(name); found && cb.matchingGVK(gvk) {
+			return true
+		}
+	}
+	return false
+}
@@ -28,4 +28,6 @@ const (
 	ReasonErrWorkloadCompose    = "ErrWorkloadCompose"
 	ReasonUpdatedAdmissionCheck = "UpdatedAdmissionCheck"
 	ReasonJobNestingTooDeep     = "JobNestingTooDeep"
+	ReasonErrAdoptJob           = "ErrAdoptJob"
+	ReasonInconsistentAdmission = "InconsistentAdmission"
 )
@@ -105,6 +105,15 @@ type JobWithSkip interface {
 	Skip() bool
 }
 
+// JobWithReadyPods interface should be implemented by generic jobs which can
+// report the number of their ready pods, to start the PodsReady timeout again
+// according to waitForPodsReady.timeoutStartPolicy.
+type JobWithReadyPods interface {
+	// ReadyPods returns the number of ready or succeeded pods, and the
+	// number of pods expected to be ready.
+	ReadyPods() (ready, total int32)
+}
+
 type JobWithPriorityClass interface {
 	// PriorityClass returns the job's priority class name.
 	PriorityClass() string
@@ -215,13 +224,20 @@ func PrebuiltWorkloadFor(job GenericJob) (string, bool) {
 }
 
 func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKeysToCopy []string) *kueue.Workload {
+	annotations := admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations())
+	if admitDeadline, found := obj.GetAnnotations()[constants.AdmitDeadlineSecondsAnnotation]; found {
+		annotations[constants.AdmitDeadlineSecondsAnnotation] = admitDeadline
+	}
+	if mayPreempt, found := obj.GetAnnotations()[constants.MayPreemptAnnotation]; found {
+		annotations[constants.MayPreemptAnnotation] = mayPreempt
+	}
 	return &kueue.Workload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   obj.GetNamespace(),
 			Labels:      maps.FilterKeys(obj.GetLabels(), labelKeysToCopy),
 			Finalizers:  []string{kueue.ResourceInUseFinalizerName},
-			Annotations: admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations()),
+			Annotations: annotations,
 		},
 		Spec: kueue.WorkloadSpec{
 			QueueName:                   QueueNameForObject(obj),
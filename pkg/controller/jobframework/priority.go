@@ -0,0 +1,97 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// DefaultPriorityResolverName is the name of the PriorityResolver used when
+// none is configured. It derives the priority from the WorkloadPriorityClass
+// of the job, or from the PriorityClass of its pods.
+const DefaultPriorityResolverName = "default"
+
+var errDuplicatePriorityResolverName = errors.New("duplicate priority resolver name")
+
+// PriorityResolver determines the priority of the workload of a job.
+type PriorityResolver interface {
+	// ResolvePriority returns the priority class name, the priority class source
+	// and the priority value of the workload created for obj. customPriorityFunc,
+	// if not nil, returns the pod PriorityClass name provided by the job.
+	ResolvePriority(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityFunc func() string) (string, string, int32, error)
+}
+
+// PriorityResolverFunc is an adapter allowing the use of a function as a PriorityResolver.
+type PriorityResolverFunc func(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityFunc func() string) (string, string, int32, error)
+
+func (f PriorityResolverFunc) ResolvePriority(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityFunc func() string) (string, string, int32, error) {
+	return f(ctx, c, obj, podSets, customPriorityFunc)
+}
+
+type priorityResolverRegistry struct {
+	resolvers map[string]PriorityResolver
+	mu        sync.RWMutex
+}
+
+// DefaultPriorityResolver is the PriorityResolver used when none is configured.
+var DefaultPriorityResolver PriorityResolver = PriorityResolverFunc(ExtractPriority)
+
+var priorityResolvers = priorityResolverRegistry{
+	resolvers: map[string]PriorityResolver{
+		DefaultPriorityResolverName: DefaultPriorityResolver,
+	},
+}
+
+// RegisterPriorityResolver registers a PriorityResolver under name, to be
+// selected with the priorityResolver field of the integrations configuration.
+func RegisterPriorityResolver(name string, resolver PriorityResolver) error {
+	priorityResolvers.mu.Lock()
+	defer priorityResolvers.mu.Unlock()
+	if _, exists := priorityResolvers.resolvers[name]; exists {
+		return fmt.Errorf("%w %q", errDuplicatePriorityResolverName, name)
+	}
+	priorityResolvers.resolvers[name] = resolver
+	return nil
+}
+
+// GetPriorityResolver looks-up the PriorityResolver registered under name.
+// An empty name resolves to the default PriorityResolver.
+func GetPriorityResolver(name string) (PriorityResolver, bool) {
+	if name == "" {
+		name = DefaultPriorityResolverName
+	}
+	priorityResolvers.mu.RLock()
+	defer priorityResolvers.mu.RUnlock()
+	resolver, found := priorityResolvers.resolvers[name]
+	return resolver, found
+}
+
+// GetPriorityResolversList returns the sorted names of the registered PriorityResolvers.
+func GetPriorityResolversList() []string {
+	priorityResolvers.mu.RLock()
+	defer priorityResolvers.mu.RUnlock()
+	return slices.Sorted(maps.Keys(priorityResolvers.resolvers))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestRegisterPriorityResolver(t *testing.T) {
+	resolver := PriorityResolverFunc(func(context.Context, client.Client, client.Object, []kueue.PodSet, func() string) (string, string, int32, error) {
+		return "", "", 100, nil
+	})
+	t.Cleanup(func() {
+		priorityResolvers.mu.Lock()
+		delete(priorityResolvers.resolvers, "fixed")
+		priorityResolvers.mu.Unlock()
+	})
+
+	if err := RegisterPriorityResolver("fixed", resolver); err != nil {
+		t.Fatalf("Unexpected error registering the priority resolver: %v", err)
+	}
+	if err := RegisterPriorityResolver("fixed", resolver); !errors.Is(err, errDuplicatePriorityResolverName) {
+		t.Errorf("Unexpected error registering the priority resolver twice, want %v, got %v", errDuplicatePriorityResolverName, err)
+	}
+	if err := RegisterPriorityResolver(DefaultPriorityResolverName, resolver); !errors.Is(err, errDuplicatePriorityResolverName) {
+		t.Errorf("Unexpected error overriding the default priority resolver, want %v, got %v", errDuplicatePriorityResolverName, err)
+	}
+
+	if diff := cmp.Diff([]string{DefaultPriorityResolverName, "fixed"}, GetPriorityResolversList()); diff != "" {
+		t.Errorf("Unexpected priority resolvers (-want,+got):\n%s", diff)
+	}
+	if _, found := GetPriorityResolver(""); !found {
+		t.Error("The default priority resolver is not found for the empty name")
+	}
+	got, found := GetPriorityResolver("fixed")
+	if !found {
+		t.Fatal("The registered priority resolver is not found")
+	}
+	if _, _, p, _ := got.ResolvePriority(t.Context(), nil, nil, nil, nil); p != 100 {
+		t.Errorf("Unexpected priority from the registered resolver, want 100, got %d", p)
+	}
+	if _, found := GetPriorityResolver("unknown"); found {
+		t.Error("Unexpected priority resolver found for an unknown name")
+	}
+}
@@ -22,14 +22,17 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -64,6 +67,10 @@ import (
 const (
 	FailedToStartFinishedReason = "FailedToStart"
 	managedOwnersChainLimit     = 10
+
+	// quotaReleaseCheckInterval is how often the reconciler checks whether
+	// the quota of a finished workload is released, before removing its finalizer.
+	quotaReleaseCheckInterval = time.Second
 )
 
 var (
@@ -73,6 +80,7 @@ var (
 	ErrNoMatchingWorkloads            = errors.New("no matching workloads")
 	ErrExtraWorkloads                 = errors.New("extra workloads")
 	ErrPrebuiltWorkloadNotFound       = errors.New("prebuilt workload not found")
+	ErrPrebuiltWorkloadMismatch       = errors.New("prebuilt workload mismatch")
 )
 
 // JobReconciler reconciles a GenericJob object
@@ -82,7 +90,11 @@ type JobReconciler struct {
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
 	waitForPodsReady             bool
+	podsReadyConditions          map[schema.GroupVersionKind]string
+	podsReadyTimeoutStartPolicy  configapi.PodsReadyTimeoutStartPolicy
 	labelKeysToCopy              []string
+	priorityResolver             PriorityResolver
+	cache                        *cache.Cache
 	clock                        clock.Clock
 }
 
@@ -90,15 +102,26 @@ type Options struct {
 	ManageJobsWithoutQueueName   bool
 	ManagedJobsNamespaceSelector labels.Selector
 	WaitForPodsReady             bool
+	PodsReadyConditions          map[string]string // PodsReadyConditions key is the framework name.
+	PodsReadyTimeoutStartPolicy  configapi.PodsReadyTimeoutStartPolicy
 	KubeServerVersion            *kubeversion.ServerVersionFetcher
 	IntegrationOptions           map[string]any // IntegrationOptions key is "$GROUP/$VERSION, Kind=$KIND".
 	EnabledFrameworks            sets.Set[string]
 	EnabledExternalFrameworks    sets.Set[string]
 	ManagerName                  string
 	LabelKeysToCopy              []string
-	Queues                       *queue.Manager
-	Cache                        *cache.Cache
-	Clock                        clock.Clock
+	PriorityResolver             PriorityResolver
+	AdmittedJobUpdatePolicy      configapi.AdmittedJobUpdatePolicy
+	MissingQueueNamePolicy       configapi.MissingQueueNamePolicy
+	// DefaultQueueName is the LocalQueue assigned to the jobs without a queue
+	// name, with the AssignDefaultQueue policy.
+	DefaultQueueName string
+	// MultiKueueDisabledIntegrations is the set of framework names whose jobs
+	// are not dispatched by MultiKueue.
+	MultiKueueDisabledIntegrations sets.Set[string]
+	Queues                         *queue.Manager
+	Cache                          *cache.Cache
+	Clock                          clock.Clock
 }
 
 // Option configures the reconciler.
@@ -133,6 +156,15 @@ func WithManagedJobsNamespaceSelector(ls labels.Selector) Option {
 func WithWaitForPodsReady(w *configapi.WaitForPodsReady) Option {
 	return func(o *Options) {
 		o.WaitForPodsReady = w != nil && w.Enable
+		if o.WaitForPodsReady && len(w.PodsReadyConditions) > 0 {
+			o.PodsReadyConditions = make(map[string]string, len(w.PodsReadyConditions))
+			for _, condition := range w.PodsReadyConditions {
+				o.PodsReadyConditions[condition.Framework] = condition.ConditionType
+			}
+		}
+		if o.WaitForPodsReady {
+			o.PodsReadyTimeoutStartPolicy = ptr.Deref(w.TimeoutStartPolicy, configapi.PodsReadyTimeoutStartAdmission)
+		}
 	}
 }
 
@@ -187,6 +219,43 @@ func WithLabelKeysToCopy(n []string) Option {
 	}
 }
 
+// WithPriorityResolver sets the PriorityResolver determining the priority
+// of the workloads.
+func WithPriorityResolver(r PriorityResolver) Option {
+	return func(o *Options) {
+		o.PriorityResolver = r
+	}
+}
+
+// WithAdmittedJobUpdatePolicy sets how the changes to the resource requests
+// of the admitted jobs are handled.
+func WithAdmittedJobUpdatePolicy(p *configapi.AdmittedJobUpdatePolicy) Option {
+	return func(o *Options) {
+		if p != nil {
+			o.AdmittedJobUpdatePolicy = *p
+		}
+	}
+}
+
+// WithMissingQueueNamePolicy sets how the jobs without a queue name are
+// handled by the webhooks, and the LocalQueue assigned to them.
+func WithMissingQueueNamePolicy(p *configapi.MissingQueueNamePolicy, defaultQueueName *string) Option {
+	return func(o *Options) {
+		if p != nil {
+			o.MissingQueueNamePolicy = *p
+		}
+		o.DefaultQueueName = ptr.Deref(defaultQueueName, "")
+	}
+}
+
+// WithMultiKueueDisabledIntegrations sets the frameworks whose jobs are not
+// defaulted to be managed by MultiKueue.
+func WithMultiKueueDisabledIntegrations(names []string) Option {
+	return func(o *Options) {
+		o.MultiKueueDisabledIntegrations = sets.New(names...)
+	}
+}
+
 // WithQueues adds the queue manager.
 func WithQueues(q *queue.Manager) Option {
 	return func(o *Options) {
@@ -219,6 +288,16 @@ func NewReconciler(
 	record record.EventRecorder,
 	opts ...Option) *JobReconciler {
 	options := ProcessOptions(opts...)
+	priorityResolver := options.PriorityResolver
+	if priorityResolver == nil {
+		priorityResolver = DefaultPriorityResolver
+	}
+	podsReadyConditions := make(map[schema.GroupVersionKind]string, len(options.PodsReadyConditions))
+	for framework, conditionType := range options.PodsReadyConditions {
+		if cb, found := GetIntegration(framework); found {
+			podsReadyConditions[cb.getGVK()] = conditionType
+		}
+	}
 
 	return &JobReconciler{
 		client:                       client,
@@ -226,7 +305,11 @@ func NewReconciler(
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
 		waitForPodsReady:             options.WaitForPodsReady,
+		podsReadyConditions:          podsReadyConditions,
+		podsReadyTimeoutStartPolicy:  options.PodsReadyTimeoutStartPolicy,
 		labelKeysToCopy:              options.LabelKeysToCopy,
+		priorityResolver:             priorityResolver,
+		cache:                        options.Cache,
 		clock:                        options.Clock,
 	}
 }
@@ -387,6 +470,14 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{}, err
 		}
 
+		// The quota of a finished workload is released when the cache processes
+		// its update event, so keep the finalizer until that happens. Otherwise,
+		// the workload could be removed while its quota is still accounted.
+		if r.cache != nil && r.cache.IsAssumedOrAdmittedWorkload(*workload.NewInfo(wl)) {
+			log.V(2).Info("Delaying the finalizer removal until the quota of the finished workload is released")
+			return ctrl.Result{RequeueAfter: quotaReleaseCheckInterval}, nil
+		}
+
 		r.record.Eventf(object, corev1.EventTypeNormal, ReasonFinishedWorkload,
 			"Workload '%s' is declared finished", workload.Key(wl))
 		return ctrl.Result{}, workload.RemoveFinalizer(ctx, r.client, wl)
@@ -470,7 +561,7 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	// handle a job when waitForPodsReady is enabled, and it is the main job
 	if r.waitForPodsReady {
 		log.V(3).Info("Handling a job when waitForPodsReady is enabled")
-		condition := generatePodsReadyCondition(log, job, wl)
+		condition := generatePodsReadyCondition(log, r.podsReady(job), r.partiallyReady(job), wl)
 		if !workload.HasConditionWithTypeAndReason(wl, &condition) {
 			log.V(3).Info("Updating the PodsReady condition", "reason", condition.Reason, "status", condition.Status)
 			apimeta.SetStatusCondition(&wl.Status.Conditions, condition)
@@ -493,8 +584,8 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		if workload.HasQuotaReservation(wl) {
 			if !job.IsActive() {
 				log.V(6).Info("The job is no longer active, clear the workloads admission")
-				// The requeued condition status set to true only on EvictedByPreemption
-				setRequeued := evCond.Reason == kueue.WorkloadEvictedByPreemption
+				// The requeued condition status set to true only on EvictedByPreemption and EvictedByNodeDrain
+				setRequeued := evCond.Reason == kueue.WorkloadEvictedByPreemption || evCond.Reason == kueue.WorkloadEvictedByNodeDrain
 				workload.SetRequeuedCondition(wl, evCond.Reason, evCond.Message, setRequeued)
 				_ = workload.UnsetQuotaReservationWithCondition(wl, "Pending", evCond.Message, r.clock.Now())
 				err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
@@ -510,6 +601,12 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	if job.IsSuspended() {
 		// start the job if the workload has been admitted, and the job is still suspended
 		if workload.IsAdmitted(wl) {
+			if !workload.HasQuotaReservation(wl) || wl.Status.Admission == nil {
+				// The workload can't be admitted without a quota reservation, the
+				// job would stay suspended until the workload is evaluated again.
+				log.V(2).Info("Workload is admitted without a quota reservation, re-evaluating its admission")
+				return ctrl.Result{}, r.reevaluateAdmission(ctx, object, wl)
+			}
 			log.V(2).Info("Job admitted, unsuspending")
 			err := r.startJob(ctx, job, object, wl)
 			if err != nil {
@@ -725,8 +822,9 @@ func (r *JobReconciler) ensureOneWorkload(ctx context.Context, job GenericJob, o
 		toDelete = toDelete[1:]
 	}
 
-	// If there is no matching workload and the job is running, suspend it.
-	if match == nil && !job.IsSuspended() {
+	// If there is no matching workload and the job is running, suspend it,
+	// unless the job is to be adopted.
+	if match == nil && !job.IsSuspended() && (len(toDelete) > 0 || !AdoptionRequested(object)) {
 		log.V(2).Info("job with no matching workload, suspending")
 		var w *kueue.Workload
 		if len(toDelete) == 1 {
@@ -856,7 +954,7 @@ func (r *JobReconciler) ensurePrebuiltWorkloadInSync(ctx context.Context, wl *ku
 // expectedRunningPodSets gets the expected podsets during the job execution, returns nil if the workload has no reservation or
 // the admission does not match.
 func expectedRunningPodSets(ctx context.Context, c client.Client, wl *kueue.Workload) []kueue.PodSet {
-	if !workload.HasQuotaReservation(wl) {
+	if !workload.HasQuotaReservation(wl) || wl.Status.Admission == nil {
 		return nil
 	}
 	info, err := getPodSetsInfoFromStatus(ctx, c, wl)
@@ -937,6 +1035,20 @@ func (r *JobReconciler) updateWorkloadToMatchJob(ctx context.Context, job Generi
 	return newWl, nil
 }
 
+// reevaluateAdmission clears the admission of a workload which is marked
+// as admitted without a quota reservation, so that the workload is queued
+// again instead of leaving its job suspended.
+func (r *JobReconciler) reevaluateAdmission(ctx context.Context, object client.Object, wl *kueue.Workload) error {
+	msg := "The workload was admitted without a quota reservation"
+	_ = workload.UnsetQuotaReservationWithCondition(wl, "Pending", msg, r.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+		return fmt.Errorf("clearing admission: %w", err)
+	}
+	r.record.Eventf(object, corev1.EventTypeWarning, ReasonInconsistentAdmission,
+		"Re-evaluating the admission of the workload %v: %s", klog.KObj(wl), msg)
+	return nil
+}
+
 // startJob will unsuspend the job, and also inject the node affinity.
 func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) error {
 	info, err := getPodSetsInfoFromStatus(ctx, r.client, wl)
@@ -1074,7 +1186,7 @@ func (r *JobReconciler) prepareWorkload(ctx context.Context, job GenericJob, wl
 
 	wl.Spec.PodSets = clearMinCountsIfFeatureDisabled(wl.Spec.PodSets)
 
-	return nil
+	return setPodSetPriorities(ctx, r.client, wl.Spec.PodSets)
 }
 
 func (r *JobReconciler) extractPriority(ctx context.Context, podSets []kueue.PodSet, job GenericJob) (string, string, int32, error) {
@@ -1082,9 +1194,11 @@ func (r *JobReconciler) extractPriority(ctx context.Context, podSets []kueue.Pod
 	if jobWithPriorityClass, isImplemented := job.(JobWithPriorityClass); isImplemented {
 		customPriorityFunc = jobWithPriorityClass.PriorityClass
 	}
-	return ExtractPriority(ctx, r.client, job.Object(), podSets, customPriorityFunc)
+	return r.priorityResolver.ResolvePriority(ctx, r.client, job.Object(), podSets, customPriorityFunc)
 }
 
+// ExtractPriority implements the DefaultPriorityResolver. It uses the WorkloadPriorityClass
+// of the job if set, or the pod PriorityClass otherwise.
 func ExtractPriority(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityFunc func() string) (string, string, int32, error) {
 	if workloadPriorityClass := WorkloadPriorityClassName(obj); len(workloadPriorityClass) > 0 {
 		return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, c, workloadPriorityClass)
@@ -1104,6 +1218,26 @@ func extractPriorityFromPodSets(podSets []kueue.PodSet) string {
 	return ""
 }
 
+// setPodSetPriorities sets the priority of the podSets whose template specifies
+// a PriorityClass, if the PodSetPriority feature is enabled.
+func setPodSetPriorities(ctx context.Context, c client.Client, podSets []kueue.PodSet) error {
+	if !features.Enabled(features.PodSetPriority) {
+		return nil
+	}
+	for i := range podSets {
+		priorityClassName := podSets[i].Template.Spec.PriorityClassName
+		if len(priorityClassName) == 0 {
+			continue
+		}
+		_, _, p, err := utilpriority.GetPriorityFromPriorityClass(ctx, c, priorityClassName)
+		if err != nil {
+			return err
+		}
+		podSets[i].Priority = &p
+	}
+	return nil
+}
+
 // getPodSetsInfoFromStatus extracts podSetsInfo from workload status, based on
 // admission, and admission checks.
 func getPodSetsInfoFromStatus(ctx context.Context, c client.Client, w *kueue.Workload) ([]podset.PodSetInfo, error) {
@@ -1113,11 +1247,19 @@ func getPodSetsInfoFromStatus(ctx context.Context, c client.Client, w *kueue.Wor
 
 	podSetsInfo := make([]podset.PodSetInfo, len(w.Status.Admission.PodSetAssignments))
 
+	admissionTolerations, err := clusterQueueAdmissionTolerations(ctx, c, w.Status.Admission.ClusterQueue)
+	if err != nil {
+		return nil, err
+	}
+
 	for i, psAssignment := range w.Status.Admission.PodSetAssignments {
 		info, err := podset.FromAssignment(ctx, c, &psAssignment, w.Spec.PodSets[i].Count)
 		if err != nil {
 			return nil, err
 		}
+		if err := info.Merge(podset.PodSetInfo{Tolerations: admissionTolerations}); err != nil {
+			return nil, err
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			info.Labels[kueuealpha.PodSetLabel] = string(psAssignment.Name)
 			info.Annotations[kueuealpha.WorkloadAnnotation] = w.Name
@@ -1137,6 +1279,17 @@ func getPodSetsInfoFromStatus(ctx context.Context, c client.Client, w *kueue.Wor
 	return podSetsInfo, nil
 }
 
+// clusterQueueAdmissionTolerations returns the tolerations that the ClusterQueue
+// adds to all the workloads admitted in it. The ClusterQueue might have been
+// deleted after the admission, in which case no tolerations are added.
+func clusterQueueAdmissionTolerations(ctx context.Context, c client.Client, cqName kueue.ClusterQueueReference) ([]corev1.Toleration, error) {
+	cq := kueue.ClusterQueue{}
+	if err := c.Get(ctx, types.NamespacedName{Name: string(cqName)}, &cq); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return cq.Spec.AdmissionTolerations, nil
+}
+
 func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job GenericJob, object client.Object) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -1148,6 +1301,11 @@ func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job Generic
 		}
 	}
 
+	// Adopt the running job, without suspending it.
+	if !usePrebuiltWorkload && !job.IsSuspended() && AdoptionRequested(object) {
+		return r.adoptJob(ctx, job, object)
+	}
+
 	// Wait until there are no active pods.
 	if job.IsActive() {
 		log.V(2).Info("Job is suspended but still has active pods, waiting")
@@ -1183,9 +1341,53 @@ func (r *JobReconciler) ignoreUnretryableError(log logr.Logger, err error) error
 	return err
 }
 
-func generatePodsReadyCondition(log logr.Logger, job GenericJob, wl *kueue.Workload) metav1.Condition {
+// podsReady returns whether the pods of the job are ready. When a condition is
+// configured for the integration of the job, the pods are ready when the job
+// has this condition set to True.
+func (r *JobReconciler) podsReady(job GenericJob) bool {
+	if conditionType, found := r.podsReadyConditions[job.GVK()]; found {
+		return jobHasTrueCondition(job.Object(), conditionType)
+	}
+	return job.PodsReady()
+}
+
+// partiallyReady returns whether enough pods of the job are ready to start the
+// PodsReady timeout again, according to the timeout start policy.
+func (r *JobReconciler) partiallyReady(job GenericJob) bool {
+	jobWithReadyPods, implements := job.(JobWithReadyPods)
+	if !implements {
+		return false
+	}
+	ready, total := jobWithReadyPods.ReadyPods()
+	switch r.podsReadyTimeoutStartPolicy {
+	case configapi.PodsReadyTimeoutStartFirstPodReady:
+		return ready > 0
+	case configapi.PodsReadyTimeoutStartQuorum:
+		return 2*ready > total
+	}
+	return false
+}
+
+// jobHasTrueCondition returns whether the condition with the given type,
+// in the .status.conditions of the job, has the True status.
+func jobHasTrueCondition(obj client.Object, conditionType string) bool {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]any); ok && condition["type"] == conditionType {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+func generatePodsReadyCondition(log logr.Logger, podsReady, partiallyReady bool, wl *kueue.Workload) metav1.Condition {
 	const (
 		notReadyMsg           = "Not all pods are ready or succeeded"
+		partiallyReadyMsg     = "Some pods are ready, waiting for the remaining pods"
 		waitingForRecoveryMsg = "At least one pod has failed, waiting for recovery"
 		readyMsg              = "All pods reached readiness and the workload is running"
 	)
@@ -1198,7 +1400,6 @@ func generatePodsReadyCondition(log logr.Logger, job GenericJob, wl *kueue.Workl
 	}
 
 	podsReadyCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPodsReady)
-	podsReady := job.PodsReady()
 	log.V(3).Info("Generating PodsReady condition",
 		"Current PodsReady condition", podsReadyCond,
 		"Pods are ready", podsReady)
@@ -1214,6 +1415,11 @@ func generatePodsReadyCondition(log logr.Logger, job GenericJob, wl *kueue.Workl
 	}
 
 	switch {
+	case podsReadyCond == nil && partiallyReady:
+		return workload.CreatePodsReadyCondition(metav1.ConditionFalse,
+			kueue.WorkloadPartiallyReady,
+			partiallyReadyMsg)
+
 	case podsReadyCond == nil:
 		return workload.CreatePodsReadyCondition(metav1.ConditionFalse,
 			kueue.WorkloadWaitForStart,
@@ -1229,6 +1435,13 @@ func generatePodsReadyCondition(log logr.Logger, job GenericJob, wl *kueue.Workl
 			kueue.WorkloadWaitForRecovery,
 			waitingForRecoveryMsg)
 
+	// Keep the reason once set, so that the timeout doesn't start again
+	// when the number of ready pods fluctuates.
+	case podsReadyCond.Reason == kueue.WorkloadPartiallyReady || partiallyReady:
+		return workload.CreatePodsReadyCondition(metav1.ConditionFalse,
+			kueue.WorkloadPartiallyReady,
+			partiallyReadyMsg)
+
 	default:
 		// handles both "WaitForPodsStart" and the old "PodsReady" reasons
 		return workload.CreatePodsReadyCondition(metav1.ConditionFalse,
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
@@ -359,19 +360,22 @@ func TestProcessOptions(t *testing.T) {
 					PodSelector: &metav1.LabelSelector{},
 				}),
 				WithLabelKeysToCopy([]string{"toCopyKey"}),
+				WithAdmittedJobUpdatePolicy(ptr.To(configapi.AdmittedJobUpdatePolicyReject)),
 				WithClock(t, fakeClock),
 			},
 			wantOpts: Options{
-				ManageJobsWithoutQueueName: true,
-				WaitForPodsReady:           true,
-				KubeServerVersion:          &kubeversion.ServerVersionFetcher{},
+				ManageJobsWithoutQueueName:  true,
+				WaitForPodsReady:            true,
+				PodsReadyTimeoutStartPolicy: configapi.PodsReadyTimeoutStartAdmission,
+				KubeServerVersion:           &kubeversion.ServerVersionFetcher{},
 				IntegrationOptions: map[string]any{
 					corev1.SchemeGroupVersion.WithKind("Pod").String(): &configapi.PodIntegrationOptions{
 						PodSelector: &metav1.LabelSelector{},
 					},
 				},
-				LabelKeysToCopy: []string{"toCopyKey"},
-				Clock:           fakeClock,
+				LabelKeysToCopy:         []string{"toCopyKey"},
+				AdmittedJobUpdatePolicy: configapi.AdmittedJobUpdatePolicyReject,
+				Clock:                   fakeClock,
 			},
 		},
 		"a single option is passed": {
@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -29,8 +30,9 @@ func PodSetTopologyRequest(meta *metav1.ObjectMeta, podIndexLabel *string, subGr
 	requiredValue, requiredFound := meta.Annotations[kueuealpha.PodSetRequiredTopologyAnnotation]
 	preferredValue, preferredFound := meta.Annotations[kueuealpha.PodSetPreferredTopologyAnnotation]
 	unconstrained, unconstrainedFound := meta.Annotations[kueuealpha.PodSetUnconstrainedTopologyAnnotation]
+	spreadValue, spreadFound := meta.Annotations[kueuealpha.PodSetSpreadTopologyAnnotation]
 
-	if requiredFound || preferredFound || unconstrainedFound {
+	if requiredFound || preferredFound || unconstrainedFound || spreadFound {
 		psTopologyReq := &kueue.PodSetTopologyRequest{
 			PodIndexLabel:      podIndexLabel,
 			SubGroupIndexLabel: subGroupIndexLabel,
@@ -44,6 +46,13 @@ func PodSetTopologyRequest(meta *metav1.ObjectMeta, podIndexLabel *string, subGr
 		case unconstrainedFound:
 			unconstrained, _ := strconv.ParseBool(unconstrained)
 			psTopologyReq.Unconstrained = &unconstrained
+		case spreadFound:
+			psTopologyReq.Spread = &spreadValue
+		}
+		if percentage, found := meta.Annotations[kueuealpha.PodSetNodeCapacityPercentageAnnotation]; found {
+			if value, err := strconv.ParseInt(percentage, 10, 32); err == nil {
+				psTopologyReq.NodeCapacityPercentage = ptr.To(int32(value))
+			}
 		}
 		return psTopologyReq
 	}
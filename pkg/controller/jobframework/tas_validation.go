@@ -18,6 +18,7 @@ package jobframework
 
 import (
 	"fmt"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
@@ -31,8 +32,9 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	requiredValue, requiredFound := replicaMetadata.Annotations[kueuealpha.PodSetRequiredTopologyAnnotation]
 	preferredValue, preferredFound := replicaMetadata.Annotations[kueuealpha.PodSetPreferredTopologyAnnotation]
 	_, unconstrainedFound := replicaMetadata.Annotations[kueuealpha.PodSetUnconstrainedTopologyAnnotation]
+	spreadValue, spreadFound := replicaMetadata.Annotations[kueuealpha.PodSetSpreadTopologyAnnotation]
 	annotationFoundCount := 0
-	for _, found := range []bool{requiredFound, preferredFound, unconstrainedFound} {
+	for _, found := range []bool{requiredFound, preferredFound, unconstrainedFound, spreadFound} {
 		if found {
 			annotationFoundCount++
 		}
@@ -40,10 +42,11 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	annotationsPath := replicaPath.Child("annotations")
 	if annotationFoundCount > 1 {
 		allErrs = append(allErrs, field.Invalid(annotationsPath, field.OmitValueType{},
-			fmt.Sprintf("must not contain more than one topology annotation: [%q, %q, %q]",
+			fmt.Sprintf("must not contain more than one topology annotation: [%q, %q, %q, %q]",
 				kueuealpha.PodSetRequiredTopologyAnnotation,
 				kueuealpha.PodSetPreferredTopologyAnnotation,
-				kueuealpha.PodSetUnconstrainedTopologyAnnotation),
+				kueuealpha.PodSetUnconstrainedTopologyAnnotation,
+				kueuealpha.PodSetSpreadTopologyAnnotation),
 		))
 	}
 	if requiredFound {
@@ -52,5 +55,17 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	if preferredFound {
 		allErrs = append(allErrs, metavalidation.ValidateLabelName(preferredValue, annotationsPath.Key(kueuealpha.PodSetPreferredTopologyAnnotation))...)
 	}
+	if spreadFound {
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(spreadValue, annotationsPath.Key(kueuealpha.PodSetSpreadTopologyAnnotation))...)
+	}
+	if percentage, found := replicaMetadata.Annotations[kueuealpha.PodSetNodeCapacityPercentageAnnotation]; found {
+		percentagePath := annotationsPath.Key(kueuealpha.PodSetNodeCapacityPercentageAnnotation)
+		if value, err := strconv.ParseInt(percentage, 10, 32); err != nil || value < 1 || value > 100 {
+			allErrs = append(allErrs, field.Invalid(percentagePath, percentage, "must be an integer between 1 and 100"))
+		}
+		if annotationFoundCount == 0 {
+			allErrs = append(allErrs, field.Required(annotationsPath, fmt.Sprintf("%q requires one of the topology annotations", kueuealpha.PodSetNodeCapacityPercentageAnnotation)))
+		}
+	}
 	return allErrs
 }
@@ -29,18 +29,23 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	resourcehelpers "k8s.io/component-helpers/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 )
 
 var (
+	specPath                      = field.NewPath("spec")
 	annotationsPath               = field.NewPath("metadata", "annotations")
 	labelsPath                    = field.NewPath("metadata", "labels")
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
@@ -69,12 +74,22 @@ func ValidateJobOnCreate(job GenericJob) field.ErrorList {
 	return allErrs
 }
 
+// ValidateMissingQueueNameOnCreate rejects the job without a queue name, when
+// the missing queue name policy is Reject.
+func ValidateMissingQueueNameOnCreate(jobObj client.Object, policy configapi.MissingQueueNamePolicy) field.ErrorList {
+	if policy != configapi.MissingQueueNameReject || QueueNameForObject(jobObj) != "" || IsOwnerManagedByKueueForObject(jobObj) {
+		return nil
+	}
+	return field.ErrorList{field.Required(queueNameLabelPath, "the queue name is required")}
+}
+
 // ValidateJobOnUpdate encapsulates all GenericJob validations that must be performed on a Update operation
-func ValidateJobOnUpdate(oldJob, newJob GenericJob) field.ErrorList {
+func ValidateJobOnUpdate(oldJob, newJob GenericJob, updatePolicy configapi.AdmittedJobUpdatePolicy) field.ErrorList {
 	allErrs := validateUpdateForQueueName(oldJob, newJob)
 	allErrs = append(allErrs, validateUpdateForPrebuiltWorkload(oldJob, newJob)...)
 	allErrs = append(allErrs, ValidateUpdateForWorkloadPriorityClassName(oldJob.Object(), newJob.Object())...)
 	allErrs = append(allErrs, validateUpdateForMaxExecTime(oldJob, newJob)...)
+	allErrs = append(allErrs, validateUpdateForResources(oldJob, newJob, updatePolicy)...)
 	return allErrs
 }
 
@@ -121,7 +136,9 @@ func ValidateQueueName(obj client.Object) field.ErrorList {
 
 func validateUpdateForQueueName(oldJob, newJob GenericJob) field.ErrorList {
 	var allErrs field.ErrorList
-	if !newJob.IsSuspended() {
+	// The queue name can be set on the running job which is to be adopted.
+	adopted := QueueName(oldJob) == "" && AdoptionRequested(newJob.Object())
+	if !newJob.IsSuspended() && !adopted {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(QueueName(newJob), QueueName(oldJob), queueNameLabelPath)...)
 	}
 	return allErrs
@@ -140,6 +157,39 @@ func validateUpdateForPrebuiltWorkload(oldJob, newJob GenericJob) field.ErrorLis
 	return allErrs
 }
 
+// validateUpdateForResources rejects the changes to the pod counts and the
+// resource requests of the running jobs, when the updates of the admitted jobs
+// are not allowed.
+func validateUpdateForResources(oldJob, newJob GenericJob, updatePolicy configapi.AdmittedJobUpdatePolicy) field.ErrorList {
+	if updatePolicy != configapi.AdmittedJobUpdatePolicyReject || oldJob.IsSuspended() || newJob.IsSuspended() {
+		return nil
+	}
+	oldPodSets, err := oldJob.PodSets()
+	if err != nil {
+		return nil
+	}
+	newPodSets, err := newJob.PodSets()
+	if err != nil {
+		return nil
+	}
+	if len(oldPodSets) != len(newPodSets) {
+		return field.ErrorList{field.Forbidden(specPath, "the pod sets of an admitted job can't be changed")}
+	}
+	var allErrs field.ErrorList
+	for i := range newPodSets {
+		oldPodSet, newPodSet := &oldPodSets[i], &newPodSets[i]
+		if oldPodSet.Name != newPodSet.Name || oldPodSet.Count != newPodSet.Count ||
+			!equality.Semantic.DeepEqual(podSetRequests(oldPodSet), podSetRequests(newPodSet)) {
+			allErrs = append(allErrs, field.Forbidden(specPath, fmt.Sprintf("the resource requests of the pod set %q of an admitted job can't be changed", newPodSet.Name)))
+		}
+	}
+	return allErrs
+}
+
+func podSetRequests(ps *kueue.PodSet) corev1.ResourceList {
+	return resourcehelpers.PodRequests(&corev1.Pod{Spec: ps.Template.Spec}, resourcehelpers.PodResourcesOptions{})
+}
+
 func ValidateUpdateForWorkloadPriorityClassName(oldObj, newObj client.Object) field.ErrorList {
 	allErrs := apivalidation.ValidateImmutableField(WorkloadPriorityClassName(newObj), WorkloadPriorityClassName(oldObj), workloadPriorityClassNamePath)
 	return allErrs
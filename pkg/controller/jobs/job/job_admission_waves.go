@@ -0,0 +1,308 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// AdmissionWaveReconciler reserves the quota for the waves of the Indexed Jobs
+// admitted in waves. The workload of the job reserves the quota for the first
+// wave, and each following wave gets its quota reserved by an additional wave
+// workload, created once the previous wave is admitted. The pods of the job
+// are created with a scheduling gate, which is removed, in the order of their
+// completion indexes, once their quota is reserved.
+type AdmissionWaveReconciler struct {
+	client client.Client
+	record record.EventRecorder
+}
+
+func NewAdmissionWaveReconciler(client client.Client, record record.EventRecorder, _ ...jobframework.Option) jobframework.JobReconcilerInterface {
+	return &AdmissionWaveReconciler{client: client, record: record}
+}
+
+var _ jobframework.JobReconcilerInterface = (*AdmissionWaveReconciler)(nil)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update;patch;delete
+
+func (r *AdmissionWaveReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if !features.Enabled(features.JobAdmissionWaves) {
+		return nil
+	}
+	ctrl.Log.V(3).Info("Setting up the admission wave reconciler for Job")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}).
+		Named("job_admission_waves").
+		Watches(&kueue.Workload{}, handler.EnqueueRequestsFromMapFunc(workloadToJob)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podToJob)).
+		Complete(r)
+}
+
+func workloadToJob(_ context.Context, obj client.Object) []reconcile.Request {
+	if name, found := obj.GetLabels()[AdmissionWaveLabel]; found {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+	}
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == gvk.Kind && owner.APIVersion == gvk.GroupVersion().String() {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: owner.Name}}}
+	}
+	return nil
+}
+
+func podToJob(_ context.Context, obj client.Object) []reconcile.Request {
+	if name, found := obj.GetLabels()[batchv1.JobNameLabel]; found {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+	}
+	return nil
+}
+
+func (r *AdmissionWaveReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	batchJob := &batchv1.Job{}
+	if err := r.client.Get(ctx, req.NamespacedName, batchJob); err != nil {
+		// we'll ignore not-found errors, since there is nothing to do.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	job := (*Job)(batchJob)
+	waveSize := job.admissionWaveSize()
+	if waveSize == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile Job admission waves")
+
+	wl, err := r.jobWorkload(ctx, batchJob)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	waves, err := r.waveWorkloads(ctx, batchJob, wl)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if job.IsSuspended() || wl == nil || !workload.IsAdmitted(wl) || workload.IsFinished(wl) {
+		return ctrl.Result{}, r.deleteWorkloads(ctx, batchJob, waves)
+	}
+
+	// The waves are not requeued after eviction. Instead, the evicted wave and
+	// the following ones are deleted, their pods lose their quota, and new
+	// waves are created for them.
+	if idx := slices.IndexFunc(waves, workload.IsEvicted); idx != -1 {
+		if err := r.deleteWorkloads(ctx, batchJob, waves[idx:]); err != nil {
+			return ctrl.Result{}, err
+		}
+		waves = waves[:idx]
+	}
+
+	reserved := wl.Spec.PodSets[0].Count
+	requested := reserved
+	pending := false
+	for _, wave := range waves {
+		requested += wave.Spec.PodSets[0].Count
+		if workload.IsAdmitted(wave) {
+			reserved += wave.Spec.PodSets[0].Count
+		} else {
+			pending = true
+		}
+	}
+
+	// Request the quota for the next wave, once the previous one is admitted.
+	if total := job.podsCount(); !pending && requested < total {
+		if err := r.createWaveWorkload(ctx, batchJob, wl, requested, min(waveSize, total-requested)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, r.syncPods(ctx, batchJob, reserved)
+}
+
+// jobWorkload returns the workload controlled by the Job, or nil if it
+// doesn't exist.
+func (r *AdmissionWaveReconciler) jobWorkload(ctx context.Context, batchJob *batchv1.Job) (*kueue.Workload, error) {
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(batchJob.Namespace),
+		client.MatchingFields{jobframework.GetOwnerKey(gvk): batchJob.Name}); err != nil {
+		return nil, err
+	}
+	for i := range workloads.Items {
+		if metav1.IsControlledBy(&workloads.Items[i], batchJob) {
+			return &workloads.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// waveWorkloads returns the wave workloads of the Job, in the order of the
+// pods they reserve the quota for. The wave workloads of a previous workload
+// of the Job are deleted.
+func (r *AdmissionWaveReconciler) waveWorkloads(ctx context.Context, batchJob *batchv1.Job, wl *kueue.Workload) ([]*kueue.Workload, error) {
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(batchJob.Namespace),
+		client.MatchingLabels{AdmissionWaveLabel: batchJob.Name}); err != nil {
+		return nil, err
+	}
+	var waves, stale []*kueue.Workload
+	for i := range workloads.Items {
+		wave := &workloads.Items[i]
+		if wl != nil && metav1.IsControlledBy(wave, wl) && wave.DeletionTimestamp.IsZero() {
+			waves = append(waves, wave)
+		} else {
+			stale = append(stale, wave)
+		}
+	}
+	if err := r.deleteWorkloads(ctx, batchJob, stale); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(waves, func(a, b *kueue.Workload) int {
+		return cmp.Compare(waveOffset(a), waveOffset(b))
+	})
+	return waves, nil
+}
+
+// waveOffset returns the number of the pods admitted before the wave.
+func waveOffset(wave *kueue.Workload) int {
+	offset, _ := strconv.Atoi(wave.Annotations[AdmissionWaveOffsetAnnotation])
+	return offset
+}
+
+func (r *AdmissionWaveReconciler) createWaveWorkload(ctx context.Context, batchJob *batchv1.Job, wl *kueue.Workload, offset, count int32) error {
+	wave := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-wave-%d", wl.Name, offset),
+			Namespace:       wl.Namespace,
+			Labels:          map[string]string{AdmissionWaveLabel: batchJob.Name},
+			Annotations:     map[string]string{AdmissionWaveOffsetAnnotation: strconv.Itoa(int(offset))},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(wl, kueue.GroupVersion.WithKind("Workload"))},
+		},
+		Spec: kueue.WorkloadSpec{
+			QueueName:           wl.Spec.QueueName,
+			PriorityClassName:   wl.Spec.PriorityClassName,
+			Priority:            wl.Spec.Priority,
+			PriorityClassSource: wl.Spec.PriorityClassSource,
+		},
+	}
+	// The template of the running job holds the node selectors of the flavors
+	// assigned to the first wave, so that the following waves are assigned
+	// the same flavors.
+	template := cleanManagedLabels(batchJob.Spec.Template.DeepCopy())
+	template.Spec.SchedulingGates = slices.DeleteFunc(template.Spec.SchedulingGates, func(g corev1.PodSchedulingGate) bool {
+		return g.Name == AdmissionWaveSchedulingGate
+	})
+	ps := *wl.Spec.PodSets[0].DeepCopy()
+	ps.Template = *template
+	ps.Count = count
+	ps.MinCount = nil
+	wave.Spec.PodSets = []kueue.PodSet{ps}
+	if err := r.client.Create(ctx, wave); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("Created the admission wave workload", "workload", klog.KObj(wave), "count", count)
+	r.record.Eventf(batchJob, corev1.EventTypeNormal, jobframework.ReasonCreatedWorkload,
+		"Created Workload: %v", workload.Key(wave))
+	return nil
+}
+
+func (r *AdmissionWaveReconciler) deleteWorkloads(ctx context.Context, batchJob *batchv1.Job, workloads []*kueue.Workload) error {
+	for _, wl := range workloads {
+		if !wl.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.client.Delete(ctx, wl); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		ctrl.LoggerFrom(ctx).V(2).Info("Deleted the admission wave workload", "workload", klog.KObj(wl))
+		r.record.Eventf(batchJob, corev1.EventTypeNormal, jobframework.ReasonDeletedWorkload,
+			"Deleted Workload: %v", workload.Key(wl))
+	}
+	return nil
+}
+
+// syncPods removes the scheduling gate from the pods of the job, in the order
+// of their completion indexes, up to the number of the pods with the quota
+// reserved. The pods exceeding the reserved quota, after an eviction of a
+// wave, are deleted, and recreated gated by the Job controller.
+func (r *AdmissionWaveReconciler) syncPods(ctx context.Context, batchJob *batchv1.Job, reserved int32) error {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(batchJob.Namespace), client.MatchingLabels{
+		batchv1.JobNameLabel: batchJob.Name,
+	}); err != nil {
+		return err
+	}
+	var gated, ungated []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !metav1.IsControlledBy(pod, batchJob) || utilpod.IsTerminated(pod) || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if utilpod.HasGate(pod, AdmissionWaveSchedulingGate) {
+			gated = append(gated, pod)
+		} else {
+			ungated = append(ungated, pod)
+		}
+	}
+	byIndex := func(a, b *corev1.Pod) int {
+		if c := cmp.Compare(completionIndex(a), completionIndex(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	}
+	slices.SortFunc(gated, byIndex)
+	slices.SortFunc(ungated, byIndex)
+
+	log := ctrl.LoggerFrom(ctx)
+	for _, pod := range gated[:min(len(gated), max(int(reserved)-len(ungated), 0))] {
+		if err := clientutil.Patch(ctx, r.client, pod, true, func() (bool, error) {
+			return utilpod.Ungate(pod, AdmissionWaveSchedulingGate), nil
+		}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Ungated the pod", "pod", klog.KObj(pod))
+	}
+	for _, pod := range ungated[min(int(reserved), len(ungated)):] {
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Deleted the pod exceeding the quota", "pod", klog.KObj(pod))
+	}
+	return nil
+}
+
+// completionIndex returns the completion index of the pod of an Indexed Job.
+func completionIndex(pod *corev1.Pod) int {
+	index, _ := strconv.Atoi(pod.Annotations[batchv1.JobCompletionIndexAnnotation])
+	return index
+}
@@ -0,0 +1,231 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestAdmissionWaveReconciler(t *testing.T) {
+	baseJob := utiltestingjob.MakeJob("job", "ns").
+		UID("job").
+		Queue("queue").
+		Suspend(false).
+		CompletionMode(batchv1.IndexedCompletion).
+		Parallelism(4).
+		Completions(4).
+		SetAnnotation(JobAdmissionWaveSizeAnnotation, "2")
+	baseWorkload := utiltesting.MakeWorkload("job-wl", "ns").
+		UID("wl-uid").
+		ControllerReference(gvk, "job", "job").
+		Queue("queue").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).Obj())
+	admittedWorkload := baseWorkload.Clone().
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Admitted(true)
+	baseWave := utiltesting.MakeWorkload("job-wl-wave-2", "ns").
+		Label(AdmissionWaveLabel, "job").
+		Annotation(AdmissionWaveOffsetAnnotation, "2").
+		ControllerReference(kueue.GroupVersion.WithKind("Workload"), "job-wl", "wl-uid").
+		Queue("queue").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).Obj())
+	pod := func(index int) *testingpod.PodWrapper {
+		return testingpod.MakePod("job-"+strconv.Itoa(index), "ns").
+			Label(batchv1.JobNameLabel, "job").
+			Annotation(batchv1.JobCompletionIndexAnnotation, strconv.Itoa(index)).
+			OwnerReference("job", gvk)
+	}
+
+	cases := map[string]struct {
+		job       *batchv1.Job
+		workloads []kueue.Workload
+		pods      []corev1.Pod
+		wantWaves map[string]int32
+		wantGated map[string]bool
+	}{
+		"next wave waits for the quota": {
+			job:       baseJob.Clone().Obj(),
+			workloads: []kueue.Workload{*admittedWorkload.Clone().Obj()},
+			pods: []corev1.Pod{
+				*pod(0).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(1).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(2).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(3).Gate(AdmissionWaveSchedulingGate).Obj(),
+			},
+			wantWaves: map[string]int32{"job-wl-wave-2": 2},
+			wantGated: map[string]bool{
+				"job-0": false,
+				"job-1": false,
+				"job-2": true,
+				"job-3": true,
+			},
+		},
+		"pods are ungated once the wave is admitted": {
+			job: baseJob.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseWave.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*pod(0).Obj(),
+				*pod(1).Obj(),
+				*pod(2).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(3).Gate(AdmissionWaveSchedulingGate).Obj(),
+			},
+			wantWaves: map[string]int32{"job-wl-wave-2": 2},
+			wantGated: map[string]bool{
+				"job-0": false,
+				"job-1": false,
+				"job-2": false,
+				"job-3": false,
+			},
+		},
+		"last wave requests the remaining pods": {
+			job: baseJob.Clone().Parallelism(5).Completions(5).Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseWave.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*pod(0).Obj(),
+				*pod(1).Obj(),
+				*pod(2).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(3).Gate(AdmissionWaveSchedulingGate).Obj(),
+				*pod(4).Gate(AdmissionWaveSchedulingGate).Obj(),
+			},
+			wantWaves: map[string]int32{
+				"job-wl-wave-2": 2,
+				"job-wl-wave-4": 1,
+			},
+			wantGated: map[string]bool{
+				"job-0": false,
+				"job-1": false,
+				"job-2": false,
+				"job-3": false,
+				"job-4": true,
+			},
+		},
+		"evicted wave is replaced and its pods are deleted": {
+			job: baseJob.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseWave.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*pod(0).Obj(),
+				*pod(1).Obj(),
+				*pod(2).Obj(),
+				*pod(3).Obj(),
+			},
+			wantWaves: map[string]int32{"job-wl-wave-2": 2},
+			wantGated: map[string]bool{
+				"job-0": false,
+				"job-1": false,
+			},
+		},
+		"suspended job releases the quota": {
+			job: baseJob.Clone().Suspend(true).Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkload.Clone().Obj(),
+				*baseWave.Clone().Obj(),
+			},
+		},
+		"job smaller than a wave is not admitted in waves": {
+			job:       baseJob.Clone().SetAnnotation(JobAdmissionWaveSizeAnnotation, "4").Obj(),
+			workloads: []kueue.Workload{*admittedWorkload.Clone().Obj()},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.JobAdmissionWaves, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clientBuilder := utiltesting.NewClientBuilder()
+			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			objs := []client.Object{tc.job}
+			for i := range tc.workloads {
+				objs = append(objs, &tc.workloads[i])
+			}
+			for i := range tc.pods {
+				objs = append(objs, &tc.pods[i])
+			}
+			kClient := clientBuilder.WithObjects(objs...).Build()
+			recorder := record.NewBroadcaster().NewRecorder(kClient.Scheme(), corev1.EventSource{Component: "test"})
+			reconciler := NewAdmissionWaveReconciler(kClient, recorder)
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "job"}}); err != nil {
+				t.Errorf("Reconcile returned error: %v", err)
+			}
+
+			var gotWorkloads kueue.WorkloadList
+			if err := kClient.List(ctx, &gotWorkloads, client.MatchingLabels{AdmissionWaveLabel: "job"}); err != nil {
+				t.Fatalf("Could not list the wave workloads: %v", err)
+			}
+			gotWaves := make(map[string]int32, len(gotWorkloads.Items))
+			for _, wl := range gotWorkloads.Items {
+				gotWaves[wl.Name] = wl.Spec.PodSets[0].Count
+			}
+			if diff := cmp.Diff(tc.wantWaves, gotWaves, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Wave workloads after reconcile (-want,+got):\n%s", diff)
+			}
+
+			var gotPods corev1.PodList
+			if err := kClient.List(ctx, &gotPods); err != nil {
+				t.Fatalf("Could not list the pods: %v", err)
+			}
+			gotGated := make(map[string]bool, len(gotPods.Items))
+			for _, pod := range gotPods.Items {
+				gotGated[pod.Name] = len(pod.Spec.SchedulingGates) > 0
+			}
+			if diff := cmp.Diff(tc.wantGated, gotGated, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Gated pods after reconcile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
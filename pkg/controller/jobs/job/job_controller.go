@@ -19,6 +19,7 @@ package job
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -55,16 +56,33 @@ const (
 	JobMinParallelismAnnotation              = "kueue.x-k8s.io/job-min-parallelism"
 	JobCompletionsEqualParallelismAnnotation = "kueue.x-k8s.io/job-completions-equal-parallelism"
 	StoppingAnnotation                       = "kueue.x-k8s.io/stopping"
+
+	// JobAdmissionWaveSizeAnnotation sets the number of pods admitted in each
+	// wave of an Indexed Job with more pods than that number.
+	JobAdmissionWaveSizeAnnotation = "kueue.x-k8s.io/admission-wave-size"
+
+	// AdmissionWaveSchedulingGate is added to the pods of the jobs admitted in
+	// waves, and removed once the quota for the pods is reserved.
+	AdmissionWaveSchedulingGate = "kueue.x-k8s.io/admission-wave"
+
+	// AdmissionWaveLabel is set to the name of the job on the workloads
+	// reserving the quota for the waves after the first one.
+	AdmissionWaveLabel = "kueue.x-k8s.io/job-admission-wave"
+
+	// AdmissionWaveOffsetAnnotation is set on the wave workloads to the number
+	// of the pods admitted in the previous waves.
+	AdmissionWaveOffsetAnnotation = "kueue.x-k8s.io/admission-wave-offset"
 )
 
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
-		SetupIndexes:      SetupIndexes,
-		NewJob:            NewJob,
-		NewReconciler:     NewReconciler,
-		SetupWebhook:      SetupWebhook,
-		JobType:           &batchv1.Job{},
-		MultiKueueAdapter: &multiKueueAdapter{},
+		SetupIndexes:             SetupIndexes,
+		NewJob:                   NewJob,
+		NewReconciler:            NewReconciler,
+		SetupWebhook:             SetupWebhook,
+		JobType:                  &batchv1.Job{},
+		MultiKueueAdapter:        &multiKueueAdapter{},
+		NewAdditionalReconcilers: []jobframework.ReconcilerFactory{NewAdmissionWaveReconciler},
 	}))
 }
 
@@ -212,7 +230,7 @@ func (j *Job) PodLabelSelector() string {
 
 func (j *Job) ReclaimablePods() ([]kueue.ReclaimablePod, error) {
 	parallelism := ptr.Deref(j.Spec.Parallelism, 1)
-	if parallelism == 1 || j.Status.Succeeded == 0 {
+	if parallelism == 1 || j.Status.Succeeded == 0 || j.admissionWaveSize() > 0 {
 		return nil, nil
 	}
 
@@ -250,6 +268,10 @@ func (j *Job) PodSets() ([]kueue.PodSet, error) {
 		Count:    j.podsCount(),
 		MinCount: j.minPodsCount(),
 	}
+	if waveSize := j.admissionWaveSize(); waveSize > 0 {
+		// The workload of the job reserves the quota for the first wave.
+		podSet.Count = waveSize
+	}
 	if features.Enabled(features.TopologyAwareScheduling) {
 		podSet.TopologyRequest = jobframework.PodSetTopologyRequest(
 			&j.Spec.Template.ObjectMeta,
@@ -269,6 +291,10 @@ func (j *Job) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	}
 
 	info := podSetsInfo[0]
+	if j.admissionWaveSize() > 0 {
+		// The pods wait for the quota of their wave.
+		info.SchedulingGates = append(slices.Clone(info.SchedulingGates), corev1.PodSchedulingGate{Name: AdmissionWaveSchedulingGate})
+	}
 
 	if j.minPodsCount() != nil {
 		j.Spec.Parallelism = ptr.To(info.Count)
@@ -314,12 +340,17 @@ func (j *Job) Finished() (message string, success, finished bool) {
 }
 
 func (j *Job) PodsReady() bool {
+	ready, total := j.ReadyPods()
+	return ready >= total
+}
+
+func (j *Job) ReadyPods() (int32, int32) {
 	ready := ptr.Deref(j.Status.Ready, 0)
 	uncountedTerminatedSucceeded := 0
 	if j.Status.UncountedTerminatedPods != nil {
 		uncountedTerminatedSucceeded = len(j.Status.UncountedTerminatedPods.Succeeded)
 	}
-	return j.Status.Succeeded+ready+int32(uncountedTerminatedSucceeded) >= j.podsCount()
+	return j.Status.Succeeded + ready + int32(uncountedTerminatedSucceeded), j.podsCount()
 }
 
 func (j *Job) CanDefaultManagedBy() bool {
@@ -355,6 +386,24 @@ func (j *Job) minPodsCount() *int32 {
 	return nil
 }
 
+// admissionWaveSize returns the number of pods admitted in each wave, when the
+// job is admitted in waves, or 0 otherwise. Only the Indexed Jobs with more
+// pods than the wave size are admitted in waves.
+func (j *Job) admissionWaveSize() int32 {
+	if !features.Enabled(features.JobAdmissionWaves) || ptr.Deref(j.Spec.CompletionMode, batchv1.NonIndexedCompletion) != batchv1.IndexedCompletion {
+		return 0
+	}
+	strVal, found := j.GetAnnotations()[JobAdmissionWaveSizeAnnotation]
+	if !found {
+		return 0
+	}
+	waveSize, err := strconv.Atoi(strVal)
+	if err != nil || waveSize <= 0 || int32(waveSize) >= j.podsCount() {
+		return 0
+	}
+	return int32(waveSize)
+}
+
 func (j *Job) syncCompletionWithParallelism() bool {
 	if strVal, found := j.GetAnnotations()[JobCompletionsEqualParallelismAnnotation]; found {
 		if bVal, err := strconv.ParseBool(strVal); err == nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package job
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -38,11 +39,15 @@ import (
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
 )
@@ -333,6 +338,7 @@ func TestPodSets(t *testing.T) {
 		job                           *Job
 		wantPodSets                   []kueue.PodSet
 		enableTopologyAwareScheduling bool
+		enableJobAdmissionWaves       bool
 	}{
 		"no partial admission": {
 			job: (*Job)(jobTemplate.Clone().Parallelism(3).Obj()),
@@ -358,6 +364,35 @@ func TestPodSets(t *testing.T) {
 			},
 			enableTopologyAwareScheduling: false,
 		},
+		"admission in waves": {
+			job: (*Job)(
+				jobTemplate.Clone().
+					CompletionMode(batchv1.IndexedCompletion).
+					Parallelism(5).
+					SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+					Obj(),
+			),
+			wantPodSets: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+					PodSpec(*jobTemplate.Clone().Spec.Template.Spec.DeepCopy()).
+					Obj(),
+			},
+			enableJobAdmissionWaves: true,
+		},
+		"no admission in waves if the feature is disabled": {
+			job: (*Job)(
+				jobTemplate.Clone().
+					CompletionMode(batchv1.IndexedCompletion).
+					Parallelism(5).
+					SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+					Obj(),
+			),
+			wantPodSets: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 5).
+					PodSpec(*jobTemplate.Clone().Spec.Template.Spec.DeepCopy()).
+					Obj(),
+			},
+		},
 		"with required topology annotation": {
 			job: (*Job)(
 				jobTemplate.Clone().
@@ -426,6 +461,7 @@ func TestPodSets(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, tc.enableTopologyAwareScheduling)
+			features.SetFeatureGateDuringTest(t, features.JobAdmissionWaves, tc.enableJobAdmissionWaves)
 			gotPodSets, err := tc.job.PodSets()
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
@@ -498,7 +534,23 @@ func TestReconciler(t *testing.T) {
 	basePCWrapper := utiltesting.MakePriorityClass("test-pc").
 		PriorityValue(200)
 
-	testNamespace := utiltesting.MakeNamespaceWrapper("ns").Label(corev1.LabelMetadataName, "ns").Obj()
+	testNamespace := utiltesting.MakeNamespaceWrapper("ns").
+		Label(corev1.LabelMetadataName, "ns").
+		Label("example.com/priority-tier", "high-tier").
+		Obj()
+
+	// namespaceTierResolver uses the WorkloadPriorityClass named after the
+	// priority tier label of the namespace of the job.
+	namespaceTierResolver := jobframework.PriorityResolverFunc(func(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityFunc func() string) (string, string, int32, error) {
+		var ns corev1.Namespace
+		if err := c.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, &ns); err != nil {
+			return "", "", 0, err
+		}
+		if tier, found := ns.Labels["example.com/priority-tier"]; found {
+			return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, c, tier)
+		}
+		return jobframework.ExtractPriority(ctx, c, obj, podSets, customPriorityFunc)
+	})
 
 	baseWaitForPodsReadyConf := &configapi.WaitForPodsReady{Enable: true}
 
@@ -510,6 +562,8 @@ func TestReconciler(t *testing.T) {
 		workloads         []kueue.Workload
 		otherJobs         []batchv1.Job
 		priorityClasses   []client.Object
+		clusterQueues     []client.Object
+		localQueues       []client.Object
 		wantJob           batchv1.Job
 		wantWorkloads     []kueue.Workload
 		wantEvents        []utiltesting.EventRecord
@@ -592,6 +646,158 @@ func TestReconciler(t *testing.T) {
 				Obj(),
 			},
 		},
+		"PodsReady reason is set to PartiallyReady when the first Pod is ready, with the FirstPodReady policy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(&configapi.WaitForPodsReady{
+					Enable:             true,
+					TimeoutStartPolicy: ptr.To(configapi.PodsReadyTimeoutStartFirstPodReady),
+				}),
+			},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(1).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(1).
+				Active(10).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitForStart,
+					Message: "Not all pods are ready or succeeded",
+				}).
+				Obj(),
+			},
+			wantWorkloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadPartiallyReady,
+					Message: "Some pods are ready, waiting for the remaining pods",
+				}).
+				Obj(),
+			},
+		},
+		"PodsReady reason is kept WaitForStart when fewer than a quorum of Pods is ready, with the Quorum policy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(&configapi.WaitForPodsReady{
+					Enable:             true,
+					TimeoutStartPolicy: ptr.To(configapi.PodsReadyTimeoutStartQuorum),
+				}),
+			},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(5).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(5).
+				Active(10).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitForStart,
+					Message: "Not all pods are ready or succeeded",
+				}).
+				Obj(),
+			},
+			wantWorkloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitForStart,
+					Message: "Not all pods are ready or succeeded",
+				}).
+				Obj(),
+			},
+		},
+		"PodsReady reason is set to PartiallyReady when a quorum of Pods is ready, with the Quorum policy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(&configapi.WaitForPodsReady{
+					Enable:             true,
+					TimeoutStartPolicy: ptr.To(configapi.PodsReadyTimeoutStartQuorum),
+				}),
+			},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(6).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(6).
+				Active(10).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitForStart,
+					Message: "Not all pods are ready or succeeded",
+				}).
+				Obj(),
+			},
+			wantWorkloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadPartiallyReady,
+					Message: "Some pods are ready, waiting for the remaining pods",
+				}).
+				Obj(),
+			},
+		},
+		"PodsReady reason is kept PartiallyReady when the number of ready Pods drops below the quorum": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(&configapi.WaitForPodsReady{
+					Enable:             true,
+					TimeoutStartPolicy: ptr.To(configapi.PodsReadyTimeoutStartQuorum),
+				}),
+			},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(2).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Ready(2).
+				Active(10).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadPartiallyReady,
+					Message: "Some pods are ready, waiting for the remaining pods",
+				}).
+				Obj(),
+			},
+			wantWorkloads: []kueue.Workload{*baseWorkloadWrapper.Clone().
+				Admitted(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPodsReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadPartiallyReady,
+					Message: "Some pods are ready, waiting for the remaining pods",
+				}).
+				Obj(),
+			},
+		},
 		"PodsReady is set to True after Workload is Admitted and all Pods reached readiness": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithWaitForPodsReady(baseWaitForPodsReadyConf),
@@ -863,6 +1069,121 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is created, it has its owner admit deadline annotation": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdmitDeadlineSecondsAnnotation, "60").
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Annotations(map[string]string{controllerconsts.AdmitDeadlineSecondsAnnotation: "60"}).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: "test-uid"}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
+		"when the running job is adopted, the workload is created admitted": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdoptAnnotation, "true").
+				UID("test-uid").
+				Suspend(false).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdoptAnnotation, "true").
+				UID("test-uid").
+				Suspend(false).
+				Active(10).
+				Obj(),
+			clusterQueues: []client.Object{
+				utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(),
+			},
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: "test-uid"}).
+					Admission(utiltesting.MakeAdmission("cq").
+						Assignment(corev1.ResourceCPU, "default", "10").
+						AssignmentPodCount(10).
+						Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionTrue,
+						Reason:  "QuotaReserved",
+						Message: "Quota reserved in ClusterQueue cq",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionTrue,
+						Reason:  "Admitted",
+						Message: "The workload is admitted",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created admitted Workload for the adopted job: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
+		"when the running job is adopted in a ClusterQueue with admission checks, the workload is not created": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdoptAnnotation, "true").
+				UID("test-uid").
+				Suspend(false).
+				Active(10).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.AdoptAnnotation, "true").
+				UID("test-uid").
+				Suspend(false).
+				Active(10).
+				Obj(),
+			clusterQueues: []client.Object{
+				utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					AdmissionChecks("check").
+					Obj(),
+			},
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Warning",
+					Reason:    "ErrAdoptJob",
+					Message:   "Unable to adopt the job: ClusterQueue cq uses admission checks",
+				},
+			},
+		},
 		"when workload is created, it has its owner ProvReq annotations": {
 			job: *baseJobWrapper.Clone().
 				SetAnnotation(controllerconsts.ProvReqAnnotationPrefix+"test-annotation", "test-val").
@@ -980,6 +1301,142 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is admitted the admission tolerations of the ClusterQueue are propagated to job": {
+			clusterQueues: []client.Object{
+				utiltesting.MakeClusterQueue("cq").
+					AdmissionToleration(corev1.Toleration{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "cq",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}).
+					Obj(),
+			},
+			job: *baseJobWrapper.Clone().
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Toleration(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "cq",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
+		"when workload is admitted without a quota reservation, its admission is re-evaluated and the job stays suspended": {
+			job:     *baseJobWrapper.Clone().Obj(),
+			wantJob: *baseJobWrapper.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(testStartTime),
+						Reason:             "ByTest",
+						Message:            "Admitted by ClusterQueue cq",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "The workload was admitted without a quota reservation",
+					}).
+					PastAdmittedTime(0).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Warning",
+					Reason:    "InconsistentAdmission",
+					Message:   "Re-evaluating the admission of the workload ns/wl: The workload was admitted without a quota reservation",
+				},
+			},
+		},
+		"when workload is admitted with the quota reserved but without admission, its admission is re-evaluated and the job stays suspended": {
+			job:     *baseJobWrapper.Clone().Obj(),
+			wantJob: *baseJobWrapper.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(testStartTime),
+						Reason:             "AdmittedByTest",
+						Message:            "Admitted by ClusterQueue cq",
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(testStartTime),
+						Reason:             "ByTest",
+						Message:            "Admitted by ClusterQueue cq",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "The workload was admitted without a quota reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					PastAdmittedTime(0).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Warning",
+					Reason:    "InconsistentAdmission",
+					Message:   "Re-evaluating the admission of the workload ns/wl: The workload was admitted without a quota reservation",
+				},
+			},
+		},
 		"when workload is evicted due to spec.active field being false, job gets suspended and quota is unset": {
 			job: *baseJobWrapper.Clone().
 				Suspend(false).
@@ -1368,6 +1825,7 @@ func TestReconciler(t *testing.T) {
 			workloads: []kueue.Workload{
 				*baseWorkloadWrapper.Clone().
 					AdmittedAt(true, testStartTime.Add(-time.Second)).
+					RequeueCount(2).
 					Condition(metav1.Condition{
 						Type:    kueue.WorkloadEvicted,
 						Status:  metav1.ConditionTrue,
@@ -1391,6 +1849,7 @@ func TestReconciler(t *testing.T) {
 			wantWorkloads: []kueue.Workload{
 				*baseWorkloadWrapper.Clone().
 					PastAdmittedTime(1).
+					RequeueCount(2).
 					Condition(metav1.Condition{
 						Type:    kueue.WorkloadAdmitted,
 						Status:  metav1.ConditionFalse,
@@ -1438,6 +1897,64 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is evicted due to node drain, job gets suspended and the workload is requeued": {
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(true).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, testStartTime.Add(-time.Second)).
+					RequeueCount(2).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByNodeDrain,
+						Message: "The workload has pods on the drained nodes: node1",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					PastAdmittedTime(1).
+					RequeueCount(2).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "The workload has pods on the drained nodes: node1",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadRequeued,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByNodeDrain,
+						Message: "The workload has pods on the drained nodes: node1",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByNodeDrain,
+						Message: "The workload has pods on the drained nodes: node1",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "The workload has pods on the drained nodes: node1",
+				},
+			},
+		},
 		"when job is initially suspended, the Workload has active=false and it's not admitted, " +
 			"it should not get an evicted condition, but the job should remain suspended": {
 			job: *baseJobWrapper.Clone().
@@ -2644,6 +3161,55 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the workload is created with the priority determined by a custom priority resolver": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithPriorityResolver(namespaceTierResolver),
+			},
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				PriorityClass("test-pc").
+				Obj(),
+			priorityClasses: []client.Object{
+				basePCWrapper.Obj(),
+				utiltesting.MakeWorkloadPriorityClass("high-tier").PriorityValue(1000).Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				PriorityClass("test-pc").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").PriorityClass("test-pc").Obj()).
+					Queue("test-queue").
+					PriorityClass("high-tier").
+					Priority(1000).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Missing Workload; unable to restore pod templates",
+				},
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
 		"the workload is created when queue name is set, with workloadPriorityClass and PriorityClass": {
 			job: *baseJobWrapper.
 				Clone().
@@ -3234,17 +3800,16 @@ func TestReconciler(t *testing.T) {
 				t.Fatalf("Could not setup indexes: %v", err)
 			}
 			objs := append(tc.priorityClasses, &tc.job, utiltesting.MakeResourceFlavor("default").Obj(), testNamespace)
+			objs = append(objs, tc.clusterQueues...)
+			objs = append(objs, tc.localQueues...)
 			kcBuilder := clientBuilder.
-				WithObjects(objs...)
+				WithObjects(objs...).
+				WithStatusSubresource(&kueue.Workload{})
 
 			if len(tc.otherJobs) > 0 {
 				kcBuilder = kcBuilder.WithLists(&batchv1.JobList{Items: tc.otherJobs})
 			}
 
-			for i := range tc.workloads {
-				kcBuilder = kcBuilder.WithStatusSubresource(&tc.workloads[i])
-			}
-
 			// For prebuilt workloads we are skipping the ownership setup in the test body and
 			// expect the reconciler to do it.
 			_, useesPrebuiltWorkload := tc.job.Labels[controllerconsts.PrebuiltWorkloadLabel]
@@ -3299,3 +3864,118 @@ func TestReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerFinishedWorkloadWaitsForQuotaRelease(t *testing.T) {
+	t.Cleanup(jobframework.EnableIntegrationsForTest(t, FrameworkName))
+	ctx, _ := utiltesting.ContextWithLog(t)
+
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	finishedJob := utiltestingjob.MakeJob("finished", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "1").
+		Image("", nil).
+		Condition(batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}).
+		Obj()
+	admittedWl := utiltesting.MakeWorkload("finished", "ns").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		Queue("lq").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Admitted(true).
+		ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "finished", "").
+		Obj()
+	finishedWl := admittedWl.DeepCopy()
+	finishedWl.Status.Conditions = append(finishedWl.Status.Conditions, metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadFinishedReasonSucceeded,
+	})
+	pendingWl := utiltesting.MakeWorkload("pending", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "1").
+		Obj()
+
+	clientBuilder := utiltesting.NewClientBuilder()
+	if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+	kClient := clientBuilder.
+		WithObjects(utiltesting.MakeNamespace("ns"), finishedJob, finishedWl, pendingWl, lq).
+		WithStatusSubresource(&kueue.Workload{}).
+		Build()
+	cqCache := cache.New(kClient)
+	queues := queue.NewManager(kClient, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	if err := queues.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in manager: %v", err)
+	}
+	if err := queues.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Inserting localQueue in manager: %v", err)
+	}
+	// The cache didn't process the update of the workload to finished yet.
+	if !cqCache.AddOrUpdateWorkload(admittedWl) {
+		t.Fatalf("Inserting workload in cache failed")
+	}
+	sched := scheduler.New(queues, cqCache, kClient, &utiltesting.EventRecorder{})
+	reconciler := NewReconciler(kClient, &utiltesting.EventRecorder{}, jobframework.WithCache(cqCache))
+	jobReq := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(finishedJob)}
+	wlKey := client.ObjectKeyFromObject(finishedWl)
+
+	gotResult, err := reconciler.Reconcile(ctx, jobReq)
+	if err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{RequeueAfter: time.Second}, gotResult); diff != "" {
+		t.Errorf("Unexpected reconcile result (-want,+got):\n%s", diff)
+	}
+	var gotWl kueue.Workload
+	if err := kClient.Get(ctx, wlKey, &gotWl); err != nil {
+		t.Fatalf("Could not get the finished workload: %v", err)
+	}
+	if diff := cmp.Diff([]string{kueue.ResourceInUseFinalizerName}, gotWl.Finalizers); diff != "" {
+		t.Errorf("Unexpected finalizers before the quota is released (-want,+got):\n%s", diff)
+	}
+	plan, err := sched.ScheduleDryRun(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected dry-run error: %v", err)
+	}
+	if len(plan.Admissions) != 0 {
+		t.Errorf("Unexpected admissions over the quota before it is released: %v", plan.Admissions)
+	}
+
+	// The delayed update is processed by the cache, which releases the quota.
+	if err := cqCache.DeleteWorkload(finishedWl); err != nil {
+		t.Fatalf("Deleting workload from cache: %v", err)
+	}
+	gotResult, err = reconciler.Reconcile(ctx, jobReq)
+	if err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{}, gotResult); diff != "" {
+		t.Errorf("Unexpected reconcile result (-want,+got):\n%s", diff)
+	}
+	if err := kClient.Get(ctx, wlKey, &gotWl); err != nil {
+		t.Fatalf("Could not get the finished workload: %v", err)
+	}
+	if len(gotWl.Finalizers) != 0 {
+		t.Errorf("Expected the finalizer to be removed after the quota is released, got %v", gotWl.Finalizers)
+	}
+	plan, err = sched.ScheduleDryRun(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected dry-run error: %v", err)
+	}
+	wantAdmissions := []client.ObjectKey{client.ObjectKeyFromObject(pendingWl)}
+	gotAdmissions := make([]client.ObjectKey, 0, len(plan.Admissions))
+	for _, a := range plan.Admissions {
+		gotAdmissions = append(gotAdmissions, a.Workload)
+	}
+	if diff := cmp.Diff(wantAdmissions, gotAdmissions); diff != "" {
+		t.Errorf("Unexpected admissions after the quota is released (-want,+got):\n%s", diff)
+	}
+}
@@ -25,12 +25,14 @@ import (
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
@@ -40,26 +42,35 @@ import (
 var (
 	minPodsCountAnnotationsPath   = field.NewPath("metadata", "annotations").Key(JobMinParallelismAnnotation)
 	syncCompletionAnnotationsPath = field.NewPath("metadata", "annotations").Key(JobCompletionsEqualParallelismAnnotation)
+	admissionWaveAnnotationsPath  = field.NewPath("metadata", "annotations").Key(JobAdmissionWaveSizeAnnotation)
 	replicaMetaPath               = field.NewPath("spec", "template", "metadata")
 )
 
 type JobWebhook struct {
-	client                       client.Client
-	manageJobsWithoutQueueName   bool
-	managedJobsNamespaceSelector labels.Selector
-	queues                       *queue.Manager
-	cache                        *cache.Cache
+	client                         client.Client
+	manageJobsWithoutQueueName     bool
+	managedJobsNamespaceSelector   labels.Selector
+	queues                         *queue.Manager
+	cache                          *cache.Cache
+	admittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	multiKueueDisabledIntegrations sets.Set[string]
+	missingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	defaultQueueName               string
 }
 
 // SetupWebhook configures the webhook for batchJob.
 func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	options := jobframework.ProcessOptions(opts...)
 	wh := &JobWebhook{
-		client:                       mgr.GetClient(),
-		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		queues:                       options.Queues,
-		cache:                        options.Cache,
+		client:                         mgr.GetClient(),
+		manageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+		queues:                         options.Queues,
+		cache:                          options.Cache,
+		admittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+		multiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+		missingQueueNamePolicy:         options.MissingQueueNamePolicy,
+		defaultQueueName:               options.DefaultQueueName,
 	}
 	obj := &batchv1.Job{}
 	return webhook.WebhookManagedBy(mgr).
@@ -80,10 +91,11 @@ func (w *JobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultQueueName(job.Object(), w.missingQueueNamePolicy, w.defaultQueueName)
 	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
-	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, log)
+	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, w.multiKueueDisabledIntegrations, log)
 
 	return nil
 }
@@ -97,7 +109,9 @@ func (w *JobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("job-webhook")
 	log.V(5).Info("Validating create")
-	return nil, w.validateCreate(job).ToAggregate()
+	allErrs := w.validateCreate(job)
+	allErrs = append(allErrs, jobframework.ValidateMissingQueueNameOnCreate(job.Object(), w.missingQueueNamePolicy)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
@@ -105,6 +119,7 @@ func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
 	allErrs = append(allErrs, jobframework.ValidateJobOnCreate(job)...)
 	allErrs = append(allErrs, w.validatePartialAdmissionCreate(job)...)
 	allErrs = append(allErrs, w.validateSyncCompletionCreate(job)...)
+	allErrs = append(allErrs, validateAdmissionWavesCreate(job)...)
 	allErrs = append(allErrs, w.validateTopologyRequest(job)...)
 	return allErrs
 }
@@ -141,6 +156,26 @@ func (w *JobWebhook) validateSyncCompletionCreate(job *Job) field.ErrorList {
 	return allErrs
 }
 
+func validateAdmissionWavesCreate(job *Job) field.ErrorList {
+	var allErrs field.ErrorList
+	strVal, found := job.Annotations[JobAdmissionWaveSizeAnnotation]
+	if !found {
+		return allErrs
+	}
+	if v, err := strconv.Atoi(strVal); err != nil {
+		allErrs = append(allErrs, field.Invalid(admissionWaveAnnotationsPath, strVal, err.Error()))
+	} else if v <= 0 {
+		allErrs = append(allErrs, field.Invalid(admissionWaveAnnotationsPath, v, "should be greater than 0"))
+	}
+	if ptr.Deref(job.Spec.CompletionMode, batchv1.NonIndexedCompletion) != batchv1.IndexedCompletion {
+		allErrs = append(allErrs, field.Invalid(admissionWaveAnnotationsPath, strVal, "should not be set for NonIndexed jobs"))
+	}
+	if _, found := job.Annotations[JobMinParallelismAnnotation]; found {
+		allErrs = append(allErrs, field.Invalid(admissionWaveAnnotationsPath, strVal, fmt.Sprintf("should not be set together with %s", JobMinParallelismAnnotation)))
+	}
+	return allErrs
+}
+
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
 func (w *JobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldJob := fromObject(oldObj)
@@ -157,7 +192,13 @@ func (w *JobWebhook) validateUpdate(oldJob, newJob *Job) field.ErrorList {
 		allErrs = append(allErrs, w.validatePartialAdmissionCreate(newJob)...)
 	}
 	allErrs = append(allErrs, w.validateSyncCompletionCreate(newJob)...)
-	allErrs = append(allErrs, jobframework.ValidateJobOnUpdate(oldJob, newJob)...)
+	if newJob.Annotations[JobAdmissionWaveSizeAnnotation] != oldJob.Annotations[JobAdmissionWaveSizeAnnotation] {
+		allErrs = append(allErrs, validateAdmissionWavesCreate(newJob)...)
+		if !oldJob.IsSuspended() {
+			allErrs = append(allErrs, field.Forbidden(admissionWaveAnnotationsPath, fmt.Sprintf("%s while the job is not suspended", apivalidation.FieldImmutableErrorMsg)))
+		}
+	}
+	allErrs = append(allErrs, jobframework.ValidateJobOnUpdate(oldJob, newJob, w.admittedJobUpdatePolicy)...)
 	allErrs = append(allErrs, validatePartialAdmissionUpdate(oldJob, newJob)...)
 	allErrs = append(allErrs, w.validateTopologyRequest(newJob)...)
 	return allErrs
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	jobsetapi "sigs.k8s.io/jobset/api/jobset/v1alpha2"
@@ -254,7 +255,49 @@ func TestValidateCreate(t *testing.T) {
 			wantErr: field.ErrorList{
 				field.Invalid(replicaMetaPath.Child("annotations"), field.OmitValueType{},
 					`must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`),
+						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`),
+			},
+		},
+		{
+			name: "valid admission wave size annotation",
+			job: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+				Obj(),
+			wantErr: nil,
+		},
+		{
+			name: "invalid admission wave size annotation (badValue)",
+			job: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "0").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(admissionWaveAnnotationsPath, 0, "should be greater than 0"),
+			},
+		},
+		{
+			name: "invalid admission wave size annotation (NonIndexed)",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(admissionWaveAnnotationsPath, "2", "should not be set for NonIndexed jobs"),
+			},
+		},
+		{
+			name: "invalid admission wave size annotation (with partial admission)",
+			job: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobMinParallelismAnnotation, "2").
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(admissionWaveAnnotationsPath, "2", "should not be set together with "+JobMinParallelismAnnotation),
 			},
 		},
 		{
@@ -313,6 +356,20 @@ func TestValidateUpdate(t *testing.T) {
 				field.Invalid(queueNameLabelPath, kueue.LocalQueueName("queue"), apivalidation.FieldImmutableErrorMsg),
 			},
 		},
+		{
+			name:    "add queue name with suspend is false, to adopt the job",
+			oldJob:  testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+			newJob:  testingutil.MakeJob("job", "default").Queue("queue").SetAnnotation(constants.AdoptAnnotation, "true").Suspend(false).Obj(),
+			wantErr: nil,
+		},
+		{
+			name:   "change queue name with suspend is false, with the adopt annotation",
+			oldJob: testingutil.MakeJob("job", "default").Queue("queue").SetAnnotation(constants.AdoptAnnotation, "true").Obj(),
+			newJob: testingutil.MakeJob("job", "default").Queue("queue2").SetAnnotation(constants.AdoptAnnotation, "true").Suspend(false).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(queueNameLabelPath, kueue.LocalQueueName("queue2"), apivalidation.FieldImmutableErrorMsg),
+			},
+		},
 		{
 			name:    "add queue name with suspend is true",
 			oldJob:  testingutil.MakeJob("job", "default").Obj(),
@@ -339,6 +396,38 @@ func TestValidateUpdate(t *testing.T) {
 			newJob:  testingutil.MakeJob("job", "default").Queue("queue name").Suspend(true).Obj(),
 			wantErr: field.ErrorList{field.Invalid(queueNameLabelPath, "queue name", invalidRFC1123Message)},
 		},
+		{
+			name: "immutable admission wave size while unsuspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+				Suspend(false).
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "3").
+				Suspend(false).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(admissionWaveAnnotationsPath, apivalidation.FieldImmutableErrorMsg+" while the job is not suspended"),
+			},
+		},
+		{
+			name: "mutable admission wave size while suspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "2").
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				CompletionMode(batchv1.IndexedCompletion).
+				Parallelism(4).
+				SetAnnotation(JobAdmissionWaveSizeAnnotation, "3").
+				Obj(),
+			wantErr: nil,
+		},
 		{
 			name: "immutable parallelism while unsuspended with partial admission enabled",
 			oldJob: testingutil.MakeJob("job", "default").
@@ -534,7 +623,7 @@ func TestValidateUpdate(t *testing.T) {
 			wantErr: field.ErrorList{
 				field.Invalid(replicaMetaPath.Child("annotations"), field.OmitValueType{},
 					`must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`)},
+						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`)},
 		},
 	}
 
@@ -558,6 +647,7 @@ func TestDefault(t *testing.T) {
 		manageJobsWithoutQueueName             bool
 		multiKueueEnabled                      bool
 		multiKueueBatchJobWithManagedByEnabled bool
+		multiKueueDisabledIntegrations         []string
 		localQueueDefaulting                   bool
 		defaultLqExist                         bool
 		enableIntegrations                     []string
@@ -609,6 +699,32 @@ func TestDefault(t *testing.T) {
 			multiKueueEnabled:                      true,
 			multiKueueBatchJobWithManagedByEnabled: true,
 		},
+		"no change in managed by: MultiKueue disabled for the integration": {
+			job: testingutil.MakeJob("job", "default").
+				Queue("local-queue").
+				Suspend(false).
+				Obj(),
+			queues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("local-queue", "default").
+					ClusterQueue("cluster-queue").
+					Obj(),
+			},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cluster-queue").
+					AdmissionChecks("admission-check").
+					Obj(),
+			},
+			admissionCheck: utiltesting.MakeAdmissionCheck("admission-check").
+				ControllerName(kueue.MultiKueueControllerName).
+				Active(metav1.ConditionTrue).
+				Obj(),
+			want: testingutil.MakeJob("job", "default").
+				Queue("local-queue").
+				Obj(),
+			multiKueueEnabled:                      true,
+			multiKueueBatchJobWithManagedByEnabled: true,
+			multiKueueDisabledIntegrations:         []string{"batch/job"},
+		},
 		"no change in managed by: user specified managed by": {
 			job: testingutil.MakeJob("job", "default").
 				Queue("local-queue").
@@ -737,11 +853,12 @@ func TestDefault(t *testing.T) {
 			}
 			t.Cleanup(jobframework.EnableIntegrationsForTest(t, tc.enableIntegrations...))
 			w := &JobWebhook{
-				client:                       cl,
-				manageJobsWithoutQueueName:   tc.manageJobsWithoutQueueName,
-				managedJobsNamespaceSelector: labels.Everything(),
-				queues:                       queueManager,
-				cache:                        cqCache,
+				client:                         cl,
+				manageJobsWithoutQueueName:     tc.manageJobsWithoutQueueName,
+				managedJobsNamespaceSelector:   labels.Everything(),
+				queues:                         queueManager,
+				cache:                          cqCache,
+				multiKueueDisabledIntegrations: sets.New(tc.multiKueueDisabledIntegrations...),
 			}
 			gotErr := w.Default(ctx, tc.job)
 			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
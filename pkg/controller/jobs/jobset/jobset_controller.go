@@ -19,6 +19,7 @@ package jobset
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	batchv1 "k8s.io/api/batch/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -43,6 +44,12 @@ var (
 	FrameworkName = "jobset.x-k8s.io/jobset"
 )
 
+const (
+	// ReplicatedJobMinReplicasAnnotation is set on the Job template of a
+	// ReplicatedJob to let Kueue admit it with as few as this number of replicas.
+	ReplicatedJobMinReplicasAnnotation = "kueue.x-k8s.io/replicated-job-min-replicas"
+)
+
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
 		SetupIndexes:      SetupIndexes,
@@ -119,6 +126,9 @@ func (j *JobSet) PodSets() ([]kueue.PodSet, error) {
 			Template: *replicatedJob.Template.Spec.Template.DeepCopy(),
 			Count:    podsCount(&replicatedJob),
 		}
+		if minReplicas := minReplicasCount(&replicatedJob); minReplicas != nil {
+			podSets[index].MinCount = ptr.To(*minReplicas * podsCountPerReplica(&replicatedJob))
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			podSets[index].TopologyRequest = jobframework.PodSetTopologyRequest(
 				&replicatedJob.Template.Spec.Template.ObjectMeta,
@@ -140,8 +150,13 @@ func (j *JobSet) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	// If there are Jobs already created by the JobSet, their node selectors will be updated by the JobSet controller
 	// before unsuspending the individual Jobs.
 	for index := range j.Spec.ReplicatedJobs {
-		template := &j.Spec.ReplicatedJobs[index].Template.Spec.Template
+		replicatedJob := &j.Spec.ReplicatedJobs[index]
+		template := &replicatedJob.Template.Spec.Template
 		info := podSetsInfo[index]
+		// if the replicated job accepts partial admission
+		if minReplicasCount(replicatedJob) != nil {
+			replicatedJob.Replicas = info.Count / podsCountPerReplica(replicatedJob)
+		}
 		if err := podset.Merge(&template.ObjectMeta, &template.Spec, info); err != nil {
 			return err
 		}
@@ -155,8 +170,15 @@ func (j *JobSet) RestorePodSetsInfo(podSetsInfo []podset.PodSetInfo) bool {
 	}
 	changed := false
 	for index := range j.Spec.ReplicatedJobs {
-		replica := &j.Spec.ReplicatedJobs[index].Template.Spec.Template
+		replicatedJob := &j.Spec.ReplicatedJobs[index]
+		replica := &replicatedJob.Template.Spec.Template
 		info := podSetsInfo[index]
+		if minReplicasCount(replicatedJob) != nil {
+			if replicas := info.Count / podsCountPerReplica(replicatedJob); replicatedJob.Replicas != replicas {
+				replicatedJob.Replicas = replicas
+				changed = true
+			}
+		}
 		changed = podset.RestorePodSpec(&replica.ObjectMeta, &replica.Spec, info) || changed
 	}
 	return changed
@@ -235,6 +257,15 @@ func podsCount(rj *jobsetapi.ReplicatedJob) int32 {
 	return rj.Replicas * podsCountPerReplica(rj)
 }
 
+func minReplicasCount(rj *jobsetapi.ReplicatedJob) *int32 {
+	if strVal, found := rj.Template.Annotations[ReplicatedJobMinReplicasAnnotation]; found {
+		if iVal, err := strconv.Atoi(strVal); err == nil {
+			return ptr.To[int32](int32(iVal))
+		}
+	}
+	return nil
+}
+
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
@@ -28,15 +28,19 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/podset"
+	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjobset "sigs.k8s.io/kueue/pkg/util/testingjobs/jobset"
 )
@@ -228,6 +232,31 @@ func TestPodSets(t *testing.T) {
 			},
 			enableTopologyAwareScheduling: false,
 		},
+		"with minimum replicas": {
+			jobSet: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "job1", Replicas: 1, Parallelism: 1, Completions: 1},
+					testingjobset.ReplicatedJobRequirements{
+						Name:        "job2",
+						Replicas:    4,
+						Parallelism: 1,
+						Completions: 1,
+						Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+					},
+				).
+				Obj()),
+			wantPodSets: func(jobSet *JobSet) []kueue.PodSet {
+				return []kueue.PodSet{
+					*utiltesting.MakePodSet(kueue.NewPodSetReference(jobSet.Spec.ReplicatedJobs[0].Name), 1).
+						PodSpec(*jobSet.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.DeepCopy()).
+						Obj(),
+					*utiltesting.MakePodSet(kueue.NewPodSetReference(jobSet.Spec.ReplicatedJobs[1].Name), 4).
+						PodSpec(*jobSet.Spec.ReplicatedJobs[1].Template.Spec.Template.Spec.DeepCopy()).
+						SetMinimumCount(2).
+						Obj(),
+				}
+			},
+		},
 		"with required topology annotation": {
 			jobSet: (*JobSet)(jobSetTemplate.Clone().
 				ReplicatedJobs(
@@ -348,10 +377,107 @@ func TestPodSets(t *testing.T) {
 	}
 }
 
+func TestRunWithPodSetsInfo(t *testing.T) {
+	jobSetTemplate := testingjobset.MakeJobSet("jobset", "ns")
+
+	testCases := map[string]struct {
+		jobSet          *JobSet
+		podSetsInfo     []podset.PodSetInfo
+		wantJobSet      *JobSet
+		wantRestoredJob *JobSet
+	}{
+		"partially admitted with the minimum number of replicas": {
+			jobSet: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "driver", Replicas: 1, Parallelism: 1, Completions: 1},
+					testingjobset.ReplicatedJobRequirements{
+						Name:        "workers",
+						Replicas:    4,
+						Parallelism: 1,
+						Completions: 1,
+						Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+					},
+				).
+				Obj()),
+			podSetsInfo: []podset.PodSetInfo{
+				{Name: "driver", Count: 1},
+				{Name: "workers", Count: 2},
+			},
+			wantJobSet: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "driver", Replicas: 1, Parallelism: 1, Completions: 1},
+					testingjobset.ReplicatedJobRequirements{
+						Name:        "workers",
+						Replicas:    2,
+						Parallelism: 1,
+						Completions: 1,
+						Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+					},
+				).
+				Suspend(false).
+				Obj()),
+			wantRestoredJob: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "driver", Replicas: 1, Parallelism: 1, Completions: 1},
+					testingjobset.ReplicatedJobRequirements{
+						Name:        "workers",
+						Replicas:    4,
+						Parallelism: 1,
+						Completions: 1,
+						Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+					},
+				).
+				Suspend(false).
+				Obj()),
+		},
+		"replicas are not changed without the minimum number of replicas": {
+			jobSet: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "workers", Replicas: 4, Parallelism: 1, Completions: 1},
+				).
+				Obj()),
+			podSetsInfo: []podset.PodSetInfo{
+				{Name: "workers", Count: 4},
+			},
+			wantJobSet: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "workers", Replicas: 4, Parallelism: 1, Completions: 1},
+				).
+				Suspend(false).
+				Obj()),
+			wantRestoredJob: (*JobSet)(jobSetTemplate.Clone().
+				ReplicatedJobs(
+					testingjobset.ReplicatedJobRequirements{Name: "workers", Replicas: 4, Parallelism: 1, Completions: 1},
+				).
+				Suspend(false).
+				Obj()),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			originalPodSets, err := tc.jobSet.PodSets()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := tc.jobSet.RunWithPodSetsInfo(tc.podSetsInfo); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantJobSet, tc.jobSet, jobCmpOpts); diff != "" {
+				t.Errorf("unexpected jobset after running (-want +got):\n%s", diff)
+			}
+			tc.jobSet.RestorePodSetsInfo(utilslices.Map(originalPodSets, podset.FromPodSet))
+			if diff := cmp.Diff(tc.wantRestoredJob, tc.jobSet, jobCmpOpts); diff != "" {
+				t.Errorf("unexpected jobset after restoring (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 var (
 	jobCmpOpts = cmp.Options{
 		cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(jobset.JobSet{}, "TypeMeta", "ObjectMeta"),
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
 	}
 	workloadCmpOpts = cmp.Options{
 		cmpopts.EquateEmpty(),
@@ -360,6 +486,9 @@ var (
 		cmpopts.IgnoreFields(kueue.WorkloadSpec{}, "Priority"),
 		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
 		cmpopts.IgnoreFields(kueue.PodSet{}, "Template"),
+		cmpopts.SortSlices(func(a, b metav1.Condition) bool {
+			return a.Type < b.Type
+		}),
 	}
 )
 
@@ -371,9 +500,35 @@ func TestReconciler(t *testing.T) {
 
 	testNamespace := utiltesting.MakeNamespaceWrapper("ns").Label(corev1.LabelMetadataName, "ns").Obj()
 
+	runningJobSet := testingjobset.MakeJobSet("jobset", "ns").
+		ReplicatedJobs(testingjobset.ReplicatedJobRequirements{
+			Name:        "replicated-job-1",
+			Replicas:    1,
+			Completions: 1,
+			Parallelism: 1,
+		}).
+		Queue("foo").
+		Suspend(false)
+	runningPodSets, err := fromObject(runningJobSet.Obj()).PodSets()
+	if err != nil {
+		t.Fatalf("Unable to get the pod sets of the JobSet: %v", err)
+	}
+	baseWorkload := utiltesting.MakeWorkload("jobset", "ns").
+		Queue("foo").
+		ControllerReference(gvk, "jobset", "").
+		PodSets(runningPodSets...)
+	admission := utiltesting.MakeAdmission("cq", "replicated-job-1").AssignmentPodCount(1).Obj()
+	startupConditionWaitForPodsReady := &configapi.WaitForPodsReady{
+		Enable: true,
+		PodsReadyConditions: []configapi.PodsReadyCondition{
+			{Framework: FrameworkName, ConditionType: string(jobset.JobSetStartupPolicyCompleted)},
+		},
+	}
+
 	cases := map[string]struct {
 		reconcilerOptions []jobframework.Option
 		job               *jobset.JobSet
+		workloads         []kueue.Workload
 		priorityClasses   []client.Object
 		wantJob           *jobset.JobSet
 		wantWorkloads     []kueue.Workload
@@ -500,6 +655,86 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"PodsReady is derived from the StartupPolicyCompleted condition of the JobSet": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(startupConditionWaitForPodsReady),
+			},
+			job: runningJobSet.Clone().
+				Condition(metav1.Condition{
+					Type:   string(jobset.JobSetStartupPolicyCompleted),
+					Status: metav1.ConditionTrue,
+					Reason: "AllReplicatedJobsStarted",
+				}).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkload.Clone().ReserveQuota(admission).Admitted(true).Obj()},
+			wantJob: runningJobSet.Clone().
+				Condition(metav1.Condition{
+					Type:   string(jobset.JobSetStartupPolicyCompleted),
+					Status: metav1.ConditionTrue,
+					Reason: "AllReplicatedJobsStarted",
+				}).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().
+					ReserveQuota(admission).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPodsReady,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadStarted,
+						Message: "All pods reached readiness and the workload is running",
+					}).
+					Obj(),
+			},
+		},
+		"PodsReady is False until the JobSet has the StartupPolicyCompleted condition, even if the pods are ready": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(startupConditionWaitForPodsReady),
+			},
+			job: runningJobSet.Clone().
+				JobsStatus(jobset.ReplicatedJobStatus{Name: "replicated-job-1", Ready: 1}).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkload.Clone().ReserveQuota(admission).Admitted(true).Obj()},
+			wantJob: runningJobSet.Clone().
+				JobsStatus(jobset.ReplicatedJobStatus{Name: "replicated-job-1", Ready: 1}).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().
+					ReserveQuota(admission).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPodsReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  kueue.WorkloadWaitForStart,
+						Message: "Not all pods are ready or succeeded",
+					}).
+					Obj(),
+			},
+		},
+		"PodsReady is derived from the pods when no condition is configured for the JobSet": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithWaitForPodsReady(&configapi.WaitForPodsReady{Enable: true}),
+			},
+			job: runningJobSet.Clone().
+				JobsStatus(jobset.ReplicatedJobStatus{Name: "replicated-job-1", Ready: 1}).
+				Obj(),
+			workloads: []kueue.Workload{*baseWorkload.Clone().ReserveQuota(admission).Admitted(true).Obj()},
+			wantJob: runningJobSet.Clone().
+				JobsStatus(jobset.ReplicatedJobStatus{Name: "replicated-job-1", Ready: 1}).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().
+					ReserveQuota(admission).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPodsReady,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadStarted,
+						Message: "All pods reached readiness and the workload is running",
+					}).
+					Obj(),
+			},
+		},
 		"workload is created with podsets, workloadPriorityClass and PriorityClass": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -540,11 +775,16 @@ func TestReconciler(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctx, _ := utiltesting.ContextWithLog(t)
-			clientBuilder := utiltesting.NewClientBuilder(jobset.AddToScheme)
+			clientBuilder := utiltesting.NewClientBuilder(jobset.AddToScheme).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
 				t.Fatalf("Could not setup indexes: %v", err)
 			}
 			objs := append(tc.priorityClasses, tc.job, testNamespace)
+			for i := range tc.workloads {
+				objs = append(objs, &tc.workloads[i])
+				clientBuilder = clientBuilder.WithStatusSubresource(&tc.workloads[i])
+			}
 			kClient := clientBuilder.WithObjects(objs...).Build()
 			recorder := record.NewBroadcaster().NewRecorder(kClient.Scheme(), corev1.EventSource{Component: "test"})
 			reconciler := NewReconciler(kClient, recorder, tc.reconcilerOptions...)
@@ -18,15 +18,20 @@ package jobset
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	jobsetapi "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
@@ -38,22 +43,30 @@ var (
 )
 
 type JobSetWebhook struct {
-	client                       client.Client
-	manageJobsWithoutQueueName   bool
-	managedJobsNamespaceSelector labels.Selector
-	queues                       *queue.Manager
-	cache                        *cache.Cache
+	client                         client.Client
+	manageJobsWithoutQueueName     bool
+	managedJobsNamespaceSelector   labels.Selector
+	queues                         *queue.Manager
+	cache                          *cache.Cache
+	admittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	multiKueueDisabledIntegrations sets.Set[string]
+	missingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	defaultQueueName               string
 }
 
 // SetupJobSetWebhook configures the webhook for kubeflow JobSet.
 func SetupJobSetWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	options := jobframework.ProcessOptions(opts...)
 	wh := &JobSetWebhook{
-		client:                       mgr.GetClient(),
-		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		queues:                       options.Queues,
-		cache:                        options.Cache,
+		client:                         mgr.GetClient(),
+		manageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+		queues:                         options.Queues,
+		cache:                          options.Cache,
+		admittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+		multiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+		missingQueueNamePolicy:         options.MissingQueueNamePolicy,
+		defaultQueueName:               options.DefaultQueueName,
 	}
 	obj := &jobsetapi.JobSet{}
 	return webhook.WebhookManagedBy(mgr).
@@ -74,11 +87,12 @@ func (w *JobSetWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(jobSet.Object(), w.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultQueueName(jobSet.Object(), w.missingQueueNamePolicy, w.defaultQueueName)
 	if err := jobframework.ApplyDefaultForSuspend(ctx, jobSet, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
 
-	jobframework.ApplyDefaultForManagedBy(jobSet, w.queues, w.cache, log)
+	jobframework.ApplyDefaultForManagedBy(jobSet, w.queues, w.cache, w.multiKueueDisabledIntegrations, log)
 
 	return nil
 }
@@ -92,7 +106,9 @@ func (w *JobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	jobSet := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("jobset-webhook")
 	log.Info("Validating create")
-	return nil, w.validateCreate(jobSet).ToAggregate()
+	allErrs := w.validateCreate(jobSet)
+	allErrs = append(allErrs, jobframework.ValidateMissingQueueNameOnCreate(jobSet.Object(), w.missingQueueNamePolicy)...)
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -106,8 +122,23 @@ func (w *JobSetWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 
 func (w *JobSetWebhook) validateUpdate(oldJob, newJob *JobSet) field.ErrorList {
 	var allErrs field.ErrorList
-	allErrs = append(allErrs, jobframework.ValidateJobOnUpdate(oldJob, newJob)...)
+	allErrs = append(allErrs, jobframework.ValidateJobOnUpdate(oldJob, newJob, w.admittedJobUpdatePolicy)...)
 	allErrs = append(allErrs, w.validateCreate(newJob)...)
+	allErrs = append(allErrs, validatePartialAdmissionUpdate(oldJob, newJob)...)
+	return allErrs
+}
+
+func validatePartialAdmissionUpdate(oldJob, newJob *JobSet) field.ErrorList {
+	var allErrs field.ErrorList
+	if ptr.Deref(oldJob.Spec.Suspend, false) || len(oldJob.Spec.ReplicatedJobs) != len(newJob.Spec.ReplicatedJobs) {
+		return allErrs
+	}
+	for i := range oldJob.Spec.ReplicatedJobs {
+		oldReplicatedJob := &oldJob.Spec.ReplicatedJobs[i]
+		if minReplicasCount(oldReplicatedJob) != nil && oldReplicatedJob.Replicas != newJob.Spec.ReplicatedJobs[i].Replicas {
+			allErrs = append(allErrs, field.Forbidden(replicatedJobsPath.Index(i).Child("replicas"), "cannot change when partial admission is enabled and the jobset is not suspended"))
+		}
+	}
 	return allErrs
 }
 
@@ -115,6 +146,29 @@ func (w *JobSetWebhook) validateCreate(jobSet *JobSet) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, jobframework.ValidateJobOnCreate(jobSet)...)
 	allErrs = append(allErrs, w.validateTopologyRequest(jobSet)...)
+	allErrs = append(allErrs, w.validatePartialAdmission(jobSet)...)
+	return allErrs
+}
+
+func (w *JobSetWebhook) validatePartialAdmission(jobSet *JobSet) field.ErrorList {
+	var allErrs field.ErrorList
+	for i := range jobSet.Spec.ReplicatedJobs {
+		replicatedJob := &jobSet.Spec.ReplicatedJobs[i]
+		strVal, found := replicatedJob.Template.Annotations[ReplicatedJobMinReplicasAnnotation]
+		if !found {
+			continue
+		}
+		annotationPath := replicatedJobsPath.Index(i).Child("template", "metadata", "annotations").Key(ReplicatedJobMinReplicasAnnotation)
+		v, err := strconv.Atoi(strVal)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationPath, strVal, err.Error()))
+		} else if int32(v) >= replicatedJob.Replicas || v <= 0 {
+			allErrs = append(allErrs, field.Invalid(annotationPath, v, fmt.Sprintf("should be between 0 and %d", replicatedJob.Replicas-1)))
+		}
+		if podsCountPerReplica(replicatedJob) != 1 {
+			allErrs = append(allErrs, field.Invalid(annotationPath, strVal, "can only be set when each replica runs a single pod"))
+		}
+	}
 	return allErrs
 }
 
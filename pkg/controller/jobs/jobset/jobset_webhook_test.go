@@ -97,7 +97,41 @@ func TestValidateCreate(t *testing.T) {
 			}).Obj(),
 			wantErr: field.ErrorList{field.Invalid(field.NewPath("spec.replicatedJobs[1].template.metadata.annotations"),
 				field.OmitValueType{}, `must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-					`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`)}.ToAggregate(),
+					`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`)}.ToAggregate(),
+		},
+		{
+			name: "valid minimum replicas",
+			job: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    4,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Obj(),
+		},
+		{
+			name: "minimum replicas not lower than the replicas",
+			job: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    4,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "4"},
+			}).Obj(),
+			wantErr: field.ErrorList{field.Invalid(replicatedJobsPath.Index(0).Child("template", "metadata", "annotations").Key(ReplicatedJobMinReplicasAnnotation),
+				4, "should be between 0 and 3")}.ToAggregate(),
+		},
+		{
+			name: "minimum replicas with multiple pods per replica",
+			job: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    4,
+				Parallelism: 2,
+				Completions: 2,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Obj(),
+			wantErr: field.ErrorList{field.Invalid(replicatedJobsPath.Index(0).Child("template", "metadata", "annotations").Key(ReplicatedJobMinReplicasAnnotation),
+				"2", "can only be set when each replica runs a single pod")}.ToAggregate(),
 		},
 	}
 
@@ -148,7 +182,42 @@ func TestValidateUpdate(t *testing.T) {
 			}).Obj(),
 			wantErr: field.ErrorList{field.Invalid(field.NewPath("spec.replicatedJobs[0].template.metadata.annotations"),
 				field.OmitValueType{}, `must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-					`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`)},
+					`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`)},
+		},
+		{
+			name: "change replicas with minimum replicas while suspended",
+			oldJob: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    4,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Obj(),
+			newJob: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    3,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Obj(),
+		},
+		{
+			name: "change replicas with minimum replicas while running",
+			oldJob: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    4,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Suspend(false).Obj(),
+			newJob: testingutil.MakeJobSet("job", "default").ReplicatedJobs(testingutil.ReplicatedJobRequirements{
+				Name:        "worker",
+				Replicas:    3,
+				Parallelism: 1,
+				Completions: 1,
+				Annotations: map[string]string{ReplicatedJobMinReplicasAnnotation: "2"},
+			}).Suspend(false).Obj(),
+			wantErr: field.ErrorList{field.Forbidden(replicatedJobsPath.Index(0).Child("replicas"), "cannot change when partial admission is enabled and the jobset is not suspended")},
 		},
 	}
 
@@ -81,6 +81,33 @@ func TestMultiKueueAdapter(t *testing.T) {
 					Obj(),
 			},
 		},
+		"sync fails when the remote PaddleJob belongs to another workload": {
+			managersPaddleJobs: []kftraining.PaddleJob{
+				*paddleJobBuilder.Clone().Obj(),
+			},
+			workerPaddleJobs: []kftraining.PaddleJob{
+				*paddleJobBuilder.Clone().
+					Label(constants.PrebuiltWorkloadLabel, "wl2").
+					Label(kueue.MultiKueueOriginLabel, "origin1").
+					StatusConditions(kftraining.JobCondition{Type: kftraining.JobSucceeded, Status: corev1.ConditionTrue}).
+					Obj(),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, types.NamespacedName{Name: "paddlejob1", Namespace: TestNamespace}, "wl1", "origin1")
+			},
+
+			wantError: jobframework.ErrPrebuiltWorkloadMismatch,
+			wantManagersPaddleJobs: []kftraining.PaddleJob{
+				*paddleJobBuilder.Clone().Obj(),
+			},
+			wantWorkerPaddleJobs: []kftraining.PaddleJob{
+				*paddleJobBuilder.Clone().
+					Label(constants.PrebuiltWorkloadLabel, "wl2").
+					Label(kueue.MultiKueueOriginLabel, "origin1").
+					StatusConditions(kftraining.JobCondition{Type: kftraining.JobSucceeded, Status: corev1.ConditionTrue}).
+					Obj(),
+			},
+		},
 		"sync status from remote PaddleJob": {
 			managersPaddleJobs: []kftraining.PaddleJob{
 				*paddleJobBuilder.Clone().Obj(),
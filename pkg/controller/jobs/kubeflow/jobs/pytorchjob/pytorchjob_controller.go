@@ -80,6 +80,7 @@ var NewReconciler = jobframework.NewGenericReconcilerFactory(func() jobframework
 type JobControl kftraining.PyTorchJob
 
 var _ kubeflowjob.KFJobControl = (*JobControl)(nil)
+var _ kubeflowjob.KFJobWithElasticPolicy = (*JobControl)(nil)
 
 func (j *JobControl) Object() client.Object {
 	return (*kftraining.PyTorchJob)(j)
@@ -117,6 +118,17 @@ func (j *JobControl) OrderedReplicaTypes() []kftraining.ReplicaType {
 	return []kftraining.ReplicaType{kftraining.PyTorchJobReplicaTypeMaster, kftraining.PyTorchJobReplicaTypeWorker}
 }
 
+func (j *JobControl) ElasticReplicaType() kftraining.ReplicaType {
+	return kftraining.PyTorchJobReplicaTypeWorker
+}
+
+func (j *JobControl) ElasticReplicas() (*int32, *int32) {
+	if j.Spec.ElasticPolicy == nil {
+		return nil, nil
+	}
+	return j.Spec.ElasticPolicy.MinReplicas, j.Spec.ElasticPolicy.MaxReplicas
+}
+
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
@@ -80,6 +80,33 @@ func TestMultiKueueAdapter(t *testing.T) {
 					Obj(),
 			},
 		},
+		"sync fails when the remote tfjob belongs to another workload": {
+			managersTFJobs: []kftraining.TFJob{
+				*tfJobBuilder.Clone().Obj(),
+			},
+			workerTFJobs: []kftraining.TFJob{
+				*tfJobBuilder.Clone().
+					Label(constants.PrebuiltWorkloadLabel, "wl2").
+					Label(kueue.MultiKueueOriginLabel, "origin1").
+					StatusConditions(kftraining.JobCondition{Type: kftraining.JobSucceeded, Status: corev1.ConditionTrue}).
+					Obj(),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, types.NamespacedName{Name: "tfjob1", Namespace: TestNamespace}, "wl1", "origin1")
+			},
+
+			wantError: jobframework.ErrPrebuiltWorkloadMismatch,
+			wantManagersTFJobs: []kftraining.TFJob{
+				*tfJobBuilder.Clone().Obj(),
+			},
+			wantWorkerTFJobs: []kftraining.TFJob{
+				*tfJobBuilder.Clone().
+					Label(constants.PrebuiltWorkloadLabel, "wl2").
+					Label(kueue.MultiKueueOriginLabel, "origin1").
+					StatusConditions(kftraining.JobCondition{Type: kftraining.JobSucceeded, Status: corev1.ConditionTrue}).
+					Obj(),
+			},
+		},
 		"sync status from remote tfjob": {
 			managersTFJobs: []kftraining.TFJob{
 				*tfJobBuilder.Clone().Obj(),
@@ -38,3 +38,13 @@ type KFJobControl interface {
 	// OrderedReplicaTypes returns the ordered list of ReplicaTypes for the KFJob.
 	OrderedReplicaTypes() []kftraining.ReplicaType
 }
+
+// KFJobWithElasticPolicy is implemented by the KFJobs which can run one of
+// their replica types with a number of replicas within a range.
+type KFJobWithElasticPolicy interface {
+	// ElasticReplicaType returns the ReplicaType which can be scaled.
+	ElasticReplicaType() kftraining.ReplicaType
+	// ElasticReplicas returns the minimum and the maximum number of replicas
+	// of the elastic ReplicaType, if set.
+	ElasticReplicas() (minReplicas, maxReplicas *int32)
+}
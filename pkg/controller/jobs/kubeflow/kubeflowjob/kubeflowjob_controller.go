@@ -18,6 +18,7 @@ package kubeflowjob
 
 import (
 	"sort"
+	"strconv"
 
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +33,11 @@ import (
 	"sigs.k8s.io/kueue/pkg/podset"
 )
 
+// OriginalReplicasAnnotation keeps the number of replicas of the elastic
+// ReplicaType set by the user while the job runs with the admitted number of
+// replicas, so that it can be restored when the job is suspended.
+const OriginalReplicasAnnotation = "kueue.x-k8s.io/original-replicas"
+
 type KubeflowJob struct {
 	KFJobControl KFJobControl
 }
@@ -65,6 +71,10 @@ func (j *KubeflowJob) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error
 	for index := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
 		info := podSetsInfo[index]
+		if _, _, ok := j.elasticReplicas(replicaType); ok {
+			j.saveOriginalReplicas(j.KFJobControl.ReplicaSpecs()[replicaType].Replicas)
+			j.KFJobControl.ReplicaSpecs()[replicaType].Replicas = ptr.To(info.Count)
+		}
 		replica := &j.KFJobControl.ReplicaSpecs()[replicaType].Template
 		if err := podset.Merge(&replica.ObjectMeta, &replica.Spec, info); err != nil {
 			return err
@@ -78,6 +88,9 @@ func (j *KubeflowJob) RestorePodSetsInfo(podSetsInfo []podset.PodSetInfo) bool {
 	changed := false
 	for index, info := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
+		if _, _, ok := j.elasticReplicas(replicaType); ok {
+			changed = j.restoreOriginalReplicas(replicaType) || changed
+		}
 		replica := &j.KFJobControl.ReplicaSpecs()[replicaType].Template
 		changed = podset.RestorePodSpec(&replica.ObjectMeta, &replica.Spec, info) || changed
 	}
@@ -107,6 +120,14 @@ func (j *KubeflowJob) PodSets() ([]kueue.PodSet, error) {
 			Template: *j.KFJobControl.ReplicaSpecs()[replicaType].Template.DeepCopy(),
 			Count:    podsCount(j.KFJobControl.ReplicaSpecs(), replicaType),
 		}
+		if minReplicas, maxReplicas, ok := j.elasticReplicas(replicaType); ok {
+			// The workload is sized for the maximum number of replicas, while
+			// it can be admitted with as few as the minimum number of replicas.
+			podSets[index].Count = max(ptr.Deref(maxReplicas, podSets[index].Count), minReplicas)
+			if minReplicas < podSets[index].Count {
+				podSets[index].MinCount = ptr.To(minReplicas)
+			}
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			podSets[index].TopologyRequest = jobframework.PodSetTopologyRequest(
 				&j.KFJobControl.ReplicaSpecs()[replicaType].Template.ObjectMeta,
@@ -199,6 +220,62 @@ func (j *KubeflowJob) ValidateOnUpdate(_ jobframework.GenericJob) field.ErrorLis
 	return j.ValidateOnCreate()
 }
 
+// elasticReplicas returns the minimum and the maximum number of replicas of
+// the replicaType, and true if the replicaType is elastic.
+func (j *KubeflowJob) elasticReplicas(replicaType kftraining.ReplicaType) (int32, *int32, bool) {
+	elasticJob, ok := j.KFJobControl.(KFJobWithElasticPolicy)
+	if !ok || elasticJob.ElasticReplicaType() != replicaType {
+		return 0, nil, false
+	}
+	minReplicas, maxReplicas := elasticJob.ElasticReplicas()
+	if minReplicas == nil {
+		return 0, nil, false
+	}
+	return *minReplicas, maxReplicas, true
+}
+
+// saveOriginalReplicas records the number of replicas of the elastic
+// ReplicaType, unless it's already recorded by a previous run.
+func (j *KubeflowJob) saveOriginalReplicas(replicas *int32) {
+	object := j.Object()
+	annotations := object.GetAnnotations()
+	if _, found := annotations[OriginalReplicasAnnotation]; found {
+		return
+	}
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	value := ""
+	if replicas != nil {
+		value = strconv.Itoa(int(*replicas))
+	}
+	annotations[OriginalReplicasAnnotation] = value
+	object.SetAnnotations(annotations)
+}
+
+// restoreOriginalReplicas sets back the number of replicas of the elastic
+// ReplicaType recorded when the job started, and returns true if the job changed.
+func (j *KubeflowJob) restoreOriginalReplicas(replicaType kftraining.ReplicaType) bool {
+	object := j.Object()
+	annotations := object.GetAnnotations()
+	value, found := annotations[OriginalReplicasAnnotation]
+	if !found {
+		return false
+	}
+	var replicas *int32
+	if value != "" {
+		count, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		replicas = ptr.To(int32(count))
+	}
+	j.KFJobControl.ReplicaSpecs()[replicaType].Replicas = replicas
+	delete(annotations, OriginalReplicasAnnotation)
+	object.SetAnnotations(annotations)
+	return true
+}
+
 func podsCount(replicaSpecs map[kftraining.ReplicaType]*kftraining.ReplicaSpec, replicaType kftraining.ReplicaType) int32 {
 	return ptr.Deref(replicaSpecs[replicaType].Replicas, 1)
 }
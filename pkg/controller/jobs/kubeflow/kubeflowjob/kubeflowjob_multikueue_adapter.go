@@ -115,6 +115,13 @@ func (a adapter[PtrT, T]) SyncJob(
 	}
 
 	if err == nil {
+		// The remote job could have been created for another workload with the same job key,
+		// don't mix its status with the one of the local job.
+		if remoteWl := remoteJob.GetLabels()[constants.PrebuiltWorkloadLabel]; remoteWl != workloadName {
+			return fmt.Errorf("%w: the remote %s %s belongs to the workload %q, expected %q",
+				jobframework.ErrPrebuiltWorkloadMismatch, a.gvk.Kind, klog.KObj(remoteJob), remoteWl, workloadName)
+		}
+
 		if a.fromObject(localJob).IsSuspended() {
 			// Ensure the job is unsuspended before updating its status; otherwise, it will fail when patching the spec.
 			log.V(2).Info("Skipping the sync since the local job is still suspended")
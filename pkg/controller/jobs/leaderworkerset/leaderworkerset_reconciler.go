@@ -59,10 +59,15 @@ type Reconciler struct {
 	labelKeysToCopy              []string
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	priorityResolver             jobframework.PriorityResolver
 }
 
 func NewReconciler(client client.Client, eventRecorder record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
 	options := jobframework.ProcessOptions(opts...)
+	priorityResolver := options.PriorityResolver
+	if priorityResolver == nil {
+		priorityResolver = jobframework.DefaultPriorityResolver
+	}
 
 	return &Reconciler{
 		client:                       client,
@@ -71,6 +76,7 @@ func NewReconciler(client client.Client, eventRecorder record.EventRecorder, opt
 		labelKeysToCopy:              options.LabelKeysToCopy,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		priorityResolver:             priorityResolver,
 	}
 }
 
@@ -163,7 +169,7 @@ func (r *Reconciler) createPrebuiltWorkload(ctx context.Context, lws *leaderwork
 		return err
 	}
 
-	priorityClassName, source, p, err := jobframework.ExtractPriority(ctx, r.client, lws, createdWorkload.Spec.PodSets, nil)
+	priorityClassName, source, p, err := r.priorityResolver.ResolvePriority(ctx, r.client, lws, createdWorkload.Spec.PodSets, nil)
 	if err != nil {
 		return err
 	}
@@ -23,11 +23,13 @@ import (
 	"github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
@@ -40,24 +42,32 @@ var (
 )
 
 type MpiJobWebhook struct {
-	client                       client.Client
-	manageJobsWithoutQueueName   bool
-	managedJobsNamespaceSelector labels.Selector
-	kubeServerVersion            *kubeversion.ServerVersionFetcher
-	queues                       *queue.Manager
-	cache                        *cache.Cache
+	client                         client.Client
+	manageJobsWithoutQueueName     bool
+	managedJobsNamespaceSelector   labels.Selector
+	kubeServerVersion              *kubeversion.ServerVersionFetcher
+	queues                         *queue.Manager
+	cache                          *cache.Cache
+	admittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	multiKueueDisabledIntegrations sets.Set[string]
+	missingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	defaultQueueName               string
 }
 
 // SetupMPIJobWebhook configures the webhook for MPIJob.
 func SetupMPIJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	options := jobframework.ProcessOptions(opts...)
 	wh := &MpiJobWebhook{
-		client:                       mgr.GetClient(),
-		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		kubeServerVersion:            options.KubeServerVersion,
-		queues:                       options.Queues,
-		cache:                        options.Cache,
+		client:                         mgr.GetClient(),
+		manageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+		kubeServerVersion:              options.KubeServerVersion,
+		queues:                         options.Queues,
+		cache:                          options.Cache,
+		admittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+		multiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+		missingQueueNamePolicy:         options.MissingQueueNamePolicy,
+		defaultQueueName:               options.DefaultQueueName,
 	}
 	obj := &v2beta1.MPIJob{}
 	return webhook.WebhookManagedBy(mgr).
@@ -78,11 +88,12 @@ func (w *MpiJobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(mpiJob.Object(), w.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultQueueName(mpiJob.Object(), w.missingQueueNamePolicy, w.defaultQueueName)
 	if err := jobframework.ApplyDefaultForSuspend(ctx, mpiJob, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
 
-	jobframework.ApplyDefaultForManagedBy(mpiJob, w.queues, w.cache, log)
+	jobframework.ApplyDefaultForManagedBy(mpiJob, w.queues, w.cache, w.multiKueueDisabledIntegrations, log)
 
 	return nil
 }
@@ -96,7 +107,9 @@ func (w *MpiJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	mpiJob := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
 	log.Info("Validating create")
-	return nil, w.validateCommon(mpiJob).ToAggregate()
+	allErrs := w.validateCommon(mpiJob)
+	allErrs = append(allErrs, jobframework.ValidateMissingQueueNameOnCreate(mpiJob.Object(), w.missingQueueNamePolicy)...)
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -105,7 +118,7 @@ func (w *MpiJobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 	newMpiJob := fromObject(newObj)
 	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
 	log.Info("Validating update")
-	allErrs := jobframework.ValidateJobOnUpdate(oldMpiJob, newMpiJob)
+	allErrs := jobframework.ValidateJobOnUpdate(oldMpiJob, newMpiJob, w.admittedJobUpdatePolicy)
 	allErrs = append(allErrs, w.validateCommon(newMpiJob)...)
 	return nil, allErrs.ToAggregate()
 }
@@ -26,6 +26,7 @@ const (
 	SuspendedByParentAnnotation       = "kueue.x-k8s.io/pod-suspending-parent"
 	GroupNameLabel                    = "kueue.x-k8s.io/pod-group-name"
 	GroupTotalCountAnnotation         = "kueue.x-k8s.io/pod-group-total-count"
+	GroupSuccessThresholdAnnotation   = "kueue.x-k8s.io/pod-group-success-threshold"
 	GroupFastAdmissionAnnotationKey   = "kueue.x-k8s.io/pod-group-fast-admission"
 	GroupFastAdmissionAnnotationValue = "true"
 	GroupServingAnnotationKey         = "kueue.x-k8s.io/pod-group-serving"
@@ -35,4 +36,15 @@ const (
 	RetriableInGroupAnnotationValue   = "false"
 	IsGroupWorkloadAnnotationKey      = "kueue.x-k8s.io/is-group-workload"
 	IsGroupWorkloadAnnotationValue    = "true"
+
+	// ArgoWorkflowLabel is set by Argo Workflows on the pods it creates,
+	// with the name of the Workflow as value.
+	ArgoWorkflowLabel = "workflows.argoproj.io/workflow"
+
+	// VolcanoGroupNameAnnotation is set by Volcano on the pods of a gang, with
+	// the name of the Volcano PodGroup as value.
+	VolcanoGroupNameAnnotation = "scheduling.k8s.io/group-name"
+	// VolcanoGroupMinMemberAnnotation indicates the minimal number of pods of
+	// a Volcano gang which need to be scheduled together.
+	VolcanoGroupMinMemberAnnotation = "scheduling.volcano.sh/group-min-member"
 )
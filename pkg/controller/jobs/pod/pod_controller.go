@@ -27,7 +27,9 @@ import (
 	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -47,6 +50,7 @@ import (
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podconstants "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -57,6 +61,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/parallelize"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
@@ -69,6 +74,7 @@ const (
 const (
 	ReasonExcessPodDeleted     = "ExcessPodDeleted"
 	ReasonOwnerReferencesAdded = "OwnerReferencesAdded"
+	ReasonQuotaHandedOver      = "QuotaHandedOver"
 )
 
 const (
@@ -111,10 +117,15 @@ func init() {
 
 type Reconciler struct {
 	*jobframework.JobReconciler
+	client            client.Client
+	record            record.EventRecorder
 	expectationsStore *expectations.Store
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.handOverQuotaToReplacementPod(ctx, req.NamespacedName); err != nil {
+		return ctrl.Result{}, err
+	}
 	return r.ReconcileGenericJob(ctx, req, NewPod(WithExcessPodExpectations(r.expectationsStore), WithClock(realClock)))
 }
 
@@ -138,6 +149,8 @@ func NewJob() jobframework.GenericJob {
 func NewReconciler(c client.Client, record record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
 	return &Reconciler{
 		JobReconciler:     jobframework.NewReconciler(c, record, opts...),
+		client:            c,
+		record:            record,
 		expectationsStore: expectations.NewStore("finalizedPods"),
 	}
 }
@@ -373,9 +386,16 @@ func (p *Pod) Finished() (message string, success, finished bool) {
 		}
 	}
 
+	successThreshold, err := p.groupSuccessThreshold(groupTotalCount)
+	if err != nil {
+		ctrl.Log.V(2).Error(err, "failed to check if pod group is finished")
+		message = "failed to check if pod group is finished"
+		return message, success, false
+	}
+
 	unretriableGroup := p.isUnretriableGroup()
 
-	if succeededCount == groupTotalCount || (!isActive && unretriableGroup) {
+	if succeededCount >= successThreshold || (!isActive && unretriableGroup) {
 		message = fmt.Sprintf("Pods succeeded: %d/%d.", succeededCount, groupTotalCount)
 	} else {
 		return message, success, false
@@ -531,6 +551,12 @@ func (p *Pod) Finalize(ctx context.Context, c client.Client) error {
 		}
 	}
 
+	if groupName != "" {
+		if err := p.deleteRemainingPodsOnSuccess(ctx, c, podsInGroup.Items); err != nil {
+			return err
+		}
+	}
+
 	return parallelize.Until(ctx, len(podsInGroup.Items), func(i int) error {
 		pod := &podsInGroup.Items[i]
 		return clientutil.Patch(ctx, c, pod, false, func() (bool, error) {
@@ -539,6 +565,41 @@ func (p *Pod) Finalize(ctx context.Context, c client.Client) error {
 	})
 }
 
+// deleteRemainingPodsOnSuccess deletes the pods of the group which are still active,
+// if the group succeeded because the number of succeeded pods reached the success threshold.
+func (p *Pod) deleteRemainingPodsOnSuccess(ctx context.Context, c client.Client, pods []corev1.Pod) error {
+	if _, ok := p.pod.GetAnnotations()[podconstants.GroupSuccessThresholdAnnotation]; !ok {
+		return nil
+	}
+	groupTotalCount, err := p.groupTotalCount()
+	if err != nil {
+		return err
+	}
+	successThreshold, err := p.groupSuccessThreshold(groupTotalCount)
+	if err != nil {
+		return err
+	}
+
+	var remainingPods []*corev1.Pod
+	succeededCount := 0
+	for i := range pods {
+		switch {
+		case pods[i].Status.Phase == corev1.PodSucceeded:
+			succeededCount++
+		case !utilpod.IsTerminated(&pods[i]) && pods[i].DeletionTimestamp.IsZero():
+			remainingPods = append(remainingPods, &pods[i])
+		}
+	}
+	if succeededCount < successThreshold {
+		return nil
+	}
+
+	return parallelize.Until(ctx, len(remainingPods), func(i int) error {
+		ctrl.LoggerFrom(ctx).V(3).Info("Deleting the remaining pod of the succeeded group", "pod", klog.KObj(remainingPods[i]))
+		return client.IgnoreNotFound(c.Delete(ctx, remainingPods[i]))
+	})
+}
+
 func (p *Pod) Skip() bool {
 	// Skip pod reconciliation, if pod is found, and it's managed label is not set or incorrect.
 	if v, ok := p.pod.GetLabels()[constants.ManagedByKueueLabelKey]; p.isFound && (!ok || v != constants.ManagedByKueueLabelValue) {
@@ -553,6 +614,42 @@ func podGroupName(p corev1.Pod) string {
 	return p.GetLabels()[podconstants.GroupNameLabel]
 }
 
+// argoWorkflowGroupName returns the name of the pod group for a pod created
+// by an Argo Workflow, or an empty string if the pod wasn't created by an Argo
+// Workflow.
+// The name includes the UID of the Workflow, so that the pods of a Workflow
+// recreated with the same name don't join the group of the previous one.
+func argoWorkflowGroupName(p corev1.Pod) string {
+	wfName := p.GetLabels()[podconstants.ArgoWorkflowLabel]
+	if wfName == "" {
+		return ""
+	}
+	var wfUID types.UID
+	for _, ref := range p.GetOwnerReferences() {
+		if ref.Kind == "Workflow" && strings.HasPrefix(ref.APIVersion, "argoproj.io/") {
+			wfUID = ref.UID
+			break
+		}
+	}
+	if wfUID == "" {
+		return ""
+	}
+	maxPrefixLen := validation.LabelValueMaxLength - len(wfUID) - 1
+	prefix := strings.TrimRight(wfName[:min(len(wfName), maxPrefixLen)], "-.")
+	return fmt.Sprintf("%s-%s", prefix, wfUID)
+}
+
+// volcanoGang returns the name of the Volcano PodGroup and the minimal number
+// of its members, if the pod has both the Volcano gang-scheduling annotations.
+func volcanoGang(p corev1.Pod) (string, string) {
+	groupName := p.GetAnnotations()[podconstants.VolcanoGroupNameAnnotation]
+	minMember := p.GetAnnotations()[podconstants.VolcanoGroupMinMemberAnnotation]
+	if groupName == "" || minMember == "" {
+		return "", ""
+	}
+	return groupName, minMember
+}
+
 // groupTotalCount returns the value of GroupTotalCountAnnotation for the pod being reconciled at the moment.
 // It doesn't check if the whole group has the same total group count annotation value.
 func (p *Pod) groupTotalCount() (int, error) {
@@ -579,6 +676,28 @@ func (p *Pod) groupTotalCount() (int, error) {
 	return gtc, nil
 }
 
+// groupSuccessThreshold returns the value of GroupSuccessThresholdAnnotation for the pod being reconciled
+// at the moment, which is the number of pods that need to succeed for the group to be finished.
+// It defaults to the group total count if the annotation is not set.
+func (p *Pod) groupSuccessThreshold(groupTotalCount int) (int, error) {
+	thresholdAnnotation, ok := p.Object().GetAnnotations()[podconstants.GroupSuccessThresholdAnnotation]
+	if !ok {
+		return groupTotalCount, nil
+	}
+
+	threshold, err := strconv.Atoi(thresholdAnnotation)
+	if err != nil {
+		return 0, err
+	}
+
+	if threshold < 1 || threshold > groupTotalCount {
+		return 0, fmt.Errorf("incorrect annotation value '%s=%s': success threshold should be greater than zero and not greater than the group total count",
+			podconstants.GroupSuccessThresholdAnnotation, thresholdAnnotation)
+	}
+
+	return threshold, nil
+}
+
 // getRoleHash will filter all the fields of the pod that are relevant to admission (pod role) and return a sha256
 // checksum of those fields. This is used to group the pods of the same roles when interacting with the workload.
 func getRoleHash(p corev1.Pod) (string, error) {
@@ -1352,6 +1471,108 @@ func NewGroupWorkload(name string, obj client.Object, podSets []kueue.PodSet, la
 	return wl
 }
 
+// handOverQuotaToReplacementPod hands over the quota reserved for a terminated
+// Pod of a Deployment to a gated Pod of the same Deployment with the same
+// requests, e.g. when a Pod of the old ReplicaSet is replaced during a rolling
+// update. The replacement Pod starts without being re-admitted, and the quota
+// can't be taken by other workloads in the meantime.
+func (r *Reconciler) handOverQuotaToReplacementPod(ctx context.Context, key types.NamespacedName) error {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, key, pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	deploymentName, isDeploymentPod := deploymentNameForPod(pod)
+	if !isDeploymentPod || podGroupName(*pod) != "" || !utilpod.IsTerminated(pod) {
+		return nil
+	}
+	wl, err := r.podWorkload(ctx, pod)
+	if err != nil || wl == nil {
+		return err
+	}
+	// The results of the admission checks belong to the workload they were
+	// performed for, so the workloads which went through checks aren't handed over.
+	if !workload.HasQuotaReservation(wl) || workload.IsFinished(wl) || len(wl.Status.AdmissionChecks) > 0 {
+		return nil
+	}
+
+	var pods corev1.PodList
+	if err := r.client.List(ctx, &pods, client.InNamespace(pod.Namespace), client.MatchingLabels{
+		controllerconstants.QueueLabel: pod.Labels[controllerconstants.QueueLabel],
+	}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		replacement := &pods.Items[i]
+		if name, ok := deploymentNameForPod(replacement); !ok || name != deploymentName ||
+			podGroupName(*replacement) != "" || !isGated(replacement) || !replacement.DeletionTimestamp.IsZero() {
+			continue
+		}
+		replacementWl, err := r.podWorkload(ctx, replacement)
+		if err != nil {
+			return err
+		}
+		if replacementWl == nil || workload.HasQuotaReservation(replacementWl) || workload.IsFinished(replacementWl) ||
+			!workload.IsActive(replacementWl) || !equality.Semantic.DeepEqual(workload.TotalRequests(wl), workload.TotalRequests(replacementWl)) {
+			continue
+		}
+		return r.handOverQuota(ctx, pod, wl, replacement, replacementWl)
+	}
+	return nil
+}
+
+func (r *Reconciler) handOverQuota(ctx context.Context, pod *corev1.Pod, wl *kueue.Workload, replacement *corev1.Pod, replacementWl *kueue.Workload) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	admission := wl.Status.Admission.DeepCopy()
+	for i := range admission.PodSetAssignments {
+		admission.PodSetAssignments[i].Name = replacementWl.Spec.PodSets[i].Name
+	}
+	workload.SetQuotaReservation(replacementWl, admission, realClock)
+	replacementWl.Status.AdmissionPath = wl.Status.AdmissionPath
+	_ = workload.SyncAdmittedCondition(replacementWl, realClock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, replacementWl, true, realClock); err != nil {
+		return err
+	}
+	log.V(2).Info("Handed over the quota to the replacement pod", "replacement", klog.KObj(replacement), "workload", klog.KObj(replacementWl))
+	r.record.Eventf(replacement, corev1.EventTypeNormal, ReasonQuotaHandedOver,
+		"Took over the quota of the terminated Pod %s", pod.Name)
+
+	// Finish the workload of the terminated Pod right away, so that its quota
+	// isn't handed over to another Pod.
+	reason := kueue.WorkloadFinishedReasonSucceeded
+	if pod.Status.Phase != corev1.PodSucceeded {
+		reason = kueue.WorkloadFinishedReasonFailed
+	}
+	return workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadFinished, metav1.ConditionTrue, reason,
+		fmt.Sprintf("The quota was handed over to the replacement Pod %s", replacement.Name), constants.JobControllerName, realClock)
+}
+
+// podWorkload returns the workload of a Pod which doesn't belong to a group,
+// or nil if the workload doesn't exist.
+func (r *Reconciler) podWorkload(ctx context.Context, pod *corev1.Pod) (*kueue.Workload, error) {
+	wl := &kueue.Workload{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: GetWorkloadNameForPod(pod.Name, pod.UID)}, wl)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return wl, err
+}
+
+// deploymentNameForPod returns the name of the Deployment owning the Pod. The
+// Pods of a Deployment are controlled by a ReplicaSet named after the Deployment
+// and the hash of the Pod template.
+func deploymentNameForPod(pod *corev1.Pod) (string, bool) {
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil || controllerRef.Kind != "ReplicaSet" || controllerRef.APIVersion != appsv1.SchemeGroupVersion.String() {
+		return "", false
+	}
+	podTemplateHash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(controllerRef.Name, "-"+podTemplateHash)
+}
+
 func isGated(pod *corev1.Pod) bool {
 	return utilpod.HasGate(pod, podconstants.SchedulingGateName)
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -569,6 +570,199 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"quota of a terminated pod of a deployment is handed over to the gated replacement pod": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					OwnerReference("deployment-old", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "old").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					UID("test-uid2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					KueueSchedulingGate().
+					OwnerReference("deployment-new", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "new").
+					Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					OwnerReference("deployment-old", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "old").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					UID("test-uid2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					KueueSchedulingGate().
+					OwnerReference("deployment-new", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "new").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod", "test-uid"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod2", "test-uid2"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid2").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod", "test-uid"), "ns").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadFinished,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadFinishedReasonSucceeded,
+						Message: "The quota was handed over to the replacement Pod pod2",
+					}).
+					Obj(),
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod2", "test-uid2"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid2").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).Obj()).
+					Conditions(
+						metav1.Condition{
+							Type:    kueue.WorkloadQuotaReserved,
+							Status:  metav1.ConditionTrue,
+							Reason:  "QuotaReserved",
+							Message: "Quota reserved in ClusterQueue cq",
+						},
+						metav1.Condition{
+							Type:    kueue.WorkloadAdmitted,
+							Status:  metav1.ConditionTrue,
+							Reason:  "Admitted",
+							Message: "The workload is admitted",
+						},
+					).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/" + GetWorkloadNameForPod("pod", "test-uid") + "' is declared finished",
+				},
+				{
+					Key:       types.NamespacedName{Name: "pod2", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    ReasonQuotaHandedOver,
+					Message:   "Took over the quota of the terminated Pod pod",
+				},
+			},
+		},
+		"quota of a terminated pod of a deployment isn't handed over to a replacement pod with different requests": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					OwnerReference("deployment-old", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "old").
+					StatusPhase(corev1.PodSucceeded).
+					StatusMessage("Job finished successfully").
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					UID("test-uid2").
+					Request(corev1.ResourceCPU, "2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					KueueSchedulingGate().
+					OwnerReference("deployment-new", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "new").
+					Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					OwnerReference("deployment-old", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "old").
+					StatusPhase(corev1.PodSucceeded).
+					StatusMessage("Job finished successfully").
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					UID("test-uid2").
+					Request(corev1.ResourceCPU, "2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					KueueSchedulingGate().
+					OwnerReference("deployment-new", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Label(appsv1.DefaultDeploymentUniqueLabelKey, "new").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod", "test-uid"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod2", "test-uid2"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "2").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid2").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod", "test-uid"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadFinished,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadFinishedReasonSucceeded,
+						Message: "Job finished successfully",
+					}).
+					Obj(),
+				*utiltesting.MakeWorkload(GetWorkloadNameForPod("pod2", "test-uid2"), "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "2").Obj()).
+					Queue("user-queue").
+					ControllerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid2").
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/" + GetWorkloadNameForPod("pod", "test-uid") + "' is declared finished",
+				},
+			},
+		},
 		"pod without scheduling gate is terminated if workload is not admitted": {
 			pods: []corev1.Pod{*basePodWrapper.
 				Clone().
@@ -1246,6 +1440,276 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"workload is finished and the remaining pods are deleted once the success threshold of the group is reached": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod3").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod4").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod5").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod3").
+					ManagedByKueueLabel().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 5).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Queue("user-queue").
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(5).Obj()).
+					Admitted(true).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod3", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod4", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod5", "test-uid").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 5).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Queue("user-queue").
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(5).Obj()).
+					Admitted(true).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod3", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod4", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod5", "test-uid").
+					Condition(metav1.Condition{
+						Type:    "Finished",
+						Status:  "True",
+						Reason:  kueue.WorkloadFinishedReasonSucceeded,
+						Message: "Pods succeeded: 3/5.",
+					}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/test-group' is declared finished",
+				},
+			},
+		},
+		"workload is not finished if the success threshold of the group is not reached": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod3").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod4").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod5").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodSucceeded).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod3").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod4").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod5").
+					ManagedByKueueLabel().
+					KueueFinalizer().
+					Group("test-group").
+					GroupTotalCount("5").
+					GroupSuccessThreshold("3").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 5).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Queue("user-queue").
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(5).Obj()).
+					Admitted(true).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod3", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod4", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod5", "test-uid").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 5).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Queue("user-queue").
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(5).Obj()).
+					Admitted(true).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod3", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod4", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod5", "test-uid").
+					ReclaimablePods(kueue.ReclaimablePod{Name: kueue.NewPodSetReference(podUID), Count: 2}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+		},
 		"workload is not deleted if the pod in group has been deleted after admission": {
 			pods: []corev1.Pod{*basePodWrapper.
 				Clone().
@@ -4188,6 +4652,106 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"workload is created for the pods of an argo workflow": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+					KueueFinalizer().
+					KueueSchedulingGate().
+					Group("test-workflow").
+					GroupTotalCount("2").
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+					KueueFinalizer().
+					KueueSchedulingGate().
+					Group("test-workflow").
+					GroupTotalCount("2").
+					Obj(),
+			},
+			wantPods: nil,
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-workflow", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 2).
+							Request(corev1.ResourceCPU, "1").
+							SchedulingGates(corev1.PodSchedulingGate{Name: podconstants.SchedulingGateName}).
+							Obj(),
+					).
+					Queue("user-queue").
+					Priority(0).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					Annotations(map[string]string{
+						podconstants.IsGroupWorkloadAnnotationKey: podconstants.IsGroupWorkloadAnnotationValue}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/test-workflow",
+				},
+			},
+		},
+		"workload is created for the pods of a volcano gang": {
+			pods: []corev1.Pod{
+				*basePodWrapper.
+					Clone().
+					ManagedByKueueLabel().
+					Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+					Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "2").
+					KueueFinalizer().
+					KueueSchedulingGate().
+					Group("test-podgroup").
+					GroupTotalCount("2").
+					Obj(),
+				*basePodWrapper.
+					Clone().
+					Name("pod2").
+					ManagedByKueueLabel().
+					Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+					Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "2").
+					KueueFinalizer().
+					KueueSchedulingGate().
+					Group("test-podgroup").
+					GroupTotalCount("2").
+					Obj(),
+			},
+			wantPods: nil,
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("test-podgroup", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.NewPodSetReference(podUID), 2).
+							Request(corev1.ResourceCPU, "1").
+							SchedulingGates(corev1.PodSchedulingGate{Name: podconstants.SchedulingGateName}).
+							Obj(),
+					).
+					Queue("user-queue").
+					Priority(0).
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod", "test-uid").
+					OwnerReference(corev1.SchemeGroupVersion.WithKind("Pod"), "pod2", "test-uid").
+					Annotations(map[string]string{
+						podconstants.IsGroupWorkloadAnnotationKey: podconstants.IsGroupWorkloadAnnotationValue}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/test-podgroup",
+				},
+			},
+		},
 		"reconciler returns error in case pod group pod index is bigger or equal pod group total count": {
 			pods: []corev1.Pod{*basePodWrapper.
 				Clone().
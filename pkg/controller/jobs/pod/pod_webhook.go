@@ -52,6 +52,7 @@ var (
 	groupNameLabelPath             = labelsPath.Key(podconstants.GroupNameLabel)
 	prebuiltWorkloadLabelPath      = labelsPath.Key(ctrlconstants.PrebuiltWorkloadLabel)
 	groupTotalCountAnnotationPath  = annotationsPath.Key(podconstants.GroupTotalCountAnnotation)
+	groupSuccessThresholdPath      = annotationsPath.Key(podconstants.GroupSuccessThresholdAnnotation)
 	retriableInGroupAnnotationPath = annotationsPath.Key(podconstants.RetriableInGroupAnnotationKey)
 
 	errPodOptsTypeAssertion = errors.New("options are not of type PodIntegrationOptions")
@@ -64,6 +65,7 @@ type PodWebhook struct {
 	managedJobsNamespaceSelector labels.Selector
 	namespaceSelector            *metav1.LabelSelector
 	podSelector                  *metav1.LabelSelector
+	admittedJobUpdatePolicy      configapi.AdmittedJobUpdatePolicy
 }
 
 // SetupWebhook configures the webhook for pods.
@@ -78,6 +80,7 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		queues:                       options.Queues,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		admittedJobUpdatePolicy:      options.AdmittedJobUpdatePolicy,
 	}
 	if podOpts != nil {
 		wh.namespaceSelector = podOpts.NamespaceSelector
@@ -201,6 +204,24 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 			utilpod.Gate(&pod.pod, kueuealpha.TopologySchedulingGate)
 		}
 
+		// The pods of an Argo Workflow which set the group total count are grouped
+		// by the workflow, so that the resources of the whole workflow are
+		// accounted in one workload.
+		if groupName := argoWorkflowGroupName(pod.pod); groupName != "" && podGroupName(pod.pod) == "" {
+			if _, found := pod.pod.Annotations[podconstants.GroupTotalCountAnnotation]; found {
+				pod.pod.Labels[podconstants.GroupNameLabel] = groupName
+			}
+		}
+
+		// The pods with the Volcano gang-scheduling annotations are grouped by
+		// the Volcano PodGroup, to ease the migration from Volcano.
+		if groupName, totalCount := volcanoGang(pod.pod); groupName != "" && podGroupName(pod.pod) == "" {
+			pod.pod.Labels[podconstants.GroupNameLabel] = groupName
+			if _, found := pod.pod.Annotations[podconstants.GroupTotalCountAnnotation]; !found {
+				pod.pod.Annotations[podconstants.GroupTotalCountAnnotation] = totalCount
+			}
+		}
+
 		if podGroupName(pod.pod) != "" {
 			if err := pod.addRoleHash(); err != nil {
 				return err
@@ -242,7 +263,7 @@ func (w *PodWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.
 	log := ctrl.LoggerFrom(ctx).WithName("pod-webhook")
 	log.V(5).Info("Validating update")
 
-	allErrs := jobframework.ValidateJobOnUpdate(oldPod, newPod)
+	allErrs := jobframework.ValidateJobOnUpdate(oldPod, newPod, w.admittedJobUpdatePolicy)
 	allErrs = append(allErrs, validateCommon(newPod)...)
 	allErrs = append(allErrs, validateUpdateForRetriableInGroupAnnotation(oldPod, newPod)...)
 
@@ -315,7 +336,8 @@ func validatePodGroupMetadata(p *Pod) field.ErrorList {
 		}
 	}
 
-	if _, err := p.groupTotalCount(); gtcExists && err != nil {
+	gtcValue, err := p.groupTotalCount()
+	if gtcExists && err != nil {
 		return append(allErrs, field.Invalid(
 			groupTotalCountAnnotationPath,
 			gtc,
@@ -323,6 +345,18 @@ func validatePodGroupMetadata(p *Pod) field.ErrorList {
 		))
 	}
 
+	if threshold, ok := p.pod.GetAnnotations()[podconstants.GroupSuccessThresholdAnnotation]; ok {
+		if podGroupName(p.pod) == "" {
+			return append(allErrs, field.Forbidden(
+				groupSuccessThresholdPath,
+				fmt.Sprintf("the '%s' annotation can only be set for pods with the '%s' label", podconstants.GroupSuccessThresholdAnnotation, podconstants.GroupNameLabel),
+			))
+		}
+		if _, err := p.groupSuccessThreshold(gtcValue); err != nil {
+			allErrs = append(allErrs, field.Invalid(groupSuccessThresholdPath, threshold, err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
@@ -28,6 +28,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -57,7 +58,19 @@ import (
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/raycluster"
 )
 
+var argoWorkflowGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"}
+
+func makeArgoWorkflow(name, namespace string) *unstructured.Unstructured {
+	wf := &unstructured.Unstructured{}
+	wf.SetGroupVersionKind(argoWorkflowGVK)
+	wf.SetName(name)
+	wf.SetNamespace(namespace)
+	wf.SetUID(types.UID(name))
+	return wf
+}
+
 func TestDefault(t *testing.T) {
+	argoWorkflow := makeArgoWorkflow("test-workflow", "test-ns")
 	defaultNamespace := utiltesting.MakeNamespaceWrapper("test-ns").Label(corev1.LabelMetadataName, "test-ns").Obj()
 	defaultNamespaceSelector := &metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
@@ -331,6 +344,178 @@ func TestDefault(t *testing.T) {
 				KueueFinalizer().
 				Obj(),
 		},
+		"pod of an argo workflow is grouped by the workflow": {
+			initObjects:       []client.Object{defaultNamespace, argoWorkflow},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				OwnerReference("test-workflow", argoWorkflowGVK).
+				GroupTotalCount("2").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				OwnerReference("test-workflow", argoWorkflowGVK).
+				Group("test-workflow-test-workflow").
+				GroupTotalCount("2").
+				RoleHash("a9f06f3a").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod of a later step of an argo workflow joins the group of the workflow": {
+			initObjects:       []client.Object{defaultNamespace, argoWorkflow},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Annotation("workflows.argoproj.io/node-name", "test-workflow[1].evaluate").
+				OwnerReference("test-workflow", argoWorkflowGVK).
+				GroupTotalCount("2").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Annotation("workflows.argoproj.io/node-name", "test-workflow[1].evaluate").
+				OwnerReference("test-workflow", argoWorkflowGVK).
+				Group("test-workflow-test-workflow").
+				GroupTotalCount("2").
+				RoleHash("a9f06f3a").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod of an argo workflow without the workflow owner isn't grouped": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				GroupTotalCount("2").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				GroupTotalCount("2").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod of an argo workflow keeps its group name label": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Group("test-group").
+				GroupTotalCount("2").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Group("test-group").
+				GroupTotalCount("2").
+				RoleHash("a9f06f3a").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod of an argo workflow without group total count isn't grouped": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod of an argo workflow without a queue isn't grouped": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Label(podconstants.ArgoWorkflowLabel, "test-workflow").
+				Obj(),
+		},
+		"pod with volcano gang annotations is grouped by the volcano pod group": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "3").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "3").
+				Group("test-podgroup").
+				GroupTotalCount("3").
+				RoleHash("a9f06f3a").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod with volcano gang annotations keeps its group name label and total count": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "3").
+				Group("test-group").
+				GroupTotalCount("2").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				Annotation(podconstants.VolcanoGroupMinMemberAnnotation, "3").
+				Group("test-group").
+				GroupTotalCount("2").
+				RoleHash("a9f06f3a").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod with volcano group name annotation without min member isn't grouped": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Annotation(podconstants.VolcanoGroupNameAnnotation, "test-podgroup").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
 		"pod with a group name label": {
 			initObjects:       []client.Object{defaultNamespace},
 			podSelector:       &metav1.LabelSelector{},
@@ -709,6 +894,32 @@ func TestValidateCreate(t *testing.T) {
 				},
 			}.ToAggregate(),
 		},
+		"pod with group success threshold greater than the group total count": {
+			pod: testingpod.MakePod("test-pod", "test-ns").
+				ManagedByKueueLabel().
+				Group("test-group").
+				GroupTotalCount("3").
+				GroupSuccessThreshold("4").
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/pod-group-success-threshold]",
+				},
+			}.ToAggregate(),
+		},
+		"pod with group success threshold and no group name": {
+			pod: testingpod.MakePod("test-pod", "test-ns").
+				ManagedByKueueLabel().
+				GroupSuccessThreshold("2").
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeForbidden,
+					Field: "metadata.annotations[kueue.x-k8s.io/pod-group-success-threshold]",
+				},
+			}.ToAggregate(),
+		},
 		"pod with incorrect group name": {
 			pod: testingpod.MakePod("test-pod", "test-ns").
 				ManagedByKueueLabel().
@@ -19,9 +19,11 @@ package raycluster
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	rayutils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,17 +44,26 @@ var (
 const (
 	headGroupPodSetName = "head"
 	FrameworkName       = "ray.io/raycluster"
+
+	// AutoscalingSchedulingGate is added to the worker pods of the autoscaling
+	// RayClusters, and removed once the quota for the pods is reserved.
+	AutoscalingSchedulingGate = "kueue.x-k8s.io/raycluster-autoscaling"
+
+	// ScaleUpLabel is set to the name of the RayCluster on the workloads
+	// reserving the quota for the workers added by its autoscaler.
+	ScaleUpLabel = "kueue.x-k8s.io/raycluster-scale-up"
 )
 
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
-		SetupIndexes:      SetupIndexes,
-		NewJob:            NewJob,
-		NewReconciler:     NewReconciler,
-		SetupWebhook:      SetupRayClusterWebhook,
-		JobType:           &rayv1.RayCluster{},
-		AddToScheme:       rayv1.AddToScheme,
-		MultiKueueAdapter: &multiKueueAdapter{},
+		SetupIndexes:             SetupIndexes,
+		NewJob:                   NewJob,
+		NewReconciler:            NewReconciler,
+		SetupWebhook:             SetupRayClusterWebhook,
+		JobType:                  &rayv1.RayCluster{},
+		AddToScheme:              rayv1.AddToScheme,
+		MultiKueueAdapter:        &multiKueueAdapter{},
+		NewAdditionalReconcilers: []jobframework.ReconcilerFactory{NewScaleUpReconciler},
 	}))
 }
 
@@ -122,17 +133,10 @@ func (j *RayCluster) PodSets() ([]kueue.PodSet, error) {
 	// workers
 	for index := range j.Spec.WorkerGroupSpecs {
 		wgs := &j.Spec.WorkerGroupSpecs[index]
-		count := int32(1)
-		if wgs.Replicas != nil {
-			count = *wgs.Replicas
-		}
-		if wgs.NumOfHosts > 1 {
-			count *= wgs.NumOfHosts
-		}
 		podSets[index+1] = kueue.PodSet{
 			Name:     kueue.NewPodSetReference(wgs.GroupName),
 			Template: *wgs.Template.DeepCopy(),
-			Count:    count,
+			Count:    j.workerCount(wgs),
 		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			podSets[index+1].TopologyRequest = jobframework.PodSetTopologyRequest(
@@ -144,6 +148,26 @@ func (j *RayCluster) PodSets() ([]kueue.PodSet, error) {
 	return podSets, nil
 }
 
+// isAutoscaling returns true if the workers of the RayCluster are added and
+// removed by the autoscaler.
+func (j *RayCluster) isAutoscaling() bool {
+	return features.Enabled(features.RayClusterAutoscaling) && ptr.Deref(j.Spec.EnableInTreeAutoscaling, false)
+}
+
+// workerCount returns the number of the pods of the worker group in the
+// workload of the RayCluster. When the RayCluster is autoscaling, the workload
+// only reserves the quota for the minimum number of replicas.
+func (j *RayCluster) workerCount(wgs *rayv1.WorkerGroupSpec) int32 {
+	count := ptr.Deref(wgs.Replicas, 1)
+	if j.isAutoscaling() {
+		count = ptr.Deref(wgs.MinReplicas, 0)
+	}
+	if wgs.NumOfHosts > 1 {
+		count *= wgs.NumOfHosts
+	}
+	return count
+}
+
 func (j *RayCluster) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	expectedLen := len(j.Spec.WorkerGroupSpecs) + 1
 	if len(podSetsInfo) != expectedLen {
@@ -164,6 +188,10 @@ func (j *RayCluster) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 		workerPod := &j.Spec.WorkerGroupSpecs[index].Template
 
 		info := podSetsInfo[index+1]
+		if j.isAutoscaling() {
+			// The workers added by the autoscaler wait for their quota.
+			info.SchedulingGates = append(slices.Clone(info.SchedulingGates), corev1.PodSchedulingGate{Name: AutoscalingSchedulingGate})
+		}
 		if err := podset.Merge(&workerPod.ObjectMeta, &workerPod.Spec, info); err != nil {
 			return err
 		}
@@ -0,0 +1,343 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayutils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// ScaleUpReconciler reserves the quota for the workers added by the autoscaler
+// of a RayCluster. The workload of the RayCluster reserves the quota for the
+// minimum number of replicas of the worker groups, and the workers above the
+// minimum get their quota reserved by additional scale-up workloads. The
+// workers are created with a scheduling gate, which is removed once their
+// quota is reserved.
+type ScaleUpReconciler struct {
+	client client.Client
+	record record.EventRecorder
+}
+
+func NewScaleUpReconciler(client client.Client, record record.EventRecorder, _ ...jobframework.Option) jobframework.JobReconcilerInterface {
+	return &ScaleUpReconciler{client: client, record: record}
+}
+
+var _ jobframework.JobReconcilerInterface = (*ScaleUpReconciler)(nil)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update;patch;delete
+
+func (r *ScaleUpReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if !features.Enabled(features.RayClusterAutoscaling) {
+		return nil
+	}
+	ctrl.Log.V(3).Info("Setting up the scale-up reconciler for RayCluster")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rayv1.RayCluster{}).
+		Named("raycluster_scale_up").
+		Watches(&kueue.Workload{}, handler.EnqueueRequestsFromMapFunc(workloadToCluster)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podToCluster)).
+		Complete(r)
+}
+
+func workloadToCluster(_ context.Context, obj client.Object) []reconcile.Request {
+	if name, found := obj.GetLabels()[ScaleUpLabel]; found {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+	}
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == gvk.Kind && owner.APIVersion == gvk.GroupVersion().String() {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: owner.Name}}}
+	}
+	return nil
+}
+
+func podToCluster(_ context.Context, obj client.Object) []reconcile.Request {
+	if name, found := obj.GetLabels()[rayutils.RayClusterLabelKey]; found {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+	}
+	return nil
+}
+
+func (r *ScaleUpReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	cluster := &rayv1.RayCluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		// we'll ignore not-found errors, since there is nothing to do.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	job := (*RayCluster)(cluster)
+	if !job.isAutoscaling() {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile RayCluster scale-up")
+
+	wl, err := r.clusterWorkload(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	scaleUps, err := r.scaleUpWorkloads(ctx, cluster, wl)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if job.IsSuspended() || wl == nil || !workload.IsAdmitted(wl) {
+		return ctrl.Result{}, r.deleteWorkloads(ctx, cluster, scaleUps)
+	}
+
+	// The scale-up workloads are not requeued after eviction. Instead, their
+	// workers lose their quota, and a new scale-up workload is created for them.
+	var evicted []*kueue.Workload
+	scaleUps = slices.DeleteFunc(scaleUps, func(scaleUp *kueue.Workload) bool {
+		if workload.IsEvicted(scaleUp) {
+			evicted = append(evicted, scaleUp)
+			return true
+		}
+		return false
+	})
+	if err := r.deleteWorkloads(ctx, cluster, evicted); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	reserved := make(map[kueue.PodSetReference]int32, len(wl.Spec.PodSets))
+	for _, ps := range wl.Spec.PodSets {
+		reserved[ps.Name] = ps.Count
+	}
+	requested := maps.Clone(reserved)
+	for _, scaleUp := range scaleUps {
+		for _, ps := range scaleUp.Spec.PodSets {
+			requested[ps.Name] += ps.Count
+			if workload.IsAdmitted(scaleUp) {
+				reserved[ps.Name] += ps.Count
+			}
+		}
+	}
+	desired := make(map[kueue.PodSetReference]int32, len(cluster.Spec.WorkerGroupSpecs))
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		wgs := &cluster.Spec.WorkerGroupSpecs[i]
+		desired[kueue.NewPodSetReference(wgs.GroupName)] = ptr.Deref(wgs.Replicas, 1) * max(wgs.NumOfHosts, 1)
+	}
+
+	// Release the quota which is no longer needed after a scale-down, starting
+	// from the pending and the most recent scale-up workloads.
+	var unneeded []*kueue.Workload
+	for i := len(scaleUps) - 1; i >= 0; i-- {
+		scaleUp := scaleUps[i]
+		if !slices.ContainsFunc(scaleUp.Spec.PodSets, func(ps kueue.PodSet) bool {
+			return requested[ps.Name]-ps.Count < desired[ps.Name]
+		}) {
+			unneeded = append(unneeded, scaleUp)
+			for _, ps := range scaleUp.Spec.PodSets {
+				requested[ps.Name] -= ps.Count
+				if workload.IsAdmitted(scaleUp) {
+					reserved[ps.Name] -= ps.Count
+				}
+			}
+		}
+	}
+	if err := r.deleteWorkloads(ctx, cluster, unneeded); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Request the quota for the workers added by the autoscaler.
+	deltas := make(map[kueue.PodSetReference]int32)
+	for name, count := range desired {
+		if count > requested[name] {
+			deltas[name] = count - requested[name]
+		}
+	}
+	if len(deltas) > 0 {
+		if err := r.createScaleUpWorkload(ctx, cluster, wl, deltas); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		groupName := cluster.Spec.WorkerGroupSpecs[i].GroupName
+		if err := r.syncWorkers(ctx, cluster, groupName, reserved[kueue.NewPodSetReference(groupName)]); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// clusterWorkload returns the workload controlled by the RayCluster, or nil if
+// it doesn't exist.
+func (r *ScaleUpReconciler) clusterWorkload(ctx context.Context, cluster *rayv1.RayCluster) (*kueue.Workload, error) {
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(cluster.Namespace),
+		client.MatchingFields{jobframework.GetOwnerKey(gvk): cluster.Name}); err != nil {
+		return nil, err
+	}
+	for i := range workloads.Items {
+		if metav1.IsControlledBy(&workloads.Items[i], cluster) {
+			return &workloads.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// scaleUpWorkloads returns the scale-up workloads of the RayCluster, sorted
+// so that the admitted workloads are before the pending ones, and in the
+// order of creation otherwise. The scale-up workloads of a previous workload
+// of the RayCluster are deleted.
+func (r *ScaleUpReconciler) scaleUpWorkloads(ctx context.Context, cluster *rayv1.RayCluster, wl *kueue.Workload) ([]*kueue.Workload, error) {
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{ScaleUpLabel: cluster.Name}); err != nil {
+		return nil, err
+	}
+	var scaleUps, stale []*kueue.Workload
+	for i := range workloads.Items {
+		scaleUp := &workloads.Items[i]
+		if wl != nil && metav1.IsControlledBy(scaleUp, wl) {
+			scaleUps = append(scaleUps, scaleUp)
+		} else {
+			stale = append(stale, scaleUp)
+		}
+	}
+	if err := r.deleteWorkloads(ctx, cluster, stale); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(scaleUps, func(a, b *kueue.Workload) int {
+		if aAdmitted, bAdmitted := workload.IsAdmitted(a), workload.IsAdmitted(b); aAdmitted != bAdmitted {
+			if aAdmitted {
+				return -1
+			}
+			return 1
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return scaleUps, nil
+}
+
+func (r *ScaleUpReconciler) createScaleUpWorkload(ctx context.Context, cluster *rayv1.RayCluster, wl *kueue.Workload, deltas map[kueue.PodSetReference]int32) error {
+	scaleUp := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-scale-up-%d", wl.Name, cluster.Generation),
+			Namespace:       wl.Namespace,
+			Labels:          map[string]string{ScaleUpLabel: cluster.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(wl, kueue.GroupVersion.WithKind("Workload"))},
+		},
+		Spec: kueue.WorkloadSpec{
+			QueueName:           wl.Spec.QueueName,
+			PriorityClassName:   wl.Spec.PriorityClassName,
+			Priority:            wl.Spec.Priority,
+			PriorityClassSource: wl.Spec.PriorityClassSource,
+		},
+	}
+	for i := range wl.Spec.PodSets {
+		if delta := deltas[wl.Spec.PodSets[i].Name]; delta > 0 {
+			ps := *wl.Spec.PodSets[i].DeepCopy()
+			ps.Count = delta
+			ps.MinCount = nil
+			scaleUp.Spec.PodSets = append(scaleUp.Spec.PodSets, ps)
+		}
+	}
+	if err := r.client.Create(ctx, scaleUp); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("Created the scale-up workload", "workload", klog.KObj(scaleUp))
+	r.record.Eventf(cluster, corev1.EventTypeNormal, jobframework.ReasonCreatedWorkload,
+		"Created Workload: %v", workload.Key(scaleUp))
+	return nil
+}
+
+func (r *ScaleUpReconciler) deleteWorkloads(ctx context.Context, cluster *rayv1.RayCluster, workloads []*kueue.Workload) error {
+	for _, wl := range workloads {
+		if err := r.client.Delete(ctx, wl); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		ctrl.LoggerFrom(ctx).V(2).Info("Deleted the scale-up workload", "workload", klog.KObj(wl))
+		r.record.Eventf(cluster, corev1.EventTypeNormal, jobframework.ReasonDeletedWorkload,
+			"Deleted Workload: %v", workload.Key(wl))
+	}
+	return nil
+}
+
+// syncWorkers removes the scheduling gate from the workers of the group, up to
+// the number of the workers with the quota reserved. The workers exceeding the
+// reserved quota, after an eviction of a scale-up workload, are deleted.
+func (r *ScaleUpReconciler) syncWorkers(ctx context.Context, cluster *rayv1.RayCluster, groupName string, reserved int32) error {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		rayutils.RayClusterLabelKey:   cluster.Name,
+		rayutils.RayNodeGroupLabelKey: groupName,
+	}); err != nil {
+		return err
+	}
+	var gated, ungated []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if utilpod.IsTerminated(pod) || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if utilpod.HasGate(pod, AutoscalingSchedulingGate) {
+			gated = append(gated, pod)
+		} else {
+			ungated = append(ungated, pod)
+		}
+	}
+	byCreation := func(a, b *corev1.Pod) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	}
+	slices.SortFunc(gated, byCreation)
+	slices.SortFunc(ungated, byCreation)
+
+	log := ctrl.LoggerFrom(ctx)
+	for _, pod := range gated[:min(len(gated), max(int(reserved)-len(ungated), 0))] {
+		if err := clientutil.Patch(ctx, r.client, pod, true, func() (bool, error) {
+			return utilpod.Ungate(pod, AutoscalingSchedulingGate), nil
+		}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Ungated the worker", "pod", klog.KObj(pod))
+	}
+	for _, pod := range ungated[min(int(reserved), len(ungated)):] {
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Deleted the worker exceeding the quota", "pod", klog.KObj(pod))
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayutils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+	testingrayutil "sigs.k8s.io/kueue/pkg/util/testingjobs/raycluster"
+)
+
+func TestScaleUpReconciler(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	baseCluster := testingrayutil.MakeCluster("cluster", "ns").
+		Queue("queue").
+		Suspend(false).
+		WithEnableAutoscaling(ptr.To(true)).
+		WithMinReplicas("workers-group-0", 1).
+		WithReplicas("workers-group-0", 3)
+	baseWorkload := utiltesting.MakeWorkload("cluster-wl", "ns").
+		UID("wl-uid").
+		ControllerReference(gvk, "cluster", "cluster-uid").
+		Queue("queue").
+		PodSets(
+			*utiltesting.MakePodSet(headGroupPodSetName, 1).Obj(),
+			*utiltesting.MakePodSet("workers-group-0", 1).Obj(),
+		)
+	admittedWorkload := baseWorkload.Clone().
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Admitted(true)
+	baseScaleUp := utiltesting.MakeWorkload("cluster-wl-scale-up-0", "ns").
+		Label(ScaleUpLabel, "cluster").
+		ControllerReference(kueue.GroupVersion.WithKind("Workload"), "cluster-wl", "wl-uid").
+		Queue("queue").
+		PodSets(*utiltesting.MakePodSet("workers-group-0", 2).Obj())
+	baseWorker := testingpod.MakePod("", "ns").
+		Label(rayutils.RayClusterLabelKey, "cluster").
+		Label(rayutils.RayNodeGroupLabelKey, "workers-group-0")
+
+	cases := map[string]struct {
+		cluster     *rayv1.RayCluster
+		workloads   []kueue.Workload
+		pods        []corev1.Pod
+		wantScaleUp map[string][]kueue.PodSet
+		wantGated   map[string]bool
+	}{
+		"scale-up waits for the quota": {
+			cluster:   baseCluster.Clone().Obj(),
+			workloads: []kueue.Workload{*admittedWorkload.Clone().Obj()},
+			pods: []corev1.Pod{
+				*baseWorker.Clone().Name("worker-1").CreationTimestamp(now).Obj(),
+				*baseWorker.Clone().Name("worker-2").CreationTimestamp(now.Add(time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+				*baseWorker.Clone().Name("worker-3").CreationTimestamp(now.Add(2 * time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+			},
+			wantScaleUp: map[string][]kueue.PodSet{
+				"cluster-wl-scale-up-1": {*utiltesting.MakePodSet("workers-group-0", 2).Obj()},
+			},
+			wantGated: map[string]bool{
+				"worker-1": false,
+				"worker-2": true,
+				"worker-3": true,
+			},
+		},
+		"workers are ungated once the scale-up is admitted": {
+			cluster: baseCluster.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseScaleUp.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*baseWorker.Clone().Name("worker-1").CreationTimestamp(now).Obj(),
+				*baseWorker.Clone().Name("worker-2").CreationTimestamp(now.Add(time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+				*baseWorker.Clone().Name("worker-3").CreationTimestamp(now.Add(2 * time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+			},
+			wantScaleUp: map[string][]kueue.PodSet{
+				"cluster-wl-scale-up-0": {*utiltesting.MakePodSet("workers-group-0", 2).Obj()},
+			},
+			wantGated: map[string]bool{
+				"worker-1": false,
+				"worker-2": false,
+				"worker-3": false,
+			},
+		},
+		"only the workers within the quota are ungated": {
+			cluster: baseCluster.Clone().WithReplicas("workers-group-0", 4).Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseScaleUp.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*baseWorker.Clone().Name("worker-1").CreationTimestamp(now).Obj(),
+				*baseWorker.Clone().Name("worker-2").CreationTimestamp(now.Add(time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+				*baseWorker.Clone().Name("worker-3").CreationTimestamp(now.Add(2 * time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+				*baseWorker.Clone().Name("worker-4").CreationTimestamp(now.Add(3 * time.Second)).Gate(AutoscalingSchedulingGate).Obj(),
+			},
+			wantScaleUp: map[string][]kueue.PodSet{
+				"cluster-wl-scale-up-0": {*utiltesting.MakePodSet("workers-group-0", 2).Obj()},
+				"cluster-wl-scale-up-1": {*utiltesting.MakePodSet("workers-group-0", 1).Obj()},
+			},
+			wantGated: map[string]bool{
+				"worker-1": false,
+				"worker-2": false,
+				"worker-3": false,
+				"worker-4": true,
+			},
+		},
+		"scale-down releases the quota": {
+			cluster: baseCluster.Clone().WithReplicas("workers-group-0", 1).Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseScaleUp.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*baseWorker.Clone().Name("worker-1").CreationTimestamp(now).Obj(),
+			},
+			wantGated: map[string]bool{
+				"worker-1": false,
+			},
+		},
+		"evicted scale-up is replaced and its workers are deleted": {
+			cluster: baseCluster.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*admittedWorkload.Clone().Obj(),
+				*baseScaleUp.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			},
+			pods: []corev1.Pod{
+				*baseWorker.Clone().Name("worker-1").CreationTimestamp(now).Obj(),
+				*baseWorker.Clone().Name("worker-2").CreationTimestamp(now.Add(time.Second)).Obj(),
+				*baseWorker.Clone().Name("worker-3").CreationTimestamp(now.Add(2 * time.Second)).Obj(),
+			},
+			wantScaleUp: map[string][]kueue.PodSet{
+				"cluster-wl-scale-up-1": {*utiltesting.MakePodSet("workers-group-0", 2).Obj()},
+			},
+			wantGated: map[string]bool{
+				"worker-1": false,
+			},
+		},
+		"suspended cluster releases the quota": {
+			cluster: baseCluster.Clone().Suspend(true).Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkload.Clone().Obj(),
+				*baseScaleUp.Clone().Obj(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.RayClusterAutoscaling, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clientBuilder := utiltesting.NewClientBuilder(rayv1.AddToScheme)
+			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			tc.cluster.UID = "cluster-uid"
+			tc.cluster.Generation = 1
+			objs := []client.Object{tc.cluster}
+			for i := range tc.workloads {
+				objs = append(objs, &tc.workloads[i])
+			}
+			for i := range tc.pods {
+				objs = append(objs, &tc.pods[i])
+			}
+			kClient := clientBuilder.WithObjects(objs...).Build()
+			recorder := record.NewBroadcaster().NewRecorder(kClient.Scheme(), corev1.EventSource{Component: "test"})
+			reconciler := NewScaleUpReconciler(kClient, recorder)
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "cluster"}}); err != nil {
+				t.Errorf("Reconcile returned error: %v", err)
+			}
+
+			var gotWorkloads kueue.WorkloadList
+			if err := kClient.List(ctx, &gotWorkloads, client.MatchingLabels{ScaleUpLabel: "cluster"}); err != nil {
+				t.Fatalf("Could not list the scale-up workloads: %v", err)
+			}
+			gotScaleUp := make(map[string][]kueue.PodSet, len(gotWorkloads.Items))
+			for _, wl := range gotWorkloads.Items {
+				gotScaleUp[wl.Name] = wl.Spec.PodSets
+			}
+			if diff := cmp.Diff(tc.wantScaleUp, gotScaleUp, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Scale-up workloads after reconcile (-want,+got):\n%s", diff)
+			}
+
+			var gotPods corev1.PodList
+			if err := kClient.List(ctx, &gotPods); err != nil {
+				t.Fatalf("Could not list the pods: %v", err)
+			}
+			gotGated := make(map[string]bool, len(gotPods.Items))
+			for _, pod := range gotPods.Items {
+				gotGated[pod.Name] = len(pod.Spec.SchedulingGates) > 0
+			}
+			if diff := cmp.Diff(tc.wantGated, gotGated, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Gated pods after reconcile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
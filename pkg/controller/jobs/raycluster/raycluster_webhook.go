@@ -23,15 +23,18 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -42,11 +45,15 @@ var (
 )
 
 type RayClusterWebhook struct {
-	client                       client.Client
-	queues                       *queue.Manager
-	manageJobsWithoutQueueName   bool
-	managedJobsNamespaceSelector labels.Selector
-	cache                        *cache.Cache
+	client                         client.Client
+	queues                         *queue.Manager
+	manageJobsWithoutQueueName     bool
+	managedJobsNamespaceSelector   labels.Selector
+	cache                          *cache.Cache
+	admittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	multiKueueDisabledIntegrations sets.Set[string]
+	missingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	defaultQueueName               string
 }
 
 // SetupRayClusterWebhook configures the webhook for rayv1 RayCluster.
@@ -56,11 +63,15 @@ func SetupRayClusterWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 		opt(&options)
 	}
 	wh := &RayClusterWebhook{
-		client:                       mgr.GetClient(),
-		queues:                       options.Queues,
-		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		cache:                        options.Cache,
+		client:                         mgr.GetClient(),
+		queues:                         options.Queues,
+		manageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+		cache:                          options.Cache,
+		admittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+		multiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+		missingQueueNamePolicy:         options.MissingQueueNamePolicy,
+		defaultQueueName:               options.DefaultQueueName,
 	}
 	obj := &rayv1.RayCluster{}
 	return webhook.WebhookManagedBy(mgr).
@@ -80,10 +91,11 @@ func (w *RayClusterWebhook) Default(ctx context.Context, obj runtime.Object) err
 	log := ctrl.LoggerFrom(ctx).WithName("raycluster-webhook")
 	log.V(10).Info("Applying defaults")
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultQueueName(job.Object(), w.missingQueueNamePolicy, w.defaultQueueName)
 	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
-	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, log)
+	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, w.multiKueueDisabledIntegrations, log)
 	return nil
 }
 
@@ -96,7 +108,9 @@ func (w *RayClusterWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	job := obj.(*rayv1.RayCluster)
 	log := ctrl.LoggerFrom(ctx).WithName("raycluster-webhook")
 	log.V(10).Info("Validating create")
-	return nil, w.validateCreate(job).ToAggregate()
+	allErrors := w.validateCreate(job)
+	allErrors = append(allErrors, jobframework.ValidateMissingQueueNameOnCreate(job, w.missingQueueNamePolicy)...)
+	return nil, allErrors.ToAggregate()
 }
 
 func (w *RayClusterWebhook) validateCreate(job *rayv1.RayCluster) field.ErrorList {
@@ -108,8 +122,9 @@ func (w *RayClusterWebhook) validateCreate(job *rayv1.RayCluster) field.ErrorLis
 		specPath := field.NewPath("spec")
 
 		// TODO revisit once Support dynamically sized (elastic) jobs #77 is implemented
-		// Should not use auto scaler. Once the resources are reserved by queue the cluster should do it's best to use them.
-		if ptr.Deref(spec.EnableInTreeAutoscaling, false) {
+		// Should not use auto scaler, unless the workers added by the autoscaler are gated by the quota.
+		// Once the resources are reserved by queue the cluster should do it's best to use them.
+		if ptr.Deref(spec.EnableInTreeAutoscaling, false) && !features.Enabled(features.RayClusterAutoscaling) {
 			allErrors = append(allErrors, field.Invalid(specPath.Child("enableInTreeAutoscaling"), spec.EnableInTreeAutoscaling, "a kueue managed job should not use autoscaling"))
 		}
 
@@ -149,7 +164,7 @@ func (w *RayClusterWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj r
 	log := ctrl.LoggerFrom(ctx).WithName("raycluster-webhook")
 	if w.manageJobsWithoutQueueName || jobframework.QueueName((*RayCluster)(newJob)) != "" {
 		log.Info("Validating update")
-		allErrors := jobframework.ValidateJobOnUpdate((*RayCluster)(oldJob), (*RayCluster)(newJob))
+		allErrors := jobframework.ValidateJobOnUpdate((*RayCluster)(oldJob), (*RayCluster)(newJob), w.admittedJobUpdatePolicy)
 		allErrors = append(allErrors, w.validateCreate(newJob)...)
 		return nil, allErrors.ToAggregate()
 	}
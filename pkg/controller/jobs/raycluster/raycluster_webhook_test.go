@@ -140,9 +140,10 @@ func TestValidateCreate(t *testing.T) {
 	bigWorkerGroup := []rayv1.WorkerGroupSpec{worker, worker, worker, worker, worker, worker, worker, worker}
 
 	testcases := map[string]struct {
-		job       *rayv1.RayCluster
-		manageAll bool
-		wantErr   error
+		job                         *rayv1.RayCluster
+		manageAll                   bool
+		enableRayClusterAutoscaling bool
+		wantErr                     error
 	}{
 		"invalid unmanaged": {
 			job: testingrayutil.MakeCluster("job", "ns").
@@ -157,6 +158,13 @@ func TestValidateCreate(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "enableInTreeAutoscaling"), ptr.To(true), "a kueue managed job should not use autoscaling"),
 			}.ToAggregate(),
 		},
+		"valid managed - has auto scaler with RayClusterAutoscaling enabled": {
+			job: testingrayutil.MakeCluster("job", "ns").Queue("queue").
+				WithEnableAutoscaling(ptr.To(true)).
+				Obj(),
+			enableRayClusterAutoscaling: true,
+			wantErr:                     nil,
+		},
 		"invalid managed - too many worker groups": {
 			job: testingrayutil.MakeCluster("job", "ns").Queue("queue").
 				WithWorkerGroups(bigWorkerGroup...).
@@ -242,18 +250,19 @@ func TestValidateCreate(t *testing.T) {
 					field.NewPath("spec.headGroupSpec.template, metadata.annotations"),
 					field.OmitValueType{},
 					`must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`),
+						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`),
 				field.Invalid(
 					field.NewPath("spec.workerGroupSpecs[0].template.metadata.annotations"),
 					field.OmitValueType{},
 					`must not contain more than one topology annotation: ["kueue.x-k8s.io/podset-required-topology", `+
-						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology"]`),
+						`"kueue.x-k8s.io/podset-preferred-topology", "kueue.x-k8s.io/podset-unconstrained-topology", "kueue.x-k8s.io/podset-spread-topology"]`),
 			}.ToAggregate(),
 		},
 	}
 
 	for name, tc := range testcases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.RayClusterAutoscaling, tc.enableRayClusterAutoscaling)
 			wh := &RayClusterWebhook{
 				manageJobsWithoutQueueName: tc.manageAll,
 			}
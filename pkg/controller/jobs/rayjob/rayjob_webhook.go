@@ -23,12 +23,14 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
@@ -42,22 +44,30 @@ var (
 )
 
 type RayJobWebhook struct {
-	client                       client.Client
-	queues                       *queue.Manager
-	manageJobsWithoutQueueName   bool
-	managedJobsNamespaceSelector labels.Selector
-	cache                        *cache.Cache
+	client                         client.Client
+	queues                         *queue.Manager
+	manageJobsWithoutQueueName     bool
+	managedJobsNamespaceSelector   labels.Selector
+	cache                          *cache.Cache
+	admittedJobUpdatePolicy        configapi.AdmittedJobUpdatePolicy
+	multiKueueDisabledIntegrations sets.Set[string]
+	missingQueueNamePolicy         configapi.MissingQueueNamePolicy
+	defaultQueueName               string
 }
 
 // SetupRayJobWebhook configures the webhook for RayJob.
 func SetupRayJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	options := jobframework.ProcessOptions(opts...)
 	wh := &RayJobWebhook{
-		client:                       mgr.GetClient(),
-		queues:                       options.Queues,
-		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
-		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		cache:                        options.Cache,
+		client:                         mgr.GetClient(),
+		queues:                         options.Queues,
+		manageJobsWithoutQueueName:     options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector:   options.ManagedJobsNamespaceSelector,
+		cache:                          options.Cache,
+		admittedJobUpdatePolicy:        options.AdmittedJobUpdatePolicy,
+		multiKueueDisabledIntegrations: options.MultiKueueDisabledIntegrations,
+		missingQueueNamePolicy:         options.MissingQueueNamePolicy,
+		defaultQueueName:               options.DefaultQueueName,
 	}
 	obj := &rayv1.RayJob{}
 	return webhook.WebhookManagedBy(mgr).
@@ -77,10 +87,11 @@ func (w *RayJobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx).WithName("rayjob-webhook")
 	log.V(5).Info("Applying defaults")
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultQueueName(job.Object(), w.missingQueueNamePolicy, w.defaultQueueName)
 	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
-	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, log)
+	jobframework.ApplyDefaultForManagedBy(job, w.queues, w.cache, w.multiKueueDisabledIntegrations, log)
 	return nil
 }
 
@@ -93,7 +104,9 @@ func (w *RayJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	job := obj.(*rayv1.RayJob)
 	log := ctrl.LoggerFrom(ctx).WithName("rayjob-webhook")
 	log.Info("Validating create")
-	return nil, w.validateCreate(job).ToAggregate()
+	allErrors := w.validateCreate(job)
+	allErrors = append(allErrors, jobframework.ValidateMissingQueueNameOnCreate(job, w.missingQueueNamePolicy)...)
+	return nil, allErrors.ToAggregate()
 }
 
 func (w *RayJobWebhook) validateCreate(job *rayv1.RayJob) field.ErrorList {
@@ -161,7 +174,7 @@ func (w *RayJobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 	log := ctrl.LoggerFrom(ctx).WithName("rayjob-webhook")
 	if w.manageJobsWithoutQueueName || jobframework.QueueName((*RayJob)(newJob)) != "" {
 		log.Info("Validating update")
-		allErrors := jobframework.ValidateJobOnUpdate((*RayJob)(oldJob), (*RayJob)(newJob))
+		allErrors := jobframework.ValidateJobOnUpdate((*RayJob)(oldJob), (*RayJob)(newJob), w.admittedJobUpdatePolicy)
 		allErrors = append(allErrors, w.validateCreate(newJob)...)
 		return nil, allErrors.ToAggregate()
 	}
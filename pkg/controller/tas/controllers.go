@@ -34,7 +34,11 @@ func SetupControllers(mgr ctrl.Manager, queues *queue.Manager, cache *cache.Cach
 	if ctrlName, err := rfRec.setupWithManager(mgr, cache, cfg); err != nil {
 		return ctrlName, err
 	}
-	topologyUngater := newTopologyUngater(mgr.GetClient())
+	nodeAffinityMode := configapi.TASNodeAffinityRequired
+	if cfg.TopologyAwareScheduling != nil && cfg.TopologyAwareScheduling.NodeAffinityMode != nil {
+		nodeAffinityMode = *cfg.TopologyAwareScheduling.NodeAffinityMode
+	}
+	topologyUngater := newTopologyUngater(mgr.GetClient(), nodeAffinityMode)
 	if ctrlName, err := topologyUngater.setupWithManager(mgr, cfg); err != nil {
 		return ctrlName, err
 	}
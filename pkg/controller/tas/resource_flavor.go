@@ -18,11 +18,15 @@ package tas
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -40,7 +44,9 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 type rfReconciler struct {
@@ -49,6 +55,7 @@ type rfReconciler struct {
 	tasCache *cache.TASCache
 	client   client.Client
 	recorder record.EventRecorder
+	clock    clock.Clock
 }
 
 var _ reconcile.Reconciler = (*rfReconciler)(nil)
@@ -57,6 +64,7 @@ var _ predicate.TypedPredicate[*kueue.ResourceFlavor] = (*rfReconciler)(nil)
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=topologies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 
 func newRfReconciler(c client.Client, queues *queue.Manager, cache *cache.Cache, recorder record.EventRecorder) *rfReconciler {
 	return &rfReconciler{
@@ -65,6 +73,7 @@ func newRfReconciler(c client.Client, queues *queue.Manager, cache *cache.Cache,
 		cache:    cache,
 		tasCache: cache.TASCache(),
 		recorder: recorder,
+		clock:    clock.RealClock{},
 	}
 }
 
@@ -158,6 +167,11 @@ func (r *rfReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 		} else {
 			log.V(3).Info("Skip topology update to cache as already present for flavor", "flavorName", flv.Name)
 		}
+		if features.Enabled(features.TASEvictOnUnavailableDomains) {
+			if err := r.evictWorkloadsOnUnavailableDomains(ctx, flavorReference); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 		// requeue inadmissible workloads as a change to the resource flavor
 		// or the set of nodes can allow admitting a workload which was
 		// previously inadmissible.
@@ -168,6 +182,35 @@ func (r *rfReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 	return reconcile.Result{}, nil
 }
 
+// evictWorkloadsOnUnavailableDomains evicts the workloads assigned to topology
+// domains of the flavor which lost all their ready and schedulable nodes, so
+// that they can be admitted again on the remaining domains.
+func (r *rfReconciler) evictWorkloadsOnUnavailableDomains(ctx context.Context, flv kueue.ResourceFlavorReference) error {
+	log := ctrl.LoggerFrom(ctx)
+	wlInfos, err := r.cache.WorkloadsOnUnavailableTopologyDomains(ctx, flv)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("A topology domain of the ResourceFlavor %s assigned to the workload has no ready and schedulable nodes", flv)
+	for _, wlInfo := range wlInfos {
+		if apimeta.IsStatusConditionTrue(wlInfo.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		wl := wlInfo.Obj.DeepCopy()
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByTopologyDomainUnavailable, message)
+		workload.ResetChecksOnEviction(wl, r.clock.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+		log.V(3).Info("Workload is evicted due to an unavailable topology domain", "workload", klog.KObj(wl), "flavorName", flv)
+		workload.ReportEvictedWorkload(r.recorder, wl, wlInfo.ClusterQueue, kueue.WorkloadEvictedByTopologyDomainUnavailable, message)
+	}
+	return nil
+}
+
 func (r *rfReconciler) Create(event event.TypedCreateEvent[*kueue.ResourceFlavor]) bool {
 	return event.Object.Spec.TopologyName != nil
 }
@@ -0,0 +1,166 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestReconcileEvictsWorkloadsOnUnavailableDomains(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+
+	baseNode := testingnode.MakeNode("").
+		StatusAllocatable(corev1.ResourceList{
+			corev1.ResourceCPU:  resource.MustParse("4"),
+			corev1.ResourcePods: resource.MustParse("10"),
+		})
+	topology := utiltesting.MakeTopology("default").Levels(tasRackLabel).Obj()
+	flavor := utiltesting.MakeResourceFlavor("tas-flavor").TopologyName(topology.Name).Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-flavor").Resource(corev1.ResourceCPU, "8").Obj()).
+		Obj()
+	baseWorkload := utiltesting.MakeWorkload("wl", "default").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+			AssignmentPodCount(1).
+			TopologyAssignment(&kueue.TopologyAssignment{
+				Levels: []string{tasRackLabel},
+				Domains: []kueue.TopologyDomainAssignment{
+					{Values: []string{"r1"}, Count: 1},
+				},
+			}).
+			Obj()).
+		Admitted(true)
+
+	cases := map[string]struct {
+		disableFeature bool
+		nodes          []corev1.Node
+		workload       *kueue.Workload
+		wantWorkload   *kueue.Workload
+	}{
+		"the assigned domain has a ready and schedulable node": {
+			nodes: []corev1.Node{
+				*baseNode.Clone().Name("r1").Label(tasRackLabel, "r1").Ready().Obj(),
+			},
+			workload:     baseWorkload.Clone().Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+		"the node of the assigned domain is cordoned": {
+			nodes: []corev1.Node{
+				*baseNode.Clone().Name("r1").Label(tasRackLabel, "r1").Ready().Unschedulable().Obj(),
+				*baseNode.Clone().Name("r2").Label(tasRackLabel, "r2").Ready().Obj(),
+			},
+			workload: baseWorkload.Clone().Obj(),
+			wantWorkload: baseWorkload.Clone().
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByTopologyDomainUnavailable,
+					Message: "A topology domain of the ResourceFlavor tas-flavor assigned to the workload has no ready and schedulable nodes",
+				}).
+				Obj(),
+		},
+		"the node of the assigned domain is removed": {
+			nodes: []corev1.Node{
+				*baseNode.Clone().Name("r2").Label(tasRackLabel, "r2").Ready().Obj(),
+			},
+			workload: baseWorkload.Clone().Obj(),
+			wantWorkload: baseWorkload.Clone().
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByTopologyDomainUnavailable,
+					Message: "A topology domain of the ResourceFlavor tas-flavor assigned to the workload has no ready and schedulable nodes",
+				}).
+				Obj(),
+		},
+		"the node of the assigned domain is removed, feature disabled": {
+			disableFeature: true,
+			nodes: []corev1.Node{
+				*baseNode.Clone().Name("r2").Label(tasRackLabel, "r2").Ready().Obj(),
+			},
+			workload:     baseWorkload.Clone().Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+			features.SetFeatureGateDuringTest(t, features.TASEvictOnUnavailableDomains, !tc.disableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			objs := []client.Object{topology, flavor, tc.workload}
+			for i := range tc.nodes {
+				objs = append(objs, &tc.nodes[i])
+			}
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(objs...).
+				WithStatusSubresource(tc.workload)
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			cl := clientBuilder.Build()
+
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(flavor)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+			}
+			cqCache.AddOrUpdateWorkload(tc.workload)
+
+			reconciler := newRfReconciler(cl, queue.NewManager(cl, cqCache), cqCache, &utiltesting.EventRecorder{})
+			reconciler.clock = testingclock.NewFakeClock(tc.workload.CreationTimestamp.Time)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: flavor.Name}}); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload); err != nil {
+				t.Fatalf("Could not get the workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload,
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.SortSlices(func(a, b metav1.Condition) bool { return a.Type < b.Type }),
+				cmpopts.EquateEmpty(),
+			); diff != "" {
+				t.Errorf("Unexpected workload (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -60,11 +60,15 @@ var (
 type topologyUngater struct {
 	client            client.Client
 	expectationsStore *expectations.Store
+	nodeAffinityMode  configapi.TASNodeAffinityMode
 }
 
 type podWithUngateInfo struct {
 	pod        *corev1.Pod
 	nodeLabels map[string]string
+	// preferredTerms are the preferred node affinity terms, one per topology
+	// level which isn't required, injected in the Preferred mode.
+	preferredTerms []corev1.PreferredSchedulingTerm
 }
 
 type podWithDomain struct {
@@ -78,11 +82,13 @@ var _ predicate.TypedPredicate[*kueue.Workload] = (*topologyUngater)(nil)
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
 
-func newTopologyUngater(c client.Client) *topologyUngater {
+func newTopologyUngater(c client.Client, nodeAffinityMode configapi.TASNodeAffinityMode) *topologyUngater {
 	return &topologyUngater{
 		client:            c,
 		expectationsStore: expectations.NewStore(TASTopologyUngater),
+		nodeAffinityMode:  nodeAffinityMode,
 	}
 }
 
@@ -184,7 +190,16 @@ func (r *topologyUngater) Reconcile(ctx context.Context, req reconcile.Request)
 			}
 			gatedPodsToDomains := assignGatedPodsToDomains(log, &psa, pods, psNameToTopologyRequest[psa.Name])
 			if len(gatedPodsToDomains) > 0 {
-				toUngate := podsToUngateInfo(&psa, gatedPodsToDomains)
+				requiredLevels := len(psa.TopologyAssignment.Levels)
+				if r.nodeAffinityMode == configapi.TASNodeAffinityPreferred {
+					level, err := r.requiredLevel(ctx, &psa, psNameToTopologyRequest[psa.Name])
+					if err != nil {
+						log.Error(err, "failed to get the required topology level for PodSet", "podset", psa.Name)
+						return reconcile.Result{}, err
+					}
+					requiredLevels = requiredLevelsCount(psa.TopologyAssignment.Levels, level)
+				}
+				toUngate := podsToUngateInfo(&psa, gatedPodsToDomains, requiredLevels)
 				log.V(2).Info("identified pods to ungate for podset", "podset", psa.Name, "count", len(toUngate))
 				allToUngate = append(allToUngate, toUngate...)
 			}
@@ -208,6 +223,9 @@ func (r *topologyUngater) Reconcile(ctx context.Context, req reconcile.Request)
 				for labelKey, labelValue := range podWithUngateInfo.nodeLabels {
 					podWithUngateInfo.pod.Spec.NodeSelector[labelKey] = labelValue
 				}
+				if ungated && len(podWithUngateInfo.preferredTerms) > 0 {
+					addPreferredNodeAffinity(podWithUngateInfo.pod, podWithUngateInfo.preferredTerms)
+				}
 				return true, nil
 			})
 			if e != nil {
@@ -265,26 +283,92 @@ func (r *topologyUngater) podsForPodSet(ctx context.Context, ns, wlName string,
 	return result, nil
 }
 
+// requiredLevel returns the topology level required by the PodSet, either
+// with the PodSet annotation or by default for the assigned flavor, or an
+// empty string if the PodSet doesn't require a level.
+func (r *topologyUngater) requiredLevel(ctx context.Context, psa *kueue.PodSetAssignment, psReq *kueue.PodSetTopologyRequest) (string, error) {
+	if psReq != nil {
+		return ptr.Deref(psReq.Required, ""), nil
+	}
+	for _, flavorName := range psa.Flavors {
+		var flavor kueue.ResourceFlavor
+		if err := r.client.Get(ctx, types.NamespacedName{Name: string(flavorName)}, &flavor); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		return ptr.Deref(flavor.Spec.DefaultRequiredTopologyLevel, ""), nil
+	}
+	return "", nil
+}
+
+// requiredLevelsCount returns the number of the topology levels, from the
+// top, which are injected as required, given the level required by the PodSet.
+func requiredLevelsCount(levels []string, requiredLevel string) int {
+	if requiredLevel == "" {
+		return 0
+	}
+	if idx := slices.Index(levels, requiredLevel); idx >= 0 {
+		return idx + 1
+	}
+	return len(levels)
+}
+
 func podsToUngateInfo(
 	psa *kueue.PodSetAssignment,
-	podToUngateWithDomain []podWithDomain) []podWithUngateInfo {
+	podToUngateWithDomain []podWithDomain,
+	requiredLevels int) []podWithUngateInfo {
 	domainIDToLabelValues := make(map[utiltas.TopologyDomainID][]string)
 	for _, psaDomain := range psa.TopologyAssignment.Domains {
 		domainID := utiltas.DomainID(psaDomain.Values)
 		domainIDToLabelValues[domainID] = psaDomain.Values
 	}
+	levels := psa.TopologyAssignment.Levels
 	toUngate := make([]podWithUngateInfo, len(podToUngateWithDomain))
 	for i, pd := range podToUngateWithDomain {
 		domainValues := domainIDToLabelValues[pd.domainID]
-		nodeLabels := utiltas.NodeLabelsFromKeysAndValues(psa.TopologyAssignment.Levels, domainValues)
+		nodeLabels := utiltas.NodeLabelsFromKeysAndValues(levels[:requiredLevels], domainValues[:requiredLevels])
 		toUngate[i] = podWithUngateInfo{
-			pod:        pd.pod,
-			nodeLabels: nodeLabels,
+			pod:            pd.pod,
+			nodeLabels:     nodeLabels,
+			preferredTerms: preferredSchedulingTerms(levels[requiredLevels:], domainValues[requiredLevels:]),
 		}
 	}
 	return toUngate
 }
 
+// preferredSchedulingTerms returns a preferred node affinity term for each
+// of the topology levels, so that the nodes matching more of the levels of
+// the assigned domain are preferred.
+func preferredSchedulingTerms(levels, values []string) []corev1.PreferredSchedulingTerm {
+	if len(levels) == 0 {
+		return nil
+	}
+	terms := make([]corev1.PreferredSchedulingTerm, len(levels))
+	for i := range levels {
+		terms[i] = corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      levels[i],
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{values[i]},
+				}},
+			},
+		}
+	}
+	return terms
+}
+
+func addPreferredNodeAffinity(pod *corev1.Pod, terms []corev1.PreferredSchedulingTerm) {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, terms...)
+}
+
 func assignGatedPodsToDomains(
 	log logr.Logger,
 	psa *kueue.PodSetAssignment,
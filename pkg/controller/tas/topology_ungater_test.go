@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
@@ -2253,7 +2254,7 @@ func TestReconcile(t *testing.T) {
 					t.Fatalf("Could not create workload: %v", err)
 				}
 			}
-			topologyUngater := newTopologyUngater(kClient)
+			topologyUngater := newTopologyUngater(kClient, configapi.TASNodeAffinityRequired)
 			key := client.ObjectKeyFromObject(&tc.workloads[0])
 			request := reconcile.Request{NamespacedName: key}
 			if len(tc.expectUIDs) > 0 {
@@ -2300,3 +2301,132 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileNodeAffinityMode(t *testing.T) {
+	preferredTerm := func(key, value string) corev1.PreferredSchedulingTerm {
+		return corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{value},
+				}},
+			},
+		}
+	}
+	withPreferredTerms := func(pod *corev1.Pod, terms ...corev1.PreferredSchedulingTerm) *corev1.Pod {
+		pod.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: terms,
+			},
+		}
+		return pod
+	}
+	podSet := func(topologyRequest *kueue.PodSetTopologyRequest) *kueue.PodSet {
+		ps := utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()
+		ps.TopologyRequest = topologyRequest
+		return ps
+	}
+	gatedPod := testingpod.MakePod("pod", "ns").
+		Annotation(kueuealpha.WorkloadAnnotation, "unit-test").
+		Label(kueuealpha.PodSetLabel, string(kueue.DefaultPodSetName)).
+		TopologySchedulingGate()
+	ungatedPod := testingpod.MakePod("pod", "ns").
+		Annotation(kueuealpha.WorkloadAnnotation, "unit-test").
+		Label(kueuealpha.PodSetLabel, string(kueue.DefaultPodSetName))
+
+	testCases := map[string]struct {
+		nodeAffinityMode configapi.TASNodeAffinityMode
+		podSet           *kueue.PodSet
+		flavor           *kueue.ResourceFlavor
+		wantPod          *corev1.Pod
+	}{
+		"required mode; all the levels are injected in the node selector": {
+			nodeAffinityMode: configapi.TASNodeAffinityRequired,
+			podSet:           podSet(&kueue.PodSetTopologyRequest{Preferred: ptr.To(tasRackLabel)}),
+			wantPod: ungatedPod.Clone().
+				NodeSelector(tasBlockLabel, "b1").
+				NodeSelector(tasRackLabel, "r1").
+				Obj(),
+		},
+		"preferred mode; the PodSet prefers a level, all the levels are injected as preferred": {
+			nodeAffinityMode: configapi.TASNodeAffinityPreferred,
+			podSet:           podSet(&kueue.PodSetTopologyRequest{Preferred: ptr.To(tasRackLabel)}),
+			wantPod: withPreferredTerms(ungatedPod.Clone().Obj(),
+				preferredTerm(tasBlockLabel, "b1"),
+				preferredTerm(tasRackLabel, "r1"),
+			),
+		},
+		"preferred mode; the levels up to the level required by the PodSet are injected in the node selector": {
+			nodeAffinityMode: configapi.TASNodeAffinityPreferred,
+			podSet:           podSet(&kueue.PodSetTopologyRequest{Required: ptr.To(tasBlockLabel)}),
+			wantPod: withPreferredTerms(ungatedPod.Clone().NodeSelector(tasBlockLabel, "b1").Obj(),
+				preferredTerm(tasRackLabel, "r1"),
+			),
+		},
+		"preferred mode; the levels up to the default level required by the flavor are injected in the node selector": {
+			nodeAffinityMode: configapi.TASNodeAffinityPreferred,
+			podSet:           podSet(nil),
+			flavor:           utiltesting.MakeResourceFlavor("tas-flavor").TopologyName("default").DefaultRequiredTopologyLevel(tasBlockLabel).Obj(),
+			wantPod: withPreferredTerms(ungatedPod.Clone().NodeSelector(tasBlockLabel, "b1").Obj(),
+				preferredTerm(tasRackLabel, "r1"),
+			),
+		},
+		"preferred mode; the lowest level is required, all the levels are injected in the node selector": {
+			nodeAffinityMode: configapi.TASNodeAffinityPreferred,
+			podSet:           podSet(&kueue.PodSetTopologyRequest{Required: ptr.To(tasRackLabel)}),
+			wantPod: ungatedPod.Clone().
+				NodeSelector(tasBlockLabel, "b1").
+				NodeSelector(tasRackLabel, "r1").
+				Obj(),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wl := utiltesting.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+				PodSets(*tc.podSet).
+				ReserveQuota(
+					utiltesting.MakeAdmission("cq").
+						Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+						AssignmentPodCount(1).
+						TopologyAssignment(&kueue.TopologyAssignment{
+							Levels: defaultTestLevels,
+							Domains: []kueue.TopologyDomainAssignment{{
+								Count:  1,
+								Values: []string{"b1", "r1"},
+							}},
+						}).
+						Obj(),
+				).
+				Admitted(true).
+				Obj()
+			clientBuilder := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			objs := []client.Object{gatedPod.Clone().Obj()}
+			if tc.flavor != nil {
+				objs = append(objs, tc.flavor)
+			}
+			kClient := clientBuilder.WithObjects(objs...).WithStatusSubresource(wl).Build()
+			if err := kClient.Create(ctx, wl); err != nil {
+				t.Fatalf("Could not create workload: %v", err)
+			}
+
+			topologyUngater := newTopologyUngater(kClient, tc.nodeAffinityMode)
+			if _, err := topologyUngater.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(wl)}); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+
+			var gotPod corev1.Pod
+			if err := kClient.Get(ctx, client.ObjectKeyFromObject(tc.wantPod), &gotPod); err != nil {
+				t.Fatalf("Could not get Pod after reconcile: %v", err)
+			}
+			if diff := gocmp.Diff(tc.wantPod, &gotPod, podCmpOpts...); diff != "" {
+				t.Errorf("Pod after reconcile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
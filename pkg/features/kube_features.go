@@ -163,6 +163,67 @@ const (
 	//
 	// Enable admission fair sharing
 	AdmissionFairSharing featuregate.Feature = "AdmissionFairSharing"
+
+	// Enable the built-in admission check controller evaluating CEL expressions.
+	CELAdmissionCheck featuregate.Feature = "CELAdmissionCheck"
+
+	// kep: https://github.com/kubernetes-sigs/kueue/tree/main/keps/2724-topology-aware-scheduling
+	//
+	// Enable evicting the workloads assigned to topology domains which lost
+	// all their ready and schedulable nodes.
+	TASEvictOnUnavailableDomains featuregate.Feature = "TASEvictOnUnavailableDomains"
+
+	// Enable recording the recent admissions and evictions of the workloads
+	// in their status.
+	WorkloadAdmissionHistory featuregate.Feature = "WorkloadAdmissionHistory"
+
+	// Enable deferring the preemptions which would evict pods in violation
+	// of a PodDisruptionBudget.
+	PreemptionRespectsPodDisruptionBudgets featuregate.Feature = "PreemptionRespectsPodDisruptionBudgets"
+
+	// Enable reusing the Ready results of the admission checks when a workload
+	// gets the quota reserved again without changes to its PodSets.
+	AdmissionCheckResultCaching featuregate.Feature = "AdmissionCheckResultCaching"
+
+	// Enable the autoscaling of the RayClusters, with the workers added by
+	// the autoscaler gated by the quota.
+	RayClusterAutoscaling featuregate.Feature = "RayClusterAutoscaling"
+
+	// Enable the ClusterQueue quotas which follow the size of a pool of nodes.
+	ElasticClusterQueueQuota featuregate.Feature = "ElasticClusterQueueQuota"
+
+	// Enable reporting the position of the pending workloads in the queue
+	// of their ClusterQueue in their status.
+	WorkloadQueuePosition featuregate.Feature = "WorkloadQueuePosition"
+
+	// Enable recording the last placement of the workloads, so that evicted
+	// workloads are preferably admitted again to the same flavors and
+	// topology domains.
+	WorkloadResumeHints featuregate.Feature = "WorkloadResumeHints"
+
+	// Enable assigning the flavors and the topology domains to the PodSets
+	// of a workload in the order of their priorities.
+	PodSetPriority featuregate.Feature = "PodSetPriority"
+
+	// Enable recording the scheduling decisions for workloads as events
+	// carrying the flavor assignments and the preemptions as structured data.
+	SchedulingDecisionEvents featuregate.Feature = "SchedulingDecisionEvents"
+
+	// Enable tracking the extended resources in milli-units, so that
+	// fractional quantities are accounted exactly.
+	ExtendedResourceMilliUnits featuregate.Feature = "ExtendedResourceMilliUnits"
+
+	// Enable admitting the large Indexed Jobs in waves, with the quota for
+	// each wave reserved by a separate workload.
+	JobAdmissionWaves featuregate.Feature = "JobAdmissionWaves"
+
+	// Enable recording the number of evictions and the reason of the last
+	// eviction in the workload status.
+	WorkloadEvictionStatus featuregate.Feature = "WorkloadEvictionStatus"
+
+	// Enable accounting in the quota for the resources requested by the
+	// admission checks for the pods of the workloads.
+	AdmissionCheckPodSetResources featuregate.Feature = "AdmissionCheckPodSetResources"
 )
 
 func init() {
@@ -253,6 +314,51 @@ var defaultVersionedFeatureGates = map[featuregate.Feature]featuregate.Versioned
 	AdmissionFairSharing: {
 		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
 	},
+	CELAdmissionCheck: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASEvictOnUnavailableDomains: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadAdmissionHistory: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	PreemptionRespectsPodDisruptionBudgets: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	AdmissionCheckResultCaching: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	RayClusterAutoscaling: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	ElasticClusterQueueQuota: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadQueuePosition: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadResumeHints: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	PodSetPriority: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	SchedulingDecisionEvents: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	ExtendedResourceMilliUnits: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	JobAdmissionWaves: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadEvictionStatus: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	AdmissionCheckPodSetResources: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
@@ -86,6 +86,23 @@ The label 'result' can have the following values:
 		}, []string{"result"},
 	)
 
+	SchedulerCycleDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "scheduler_cycle_duration_seconds",
+			Help:      "The duration of a scheduling cycle, from taking the snapshot to requeueing the workloads which weren't admitted",
+		},
+	)
+
+	SchedulerWorkloadsConsidered = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "scheduler_workloads_considered",
+			Help:      "The number of workloads, heads of the ClusterQueues, considered in a scheduling cycle",
+			Buckets:   generateExponentialBuckets(14),
+		},
+	)
+
 	AdmissionCyclePreemptionSkips = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -173,9 +190,10 @@ The label 'result' can have the following values:
 		prometheus.HistogramOpts{
 			Subsystem: constants.KueueName,
 			Name:      "admission_wait_time_seconds",
-			Help:      "The time between a workload was created or requeued until admission, per 'cluster_queue'",
-			Buckets:   generateExponentialBuckets(14),
-		}, []string{"cluster_queue"},
+			Help:      "The time between a workload was created or requeued until admission, per 'cluster_queue' and 'priority_class'",
+			// Up to 40960s, so that the percentiles of waits of several hours can be computed.
+			Buckets: generateExponentialBuckets(16),
+		}, []string{"cluster_queue", "priority_class"},
 	)
 
 	localQueueAdmissionWaitTime = prometheus.NewHistogramVec(
@@ -196,6 +214,15 @@ The label 'result' can have the following values:
 		}, []string{"cluster_queue"},
 	)
 
+	AdmissionCheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "admission_check_duration_seconds",
+			Help:      "The time an admission check stayed Pending before turning Ready or Rejected for a workload, per 'check'",
+			Buckets:   generateExponentialBuckets(14),
+		}, []string{"check"},
+	)
+
 	localQueueAdmissionChecksWaitTime = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Subsystem: constants.KueueName,
@@ -246,6 +273,17 @@ The label 'reason' can have the following values:
 		}, []string{"preempting_cluster_queue", "reason"},
 	)
 
+	PreemptionFailedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "preemption_failed_total",
+			Help: `The number of times the preemptions issued by a workload didn't free enough capacity to admit it, per 'cluster_queue',
+The label 'reason' can have the following values:
+- "NoCandidates" means that, after the preemptions, there were no workloads left which could be preempted.
+- "InsufficientCapacity" means that, after the preemptions, the workload still needed more capacity than preempting the candidate workloads could free, for example due to fragmentation.`,
+		}, []string{"cluster_queue", "reason"},
+	)
+
 	// Metrics tied to the cache.
 
 	ReservingActiveWorkloads = prometheus.NewGaugeVec(
@@ -369,6 +407,34 @@ the maximum possible share value.`,
 		}, []string{"cluster_queue"},
 	)
 
+	ClusterQueueNearCapacity = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_near_capacity",
+			Help: `Reports whether the cluster_queue's quota reservation is above its near capacity threshold
+in any of the flavors and resources. The value is 1 when it's above the threshold, and 0 otherwise.
+Only reported for the ClusterQueues which set the near capacity threshold.`,
+		}, []string{"cluster_queue"},
+	)
+
+	CohortResourceUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_usage",
+			Help: `Reports the cohort's total resource usage within all the flavors, summed over
+all the ClusterQueues in the cohort's subtree`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
+	CohortResourceNominalQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_nominal",
+			Help: `Reports the cohort's total resource nominal quota within all the flavors, summed over
+the cohort and all the ClusterQueues and cohorts in its subtree`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
 	CohortWeightedShare = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -381,6 +447,15 @@ If the Cohort has a weight of zero and is borrowing, this will return 9223372036
 the maximum possible share value.`,
 		}, []string{"cohort"},
 	)
+
+	ResourceFlavorNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "resource_flavor_nodes",
+			Help: `The number of nodes matching the node labels of the ResourceFlavor, per 'flavor'.
+The metric is reported for all the active ResourceFlavors.`,
+		}, []string{"flavor"},
+	)
 )
 
 func generateExponentialBuckets(count int) []float64 {
@@ -392,6 +467,13 @@ func AdmissionAttempt(result AdmissionResult, duration time.Duration) {
 	admissionAttemptDuration.WithLabelValues(string(result)).Observe(duration.Seconds())
 }
 
+// SchedulingCycle records the duration of a scheduling cycle and the number
+// of workloads considered in it.
+func SchedulingCycle(duration time.Duration, workloads int) {
+	SchedulerCycleDuration.Observe(duration.Seconds())
+	SchedulerWorkloadsConsidered.Observe(float64(workloads))
+}
+
 func QuotaReservedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Duration) {
 	QuotaReservedWorkloadsTotal.WithLabelValues(string(cqName)).Inc()
 	quotaReservedWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
@@ -402,9 +484,9 @@ func LocalQueueQuotaReservedWorkload(lq LocalQueueReference, waitTime time.Durat
 	localQueueQuotaReservedWaitTime.WithLabelValues(string(lq.Name), lq.Namespace).Observe(waitTime.Seconds())
 }
 
-func AdmittedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Duration) {
+func AdmittedWorkload(cqName kueue.ClusterQueueReference, priorityClass string, waitTime time.Duration) {
 	AdmittedWorkloadsTotal.WithLabelValues(string(cqName)).Inc()
-	admissionWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
+	admissionWaitTime.WithLabelValues(string(cqName), priorityClass).Observe(waitTime.Seconds())
 }
 
 func LocalQueueAdmittedWorkload(lq LocalQueueReference, waitTime time.Duration) {
@@ -416,6 +498,10 @@ func AdmissionChecksWaitTime(cqName kueue.ClusterQueueReference, waitTime time.D
 	admissionChecksWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
 }
 
+func ReportAdmissionCheckDuration(check kueue.AdmissionCheckReference, duration time.Duration) {
+	AdmissionCheckDuration.WithLabelValues(string(check)).Observe(duration.Seconds())
+}
+
 func LocalQueueAdmissionChecksWaitTime(lq LocalQueueReference, waitTime time.Duration) {
 	localQueueAdmissionChecksWaitTime.WithLabelValues(string(lq.Name), lq.Namespace).Observe(waitTime.Seconds())
 }
@@ -443,6 +529,10 @@ func ReportPreemption(preemptingCqName kueue.ClusterQueueReference, preemptingRe
 	ReportEvictedWorkloads(targetCqName, kueue.WorkloadEvictedByPreemption)
 }
 
+func ReportPreemptionFailure(cqName kueue.ClusterQueueReference, reason string) {
+	PreemptionFailedTotal.WithLabelValues(string(cqName), reason).Inc()
+}
+
 func LQRefFromWorkload(wl *kueue.Workload) LocalQueueReference {
 	return LocalQueueReference{
 		Name:      wl.Spec.QueueName,
@@ -457,10 +547,11 @@ func ClearClusterQueueMetrics(cqName string) {
 	QuotaReservedWorkloadsTotal.DeleteLabelValues(cqName)
 	quotaReservedWaitTime.DeleteLabelValues(cqName)
 	AdmittedWorkloadsTotal.DeleteLabelValues(cqName)
-	admissionWaitTime.DeleteLabelValues(cqName)
+	admissionWaitTime.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	admissionChecksWaitTime.DeleteLabelValues(cqName)
 	EvictedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	PreemptedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	PreemptionFailedTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 }
 
 func ClearLocalQueueMetrics(lq LocalQueueReference) {
@@ -542,10 +633,43 @@ func ReportClusterQueueWeightedShare(cq string, weightedShare int64) {
 	ClusterQueueWeightedShare.WithLabelValues(cq).Set(float64(weightedShare))
 }
 
+func ReportClusterQueueNearCapacity(cqName string, nearCapacity bool) {
+	var v float64
+	if nearCapacity {
+		v = 1
+	}
+	ClusterQueueNearCapacity.WithLabelValues(cqName).Set(v)
+}
+
+func ClearClusterQueueNearCapacity(cqName string) {
+	ClusterQueueNearCapacity.DeleteLabelValues(cqName)
+}
+
 func ReportCohortWeightedShare(cohort string, weightedShare int64) {
 	CohortWeightedShare.WithLabelValues(cohort).Set(float64(weightedShare))
 }
 
+func ReportCohortResourceMetrics(cohort kueue.CohortReference, flavor, resource string, nominal, usage float64) {
+	CohortResourceNominalQuota.WithLabelValues(string(cohort), flavor, resource).Set(nominal)
+	CohortResourceUsage.WithLabelValues(string(cohort), flavor, resource).Set(usage)
+}
+
+func ClearCohortResourceMetrics(cohort kueue.CohortReference) {
+	lbls := prometheus.Labels{
+		"cohort": string(cohort),
+	}
+	CohortResourceNominalQuota.DeletePartialMatch(lbls)
+	CohortResourceUsage.DeletePartialMatch(lbls)
+}
+
+func ReportResourceFlavorNodes(flavor kueue.ResourceFlavorReference, nodes int) {
+	ResourceFlavorNodes.WithLabelValues(string(flavor)).Set(float64(nodes))
+}
+
+func ClearResourceFlavorNodes(flavor kueue.ResourceFlavorReference) {
+	ResourceFlavorNodes.DeleteLabelValues(string(flavor))
+}
+
 func ClearClusterQueueResourceMetrics(cqName string) {
 	lbls := prometheus.Labels{
 		"cluster_queue": cqName,
@@ -615,6 +739,8 @@ func Register() {
 	metrics.Registry.MustRegister(
 		AdmissionAttemptsTotal,
 		admissionAttemptDuration,
+		SchedulerCycleDuration,
+		SchedulerWorkloadsConsidered,
 		AdmissionCyclePreemptionSkips,
 		PendingWorkloads,
 		ReservingActiveWorkloads,
@@ -624,8 +750,10 @@ func Register() {
 		AdmittedWorkloadsTotal,
 		EvictedWorkloadsTotal,
 		PreemptedWorkloadsTotal,
+		PreemptionFailedTotal,
 		admissionWaitTime,
 		admissionChecksWaitTime,
+		AdmissionCheckDuration,
 		ClusterQueueResourceUsage,
 		ClusterQueueByStatus,
 		ClusterQueueResourceReservations,
@@ -633,7 +761,11 @@ func Register() {
 		ClusterQueueResourceBorrowingLimit,
 		ClusterQueueResourceLendingLimit,
 		ClusterQueueWeightedShare,
+		ClusterQueueNearCapacity,
+		CohortResourceUsage,
+		CohortResourceNominalQuota,
 		CohortWeightedShare,
+		ResourceFlavorNodes,
 	)
 	if features.Enabled(features.LocalQueueMetrics) {
 		RegisterLQMetrics()
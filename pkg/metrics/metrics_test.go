@@ -18,9 +18,11 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 
 	"sigs.k8s.io/kueue/pkg/util/testing/metrics"
 )
@@ -144,6 +146,35 @@ func TestReportAndCleanupClusterQueueUsage(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 0, "cluster_queue", "queue", "flavor", "flavor", "resource", "res2")
 }
 
+func TestReportAndCleanupCohortResourceMetrics(t *testing.T) {
+	ReportCohortResourceMetrics("cohort", "flavor", "res", 10, 5)
+	ReportCohortResourceMetrics("cohort", "flavor2", "res", 4, 1)
+	ReportCohortResourceMetrics("cohort2", "flavor", "res", 6, 3)
+
+	expectFilteredMetricsCount(t, CohortResourceNominalQuota, 2, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceUsage, 2, "cohort", "cohort")
+
+	ClearCohortResourceMetrics("cohort")
+
+	expectFilteredMetricsCount(t, CohortResourceNominalQuota, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceUsage, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceNominalQuota, 1, "cohort", "cohort2")
+	expectFilteredMetricsCount(t, CohortResourceUsage, 1, "cohort", "cohort2")
+}
+
+func TestReportAndCleanupResourceFlavorNodes(t *testing.T) {
+	ReportResourceFlavorNodes("flavor", 3)
+	ReportResourceFlavorNodes("flavor2", 1)
+
+	expectFilteredMetricsCount(t, ResourceFlavorNodes, 1, "flavor", "flavor")
+	expectFilteredMetricsCount(t, ResourceFlavorNodes, 1, "flavor", "flavor2")
+
+	ClearResourceFlavorNodes("flavor")
+
+	expectFilteredMetricsCount(t, ResourceFlavorNodes, 0, "flavor", "flavor")
+	expectFilteredMetricsCount(t, ResourceFlavorNodes, 1, "flavor", "flavor2")
+}
+
 func TestReportAndCleanupClusterQueueEvictedNumber(t *testing.T) {
 	ReportEvictedWorkloads("cluster_queue1", "Preempted")
 	ReportEvictedWorkloads("cluster_queue1", "Evicted")
@@ -173,3 +204,40 @@ func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 0, "preempting_cluster_queue", "cluster_queue1")
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
+
+func TestAdmissionWaitTimeBuckets(t *testing.T) {
+	waitTimes := []time.Duration{
+		500 * time.Millisecond,
+		3 * time.Second,
+		30 * time.Second,
+		10 * time.Minute,
+		5 * time.Hour,
+	}
+	for _, waitTime := range waitTimes {
+		AdmittedWorkload("cluster_queue1", "high", waitTime)
+	}
+	AdmittedWorkload("cluster_queue1", "low", time.Minute)
+
+	m := &dto.Metric{}
+	if err := admissionWaitTime.WithLabelValues("cluster_queue1", "high").(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Failed to read the metric: %v", err)
+	}
+	gotCounts := make(map[float64]uint64, len(m.Histogram.Bucket))
+	for _, b := range m.Histogram.Bucket {
+		gotCounts[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	wantCounts := map[float64]uint64{
+		1: 1, 2.5: 1, 5: 2, 10: 2, 20: 2, 40: 3, 80: 3, 160: 3, 320: 3, 640: 4,
+		1280: 4, 2560: 4, 5120: 4, 10240: 4, 20480: 5, 40960: 5,
+	}
+	if diff := cmp.Diff(wantCounts, gotCounts); diff != "" {
+		t.Errorf("Unexpected bucket counts (-want,+got):\n%s", diff)
+	}
+	if got := m.Histogram.GetSampleCount(); got != uint64(len(waitTimes)) {
+		t.Errorf("Unexpected sample count, want %d, got %d", len(waitTimes), got)
+	}
+
+	expectFilteredMetricsCount(t, admissionWaitTime, 2, "cluster_queue", "cluster_queue1")
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, admissionWaitTime, 0, "cluster_queue", "cluster_queue1")
+}
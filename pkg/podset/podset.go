@@ -78,7 +78,7 @@ func FromAssignment(ctx context.Context, client client.Client, assignment *kueue
 			return info, err
 		}
 		info.NodeSelector = utilmaps.MergeKeepFirst(info.NodeSelector, flv.Spec.NodeLabels)
-		info.Tolerations = append(info.Tolerations, flv.Spec.Tolerations...)
+		info.Tolerations = appendTolerations(info.Tolerations, flv.Spec.Tolerations...)
 
 		processedFlvs.Insert(flvRef)
 	}
@@ -122,12 +122,7 @@ func (podSetInfo *PodSetInfo) Merge(o PodSetInfo) error {
 	podSetInfo.Labels = utilmaps.MergeKeepFirst(podSetInfo.Labels, o.Labels)
 	podSetInfo.NodeSelector = utilmaps.MergeKeepFirst(podSetInfo.NodeSelector, o.NodeSelector)
 
-	// make sure we don't duplicate tolerations
-	for _, t := range o.Tolerations {
-		if slices.Index(podSetInfo.Tolerations, t) == -1 {
-			podSetInfo.Tolerations = append(podSetInfo.Tolerations, t)
-		}
-	}
+	podSetInfo.Tolerations = appendTolerations(podSetInfo.Tolerations, o.Tolerations...)
 	// make sure we don't duplicate schedulingGates
 	for _, t := range o.SchedulingGates {
 		if slices.Index(podSetInfo.SchedulingGates, t) == -1 {
@@ -137,6 +132,20 @@ func (podSetInfo *PodSetInfo) Merge(o PodSetInfo) error {
 	return nil
 }
 
+// appendTolerations appends the tolerations which are not already present,
+// comparing them by key, operator, value and effect, so that we don't
+// duplicate tolerations.
+func appendTolerations(tolerations []corev1.Toleration, others ...corev1.Toleration) []corev1.Toleration {
+	for _, o := range others {
+		if !slices.ContainsFunc(tolerations, func(t corev1.Toleration) bool {
+			return t.Key == o.Key && t.Operator == o.Operator && t.Value == o.Value && t.Effect == o.Effect
+		}) {
+			tolerations = append(tolerations, o)
+		}
+	}
+	return tolerations
+}
+
 // AddOrUpdateLabel adds or updates the label identified by k with value v
 // allocating a new Labels nap if nil
 func (podSetInfo *PodSetInfo) AddOrUpdateLabel(k, v string) {
@@ -64,6 +64,11 @@ func TestFromAssignment(t *testing.T) {
 		Toleration(*toleration3.DeepCopy()).
 		Obj()
 
+	flavor3 := utiltesting.MakeResourceFlavor("flavor3").
+		Toleration(*toleration1.DeepCopy()).
+		Toleration(*toleration3.DeepCopy()).
+		Obj()
+
 	cases := map[string]struct {
 		enableTopologyAwareScheduling bool
 
@@ -137,6 +142,27 @@ func TestFromAssignment(t *testing.T) {
 				Tolerations: []corev1.Toleration{*toleration1.DeepCopy(), *toleration2.DeepCopy()},
 			},
 		},
+		"flavors with overlapping tolerations": {
+			assignment: &kueue.PodSetAssignment{
+				Name: "name",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU:    kueue.ResourceFlavorReference(flavor1.Name),
+					corev1.ResourceMemory: kueue.ResourceFlavorReference(flavor3.Name),
+				},
+				Count: ptr.To[int32](2),
+			},
+			defaultCount: 4,
+			flavors:      []kueue.ResourceFlavor{*flavor1.DeepCopy(), *flavor3.DeepCopy()},
+			wantInfo: PodSetInfo{
+				Name:  "name",
+				Count: 2,
+				NodeSelector: map[string]string{
+					"f1l1": "f1v1",
+					"f1l2": "f1v2",
+				},
+				Tolerations: []corev1.Toleration{*toleration1.DeepCopy(), *toleration2.DeepCopy(), *toleration3.DeepCopy()},
+			},
+		},
 		"flavor not found": {
 			assignment: &kueue.PodSetAssignment{
 				Name: "name",
@@ -352,6 +378,53 @@ func TestMergeRestore(t *testing.T) {
 				Obj(),
 			wantRestoreChanges: true,
 		},
+		"don't duplicate overlapping tolerations": {
+			podSet: utiltesting.MakePodSet("", 1).
+				Toleration(corev1.Toleration{
+					Key:               "t0",
+					Operator:          corev1.TolerationOpEqual,
+					Value:             "t0v",
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To[int64](30),
+				}).
+				Obj(),
+			info: PodSetInfo{
+				Tolerations: []corev1.Toleration{
+					{
+						Key:               "t0",
+						Operator:          corev1.TolerationOpEqual,
+						Value:             "t0v",
+						Effect:            corev1.TaintEffectNoExecute,
+						TolerationSeconds: ptr.To[int64](30),
+					},
+					{
+						Key:      "t1",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+					{
+						Key:      "t1",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+			},
+			wantPodSet: utiltesting.MakePodSet("", 1).
+				Toleration(corev1.Toleration{
+					Key:               "t0",
+					Operator:          corev1.TolerationOpEqual,
+					Value:             "t0v",
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To[int64](30),
+				}).
+				Toleration(corev1.Toleration{
+					Key:      "t1",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}).
+				Obj(),
+			wantRestoreChanges: true,
+		},
 		"conflicting label": {
 			podSet: basePodSet.DeepCopy(),
 			info: PodSetInfo{
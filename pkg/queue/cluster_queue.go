@@ -92,9 +92,9 @@ func workloadKey(i *workload.Info) string {
 	return workload.Key(i.Obj)
 }
 
-func newClusterQueue(ctx context.Context, client client.Client, cq *kueue.ClusterQueue, wo workload.Ordering, afsConfig *config.AdmissionFairSharing) (*ClusterQueue, error) {
+func newClusterQueue(ctx context.Context, client client.Client, cq *kueue.ClusterQueue, wo workload.Ordering, customOrdering OrderingFactory, afsConfig *config.AdmissionFairSharing) (*ClusterQueue, error) {
 	enableAdmissionFs, fsResWeights := afsResourceWeights(cq, afsConfig)
-	cqImpl := newClusterQueueImpl(ctx, client, wo, realClock, fsResWeights, enableAdmissionFs)
+	cqImpl := newClusterQueueImpl(ctx, client, wo, customOrdering, realClock, fsResWeights, enableAdmissionFs)
 	err := cqImpl.Update(cq)
 	if err != nil {
 		return nil, err
@@ -111,8 +111,11 @@ func afsResourceWeights(cq *kueue.ClusterQueue, afsConfig *config.AdmissionFairS
 	return enableAdmissionFs, fsResWeights
 }
 
-func newClusterQueueImpl(ctx context.Context, client client.Client, wo workload.Ordering, clock clock.Clock, fsResWeights map[corev1.ResourceName]float64, enableAdmissionFs bool) *ClusterQueue {
+func newClusterQueueImpl(ctx context.Context, client client.Client, wo workload.Ordering, customOrdering OrderingFactory, clock clock.Clock, fsResWeights map[corev1.ResourceName]float64, enableAdmissionFs bool) *ClusterQueue {
 	lessFunc := queueOrderingFunc(ctx, client, wo, fsResWeights, enableAdmissionFs)
+	if customOrdering != nil {
+		lessFunc = customOrdering(lessFunc)
+	}
 	return &ClusterQueue{
 		heap:                   *heap.New(workloadKey, lessFunc),
 		inadmissibleWorkloads:  make(map[string]*workload.Info),
@@ -344,6 +347,14 @@ func (c *ClusterQueue) Pop() *workload.Info {
 	return c.inflight
 }
 
+// Peek returns the head of the queue without removing it. It returns nil if
+// the queue is empty.
+func (c *ClusterQueue) Peek() *workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.heap.Peek()
+}
+
 // Dump produces a dump of the current workloads in the heap of
 // this ClusterQueue. It returns false if the queue is empty,
 // otherwise returns true.
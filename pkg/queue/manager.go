@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -48,6 +49,7 @@ type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	workloadInfoOptions         []workload.InfoOption
 	admissionFairSharing        *config.AdmissionFairSharing
+	ordering                    string
 }
 
 // Option configures the manager.
@@ -74,6 +76,15 @@ func WithPodsReadyRequeuingTimestamp(ts config.RequeuingTimestamp) Option {
 	}
 }
 
+// WithOrdering sets the name of the custom ordering of the pending workloads,
+// registered with RegisterOrdering. When empty or not registered, the built-in
+// ordering is used.
+func WithOrdering(name string) Option {
+	return func(o *options) {
+		o.ordering = name
+	}
+}
+
 // WithExcludedResourcePrefixes sets the list of excluded resource prefixes
 func WithExcludedResourcePrefixes(excludedPrefixes []string) Option {
 	return func(o *options) {
@@ -104,8 +115,13 @@ type Manager struct {
 	snapshots      map[kueue.ClusterQueueReference][]kueue.ClusterQueuePendingWorkload
 
 	workloadOrdering workload.Ordering
+	// customOrdering builds the custom ordering of the pending workloads, if any.
+	customOrdering OrderingFactory
 
 	workloadInfoOptions []workload.InfoOption
+	// baseWorkloadInfoOptions are the workload.InfoOptions the manager
+	// was created with, before any update of the resource transformations.
+	baseWorkloadInfoOptions []workload.InfoOption
 
 	hm hierarchy.Manager[*ClusterQueue, *cohort]
 
@@ -128,12 +144,16 @@ func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Ma
 		workloadOrdering: workload.Ordering{
 			PodsReadyRequeuingTimestamp: options.podsReadyRequeuingTimestamp,
 		},
-		workloadInfoOptions: options.workloadInfoOptions,
-		hm:                  hierarchy.NewManager[*ClusterQueue, *cohort](newCohort),
+		workloadInfoOptions:     options.workloadInfoOptions,
+		baseWorkloadInfoOptions: options.workloadInfoOptions,
+		hm:                      hierarchy.NewManager[*ClusterQueue, *cohort](newCohort),
 
 		topologyUpdateWatchers:     make([]TopologyUpdateWatcher, 0),
 		admissionFairSharingConfig: options.admissionFairSharing,
 	}
+	if options.ordering != "" {
+		m.customOrdering, _ = getOrdering(options.ordering)
+	}
 	m.cond.L = &m.RWMutex
 	return m
 }
@@ -174,7 +194,7 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 		return errClusterQueueAlreadyExists
 	}
 
-	cqImpl, err := newClusterQueue(ctx, m.client, cq, m.workloadOrdering, m.admissionFairSharingConfig)
+	cqImpl, err := newClusterQueue(ctx, m.client, cq, m.workloadOrdering, m.customOrdering, m.admissionFairSharingConfig)
 	if err != nil {
 		return err
 	}
@@ -537,6 +557,31 @@ func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.S
 	}
 }
 
+// UpdateResourceTransformations replaces the resource transformations the
+// manager was created with by the given ones, and recomputes the
+// requests of all the pending workloads. The inadmissible workloads are moved
+// back to the heap, as they might fit with their new requests.
+func (m *Manager) UpdateResourceTransformations(ctx context.Context, transforms []config.ResourceTransformation) {
+	m.Lock()
+	defer m.Unlock()
+	m.workloadInfoOptions = append(slices.Clone(m.baseWorkloadInfoOptions), workload.WithResourceTransformations(transforms))
+
+	for _, q := range m.localQueues {
+		cq := m.hm.ClusterQueue(q.ClusterQueue)
+		for _, info := range q.items {
+			wInfo := workload.NewInfo(info.Obj, m.workloadInfoOptions...)
+			q.AddOrUpdate(wInfo)
+			if cq != nil {
+				cq.PushOrUpdate(wInfo)
+			}
+		}
+	}
+	for _, cq := range m.hm.ClusterQueues() {
+		cq.QueueInadmissibleWorkloads(ctx, m.client)
+	}
+	m.Broadcast()
+}
+
 // requeueWorkloadsCQ moves all workloads in the same
 // cohort with this ClusterQueue from inadmissibleWorkloads to heap. If the
 // cohort of this ClusterQueue is empty, it just moves all workloads in this
@@ -653,6 +698,28 @@ func (m *Manager) heads() []workload.Info {
 	return workloads
 }
 
+// PeekHeads returns the heads of the queues, along with their associated
+// ClusterQueue, without removing them from the queues. Unlike Heads, it
+// doesn't block when the queues are empty.
+func (m *Manager) PeekHeads() []workload.Info {
+	m.RLock()
+	defer m.RUnlock()
+	var workloads []workload.Info
+	for cqName, cq := range m.hm.ClusterQueues() {
+		if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
+			continue
+		}
+		wl := cq.Peek()
+		if wl == nil {
+			continue
+		}
+		wlCopy := *wl
+		wlCopy.ClusterQueue = cqName
+		workloads = append(workloads, wlCopy)
+	}
+	return workloads
+}
+
 func (m *Manager) Broadcast() {
 	m.cond.Broadcast()
 }
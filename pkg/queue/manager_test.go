@@ -26,13 +26,19 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -213,6 +219,89 @@ func TestUpdateClusterQueue(t *testing.T) {
 	}
 }
 
+func TestUpdateResourceTransformations(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ConfigurableResourceTransformations, true)
+	cq := utiltesting.MakeClusterQueue("cq").Obj()
+	lq := utiltesting.MakeLocalQueue("foo", defaultNamespace).ClusterQueue("cq").Obj()
+	now := time.Now()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", defaultNamespace).Queue("foo").Creation(now).Request("example.com/gpu", "1").Obj(),
+		utiltesting.MakeWorkload("b", defaultNamespace).Queue("foo").Creation(now.Add(time.Second)).Request("example.com/gpu", "2").Obj(),
+	}
+	transformation := func(credits string) []config.ResourceTransformation {
+		return []config.ResourceTransformation{{
+			Input:    "example.com/gpu",
+			Strategy: ptr.To(config.Replace),
+			Outputs:  corev1.ResourceList{"example.com/credits": resource.MustParse(credits)},
+		}}
+	}
+	// Setup.
+	ctx := t.Context()
+	cl := utiltesting.NewFakeClient(utiltesting.MakeNamespace(defaultNamespace))
+	manager := NewManager(cl, nil, WithResourceTransformations(transformation("2")))
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+	}
+	if err := manager.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Failed adding queue %s: %v", lq.Name, err)
+	}
+	if err := cl.Create(ctx, workloads[0]); err != nil {
+		t.Fatalf("Failed adding workload to client: %v", err)
+	}
+	if err := manager.AddOrUpdateWorkload(workloads[0]); err != nil {
+		t.Fatalf("Failed adding workload %s: %v", workloads[0].Name, err)
+	}
+	// Increase the popCycle to ensure that the second workload will be added as inadmissible.
+	manager.getClusterQueue("cq").popCycle++
+	if err := cl.Create(ctx, workloads[1]); err != nil {
+		t.Fatalf("Failed adding workload to client: %v", err)
+	}
+	manager.RequeueWorkload(ctx, workload.NewInfo(workloads[1], manager.workloadInfoOptions...), RequeueReasonGeneric)
+
+	gotRequests := func() map[string]resources.Requests {
+		result := make(map[string]resources.Requests)
+		for _, info := range manager.PendingWorkloadsInfo("cq") {
+			result[info.Obj.Name] = info.TotalRequests[0].Requests
+		}
+		return result
+	}
+	wantRequests := map[string]resources.Requests{
+		"a": {"example.com/credits": 2},
+		"b": {"example.com/credits": 4},
+	}
+	if diff := cmp.Diff(wantRequests, gotRequests()); diff != "" {
+		t.Errorf("Unexpected requests before the update (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[kueue.ClusterQueueReference][]string{"cq": {"default/b"}}, manager.DumpInadmissible()); diff != "" {
+		t.Errorf("Unexpected inadmissible workloads before the update (-want,+got):\n%s", diff)
+	}
+
+	manager.UpdateResourceTransformations(ctx, transformation("3"))
+
+	wantRequests = map[string]resources.Requests{
+		"a": {"example.com/credits": 3},
+		"b": {"example.com/credits": 6},
+	}
+	if diff := cmp.Diff(wantRequests, gotRequests()); diff != "" {
+		t.Errorf("Unexpected requests after the update (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[kueue.ClusterQueueReference][]string(nil), manager.DumpInadmissible()); diff != "" {
+		t.Errorf("Unexpected inadmissible workloads after the update (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[kueue.ClusterQueueReference][]string{"cq": {"default/a", "default/b"}}, manager.Dump(), cmpDump...); diff != "" {
+		t.Errorf("Unexpected active workloads after the update (-want,+got):\n%s", diff)
+	}
+
+	// The workloads added after the update use the new transformations.
+	wl := utiltesting.MakeWorkload("c", defaultNamespace).Queue("foo").Request("example.com/gpu", "1").Obj()
+	if err := manager.AddOrUpdateWorkload(wl); err != nil {
+		t.Fatalf("Failed adding workload %s: %v", wl.Name, err)
+	}
+	if diff := cmp.Diff(resources.Requests{"example.com/credits": 3}, gotRequests()["c"]); diff != "" {
+		t.Errorf("Unexpected requests of the workload added after the update (-want,+got):\n%s", diff)
+	}
+}
+
 func TestRequeueWorkloadsCohortCycle(t *testing.T) {
 	cohorts := []*kueuealpha.Cohort{
 		utiltesting.MakeCohort("cohort-a").Parent("cohort-b").Obj(),
@@ -926,6 +1015,14 @@ func TestHeads(t *testing.T) {
 				}
 			}
 
+			peekedNames := sets.New[string]()
+			for _, h := range manager.PeekHeads() {
+				peekedNames.Insert(h.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, peekedNames); diff != "" {
+				t.Errorf("PeekHeads returned wrong heads (-want,+got):\n%s", diff)
+			}
+
 			wlNames := sets.New[string]()
 			heads := manager.Heads(ctx)
 			for _, h := range heads {
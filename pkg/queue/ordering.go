@@ -0,0 +1,82 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	errDuplicateOrderingName = errors.New("duplicate ordering name")
+	errEmptyOrderingName     = errors.New("empty ordering name")
+	errNilOrderingFactory    = errors.New("nil ordering factory")
+)
+
+// OrderingFunc reports whether the pending workload a should be popped
+// from the ClusterQueue before the pending workload b.
+type OrderingFunc func(a, b *workload.Info) bool
+
+// OrderingFactory builds a custom ordering of the pending workloads. The
+// built-in ordering, by priority and queue order timestamp, is passed so that
+// the custom ordering can use it to break ties.
+type OrderingFactory func(builtIn OrderingFunc) OrderingFunc
+
+type orderingRegistry struct {
+	sync.RWMutex
+	factories map[string]OrderingFactory
+}
+
+var orderings orderingRegistry
+
+// RegisterOrdering registers a custom ordering of the pending workloads, which
+// can be selected by name with the scheduler.workloadOrdering field of the
+// configuration. It's meant to be called at init time by custom builds of Kueue.
+func RegisterOrdering(name string, factory OrderingFactory) error {
+	if name == "" {
+		return errEmptyOrderingName
+	}
+	if factory == nil {
+		return fmt.Errorf("%w for %q", errNilOrderingFactory, name)
+	}
+	orderings.Lock()
+	defer orderings.Unlock()
+	if orderings.factories == nil {
+		orderings.factories = make(map[string]OrderingFactory)
+	}
+	if _, exists := orderings.factories[name]; exists {
+		return fmt.Errorf("%w %q", errDuplicateOrderingName, name)
+	}
+	orderings.factories[name] = factory
+	return nil
+}
+
+// OrderingRegistered returns whether a custom ordering is registered with the name.
+func OrderingRegistered(name string) bool {
+	_, found := getOrdering(name)
+	return found
+}
+
+func getOrdering(name string) (OrderingFactory, bool) {
+	orderings.RLock()
+	defer orderings.RUnlock()
+	factory, found := orderings.factories[name]
+	return factory, found
+}
@@ -0,0 +1,121 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const teamRankLabel = "example.com/team-rank"
+
+// byTeamRank orders the pending workloads by the value of the team rank
+// label, and falls back to the built-in ordering for the same rank.
+func byTeamRank(builtIn OrderingFunc) OrderingFunc {
+	return func(a, b *workload.Info) bool {
+		rankA := a.Obj.Labels[teamRankLabel]
+		rankB := b.Obj.Labels[teamRankLabel]
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		return builtIn(a, b)
+	}
+}
+
+func TestRegisterOrdering(t *testing.T) {
+	if err := RegisterOrdering("test-register", byTeamRank); err != nil {
+		t.Fatalf("Failed registering the ordering: %v", err)
+	}
+	if !OrderingRegistered("test-register") {
+		t.Errorf("Ordering %q is not registered", "test-register")
+	}
+	if OrderingRegistered("test-unknown") {
+		t.Errorf("Ordering %q is unexpectedly registered", "test-unknown")
+	}
+	if err := RegisterOrdering("test-register", byTeamRank); !errors.Is(err, errDuplicateOrderingName) {
+		t.Errorf("Unexpected error registering a duplicate ordering: %v", err)
+	}
+	if err := RegisterOrdering("", byTeamRank); !errors.Is(err, errEmptyOrderingName) {
+		t.Errorf("Unexpected error registering an ordering without name: %v", err)
+	}
+	if err := RegisterOrdering("test-nil", nil); !errors.Is(err, errNilOrderingFactory) {
+		t.Errorf("Unexpected error registering a nil ordering: %v", err)
+	}
+}
+
+func TestCustomOrdering(t *testing.T) {
+	if err := RegisterOrdering("test-team-rank", byTeamRank); err != nil {
+		t.Fatalf("Failed registering the ordering: %v", err)
+	}
+	now := time.Now().Truncate(time.Second)
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "").Queue("foo").Creation(now).
+			Priority(10).Label(teamRankLabel, "2").Obj(),
+		utiltesting.MakeWorkload("b", "").Queue("foo").Creation(now.Add(time.Second)).
+			Label(teamRankLabel, "1").Obj(),
+		utiltesting.MakeWorkload("c", "").Queue("foo").Creation(now).
+			Label(teamRankLabel, "1").Obj(),
+	}
+
+	cases := map[string]struct {
+		options   []Option
+		wantOrder []string
+	}{
+		"built-in ordering": {
+			wantOrder: []string{"a", "c", "b"},
+		},
+		"custom ordering": {
+			options:   []Option{WithOrdering("test-team-rank")},
+			wantOrder: []string{"c", "b", "a"},
+		},
+		"unregistered ordering falls back to the built-in ordering": {
+			options:   []Option{WithOrdering("test-unknown")},
+			wantOrder: []string{"a", "c", "b"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			manager := NewManager(utiltesting.NewFakeClient(), nil, tc.options...)
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding clusterQueue: %v", err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding queue: %v", err)
+			}
+			for _, w := range workloads {
+				if err := manager.AddOrUpdateWorkload(w.DeepCopy()); err != nil {
+					t.Fatalf("Failed adding workload %s: %v", w.Name, err)
+				}
+			}
+			var gotOrder []string
+			for _, info := range manager.PendingWorkloadsInfo("cq") {
+				gotOrder = append(gotOrder, info.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order of the pending workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
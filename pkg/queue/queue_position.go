@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// queuePositionUpdateInterval is the period of the updates of the positions
+// of the pending workloads in their status.
+const queuePositionUpdateInterval = 5 * time.Second
+
+// QueuePositionUpdater reports the position of the pending workloads among
+// the pending workloads of their ClusterQueue, in the status of the workloads.
+// Only the positions which changed since the previous update are patched.
+type QueuePositionUpdater struct {
+	manager  *Manager
+	client   client.Client
+	interval time.Duration
+
+	// positions are the last reported positions of the pending workloads,
+	// by ClusterQueue.
+	positions map[kueue.ClusterQueueReference]map[types.NamespacedName]int32
+}
+
+func NewQueuePositionUpdater(manager *Manager, c client.Client) *QueuePositionUpdater {
+	return &QueuePositionUpdater{
+		manager:   manager,
+		client:    c,
+		interval:  queuePositionUpdateInterval,
+		positions: make(map[kueue.ClusterQueueReference]map[types.NamespacedName]int32),
+	}
+}
+
+// Start implements manager.Runnable.
+func (u *QueuePositionUpdater) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, u.update, u.interval)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (u *QueuePositionUpdater) NeedLeaderElection() bool {
+	return true
+}
+
+func (u *QueuePositionUpdater) update(ctx context.Context) {
+	pending := make(map[kueue.ClusterQueueReference][]*workload.Info)
+	allPending := sets.New[types.NamespacedName]()
+	for _, cqName := range u.manager.GetClusterQueueNames() {
+		pending[cqName] = u.manager.PendingWorkloadsInfo(cqName)
+		for _, info := range pending[cqName] {
+			allPending.Insert(client.ObjectKeyFromObject(info.Obj))
+		}
+	}
+	for cqName, prevPositions := range u.positions {
+		if _, found := pending[cqName]; !found {
+			u.clearPositions(ctx, prevPositions, allPending)
+			delete(u.positions, cqName)
+		}
+	}
+	for cqName, infos := range pending {
+		u.updateClusterQueue(ctx, cqName, infos, allPending)
+	}
+}
+
+// updateClusterQueue reports the positions of the pending workloads of the
+// ClusterQueue, and clears the positions of the workloads which are no longer
+// pending.
+func (u *QueuePositionUpdater) updateClusterQueue(ctx context.Context, cqName kueue.ClusterQueueReference, pending []*workload.Info, allPending sets.Set[types.NamespacedName]) {
+	log := ctrl.LoggerFrom(ctx).WithValues("clusterQueue", klog.KRef("", string(cqName)))
+	prevPositions := u.positions[cqName]
+	positions := make(map[types.NamespacedName]int32, len(pending))
+	for i, info := range pending {
+		key := client.ObjectKeyFromObject(info.Obj)
+		position := int32(i)
+		positions[key] = position
+		if prev, found := prevPositions[key]; found && prev == position {
+			continue
+		}
+		if ptr.Equal(info.Obj.Status.QueuePosition, &position) {
+			continue
+		}
+		if err := patchQueuePosition(ctx, u.client, key, &position); err != nil {
+			log.V(2).Info("Failed to update the queue position of the workload", "workload", key, "error", err)
+			// Retry in the next update.
+			positions[key] = -1
+		}
+	}
+	u.clearPositions(ctx, prevPositions, allPending)
+	u.positions[cqName] = positions
+}
+
+// clearPositions clears the reported positions of the workloads which are no
+// longer pending.
+func (u *QueuePositionUpdater) clearPositions(ctx context.Context, positions map[types.NamespacedName]int32, allPending sets.Set[types.NamespacedName]) {
+	for key := range positions {
+		if allPending.Has(key) {
+			continue
+		}
+		if err := patchQueuePosition(ctx, u.client, key, nil); client.IgnoreNotFound(err) != nil {
+			ctrl.LoggerFrom(ctx).V(2).Info("Failed to clear the queue position of the workload", "workload", key, "error", err)
+		}
+	}
+}
+
+// patchQueuePosition sets the queue position in the status of the workload,
+// or clears it when position is nil.
+func patchQueuePosition(ctx context.Context, c client.Client, key types.NamespacedName, position *int32) error {
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{"queuePosition": position},
+	})
+	if err != nil {
+		return err
+	}
+	wl := &kueue.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	return c.Status().Patch(ctx, wl, client.RawPatch(types.MergePatchType, patch))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestQueuePositionUpdater(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "default").Queue("foo").Creation(now).Obj(),
+		utiltesting.MakeWorkload("b", "default").Queue("foo").Creation(now.Add(time.Second)).Obj(),
+		utiltesting.MakeWorkload("c", "default").Queue("foo").Creation(now.Add(2 * time.Second)).Obj(),
+		utiltesting.MakeWorkload("other", "default").Queue("bar").Creation(now).Obj(),
+	}
+	highPriority := utiltesting.MakeWorkload("d", "default").Queue("foo").Priority(100).Creation(now.Add(3 * time.Second)).Obj()
+
+	builder := utiltesting.NewClientBuilder().WithStatusSubresource(&kueue.Workload{})
+	for _, wl := range workloads {
+		builder = builder.WithObjects(wl.DeepCopy())
+	}
+	cl := builder.Build()
+
+	manager := NewManager(cl, nil)
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq").Obj(),
+		utiltesting.MakeClusterQueue("other-cq").Obj(),
+	} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+		}
+	}
+	for _, lq := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("foo", "default").ClusterQueue("cq").Obj(),
+		utiltesting.MakeLocalQueue("bar", "default").ClusterQueue("other-cq").Obj(),
+	} {
+		if err := manager.AddLocalQueue(ctx, lq); err != nil {
+			t.Fatalf("Failed adding LocalQueue %s: %v", lq.Name, err)
+		}
+	}
+	updater := NewQueuePositionUpdater(manager, cl)
+
+	checkPositions := func(t *testing.T, want map[string]*int32) {
+		t.Helper()
+		var wlList kueue.WorkloadList
+		if err := cl.List(ctx, &wlList); err != nil {
+			t.Fatalf("Failed listing Workloads: %v", err)
+		}
+		got := make(map[string]*int32, len(wlList.Items))
+		for _, wl := range wlList.Items {
+			got[wl.Name] = wl.Status.QueuePosition
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected queue positions (-want,+got):\n%s", diff)
+		}
+	}
+
+	updater.update(ctx)
+	checkPositions(t, map[string]*int32{
+		"a":     ptr.To[int32](0),
+		"b":     ptr.To[int32](1),
+		"c":     ptr.To[int32](2),
+		"other": ptr.To[int32](0),
+	})
+
+	// The head of the queue is admitted.
+	manager.DeleteWorkload(workloads[0])
+	updater.update(ctx)
+	checkPositions(t, map[string]*int32{
+		"a":     nil,
+		"b":     ptr.To[int32](0),
+		"c":     ptr.To[int32](1),
+		"other": ptr.To[int32](0),
+	})
+
+	// A workload with a higher priority is queued ahead of the others.
+	if err := cl.Create(ctx, highPriority.DeepCopy()); err != nil {
+		t.Fatalf("Failed creating Workload %s: %v", highPriority.Name, err)
+	}
+	if err := manager.AddOrUpdateWorkload(highPriority); err != nil {
+		t.Fatalf("Failed adding Workload %s: %v", highPriority.Name, err)
+	}
+	updater.update(ctx)
+	checkPositions(t, map[string]*int32{
+		"a":     nil,
+		"b":     ptr.To[int32](1),
+		"c":     ptr.To[int32](2),
+		"d":     ptr.To[int32](0),
+		"other": ptr.To[int32](0),
+	})
+
+	// The ClusterQueue is deleted.
+	manager.DeleteClusterQueue(utiltesting.MakeClusterQueue("cq").Obj())
+	updater.update(ctx)
+	checkPositions(t, map[string]*int32{
+		"a":     nil,
+		"b":     nil,
+		"c":     nil,
+		"d":     nil,
+		"other": ptr.To[int32](0),
+	})
+}
@@ -24,12 +24,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/pkg/features"
 )
 
 // The following resources calculations are inspired on
 // https://github.com/kubernetes/kubernetes/blob/master/pkg/scheduler/framework/types.go
 
-// Requests maps ResourceName to flavor to value; for CPU it is tracked in MilliCPU.
+// Requests maps ResourceName to flavor to value; for CPU it is tracked in MilliCPU,
+// and so are the extended resources when the ExtendedResourceMilliUnits feature
+// is enabled.
 type Requests map[corev1.ResourceName]int64
 
 func NewRequests(rl corev1.ResourceList) Requests {
@@ -89,18 +93,27 @@ func (r Requests) ToResourceList() corev1.ResourceList {
 }
 
 // ResourceValue returns the integer value for the resource name.
-// It's milli-units for CPU and absolute units for everything else.
+// It's milli-units for CPU, and for extended resources when the
+// ExtendedResourceMilliUnits feature is enabled, so that fractional
+// quantities, like shares of a GPU, are tracked exactly, and absolute units
+// for everything else.
 func ResourceValue(name corev1.ResourceName, q resource.Quantity) int64 {
-	if name == corev1.ResourceCPU {
+	if tracksMilliValue(name) {
+		// Saturate, rather than wrap around, for the quantities whose
+		// milli-value doesn't fit in an int64.
+		if q.CmpInt64(math.MaxInt64/1000) > 0 {
+			return math.MaxInt64
+		}
 		return q.MilliValue()
 	}
 	return q.Value()
 }
 
 func ResourceQuantity(name corev1.ResourceName, v int64) resource.Quantity {
-	switch name {
-	case corev1.ResourceCPU:
+	if tracksMilliValue(name) {
 		return *resource.NewMilliQuantity(v, resource.DecimalSI)
+	}
+	switch name {
 	case corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
 		return *resource.NewQuantity(v, resource.BinarySI)
 	default:
@@ -111,6 +124,21 @@ func ResourceQuantity(name corev1.ResourceName, v int64) resource.Quantity {
 	}
 }
 
+// tracksMilliValue returns whether the values of the resource are tracked in
+// milli-units.
+func tracksMilliValue(name corev1.ResourceName) bool {
+	if name == corev1.ResourceCPU {
+		return true
+	}
+	return features.Enabled(features.ExtendedResourceMilliUnits) && isExtendedResourceName(name)
+}
+
+// isExtendedResourceName returns whether name is the fully-qualified name of
+// an extended resource, outside of the kubernetes.io domain.
+func isExtendedResourceName(name corev1.ResourceName) bool {
+	return strings.Contains(string(name), "/") && !strings.Contains(string(name), corev1.ResourceDefaultNamespacePrefix)
+}
+
 func ResourceQuantityString(name corev1.ResourceName, v int64) string {
 	rq := ResourceQuantity(name, v)
 	return rq.String()
@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kueue/pkg/features"
 )
 
 func TestCountIn(t *testing.T) {
@@ -121,3 +124,84 @@ func TestCountIn(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceValue(t *testing.T) {
+	cases := map[string]struct {
+		name                  corev1.ResourceName
+		quantity              string
+		enableMilliUnits      bool
+		wantValue             int64
+		wantQuantityRoundTrip string
+	}{
+		"cpu": {
+			name:                  corev1.ResourceCPU,
+			quantity:              "1500m",
+			wantValue:             1_500,
+			wantQuantityRoundTrip: "1500m",
+		},
+		"memory": {
+			name:                  corev1.ResourceMemory,
+			quantity:              "1Gi",
+			wantValue:             1024 * 1024 * 1024,
+			wantQuantityRoundTrip: "1Gi",
+		},
+		"extended resource, milli-units disabled": {
+			name:                  "nvidia.com/gpu",
+			quantity:              "2",
+			wantValue:             2,
+			wantQuantityRoundTrip: "2",
+		},
+		"fractional extended resource, milli-units disabled": {
+			name:                  "nvidia.com/gpu",
+			quantity:              "500m",
+			wantValue:             1,
+			wantQuantityRoundTrip: "1",
+		},
+		"extended resource, milli-units enabled": {
+			name:                  "nvidia.com/gpu",
+			quantity:              "2",
+			enableMilliUnits:      true,
+			wantValue:             2_000,
+			wantQuantityRoundTrip: "2",
+		},
+		"fractional extended resource, milli-units enabled": {
+			name:                  "nvidia.com/gpu",
+			quantity:              "500m",
+			enableMilliUnits:      true,
+			wantValue:             500,
+			wantQuantityRoundTrip: "500m",
+		},
+		"kubernetes.io resource, milli-units enabled": {
+			name:                  "kubernetes.io/batteries",
+			quantity:              "2",
+			enableMilliUnits:      true,
+			wantValue:             2,
+			wantQuantityRoundTrip: "2",
+		},
+		"cpu overflowing in milli-units": {
+			name:                  corev1.ResourceCPU,
+			quantity:              "10Ei",
+			wantValue:             math.MaxInt64,
+			wantQuantityRoundTrip: "9223372036854775807m",
+		},
+		"extended resource overflowing in milli-units": {
+			name:                  "nvidia.com/gpu",
+			quantity:              "9223372036854776",
+			enableMilliUnits:      true,
+			wantValue:             math.MaxInt64,
+			wantQuantityRoundTrip: "9223372036854775807m",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ExtendedResourceMilliUnits, tc.enableMilliUnits)
+			got := ResourceValue(tc.name, resource.MustParse(tc.quantity))
+			if got != tc.wantValue {
+				t.Errorf("Unexpected value, want=%d, got=%d", tc.wantValue, got)
+			}
+			if gotQuantity := ResourceQuantityString(tc.name, got); gotQuantity != tc.wantQuantityRoundTrip {
+				t.Errorf("Unexpected quantity, want=%s, got=%s", tc.wantQuantityRoundTrip, gotQuantity)
+			}
+		})
+	}
+}
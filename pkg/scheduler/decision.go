@@ -0,0 +1,149 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/util/api"
+)
+
+const (
+	// SchedulingDecisionAnnotation is the annotation of the SchedulingDecision
+	// events holding the scheduling decision for the workload, encoded in JSON.
+	SchedulingDecisionAnnotation = "kueue.x-k8s.io/scheduling-decision"
+
+	schedulingDecisionReason = "SchedulingDecision"
+)
+
+// schedulingDecision describes the flavors assigned to the podSets of a
+// workload, and the workloads it preempts.
+type schedulingDecision struct {
+	ClusterQueue  kueue.ClusterQueueReference `json:"clusterQueue"`
+	Mode          string                      `json:"mode"`
+	AdmissionPath kueue.AdmissionPath         `json:"admissionPath,omitempty"`
+	PodSets       []podSetDecision            `json:"podSets"`
+	Preemptions   []preemptionDecision        `json:"preemptions,omitempty"`
+}
+
+type podSetDecision struct {
+	Name      kueue.PodSetReference `json:"name"`
+	Count     int32                 `json:"count"`
+	Resources []resourceDecision    `json:"resources,omitempty"`
+}
+
+type resourceDecision struct {
+	Name     corev1.ResourceName           `json:"name"`
+	Flavor   kueue.ResourceFlavorReference `json:"flavor"`
+	Mode     string                        `json:"mode"`
+	Quantity resource.Quantity             `json:"quantity"`
+}
+
+type preemptionDecision struct {
+	Workload     klog.ObjectRef              `json:"workload"`
+	ClusterQueue kueue.ClusterQueueReference `json:"clusterQueue"`
+	Reason       string                      `json:"reason"`
+}
+
+func newSchedulingDecision(e *entry, path kueue.AdmissionPath, targets []*preemption.Target) *schedulingDecision {
+	d := &schedulingDecision{
+		ClusterQueue:  e.ClusterQueue,
+		Mode:          e.assignment.RepresentativeMode().String(),
+		AdmissionPath: path,
+		PodSets:       make([]podSetDecision, 0, len(e.assignment.PodSets)),
+	}
+	for _, psAssignment := range e.assignment.PodSets {
+		psDecision := podSetDecision{
+			Name:      psAssignment.Name,
+			Count:     psAssignment.Count,
+			Resources: make([]resourceDecision, 0, len(psAssignment.Flavors)),
+		}
+		for res, flvAssignment := range psAssignment.Flavors {
+			psDecision.Resources = append(psDecision.Resources, resourceDecision{
+				Name:     res,
+				Flavor:   flvAssignment.Name,
+				Mode:     flvAssignment.Mode.String(),
+				Quantity: psAssignment.Requests[res],
+			})
+		}
+		slices.SortFunc(psDecision.Resources, func(a, b resourceDecision) int {
+			return strings.Compare(string(a.Name), string(b.Name))
+		})
+		d.PodSets = append(d.PodSets, psDecision)
+	}
+	for _, t := range targets {
+		d.Preemptions = append(d.Preemptions, preemptionDecision{
+			Workload:     klog.KObj(t.WorkloadInfo.Obj),
+			ClusterQueue: t.WorkloadInfo.ClusterQueue,
+			Reason:       t.Reason,
+		})
+	}
+	return d
+}
+
+// String returns the human readable summary of the decision, used as the
+// message of the event.
+func (d *schedulingDecision) String() string {
+	var b strings.Builder
+	if len(d.Preemptions) > 0 {
+		fmt.Fprintf(&b, "Preempting %d workload(s) to reserve quota in ClusterQueue %s with flavors", len(d.Preemptions), d.ClusterQueue)
+	} else {
+		fmt.Fprintf(&b, "Quota reserved in ClusterQueue %s with flavors", d.ClusterQueue)
+	}
+	for i, ps := range d.PodSets {
+		if i > 0 {
+			b.WriteString(";")
+		}
+		fmt.Fprintf(&b, " %s:", ps.Name)
+		for j, res := range ps.Resources {
+			if j > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(&b, " %s=%s", res.Name, res.Flavor)
+		}
+	}
+	return b.String()
+}
+
+// recordSchedulingDecision records an event describing the scheduling
+// decision for the workload of the entry, if the SchedulingDecisionEvents
+// feature is enabled. The full decision is attached to the event in the
+// SchedulingDecisionAnnotation.
+func (s *Scheduler) recordSchedulingDecision(log logr.Logger, wl *kueue.Workload, e *entry, path kueue.AdmissionPath, targets []*preemption.Target) {
+	if !features.Enabled(features.SchedulingDecisionEvents) {
+		return
+	}
+	d := newSchedulingDecision(e, path, targets)
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		log.Error(err, "Failed to encode the scheduling decision")
+		return
+	}
+	annotations := map[string]string{SchedulingDecisionAnnotation: string(encoded)}
+	s.recorder.AnnotatedEventf(wl, annotations, corev1.EventTypeNormal, schedulingDecisionReason, "%s", api.TruncateEventMessage(d.String()))
+}
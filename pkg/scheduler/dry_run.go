@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"maps"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+// SchedulingPlan is the outcome of a dry-run scheduling cycle.
+type SchedulingPlan struct {
+	// Admissions are the workloads that would get quota reserved, in the
+	// order in which the scheduler would admit them.
+	Admissions []PlannedAdmission
+	// Preemptions are the preemptions the scheduler would issue.
+	Preemptions []PlannedPreemption
+}
+
+// PlannedAdmission is a workload that would get quota reserved.
+type PlannedAdmission struct {
+	Workload  client.ObjectKey
+	Admission kueue.Admission
+}
+
+// PlannedPreemption is a set of workloads that would be preempted to
+// accommodate the preemptor.
+type PlannedPreemption struct {
+	Preemptor    client.ObjectKey
+	ClusterQueue kueue.ClusterQueueReference
+	Targets      []PlannedPreemptionTarget
+}
+
+// PlannedPreemptionTarget is a workload that would be preempted.
+type PlannedPreemptionTarget struct {
+	Workload     client.ObjectKey
+	ClusterQueue kueue.ClusterQueueReference
+	Reason       string
+}
+
+// ScheduleDryRun computes the admissions and preemptions that a scheduling
+// cycle would perform for the current heads of the queues and the current
+// state of the cache. It doesn't pop the workloads from the queues, nor
+// does it update the cache or the API objects, nor report metrics.
+func (s *Scheduler) ScheduleDryRun(ctx context.Context) (*SchedulingPlan, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("dryRun", true)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	plan := &SchedulingPlan{}
+	headWorkloads := s.queues.PeekHeads()
+	if len(headWorkloads) == 0 {
+		return plan, nil
+	}
+
+	// The snapshot is a copy of the cache, so the usage added while
+	// processing the entries doesn't leak into the cache.
+	snapshot, err := s.cache.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.WithholdPendingScheduledQuotas(s.clock.Now())
+
+	// The state of the scheduling loop is copied, so that the dry run
+	// neither races with, nor changes, the scheduling loop.
+	s.stateLock.RLock()
+	deferredWorkloads := s.deferredWorkloads.Clone()
+	lastAdmissions := maps.Clone(s.lastAdmissions)
+	s.stateLock.RUnlock()
+
+	entries := s.nominate(ctx, headWorkloads, snapshot)
+	budget := newAdmissionBudget(s.maxAdmissionsPerCycle, deferredWorkloads, entries)
+	s.processEntries(ctx, snapshot, entries, budget, lastAdmissions, &planApplier{plan: plan})
+	return plan, nil
+}
+
+// planApplier records the decisions of a scheduling cycle in a plan, without
+// side effects.
+type planApplier struct {
+	plan *SchedulingPlan
+}
+
+func (a *planApplier) preempt(_ context.Context, e *entry) {
+	a.plan.Preemptions = append(a.plan.Preemptions, plannedPreemption(e))
+}
+
+func (a *planApplier) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
+	ctrl.LoggerFrom(ctx).V(3).Info("Workload would be admitted")
+	a.plan.Admissions = append(a.plan.Admissions, PlannedAdmission{
+		Workload:  client.ObjectKeyFromObject(e.Obj),
+		Admission: *e.admission(cq),
+	})
+	return nil
+}
+
+func plannedPreemption(e *entry) PlannedPreemption {
+	p := PlannedPreemption{
+		Preemptor:    client.ObjectKeyFromObject(e.Obj),
+		ClusterQueue: e.ClusterQueue,
+		Targets:      make([]PlannedPreemptionTarget, 0, len(e.preemptionTargets)),
+	}
+	for _, target := range e.preemptionTargets {
+		p.Targets = append(p.Targets, PlannedPreemptionTarget{
+			Workload:     client.ObjectKeyFromObject(target.WorkloadInfo.Obj),
+			ClusterQueue: target.WorkloadInfo.ClusterQueue,
+			Reason:       target.Reason,
+		})
+	}
+	return p
+}
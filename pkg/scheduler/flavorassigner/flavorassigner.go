@@ -19,9 +19,11 @@ package flavorassigner
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -35,9 +37,11 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption/classical"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -54,6 +58,16 @@ type Assignment struct {
 
 	// representativeMode is the cached representative mode for this assignment.
 	representativeMode *FlavorAssignmentMode
+
+	// preemptionAlternatives are the flavors, other than the assigned ones,
+	// in which the pod sets could fit by preempting workloads.
+	preemptionAlternatives []podSetFlavors
+}
+
+// podSetFlavors holds the flavors of one resource group for a pod set.
+type podSetFlavors struct {
+	podSet  int
+	flavors ResourceAssignment
 }
 
 // UpdateForTASResult updates the Assignment with the TAS result
@@ -77,9 +91,10 @@ func (a *Assignment) computeTASUsage() workload.TASUsage {
 				}
 				for _, domain := range psa.TopologyAssignment.Domains {
 					result[flv.Name] = append(result[flv.Name], workload.TopologyDomainRequests{
-						Values:            domain.Values,
-						SinglePodRequests: singlePodRequests.Clone(),
-						Count:             domain.Count,
+						Values:                 domain.Values,
+						SinglePodRequests:      singlePodRequests.Clone(),
+						Count:                  domain.Count,
+						NodeCapacityPercentage: psa.NodeCapacityPercentage,
 					})
 				}
 			}
@@ -141,6 +156,82 @@ func (a *Assignment) Message() string {
 	return builder.String()
 }
 
+// PreemptionAlternatives returns copies of the assignment in which the flavor
+// of one resource group of a pod set is replaced by another flavor of the
+// resource group in which the pod set could fit by preempting workloads.
+// The alternatives are listed in the order of the flavors in the ClusterQueue.
+func (a *Assignment) PreemptionAlternatives() []Assignment {
+	alternatives := make([]Assignment, 0, len(a.preemptionAlternatives))
+	for _, psFlavors := range a.preemptionAlternatives {
+		alternative := Assignment{
+			PodSets: make([]PodSetAssignment, 0, len(a.PodSets)),
+			Usage: workload.Usage{
+				Quota: make(resources.FlavorResourceQuantities),
+			},
+		}
+		for i, psAssignment := range a.PodSets {
+			if i == psFlavors.podSet {
+				psAssignment.Flavors = maps.Clone(psAssignment.Flavors)
+				maps.Copy(psAssignment.Flavors, psFlavors.flavors)
+			}
+			alternative.append(resources.NewRequests(psAssignment.Requests), &psAssignment)
+		}
+		alternative.LastState = a.LastState
+		alternatives = append(alternatives, alternative)
+	}
+	return alternatives
+}
+
+// WithoutBorrowing returns a copy of the assignment in which the flavors of
+// the resources requiring borrowing are assigned in the Preempt mode, so that
+// the pod sets fit within the nominal quota of the ClusterQueue by preempting
+// workloads, instead of borrowing.
+func (a *Assignment) WithoutBorrowing() Assignment {
+	result := Assignment{
+		PodSets: make([]PodSetAssignment, 0, len(a.PodSets)),
+		Usage: workload.Usage{
+			Quota: make(resources.FlavorResourceQuantities),
+		},
+	}
+	for _, psAssignment := range a.PodSets {
+		psAssignment.Flavors = maps.Clone(psAssignment.Flavors)
+		if psAssignment.Status != nil {
+			status := *psAssignment.Status
+			status.reasons = slices.Clone(status.reasons)
+			psAssignment.Status = &status
+		}
+		for res, flvAssignment := range psAssignment.Flavors {
+			if flvAssignment.borrow == 0 {
+				continue
+			}
+			psAssignment.Flavors[res] = &FlavorAssignment{
+				Name:           flvAssignment.Name,
+				Mode:           Preempt,
+				TriedFlavorIdx: flvAssignment.TriedFlavorIdx,
+			}
+			psAssignment.reason(fmt.Sprintf("preferring preemption over borrowing for %s in flavor %s", res, flvAssignment.Name))
+		}
+		result.append(resources.NewRequests(psAssignment.Requests), &psAssignment)
+	}
+	result.LastState = a.LastState
+	return result
+}
+
+// StartTime returns the time before which the workload can't be admitted,
+// because some of its resources only fit in the quota scheduled to become
+// available at that time, or nil if the workload can be admitted immediately.
+func (a *Assignment) StartTime() *metav1.Time {
+	var result *metav1.Time
+	for _, ps := range a.PodSets {
+		for _, flvAssignment := range ps.Flavors {
+			if flvAssignment.startTime != nil && (result == nil || flvAssignment.startTime.After(result.Time)) {
+				result = ptr.To(metav1.NewTime(*flvAssignment.startTime))
+			}
+		}
+	}
+	return result
+}
+
 func (a *Assignment) ToAPI() []kueue.PodSetAssignment {
 	psFlavors := make([]kueue.PodSetAssignment, len(a.PodSets))
 	for i := range psFlavors {
@@ -219,6 +310,10 @@ type PodSetAssignment struct {
 	Count    int32
 
 	TopologyAssignment *kueue.TopologyAssignment
+
+	// NodeCapacityPercentage is the percentage of the node capacity requested
+	// by each pod of the pod set, resolved by the TAS assignment.
+	NodeCapacityPercentage int32
 }
 
 // RepresentativeMode calculates the representative mode for this assignment as
@@ -335,6 +430,9 @@ type FlavorAssignment struct {
 	Mode           FlavorAssignmentMode
 	TriedFlavorIdx int
 	borrow         int
+	// startTime is the time at which the scheduled quota, the resource
+	// fits in, becomes available.
+	startTime *time.Time
 }
 
 type preemptionOracle interface {
@@ -347,6 +445,10 @@ type FlavorAssigner struct {
 	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	enableFairSharing bool
 	oracle            preemptionOracle
+
+	// fallback indicates that the flavors are assigned using the quota of
+	// the fallback cohort of the ClusterQueue.
+	fallback bool
 }
 
 func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle) *FlavorAssigner {
@@ -378,7 +480,21 @@ func (a *FlavorAssigner) Assign(log logr.Logger, counts []int32) Assignment {
 		}
 		a.wl.LastAssignment = nil
 	}
-	return a.assignFlavors(log, counts)
+	assignment := a.assignFlavors(log, counts)
+	if assignment.RepresentativeMode() == Fit || a.cq.FallbackCohort == nil {
+		return assignment
+	}
+	// The workload doesn't fit in the cohort of the ClusterQueue, try to
+	// borrow the quota of its fallback cohort.
+	a.fallback = true
+	defer func() { a.fallback = false }()
+	fallbackAssignment := a.assignFlavors(log, counts)
+	if fallbackAssignment.RepresentativeMode() != Fit {
+		return assignment
+	}
+	log.V(3).Info("Assigning flavors using the quota of the fallback cohort", "fallbackCohort", a.cq.FallbackCohort.Name)
+	fallbackAssignment.Usage.Fallback = true
+	return fallbackAssignment
 }
 
 func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignment {
@@ -402,38 +518,53 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 		},
 	}
 
-	for i, podSet := range requests {
+	topologyRequests := workload.PodSetNameToTopologyRequest(a.wl.Obj)
+	order := workload.PodSetsInPriorityOrder(a.wl.Obj.Spec.PodSets)
+	for k := range requests {
+		i := k
+		if order != nil {
+			i = order[k]
+		}
+		podSet := requests[i]
 		if a.cq.RGByResource(corev1.ResourcePods) != nil {
 			podSet.Requests[corev1.ResourcePods] = int64(podSet.Count)
 		}
 
 		psAssignment := PodSetAssignment{
-			Name:     podSet.Name,
-			Flavors:  make(ResourceAssignment, len(podSet.Requests)),
-			Requests: podSet.Requests.ToResourceList(),
-			Count:    podSet.Count,
+			Name:                   podSet.Name,
+			Flavors:                make(ResourceAssignment, len(podSet.Requests)),
+			Requests:               podSet.Requests.ToResourceList(),
+			Count:                  podSet.Count,
+			NodeCapacityPercentage: workload.NodeCapacityPercentage(topologyRequests[podSet.Name]),
 		}
 
-		for resName := range podSet.Requests {
+		// Iterate the resources in a stable order, so that the assignment and
+		// its status reasons are the same for the same inputs.
+		for _, resName := range slices.Sorted(maps.Keys(podSet.Requests)) {
 			if _, found := psAssignment.Flavors[resName]; found {
 				// This resource got assigned the same flavor as its resource group.
 				// No need to compute again.
 				continue
 			}
-			flavors, status := a.findFlavorForPodSetResource(log, i, podSet.Requests, resName, assignment.Usage.Quota)
+			flavors, alternatives, status := a.findFlavorForPodSetResource(log, i, podSet.Requests, resName, assignment.Usage.Quota)
 			if status.IsError() || len(flavors) == 0 {
 				psAssignment.Flavors = nil
 				psAssignment.Status = status
 				break
 			}
 			psAssignment.append(flavors, status)
+			for _, alternative := range alternatives {
+				assignment.preemptionAlternatives = append(assignment.preemptionAlternatives, podSetFlavors{podSet: i, flavors: alternative})
+			}
 		}
 
 		assignment.append(podSet.Requests, &psAssignment)
 		if psAssignment.Status.IsError() || (len(podSet.Requests) > 0 && len(psAssignment.Flavors) == 0) {
+			assignment.restorePodSetsOrder(order, len(requests))
 			return assignment
 		}
 	}
+	assignment.restorePodSetsOrder(order, len(requests))
 	if assignment.RepresentativeMode() == NoFit {
 		return assignment
 	}
@@ -494,9 +625,35 @@ func (a *Assignment) append(requests resources.Requests, psAssignment *PodSetAss
 	a.LastState.LastTriedFlavorIdx = append(a.LastState.LastTriedFlavorIdx, flavorIdx)
 }
 
+// restorePodSetsOrder sorts the assigned PodSets, which were appended in the
+// given order of the PodSets of the workload, back in the order of the
+// PodSets of the workload. The last tried flavors of the PodSets which
+// weren't assigned are left empty.
+func (a *Assignment) restorePodSetsOrder(order []int, podSetsCount int) {
+	if order == nil {
+		return
+	}
+	podSets := make([]PodSetAssignment, 0, len(a.PodSets))
+	lastTriedFlavorIdx := make([]map[corev1.ResourceName]int, podSetsCount)
+	assigned := make([]*PodSetAssignment, podSetsCount)
+	for k := range a.PodSets {
+		assigned[order[k]] = &a.PodSets[k]
+		lastTriedFlavorIdx[order[k]] = a.LastState.LastTriedFlavorIdx[k]
+	}
+	for _, psAssignment := range assigned {
+		if psAssignment != nil {
+			podSets = append(podSets, *psAssignment)
+		}
+	}
+	a.PodSets = podSets
+	a.LastState.LastTriedFlavorIdx = lastTriedFlavorIdx
+}
+
 // findFlavorForPodSetResource finds the flavor which can satisfy the podSet request
 // for all resources in the same group as resName.
 // Returns the chosen flavor, along with the information about resources that need to be borrowed.
+// It also returns the other flavors that were considered and in which the
+// resources could fit by preempting workloads.
 // If the flavor cannot be immediately assigned, it returns a status with
 // reasons or failure.
 func (a *FlavorAssigner) findFlavorForPodSetResource(
@@ -505,10 +662,10 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	requests resources.Requests,
 	resName corev1.ResourceName,
 	assignmentUsage resources.FlavorResourceQuantities,
-) (ResourceAssignment, *Status) {
+) (ResourceAssignment, []ResourceAssignment, *Status) {
 	resourceGroup := a.cq.RGByResource(resName)
 	if resourceGroup == nil {
-		return nil, &Status{
+		return nil, nil, &Status{
 			reasons: []string{fmt.Sprintf("resource %s unavailable in ClusterQueue", resName)},
 		}
 	}
@@ -520,20 +677,31 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 
 	var bestAssignment ResourceAssignment
 	bestAssignmentMode := noFit
+	var preemptAssignments []ResourceAssignment
 
 	// We will only check against the flavors' labels for the resource.
 	selector := flavorSelector(podSpec, resourceGroup.LabelKeys)
+	flavors := preferredFlavors(ps, resourceGroup.Flavors)
+	previousFlavor := a.previouslyAssignedFlavor(ps.Name, resName)
+	if previousFlavor != nil {
+		flavors = moveToFront(flavors, *previousFlavor)
+	}
+	var bestFlavor kueue.ResourceFlavorReference
 	attemptedFlavorIdx := -1
 	idx := a.wl.LastAssignment.NextFlavorToTryForPodSetResource(psID, resName)
-	for ; idx < len(resourceGroup.Flavors); idx++ {
+	for ; idx < len(flavors); idx++ {
 		attemptedFlavorIdx = idx
-		fName := resourceGroup.Flavors[idx]
+		fName := flavors[idx]
 		flavor, exist := a.resourceFlavors[fName]
 		if !exist {
 			log.Error(nil, "Flavor not found", "Flavor", fName)
 			status.appendf("flavor %s not found", fName)
 			continue
 		}
+		if ptr.Deref(flavor.Spec.Disabled, false) {
+			status.appendf("flavor %s is disabled", fName)
+			continue
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			if message := checkPodSetAndFlavorMatchForTAS(a.cq, ps, flavor); message != nil {
 				log.Error(nil, *message)
@@ -548,10 +716,16 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			status.appendf("untolerated taint %s in flavor %s", taint, fName)
 			continue
 		}
+		// The taints to tolerate are matched against the tolerations of the pods only,
+		// so that the flavor tolerations don't grant access to the flavor.
+		if taint, untolerated := corev1helpers.FindMatchingUntoleratedTaint(flavor.Spec.TaintsToTolerate, podSpec.Tolerations, nil); untolerated {
+			status.appendf("untolerated taint %s in flavor %s", taint, fName)
+			continue
+		}
 		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: flavor.Spec.NodeLabels}}); !match || err != nil {
 			if err != nil {
 				status.err = err
-				return nil, status
+				return nil, nil, status
 			}
 			status.appendf("flavor %s doesn't match node affinity", fName)
 			continue
@@ -560,11 +734,12 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 		assignments := make(ResourceAssignment, len(requests))
 		// Calculate representativeMode for this assignment as the worst mode among all requests.
 		representativeMode := fit
-		for rName, val := range requests {
+		for _, rName := range slices.Sorted(maps.Keys(requests)) {
+			val := requests[rName]
 			resQuota := a.cq.QuotaFor(resources.FlavorResource{Flavor: fName, Resource: rName})
 			// Check considering the flavor usage by previous pod sets.
 			fr := resources.FlavorResource{Flavor: fName, Resource: rName}
-			mode, borrow, s := a.fitsResourceQuota(log, fr, val+assignmentUsage[fr], resQuota)
+			mode, borrow, startTime, s := a.fitsResourceQuota(log, fr, val+assignmentUsage[fr], resQuota)
 			if s != nil {
 				status.reasons = append(status.reasons, s.reasons...)
 			}
@@ -578,11 +753,15 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			}
 
 			assignments[rName] = &FlavorAssignment{
-				Name:   fName,
-				Mode:   mode.flavorAssignmentMode(),
-				borrow: borrow,
+				Name:      fName,
+				Mode:      mode.flavorAssignmentMode(),
+				borrow:    borrow,
+				startTime: startTime,
 			}
 		}
+		if representativeMode.isPreemptMode() {
+			preemptAssignments = append(preemptAssignments, assignments)
+		}
 
 		if features.Enabled(features.FlavorFungibility) {
 			if !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility, needsBorrowing) {
@@ -590,23 +769,25 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 				bestAssignmentMode = representativeMode
 				break
 			}
-			if representativeMode > bestAssignmentMode {
+			if isBetterFlavor(representativeMode, fName, bestAssignmentMode, bestFlavor, previousFlavor) {
 				bestAssignment = assignments
 				bestAssignmentMode = representativeMode
+				bestFlavor = fName
 			}
-		} else if representativeMode > bestAssignmentMode {
+		} else if isBetterFlavor(representativeMode, fName, bestAssignmentMode, bestFlavor, previousFlavor) {
 			bestAssignment = assignments
 			bestAssignmentMode = representativeMode
+			bestFlavor = fName
 			if bestAssignmentMode == fit {
 				// All the resources fit in the cohort, no need to check more flavors.
-				return bestAssignment, nil
+				return bestAssignment, nil, nil
 			}
 		}
 	}
 
 	if features.Enabled(features.FlavorFungibility) {
 		for _, assignment := range bestAssignment {
-			if attemptedFlavorIdx == len(resourceGroup.Flavors)-1 {
+			if attemptedFlavorIdx == len(flavors)-1 {
 				// we have reach the last flavor, try from the first flavor next time
 				assignment.TriedFlavorIdx = -1
 			} else {
@@ -614,10 +795,115 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			}
 		}
 		if bestAssignmentMode == fit {
-			return bestAssignment, nil
+			return bestAssignment, nil, nil
 		}
 	}
-	return bestAssignment, status
+	alternatives := slices.DeleteFunc(preemptAssignments, func(assignments ResourceAssignment) bool {
+		return maps.Equal(assignments, bestAssignment)
+	})
+	return bestAssignment, alternatives, status
+}
+
+// isBetterFlavor returns whether the assignment to the flavor in the given
+// mode is better than the best assignment found so far. Among the flavors
+// with the same mode, the previously assigned flavor is kept, and otherwise
+// the ties are broken by the flavor name, so that the assignment is stable.
+func isBetterFlavor(mode granularMode, fName kueue.ResourceFlavorReference, bestMode granularMode, bestFName kueue.ResourceFlavorReference, previous *kueue.ResourceFlavorReference) bool {
+	if mode != bestMode {
+		return mode > bestMode
+	}
+	if mode == noFit {
+		return false
+	}
+	if previous != nil && (bestFName == *previous || fName == *previous) {
+		return fName == *previous
+	}
+	return fName < bestFName
+}
+
+// preferredFlavors returns the flavors to try for the podSet, out of the flavors
+// of a resource group. When the podSet lists preferred flavors that belong to
+// the resource group, only those are tried, in the order of preference.
+// Otherwise, the flavors are tried in the order of the resource group.
+func preferredFlavors(ps *kueue.PodSet, flavors []kueue.ResourceFlavorReference) []kueue.ResourceFlavorReference {
+	value, found := ps.Template.Annotations[constants.PodSetPreferredFlavorsAnnotation]
+	if !found {
+		return flavors
+	}
+	var preferred []kueue.ResourceFlavorReference
+	for _, name := range strings.Split(value, ",") {
+		fName := kueue.ResourceFlavorReference(strings.TrimSpace(name))
+		if slices.Contains(flavors, fName) && !slices.Contains(preferred, fName) {
+			preferred = append(preferred, fName)
+		}
+	}
+	if len(preferred) == 0 {
+		return flavors
+	}
+	return preferred
+}
+
+// previouslyAssignedFlavor returns the flavor that the workload was assigned
+// for the resource of the podSet in its current admission, if any. When the
+// workload is not admitted, for example because it was evicted, the flavor of
+// its last placement in the ClusterQueue is returned instead.
+func (a *FlavorAssigner) previouslyAssignedFlavor(psName kueue.PodSetReference, resName corev1.ResourceName) *kueue.ResourceFlavorReference {
+	if admission := a.wl.Obj.Status.Admission; admission != nil {
+		if admission.ClusterQueue != a.cq.Name {
+			return nil
+		}
+		for i := range admission.PodSetAssignments {
+			psa := &admission.PodSetAssignments[i]
+			if psa.Name != psName {
+				continue
+			}
+			if fName, found := psa.Flavors[resName]; found {
+				return &fName
+			}
+		}
+		return nil
+	}
+	psp := lastPlacementOfPodSet(a.wl.Obj, a.cq.Name, psName)
+	if psp == nil {
+		return nil
+	}
+	if fName, found := psp.Flavors[resName]; found {
+		return &fName
+	}
+	return nil
+}
+
+// lastPlacementOfPodSet returns the last placement of the podSet of the
+// workload in the ClusterQueue, when the WorkloadResumeHints feature is
+// enabled.
+func lastPlacementOfPodSet(wl *kueue.Workload, cqName kueue.ClusterQueueReference, psName kueue.PodSetReference) *kueue.PodSetPlacement {
+	if !features.Enabled(features.WorkloadResumeHints) {
+		return nil
+	}
+	placement := wl.Status.LastPlacement
+	if placement == nil || placement.ClusterQueue != cqName {
+		return nil
+	}
+	for i := range placement.PodSets {
+		if placement.PodSets[i].Name == psName {
+			return &placement.PodSets[i]
+		}
+	}
+	return nil
+}
+
+// moveToFront returns a copy of the flavors with the given flavor first, so
+// that it is preferred over the others. The rest of the flavors keep their
+// order. If the flavor is not listed, the flavors are returned unchanged.
+func moveToFront(flavors []kueue.ResourceFlavorReference, fName kueue.ResourceFlavorReference) []kueue.ResourceFlavorReference {
+	idx := slices.Index(flavors, fName)
+	if idx <= 0 {
+		return flavors
+	}
+	result := make([]kueue.ResourceFlavorReference, 0, len(flavors))
+	result = append(result, fName)
+	result = append(result, flavors[:idx]...)
+	return append(result, flavors[idx+1:]...)
 }
 
 func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueue.FlavorFungibility, needsBorrowing bool) bool {
@@ -692,29 +978,60 @@ func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffi
 // if borrowing is required when preempting.
 // If the flavor doesn't satisfy limits immediately (when waiting or preemption
 // could help), it returns a Status with reasons.
-func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorResource, val int64, rQuota cache.ResourceQuota) (granularMode, int, *Status) {
+// If it only fits in the quota scheduled to become available, it also returns
+// the time at which the quota becomes available.
+func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorResource, val int64, rQuota cache.ResourceQuota) (granularMode, int, *time.Time, *Status) {
 	var status Status
 
-	available := a.cq.Available(fr)
-	maxCapacity := a.cq.PotentialAvailable(fr)
+	if a.fallback {
+		mode, borrow, s := a.fitsFallbackQuota(fr, val)
+		return mode, borrow, nil, s
+	}
+
+	// The part of the quota reserved for the workloads with a higher priority
+	// is not available for the workload.
+	reserved := a.unusedPriorityReservation(fr, rQuota)
+	// The quota scheduled to become available in the future is withheld
+	// from the snapshot, and it can only be used by the workloads admitted
+	// to start at that time.
+	pending := a.cq.PendingScheduledQuota[fr]
+	available := a.cq.Available(fr) - reserved
+	maxCapacity := a.cq.PotentialAvailable(fr) + pending - reserved
 
 	// No Fit
 	if val > maxCapacity {
 		status.appendf("insufficient quota for %s in flavor %s, request > maximum capacity (%s > %s)",
 			fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, maxCapacity))
-		return noFit, 0, &status
+		return noFit, 0, nil, &status
 	}
 
 	borrow, mayReclaimInHierarchy := classical.FindHeightOfLowestSubtreeThatFits(a.cq, fr, val)
+
+	// The workloads below the borrowing minimum priority are only admitted
+	// within the quota of the ClusterQueue, possibly by preempting the
+	// workloads in the ClusterQueue.
+	if borrow > 0 && !a.cq.MayBorrow(a.wl.Obj) {
+		status.appendf("insufficient unused quota for %s in flavor %s, and workloads with priority below %d can't borrow",
+			fr.Resource, fr.Flavor, *a.cq.BorrowingMinPriority)
+		if val <= rQuota.Nominal-reserved {
+			return preempt, 0, nil, &status
+		}
+		return noFit, 0, nil, &status
+	}
+
 	// Fit
 	if val <= available {
-		return fit, borrow, nil
+		return fit, borrow, nil, nil
+	}
+	// Fit, once the scheduled quota becomes available
+	if val <= available+pending {
+		return fit, borrow, &rQuota.ScheduledQuota.StartTime, nil
 	}
 
 	// Check if preemption is possible
 	mode := noFit
 	// For single-level hierarchies, mayReclaimInHierarchy = true iff val <= rQuota.Nominal
-	if val <= rQuota.Nominal || mayReclaimInHierarchy {
+	if val <= rQuota.Nominal-reserved || mayReclaimInHierarchy {
 		mode = preempt
 		if a.oracle.IsReclaimPossible(log, a.cq, *a.wl, fr, val) {
 			mode = reclaim
@@ -726,7 +1043,44 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 	status.appendf("insufficient unused quota for %s in flavor %s, %s more needed",
 		fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val-available))
 
-	return mode, borrow, &status
+	return mode, borrow, nil, &status
+}
+
+// unusedPriorityReservation returns the part of the quota reserved for the
+// workloads with a priority of at least the reservation threshold, which the
+// workload can't use because its priority is lower. The reserved quota used by
+// the admitted workloads above the threshold is not counted.
+func (a *FlavorAssigner) unusedPriorityReservation(fr resources.FlavorResource, rQuota cache.ResourceQuota) int64 {
+	reservation := rQuota.PriorityReservation
+	if reservation == nil || priority.Priority(a.wl.Obj) >= reservation.MinPriority {
+		return 0
+	}
+	var used int64
+	for _, wl := range a.cq.Workloads {
+		if priority.Priority(wl.Obj) >= reservation.MinPriority {
+			used += wl.FlavorResourceUsage()[fr]
+		}
+	}
+	return max(reservation.Quantity-used, 0)
+}
+
+// fitsFallbackQuota returns whether the flavor fits in the remaining quota of
+// the fallback cohort of the ClusterQueue. Workloads aren't preempted to make
+// room in the fallback cohort.
+func (a *FlavorAssigner) fitsFallbackQuota(fr resources.FlavorResource, val int64) (granularMode, int, *Status) {
+	var status Status
+	if !a.cq.MayBorrow(a.wl.Obj) {
+		status.appendf("workloads with priority below %d can't borrow from the fallback cohort %s",
+			*a.cq.BorrowingMinPriority, a.cq.FallbackCohort.Name)
+		return noFit, 0, &status
+	}
+	available := a.cq.FallbackAvailable(fr)
+	if val <= available {
+		return fit, a.cq.FallbackBorrowingHeight(), nil
+	}
+	status.appendf("insufficient unused quota for %s in flavor %s in the fallback cohort %s, %s more needed",
+		fr.Resource, fr.Flavor, a.cq.FallbackCohort.Name, resources.ResourceQuantityString(fr.Resource, val-available))
+	return noFit, 0, &status
 }
 
 func (a *FlavorAssigner) canPreemptWhileBorrowing() bool {
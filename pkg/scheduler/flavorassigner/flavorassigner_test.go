@@ -25,10 +25,12 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -67,6 +69,20 @@ func TestAssignFlavors(t *testing.T) {
 				Effect:   corev1.TaintEffectNoSchedule,
 			}).
 			Obj(),
+		"toleration_required": utiltesting.MakeResourceFlavor("toleration_required").
+			Toleration(corev1.Toleration{
+				Key:      "gpu",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "true",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}).
+			TaintToTolerate(corev1.Taint{
+				Key:    "gpu",
+				Value:  "true",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).
+			Obj(),
+		"disabled": utiltesting.MakeResourceFlavor("disabled").Disabled(true).Obj(),
 	}
 
 	cases := map[string]struct {
@@ -77,9 +93,15 @@ func TestAssignFlavors(t *testing.T) {
 		secondaryClusterQueue      *kueue.ClusterQueue
 		secondaryClusterQueueUsage resources.FlavorResourceQuantities
 		wantRepMode                FlavorAssignmentMode
+		wlAdmission                *kueue.Admission
+		wlLastPlacement            *kueue.WorkloadPlacement
+		wlPriority                 *int32
 		wantAssignment             Assignment
 		disableLendingLimit        bool
 		enableFairSharing          bool
+		enableResumeHints          bool
+		enablePodSetPriority       bool
+		enableMilliUnits           bool
 	}{
 		"single flavor, fits": {
 			wlPods: []kueue.PodSet{
@@ -181,6 +203,159 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 			},
 		},
+		"single flavor, fits flavor requiring a toleration tolerated by the pods": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Toleration(corev1.Toleration{
+						Key:      "gpu",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}).
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("toleration_required").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "toleration_required", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "toleration_required", Resource: corev1.ResourceCPU}: 1_000,
+				}},
+			},
+		},
+		"single flavor, doesn't fit flavor requiring a toleration not tolerated by the pods": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("toleration_required").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{"untolerated taint {gpu true NoSchedule <nil>} in flavor toleration_required"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"single flavor, doesn't fit disabled flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("disabled").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{"flavor disabled is disabled"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"multiple flavors, fits while skipping disabled flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("disabled").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"previously assigned disabled flavor is skipped": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAdmission: utiltesting.MakeAdmission("test-clusterqueue").
+				Assignment(corev1.ResourceCPU, "disabled", "3").
+				Obj(),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("disabled").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
 		"single flavor, used resources, doesn't fit": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
@@ -419,6 +594,107 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 			},
 		},
+		"fractional extended resource, fits exactly": {
+			enableMilliUnits: true,
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).
+					Request("example.com/gpu", "500m").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("b_one").
+						Resource("example.com/gpu", "1500m").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						"example.com/gpu": {Name: "b_one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						"example.com/gpu": resource.MustParse("1500m"),
+					},
+					Count: 3,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "b_one", Resource: "example.com/gpu"}: 1_500,
+				}},
+			},
+		},
+		"fractional extended resource, doesn't fit by a fraction": {
+			enableMilliUnits: true,
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).
+					Request("example.com/gpu", "500m").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("b_one").
+						Resource("example.com/gpu", "1250m").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Requests: corev1.ResourceList{
+						"example.com/gpu": resource.MustParse("1500m"),
+					},
+					Status: &Status{
+						reasons: []string{"insufficient quota for example.com/gpu in flavor b_one, request > maximum capacity (1500m > 1250m)"},
+					},
+					Count: 3,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+			},
+		},
+		"fractional extended resource, borrows a fraction": {
+			enableMilliUnits: true,
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).
+					Request("example.com/gpu", "500m").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("b_one").
+						Resource("example.com/gpu", "1").
+						FlavorQuotas,
+				).Cohort("test-cohort").
+				ClusterQueue,
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("b_one").
+						Resource("example.com/gpu", "1").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "b_one", Resource: "example.com/gpu"}: 250,
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						"example.com/gpu": {Name: "b_one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						"example.com/gpu": resource.MustParse("1500m"),
+					},
+					Count: 3,
+				}},
+				Borrowing: 1,
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "b_one", Resource: "example.com/gpu"}: 1_500,
+				}},
+			},
+		},
 		"multiple resources in a group, doesn't fit": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
@@ -1672,253 +1948,865 @@ func TestAssignFlavors(t *testing.T) {
 				},
 			},
 		},
-		"lend try next flavor, found the second flavor": {
+		"low priority workload is denied borrowing": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
-					Request(corev1.ResourceCPU, "9").
+					Request(corev1.ResourceCPU, "4").
 					Obj(),
 			},
+			wlPriority: ptr.To[int32](10),
 			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
-				FlavorFungibility(kueue.FlavorFungibility{
-					WhenCanBorrow:  kueue.TryNextFlavor,
-					WhenCanPreempt: kueue.TryNextFlavor},
-				).
 				ResourceGroup(
-					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourcePods, "10").
-						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("1").Append().
-						FlavorQuotas,
-					utiltesting.MakeFlavorQuotas("two").
-						Resource(corev1.ResourcePods, "10").
-						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("0").Append().
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
 						FlavorQuotas,
 				).Cohort("test-cohort").
+				BorrowingMinPriority(100).
 				ClusterQueue,
-			clusterQueueUsage: resources.FlavorResourceQuantities{
-				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
-			},
 			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
 				ResourceGroup(
-					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "1").
-						FlavorQuotas,
+					*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Obj(),
 				).
 				Cohort("test-cohort").
 				Obj(),
-			wantRepMode: Fit,
+			wantRepMode: NoFit,
 			wantAssignment: Assignment{
-				PodSets: []PodSetAssignment{{
-					Name: kueue.DefaultPodSetName,
-					Flavors: ResourceAssignment{
-						corev1.ResourceCPU:  {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
-						corev1.ResourcePods: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
-					},
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("9"),
-						corev1.ResourcePods: resource.MustParse("1"),
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{
+					{
+						Name: kueue.DefaultPodSetName,
+						Status: &Status{
+							reasons: []string{"insufficient unused quota for cpu in flavor default, and workloads with priority below 100 can't borrow"},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4"),
+						},
+						Count: 1,
+					},
+				},
+			},
+		},
+		"high priority workload is allowed to borrow": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wlPriority: ptr.To[int32](100),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
+						FlavorQuotas,
+				).Cohort("test-cohort").
+				BorrowingMinPriority(100).
+				ClusterQueue,
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Obj(),
+				).
+				Cohort("test-cohort").
+				Obj(),
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: kueue.DefaultPodSetName,
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4"),
+						},
+						Count: 1,
+					},
+				},
+				Borrowing: 1,
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 4_000,
+				}},
+			},
+		},
+		"low priority workload preempts within the ClusterQueue instead of borrowing": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wlPriority: ptr.To[int32](10),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
+						FlavorQuotas,
+				).Cohort("test-cohort").
+				BorrowingMinPriority(100).
+				ClusterQueue,
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Obj(),
+				).
+				Cohort("test-cohort").
+				Obj(),
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+			},
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: kueue.DefaultPodSetName,
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "default", Mode: Preempt, TriedFlavorIdx: -1},
+						},
+						Status: &Status{
+							reasons: []string{"insufficient unused quota for cpu in flavor default, and workloads with priority below 100 can't borrow"},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+						Count: 1,
+					},
+				},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+				}},
+			},
+		},
+		"lend try next flavor, found the second flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "9").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				FlavorFungibility(kueue.FlavorFungibility{
+					WhenCanBorrow:  kueue.TryNextFlavor,
+					WhenCanPreempt: kueue.TryNextFlavor},
+				).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourcePods, "10").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("1").Append().
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourcePods, "10").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("0").Append().
+						FlavorQuotas,
+				).Cohort("test-cohort").
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "1").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU:  {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+						corev1.ResourcePods: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("9"),
+						corev1.ResourcePods: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}:  9_000,
+					{Flavor: "two", Resource: corev1.ResourcePods}: 1,
+				}},
+			},
+		},
+		"lend try next flavor, found the first flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "9").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.TryNextFlavor, WhenCanPreempt: kueue.TryNextFlavor}).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourcePods, "10").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("1").Append().
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourcePods, "10").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("1").LendingLimit("0").Append().
+						FlavorQuotas,
+				).Cohort("test-cohort").
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "1").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU:  {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+						corev1.ResourcePods: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("9"),
+						corev1.ResourcePods: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Borrowing: 1,
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}:  9_000,
+					{Flavor: "one", Resource: corev1.ResourcePods}: 1,
+				}},
+			},
+		},
+		"quota exhausted, but can preempt in cohort and ClusterQueue": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "9").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourcePods, "10").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("0").Append().
+						FlavorQuotas,
+				).Cohort("test-cohort").ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourcePods, "0").
+						Resource(corev1.ResourceCPU, "0").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU:  {Name: "one", Mode: Preempt, TriedFlavorIdx: -1},
+						corev1.ResourcePods: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("9"),
+						corev1.ResourcePods: resource.MustParse("1"),
+					},
+					Status: &Status{
+						reasons: []string{"insufficient unused quota for cpu in flavor one, 1 more needed"},
+					},
+					Count: 1,
+				}},
+				Borrowing: 1,
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}:  9_000,
+					{Flavor: "one", Resource: corev1.ResourcePods}: 1,
+				}},
+			},
+		},
+		"when borrowing while preemption is needed for flavor one, fair sharing enabled, reclaimWithinCohort=Any": {
+			enableFairSharing: true,
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "12").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Preemption(kueue.ClusterQueuePreemption{ReclaimWithinCohort: kueue.PreemptionPolicyAny}).
+				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.Preempt}).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "0").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "12").
+						FlavorQuotas,
+				).Cohort("test-cohort").ClusterQueue,
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "12").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 10_000,
+			},
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				Borrowing: 1,
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Preempt, TriedFlavorIdx: 0},
+					},
+					Status: &Status{
+						reasons: []string{"insufficient unused quota for cpu in flavor one, 10 more needed"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("12"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 12_000,
+				}},
+			},
+		},
+		"when borrowing while preemption is needed for flavor one, fair sharing enabled, reclaimWithinCohor=Never": {
+			enableFairSharing: true,
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "12").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Preemption(kueue.ClusterQueuePreemption{ReclaimWithinCohort: kueue.PreemptionPolicyNever}).
+				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.Preempt}).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "0").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "12").
+						FlavorQuotas,
+				).Cohort("test-cohort").ClusterQueue,
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "12").
+						FlavorQuotas,
+				).
+				Cohort("test-cohort").
+				Obj(),
+			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 10_000,
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("12"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 12_000,
+				}},
+			},
+		},
+		"preferred flavors, fits in the preferred flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Annotations(map[string]string{constants.PodSetPreferredFlavorsAnnotation: "two,one"}).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: 0},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"preferred flavors, falls back when the preferred flavor is full": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Annotations(map[string]string{constants.PodSetPreferredFlavorsAnnotation: "two,one"}).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"preferred flavors, doesn't fall back to a flavor which isn't listed": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Annotations(map[string]string{constants.PodSetPreferredFlavorsAnnotation: "two"}).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Preempt, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Status: &Status{
+						reasons: []string{"insufficient unused quota for cpu in flavor two, 2 more needed"},
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"previously assigned flavor, keeps it when it still fits": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAdmission: utiltesting.MakeAdmission("test-clusterqueue").
+				Assignment(corev1.ResourceCPU, "two", "3").
+				Obj(),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: 0},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"previously assigned flavor, falls back when it doesn't fit anymore": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAdmission: utiltesting.MakeAdmission("test-clusterqueue").
+				Assignment(corev1.ResourceCPU, "two", "3").
+				Obj(),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"previously assigned flavor in another ClusterQueue is ignored": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAdmission: utiltesting.MakeAdmission("other-clusterqueue").
+				Assignment(corev1.ResourceCPU, "two", "3").
+				Obj(),
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: 0},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
 					},
 					Count: 1,
 				}},
 				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
-					{Flavor: "two", Resource: corev1.ResourceCPU}:  9_000,
-					{Flavor: "two", Resource: corev1.ResourcePods}: 1,
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
 				}},
 			},
 		},
-		"lend try next flavor, found the first flavor": {
+		"last placement of an evicted workload is preferred": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
-					Request(corev1.ResourceCPU, "9").
+					Request(corev1.ResourceCPU, "3").
 					Obj(),
 			},
+			wlLastPlacement: &kueue.WorkloadPlacement{
+				ClusterQueue: "test-clusterqueue",
+				PodSets: []kueue.PodSetPlacement{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "two"},
+				}},
+			},
+			enableResumeHints: true,
 			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
-				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.TryNextFlavor, WhenCanPreempt: kueue.TryNextFlavor}).
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourcePods, "10").
-						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("1").Append().
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
 					utiltesting.MakeFlavorQuotas("two").
-						Resource(corev1.ResourcePods, "10").
-						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("1").LendingLimit("0").Append().
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).Cohort("test-cohort").
-				ClusterQueue,
-			clusterQueueUsage: resources.FlavorResourceQuantities{
-				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: 0},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
 			},
-			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+		},
+		"last placement is ignored when the resume hints are disabled": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlLastPlacement: &kueue.WorkloadPlacement{
+				ClusterQueue: "test-clusterqueue",
+				PodSets: []kueue.PodSetPlacement{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "two"},
+				}},
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "1").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).
-				Cohort("test-cohort").
-				Obj(),
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
 			wantRepMode: Fit,
 			wantAssignment: Assignment{
 				PodSets: []PodSetAssignment{{
 					Name: kueue.DefaultPodSetName,
 					Flavors: ResourceAssignment{
-						corev1.ResourceCPU:  {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
-						corev1.ResourcePods: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: 0},
 					},
 					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("9"),
-						corev1.ResourcePods: resource.MustParse("1"),
+						corev1.ResourceCPU: resource.MustParse("3"),
 					},
 					Count: 1,
 				}},
-				Borrowing: 1,
 				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
-					{Flavor: "one", Resource: corev1.ResourceCPU}:  9_000,
-					{Flavor: "one", Resource: corev1.ResourcePods}: 1,
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
 				}},
 			},
 		},
-		"quota exhausted, but can preempt in cohort and ClusterQueue": {
+		"flavors in the same mode, the flavor with the lowest name is assigned": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
-					Request(corev1.ResourceCPU, "9").
+					Request(corev1.ResourceCPU, "3").
 					Obj(),
 			},
 			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
 				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourcePods, "10").
-						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").LendingLimit("0").Append().
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).Cohort("test-cohort").ClusterQueue,
+				).ClusterQueue,
 			clusterQueueUsage: resources.FlavorResourceQuantities{
-				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
 			},
-			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
-				ResourceGroup(
-					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourcePods, "0").
-						Resource(corev1.ResourceCPU, "0").
-						FlavorQuotas,
-				).
-				Cohort("test-cohort").
-				Obj(),
-
 			wantRepMode: Preempt,
 			wantAssignment: Assignment{
 				PodSets: []PodSetAssignment{{
 					Name: kueue.DefaultPodSetName,
 					Flavors: ResourceAssignment{
-						corev1.ResourceCPU:  {Name: "one", Mode: Preempt, TriedFlavorIdx: -1},
-						corev1.ResourcePods: {Name: "one", Mode: Fit, TriedFlavorIdx: -1},
+						corev1.ResourceCPU: {Name: "one", Mode: Preempt, TriedFlavorIdx: -1},
 					},
 					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("9"),
-						corev1.ResourcePods: resource.MustParse("1"),
+						corev1.ResourceCPU: resource.MustParse("3"),
 					},
 					Status: &Status{
-						reasons: []string{"insufficient unused quota for cpu in flavor one, 1 more needed"},
+						reasons: []string{
+							"insufficient unused quota for cpu in flavor two, 2 more needed",
+							"insufficient unused quota for cpu in flavor one, 2 more needed",
+						},
 					},
 					Count: 1,
 				}},
-				Borrowing: 1,
 				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
-					{Flavor: "one", Resource: corev1.ResourceCPU}:  9_000,
-					{Flavor: "one", Resource: corev1.ResourcePods}: 1,
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
 				}},
 			},
 		},
-		"when borrowing while preemption is needed for flavor one, fair sharing enabled, reclaimWithinCohort=Any": {
-			enableFairSharing: true,
+		"flavors in the same mode, the previously assigned flavor is kept": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
-					Request(corev1.ResourceCPU, "12").
+					Request(corev1.ResourceCPU, "3").
 					Obj(),
 			},
+			wlAdmission: utiltesting.MakeAdmission("test-clusterqueue").
+				Assignment(corev1.ResourceCPU, "two", "3").
+				Obj(),
 			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
-				Preemption(kueue.ClusterQueuePreemption{ReclaimWithinCohort: kueue.PreemptionPolicyAny}).
-				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.Preempt}).
 				ResourceGroup(
-					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "0").
-						FlavorQuotas,
 					utiltesting.MakeFlavorQuotas("two").
-						Resource(corev1.ResourceCPU, "12").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).Cohort("test-cohort").ClusterQueue,
-			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
-				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "12").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).
-				Cohort("test-cohort").
-				Obj(),
-			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
-				{Flavor: "one", Resource: corev1.ResourceCPU}: 10_000,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
 			},
 			wantRepMode: Preempt,
 			wantAssignment: Assignment{
-				Borrowing: 1,
 				PodSets: []PodSetAssignment{{
 					Name: kueue.DefaultPodSetName,
 					Flavors: ResourceAssignment{
-						corev1.ResourceCPU: {Name: "one", Mode: Preempt, TriedFlavorIdx: 0},
-					},
-					Status: &Status{
-						reasons: []string{"insufficient unused quota for cpu in flavor one, 10 more needed"},
+						corev1.ResourceCPU: {Name: "two", Mode: Preempt, TriedFlavorIdx: -1},
 					},
 					Requests: corev1.ResourceList{
-						corev1.ResourceCPU: resource.MustParse("12"),
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Status: &Status{
+						reasons: []string{
+							"insufficient unused quota for cpu in flavor two, 2 more needed",
+							"insufficient unused quota for cpu in flavor one, 2 more needed",
+						},
 					},
 					Count: 1,
 				}},
 				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
-					{Flavor: "one", Resource: corev1.ResourceCPU}: 12_000,
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
 				}},
 			},
 		},
-		"when borrowing while preemption is needed for flavor one, fair sharing enabled, reclaimWithinCohor=Never": {
-			enableFairSharing: true,
+		"higher priority podSet is assigned the preferred flavor first": {
 			wlPods: []kueue.PodSet{
-				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
-					Request(corev1.ResourceCPU, "12").
+				*utiltesting.MakePodSet("workers", 3).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("driver", 1).
+					Request(corev1.ResourceCPU, "2").
+					Priority(100).
 					Obj(),
 			},
+			enablePodSetPriority: true,
 			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
-				Preemption(kueue.ClusterQueuePreemption{ReclaimWithinCohort: kueue.PreemptionPolicyNever}).
-				FlavorFungibility(kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.Preempt}).
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "0").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
 					utiltesting.MakeFlavorQuotas("two").
-						Resource(corev1.ResourceCPU, "12").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).Cohort("test-cohort").ClusterQueue,
-			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				).ClusterQueue,
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "workers",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3"),
+						},
+						Count: 3,
+					},
+					{
+						Name: "driver",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: 0},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+						Count: 1,
+					},
+				},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"podSets are assigned flavors in order when the podSet priority is disabled": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers", 3).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("driver", 1).
+					Request(corev1.ResourceCPU, "2").
+					Priority(100).
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("one").
-						Resource(corev1.ResourceCPU, "12").
+						Resource(corev1.ResourceCPU, "4").
 						FlavorQuotas,
-				).
-				Cohort("test-cohort").
-				Obj(),
-			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
-				{Flavor: "one", Resource: corev1.ResourceCPU}: 10_000,
-			},
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
 			wantRepMode: Fit,
 			wantAssignment: Assignment{
-				PodSets: []PodSetAssignment{{
-					Name: kueue.DefaultPodSetName,
-					Flavors: ResourceAssignment{
-						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+				PodSets: []PodSetAssignment{
+					{
+						Name: "workers",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: 0},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3"),
+						},
+						Count: 3,
 					},
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU: resource.MustParse("12"),
+					{
+						Name: "driver",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+						Count: 1,
 					},
-					Count: 1,
-				}},
+				},
 				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
-					{Flavor: "two", Resource: corev1.ResourceCPU}: 12_000,
+					{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 2_000,
 				}},
 			},
 		},
@@ -1929,15 +2817,21 @@ func TestAssignFlavors(t *testing.T) {
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.WorkloadResumeHints, tc.enableResumeHints)
+			features.SetFeatureGateDuringTest(t, features.PodSetPriority, tc.enablePodSetPriority)
+			features.SetFeatureGateDuringTest(t, features.ExtendedResourceMilliUnits, tc.enableMilliUnits)
 			log := testr.NewWithOptions(t, testr.Options{
 				Verbosity: 2,
 			})
 			wlInfo := workload.NewInfo(&kueue.Workload{
 				Spec: kueue.WorkloadSpec{
-					PodSets: tc.wlPods,
+					PodSets:  tc.wlPods,
+					Priority: tc.wlPriority,
 				},
 				Status: kueue.WorkloadStatus{
 					ReclaimablePods: tc.wlReclaimablePods,
+					Admission:       tc.wlAdmission,
+					LastPlacement:   tc.wlLastPlacement,
 				},
 			})
 
@@ -1992,6 +2886,64 @@ func TestAssignFlavors(t *testing.T) {
 	}
 }
 
+func TestAssignFlavorsIsStable(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	log := testr.NewWithOptions(t, testr.Options{
+		Verbosity: 2,
+	})
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Obj(),
+	}
+	cq := utiltesting.MakeClusterQueue("test-clusterqueue").
+		ResourceGroup(
+			utiltesting.MakeFlavorQuotas("one").
+				Resource(corev1.ResourceCPU, "4").
+				Resource(corev1.ResourceMemory, "4Mi").
+				Resource("example.com/gpu", "4").
+				FlavorQuotas,
+			utiltesting.MakeFlavorQuotas("two").
+				Resource(corev1.ResourceCPU, "4").
+				Resource(corev1.ResourceMemory, "4Mi").
+				Resource("example.com/gpu", "4").
+				FlavorQuotas,
+		).Obj()
+	wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+			Request(corev1.ResourceCPU, "5").
+			Request(corev1.ResourceMemory, "5Mi").
+			Request("example.com/gpu", "5").
+			Obj()).
+		Obj())
+
+	cache := cache.New(utiltesting.NewFakeClient())
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed to add CQ to cache: %v", err)
+	}
+	for _, rf := range resourceFlavors {
+		cache.AddOrUpdateResourceFlavor(rf)
+	}
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	clusterQueue := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+
+	wantReasons := []string{
+		"insufficient quota for cpu in flavor one, request > maximum capacity (5 > 4)",
+		"insufficient quota for cpu in flavor two, request > maximum capacity (5 > 4)",
+	}
+	for range 10 {
+		assignment := New(wlInfo, clusterQueue, resourceFlavors, false, &testOracle{}).Assign(log, nil)
+		if repMode := assignment.RepresentativeMode(); repMode != NoFit {
+			t.Fatalf("e.assignFlavors(_).RepresentativeMode()=%s, want %s", repMode, NoFit)
+		}
+		if diff := cmp.Diff(wantReasons, assignment.PodSets[0].Status.reasons); diff != "" {
+			t.Fatalf("Unexpected status reasons (-want,+got):\n%s", diff)
+		}
+	}
+}
+
 // We have 3 flavors: uno, due, tre. Each has 10 compute and 10 gpu.
 // These FlavorResources are provided by test-clusterqueue, and made
 // available to its Cohort.
@@ -2043,7 +2995,7 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 			wantMode:      Preempt,
 			wantAssigment: rfMap{"gpu": "uno"},
 		},
-		"Select first flavor where priority based preemption is possible": {
+		"Select the flavor with the lowest name where priority based preemption is possible": {
 			workloadRequests: utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
 			testClusterQueueUsage: resources.FlavorResourceQuantities{
 				{Flavor: "uno", Resource: "gpu"}: 1,
@@ -2051,7 +3003,7 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 				{Flavor: "tre", Resource: "gpu"}: 1,
 			},
 			wantMode:      Preempt,
-			wantAssigment: rfMap{"gpu": "uno"},
+			wantAssigment: rfMap{"gpu": "due"},
 		},
 		"Select second flavor where gpu reclamation is possible, as compute Fits": {
 			workloadRequests: utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10").Request("compute", "10"),
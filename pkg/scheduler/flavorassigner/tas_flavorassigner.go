@@ -19,6 +19,8 @@ package flavorassigner
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"k8s.io/utils/ptr"
 
@@ -30,9 +32,15 @@ import (
 // WorkloadsTopologyRequests - returns the TopologyRequests of the workload
 func (a *Assignment) WorkloadsTopologyRequests(wl *workload.Info, cq *cache.ClusterQueueSnapshot) cache.WorkloadTASRequests {
 	tasRequests := make(cache.WorkloadTASRequests)
-	for i, podSet := range wl.Obj.Spec.PodSets {
-		if isTASRequested(&podSet, cq) {
-			psAssignment := a.podSetAssignmentByName(podSet.Name)
+	order := workload.PodSetsInPriorityOrder(wl.Obj.Spec.PodSets)
+	for k := range wl.Obj.Spec.PodSets {
+		i := k
+		if order != nil {
+			i = order[k]
+		}
+		podSet := wl.Obj.Spec.PodSets[i]
+		psAssignment := a.podSetAssignmentByName(podSet.Name)
+		if isTASRequested(&podSet, cq) || isTASDefaultedByFlavor(psAssignment, cq) {
 			if psAssignment.Status.IsError() {
 				// There is no resource quota assignment for the PodSet - no need to check TAS.
 				continue
@@ -69,14 +77,29 @@ func podSetTopologyRequest(psAssignment *PodSetAssignment,
 	}
 	podSet := &wl.Obj.Spec.PodSets[podSetIndex]
 	return &cache.TASPodSetRequests{
-		Count:             podCount,
-		SinglePodRequests: singlePodRequests,
-		PodSet:            podSet,
-		Flavor:            *tasFlvr,
-		Implied:           isTASImplied,
+		Count:              podCount,
+		SinglePodRequests:  singlePodRequests,
+		PodSet:             podSet,
+		Flavor:             *tasFlvr,
+		Implied:            isTASImplied,
+		PreviousAssignment: previousTopologyAssignment(wl.Obj, cq.Name, podSet.Name, *tasFlvr),
 	}, nil
 }
 
+// previousTopologyAssignment returns the topology assignment of the podSet in
+// the last placement of the workload in the ClusterQueue, if it was placed
+// in the same flavor.
+func previousTopologyAssignment(wl *kueue.Workload, cqName kueue.ClusterQueueReference, psName kueue.PodSetReference, tasFlvr kueue.ResourceFlavorReference) *kueue.TopologyAssignment {
+	psp := lastPlacementOfPodSet(wl, cqName, psName)
+	if psp == nil || psp.TopologyAssignment == nil {
+		return nil
+	}
+	if !slices.Contains(slices.Collect(maps.Values(psp.Flavors)), tasFlvr) {
+		return nil
+	}
+	return psp.TopologyAssignment
+}
+
 func onlyFlavor(ra ResourceAssignment) (*kueue.ResourceFlavorReference, error) {
 	var result *kueue.ResourceFlavorReference
 	for _, v := range ra {
@@ -93,8 +116,13 @@ func onlyFlavor(ra ResourceAssignment) (*kueue.ResourceFlavorReference, error) {
 }
 
 func checkPodSetAndFlavorMatchForTAS(cq *cache.ClusterQueueSnapshot, ps *kueue.PodSet, flavor *kueue.ResourceFlavor) *string {
+	topologyRequest := ps.TopologyRequest
+	if topologyRequest == nil && flavor.Spec.TopologyName != nil && flavor.Spec.DefaultRequiredTopologyLevel != nil {
+		// The PodSet gets the default required level of the flavor
+		topologyRequest = &kueue.PodSetTopologyRequest{Required: flavor.Spec.DefaultRequiredTopologyLevel}
+	}
 	// For PodSets which require TAS skip resource flavors which don't support it
-	if ps.TopologyRequest != nil {
+	if topologyRequest != nil {
 		if flavor.Spec.TopologyName == nil {
 			return ptr.To(fmt.Sprintf("Flavor %q does not support TopologyAwareScheduling", flavor.Name))
 		}
@@ -105,7 +133,7 @@ func checkPodSetAndFlavorMatchForTAS(cq *cache.ClusterQueueSnapshot, ps *kueue.P
 			// API object was recently added but is not cached yet.
 			return ptr.To(fmt.Sprintf("Flavor %q information missing in TAS cache", flavor.Name))
 		}
-		if !s.HasLevel(ps.TopologyRequest) {
+		if !s.HasLevel(topologyRequest) {
 			// Skip flavors which don't have the requested level
 			return ptr.To(fmt.Sprintf("Flavor %q does not contain the requested level", flavor.Name))
 		}
@@ -115,7 +143,7 @@ func checkPodSetAndFlavorMatchForTAS(cq *cache.ClusterQueueSnapshot, ps *kueue.P
 		return nil
 	}
 	// For PodSets which don't use TAS skip resource flavors which are only for TAS
-	if ps.TopologyRequest == nil && flavor.Spec.TopologyName != nil {
+	if topologyRequest == nil && flavor.Spec.TopologyName != nil {
 		return ptr.To(fmt.Sprintf("Flavor %q supports only TopologyAwareScheduling", flavor.Name))
 	}
 	return nil
@@ -127,6 +155,20 @@ func isTASImplied(ps *kueue.PodSet, cq *cache.ClusterQueueSnapshot) bool {
 	return ps.TopologyRequest == nil && cq.IsTASOnly()
 }
 
+// isTASDefaultedByFlavor returns true if TAS is requested by the default
+// required topology level of the flavor assigned to the PodSet.
+func isTASDefaultedByFlavor(psAssignment *PodSetAssignment, cq *cache.ClusterQueueSnapshot) bool {
+	if psAssignment == nil {
+		return false
+	}
+	tasFlvr, err := onlyFlavor(psAssignment.Flavors)
+	if err != nil {
+		return false
+	}
+	s := cq.TASFlavors[*tasFlvr]
+	return s != nil && s.HasDefaultRequiredLevel()
+}
+
 // isTASRequested checks if TAS is requested for the input PodSet, either
 // explicitly or implicitly.
 func isTASRequested(ps *kueue.PodSet, cq *cache.ClusterQueueSnapshot) bool {
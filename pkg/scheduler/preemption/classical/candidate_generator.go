@@ -17,6 +17,7 @@ limitations under the License.
 package classical
 
 import (
+	"slices"
 	"sort"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -153,6 +155,31 @@ func (c *candidateIterator) candidateIsValid(candidate *candidateElem, borrow bo
 	return true
 }
 
+// ReorderWithinPriority sorts, according to cmp, the consecutive candidates
+// which are equivalent for the candidates ordering: same eviction state, same
+// ClusterQueue relation, same preemption variant and same priority. The
+// relative order of the candidates which are equal for cmp is preserved.
+func (c *candidateIterator) ReorderWithinPriority(cmp func(a, b *workload.Info) int) {
+	for start := 0; start < len(c.candidates); {
+		end := start + 1
+		for end < len(c.candidates) && c.equivalent(c.candidates[start], c.candidates[end]) {
+			end++
+		}
+		slices.SortStableFunc(c.candidates[start:end], func(a, b *candidateElem) int {
+			return cmp(a.wl, b.wl)
+		})
+		start = end
+	}
+}
+
+func (c *candidateIterator) equivalent(a, b *candidateElem) bool {
+	cq := c.hierarchicalReclaimCtx.Cq.Name
+	return a.preemptionVariant == b.preemptionVariant &&
+		(a.wl.ClusterQueue == cq) == (b.wl.ClusterQueue == cq) &&
+		meta.IsStatusConditionTrue(a.wl.Obj.Status.Conditions, kueue.WorkloadEvicted) == meta.IsStatusConditionTrue(b.wl.Obj.Status.Conditions, kueue.WorkloadEvicted) &&
+		priority.Priority(a.wl.Obj) == priority.Priority(b.wl.Obj)
+}
+
 // Reset moves the candidate iterator back to the starting position.
 // It is required to reset the iterator before each run.
 func (c *candidateIterator) Reset() {
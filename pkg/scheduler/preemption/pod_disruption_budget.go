@@ -0,0 +1,86 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// BlockedByPodDisruptionBudget returns a message describing the first target
+// whose eviction would violate a PodDisruptionBudget, or an empty string if
+// all the targets can be evicted.
+func (p *Preemptor) BlockedByPodDisruptionBudget(ctx context.Context, targets []*Target) (string, error) {
+	for _, target := range targets {
+		pdb, err := blockingPodDisruptionBudget(ctx, p.client, target.WorkloadInfo.Obj)
+		if err != nil {
+			return "", err
+		}
+		if pdb != nil {
+			return fmt.Sprintf("evicting workload %s would violate PodDisruptionBudget %s, which allows %d disruption(s)",
+				client.ObjectKeyFromObject(target.WorkloadInfo.Obj), client.ObjectKeyFromObject(pdb), pdb.Status.DisruptionsAllowed), nil
+		}
+	}
+	return "", nil
+}
+
+// blockingPodDisruptionBudget returns the PodDisruptionBudget, if any, which
+// selects more pods of the workload than the disruptions it allows.
+func blockingPodDisruptionBudget(ctx context.Context, c client.Client, wl *kueue.Workload) (*policyv1.PodDisruptionBudget, error) {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := c.List(ctx, &pdbs, client.InNamespace(wl.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		var pods int32
+		for _, ps := range wl.Spec.PodSets {
+			if selector.Matches(labels.Set(ps.Template.Labels)) {
+				pods += admittedCount(wl, ps)
+			}
+		}
+		if pods > pdb.Status.DisruptionsAllowed {
+			return pdb, nil
+		}
+	}
+	return nil, nil
+}
+
+// admittedCount returns the number of pods admitted for the podSet, which
+// can be lower than its count with partial admission.
+func admittedCount(wl *kueue.Workload, ps kueue.PodSet) int32 {
+	if wl.Status.Admission != nil {
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			if psa.Name == ps.Name {
+				return ptr.Deref(psa.Count, ps.Count)
+			}
+		}
+	}
+	return ps.Count
+}
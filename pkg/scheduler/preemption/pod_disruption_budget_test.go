@@ -0,0 +1,110 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"testing"
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestBlockedByPodDisruptionBudget(t *testing.T) {
+	baseTarget := utiltesting.MakeWorkload("target", "default").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).
+				Labels(map[string]string{"app": "driver"}).
+				Obj(),
+			*utiltesting.MakePodSet("workers", 4).
+				Labels(map[string]string{"app": "workers"}).
+				Obj(),
+		)
+	target := baseTarget.Clone().Obj()
+	makePDB := func(namespace string, selector *metav1.LabelSelector, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: namespace},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: selector},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+	workersSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "workers"}}
+
+	cases := map[string]struct {
+		target      *kueue.Workload
+		pdbs        []client.Object
+		wantBlocked string
+	}{
+		"no PodDisruptionBudget": {
+			target: target,
+		},
+		"PodDisruptionBudget in another namespace": {
+			target: target,
+			pdbs:   []client.Object{makePDB("other", workersSelector, 0)},
+		},
+		"PodDisruptionBudget not selecting the pods": {
+			target: target,
+			pdbs: []client.Object{
+				makePDB("default", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}, 0),
+			},
+		},
+		"PodDisruptionBudget allowing the disruption of all the pods": {
+			target: target,
+			pdbs:   []client.Object{makePDB("default", workersSelector, 4)},
+		},
+		"PodDisruptionBudget blocking the disruption": {
+			target:      target,
+			pdbs:        []client.Object{makePDB("default", workersSelector, 3)},
+			wantBlocked: "evicting workload default/target would violate PodDisruptionBudget default/pdb, which allows 3 disruption(s)",
+		},
+		"PodDisruptionBudget selecting all the pods": {
+			target:      target,
+			pdbs:        []client.Object{makePDB("default", &metav1.LabelSelector{}, 4)},
+			wantBlocked: "evicting workload default/target would violate PodDisruptionBudget default/pdb, which allows 4 disruption(s)",
+		},
+		"PodDisruptionBudget allowing the disruption of the partially admitted pods": {
+			target: baseTarget.Clone().
+				ReserveQuota(utiltesting.MakeAdmission("cq", "driver", "workers").AssignmentPodCountWithIndex(1, 2).Obj()).
+				Obj(),
+			pdbs: []client.Object{makePDB("default", workersSelector, 2)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.pdbs...).Build()
+			p := New(cl, workload.Ordering{}, &utiltesting.EventRecorder{}, config.FairSharing{}, 0, "", clocktesting.NewFakeClock(time.Now()))
+
+			targets := []*Target{{WorkloadInfo: workload.NewInfo(tc.target), Reason: kueue.InClusterQueueReason}}
+			gotBlocked, err := p.BlockedByPodDisruptionBudget(ctx, targets)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotBlocked != tc.wantBlocked {
+				t.Errorf("Unexpected result, want %q, got %q", tc.wantBlocked, gotBlocked)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package preemption
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"sync/atomic"
 	"time"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -60,9 +63,14 @@ type Preemptor struct {
 	workloadOrdering  workload.Ordering
 	enableFairSharing bool
 	fsStrategies      []fairsharing.Strategy
+	// admissionCooldown is the period after the quota reservation during
+	// which a workload can't be preempted.
+	admissionCooldown time.Duration
+	// objective defines how the targets are chosen among the candidates.
+	objective config.PreemptionObjective
 
 	// stubs
-	applyPreemption func(ctx context.Context, w *kueue.Workload, reason, message string) error
+	applyPreemption func(ctx context.Context, w, preemptor *kueue.Workload, reason, message string) error
 }
 
 type preemptionCtx struct {
@@ -73,13 +81,27 @@ type preemptionCtx struct {
 	workloadUsage     workload.Usage
 	tasRequests       cache.WorkloadTASRequests
 	frsNeedPreemption sets.Set[resources.FlavorResource]
+	// candidatesFound is set when the simulation considered any candidate
+	// for preemption.
+	candidatesFound bool
 }
 
+const (
+	// PreemptionFailedNoCandidates means that there were no workloads which
+	// could be preempted.
+	PreemptionFailedNoCandidates = "NoCandidates"
+	// PreemptionFailedInsufficientCapacity means that preempting all the
+	// candidates wouldn't free enough capacity to admit the workload.
+	PreemptionFailedInsufficientCapacity = "InsufficientCapacity"
+)
+
 func New(
 	cl client.Client,
 	workloadOrdering workload.Ordering,
 	recorder record.EventRecorder,
 	fs config.FairSharing,
+	admissionCooldown time.Duration,
+	objective config.PreemptionObjective,
 	clock clock.Clock,
 ) *Preemptor {
 	p := &Preemptor{
@@ -89,12 +111,14 @@ func New(
 		workloadOrdering:  workloadOrdering,
 		enableFairSharing: fs.Enable,
 		fsStrategies:      parseStrategies(fs.PreemptionStrategies),
+		admissionCooldown: admissionCooldown,
+		objective:         objective,
 	}
 	p.applyPreemption = p.applyPreemptionWithSSA
 	return p
 }
 
-func (p *Preemptor) OverrideApply(f func(context.Context, *kueue.Workload, string, string) error) {
+func (p *Preemptor) OverrideApply(f func(context.Context, *kueue.Workload, *kueue.Workload, string, string) error) {
 	p.applyPreemption = f
 }
 
@@ -106,9 +130,17 @@ type Target struct {
 // GetTargets returns the list of workloads that should be evicted in
 // order to make room for wl.
 func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*Target {
+	targets, _ := p.getTargetsForAssignment(log, wl, assignment, snapshot)
+	return targets
+}
+
+// getTargetsForAssignment returns the list of workloads that should be
+// evicted in order to make room for wl, or the reason why preemption can't
+// make room for it.
+func (p *Preemptor) getTargetsForAssignment(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) ([]*Target, string) {
 	cq := snapshot.ClusterQueue(wl.ClusterQueue)
 	tasRequests := assignment.WorkloadsTopologyRequests(&wl, cq)
-	return p.getTargets(&preemptionCtx{
+	preemptionCtx := &preemptionCtx{
 		log:               log,
 		preemptor:         wl,
 		preemptorCQ:       cq,
@@ -119,14 +151,147 @@ func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment fla
 			Quota: assignment.TotalRequestsFor(&wl),
 			TAS:   wl.TASUsage(),
 		},
+	}
+	if targets := p.getTargets(preemptionCtx); len(targets) > 0 {
+		return targets, ""
+	}
+	if preemptionCtx.candidatesFound {
+		return nil, PreemptionFailedInsufficientCapacity
+	}
+	return nil, PreemptionFailedNoCandidates
+}
+
+// GetTargetsAcrossFlavors returns the list of workloads that should be
+// evicted in order to make room for wl, along with the assignment for which
+// they were found. When there are no targets in the flavors of the assignment,
+// it considers the other flavors of the ClusterQueue's resource groups in which
+// wl could fit by preempting workloads.
+// When no targets are found, it returns the reason why preemption can't make
+// room for wl.
+func (p *Preemptor) GetTargetsAcrossFlavors(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) (flavorassigner.Assignment, []*Target, string) {
+	targets, failureReason := p.getTargetsForAssignment(log, wl, assignment, snapshot)
+	if len(targets) > 0 {
+		return assignment, targets, ""
+	}
+	for _, alternative := range assignment.PreemptionAlternatives() {
+		targets, reason := p.getTargetsForAssignment(log, wl, alternative, snapshot)
+		if len(targets) > 0 {
+			log.V(3).Info("Found preemption targets in an alternative flavor assignment", "assignment", alternative.ToAPI())
+			return alternative, targets, ""
+		}
+		if reason == PreemptionFailedInsufficientCapacity {
+			failureReason = reason
+		}
+	}
+	log.V(3).Info("Preemption can't make room for the workload", "reason", failureReason)
+	return assignment, nil, failureReason
+}
+
+// GetTargetsWithoutBorrowing returns the list of workloads in the ClusterQueue
+// of wl that should be evicted in order to make room for wl within the
+// nominal quota of the ClusterQueue, so that wl doesn't need to borrow.
+func (p *Preemptor) GetTargetsWithoutBorrowing(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*Target {
+	cq := snapshot.ClusterQueue(wl.ClusterQueue)
+	preemptionCtx := &preemptionCtx{
+		log:               log,
+		preemptor:         wl,
+		preemptorCQ:       cq,
+		snapshot:          snapshot,
+		tasRequests:       assignment.WorkloadsTopologyRequests(&wl, cq),
+		frsNeedPreemption: flavorResourcesNeedPreemption(assignment),
+		workloadUsage: workload.Usage{
+			Quota: assignment.TotalRequestsFor(&wl),
+			TAS:   wl.TASUsage(),
+		},
+	}
+	candidates := slices.DeleteFunc(p.findCandidates(wl.Obj, cq, preemptionCtx.frsNeedPreemption), func(candidate *workload.Info) bool {
+		return candidate.ClusterQueue != cq.Name
 	})
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, CandidatesOrdering(candidates, cq.Name, p.clock.Now()))
+
+	var targets []*Target
+	for _, candidate := range candidates {
+		preemptionCtx.snapshot.RemoveWorkload(candidate)
+		targets = append(targets, &Target{
+			WorkloadInfo: candidate,
+			Reason:       kueue.InClusterQueueReason,
+		})
+		if workloadFits(preemptionCtx, false) {
+			targets = fillBackWorkloads(preemptionCtx, targets, false)
+			restoreSnapshot(preemptionCtx.snapshot, targets)
+			return targets
+		}
+	}
+	restoreSnapshot(preemptionCtx.snapshot, targets)
+	return nil
 }
 
 func (p *Preemptor) getTargets(preemptionCtx *preemptionCtx) []*Target {
+	fallbackTargets := p.fallbackReclaimPreemptions(preemptionCtx)
+	if len(fallbackTargets) > 0 && workloadFits(preemptionCtx, true) {
+		fallbackTargets = fillBackWorkloads(preemptionCtx, fallbackTargets, true)
+		restoreSnapshot(preemptionCtx.snapshot, fallbackTargets)
+		return fallbackTargets
+	}
+	var targets []*Target
 	if p.enableFairSharing {
-		return p.fairPreemptions(preemptionCtx, p.fsStrategies)
+		targets = p.fairPreemptions(preemptionCtx, p.fsStrategies)
+	} else {
+		targets = p.classicalPreemptions(preemptionCtx)
+	}
+	restoreSnapshot(preemptionCtx.snapshot, fallbackTargets)
+	if len(targets) == 0 {
+		return nil
+	}
+	return append(fallbackTargets, targets...)
+}
+
+// fallbackReclaimPreemptions removes from the snapshot the workloads admitted
+// using the quota of a fallback cohort in the cohort tree of the preemptor,
+// and returns them as targets. Such workloads borrow the quota from outside
+// of the tree, so the ClusterQueues of the tree can always reclaim it,
+// regardless of the priorities. The caller must restore the snapshot.
+func (p *Preemptor) fallbackReclaimPreemptions(preemptionCtx *preemptionCtx) []*Target {
+	cq := preemptionCtx.preemptorCQ
+	if !cq.HasParent() || cq.Preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever {
+		return nil
+	}
+	root := cq.Parent().Root()
+	var candidates []*workload.Info
+	for _, fallbackCQ := range preemptionCtx.snapshot.ClusterQueues() {
+		if fallbackCQ == cq || fallbackCQ.FallbackCohort == nil || fallbackCQ.FallbackCohort.Root() != root {
+			continue
+		}
+		for _, candidateWl := range fallbackCQ.Workloads {
+			if !candidateWl.UsesFallbackCohort() || !classical.WorkloadUsesResources(candidateWl, preemptionCtx.frsNeedPreemption) {
+				continue
+			}
+			if p.inAdmissionCooldown(candidateWl.Obj) || p.inReservationHold(candidateWl, fallbackCQ) {
+				continue
+			}
+			candidates = append(candidates, candidateWl)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
 	}
-	return p.classicalPreemptions(preemptionCtx)
+	preemptionCtx.candidatesFound = true
+	sort.Slice(candidates, CandidatesOrdering(candidates, cq.Name, p.clock.Now()))
+	targets := make([]*Target, 0, len(candidates))
+	for _, candidate := range candidates {
+		preemptionCtx.snapshot.RemoveWorkload(candidate)
+		targets = append(targets, &Target{
+			WorkloadInfo: candidate,
+			Reason:       kueue.InCohortReclamationReason,
+		})
+		if workloadFits(preemptionCtx, true) {
+			break
+		}
+	}
+	return targets
 }
 
 var HumanReadablePreemptionReasons = map[string]string{
@@ -165,7 +330,7 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 		target := targets[i]
 		if !meta.IsStatusConditionTrue(target.WorkloadInfo.Obj.Status.Conditions, kueue.WorkloadEvicted) {
 			message := preemptionMessage(preemptor.Obj, target.Reason)
-			err := p.applyPreemption(ctx, target.WorkloadInfo.Obj, target.Reason, message)
+			err := p.applyPreemption(ctx, target.WorkloadInfo.Obj, preemptor.Obj, target.Reason, message)
 			if err != nil {
 				errCh.SendErrorWithCancel(err, cancel)
 				return
@@ -179,17 +344,41 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 		}
 		successfullyPreempted.Add(1)
 	})
-	return int(successfullyPreempted.Load()), errCh.ReceiveError()
+	if err := errCh.ReceiveError(); err != nil {
+		return int(successfullyPreempted.Load()), err
+	}
+	if err := p.recordPreemptedWorkloads(ctx, preemptor.Obj, targets); err != nil {
+		log.Error(err, "Failed to record the preempted workloads", "preemptingWorkload", klog.KObj(preemptor.Obj))
+	}
+	return int(successfullyPreempted.Load()), nil
 }
 
-func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workload, reason, message string) error {
+func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w, preemptor *kueue.Workload, reason, message string) error {
 	w = w.DeepCopy()
 	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, message)
 	workload.ResetChecksOnEviction(w, p.clock.Now())
 	workload.SetPreemptedCondition(w, reason, message)
+	w.Status.PreemptedBy = ptr.To(workload.PreemptionReference(preemptor))
 	return workload.ApplyAdmissionStatus(ctx, p.client, w, true, p.clock)
 }
 
+// recordPreemptedWorkloads records the targets in the status of the preemptor,
+// which, together with the preemptedBy reference in the status of the targets,
+// keeps track of the preemption for auditing.
+func (p *Preemptor) recordPreemptedWorkloads(ctx context.Context, preemptor *kueue.Workload, targets []*Target) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	preempted := make([]kueue.WorkloadPreemptionReference, 0, len(targets))
+	for _, target := range targets {
+		preempted = append(preempted, workload.PreemptionReference(target.WorkloadInfo.Obj))
+	}
+	if slices.Equal(preemptor.Status.PreemptedWorkloads, preempted) {
+		return nil
+	}
+	return client.IgnoreNotFound(workload.UpdatePreemptedWorkloads(ctx, p.client, preemptor, preempted))
+}
+
 type preemptionAttemptOpts struct {
 	borrowing bool
 }
@@ -201,7 +390,9 @@ type preemptionAttemptOpts struct {
 // doesn't fit in the quota.
 // Once the Workload fits, the heuristic tries to add Workloads back, in the
 // reverse order in which they were removed, while the incoming Workload still
-// fits
+// fits.
+// For a Workload requiring a single topology domain, the heuristic first
+// considers the candidates within each of the domains separately.
 func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target {
 	hierarchicalReclaimCtx := &classical.HierarchicalPreemptionCtx{
 		Wl:                preemptionCtx.preemptor.Obj,
@@ -211,6 +402,9 @@ func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target
 		WorkloadOrdering:  p.workloadOrdering,
 	}
 	candidatesGenerator := classical.NewCandidateIterator(hierarchicalReclaimCtx, preemptionCtx.frsNeedPreemption, preemptionCtx.snapshot, p.clock, CandidatesOrdering)
+	if compare := candidatesComparator(preemptionCtx, p.objective); compare != nil {
+		candidatesGenerator.ReorderWithinPriority(compare)
+	}
 	var attemptPossibleOpts []preemptionAttemptOpts
 	borrowWithinCohortForbidden, _ := classical.IsBorrowingWithinCohortForbidden(preemptionCtx.preemptorCQ)
 	// We have three types of candidates:
@@ -238,9 +432,16 @@ func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target
 	}
 
 	for _, attemptOpts := range attemptPossibleOpts {
+		if targets := p.domainScopedPreemptions(preemptionCtx, candidatesGenerator, attemptOpts.borrowing); len(targets) > 0 {
+			return targets
+		}
 		var targets []*Target
 		candidatesGenerator.Reset()
 		for candidate, reason := candidatesGenerator.Next(attemptOpts.borrowing); candidate != nil; candidate, reason = candidatesGenerator.Next(attemptOpts.borrowing) {
+			if p.inAdmissionCooldown(candidate.Obj) || p.inReservationHold(candidate, preemptionCtx.snapshot.ClusterQueue(candidate.ClusterQueue)) {
+				continue
+			}
+			preemptionCtx.candidatesFound = true
 			preemptionCtx.snapshot.RemoveWorkload(candidate)
 			targets = append(targets, &Target{
 				WorkloadInfo: candidate,
@@ -257,6 +458,50 @@ func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target
 	return nil
 }
 
+// candidatesComparator returns the function ordering the equivalent
+// candidates for the objective, or nil if the candidates are preempted in
+// order.
+func candidatesComparator(preemptionCtx *preemptionCtx, objective config.PreemptionObjective) func(a, b *workload.Info) int {
+	var sign int
+	switch objective {
+	case config.PreemptionObjectiveFewestWorkloads:
+		// Larger candidates first, so that fewer of them are needed.
+		sign = -1
+	case config.PreemptionObjectiveFewestResources:
+		// Smaller candidates first, the unneeded larger ones are
+		// filled back.
+		sign = 1
+	default:
+		return nil
+	}
+	sizes := make(map[*workload.Info]float64)
+	size := func(wl *workload.Info) float64 {
+		if s, ok := sizes[wl]; ok {
+			return s
+		}
+		s := disruptedResources(preemptionCtx, wl)
+		sizes[wl] = s
+		return s
+	}
+	return func(a, b *workload.Info) int {
+		return sign * cmp.Compare(size(a), size(b))
+	}
+}
+
+// disruptedResources returns the usage of the workload in the flavor-resources
+// which need preemption, relative to the requests of the preemptor, so that
+// the different resources are comparable.
+func disruptedResources(preemptionCtx *preemptionCtx, wl *workload.Info) float64 {
+	var size float64
+	usage := wl.FlavorResourceUsage()
+	for fr := range preemptionCtx.frsNeedPreemption {
+		if req := preemptionCtx.workloadUsage.Quota[fr]; req > 0 {
+			size += float64(usage[fr]) / float64(req)
+		}
+	}
+	return size
+}
+
 func fillBackWorkloads(preemptionCtx *preemptionCtx, targets []*Target, allowBorrowing bool) []*Target {
 	// In the reverse order, check if any of the workloads can be added back.
 	for i := len(targets) - 2; i >= 0; i-- {
@@ -375,6 +620,7 @@ func (p *Preemptor) fairPreemptions(preemptionCtx *preemptionCtx, strategies []f
 	if len(candidates) == 0 {
 		return nil
 	}
+	preemptionCtx.candidatesFound = true
 	sort.Slice(candidates, CandidatesOrdering(candidates, preemptionCtx.preemptorCQ.Name, p.clock.Now()))
 	if logV := preemptionCtx.log.V(5); logV.Enabled() {
 		logV.Info("Simulating fair preemption", "candidates", workload.References(candidates), "resourcesRequiringPreemption", preemptionCtx.frsNeedPreemption.UnsortedList(), "preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj))
@@ -434,6 +680,9 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 			if !classical.WorkloadUsesResources(candidateWl, frsNeedPreemption) {
 				continue
 			}
+			if p.inAdmissionCooldown(candidateWl.Obj) || p.inReservationHold(candidateWl, cq) {
+				continue
+			}
 			candidates = append(candidates, candidateWl)
 		}
 	}
@@ -452,6 +701,9 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 				if !classical.WorkloadUsesResources(candidateWl, frsNeedPreemption) {
 					continue
 				}
+				if p.inAdmissionCooldown(candidateWl.Obj) || p.inReservationHold(candidateWl, cohortCQ) {
+					continue
+				}
 				candidates = append(candidates, candidateWl)
 			}
 		}
@@ -475,6 +727,18 @@ func cqIsBorrowing(cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[re
 // requestable resources and simulated usage of the ClusterQueue and its cohort,
 // if it belongs to one.
 func workloadFits(preemptionCtx *preemptionCtx, allowBorrowing bool) bool {
+	if !quotaFits(preemptionCtx, allowBorrowing) {
+		return false
+	}
+	tasResult := preemptionCtx.preemptorCQ.FindTopologyAssignmentsForWorkload(preemptionCtx.tasRequests, false)
+	return tasResult.Failure() == nil
+}
+
+// quotaFits determines if the workload requests would fit in the quota of the
+// ClusterQueue and its cohort, regardless of the topology. The workloads below
+// the borrowing minimum priority of the ClusterQueue never borrow.
+func quotaFits(preemptionCtx *preemptionCtx, allowBorrowing bool) bool {
+	allowBorrowing = allowBorrowing && preemptionCtx.preemptorCQ.MayBorrow(preemptionCtx.preemptor.Obj)
 	for fr, v := range preemptionCtx.workloadUsage.Quota {
 		if !allowBorrowing && preemptionCtx.preemptorCQ.BorrowingWith(fr, v) {
 			return false
@@ -483,8 +747,7 @@ func workloadFits(preemptionCtx *preemptionCtx, allowBorrowing bool) bool {
 			return false
 		}
 	}
-	tasResult := preemptionCtx.preemptorCQ.FindTopologyAssignmentsForWorkload(preemptionCtx.tasRequests, false)
-	return tasResult.Failure() == nil
+	return true
 }
 
 // workloadFitsForFairSharing is a lightweight wrapper around
@@ -542,6 +805,26 @@ func CandidatesOrdering(candidates []*workload.Info, cq kueue.ClusterQueueRefere
 	}
 }
 
+// inAdmissionCooldown returns true if the workload got the quota reserved
+// less than the admission cooldown ago, so it can't be preempted yet.
+func (p *Preemptor) inAdmissionCooldown(wl *kueue.Workload) bool {
+	if p.admissionCooldown <= 0 {
+		return false
+	}
+	now := p.clock.Now()
+	return now.Sub(quotaReservationTime(wl, now)) < p.admissionCooldown
+}
+
+// inReservationHold returns true if the quota reservation of the workload is
+// held by one of its Pending admission checks, so it can't be preempted.
+func (p *Preemptor) inReservationHold(wl *workload.Info, cq *cache.ClusterQueueSnapshot) bool {
+	if cq == nil {
+		return false
+	}
+	remaining, held := workload.ReservationHoldRemaining(wl.Obj, cq.ReservationHolds, p.clock.Now())
+	return held && remaining > 0
+}
+
 func quotaReservationTime(wl *kueue.Workload, now time.Time) time.Time {
 	cond := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
 	if cond == nil || cond.Status != metav1.ConditionTrue {
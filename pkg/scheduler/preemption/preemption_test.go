@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
@@ -58,7 +59,7 @@ var snapCmpOpts = cmp.Options{
 	cmp.AllowUnexported(hierarchy.Manager[*cache.ClusterQueueSnapshot, *cache.CohortSnapshot]{}),
 	cmpopts.IgnoreFields(hierarchy.Manager[*cache.ClusterQueueSnapshot, *cache.CohortSnapshot]{}, "cohortFactory"),
 	cmpopts.IgnoreFields(cache.CohortSnapshot{}, "Cohort"),
-	cmp.AllowUnexported(cache.ClusterQueueSnapshot{}),
+	cmp.AllowUnexported(cache.ClusterQueueSnapshot{}, cache.CohortSnapshot{}),
 	cmpopts.IgnoreFields(cache.ClusterQueueSnapshot{}, "ClusterQueue"),
 }
 
@@ -285,6 +286,9 @@ func TestPreemption(t *testing.T) {
 		assignment          flavorassigner.Assignment
 		wantPreempted       sets.Set[string]
 		disableLendingLimit bool
+		admissionCooldown   time.Duration
+		objective           config.PreemptionObjective
+		admissionChecks     []*kueue.AdmissionCheck
 	}{
 		"preempt lowest priority": {
 			clusterQueues: defaultClusterQueues,
@@ -1804,6 +1808,505 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New(targetKeyReason("/to-be-preempted", kueue.InCohortReclamationReason)),
 		},
+		"reclaim the quota borrowed from the fallback cohort regardless of the priority": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("owner").
+					Cohort("shared-pool").
+					Preemption(kueue.ClusterQueuePreemption{
+						ReclaimWithinCohort: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+					).Obj(),
+				utiltesting.MakeClusterQueue("guest").
+					Cohort("team").
+					FallbackCohort("shared-pool").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "0").
+						Obj(),
+					).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("fallback-borrower", "").
+					Priority(10).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("guest").FallbackCohort("shared-pool").Assignment(corev1.ResourceCPU, "default", "4").Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("incoming", "").
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "owner",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/fallback-borrower", kueue.InCohortReclamationReason)),
+		},
+		"reclaim the quota borrowed from the fallback cohort together with the quota borrowed in the cohort": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("owner").
+					Cohort("shared-pool").
+					Preemption(kueue.ClusterQueuePreemption{
+						ReclaimWithinCohort: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+					).Obj(),
+				utiltesting.MakeClusterQueue("member").
+					Cohort("shared-pool").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "0").
+						Obj(),
+					).Obj(),
+				utiltesting.MakeClusterQueue("guest").
+					Cohort("team").
+					FallbackCohort("shared-pool").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "0").
+						Obj(),
+					).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("fallback-borrower", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("guest").FallbackCohort("shared-pool").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("cohort-borrower", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("member").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("incoming", "").
+				Request(corev1.ResourceCPU, "4").
+				Obj(),
+			targetCQ: "owner",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(
+				targetKeyReason("/fallback-borrower", kueue.InCohortReclamationReason),
+				targetKeyReason("/cohort-borrower", kueue.InCohortReclamationReason),
+			),
+		},
+		"don't preempt a workload admitted within the cooldown": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-30*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-time.Hour),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			admissionCooldown: time.Minute,
+			wantPreempted:     sets.New(targetKeyReason("/mid", kueue.InClusterQueueReason)),
+		},
+		"preempt a workload once its cooldown expired": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-2*time.Minute),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-time.Hour),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			admissionCooldown: time.Minute,
+			wantPreempted:     sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
+		},
+		"no preemption when all the candidates are within the cooldown": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-20*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-time.Hour),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			admissionCooldown: time.Minute,
+			wantPreempted:     sets.New[string](),
+		},
+		"don't preempt a workload whose quota reservation is held by a pending admission check": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("standalone").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("default").
+							Resource(corev1.ResourceCPU, "4").
+							Obj(),
+					).
+					AdmissionChecks("provisioning").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+			},
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("provisioning").
+					ControllerName("provisioning-controller").
+					ReservationHoldSeconds(600).
+					Active(metav1.ConditionTrue).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "provisioning", State: kueue.CheckStatePending}).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "provisioning", State: kueue.CheckStateReady}).
+					Admitted(true).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/mid", kueue.InClusterQueueReason)),
+		},
+		"preempt a workload once the reservation hold of its pending admission check expired": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("standalone").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("default").
+							Resource(corev1.ResourceCPU, "4").
+							Obj(),
+					).
+					AdmissionChecks("provisioning").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+			},
+			admissionChecks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("provisioning").
+					ControllerName("provisioning-controller").
+					ReservationHoldSeconds(60).
+					Active(metav1.ConditionTrue).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "provisioning", State: kueue.CheckStatePending}).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+						now.Add(-5*time.Minute),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "provisioning", State: kueue.CheckStateReady}).
+					Admitted(true).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
+		},
+		"candidate order preempts several small workloads": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("small-1", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-2", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-20*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-3", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-30*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("large", "").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+						now.Add(-40*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "3").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/small-1", kueue.InClusterQueueReason), targetKeyReason("/small-2", kueue.InClusterQueueReason), targetKeyReason("/small-3", kueue.InClusterQueueReason)),
+		},
+		"fewest workloads objective preempts one large workload": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("small-1", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-2", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-20*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-3", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-30*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("large", "").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+						now.Add(-40*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "3").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			objective:     config.PreemptionObjectiveFewestWorkloads,
+			wantPreempted: sets.New(targetKeyReason("/large", kueue.InClusterQueueReason)),
+		},
+		"candidate order preempts one large workload": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("large", "").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "4").Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-1", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-20*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-2", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-30*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/large", kueue.InClusterQueueReason)),
+		},
+		"fewest resources objective preempts several small workloads": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("large", "").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "4").Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-1", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-20*time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("small-2", "").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+						now.Add(-30*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			objective:     config.PreemptionObjectiveFewestResources,
+			wantPreempted: sets.New(targetKeyReason("/small-1", kueue.InClusterQueueReason), targetKeyReason("/small-2", kueue.InClusterQueueReason)),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1819,6 +2322,9 @@ func TestPreemption(t *testing.T) {
 			for _, flv := range flavors {
 				cqCache.AddOrUpdateResourceFlavor(flv)
 			}
+			for _, ac := range tc.admissionChecks {
+				cqCache.AddOrUpdateAdmissionCheck(ac)
+			}
 			for _, cq := range tc.clusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
@@ -1838,8 +2344,8 @@ func TestPreemption(t *testing.T) {
 				t.Fatalf("Failed adding kueue scheme: %v", err)
 			}
 			recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.AdmissionName})
-			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
-			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload, reason, _ string) error {
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, tc.admissionCooldown, tc.objective, clocktesting.NewFakeClock(now))
+			preemptor.applyPreemption = func(ctx context.Context, w, _ *kueue.Workload, reason, _ string) error {
 				lock.Lock()
 				gotPreempted.Insert(targetKeyReason(workload.Key(w), reason))
 				lock.Unlock()
@@ -1928,15 +2434,148 @@ func TestFairPreemptions(t *testing.T) {
 			Obj(),
 	}
 	unitWl := *utiltesting.MakeWorkload("unit", "").Request(corev1.ResourceCPU, "1")
+	// The ClusterQueue b borrows mostly cpu, and the ClusterQueue c
+	// borrows mostly gpu, with the same dominant resource share.
+	gpuCQs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("a").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "4").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{
+				ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+			}).
+			Obj(),
+		utiltesting.MakeClusterQueue("b").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("c").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "4").Obj()).
+			Obj(),
+	}
+	gpuAdmitted := []kueue.Workload{
+		*utiltesting.MakeWorkload("b1", "").Priority(1).
+			Request(corev1.ResourceCPU, "4").Request("example.com/gpu", "4").
+			SimpleReserveQuota("b", "default", now).Obj(),
+		*utiltesting.MakeWorkload("b2", "").
+			Request(corev1.ResourceCPU, "3").Request("example.com/gpu", "1").
+			SimpleReserveQuota("b", "default", now).Obj(),
+		*utiltesting.MakeWorkload("c1", "").Priority(1).
+			Request(corev1.ResourceCPU, "4").Request("example.com/gpu", "4").
+			SimpleReserveQuota("c", "default", now).Obj(),
+		*utiltesting.MakeWorkload("c2", "").
+			Request(corev1.ResourceCPU, "1").Request("example.com/gpu", "3").
+			SimpleReserveQuota("c", "default", now).Obj(),
+	}
+	// The ClusterQueue b borrows both cpu and gpu, and the ClusterQueue d
+	// borrows only cpu, with a higher dominant resource share but a lower
+	// sum of the resource shares.
+	mixedCQs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("a").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "8").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{
+				ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+			}).
+			Obj(),
+		utiltesting.MakeClusterQueue("b").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "8").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("d").
+			Cohort("all").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource("example.com/gpu", "8").Obj()).
+			Obj(),
+	}
+	mixedAdmitted := []kueue.Workload{
+		*utiltesting.MakeWorkload("b1", "").Priority(1).
+			Request(corev1.ResourceCPU, "4").Request("example.com/gpu", "8").
+			SimpleReserveQuota("b", "default", now).Obj(),
+		*utiltesting.MakeWorkload("b2", "").
+			Request(corev1.ResourceCPU, "1").Request("example.com/gpu", "5").
+			SimpleReserveQuota("b", "default", now).Obj(),
+		*utiltesting.MakeWorkload("d1", "").Priority(1).
+			Request(corev1.ResourceCPU, "4").Request("example.com/gpu", "8").
+			SimpleReserveQuota("d", "default", now).Obj(),
+		*utiltesting.MakeWorkload("d2", "").
+			Request(corev1.ResourceCPU, "3").
+			SimpleReserveQuota("d", "default", now).Obj(),
+	}
 	cases := map[string]struct {
-		clusterQueues []*kueue.ClusterQueue
-		cohorts       []*kueuealpha.Cohort
-		strategies    []config.PreemptionStrategy
-		admitted      []kueue.Workload
-		incoming      *kueue.Workload
-		targetCQ      kueue.ClusterQueueReference
-		wantPreempted sets.Set[string]
+		clusterQueues   []*kueue.ClusterQueue
+		cohorts         []*kueuealpha.Cohort
+		strategies      []config.PreemptionStrategy
+		resourceWeights map[corev1.ResourceName]float64
+		shareMode       config.FairSharingShareMode
+		admitted        []kueue.Workload
+		incoming        *kueue.Workload
+		targetCQ        kueue.ClusterQueueReference
+		wantPreempted   sets.Set[string]
 	}{
+		"reclaim from the queue borrowing the most cpu, when cpu is dominant": {
+			clusterQueues:   gpuCQs,
+			resourceWeights: map[corev1.ResourceName]float64{"example.com/gpu": 0},
+			admitted:        gpuAdmitted,
+			incoming:        unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:        "a",
+			wantPreempted:   sets.New(targetKeyReason("/b2", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim from the queue borrowing the most gpu, when gpu is dominant": {
+			clusterQueues:   gpuCQs,
+			resourceWeights: map[corev1.ResourceName]float64{corev1.ResourceCPU: 0},
+			admitted:        gpuAdmitted,
+			incoming:        unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:        "a",
+			wantPreempted:   sets.New(targetKeyReason("/c2", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim from the queue borrowing the most gpu, when gpu is weighted higher": {
+			clusterQueues:   gpuCQs,
+			resourceWeights: map[corev1.ResourceName]float64{"example.com/gpu": 2},
+			admitted:        gpuAdmitted,
+			incoming:        unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:        "a",
+			wantPreempted:   sets.New(targetKeyReason("/c2", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim from the queue with the highest dominant resource share, for mixed workloads": {
+			// b: max(1/12 cpu, 5/24 gpu) = 208, d: 3/12 cpu = 250.
+			clusterQueues: mixedCQs,
+			shareMode:     config.FairSharingDominantResource,
+			admitted:      mixedAdmitted,
+			incoming:      unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:      "a",
+			wantPreempted: sets.New(targetKeyReason("/d2", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim from the queue with the highest weighted sum of the resource shares, for mixed workloads": {
+			// b: 1/12 cpu + 5/24 gpu = 291, d: 3/12 cpu = 250.
+			clusterQueues: mixedCQs,
+			shareMode:     config.FairSharingWeightedSum,
+			admitted:      mixedAdmitted,
+			incoming:      unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:      "a",
+			wantPreempted: sets.New(targetKeyReason("/b2", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim from the queue with the highest weighted sum of the resource shares, when gpu is weighted lower": {
+			// b: 1/12 cpu + 5/24 gpu * 0.5 = 187, d: 3/12 cpu = 250.
+			clusterQueues:   mixedCQs,
+			shareMode:       config.FairSharingWeightedSum,
+			resourceWeights: map[corev1.ResourceName]float64{"example.com/gpu": 0.5},
+			admitted:        mixedAdmitted,
+			incoming:        unitWl.Clone().Name("a_incoming").Obj(),
+			targetCQ:        "a",
+			wantPreempted:   sets.New(targetKeyReason("/d2", kueue.InCohortFairSharingReason)),
+		},
 		"reclaim nominal from user using the most": {
 			clusterQueues: baseCQs,
 			admitted: []kueue.Workload{
@@ -2733,7 +3372,9 @@ func TestFairPreemptions(t *testing.T) {
 			cl := utiltesting.NewClientBuilder().
 				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
 				Build()
-			cqCache := cache.New(cl)
+			cqCache := cache.New(cl,
+				cache.WithFairSharingResourceWeights(tc.resourceWeights),
+				cache.WithFairSharingShareMode(tc.shareMode))
 			for _, flv := range flavors {
 				cqCache.AddOrUpdateResourceFlavor(flv)
 			}
@@ -2754,7 +3395,8 @@ func TestFairPreemptions(t *testing.T) {
 			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{
 				Enable:               true,
 				PreemptionStrategies: tc.strategies,
-			}, clocktesting.NewFakeClock(now))
+				ResourceWeights:      tc.resourceWeights,
+			}, 0, "", clocktesting.NewFakeClock(now))
 
 			beforeSnapshot, err := cqCache.Snapshot(ctx)
 			if err != nil {
@@ -2835,6 +3477,167 @@ func TestCandidatesOrdering(t *testing.T) {
 	}
 }
 
+func TestGetTargetsAcrossFlavors(t *testing.T) {
+	now := time.Now()
+	flavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("alpha").Obj(),
+		utiltesting.MakeResourceFlavor("beta").Obj(),
+	}
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("alpha").
+				Resource(corev1.ResourceCPU, "4").
+				Obj(),
+			*utiltesting.MakeFlavorQuotas("beta").
+				Resource(corev1.ResourceCPU, "4").
+				Obj(),
+		).
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+		}).
+		Obj()
+	cases := map[string]struct {
+		admitted          []kueue.Workload
+		wantFlavor        kueue.ResourceFlavorReference
+		wantPreempted     sets.Set[string]
+		wantFailureReason string
+	}{
+		"preempt in the assigned flavor": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("alpha-low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "alpha", "4").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "4").Obj(), now).
+					Obj(),
+			},
+			wantFlavor:    "alpha",
+			wantPreempted: sets.New(targetKeyReason("/alpha-low", kueue.InClusterQueueReason)),
+		},
+		"preempt low priority workloads in another flavor of the resource group": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("alpha-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "alpha", "4").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-low-1", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "2").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-low-2", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "2").Obj(), now).
+					Obj(),
+			},
+			wantFlavor: "beta",
+			wantPreempted: sets.New(
+				targetKeyReason("/beta-low-1", kueue.InClusterQueueReason),
+				targetKeyReason("/beta-low-2", kueue.InClusterQueueReason),
+			),
+		},
+		"no candidates in any flavor": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("alpha-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "alpha", "4").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "4").Obj(), now).
+					Obj(),
+			},
+			wantFlavor:        "alpha",
+			wantFailureReason: PreemptionFailedNoCandidates,
+		},
+		"preempting the candidates doesn't free enough capacity in any flavor": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("alpha-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "alpha", "2").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("alpha-low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "alpha", "2").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "2").Obj(), now).
+					Obj(),
+				*utiltesting.MakeWorkload("beta-low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "beta", "2").Obj(), now).
+					Obj(),
+			},
+			wantFlavor:        "alpha",
+			wantFailureReason: PreemptionFailedInsufficientCapacity,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
+				Build()
+
+			cqCache := cache.New(cl)
+			for _, flv := range flavors {
+				cqCache.AddOrUpdateResourceFlavor(flv)
+			}
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+			}
+
+			gotPreempted := sets.New[string]()
+			preemptor := New(cl, workload.Ordering{}, record.NewFakeRecorder(10), config.FairSharing{}, 0, "", clocktesting.NewFakeClock(now))
+			preemptor.applyPreemption = func(ctx context.Context, w, _ *kueue.Workload, reason, _ string) error {
+				gotPreempted.Insert(targetKeyReason(workload.Key(w), reason))
+				return nil
+			}
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("in", "").
+				Request(corev1.ResourceCPU, "4").
+				Obj())
+			wlInfo.ClusterQueue = "cq"
+			flvAssigner := flavorassigner.New(wlInfo, snapshot.ClusterQueue("cq"), snapshot.ResourceFlavors, false, NewOracle(preemptor, snapshot))
+			assignment := flvAssigner.Assign(log, nil)
+			if mode := assignment.RepresentativeMode(); mode != flavorassigner.Preempt {
+				t.Fatalf("Unexpected assignment mode %v, want Preempt", mode)
+			}
+
+			gotAssignment, targets, gotFailureReason := preemptor.GetTargetsAcrossFlavors(log, *wlInfo, assignment, snapshot)
+			if gotFlavor := gotAssignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; gotFlavor != tc.wantFlavor {
+				t.Errorf("Unexpected assigned flavor, want=%s, got=%s", tc.wantFlavor, gotFlavor)
+			}
+			if _, err := preemptor.IssuePreemptions(ctx, wlInfo, targets); err != nil {
+				t.Fatalf("Failed doing preemption")
+			}
+			if diff := cmp.Diff(tc.wantPreempted, gotPreempted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Issued preemptions (-want,+got):\n%s", diff)
+			}
+			if gotFailureReason != tc.wantFailureReason {
+				t.Errorf("Unexpected failure reason, want=%q, got=%q", tc.wantFailureReason, gotFailureReason)
+			}
+		})
+	}
+}
+
 func singlePodSetAssignment(assignments flavorassigner.ResourceAssignment) flavorassigner.Assignment {
 	return flavorassigner.Assignment{
 		PodSets: []flavorassigner.PodSetAssignment{{
@@ -2877,6 +3680,72 @@ func TestPreemptionMessage(t *testing.T) {
 	}
 }
 
+func TestIssuePreemptionsRecordsPreemptionChain(t *testing.T) {
+	now := time.Now()
+	ctx, _ := utiltesting.ContextWithLog(t)
+	preemptor := utiltesting.MakeWorkload("preemptor", "default").
+		UID("preemptor-uid").
+		Priority(100).
+		Request(corev1.ResourceCPU, "2").
+		Obj()
+	victims := []*kueue.Workload{
+		utiltesting.MakeWorkload("victim1", "default").
+			UID("victim1-uid").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("victim2", "default").
+			UID("victim2-uid").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+			Obj(),
+	}
+	cl := utiltesting.NewFakeClientSSAAsSM(preemptor, victims[0], victims[1])
+
+	targets := make([]*Target, 0, len(victims))
+	for _, victim := range victims {
+		wl := &kueue.Workload{}
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(victim), wl); err != nil {
+			t.Fatalf("Failed getting victim: %v", err)
+		}
+		targets = append(targets, &Target{WorkloadInfo: workload.NewInfo(wl), Reason: kueue.InClusterQueueReason})
+	}
+	preemptorInfo := workload.NewInfo(preemptor)
+	preemptorInfo.ClusterQueue = "cq"
+
+	p := New(cl, workload.Ordering{}, &utiltesting.EventRecorder{}, config.FairSharing{}, 0, "", clocktesting.NewFakeClock(now))
+	preempted, err := p.IssuePreemptions(ctx, preemptorInfo, targets)
+	if err != nil {
+		t.Fatalf("Failed issuing preemptions: %v", err)
+	}
+	if preempted != len(victims) {
+		t.Errorf("Reported %d preemptions, want %d", preempted, len(victims))
+	}
+
+	wantPreemptedBy := &kueue.WorkloadPreemptionReference{Namespace: "default", Name: "preemptor", UID: "preemptor-uid"}
+	for _, victim := range victims {
+		gotVictim := &kueue.Workload{}
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(victim), gotVictim); err != nil {
+			t.Fatalf("Failed getting victim: %v", err)
+		}
+		if diff := cmp.Diff(wantPreemptedBy, gotVictim.Status.PreemptedBy); diff != "" {
+			t.Errorf("Unexpected preemptedBy of %s (-want,+got):\n%s", victim.Name, diff)
+		}
+	}
+
+	gotPreemptor := &kueue.Workload{}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(preemptor), gotPreemptor); err != nil {
+		t.Fatalf("Failed getting preemptor: %v", err)
+	}
+	wantPreemptedWorkloads := []kueue.WorkloadPreemptionReference{
+		{Namespace: "default", Name: "victim1", UID: "victim1-uid"},
+		{Namespace: "default", Name: "victim2", UID: "victim2-uid"},
+	}
+	if diff := cmp.Diff(wantPreemptedWorkloads, gotPreemptor.Status.PreemptedWorkloads); diff != "" {
+		t.Errorf("Unexpected preemptedWorkloads (-want,+got):\n%s", diff)
+	}
+}
+
 func TestHierarchicalPreemptions(t *testing.T) {
 	now := time.Now()
 	flavors := []*kueue.ResourceFlavor{
@@ -4048,8 +4917,8 @@ func TestHierarchicalPreemptions(t *testing.T) {
 				t.Fatalf("Failed adding kueue scheme: %v", err)
 			}
 			recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.AdmissionName})
-			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
-			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload, reason, _ string) error {
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, 0, "", clocktesting.NewFakeClock(now))
+			preemptor.applyPreemption = func(ctx context.Context, w, _ *kueue.Workload, reason, _ string) error {
 				lock.Lock()
 				gotPreempted.Insert(targetKeyReason(workload.Key(w), reason))
 				lock.Unlock()
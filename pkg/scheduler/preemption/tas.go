@@ -0,0 +1,153 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type candidateIterator interface {
+	Next(borrow bool) (*workload.Info, string)
+	Reset()
+}
+
+// requiredDomainScope identifies the PodSet of the preemptor which requires
+// all its pods to be placed within a single topology domain.
+type requiredDomainScope struct {
+	flavor      kueue.ResourceFlavorReference
+	tasFlavor   *cache.TASFlavorSnapshot
+	tasRequests *cache.TASPodSetRequests
+}
+
+// candidateDomains returns the IDs of the domains, at the required topology
+// level, in which the candidate is placed.
+func (s *requiredDomainScope) candidateDomains(candidate *workload.Info) sets.Set[utiltas.TopologyDomainID] {
+	domains := sets.New[utiltas.TopologyDomainID]()
+	for _, domainRequests := range candidate.TASUsage()[s.flavor] {
+		if id, found := s.tasFlavor.RequiredDomainID(s.tasRequests, domainRequests.Values); found {
+			domains.Insert(id)
+		}
+	}
+	return domains
+}
+
+// requiredDomainScopeFor returns the scope for the domain-scoped preemption,
+// or nil if the preemptor doesn't have a single TAS PodSet requiring a
+// topology domain.
+func requiredDomainScopeFor(preemptionCtx *preemptionCtx) *requiredDomainScope {
+	if len(preemptionCtx.tasRequests) != 1 {
+		return nil
+	}
+	for flavor, flavorTASRequests := range preemptionCtx.tasRequests {
+		tasFlavor := preemptionCtx.preemptorCQ.TASFlavors[flavor]
+		if len(flavorTASRequests) != 1 || tasFlavor == nil || !tasFlavor.RequiresDomain(&flavorTASRequests[0]) {
+			return nil
+		}
+		return &requiredDomainScope{
+			flavor:      flavor,
+			tasFlavor:   tasFlavor,
+			tasRequests: &flavorTASRequests[0],
+		}
+	}
+	return nil
+}
+
+// domainScopedPreemptions finds the targets for a workload which fits in the
+// quota, but requires preemption to fit in a single topology domain. The
+// candidates are considered separately for each domain at the required
+// topology level, so that the targets are all placed in the domain in which
+// the workload is going to fit. Among the domains, it chooses the one in
+// which the highest priority of the targets is the lowest, and then the one
+// with the fewest targets.
+// It returns nil if the workload can't fit in any domain this way.
+func (p *Preemptor) domainScopedPreemptions(preemptionCtx *preemptionCtx, candidates candidateIterator, allowBorrowing bool) []*Target {
+	scope := requiredDomainScopeFor(preemptionCtx)
+	if scope == nil || !quotaFits(preemptionCtx, allowBorrowing) {
+		return nil
+	}
+
+	// Collect the domains of the candidates, in the order of the candidates.
+	var domains []utiltas.TopologyDomainID
+	seen := sets.New[utiltas.TopologyDomainID]()
+	candidates.Reset()
+	for candidate, _ := candidates.Next(allowBorrowing); candidate != nil; candidate, _ = candidates.Next(allowBorrowing) {
+		for domain := range scope.candidateDomains(candidate) {
+			if !seen.Has(domain) {
+				seen.Insert(domain)
+				domains = append(domains, domain)
+			}
+		}
+	}
+
+	var best []*Target
+	for _, domain := range domains {
+		var targets []*Target
+		fits := false
+		candidates.Reset()
+		for candidate, reason := candidates.Next(allowBorrowing); candidate != nil; candidate, reason = candidates.Next(allowBorrowing) {
+			if !scope.candidateDomains(candidate).Has(domain) {
+				continue
+			}
+			if p.inAdmissionCooldown(candidate.Obj) || p.inReservationHold(candidate, preemptionCtx.snapshot.ClusterQueue(candidate.ClusterQueue)) {
+				continue
+			}
+			preemptionCtx.candidatesFound = true
+			preemptionCtx.snapshot.RemoveWorkload(candidate)
+			targets = append(targets, &Target{
+				WorkloadInfo: candidate,
+				Reason:       reason,
+			})
+			if workloadFits(preemptionCtx, allowBorrowing) {
+				targets = fillBackWorkloads(preemptionCtx, targets, allowBorrowing)
+				fits = true
+				break
+			}
+		}
+		restoreSnapshot(preemptionCtx.snapshot, targets)
+		if fits && (best == nil || betterTargets(targets, best)) {
+			best = targets
+		}
+	}
+	return best
+}
+
+// betterTargets returns true if preempting a is less disruptive than
+// preempting b, that is the highest priority of the targets is lower,
+// or it's equal and there are fewer targets.
+func betterTargets(a, b []*Target) bool {
+	aPriority, bPriority := highestPriority(a), highestPriority(b)
+	if aPriority != bPriority {
+		return aPriority < bPriority
+	}
+	return len(a) < len(b)
+}
+
+func highestPriority(targets []*Target) int32 {
+	var result int32
+	for i, target := range targets {
+		if p := priority.Priority(target.WorkloadInfo.Obj); i == 0 || p > result {
+			result = p
+		}
+	}
+	return result
+}
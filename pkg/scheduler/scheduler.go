@@ -22,16 +22,21 @@ import (
 	"maps"
 	"slices"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -76,6 +81,21 @@ type Scheduler struct {
 	// attempts since the last restart.
 	schedulingCycle int64
 
+	// maxAdmissionsPerCycle limits the number of workloads admitted within
+	// a single scheduling cycle. Zero means no limit.
+	maxAdmissionsPerCycle int
+	// deferredWorkloads holds the keys of the workloads which were deferred
+	// in the previous cycle because of maxAdmissionsPerCycle.
+	deferredWorkloads sets.Set[string]
+	// lastAdmissions holds the time of the last quota reservation in the
+	// ClusterQueues which set a minimum interval between admissions, while
+	// the interval hasn't elapsed.
+	lastAdmissions map[kueue.ClusterQueueReference]time.Time
+	// stateLock protects deferredWorkloads and lastAdmissions, which are only
+	// updated by the scheduling loop, against the concurrent reads of the
+	// dry-run scheduling.
+	stateLock sync.RWMutex
+
 	// Stubs.
 	applyAdmission func(context.Context, *kueue.Workload) error
 }
@@ -84,6 +104,9 @@ type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	fairSharing                 config.FairSharing
 	clock                       clock.Clock
+	maxAdmissionsPerCycle       int
+	admissionCooldown           time.Duration
+	preemptionObjective         config.PreemptionObjective
 }
 
 // Option configures the reconciler.
@@ -110,6 +133,36 @@ func WithFairSharing(fs *config.FairSharing) Option {
 	}
 }
 
+// WithMaxAdmissionsPerCycle limits the number of workloads admitted
+// within a single scheduling cycle.
+func WithMaxAdmissionsPerCycle(limit *int32) Option {
+	return func(o *options) {
+		if limit != nil {
+			o.maxAdmissionsPerCycle = int(*limit)
+		}
+	}
+}
+
+// WithAdmissionCooldown sets the period after the quota reservation during
+// which a workload can't be preempted.
+func WithAdmissionCooldown(cooldown *metav1.Duration) Option {
+	return func(o *options) {
+		if cooldown != nil {
+			o.admissionCooldown = cooldown.Duration
+		}
+	}
+}
+
+// WithPreemptionObjective sets how the workloads to preempt are chosen
+// among the candidates.
+func WithPreemptionObjective(objective *config.PreemptionObjective) Option {
+	return func(o *options) {
+		if objective != nil {
+			o.preemptionObjective = *objective
+		}
+	}
+}
+
 func WithClock(_ testing.TB, c clock.Clock) Option {
 	return func(o *options) {
 		o.clock = c
@@ -130,10 +183,12 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 		cache:                   cache,
 		client:                  cl,
 		recorder:                recorder,
-		preemptor:               preemption.New(cl, wo, recorder, options.fairSharing, options.clock),
+		preemptor:               preemption.New(cl, wo, recorder, options.fairSharing, options.admissionCooldown, options.preemptionObjective, options.clock),
 		admissionRoutineWrapper: routine.DefaultWrapper,
 		workloadOrdering:        wo,
 		clock:                   options.clock,
+		maxAdmissionsPerCycle:   options.maxAdmissionsPerCycle,
+		lastAdmissions:          make(map[kueue.ClusterQueueReference]time.Time),
 	}
 	s.applyAdmission = s.applyAdmissionWithSSA
 	return s
@@ -193,23 +248,106 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		log.Error(err, "failed to build snapshot for scheduling")
 		return wait.SlowDown
 	}
+	snapshot.WithholdPendingScheduledQuotas(s.clock.Now())
 	logSnapshotIfVerbose(log, snapshot)
 
 	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
 	entries := s.nominate(ctx, headWorkloads, snapshot)
 
-	// 4. Create iterator which returns ordered entries.
-	iterator := makeIterator(ctx, entries, s.workloadOrdering, s.fairSharing.Enable)
+	// 4. Admit or preempt for the entries, in order.
+	budget := newAdmissionBudget(s.maxAdmissionsPerCycle, s.deferredWorkloads, entries)
+	skippedPreemptions := s.processEntries(ctx, snapshot, entries, budget, s.lastAdmissions, &schedulerApplier{s: s})
+
+	// 5. Requeue the heads that were not scheduled.
+	result := metrics.AdmissionResultInadmissible
+	for _, e := range entries {
+		logAdmissionAttemptIfVerbose(log, &e)
+		if e.status != assumed {
+			if reason := e.failedPreemptionReason(); reason != "" {
+				log.V(3).Info("The preemptions issued by the workload didn't make room for it", "workload", klog.KObj(e.Obj), "reason", reason)
+				metrics.ReportPreemptionFailure(e.ClusterQueue, reason)
+			}
+			s.requeueAndUpdate(ctx, e)
+		} else {
+			result = metrics.AdmissionResultSuccess
+		}
+	}
+	reportSkippedPreemptions(skippedPreemptions)
+	s.stateLock.Lock()
+	s.deferredWorkloads = budget.deferred
+	s.pruneLastAdmissions(snapshot)
+	s.stateLock.Unlock()
+	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
+	metrics.SchedulingCycle(s.clock.Since(startTime), len(entries))
+	if result != metrics.AdmissionResultSuccess {
+		return wait.SlowDown
+	}
+	return wait.KeepGoing
+}
+
+// cycleApplier applies the decisions taken for the entries of a scheduling cycle.
+type cycleApplier interface {
+	// preempt issues the preemptions of the targets of the entry.
+	preempt(ctx context.Context, e *entry)
+	// admit reserves the quota for the entry in the ClusterQueue.
+	admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error
+}
+
+// schedulerApplier applies the decisions to the cache and the API objects.
+type schedulerApplier struct {
+	s *Scheduler
+}
+
+func (a *schedulerApplier) preempt(ctx context.Context, e *entry) {
+	s := a.s
+	log := ctrl.LoggerFrom(ctx)
+	preempted, err := s.preemptor.IssuePreemptions(ctx, &e.Info, e.preemptionTargets)
+	if err != nil {
+		log.Error(err, "Failed to preempt workloads")
+	}
+	if preempted != 0 {
+		e.preemptionPath = preemptionPath(e.preemptionTargets)
+		s.recordSchedulingDecision(log, e.Obj, e, "", e.preemptionTargets)
+		e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", preempted)
+		e.requeueReason = queue.RequeueReasonPendingPreemption
+	}
+}
 
-	// 5. Admit entries, ensuring that no more than one workload gets
-	// admitted by a cohort (if borrowing).
-	// This is because there can be other workloads deeper in a clusterQueue whose
-	// head got admitted that should be scheduled in the cohort before the heads
-	// of other clusterQueues.
+func (a *schedulerApplier) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
+	s := a.s
+	log := ctrl.LoggerFrom(ctx)
+	if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+		log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
+		// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
+		// Block admission until all currently admitted workloads are in
+		// PodsReady condition if the waitForPodsReady is enabled
+		workload.UnsetQuotaReservationWithCondition(e.Obj, "Waiting", "waiting for all admitted workloads to be in PodsReady condition", s.clock.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, s.client, e.Obj, false, s.clock); err != nil {
+			log.Error(err, "Could not update Workload status")
+		}
+		s.cache.WaitForPodsReady(ctx)
+		log.V(5).Info("Finished waiting for all admitted workloads to be in the PodsReady condition")
+	}
+	return s.admit(ctx, e, cq)
+}
+
+// processEntries admits the entries, or issues preemptions for them, through the applier,
+// in the order given by the iterator. The usage of the admitted entries is added to the snapshot,
+// ensuring that no more than one workload gets admitted by a cohort (if borrowing).
+// This is because there can be other workloads deeper in a clusterQueue whose
+// head got admitted that should be scheduled in the cohort before the heads
+// of other clusterQueues.
+// The lastAdmissions are only read, to delay the admissions in the ClusterQueues with a
+// minimum interval between admissions.
+// It returns the number of preemptions skipped by ClusterQueue.
+func (s *Scheduler) processEntries(ctx context.Context, snapshot *cache.Snapshot, entries []entry, budget *admissionBudget, lastAdmissions map[kueue.ClusterQueueReference]time.Time, applier cycleApplier) map[kueue.ClusterQueueReference]int {
+	log := ctrl.LoggerFrom(ctx)
+	iterator := makeIterator(ctx, entries, s.workloadOrdering, s.fairSharing.Enable)
 	preemptedWorkloads := make(preemption.PreemptedWorkloads)
 	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
 	for iterator.hasNext() {
 		e := iterator.pop()
+		wasDeferred := budget.pop(e)
 
 		cq := snapshot.ClusterQueue(e.ClusterQueue)
 		log := log.WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", string(e.ClusterQueue)))
@@ -225,6 +363,28 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		}
 		log.V(2).Info("Attempting to schedule workload")
 
+		needsChecks := requiresAdmissionChecks(cq, &e.assignment)
+		if needsChecks && !cq.HasAdmissionCheckSlot() {
+			log.V(3).Info("Skipping workload as the limit of workloads in the admission checks is reached", "maxConcurrentChecks", *cq.MaxConcurrentChecks)
+			e.inadmissibleMsg = fmt.Sprintf("Waiting for a free slot for the admission checks in ClusterQueue %s, the limit of %d workloads is reached", cq.Name, *cq.MaxConcurrentChecks)
+			e.LastAssignment = nil
+			continue
+		}
+
+		if msg := cq.LocalQueueLimitsMessage(queue.KeyFromWorkload(e.Obj), e.assignmentUsage().Quota.FlattenFlavors()); msg != "" {
+			log.V(3).Info("Skipping workload as it doesn't fit the resource limits of the LocalQueues", "reason", msg)
+			e.inadmissibleMsg = fmt.Sprintf("Workload doesn't fit the resource limits of the LocalQueues: %s", msg)
+			e.LastAssignment = nil
+			continue
+		}
+
+		if msg := snapshot.NamespaceLimitsMessage(e.Obj.Namespace, e.assignmentUsage().Quota.FlattenFlavors()); msg != "" {
+			log.V(3).Info("Skipping workload as it doesn't fit the resource limits of its namespace", "reason", msg)
+			e.inadmissibleMsg = fmt.Sprintf("Workload doesn't fit the resource limits of its namespace: %s", msg)
+			e.LastAssignment = nil
+			continue
+		}
+
 		if mode == flavorassigner.Preempt && len(e.preemptionTargets) == 0 {
 			log.V(2).Info("Workload requires preemption, but there are no candidate workloads allowed for preemption", "preemption", cq.Preemption)
 			// we reserve capacity if we are uncertain
@@ -250,63 +410,138 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		}
 
 		usage := e.assignmentUsage()
-		if !fits(cq, &usage, preemptedWorkloads, e.preemptionTargets) {
+		startsLater := e.assignment.StartTime() != nil
+		if !fits(cq, &usage, startsLater, preemptedWorkloads, e.preemptionTargets) {
 			setSkipped(e, "Workload no longer fits after processing another workload")
 			if mode == flavorassigner.Preempt {
 				skippedPreemptions[cq.Name]++
 			}
 			continue
 		}
+		if remaining := s.admissionIntervalRemaining(cq, lastAdmissions); mode != flavorassigner.Preempt && remaining > 0 {
+			log.V(3).Info("Delaying workload as the minimum interval between admissions in the ClusterQueue hasn't elapsed", "minAdmissionInterval", cq.MinAdmissionInterval, "remaining", remaining)
+			setSkipped(e, fmt.Sprintf("Waiting %s for the minimum interval between admissions in ClusterQueue %s to elapse", remaining, cq.Name))
+			continue
+		}
+		if mode != flavorassigner.Preempt && !budget.take(wasDeferred) {
+			log.V(3).Info("Deferring workload to the next cycle as the limit of admissions per cycle is reached", "maxAdmissionsPerCycle", s.maxAdmissionsPerCycle)
+			setSkipped(e, "Workload deferred to the next scheduling cycle as the limit of admissions per cycle is reached")
+			budget.deferred.Insert(workload.Key(e.Obj))
+			continue
+		}
 		preemptedWorkloads.Insert(e.preemptionTargets)
+		if startsLater {
+			cq.UsePendingScheduledQuota(usage.Quota)
+		}
 		cq.AddUsage(usage)
+		cq.AddLocalQueueUsage(queue.KeyFromWorkload(e.Obj), usage.Quota.FlattenFlavors())
+		snapshot.AddNamespaceUsage(e.Obj.Namespace, usage.Quota.FlattenFlavors())
 
 		if e.assignment.RepresentativeMode() == flavorassigner.Preempt {
 			// If preemptions are issued, the next attempt should try all the flavors.
 			e.LastAssignment = nil
-			preempted, err := s.preemptor.IssuePreemptions(ctx, &e.Info, e.preemptionTargets)
-			if err != nil {
-				log.Error(err, "Failed to preempt workloads")
-			}
-			if preempted != 0 {
-				e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", preempted)
-				e.requeueReason = queue.RequeueReasonPendingPreemption
+			if blockedMsg, err := s.preemptionBlockedByPodDisruptionBudget(ctx, e); err != nil {
+				log.Error(err, "Failed to check the PodDisruptionBudgets of the preemption targets")
+				continue
+			} else if blockedMsg != "" {
+				log.V(3).Info("Deferring the preemption as it would violate a PodDisruptionBudget", "reason", blockedMsg)
+				e.inadmissibleMsg += fmt.Sprintf(". Preemption deferred: %s", blockedMsg)
+				continue
 			}
+			applier.preempt(ctx, e)
 			continue
 		}
-		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
-			log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
-			// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
-			// Block admission until all currently admitted workloads are in
-			// PodsReady condition if the waitForPodsReady is enabled
-			workload.UnsetQuotaReservationWithCondition(e.Obj, "Waiting", "waiting for all admitted workloads to be in PodsReady condition", s.clock.Now())
-			if err := workload.ApplyAdmissionStatus(ctx, s.client, e.Obj, false, s.clock); err != nil {
-				log.Error(err, "Could not update Workload status")
-			}
-			s.cache.WaitForPodsReady(ctx)
-			log.V(5).Info("Finished waiting for all admitted workloads to be in the PodsReady condition")
+		if needsChecks {
+			cq.TakeAdmissionCheckSlot()
 		}
 		e.status = nominated
-		if err := s.admit(ctx, e, cq); err != nil {
+		if err := applier.admit(ctx, e, cq); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
 		}
 	}
+	return skippedPreemptions
+}
 
-	// 6. Requeue the heads that were not scheduled.
-	result := metrics.AdmissionResultInadmissible
-	for _, e := range entries {
-		logAdmissionAttemptIfVerbose(log, &e)
-		if e.status != assumed {
-			s.requeueAndUpdate(ctx, e)
-		} else {
-			result = metrics.AdmissionResultSuccess
+// admissionIntervalRemaining returns the time left before the ClusterQueue
+// can reserve the quota for another workload, according to its minimum
+// interval between admissions.
+func (s *Scheduler) admissionIntervalRemaining(cq *cache.ClusterQueueSnapshot, lastAdmissions map[kueue.ClusterQueueReference]time.Time) time.Duration {
+	last, found := lastAdmissions[cq.Name]
+	if !found {
+		return 0
+	}
+	return max(0, cq.MinAdmissionInterval-s.clock.Since(last))
+}
+
+// pruneLastAdmissions forgets the last admissions of the ClusterQueues whose
+// minimum interval between admissions has elapsed, or which are gone.
+// It must be called with the stateLock held.
+func (s *Scheduler) pruneLastAdmissions(snapshot *cache.Snapshot) {
+	for name, last := range s.lastAdmissions {
+		if cq := snapshot.ClusterQueue(name); cq == nil || s.clock.Since(last) >= cq.MinAdmissionInterval {
+			delete(s.lastAdmissions, name)
 		}
 	}
-	reportSkippedPreemptions(skippedPreemptions)
-	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
-	if result != metrics.AdmissionResultSuccess {
-		return wait.SlowDown
+}
+
+// admissionBudget bounds the number of workloads admitted within a single
+// scheduling cycle. Slots are held for the workloads deferred in the previous
+// cycle, so that the same workloads are not deferred cycle after cycle.
+type admissionBudget struct {
+	limit    int
+	admitted int
+	// previouslyDeferred are the workloads deferred in the previous cycle.
+	previouslyDeferred sets.Set[string]
+	// held is the number of slots held for previously deferred workloads
+	// which were not processed yet in this cycle.
+	held int
+	// deferred are the workloads deferred in this cycle.
+	deferred sets.Set[string]
+}
+
+func newAdmissionBudget(limit int, previouslyDeferred sets.Set[string], entries []entry) *admissionBudget {
+	b := &admissionBudget{
+		limit:              limit,
+		previouslyDeferred: previouslyDeferred,
+		deferred:           sets.New[string](),
 	}
-	return wait.KeepGoing
+	if limit == 0 {
+		return b
+	}
+	for i := range entries {
+		if entries[i].assignment.RepresentativeMode() == flavorassigner.Fit && previouslyDeferred.Has(workload.Key(entries[i].Obj)) {
+			b.held++
+		}
+	}
+	return b
+}
+
+// pop releases the slot held for the entry, if any, and returns whether the
+// entry was deferred in the previous cycle.
+func (b *admissionBudget) pop(e *entry) bool {
+	if b.limit == 0 || !b.previouslyDeferred.Has(workload.Key(e.Obj)) {
+		return false
+	}
+	if e.assignment.RepresentativeMode() == flavorassigner.Fit {
+		b.held--
+	}
+	return true
+}
+
+// take consumes a slot, returning false if there is no slot available.
+func (b *admissionBudget) take(wasDeferred bool) bool {
+	if b.limit == 0 {
+		return true
+	}
+	available := b.limit - b.admitted
+	if !wasDeferred {
+		available -= b.held
+	}
+	if available <= 0 {
+		return false
+	}
+	b.admitted++
+	return true
 }
 
 type entryStatus string
@@ -333,12 +568,46 @@ type entry struct {
 	requeueReason        queue.RequeueReason
 	preemptionTargets    []*preemption.Target
 	clusterQueueSnapshot *cache.ClusterQueueSnapshot
+	// exceedsQueueCapacity indicates that the workload requests more resources
+	// than the ClusterQueue could ever admit.
+	exceedsQueueCapacity bool
+	// preemptionPath is the admission path of the preemptions issued for the
+	// workload in the cycle, if any.
+	preemptionPath kueue.AdmissionPath
+	// preemptionFailureReason is the reason why the workload doesn't fit in
+	// the ClusterQueue even by preempting other workloads, if it doesn't.
+	preemptionFailureReason string
 }
 
 func (e *entry) assignmentUsage() workload.Usage {
 	return e.assignment.Usage
 }
 
+// admission returns the admission of the entry in the ClusterQueue, according to its assignment.
+func (e *entry) admission(cq *cache.ClusterQueueSnapshot) *kueue.Admission {
+	admission := &kueue.Admission{
+		ClusterQueue:      e.ClusterQueue,
+		PodSetAssignments: e.assignment.ToAPI(),
+		StartTime:         e.assignment.StartTime(),
+	}
+	if e.assignment.Usage.Fallback && cq.FallbackCohort != nil {
+		admission.FallbackCohort = cq.FallbackCohort.Name
+	}
+	return admission
+}
+
+// pendingReason returns the reason used for the QuotaReserved condition and
+// the event when the workload couldn't reserve quota.
+func (e *entry) pendingReason() string {
+	if e.requeueReason == queue.RequeueReasonNamespaceMismatch {
+		return kueue.WorkloadNamespaceNotAllowed
+	}
+	if e.exceedsQueueCapacity {
+		return kueue.WorkloadExceedsQueueCapacity
+	}
+	return "Pending"
+}
+
 // nominate returns the workloads with their requirements (resource flavors, borrowing) if
 // they were admitted by the clusterQueues in the snapshot.
 func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap *cache.Snapshot) []entry {
@@ -368,30 +637,91 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		} else if err := workload.ValidateLimitRange(ctx, s.client, &w); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("%s: %v", errLimitRangeConstraintsUnsatisfiedResources, err.ToAggregate())
 		} else {
-			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap)
+			wi := e.Info.WithAdmissionCheckResources().WithResourceOverheads(e.clusterQueueSnapshot.ResourceOverheads)
+			e.assignment, e.preemptionTargets, e.preemptionFailureReason = s.getAssignments(log, wi, snap)
 			e.inadmissibleMsg = e.assignment.Message()
 			e.LastAssignment = &e.assignment.LastState
+			e.exceedsQueueCapacity = e.assignment.RepresentativeMode() == flavorassigner.NoFit && exceedsQueueCapacity(wi, e.clusterQueueSnapshot)
 		}
 		entries = append(entries, e)
 	}
 	return entries
 }
 
-func fits(cq *cache.ClusterQueueSnapshot, usage *workload.Usage, preemptedWorkloads preemption.PreemptedWorkloads, newTargets []*preemption.Target) bool {
+// exceedsQueueCapacity returns whether a pod set of the workload requests more
+// of a resource than the maximum capacity of the ClusterQueue, including the
+// capacity it can borrow, in each of the flavors of the resource. Such a
+// workload can never be admitted by the ClusterQueue.
+func exceedsQueueCapacity(wl *workload.Info, cq *cache.ClusterQueueSnapshot) bool {
+	if wl.CanBePartiallyAdmitted() || cq.FallbackCohort != nil {
+		return false
+	}
+	for _, ps := range wl.TotalRequests {
+		for res, val := range ps.Requests {
+			rg := cq.RGByResource(res)
+			if rg == nil {
+				continue
+			}
+			fitsInFlavor := slices.ContainsFunc(rg.Flavors, func(flavor kueue.ResourceFlavorReference) bool {
+				return val <= cq.PotentialAvailable(resources.FlavorResource{Flavor: flavor, Resource: res})
+			})
+			if !fitsInFlavor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresAdmissionChecks returns whether the workload needs to pass admission
+// checks of the ClusterQueue, given the flavors of the assignment.
+func requiresAdmissionChecks(cq *cache.ClusterQueueSnapshot, assignment *flavorassigner.Assignment) bool {
+	for _, flavors := range cq.AdmissionChecks {
+		if flavors.Len() == 0 {
+			return true
+		}
+		for _, psAssignment := range assignment.PodSets {
+			for _, flvAssignment := range psAssignment.Flavors {
+				if flavors.Has(flvAssignment.Name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func fits(cq *cache.ClusterQueueSnapshot, usage *workload.Usage, startsLater bool, preemptedWorkloads preemption.PreemptedWorkloads, newTargets []*preemption.Target) bool {
 	workloads := slices.Collect(maps.Values(preemptedWorkloads))
 	for _, target := range newTargets {
 		workloads = append(workloads, target.WorkloadInfo)
 	}
 	revertUsage := cq.SimulateWorkloadRemoval(workloads)
 	defer revertUsage()
+	// The workloads admitted to start at the start time of the scheduled
+	// quota can use the pending scheduled quota.
+	if startsLater {
+		revertScheduled := cq.UsePendingScheduledQuota(usage.Quota)
+		defer revertScheduled()
+	}
 	return cq.Fits(*usage)
 }
 
+// preemptionBlockedByPodDisruptionBudget returns a message if issuing the
+// preemptions of the entry would violate a PodDisruptionBudget.
+func (s *Scheduler) preemptionBlockedByPodDisruptionBudget(ctx context.Context, e *entry) (string, error) {
+	if !features.Enabled(features.PreemptionRespectsPodDisruptionBudgets) {
+		return "", nil
+	}
+	return s.preemptor.BlockedByPodDisruptionBudget(ctx, e.preemptionTargets)
+}
+
 // resourcesToReserve calculates how much of the available resources in cq/cohort assignment should be reserved.
 func resourcesToReserve(e *entry, cq *cache.ClusterQueueSnapshot) workload.Usage {
 	return workload.Usage{
-		Quota: quotaResourcesToReserve(e, cq),
-		TAS:   e.assignment.Usage.TAS,
+		Quota:    quotaResourcesToReserve(e, cq),
+		TAS:      e.assignment.Usage.TAS,
+		Fallback: e.assignment.Usage.Fallback,
 	}
 }
 
@@ -420,27 +750,49 @@ type partialAssignment struct {
 	preemptionTargets []*preemption.Target
 }
 
-func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
-	assignment, targets := s.getInitialAssignments(log, wl, snap)
+// getAssignments returns the assignment of the workload, along with the
+// preemption targets when it needs to preempt other workloads, or the reason
+// why preemption can't make room for it when it doesn't fit.
+func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target, string) {
+	assignment, targets, failureReason := s.getInitialAssignments(log, wl, snap)
 	cq := snap.ClusterQueue(wl.ClusterQueue)
 	updateAssignmentForTAS(cq, wl, &assignment, targets)
-	return assignment, targets
+	return assignment, targets, failureReason
 }
 
-func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
+func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target, string) {
 	cq := snap.ClusterQueue(wl.ClusterQueue)
 	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap))
 	fullAssignment := flvAssigner.Assign(log, nil)
 
+	// A workload which may not preempt is only admitted if it fits without
+	// preemption.
+	mayPreempt := wl.MayPreempt()
 	arm := fullAssignment.RepresentativeMode()
 	if arm == flavorassigner.Fit {
-		return fullAssignment, nil
+		if mayPreempt && fullAssignment.Borrows() > 0 && ptr.Deref(cq.Preemption.BorrowingPreference, kueue.PreferBorrowing) == kueue.PreferPreemption {
+			// Try to fit within the nominal quota by preempting workloads
+			// in the ClusterQueue, and fall back to borrowing otherwise.
+			noBorrowingAssignment := fullAssignment.WithoutBorrowing()
+			if targets := s.preemptor.GetTargetsWithoutBorrowing(log, *wl, noBorrowingAssignment, snap); len(targets) > 0 {
+				log.V(3).Info("Preferring preemption over borrowing", "targets", len(targets))
+				return noBorrowingAssignment, targets, ""
+			}
+		}
+		return fullAssignment, nil, ""
 	}
 
+	// With the NoFit mode, the flavor assigner found that preempting the
+	// candidates can't free enough capacity.
+	failureReason := preemption.PreemptionFailedInsufficientCapacity
 	if arm == flavorassigner.Preempt {
-		faPreemptionTargets := s.preemptor.GetTargets(log, *wl, fullAssignment, snap)
-		if len(faPreemptionTargets) > 0 {
-			return fullAssignment, faPreemptionTargets
+		failureReason = preemption.PreemptionFailedNoCandidates
+		if mayPreempt {
+			faAssignment, faPreemptionTargets, reason := s.preemptor.GetTargetsAcrossFlavors(log, *wl, fullAssignment, snap)
+			if len(faPreemptionTargets) > 0 {
+				return faAssignment, faPreemptionTargets, ""
+			}
+			failureReason = reason
 		}
 	}
 
@@ -452,8 +804,8 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 				return &partialAssignment{assignment: assignment}, true
 			}
 
-			if mode == flavorassigner.Preempt {
-				preemptionTargets := s.preemptor.GetTargets(log, *wl, assignment, snap)
+			if mode == flavorassigner.Preempt && mayPreempt {
+				assignment, preemptionTargets, _ := s.preemptor.GetTargetsAcrossFlavors(log, *wl, assignment, snap)
 				if len(preemptionTargets) > 0 {
 					return &partialAssignment{assignment: assignment, preemptionTargets: preemptionTargets}, true
 				}
@@ -461,15 +813,20 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 			return nil, false
 		})
 		if pa, found := reducer.Search(); found {
-			return pa.assignment, pa.preemptionTargets
+			return pa.assignment, pa.preemptionTargets, ""
 		}
 	}
-	return fullAssignment, nil
+	return fullAssignment, nil, failureReason
 }
 
 func updateAssignmentForTAS(cq *cache.ClusterQueueSnapshot, wl *workload.Info, assignment *flavorassigner.Assignment, targets []*preemption.Target) {
-	if features.Enabled(features.TopologyAwareScheduling) && assignment.RepresentativeMode() == flavorassigner.Preempt && (wl.IsRequestingTAS() || cq.IsTASOnly()) {
+	if features.Enabled(features.TopologyAwareScheduling) && assignment.RepresentativeMode() == flavorassigner.Preempt {
+		// The TAS requests include the PodSets which don't request TAS explicitly,
+		// but are assigned a flavor with a default required topology level.
 		tasRequests := assignment.WorkloadsTopologyRequests(wl, cq)
+		if len(tasRequests) == 0 {
+			return
+		}
 		var tasResult cache.TASAssignmentsResult
 		if len(targets) > 0 {
 			var targetWorkloads []*workload.Info
@@ -492,18 +849,85 @@ func updateAssignmentForTAS(cq *cache.ClusterQueueSnapshot, wl *workload.Info, a
 	}
 }
 
+// pendingAdmissionPath returns the admission path to record in the status of
+// the pending workload of the entry: the path of the preemptions issued in the
+// cycle, or the path of the preemptions issued before while the workload waits
+// for them. The path is cleared once the workload doesn't fit even with
+// preemption, as the preemptions issued before didn't make room for it.
+func (e *entry) pendingAdmissionPath() kueue.AdmissionPath {
+	if e.preemptionPath != "" {
+		return e.preemptionPath
+	}
+	if e.preemptionFailureReason != "" {
+		return ""
+	}
+	return e.Obj.Status.AdmissionPath
+}
+
+// failedPreemptionReason returns the reason why the preemptions issued by the
+// workload of the entry in the previous cycles didn't make room for it, if
+// they didn't: the workload doesn't fit even by preempting other workloads,
+// or it needs to preempt workloads which it didn't preempt before.
+func (e *entry) failedPreemptionReason() string {
+	if e.Obj.Status.AdmissionPath == "" {
+		// The workload isn't waiting for the preemptions it issued.
+		return ""
+	}
+	if e.preemptionFailureReason != "" {
+		return e.preemptionFailureReason
+	}
+	if e.preemptionPath != "" && !preemptedBefore(e.Obj, e.preemptionTargets) {
+		return preemption.PreemptionFailedInsufficientCapacity
+	}
+	return ""
+}
+
+// preemptedBefore returns whether all the targets were preempted the last time
+// the workload issued preemptions.
+func preemptedBefore(wl *kueue.Workload, targets []*preemption.Target) bool {
+	for _, target := range targets {
+		if !slices.Contains(wl.Status.PreemptedWorkloads, workload.PreemptionReference(target.WorkloadInfo.Obj)) {
+			return false
+		}
+	}
+	return true
+}
+
+// admissionPath returns how the quota of the entry was obtained: by the
+// preemptions it issued before, as recorded in the status of the pending
+// workload, or by fitting in the nominal quota or by borrowing otherwise.
+func admissionPath(e *entry) kueue.AdmissionPath {
+	if e.Obj.Status.AdmissionPath != "" {
+		return e.Obj.Status.AdmissionPath
+	}
+	if e.assignment.Borrowing > 0 {
+		return kueue.AdmissionPathBorrowing
+	}
+	return kueue.AdmissionPathNominal
+}
+
+// preemptionPath returns the admission path of a workload preempting the
+// targets: within its ClusterQueue, or reclaiming within the cohort when any
+// of the targets belongs to another ClusterQueue.
+func preemptionPath(targets []*preemption.Target) kueue.AdmissionPath {
+	for _, t := range targets {
+		if t.Reason != kueue.InClusterQueueReason {
+			return kueue.AdmissionPathReclaimWithinCohort
+		}
+	}
+	return kueue.AdmissionPathPreemptionWithinClusterQueue
+}
+
 // admit sets the admitting clusterQueue and flavors into the workload of
 // the entry, and asynchronously updates the object in the apiserver after
 // assuming it in the cache.
 func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
 	log := ctrl.LoggerFrom(ctx)
 	newWorkload := e.Obj.DeepCopy()
-	admission := &kueue.Admission{
-		ClusterQueue:      e.ClusterQueue,
-		PodSetAssignments: e.assignment.ToAPI(),
-	}
+	admission := e.admission(cq)
 
 	workload.SetQuotaReservation(newWorkload, admission, s.clock)
+	newWorkload.Status.AdmissionPath = admissionPath(e)
 	if workload.HasAllChecks(newWorkload, workload.AdmissionChecksForWorkload(log, newWorkload, cq.AdmissionChecks)) {
 		// sync Admitted, ignore the result since an API update is always done.
 		_ = workload.SyncAdmittedCondition(newWorkload, s.clock.Now())
@@ -513,19 +937,25 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 	}
 	e.status = assumed
 	log.V(2).Info("Workload assumed in the cache")
+	if cq.MinAdmissionInterval > 0 {
+		s.stateLock.Lock()
+		s.lastAdmissions[cq.Name] = s.clock.Now()
+		s.stateLock.Unlock()
+	}
 
 	s.admissionRoutineWrapper.Run(func() {
 		err := s.applyAdmission(ctx, newWorkload)
 		if err == nil {
 			waitTime := workload.QueuedWaitTime(newWorkload)
 			s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "QuotaReserved", "Quota reserved in ClusterQueue %v, wait time since queued was %.0fs", admission.ClusterQueue, waitTime.Seconds())
+			s.recordSchedulingDecision(log, newWorkload, e, newWorkload.Status.AdmissionPath, nil)
 			metrics.QuotaReservedWorkload(admission.ClusterQueue, waitTime)
 			if features.Enabled(features.LocalQueueMetrics) {
 				metrics.LocalQueueQuotaReservedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
 			}
 			if workload.IsAdmitted(newWorkload) {
 				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was 0s", admission.ClusterQueue)
-				metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
+				metrics.AdmittedWorkload(admission.ClusterQueue, newWorkload.Spec.PriorityClassName, waitTime)
 				if features.Enabled(features.LocalQueueMetrics) {
 					metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
 				}
@@ -652,13 +1082,18 @@ func (s *Scheduler) requeueAndUpdate(ctx context.Context, e entry) {
 
 	if e.status == notNominated || e.status == skipped {
 		patch := workload.PrepareWorkloadPatch(e.Obj, true, s.clock)
-		reservationIsChanged := workload.UnsetQuotaReservationWithCondition(patch, "Pending", e.inadmissibleMsg, s.clock.Now())
+		// The admission path of the pending workload is set below, so that
+		// it isn't cleared along with the quota reservation.
+		patch.Status.AdmissionPath = ""
+		reservationIsChanged := workload.UnsetQuotaReservationWithCondition(patch, e.pendingReason(), e.inadmissibleMsg, s.clock.Now())
+		patch.Status.AdmissionPath = e.pendingAdmissionPath()
+		admissionPathIsChanged := patch.Status.AdmissionPath != e.Obj.Status.AdmissionPath
 		resourceRequestsIsChanged := workload.PropagateResourceRequests(patch, &e.Info)
-		if reservationIsChanged || resourceRequestsIsChanged {
+		if reservationIsChanged || admissionPathIsChanged || resourceRequestsIsChanged {
 			if err := workload.ApplyAdmissionStatusPatch(ctx, s.client, patch); err != nil {
 				log.Error(err, "Could not update Workload status")
 			}
 		}
-		s.recorder.Eventf(e.Obj, corev1.EventTypeWarning, "Pending", api.TruncateEventMessage(e.inadmissibleMsg))
+		s.recorder.Eventf(e.Obj, corev1.EventTypeWarning, e.pendingReason(), api.TruncateEventMessage(e.inadmissibleMsg))
 	}
 }
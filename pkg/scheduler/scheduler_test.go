@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"sync"
 	"testing"
@@ -27,7 +28,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/component-base/metrics/testutil"
+	"k8s.io/klog/v2"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,17 +50,20 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
 	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -66,6 +73,27 @@ const (
 	queueingTimeout = time.Second
 )
 
+// schedulingMetrics are the metrics reported along the scheduling cycles, by name.
+var schedulingMetrics = map[string]prometheus.Collector{
+	"admission_attempts_total":         metrics.AdmissionAttemptsTotal,
+	"admission_cycle_preemption_skips": metrics.AdmissionCyclePreemptionSkips,
+	"admitted_workloads_total":         metrics.AdmittedWorkloadsTotal,
+	"evicted_workloads_total":          metrics.EvictedWorkloadsTotal,
+	"preempted_workloads_total":        metrics.PreemptedWorkloadsTotal,
+	"preemption_failed_total":          metrics.PreemptionFailedTotal,
+	"quota_reserved_workloads_total":   metrics.QuotaReservedWorkloadsTotal,
+	"scheduler_cycle_duration_seconds": metrics.SchedulerCycleDuration,
+	"scheduler_workloads_considered":   metrics.SchedulerWorkloadsConsidered,
+}
+
+func collectSchedulingMetrics() map[string][]testingmetrics.MetricDataPoint {
+	got := make(map[string][]testingmetrics.MetricDataPoint, len(schedulingMetrics))
+	for name, collector := range schedulingMetrics {
+		got[name] = testingmetrics.CollectFilteredGaugeVec(collector, nil)
+	}
+	return got
+}
+
 var cmpDump = cmp.Options{
 	cmpopts.SortSlices(func(a, b string) bool { return a < b }),
 }
@@ -80,6 +108,7 @@ func TestSchedule(t *testing.T) {
 		utiltesting.MakeResourceFlavor("on-demand").Obj(),
 		utiltesting.MakeResourceFlavor("spot").Obj(),
 		utiltesting.MakeResourceFlavor("model-a").Obj(),
+		utiltesting.MakeResourceFlavor("disabled").Disabled(true).Obj(),
 	}
 	clusterQueues := []kueue.ClusterQueue{
 		*utiltesting.MakeClusterQueue("sales").
@@ -242,9 +271,11 @@ func TestSchedule(t *testing.T) {
 	}
 	cases := map[string]struct {
 		// Features
-		disableLendingLimit     bool
-		disablePartialAdmission bool
-		enableFairSharing       bool
+		disableLendingLimit                 bool
+		disablePartialAdmission             bool
+		enableFairSharing                   bool
+		enablePreemptionPDBs                bool
+		enableAdmissionCheckPodSetResources bool
 
 		workloads      []kueue.Workload
 		objects        []client.Object
@@ -256,6 +287,10 @@ func TestSchedule(t *testing.T) {
 
 		cohorts []kueuealpha.Cohort
 
+		admissionChecks []kueue.AdmissionCheck
+
+		namespaceLimits []config.NamespaceResourceLimit
+
 		// wantAssignments is a summary of all the admissions in the cache after this cycle.
 		wantAssignments map[string]kueue.Admission
 		// wantScheduled is the subset of workloads that got scheduled/admitted in this cycle.
@@ -276,6 +311,8 @@ func TestSchedule(t *testing.T) {
 		eventCmpOpts cmp.Options
 
 		wantSkippedPreemptions map[string]int
+		// wantPreemptionFailures is the failed preemption metric after this cycle.
+		wantPreemptionFailures []testingmetrics.MetricDataPoint
 	}{
 		"workload fits in single clusterQueue, with check state ready": {
 			workloads: []kueue.Workload{
@@ -332,6 +369,7 @@ func TestSchedule(t *testing.T) {
 							AssignmentPodCount(10).
 							Obj(),
 					).
+					AdmissionPath(kueue.AdmissionPathNominal).
 					Generation(1).
 					Condition(metav1.Condition{
 						Type:               kueue.WorkloadQuotaReserved,
@@ -465,200 +503,751 @@ func TestSchedule(t *testing.T) {
 			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
 				"eng-alpha": {"sales/new"},
 			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "new"},
+					Reason:    kueue.WorkloadNamespaceNotAllowed,
+					EventType: corev1.EventTypeWarning,
+					Message:   "Workload namespace doesn't match ClusterQueue selector",
+				},
+			},
 		},
-		"admit in different cohorts": {
+		"workload requesting more than the nominal and borrowing quota of the clusterQueue": {
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "sales").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 1).
-						Request(corev1.ResourceCPU, "1").
-						Obj()).
-					Obj(),
 				*utiltesting.MakeWorkload("new", "eng-alpha").
 					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 51 /* Will borrow */).
+					PodSets(*utiltesting.MakePodSet("one", 101).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
 			},
-			wantAssignments: map[string]kueue.Admission{
-				"sales/new": {
-					ClusterQueue: "sales",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "default",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("1000m"),
-							},
-							Count: ptr.To[int32](1),
-						},
-					},
-				},
-				"eng-alpha/new": {
-					ClusterQueue: "eng-alpha",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("51000m"),
-							},
-							Count: ptr.To[int32](51),
-						},
-					},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-alpha": {"eng-alpha/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "new"},
+					Reason:    kueue.WorkloadExceedsQueueCapacity,
+					EventType: corev1.EventTypeWarning,
+					Message:   "couldn't assign flavors to pod set one: insufficient quota for cpu in flavor on-demand, request > maximum capacity (101 > 100), insufficient quota for cpu in flavor spot, request > maximum capacity (101 > 100)",
 				},
 			},
-			wantScheduled: []string{"sales/new", "eng-alpha/new"},
 		},
-		"admit in same cohort with no borrowing": {
+		"workload borrows the cpu next to the non-borrowable resource": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("licensed-a").
+					Cohort("licensed").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						ResourceQuotaWrapper("example.com/license").NominalQuota("2").Borrowable(false).Append().
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("licensed-b").
+					Cohort("licensed").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Resource("example.com/license", "2").
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-licensed", "sales").ClusterQueue("licensed-a").Obj(),
+			},
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "eng-alpha").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 40).
-						Request(corev1.ResourceCPU, "1").
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-licensed").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "15").
+						Request("example.com/license", "2").
 						Obj()).
 					Obj(),
-				*utiltesting.MakeWorkload("new", "eng-beta").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 40).
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new": *utiltesting.MakeAdmission("licensed-a", "one").
+					Assignment(corev1.ResourceCPU, "default", "15").
+					Assignment("example.com/license", "default", "2").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"workload can't borrow the non-borrowable resource": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("licensed-a").
+					Cohort("licensed").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						ResourceQuotaWrapper("example.com/license").NominalQuota("2").Borrowable(false).Append().
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("licensed-b").
+					Cohort("licensed").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Resource("example.com/license", "2").
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-licensed", "sales").ClusterQueue("licensed-a").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-licensed").
+					PodSets(*utiltesting.MakePodSet("one", 1).
 						Request(corev1.ResourceCPU, "1").
+						Request("example.com/license", "3").
 						Obj()).
 					Obj(),
 			},
-			wantAssignments: map[string]kueue.Admission{
-				"eng-alpha/new": {
-					ClusterQueue: "eng-alpha",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("40000m"),
-							},
-							Count: ptr.To[int32](40),
-						},
-					},
-				},
-				"eng-beta/new": {
-					ClusterQueue: "eng-beta",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("40000m"),
-							},
-							Count: ptr.To[int32](40),
-						},
-					},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"licensed-a": {"sales/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "new"},
+					Reason:    kueue.WorkloadExceedsQueueCapacity,
+					EventType: corev1.EventTypeWarning,
+					Message:   "couldn't assign flavors to pod set one: insufficient quota for example.com/license in flavor default, request > maximum capacity (3 > 2)",
 				},
 			},
-			wantScheduled: []string{"eng-alpha/new", "eng-beta/new"},
 		},
-		"assign multiple resources and flavors": {
+		"low priority workload can't use the quota reserved for high priority workloads": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tiered").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").PriorityReservation("4", 100).Append().
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-tiered", "sales").ClusterQueue("tiered").Obj(),
+			},
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "eng-beta").
-					Queue("main").
-					PodSets(
-						*utiltesting.MakePodSet("one", 10).
-							Request(corev1.ResourceCPU, "6").
-							Request("example.com/gpu", "1").
-							Obj(),
-						*utiltesting.MakePodSet("two", 40).
-							Request(corev1.ResourceCPU, "1").
-							Obj(),
-					).
+				*utiltesting.MakeWorkload("high", "sales").
+					Queue("lq-tiered").
+					Priority(100).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("tiered", "one").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low", "sales").
+					Queue("lq-tiered").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "9").
+						Obj()).
 					Obj(),
 			},
 			wantAssignments: map[string]kueue.Admission{
-				"eng-beta/new": {
-					ClusterQueue: "eng-beta",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-								"example.com/gpu":  "model-a",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("60000m"),
-								"example.com/gpu":  resource.MustParse("10"),
-							},
-							Count: ptr.To[int32](10),
-						},
-						{
-							Name: "two",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "spot",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("40000m"),
-							},
-							Count: ptr.To[int32](40),
-						},
-					},
+				"sales/high": *utiltesting.MakeAdmission("tiered", "one").
+					Assignment(corev1.ResourceCPU, "default", "2").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"tiered": {"sales/low"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "low"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   "couldn't assign flavors to pod set one: insufficient quota for cpu in flavor default, request > maximum capacity (9 > 8)",
 				},
 			},
-			wantScheduled: []string{"eng-beta/new"},
 		},
-		"cannot borrow if cohort was assigned and would result in overadmission": {
+		"high priority workload uses the quota reserved for high priority workloads": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tiered").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").PriorityReservation("4", 100).Append().
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-tiered", "sales").ClusterQueue("tiered").Obj(),
+			},
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "eng-alpha").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 45).
-						Request(corev1.ResourceCPU, "1").
+				*utiltesting.MakeWorkload("low", "sales").
+					Queue("lq-tiered").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "6").
 						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("tiered", "one").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
 					Obj(),
-				*utiltesting.MakeWorkload("new", "eng-beta").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 56).
-						Request(corev1.ResourceCPU, "1").
+				*utiltesting.MakeWorkload("high", "sales").
+					Queue("lq-tiered").
+					Priority(100).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "4").
 						Obj()).
 					Obj(),
 			},
 			wantAssignments: map[string]kueue.Admission{
-				"eng-alpha/new": {
-					ClusterQueue: "eng-alpha",
-					PodSetAssignments: []kueue.PodSetAssignment{
-						{
-							Name: "one",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("45000m"),
-							},
-							Count: ptr.To[int32](45),
-						},
-					},
-				},
-			},
-			wantScheduled: []string{"eng-alpha/new"},
-			wantLeft: map[kueue.ClusterQueueReference][]string{
-				"eng-beta": {"eng-beta/new"},
+				"sales/low": *utiltesting.MakeAdmission("tiered", "one").
+					Assignment(corev1.ResourceCPU, "default", "6").
+					Obj(),
+				"sales/high": *utiltesting.MakeAdmission("tiered", "one").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					Obj(),
 			},
+			wantScheduled: []string{"sales/high"},
 		},
-		"can borrow if cohort was assigned and will not result in overadmission": {
+		"workload fitting only in the scheduled quota is admitted to start at the start time": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("scheduled").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").ScheduledQuota("4", now.Truncate(time.Second).Add(time.Hour)).Append().
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-scheduled", "sales").ClusterQueue("scheduled").Obj(),
+			},
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "eng-alpha").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 45).
-						Request(corev1.ResourceCPU, "1").
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "5").
 						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("scheduled", "one").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
 					Obj(),
-				*utiltesting.MakeWorkload("new", "eng-beta").
-					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 55).
-						Request(corev1.ResourceCPU, "1").
+				*utiltesting.MakeWorkload("later", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "4").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "5").
+					Obj(),
+				"sales/later": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					StartTime(now.Truncate(time.Second).Add(time.Hour)).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/later"},
+		},
+		"workload fitting in the scheduled quota after the start time is admitted immediately": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("scheduled").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").ScheduledQuota("4", now.Add(-time.Hour)).Append().
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-scheduled", "sales").ClusterQueue("scheduled").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("scheduled", "one").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "4").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "5").
+					Obj(),
+				"sales/new": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"workload fitting in the quota not used by the workloads admitted to start at the start time is admitted immediately": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("scheduled").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").ScheduledQuota("4", now.Truncate(time.Second).Add(time.Hour)).Append().
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-scheduled", "sales").ClusterQueue("scheduled").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("scheduled", "one").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("later", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "4").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("scheduled", "one").
+						Assignment(corev1.ResourceCPU, "default", "4").
+						StartTime(now.Truncate(time.Second).Add(time.Hour)).
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-scheduled").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "5").
+					Obj(),
+				"sales/later": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					StartTime(now.Truncate(time.Second).Add(time.Hour)).
+					Obj(),
+				"sales/new": *utiltesting.MakeAdmission("scheduled", "one").
+					Assignment(corev1.ResourceCPU, "default", "1").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"workload can't borrow the scheduled quota of another clusterQueue before the start time": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("scheduled").
+					Cohort("scheduled").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").ScheduledQuota("4", now.Truncate(time.Second).Add(time.Hour)).Append().
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("borrower").
+					Cohort("scheduled").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "0").
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-borrower", "sales").ClusterQueue("borrower").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-borrower").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "8").
+						Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"borrower": {"sales/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "new"},
+					Reason:    "WorkloadExceedsQueueCapacity",
+					EventType: corev1.EventTypeWarning,
+					Message:   "couldn't assign flavors to pod set one: insufficient quota for cpu in flavor default, request > maximum capacity (8 > 6)",
+				},
+			},
+		},
+		"workload can use the nominal quota of its clusterQueue while another clusterQueue in the cohort is overcommitted": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("overcommitted").
+					Cohort("overcommit").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").OvercommitFactor("1.5").Append().
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("nominal").
+					Cohort("overcommit").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-overcommitted", "sales").ClusterQueue("overcommitted").Obj(),
+				*utiltesting.MakeLocalQueue("lq-nominal", "sales").ClusterQueue("nominal").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("lq-overcommitted").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "15").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("overcommitted", "one").Assignment(corev1.ResourceCPU, "default", "15").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-nominal").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "10").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("overcommitted", "one").
+					Assignment(corev1.ResourceCPU, "default", "15").
+					Obj(),
+				"sales/new": *utiltesting.MakeAdmission("nominal", "one").
+					Assignment(corev1.ResourceCPU, "default", "10").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"workload requesting within the nominal and borrowing quota of the clusterQueue": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 100).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("assigned-on-demand", "eng-alpha").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "on-demand", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("assigned-spot", "eng-alpha").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "spot", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/assigned-on-demand": *utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "on-demand", "10").AssignmentPodCount(10).Obj(),
+				"eng-alpha/assigned-spot":      *utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "spot", "10").AssignmentPodCount(10).Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-alpha": {"eng-alpha/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "new"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   "couldn't assign flavors to pod set one: insufficient unused quota for cpu in flavor on-demand, 10 more needed, insufficient unused quota for cpu in flavor spot, 10 more needed",
+				},
+			},
+		},
+		"workload waits for a free admission check slot": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("checked").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					AdmissionChecks("check").
+					MaxConcurrentChecks(1).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-checked", "sales").ClusterQueue("checked").Obj(),
+			},
+			admissionChecks: []kueue.AdmissionCheck{
+				*utiltesting.MakeAdmissionCheck("check").ControllerName("ctrl").Active(metav1.ConditionTrue).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-checked").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("in-checks", "sales").
+					Queue("lq-checked").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/in-checks": *utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"checked": {"sales/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "new"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   "Waiting for a free slot for the admission checks in ClusterQueue checked, the limit of 1 workloads is reached",
+				},
+			},
+		},
+		"only one of the workloads enters the admission checks when the limit is reached in the cycle": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("checked").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					AdmissionChecks("check").
+					MaxConcurrentChecks(1).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-checked", "sales").ClusterQueue("checked").Obj(),
+			},
+			admissionChecks: []kueue.AdmissionCheck{
+				*utiltesting.MakeAdmissionCheck("check").ControllerName("ctrl").Active(metav1.ConditionTrue).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("high", "sales").
+					Queue("lq-checked").
+					Priority(1).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low", "sales").
+					Queue("lq-checked").
+					Priority(0).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/high": *utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantScheduled: []string{"sales/high"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"checked": {"sales/low"},
+			},
+		},
+		"admitted workloads don't take an admission check slot": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("checked").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					AdmissionChecks("check").
+					MaxConcurrentChecks(1).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-checked", "sales").ClusterQueue("checked").Obj(),
+			},
+			admissionChecks: []kueue.AdmissionCheck{
+				*utiltesting.MakeAdmissionCheck("check").ControllerName("ctrl").Active(metav1.ConditionTrue).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("lq-checked").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("admitted", "sales").
+					Queue("lq-checked").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/admitted": *utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+				"sales/new":      *utiltesting.MakeAdmission("checked", "one").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"admit in different cohorts": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 51 /* Will borrow */).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("1000m"),
+							},
+							Count: ptr.To[int32](1),
+						},
+					},
+				},
+				"eng-alpha/new": {
+					ClusterQueue: "eng-alpha",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("51000m"),
+							},
+							Count: ptr.To[int32](51),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"sales/new", "eng-alpha/new"},
+		},
+		"admit in same cohort with no borrowing": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 40).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 40).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/new": {
+					ClusterQueue: "eng-alpha",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("40000m"),
+							},
+							Count: ptr.To[int32](40),
+						},
+					},
+				},
+				"eng-beta/new": {
+					ClusterQueue: "eng-beta",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("40000m"),
+							},
+							Count: ptr.To[int32](40),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"eng-alpha/new", "eng-beta/new"},
+		},
+		"assign multiple resources and flavors": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					PodSets(
+						*utiltesting.MakePodSet("one", 10).
+							Request(corev1.ResourceCPU, "6").
+							Request("example.com/gpu", "1").
+							Obj(),
+						*utiltesting.MakePodSet("two", 40).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/new": {
+					ClusterQueue: "eng-beta",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+								"example.com/gpu":  "model-a",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("60000m"),
+								"example.com/gpu":  resource.MustParse("10"),
+							},
+							Count: ptr.To[int32](10),
+						},
+						{
+							Name: "two",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "spot",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("40000m"),
+							},
+							Count: ptr.To[int32](40),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"eng-beta/new"},
+		},
+		"cannot borrow if cohort was assigned and would result in overadmission": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 45).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 56).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/new": {
+					ClusterQueue: "eng-alpha",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("45000m"),
+							},
+							Count: ptr.To[int32](45),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+		},
+		"can borrow if cohort was assigned and will not result in overadmission": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 45).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 55).
+						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
 			},
@@ -757,18 +1346,57 @@ func TestSchedule(t *testing.T) {
 					Request(corev1.ResourceCPU, "3").
 					Obj(),
 			},
-			wantAssignments: map[string]kueue.Admission{
-				"lend/a": *utiltesting.MakeAdmission("lend-b").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
-				"lend/b": *utiltesting.MakeAdmission("lend-b").Assignment(corev1.ResourceCPU, "default", "3000m").Obj(),
+			wantAssignments: map[string]kueue.Admission{
+				"lend/a": *utiltesting.MakeAdmission("lend-b").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+				"lend/b": *utiltesting.MakeAdmission("lend-b").Assignment(corev1.ResourceCPU, "default", "3000m").Obj(),
+			},
+			wantScheduled: []string{
+				"lend/b",
+			},
+		},
+		"preempt workloads in ClusterQueue and cohort": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("preemptor", "eng-beta").
+					Queue("main").
+					Request(corev1.ResourceCPU, "20").
+					Obj(),
+				*utiltesting.MakeWorkload("use-all-spot", "eng-alpha").
+					Request(corev1.ResourceCPU, "100").
+					ReserveQuota(utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "spot", "100000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low-1", "eng-beta").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "30").
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "30000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low-2", "eng-beta").
+					Priority(-2).
+					Request(corev1.ResourceCPU, "10").
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "10000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("borrower", "eng-alpha").
+					Request(corev1.ResourceCPU, "60").
+					ReserveQuota(utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "60000m").Obj()).
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				// Preemptor is not admitted in this cycle.
+				"eng-beta": {"eng-beta/preemptor"},
 			},
-			wantScheduled: []string{
-				"lend/b",
+			wantPreempted: sets.New("eng-alpha/borrower", "eng-beta/low-2"),
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/use-all-spot": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "spot", "100").Obj(),
+				"eng-beta/low-1":         *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "30").Obj(),
+				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
+				"eng-beta/low-2":     *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "10").Obj(),
+				"eng-alpha/borrower": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "60").Obj(),
 			},
 		},
-		"preempt workloads in ClusterQueue and cohort": {
+		"workload which may not preempt waits instead of preempting": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("preemptor", "eng-beta").
 					Queue("main").
+					Annotation(controllerconsts.MayPreemptAnnotation, "false").
 					Request(corev1.ResourceCPU, "20").
 					Obj(),
 				*utiltesting.MakeWorkload("use-all-spot", "eng-alpha").
@@ -791,16 +1419,14 @@ func TestSchedule(t *testing.T) {
 					Obj(),
 			},
 			wantLeft: map[kueue.ClusterQueueReference][]string{
-				// Preemptor is not admitted in this cycle.
+				// The preemptor waits for the quota, without preempting.
 				"eng-beta": {"eng-beta/preemptor"},
 			},
-			wantPreempted: sets.New("eng-alpha/borrower", "eng-beta/low-2"),
 			wantAssignments: map[string]kueue.Admission{
 				"eng-alpha/use-all-spot": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "spot", "100").Obj(),
 				"eng-beta/low-1":         *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "30").Obj(),
-				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
-				"eng-beta/low-2":     *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "10").Obj(),
-				"eng-alpha/borrower": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "60").Obj(),
+				"eng-beta/low-2":         *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "10").Obj(),
+				"eng-alpha/borrower":     *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "60").Obj(),
 			},
 		},
 		"multiple CQs need preemption": {
@@ -825,36 +1451,454 @@ func TestSchedule(t *testing.T) {
 					Obj(),
 			},
 			additionalLocalQueues: []kueue.LocalQueue{
-				*utiltesting.MakeLocalQueue("other", "eng-alpha").ClusterQueue("other-alpha").Obj(),
-				*utiltesting.MakeLocalQueue("other", "eng-beta").ClusterQueue("other-beta").Obj(),
+				*utiltesting.MakeLocalQueue("other", "eng-alpha").ClusterQueue("other-alpha").Obj(),
+				*utiltesting.MakeLocalQueue("other", "eng-beta").ClusterQueue("other-beta").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("preemptor", "eng-beta").
+					Priority(-1).
+					Queue("other").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("pending", "eng-alpha").
+					Priority(1).
+					Queue("other").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("use-all", "eng-alpha").
+					Request(corev1.ResourceCPU, "100").
+					ReserveQuota(utiltesting.MakeAdmission("other-alpha").Assignment(corev1.ResourceCPU, "on-demand", "100").Obj()).
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				// Preemptor is not admitted in this cycle.
+				"other-beta": {"eng-beta/preemptor"},
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"other-alpha": {"eng-alpha/pending"},
+			},
+			wantPreempted: sets.New("eng-alpha/use-all"),
+			wantAssignments: map[string]kueue.Admission{
+				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
+				"eng-alpha/use-all": *utiltesting.MakeAdmission("other-alpha").Assignment(corev1.ResourceCPU, "on-demand", "100").Obj(),
+			},
+		},
+		"workload borrows from the fallback cohort after its cohort runs dry": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("primary-a").
+					Cohort("primary").
+					FallbackCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("primary-b").
+					Cohort("primary").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("spare").
+					Cohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("primary", "eng-alpha").ClusterQueue("primary-a").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running-a", "eng-alpha").
+					Queue("primary").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("running-b", "eng-beta").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("primary-b").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("primary").
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running-a": *utiltesting.MakeAdmission("primary-a").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj(),
+				"eng-beta/running-b":  *utiltesting.MakeAdmission("primary-b").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("primary-a").
+					Assignment(corev1.ResourceCPU, "on-demand", "5").
+					FallbackCohort("fallback").
+					Obj(),
+			},
+		},
+		"workload admitted with the resource overheads of its ClusterQueue": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("overhead").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					ResourceOverhead(corev1.ResourceCPU, "1.5", "100m").
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("overhead", "eng-alpha").ClusterQueue("overhead").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("overhead").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/new": *utiltesting.MakeAdmission("overhead").
+					Assignment(corev1.ResourceCPU, "on-demand", "6400m").
+					AssignmentPodCount(4).
+					Obj(),
+			},
+		},
+		"workload doesn't fit with the resource overheads of its ClusterQueue": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("overhead").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					ResourceOverhead(corev1.ResourceCPU, "1.5", "100m").
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("overhead", "eng-alpha").ClusterQueue("overhead").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("overhead").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"overhead": {"eng-alpha/new"},
+			},
+		},
+		"workload admitted with the resources requested by its admission checks": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("sidecar").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("sidecar", "eng-alpha").ClusterQueue("sidecar").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("sidecar").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:  "logging-agent",
+						State: kueue.CheckStatePending,
+						PodSetResources: []kueue.PodSetResources{{
+							Name:      kueue.DefaultPodSetName,
+							Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						}},
+					}).
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/new": *utiltesting.MakeAdmission("sidecar").
+					Assignment(corev1.ResourceCPU, "on-demand", "6").
+					AssignmentPodCount(4).
+					Obj(),
+			},
+			enableAdmissionCheckPodSetResources: true,
+		},
+		"workload doesn't fit with the resources requested by its admission checks": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("sidecar").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("sidecar", "eng-alpha").ClusterQueue("sidecar").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("sidecar").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+						Request(corev1.ResourceCPU, "2.5").
+						Obj()).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:  "logging-agent",
+						State: kueue.CheckStatePending,
+						PodSetResources: []kueue.PodSetResources{{
+							Name:      kueue.DefaultPodSetName,
+							Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						}},
+					}).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"sidecar": {"eng-alpha/new"},
+			},
+			enableAdmissionCheckPodSetResources: true,
+		},
+		"resources requested by the admission checks are ignored when the AdmissionCheckPodSetResources feature is disabled": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("sidecar").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("sidecar", "eng-alpha").ClusterQueue("sidecar").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("sidecar").
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+						Request(corev1.ResourceCPU, "2.5").
+						Obj()).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:  "logging-agent",
+						State: kueue.CheckStatePending,
+						PodSetResources: []kueue.PodSetResources{{
+							Name:      kueue.DefaultPodSetName,
+							Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						}},
+					}).
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/new": *utiltesting.MakeAdmission("sidecar").
+					Assignment(corev1.ResourceCPU, "on-demand", "10").
+					AssignmentPodCount(4).
+					Obj(),
+			},
+		},
+		"workload doesn't fit when the fallback cohort runs dry": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("primary-a").
+					Cohort("primary").
+					FallbackCohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("spare").
+					Cohort("fallback").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("primary", "eng-alpha").ClusterQueue("primary-a").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "eng-alpha").
+					Queue("primary").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("borrower", "eng-alpha").
+					Queue("primary").
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuota(utiltesting.MakeAdmission("primary-a").
+						Assignment(corev1.ResourceCPU, "on-demand", "8").
+						FallbackCohort("fallback").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("primary").
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"primary-a": {"eng-alpha/new"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running": *utiltesting.MakeAdmission("primary-a").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj(),
+				"eng-alpha/borrower": *utiltesting.MakeAdmission("primary-a").
+					Assignment(corev1.ResourceCPU, "on-demand", "8").
+					FallbackCohort("fallback").
+					Obj(),
+			},
+		},
+		"workload avoids a disabled flavor, without evicting the workloads using it": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("phase-out").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("disabled").
+							Resource(corev1.ResourceCPU, "10").Obj(),
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource(corev1.ResourceCPU, "10").Obj(),
+					).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("phase-out", "eng-alpha").ClusterQueue("phase-out").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "eng-alpha").
+					Queue("phase-out").
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuota(utiltesting.MakeAdmission("phase-out").Assignment(corev1.ResourceCPU, "disabled", "8").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Priority(1).
+					Queue("phase-out").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running": *utiltesting.MakeAdmission("phase-out").Assignment(corev1.ResourceCPU, "disabled", "8").Obj(),
+				"eng-alpha/new":     *utiltesting.MakeAdmission("phase-out").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj(),
+			},
+		},
+		"workload exceeding the maxQuota of its LocalQueue is inadmissible": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("capped", "eng-alpha").
+					ClusterQueue("limited").
+					ResourceLimit(corev1.ResourceCPU, "", "4").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("existing", "eng-alpha").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"limited": {"eng-alpha/new"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/existing": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+			},
+		},
+		"workload exceeding the limit of its namespace across ClusterQueues is inadmissible": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq-b").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-a", "eng-alpha").ClusterQueue("cq-a").Obj(),
+				*utiltesting.MakeLocalQueue("lq-b", "eng-alpha").ClusterQueue("cq-b").Obj(),
+			},
+			namespaceLimits: []config.NamespaceResourceLimit{
+				{
+					Namespace: "eng-alpha",
+					Limits:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")},
+				},
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("existing", "eng-alpha").
+					Queue("lq-a").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("lq-b").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"cq-b": {"eng-alpha/new"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/existing": *utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "new"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   "Workload doesn't fit the resource limits of its namespace: the namespace would exceed its limit of 6 for cpu",
+				},
+			},
+		},
+		"workload can't use the nominalQuota of other LocalQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("guaranteed", "eng-alpha").
+					ClusterQueue("limited").
+					ResourceLimit(corev1.ResourceCPU, "6", "").
+					Obj(),
+				*utiltesting.MakeLocalQueue("other", "eng-alpha").
+					ClusterQueue("limited").
+					Obj(),
 			},
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("preemptor", "eng-beta").
-					Priority(-1).
+				*utiltesting.MakeWorkload("new", "eng-alpha").
 					Queue("other").
-					Request(corev1.ResourceCPU, "1").
+					Request(corev1.ResourceCPU, "5").
 					Obj(),
-				*utiltesting.MakeWorkload("pending", "eng-alpha").
-					Priority(1).
-					Queue("other").
+				*utiltesting.MakeWorkload("existing", "eng-alpha").
+					Queue("guaranteed").
 					Request(corev1.ResourceCPU, "1").
+					ReserveQuota(utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
 					Obj(),
-				*utiltesting.MakeWorkload("use-all", "eng-alpha").
-					Request(corev1.ResourceCPU, "100").
-					ReserveQuota(utiltesting.MakeAdmission("other-alpha").Assignment(corev1.ResourceCPU, "on-demand", "100").Obj()).
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"limited": {"eng-alpha/new"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/existing": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+		},
+		"workload fits in the capacity not reserved for other LocalQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
 					Obj(),
 			},
-			wantLeft: map[kueue.ClusterQueueReference][]string{
-				// Preemptor is not admitted in this cycle.
-				"other-beta": {"eng-beta/preemptor"},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("guaranteed", "eng-alpha").
+					ClusterQueue("limited").
+					ResourceLimit(corev1.ResourceCPU, "6", "").
+					Obj(),
+				*utiltesting.MakeLocalQueue("other", "eng-alpha").
+					ClusterQueue("limited").
+					Obj(),
 			},
-			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
-				"other-alpha": {"eng-alpha/pending"},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("other").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
 			},
-			wantPreempted: sets.New("eng-alpha/use-all"),
+			wantScheduled: []string{"eng-alpha/new"},
 			wantAssignments: map[string]kueue.Admission{
-				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
-				"eng-alpha/use-all": *utiltesting.MakeAdmission("other-alpha").Assignment(corev1.ResourceCPU, "on-demand", "100").Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "4").Obj(),
 			},
 		},
 		"cannot borrow resource not listed in clusterQueue": {
@@ -993,117 +2037,369 @@ func TestSchedule(t *testing.T) {
 						).
 						Obj()).
 					Obj(),
-			},
-			additionalClusterQueues: []kueue.ClusterQueue{
-				*utiltesting.MakeClusterQueue("eng-gamma").
-					Cohort("eng").
-					Preemption(kueue.ClusterQueuePreemption{
-						ReclaimWithinCohort: kueue.PreemptionPolicyAny,
-						WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
-					}).
-					ResourceGroup(
-						*utiltesting.MakeFlavorQuotas("on-demand").
-							Resource(corev1.ResourceCPU, "50", "10").Obj(),
-						*utiltesting.MakeFlavorQuotas("spot").
-							Resource(corev1.ResourceCPU, "0", "100").Obj(),
-					).
+			},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("eng-gamma").
+					Cohort("eng").
+					Preemption(kueue.ClusterQueuePreemption{
+						ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+						WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource(corev1.ResourceCPU, "50", "10").Obj(),
+						*utiltesting.MakeFlavorQuotas("spot").
+							Resource(corev1.ResourceCPU, "0", "100").Obj(),
+					).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "eng-gamma",
+						Name:      "main",
+					},
+					Spec: kueue.LocalQueueSpec{
+						ClusterQueue: "eng-gamma",
+					},
+				},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-gamma/existing": *utiltesting.MakeAdmission("eng-gamma").
+					PodSets(
+						kueue.PodSetAssignment{
+							Name: "borrow-on-demand",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "on-demand",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("51"),
+							},
+							Count: ptr.To[int32](51),
+						},
+						kueue.PodSetAssignment{
+							Name: "use-all-spot",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "spot",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100"),
+							},
+							Count: ptr.To[int32](100),
+						},
+					).Obj(),
+				"eng-beta/new":        *utiltesting.MakeAdmission("eng-beta", "one").Assignment(corev1.ResourceCPU, "on-demand", "50").AssignmentPodCount(50).Obj(),
+				"eng-alpha/new-alpha": *utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "on-demand", "1").AssignmentPodCount(1).Obj(),
+			},
+			wantScheduled: []string{"eng-beta/new", "eng-alpha/new-alpha"},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-gamma": {"eng-gamma/new-gamma"},
+			},
+			wantSkippedPreemptions: map[string]int{
+				"eng-alpha": 0,
+				"eng-beta":  0,
+				"eng-gamma": 0,
+			},
+		},
+		"partial admission single variable pod set": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 50).
+						SetMinimumCount(20).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("50000m"),
+							},
+							Count: ptr.To[int32](25),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"sales/new"},
+		},
+		"partial admission single variable pod set, preempt first": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						SetMinimumCount(10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("old", "eng-beta").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/old": {
+					ClusterQueue: "eng-beta",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								"example.com/gpu": "model-a",
+							},
+							ResourceUsage: corev1.ResourceList{
+								"example.com/gpu": resource.MustParse("10"),
+							},
+							Count: ptr.To[int32](10),
+						},
+					},
+				},
+			},
+			wantPreempted: sets.New("eng-beta/old"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+		},
+		"preemptor which doesn't fit after the preemptions it issued": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					UID("new").
+					Queue("main").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request("example.com/gpu", "1").
+						Obj()).
+					AdmissionPath(kueue.AdmissionPathPreemptionWithinClusterQueue).
+					PreemptedWorkloads(kueue.WorkloadPreemptionReference{Namespace: "eng-beta", Name: "old", UID: "old"}).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "eng-beta").
+					UID("high").
+					Priority(5).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/high": *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+			wantPreemptionFailures: []testingmetrics.MetricDataPoint{{
+				Labels: map[string]string{"cluster_queue": "eng-beta", "reason": preemption.PreemptionFailedNoCandidates},
+				Value:  1,
+			}},
+		},
+		"preemptor for which preempting the candidates doesn't free enough capacity after the preemptions it issued": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					UID("new").
+					Queue("main").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request("example.com/gpu", "1").
+						Obj()).
+					AdmissionPath(kueue.AdmissionPathPreemptionWithinClusterQueue).
+					PreemptedWorkloads(kueue.WorkloadPreemptionReference{Namespace: "eng-beta", Name: "old", UID: "old"}).
+					Obj(),
+				*utiltesting.MakeWorkload("low", "eng-beta").
+					UID("low").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "eng-beta").
+					UID("high").
+					Priority(5).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/low":  *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj(),
+				"eng-beta/high": *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+			wantPreemptionFailures: []testingmetrics.MetricDataPoint{{
+				Labels: map[string]string{"cluster_queue": "eng-beta", "reason": preemption.PreemptionFailedInsufficientCapacity},
+				Value:  1,
+			}},
+		},
+		"preemptor waiting for the preemptions it issued": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					UID("new").
+					Queue("main").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					AdmissionPath(kueue.AdmissionPathPreemptionWithinClusterQueue).
+					PreemptedWorkloads(kueue.WorkloadPreemptionReference{Namespace: "eng-beta", Name: "old", UID: "old"}).
+					Obj(),
+				*utiltesting.MakeWorkload("old", "eng-beta").
+					UID("old").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "20").AssignmentPodCount(20).Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/old": *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "20").AssignmentPodCount(20).Obj(),
+			},
+			wantPreempted: sets.New("eng-beta/old"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+		},
+		"preemptor which needs to preempt more workloads after the preemptions it issued": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					UID("new").
+					Queue("main").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request("example.com/gpu", "1").
+						Obj()).
+					AdmissionPath(kueue.AdmissionPathPreemptionWithinClusterQueue).
+					PreemptedWorkloads(kueue.WorkloadPreemptionReference{Namespace: "eng-beta", Name: "old", UID: "old"}).
+					Obj(),
+				*utiltesting.MakeWorkload("old", "eng-beta").
+					UID("old").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("other", "eng-beta").
+					UID("other").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request("example.com/gpu", "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
 					Obj(),
 			},
-			additionalLocalQueues: []kueue.LocalQueue{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "eng-gamma",
-						Name:      "main",
-					},
-					Spec: kueue.LocalQueueSpec{
-						ClusterQueue: "eng-gamma",
-					},
-				},
-			},
 			wantAssignments: map[string]kueue.Admission{
-				"eng-gamma/existing": *utiltesting.MakeAdmission("eng-gamma").
-					PodSets(
-						kueue.PodSetAssignment{
-							Name: "borrow-on-demand",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "on-demand",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("51"),
-							},
-							Count: ptr.To[int32](51),
-						},
-						kueue.PodSetAssignment{
-							Name: "use-all-spot",
-							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "spot",
-							},
-							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("100"),
-							},
-							Count: ptr.To[int32](100),
-						},
-					).Obj(),
-				"eng-beta/new":        *utiltesting.MakeAdmission("eng-beta", "one").Assignment(corev1.ResourceCPU, "on-demand", "50").AssignmentPodCount(50).Obj(),
-				"eng-alpha/new-alpha": *utiltesting.MakeAdmission("eng-alpha", "one").Assignment(corev1.ResourceCPU, "on-demand", "1").AssignmentPodCount(1).Obj(),
+				"eng-beta/old":   *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj(),
+				"eng-beta/other": *utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj(),
 			},
-			wantScheduled: []string{"eng-beta/new", "eng-alpha/new-alpha"},
-			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
-				"eng-gamma": {"eng-gamma/new-gamma"},
-			},
-			wantSkippedPreemptions: map[string]int{
-				"eng-alpha": 0,
-				"eng-beta":  0,
-				"eng-gamma": 0,
+			wantPreempted: sets.New("eng-beta/old", "eng-beta/other"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
 			},
+			wantPreemptionFailures: []testingmetrics.MetricDataPoint{{
+				Labels: map[string]string{"cluster_queue": "eng-beta", "reason": preemption.PreemptionFailedInsufficientCapacity},
+				Value:  1,
+			}},
 		},
-		"partial admission single variable pod set": {
+		"preemption deferred by a PodDisruptionBudget": {
+			enablePreemptionPDBs: true,
 			workloads: []kueue.Workload{
-				*utiltesting.MakeWorkload("new", "sales").
+				*utiltesting.MakeWorkload("new", "eng-beta").
 					Queue("main").
-					PodSets(*utiltesting.MakePodSet("one", 50).
-						SetMinimumCount(20).
-						Request(corev1.ResourceCPU, "2").
+					Priority(4).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request("example.com/gpu", "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("old", "eng-beta").
+					Priority(-4).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Labels(map[string]string{"app": "old"}).
+						Request("example.com/gpu", "1").
 						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
 					Obj(),
 			},
+			objects: []client.Object{
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "eng-beta"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "old"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+				},
+			},
 			wantAssignments: map[string]kueue.Admission{
-				"sales/new": {
-					ClusterQueue: "sales",
+				"eng-beta/old": {
+					ClusterQueue: "eng-beta",
 					PodSetAssignments: []kueue.PodSetAssignment{
 						{
 							Name: "one",
 							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
-								corev1.ResourceCPU: "default",
+								"example.com/gpu": "model-a",
 							},
 							ResourceUsage: corev1.ResourceList{
-								corev1.ResourceCPU: resource.MustParse("50000m"),
+								"example.com/gpu": resource.MustParse("10"),
 							},
-							Count: ptr.To[int32](25),
+							Count: ptr.To[int32](10),
 						},
 					},
 				},
 			},
-			wantScheduled: []string{"sales/new"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta": {"eng-beta/new"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "eng-beta", Name: "new"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message: "couldn't assign flavors to pod set one: insufficient unused quota for example.com/gpu in flavor model-a, 10 more needed. " +
+						"Preemption deferred: evicting workload eng-beta/old would violate PodDisruptionBudget eng-beta/old, which allows 1 disruption(s)",
+				},
+			},
 		},
-		"partial admission single variable pod set, preempt first": {
+		"preemption allowed by a PodDisruptionBudget": {
+			enablePreemptionPDBs: true,
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("new", "eng-beta").
 					Queue("main").
 					Priority(4).
 					PodSets(*utiltesting.MakePodSet("one", 20).
-						SetMinimumCount(10).
 						Request("example.com/gpu", "1").
 						Obj()).
 					Obj(),
 				*utiltesting.MakeWorkload("old", "eng-beta").
 					Priority(-4).
 					PodSets(*utiltesting.MakePodSet("one", 10).
+						Labels(map[string]string{"app": "old"}).
 						Request("example.com/gpu", "1").
 						Obj()).
 					ReserveQuota(utiltesting.MakeAdmission("eng-beta", "one").Assignment("example.com/gpu", "model-a", "10").AssignmentPodCount(10).Obj()).
 					Obj(),
 			},
+			objects: []client.Object{
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "eng-beta"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "old"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 10},
+				},
+			},
 			wantAssignments: map[string]kueue.Admission{
 				"eng-beta/old": {
 					ClusterQueue: "eng-beta",
@@ -1126,6 +2422,134 @@ func TestSchedule(t *testing.T) {
 				"eng-beta": {"eng-beta/new"},
 			},
 		},
+		"workload borrows instead of preempting within the ClusterQueue when preferring borrowing": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq_prefer").
+					Cohort("borrowing-preference").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
+						BorrowingPreference: ptr.To(kueue.PreferBorrowing),
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq_lender").
+					Cohort("borrowing-preference").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq_prefer", "eng-alpha").ClusterQueue("cq_prefer").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("lq_prefer").
+					Priority(1).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low", "eng-alpha").
+					Priority(-1).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+						Request(corev1.ResourceCPU, "3").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq_prefer").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/low": {
+					ClusterQueue: "cq_prefer",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: kueue.DefaultPodSetName,
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("3"),
+							},
+							Count: ptr.To[int32](1),
+						},
+					},
+				},
+				"eng-alpha/new": {
+					ClusterQueue: "cq_prefer",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: kueue.DefaultPodSetName,
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("2"),
+							},
+							Count: ptr.To[int32](1),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"eng-alpha/new"},
+		},
+		"workload preempts within the ClusterQueue instead of borrowing when preferring preemption": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq_prefer").
+					Cohort("borrowing-preference").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
+						BorrowingPreference: ptr.To(kueue.PreferPreemption),
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq_lender").
+					Cohort("borrowing-preference").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq_prefer", "eng-alpha").ClusterQueue("cq_prefer").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("lq_prefer").
+					Priority(1).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low", "eng-alpha").
+					Priority(-1).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+						Request(corev1.ResourceCPU, "3").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq_prefer").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/low": {
+					ClusterQueue: "cq_prefer",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: kueue.DefaultPodSetName,
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("3"),
+							},
+							Count: ptr.To[int32](1),
+						},
+					},
+				},
+			},
+			wantPreempted: sets.New("eng-alpha/low"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"cq_prefer": {"eng-alpha/new"},
+			},
+		},
 		"partial admission single variable pod set, preempt with partial admission": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("new", "eng-beta").
@@ -2739,41 +4163,87 @@ func TestSchedule(t *testing.T) {
 			},
 			additionalLocalQueues: []kueue.LocalQueue{
 				*utiltesting.MakeLocalQueue("other", "eng-alpha").ClusterQueue("other-alpha").Obj(),
-				*utiltesting.MakeLocalQueue("other", "eng-beta").ClusterQueue("other-beta").Obj(),
+				*utiltesting.MakeLocalQueue("other", "eng-beta").ClusterQueue("other-beta").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "eng-alpha").
+					Priority(50).
+					Queue("other").
+					Request("gpu", "5").
+					SimpleReserveQuota("other-alpha", "on-demand", now).
+					Obj(),
+				*utiltesting.MakeWorkload("a2", "eng-alpha").
+					Priority(50).
+					Queue("other").
+					Request("gpu", "5").
+					SimpleReserveQuota("other-alpha", "spot", now).
+					Obj(),
+				*utiltesting.MakeWorkload("b1", "eng-beta").
+					Priority(50).
+					Queue("other").
+					Request("gpu", "5").
+					SimpleReserveQuota("other-beta", "spot", now).
+					Obj(),
+				*utiltesting.MakeWorkload("preemptor", "eng-alpha").
+					Priority(100).
+					Queue("other").
+					Request("gpu", "6").
+					Obj(),
+			},
+			wantPreempted: sets.New("eng-alpha/a1"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"other-alpha": {"eng-alpha/preemptor"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/a1": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "on-demand", "5").Obj(),
+				"eng-alpha/a2": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "spot", "5").Obj(),
+				"eng-beta/b1":  *utiltesting.MakeAdmission("other-beta").Assignment("gpu", "spot", "5").Obj(),
+			},
+		},
+		"preempt in the second flavor when there are no candidates in the first flavor": {
+			// Flavor 1, on-demand, requires preemption, but it's only used by a
+			// workload with a higher priority.
+			// Flavor 2, spot, requires preemption of a lower priority workload.
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("other-alpha").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(
+						utiltesting.MakeFlavorQuotas("on-demand").Resource("gpu", "10").FlavorQuotas,
+						utiltesting.MakeFlavorQuotas("spot").Resource("gpu", "10").FlavorQuotas,
+					).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("other", "eng-alpha").ClusterQueue("other-alpha").Obj(),
 			},
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("a1", "eng-alpha").
-					Priority(50).
+					Priority(200).
 					Queue("other").
-					Request("gpu", "5").
+					Request("gpu", "10").
 					SimpleReserveQuota("other-alpha", "on-demand", now).
 					Obj(),
 				*utiltesting.MakeWorkload("a2", "eng-alpha").
 					Priority(50).
 					Queue("other").
-					Request("gpu", "5").
+					Request("gpu", "10").
 					SimpleReserveQuota("other-alpha", "spot", now).
 					Obj(),
-				*utiltesting.MakeWorkload("b1", "eng-beta").
-					Priority(50).
-					Queue("other").
-					Request("gpu", "5").
-					SimpleReserveQuota("other-beta", "spot", now).
-					Obj(),
 				*utiltesting.MakeWorkload("preemptor", "eng-alpha").
 					Priority(100).
 					Queue("other").
 					Request("gpu", "6").
 					Obj(),
 			},
-			wantPreempted: sets.New("eng-alpha/a1"),
+			wantPreempted: sets.New("eng-alpha/a2"),
 			wantLeft: map[kueue.ClusterQueueReference][]string{
 				"other-alpha": {"eng-alpha/preemptor"},
 			},
 			wantAssignments: map[string]kueue.Admission{
-				"eng-alpha/a1": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "on-demand", "5").Obj(),
-				"eng-alpha/a2": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "spot", "5").Obj(),
-				"eng-beta/b1":  *utiltesting.MakeAdmission("other-beta").Assignment("gpu", "spot", "5").Obj(),
+				"eng-alpha/a1": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "on-demand", "10").Obj(),
+				"eng-alpha/a2": *utiltesting.MakeAdmission("other-alpha").Assignment("gpu", "spot", "10").Obj(),
 			},
 		},
 		"prefer first preemption flavor when second flavor also requires cq preemption": {
@@ -3111,12 +4581,15 @@ func TestSchedule(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			metrics.AdmissionCyclePreemptionSkips.Reset()
+			metrics.PreemptionFailedTotal.Reset()
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
 			if tc.disablePartialAdmission {
 				features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.PreemptionRespectsPodDisruptionBudgets, tc.enablePreemptionPDBs)
+			features.SetFeatureGateDuringTest(t, features.AdmissionCheckPodSetResources, tc.enableAdmissionCheckPodSetResources)
 			ctx, _ := utiltesting.ContextWithLog(t)
 
 			allQueues := append(queues, tc.additionalLocalQueues...)
@@ -3135,7 +4608,7 @@ func TestSchedule(t *testing.T) {
 				)...)
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
-			cqCache := cache.New(cl)
+			cqCache := cache.New(cl, cache.WithNamespaceLimits(tc.namespaceLimits))
 			qManager := queue.NewManager(cl, cqCache)
 			// Workloads are loaded into queues or clusterQueues as we add them.
 			for _, q := range allQueues {
@@ -3146,6 +4619,9 @@ func TestSchedule(t *testing.T) {
 			for i := range resourceFlavors {
 				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
 			}
+			for i := range tc.admissionChecks {
+				cqCache.AddOrUpdateAdmissionCheck(&tc.admissionChecks[i])
+			}
 			for _, cq := range allClusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
 					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
@@ -3182,7 +4658,7 @@ func TestSchedule(t *testing.T) {
 				func() { wg.Done() },
 			))
 			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w, _ *kueue.Workload, _, _ string) error {
 				mu.Lock()
 				gotPreempted.Insert(workload.Key(w))
 				mu.Unlock()
@@ -3193,9 +4669,37 @@ func TestSchedule(t *testing.T) {
 			go qManager.CleanUpOnContext(ctx)
 			defer cancel()
 
+			metricsBeforeDryRun := collectSchedulingMetrics()
+			plan, err := scheduler.ScheduleDryRun(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error in the dry-run scheduling cycle: %v", err)
+			}
+			if diff := cmp.Diff(metricsBeforeDryRun, collectSchedulingMetrics(), cmpopts.SortSlices(func(a, b testingmetrics.MetricDataPoint) bool { return a.Less(&b) })); diff != "" {
+				t.Errorf("Unexpected metrics reported by the dry-run (-before,+after):\n%s", diff)
+			}
+
 			scheduler.schedule(ctx)
 			wg.Wait()
 
+			if tc.admissionError == nil {
+				plannedScheduled := make(map[string]kueue.Admission)
+				for _, a := range plan.Admissions {
+					plannedScheduled[a.Workload.String()] = a.Admission
+				}
+				if diff := cmp.Diff(gotScheduled, plannedScheduled); diff != "" {
+					t.Errorf("Unexpected workloads scheduled in the dry-run (-actual,+dryRun):\n%s", diff)
+				}
+			}
+			plannedPreempted := sets.New[string]()
+			for _, p := range plan.Preemptions {
+				for _, target := range p.Targets {
+					plannedPreempted.Insert(target.Workload.String())
+				}
+			}
+			if diff := cmp.Diff(gotPreempted, plannedPreempted); diff != "" {
+				t.Errorf("Unexpected preemptions in the dry-run (-actual,+dryRun):\n%s", diff)
+			}
+
 			wantScheduled := make(map[string]kueue.Admission)
 			for _, key := range tc.wantScheduled {
 				wantScheduled[key] = tc.wantAssignments[key]
@@ -3267,6 +4771,11 @@ func TestSchedule(t *testing.T) {
 					t.Errorf("Counted %d skips for %q, want %d", got, cqName, want)
 				}
 			}
+
+			gotPreemptionFailures := testingmetrics.CollectFilteredGaugeVec(metrics.PreemptionFailedTotal, nil)
+			if diff := cmp.Diff(tc.wantPreemptionFailures, gotPreemptionFailures, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected failed preemption metric (-want,+got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -3990,7 +5499,7 @@ func TestLastSchedulingContext(t *testing.T) {
 				func() { wg.Done() },
 			))
 			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w, _ *kueue.Workload, _, _ string) error {
 				mu.Lock()
 				gotPreempted.Insert(workload.Key(w))
 				mu.Unlock()
@@ -4054,6 +5563,617 @@ func TestLastSchedulingContext(t *testing.T) {
 	}
 }
 
+func TestScheduleWithMaxAdmissionsPerCycle(t *testing.T) {
+	now := time.Now()
+	resourceFlavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("default").Obj(),
+	}
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		*utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		*utiltesting.MakeClusterQueue("cq-c").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	queues := []kueue.LocalQueue{
+		*utiltesting.MakeLocalQueue("lq-a", "default").ClusterQueue("cq-a").Obj(),
+		*utiltesting.MakeLocalQueue("lq-b", "default").ClusterQueue("cq-b").Obj(),
+		*utiltesting.MakeLocalQueue("lq-c", "default").ClusterQueue("cq-c").Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("a1", "default").Queue("lq-a").Priority(10).Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("a2", "default").Queue("lq-a").Priority(10).Creation(now.Add(time.Second)).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("b1", "default").Queue("lq-b").Priority(5).Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("c1", "default").Queue("lq-c").Priority(1).Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+	}
+
+	cases := map[string]struct {
+		maxAdmissionsPerCycle *int32
+		// wantAdmittedPerCycle lists the workloads admitted in consecutive cycles.
+		wantAdmittedPerCycle [][]string
+	}{
+		"no limit": {
+			wantAdmittedPerCycle: [][]string{
+				{"default/a1", "default/b1", "default/c1"},
+				{"default/a2"},
+			},
+		},
+		"one admission per cycle; deferred workloads take precedence in the next cycle": {
+			maxAdmissionsPerCycle: ptr.To[int32](1),
+			wantAdmittedPerCycle: [][]string{
+				{"default/a1"},
+				{"default/b1"},
+				{"default/a2"},
+				{"default/c1"},
+			},
+		},
+		"two admissions per cycle": {
+			maxAdmissionsPerCycle: ptr.To[int32](2),
+			wantAdmittedPerCycle: [][]string{
+				{"default/a1", "default/b1"},
+				{"default/a2", "default/c1"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: queues}).
+				WithObjects(utiltesting.MakeNamespace("default")).
+				Build()
+			recorder := &utiltesting.EventRecorder{}
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			for i := range resourceFlavors {
+				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
+			}
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+				}
+				if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				}
+			}
+			for _, q := range queues {
+				if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+					t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+				}
+			}
+			scheduler := New(qManager, cqCache, cl, recorder, WithMaxAdmissionsPerCycle(tc.maxAdmissionsPerCycle), WithClock(t, testingclock.NewFakeClock(now)))
+			var gotScheduled []string
+			var mu sync.Mutex
+			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+				mu.Lock()
+				gotScheduled = append(gotScheduled, workload.Key(w))
+				mu.Unlock()
+				return nil
+			}
+			wg := sync.WaitGroup{}
+			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+				func() { wg.Add(1) },
+				func() { wg.Done() },
+			))
+
+			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+			go qManager.CleanUpOnContext(ctx)
+			defer cancel()
+
+			for i, want := range tc.wantAdmittedPerCycle {
+				gotScheduled = nil
+				scheduler.schedule(ctx)
+				wg.Wait()
+				if diff := cmp.Diff(want, gotScheduled, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+					t.Errorf("Unexpected admitted workloads in cycle %d (-want,+got):\n%s", i+1, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleWithMinAdmissionInterval(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now()
+	resourceFlavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("default").Obj(),
+	}
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			MinAdmissionIntervalSeconds(10).
+			Obj(),
+		*utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	queues := []kueue.LocalQueue{
+		*utiltesting.MakeLocalQueue("lq-a", "default").ClusterQueue("cq-a").Obj(),
+		*utiltesting.MakeLocalQueue("lq-b", "default").ClusterQueue("cq-b").Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("a1", "default").Queue("lq-a").Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("a2", "default").Queue("lq-a").Creation(now.Add(time.Second)).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("a3", "default").Queue("lq-a").Creation(now.Add(2*time.Second)).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("b1", "default").Queue("lq-b").Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("b2", "default").Queue("lq-b").Creation(now.Add(time.Second)).
+			Request(corev1.ResourceCPU, "1").Obj(),
+	}
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: queues}).
+		WithObjects(utiltesting.MakeNamespace("default")).
+		Build()
+	recorder := &utiltesting.EventRecorder{}
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	for i := range resourceFlavors {
+		cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
+	}
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range queues {
+		if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+			t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+		}
+	}
+	fakeClock := testingclock.NewFakeClock(now)
+	scheduler := New(qManager, cqCache, cl, recorder, WithClock(t, fakeClock))
+	var gotScheduled []string
+	var mu sync.Mutex
+	scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+		mu.Lock()
+		gotScheduled = append(gotScheduled, workload.Key(w))
+		mu.Unlock()
+		return nil
+	}
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	go qManager.CleanUpOnContext(ctx)
+	defer cancel()
+
+	steps := []struct {
+		// elapsed is the time elapsed since the previous scheduling cycle.
+		elapsed      time.Duration
+		wantAdmitted []string
+	}{
+		{wantAdmitted: []string{"default/a1", "default/b1"}},
+		{elapsed: 5 * time.Second, wantAdmitted: []string{"default/b2"}},
+		{elapsed: 5 * time.Second, wantAdmitted: []string{"default/a2"}},
+		{elapsed: 9 * time.Second},
+		{elapsed: time.Second, wantAdmitted: []string{"default/a3"}},
+	}
+	for i, step := range steps {
+		fakeClock.Step(step.elapsed)
+		plan, err := scheduler.ScheduleDryRun(ctx)
+		if err != nil {
+			t.Fatalf("Dry-run scheduling in cycle %d: %v", i+1, err)
+		}
+		var gotPlanned []string
+		for _, a := range plan.Admissions {
+			gotPlanned = append(gotPlanned, a.Workload.String())
+		}
+		if diff := cmp.Diff(step.wantAdmitted, gotPlanned, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("Unexpected planned admissions in cycle %d (-want,+got):\n%s", i+1, diff)
+		}
+		gotScheduled = nil
+		scheduler.schedule(ctx)
+		wg.Wait()
+		if diff := cmp.Diff(step.wantAdmitted, gotScheduled, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("Unexpected admitted workloads in cycle %d (-want,+got):\n%s", i+1, diff)
+		}
+	}
+
+	wantPendingEvents := []utiltesting.EventRecord{
+		{
+			Key:       types.NamespacedName{Namespace: "default", Name: "a2"},
+			EventType: corev1.EventTypeWarning,
+			Reason:    "Pending",
+			Message:   "Waiting 5s for the minimum interval between admissions in ClusterQueue cq-a to elapse",
+		},
+		{
+			Key:       types.NamespacedName{Namespace: "default", Name: "a3"},
+			EventType: corev1.EventTypeWarning,
+			Reason:    "Pending",
+			Message:   "Waiting 1s for the minimum interval between admissions in ClusterQueue cq-a to elapse",
+		},
+	}
+	var gotPendingEvents []utiltesting.EventRecord
+	for _, e := range recorder.RecordedEvents {
+		if e.Reason == "Pending" {
+			gotPendingEvents = append(gotPendingEvents, e)
+		}
+	}
+	if diff := cmp.Diff(wantPendingEvents, gotPendingEvents); diff != "" {
+		t.Errorf("Unexpected pending events (-want,+got):\n%s", diff)
+	}
+}
+
+func TestScheduleDryRunConcurrentWithSchedule(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now()
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			MinAdmissionIntervalSeconds(10).
+			Obj(),
+		*utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	queues := []kueue.LocalQueue{
+		*utiltesting.MakeLocalQueue("lq-a", "default").ClusterQueue("cq-a").Obj(),
+		*utiltesting.MakeLocalQueue("lq-b", "default").ClusterQueue("cq-b").Obj(),
+	}
+	// There are enough workloads to keep both ClusterQueues busy during all
+	// the scheduling cycles.
+	var workloads []kueue.Workload
+	for i := range 25 {
+		workloads = append(workloads,
+			*utiltesting.MakeWorkload(fmt.Sprintf("a%d", i), "default").Queue("lq-a").Creation(now.Add(time.Duration(i)*time.Second)).
+				Request(corev1.ResourceCPU, "1").Obj(),
+			*utiltesting.MakeWorkload(fmt.Sprintf("b%d", i), "default").Queue("lq-b").Creation(now.Add(time.Duration(i)*time.Second)).
+				Request(corev1.ResourceCPU, "1").Obj(),
+		)
+	}
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: queues}).
+		WithObjects(utiltesting.MakeNamespace("default")).
+		Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range queues {
+		if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+			t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+		}
+	}
+	fakeClock := testingclock.NewFakeClock(now)
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock), WithMaxAdmissionsPerCycle(ptr.To[int32](1)))
+	scheduler.applyAdmission = func(context.Context, *kueue.Workload) error {
+		return nil
+	}
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	go qManager.CleanUpOnContext(ctx)
+	defer cancel()
+
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	// The dry run doesn't forget the last admission in cq-a, even once the
+	// minimum interval between admissions has elapsed.
+	fakeClock.Step(time.Minute)
+	scheduler.stateLock.RLock()
+	wantLastAdmissions := maps.Clone(scheduler.lastAdmissions)
+	wantDeferred := scheduler.deferredWorkloads.Clone()
+	scheduler.stateLock.RUnlock()
+	if _, err := scheduler.ScheduleDryRun(ctx); err != nil {
+		t.Fatalf("Unexpected error in the dry-run scheduling: %v", err)
+	}
+	if diff := cmp.Diff(wantLastAdmissions, scheduler.lastAdmissions); diff != "" {
+		t.Errorf("Unexpected last admissions after the dry run (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantDeferred, scheduler.deferredWorkloads); diff != "" {
+		t.Errorf("Unexpected deferred workloads after the dry run (-want,+got):\n%s", diff)
+	}
+
+	// The dry run can be called concurrently with the scheduling loop.
+	done := make(chan struct{})
+	var dryRunWg sync.WaitGroup
+	dryRunWg.Add(1)
+	go func() {
+		defer dryRunWg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := scheduler.ScheduleDryRun(ctx); err != nil {
+				t.Errorf("Unexpected error in the dry-run scheduling: %v", err)
+				return
+			}
+		}
+	}()
+	for range 20 {
+		fakeClock.Step(5 * time.Second)
+		scheduler.schedule(ctx)
+	}
+	close(done)
+	dryRunWg.Wait()
+	wg.Wait()
+}
+
+func TestScheduleSchedulingDecisionEvents(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.SchedulingDecisionEvents, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now()
+	resourceFlavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("on-demand").Obj(),
+		utiltesting.MakeResourceFlavor("spot").Obj(),
+	}
+	clusterQueue := *utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource(corev1.ResourceCPU, "2").
+				Resource(corev1.ResourceMemory, "10Gi").Obj(),
+			*utiltesting.MakeFlavorQuotas("spot").
+				Resource(corev1.ResourceCPU, "10").
+				Resource(corev1.ResourceMemory, "10Gi").Obj(),
+		).
+		Obj()
+	lq := *utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+	wl := *utiltesting.MakeWorkload("wl", "default").Queue("lq").Creation(now).
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).
+				Request(corev1.ResourceCPU, "1").
+				Obj(),
+			*utiltesting.MakePodSet("workers", 4).
+				Request(corev1.ResourceCPU, "1").
+				Request(corev1.ResourceMemory, "1Gi").
+				Obj(),
+		).
+		Obj()
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: []kueue.Workload{wl}}, &kueue.LocalQueueList{Items: []kueue.LocalQueue{lq}}).
+		WithObjects(utiltesting.MakeNamespace("default")).
+		Build()
+	recorder := &utiltesting.EventRecorder{}
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	for i := range resourceFlavors {
+		cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
+	}
+	if err := cqCache.AddClusterQueue(ctx, &clusterQueue); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in cache: %v", clusterQueue.Name, err)
+	}
+	if err := qManager.AddClusterQueue(ctx, &clusterQueue); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in manager: %v", clusterQueue.Name, err)
+	}
+	if err := qManager.AddLocalQueue(ctx, &lq); err != nil {
+		t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+	}
+	scheduler := New(qManager, cqCache, cl, recorder, WithClock(t, testingclock.NewFakeClock(now)))
+	scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+		return nil
+	}
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	go qManager.CleanUpOnContext(ctx)
+	defer cancel()
+
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	wantEvents := []utiltesting.EventRecord{{
+		Key:       types.NamespacedName{Namespace: "default", Name: "wl"},
+		EventType: corev1.EventTypeNormal,
+		Reason:    "SchedulingDecision",
+		Message:   "Quota reserved in ClusterQueue cq with flavors driver: cpu=on-demand; workers: cpu=spot, memory=spot",
+		Annotations: map[string]string{
+			SchedulingDecisionAnnotation: `{"clusterQueue":"cq","mode":"Fit","admissionPath":"Nominal","podSets":[` +
+				`{"name":"driver","count":1,"resources":[{"name":"cpu","flavor":"on-demand","mode":"Fit","quantity":"1"}]},` +
+				`{"name":"workers","count":4,"resources":[{"name":"cpu","flavor":"spot","mode":"Fit","quantity":"4"},` +
+				`{"name":"memory","flavor":"spot","mode":"Fit","quantity":"4Gi"}]}]}`,
+		},
+	}}
+	var gotEvents []utiltesting.EventRecord
+	for _, e := range recorder.RecordedEvents {
+		if e.Reason == "SchedulingDecision" {
+			gotEvents = append(gotEvents, e)
+		}
+	}
+	if diff := cmp.Diff(wantEvents, gotEvents); diff != "" {
+		t.Errorf("Unexpected scheduling decision events (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSchedulingDecisionWithPreemptions(t *testing.T) {
+	preemptedWl := utiltesting.MakeWorkload("low", "default").Obj()
+	e := &entry{
+		Info: workload.Info{ClusterQueue: "cq"},
+		assignment: flavorassigner.Assignment{
+			PodSets: []flavorassigner.PodSetAssignment{
+				{
+					Name: "driver",
+					Flavors: flavorassigner.ResourceAssignment{
+						corev1.ResourceCPU: {Name: "on-demand", Mode: flavorassigner.Fit},
+					},
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Count:    1,
+				},
+				{
+					Name: "workers",
+					Flavors: flavorassigner.ResourceAssignment{
+						corev1.ResourceCPU: {Name: "on-demand", Mode: flavorassigner.Preempt},
+					},
+					Status:   &flavorassigner.Status{},
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+					Count:    4,
+				},
+			},
+		},
+	}
+	targets := []*preemption.Target{{
+		WorkloadInfo: &workload.Info{Obj: preemptedWl, ClusterQueue: "other-cq"},
+		Reason:       kueue.InCohortReclamationReason,
+	}}
+
+	d := newSchedulingDecision(e, "", targets)
+	wantDecision := &schedulingDecision{
+		ClusterQueue: "cq",
+		Mode:         "Preempt",
+		PodSets: []podSetDecision{
+			{
+				Name:  "driver",
+				Count: 1,
+				Resources: []resourceDecision{
+					{Name: corev1.ResourceCPU, Flavor: "on-demand", Mode: "Fit", Quantity: resource.MustParse("1")},
+				},
+			},
+			{
+				Name:  "workers",
+				Count: 4,
+				Resources: []resourceDecision{
+					{Name: corev1.ResourceCPU, Flavor: "on-demand", Mode: "Preempt", Quantity: resource.MustParse("4")},
+				},
+			},
+		},
+		Preemptions: []preemptionDecision{{
+			Workload:     klog.KObj(preemptedWl),
+			ClusterQueue: "other-cq",
+			Reason:       kueue.InCohortReclamationReason,
+		}},
+	}
+	if diff := cmp.Diff(wantDecision, d); diff != "" {
+		t.Errorf("Unexpected scheduling decision (-want,+got):\n%s", diff)
+	}
+	wantMessage := "Preempting 1 workload(s) to reserve quota in ClusterQueue cq with flavors driver: cpu=on-demand; workers: cpu=on-demand"
+	if got := d.String(); got != wantMessage {
+		t.Errorf("Unexpected message, want=%q, got=%q", wantMessage, got)
+	}
+}
+
+func TestScheduleCycleMetrics(t *testing.T) {
+	now := time.Now()
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		*utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	queues := []kueue.LocalQueue{
+		*utiltesting.MakeLocalQueue("lq-a", "default").ClusterQueue("cq-a").Obj(),
+		*utiltesting.MakeLocalQueue("lq-b", "default").ClusterQueue("cq-b").Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("a1", "default").Queue("lq-a").Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("a2", "default").Queue("lq-a").Creation(now.Add(time.Second)).
+			Request(corev1.ResourceCPU, "1").Obj(),
+		*utiltesting.MakeWorkload("b1", "default").Queue("lq-b").Creation(now).
+			Request(corev1.ResourceCPU, "1").Obj(),
+	}
+
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: queues}).
+		WithObjects(utiltesting.MakeNamespace("default")).
+		Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range queues {
+		if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+			t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+		}
+	}
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, testingclock.NewFakeClock(now)))
+	scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+		return nil
+	}
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	go qManager.CleanUpOnContext(ctx)
+	defer cancel()
+
+	cyclesBefore, err := testutil.GetHistogramMetricCount(metrics.SchedulerCycleDuration)
+	if err != nil {
+		t.Fatalf("Couldn't get the count of scheduler_cycle_duration_seconds: %v", err)
+	}
+	consideredBefore, err := testutil.GetHistogramMetricValue(metrics.SchedulerWorkloadsConsidered)
+	if err != nil {
+		t.Fatalf("Couldn't get the sum of scheduler_workloads_considered: %v", err)
+	}
+
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	cycles, err := testutil.GetHistogramMetricCount(metrics.SchedulerCycleDuration)
+	if err != nil {
+		t.Fatalf("Couldn't get the count of scheduler_cycle_duration_seconds: %v", err)
+	}
+	if got := cycles - cyclesBefore; got != 1 {
+		t.Errorf("Observed %d scheduling cycles, want 1", got)
+	}
+	considered, err := testutil.GetHistogramMetricValue(metrics.SchedulerWorkloadsConsidered)
+	if err != nil {
+		t.Fatalf("Couldn't get the sum of scheduler_workloads_considered: %v", err)
+	}
+	// The heads of both ClusterQueues are considered in the cycle.
+	if got := considered - consideredBefore; got != 2 {
+		t.Errorf("Observed %v workloads considered, want 2", got)
+	}
+}
+
 var ignoreConditionTimestamps = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
 
 func TestRequeueAndUpdate(t *testing.T) {
@@ -4072,15 +6192,37 @@ func TestRequeueAndUpdate(t *testing.T) {
 		{
 			name: "workload didn't fit",
 			e: entry{
-				inadmissibleMsg: "didn't fit",
+				inadmissibleMsg: "didn't fit",
+			},
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "didn't fit",
+					},
+				},
+				ResourceRequests: []kueue.PodSetRequest{{Name: kueue.DefaultPodSetName}},
+			},
+			wantInadmissible: map[kueue.ClusterQueueReference][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
+		{
+			name: "namespace not allowed",
+			e: entry{
+				inadmissibleMsg: "Workload namespace doesn't match ClusterQueue selector",
+				requeueReason:   queue.RequeueReasonNamespaceMismatch,
 			},
 			wantStatus: kueue.WorkloadStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:    kueue.WorkloadQuotaReserved,
 						Status:  metav1.ConditionFalse,
-						Reason:  "Pending",
-						Message: "didn't fit",
+						Reason:  kueue.WorkloadNamespaceNotAllowed,
+						Message: "Workload namespace doesn't match ClusterQueue selector",
 					},
 				},
 				ResourceRequests: []kueue.PodSetRequest{{Name: kueue.DefaultPodSetName}},
@@ -4132,6 +6274,52 @@ func TestRequeueAndUpdate(t *testing.T) {
 			},
 			wantStatusUpdates: 1,
 		},
+		{
+			name: "pending the preemptions issued in the cycle",
+			e: entry{
+				inadmissibleMsg: "insufficient unused quota for cpu in flavor default, 1 more needed. Pending the preemption of 1 workload(s)",
+				requeueReason:   queue.RequeueReasonPendingPreemption,
+				preemptionPath:  kueue.AdmissionPathReclaimWithinCohort,
+			},
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "insufficient unused quota for cpu in flavor default, 1 more needed. Pending the preemption of 1 workload(s)",
+					},
+				},
+				ResourceRequests: []kueue.PodSetRequest{{Name: kueue.DefaultPodSetName}},
+				AdmissionPath:    kueue.AdmissionPathReclaimWithinCohort,
+			},
+			wantWorkloads: map[kueue.ClusterQueueReference][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
+		{
+			name: "workload exceeding the capacity of the clusterQueue",
+			e: entry{
+				inadmissibleMsg:      "insufficient quota for cpu in flavor default, request > maximum capacity",
+				exceedsQueueCapacity: true,
+			},
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  kueue.WorkloadExceedsQueueCapacity,
+						Message: "insufficient quota for cpu in flavor default, request > maximum capacity",
+					},
+				},
+				ResourceRequests: []kueue.PodSetRequest{{Name: kueue.DefaultPodSetName}},
+			},
+			wantInadmissible: map[kueue.ClusterQueueReference][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
 	}
 
 	for _, tc := range cases {
@@ -4394,6 +6582,176 @@ func TestResourcesToReserve(t *testing.T) {
 	}
 }
 
+func TestAdmissionPath(t *testing.T) {
+	cases := map[string]struct {
+		pendingPath kueue.AdmissionPath
+		borrowing   int
+		want        kueue.AdmissionPath
+	}{
+		"fits in the nominal quota": {
+			want: kueue.AdmissionPathNominal,
+		},
+		"fits by borrowing": {
+			borrowing: 1,
+			want:      kueue.AdmissionPathBorrowing,
+		},
+		"fits after preempting within the ClusterQueue": {
+			pendingPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+			borrowing:   1,
+			want:        kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"fits after reclaiming within the cohort": {
+			pendingPath: kueue.AdmissionPathReclaimWithinCohort,
+			want:        kueue.AdmissionPathReclaimWithinCohort,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &entry{
+				Info:       *workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").AdmissionPath(tc.pendingPath).Obj()),
+				assignment: flavorassigner.Assignment{Borrowing: tc.borrowing},
+			}
+			if got := admissionPath(e); got != tc.want {
+				t.Errorf("Unexpected admission path, want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPendingAdmissionPath(t *testing.T) {
+	cases := map[string]struct {
+		pendingPath             kueue.AdmissionPath
+		preemptionPath          kueue.AdmissionPath
+		preemptionFailureReason string
+		want                    kueue.AdmissionPath
+	}{
+		"issues preemptions": {
+			preemptionPath: kueue.AdmissionPathReclaimWithinCohort,
+			want:           kueue.AdmissionPathReclaimWithinCohort,
+		},
+		"waits for the preemptions issued before": {
+			pendingPath:    kueue.AdmissionPathPreemptionWithinClusterQueue,
+			preemptionPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+			want:           kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"fits after the preemptions issued before, but isn't admitted in the cycle": {
+			pendingPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+			want:        kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"has no preemption targets after the preemptions issued before": {
+			pendingPath:             kueue.AdmissionPathPreemptionWithinClusterQueue,
+			preemptionFailureReason: preemption.PreemptionFailedNoCandidates,
+		},
+		"doesn't fit after the preemptions issued before": {
+			pendingPath:             kueue.AdmissionPathReclaimWithinCohort,
+			preemptionFailureReason: preemption.PreemptionFailedInsufficientCapacity,
+		},
+		"fits without issuing preemptions": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &entry{
+				Info:                    *workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").AdmissionPath(tc.pendingPath).Obj()),
+				preemptionPath:          tc.preemptionPath,
+				preemptionFailureReason: tc.preemptionFailureReason,
+			}
+			if got := e.pendingAdmissionPath(); got != tc.want {
+				t.Errorf("Unexpected pending admission path, want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFailedPreemptionReason(t *testing.T) {
+	preempted := utiltesting.MakeWorkload("preempted", "ns").UID("preempted").Obj()
+	other := utiltesting.MakeWorkload("other", "ns").UID("other").Obj()
+	cases := map[string]struct {
+		pendingPath             kueue.AdmissionPath
+		targets                 []*kueue.Workload
+		preemptionPath          kueue.AdmissionPath
+		preemptionFailureReason string
+		want                    string
+	}{
+		"issues preemptions for the first time": {
+			targets:        []*kueue.Workload{preempted},
+			preemptionPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"doesn't fit without having issued preemptions": {
+			preemptionFailureReason: preemption.PreemptionFailedNoCandidates,
+		},
+		"waits for the preemptions issued before": {
+			pendingPath:    kueue.AdmissionPathPreemptionWithinClusterQueue,
+			targets:        []*kueue.Workload{preempted},
+			preemptionPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"isn't evaluated while waiting for the preemptions issued before": {
+			pendingPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"has no preemption targets after the preemptions issued before": {
+			pendingPath:             kueue.AdmissionPathPreemptionWithinClusterQueue,
+			preemptionFailureReason: preemption.PreemptionFailedNoCandidates,
+			want:                    preemption.PreemptionFailedNoCandidates,
+		},
+		"doesn't fit after the preemptions issued before": {
+			pendingPath:             kueue.AdmissionPathReclaimWithinCohort,
+			preemptionFailureReason: preemption.PreemptionFailedInsufficientCapacity,
+			want:                    preemption.PreemptionFailedInsufficientCapacity,
+		},
+		"needs to preempt more workloads after the preemptions issued before": {
+			pendingPath:    kueue.AdmissionPathPreemptionWithinClusterQueue,
+			targets:        []*kueue.Workload{preempted, other},
+			preemptionPath: kueue.AdmissionPathPreemptionWithinClusterQueue,
+			want:           preemption.PreemptionFailedInsufficientCapacity,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				AdmissionPath(tc.pendingPath).
+				PreemptedWorkloads(workload.PreemptionReference(preempted)).
+				Obj()
+			e := &entry{
+				Info:                    *workload.NewInfo(wl),
+				preemptionPath:          tc.preemptionPath,
+				preemptionFailureReason: tc.preemptionFailureReason,
+			}
+			for _, target := range tc.targets {
+				e.preemptionTargets = append(e.preemptionTargets, &preemption.Target{WorkloadInfo: workload.NewInfo(target)})
+			}
+			if got := e.failedPreemptionReason(); got != tc.want {
+				t.Errorf("Unexpected failed preemption reason, want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPreemptionPath(t *testing.T) {
+	cases := map[string]struct {
+		reasons []string
+		want    kueue.AdmissionPath
+	}{
+		"only targets in the ClusterQueue": {
+			reasons: []string{kueue.InClusterQueueReason, kueue.InClusterQueueReason},
+			want:    kueue.AdmissionPathPreemptionWithinClusterQueue,
+		},
+		"a target reclaimed in the cohort": {
+			reasons: []string{kueue.InClusterQueueReason, kueue.InCohortReclamationReason},
+			want:    kueue.AdmissionPathReclaimWithinCohort,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			targets := make([]*preemption.Target, len(tc.reasons))
+			for i, reason := range tc.reasons {
+				targets[i] = &preemption.Target{Reason: reason}
+			}
+			if got := preemptionPath(targets); got != tc.want {
+				t.Errorf("Unexpected preemption path, want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestScheduleForTAS(t *testing.T) {
 	const (
 		tasRackLabel = "cloud.provider.com/rack"
@@ -5116,7 +7474,7 @@ func TestScheduleForTAS(t *testing.T) {
 							Request(corev1.ResourceCPU, "1").
 							Obj(),
 						*utiltesting.MakePodSet("worker", 1).
-							PreferredTopologyRequest(corev1.LabelHostname).
+							RequiredTopologyRequest(corev1.LabelHostname).
 							Request(corev1.ResourceCPU, "7").
 							Obj()).
 					Obj(),
@@ -5133,6 +7491,85 @@ func TestScheduleForTAS(t *testing.T) {
 				},
 			},
 		},
+		"workload with multiple PodSets requesting the same TAS flavor; preferred PodSet which doesn't fit is admitted without topology assignment": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "y1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologies:      []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASTwoLevelFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "50").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(
+						*utiltesting.MakePodSet("launcher", 1).
+							PreferredTopologyRequest(corev1.LabelHostname).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+						*utiltesting.MakePodSet("worker", 1).
+							PreferredTopologyRequest(corev1.LabelHostname).
+							Request(corev1.ResourceCPU, "7").
+							Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "launcher", "worker").
+					AssignmentWithIndex(0, corev1.ResourceCPU, "tas-default", "1000m").
+					AssignmentPodCountWithIndex(0, 1).
+					TopologyAssignmentWithIndex(0, &kueue.TopologyAssignment{
+						Levels: []string{corev1.LabelHostname},
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count: 1,
+								Values: []string{
+									"x1",
+								},
+							},
+						},
+					}).
+					AssignmentWithIndex(1, corev1.ResourceCPU, "tas-default", "7000m").
+					AssignmentPodCountWithIndex(1, 1).
+					Obj(),
+			},
+			eventCmpOpts: cmp.Options{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
 		"scheduling workload with multiple PodSets requesting TAS flavor and will succeed": {
 			nodes: []corev1.Node{
 				*testingnode.MakeNode("x1").
@@ -5632,9 +8069,104 @@ func TestScheduleForTAS(t *testing.T) {
 						Levels: utiltas.Levels(&defaultSingleLevelTopology),
 						Domains: []kueue.TopologyDomainAssignment{
 							{
-								Count: 1,
+								Count: 1,
+								Values: []string{
+									"x1",
+								},
+							},
+						},
+					}).Obj(),
+			},
+			eventCmpOpts: cmp.Options{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload which does not specify TAS annotation gets the default required level of the flavor": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "y1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y2").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "y2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologies: []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{
+				*utiltesting.MakeResourceFlavor("tas-default").
+					NodeLabel("tas-node", "true").
+					TopologyName("tas-two-level").
+					DefaultRequiredTopologyLevel(tasRackLabel).
+					Obj(),
+				defaultFlavor,
+			},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "50").Obj(),
+						*utiltesting.MakeFlavorQuotas("default").
+							Resource(corev1.ResourceCPU, "50").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 4).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "4000m").
+					AssignmentPodCount(4).
+					TopologyAssignment(&kueue.TopologyAssignment{
+						Levels: []string{corev1.LabelHostname},
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count: 2,
+								Values: []string{
+									"y1",
+								},
+							},
+							{
+								Count: 2,
 								Values: []string{
-									"x1",
+									"y2",
 								},
 							},
 						},
@@ -5654,6 +8186,78 @@ func TestScheduleForTAS(t *testing.T) {
 				},
 			},
 		},
+		"workload which does not specify TAS annotation doesn't fit in the default required level of the flavor": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y1").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "y1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y2").
+					Label("tas-node", "true").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "y2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologies: []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{
+				*utiltesting.MakeResourceFlavor("tas-default").
+					NodeLabel("tas-node", "true").
+					TopologyName("tas-two-level").
+					DefaultRequiredTopologyLevel(tasRackLabel).
+					Obj(),
+				defaultFlavor,
+			},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "50").Obj(),
+						*utiltesting.MakeFlavorQuotas("default").
+							Resource(corev1.ResourceCPU, "50").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 5).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			// the workload is requeued to try the next flavor
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: cmp.Options{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					EventType: "Warning",
+					Reason:    "Pending",
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -5685,6 +8289,7 @@ func TestScheduleForTAS(t *testing.T) {
 					tasCache := cqCache.TASCache()
 					levels := utiltas.Levels(&t)
 					tasFlavorCache := tasCache.NewTASFlavorCache(*flavor.Spec.TopologyName, levels, flavor.Spec.NodeLabels, flavor.Spec.Tolerations)
+					tasFlavorCache.DefaultRequiredLevel = flavor.Spec.DefaultRequiredTopologyLevel
 					tasCache.Set(kueue.ResourceFlavorReference(flavor.Name), tasFlavorCache)
 				}
 			}
@@ -5770,6 +8375,7 @@ func TestScheduleForTAS(t *testing.T) {
 }
 
 func TestScheduleForTASPreemption(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
 	singleNode := testingnode.MakeNode("x1").
 		Label("tas-node", "true").
 		Label(corev1.LabelHostname, "x1").
@@ -5859,7 +8465,7 @@ func TestScheduleForTASPreemption(t *testing.T) {
 					Queue("tas-main").
 					Priority(3).
 					PodSets(*utiltesting.MakePodSet("one", 1).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "2").
 						Obj()).
 					Obj(),
@@ -5908,6 +8514,69 @@ func TestScheduleForTASPreemption(t *testing.T) {
 				},
 			},
 		},
+		"workload with preferred topology is admitted without topology assignment when the node is full of TAS workloads": {
+			// The preferred topology is a best-effort optimization, so the
+			// workload doesn't preempt the TAS workloads to get a topology
+			// assignment.
+			nodes:           defaultSingleNode,
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			clusterQueues:   []kueue.ClusterQueue{defaultClusterQueueWithPreemption},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(3).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						PreferredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("low-priority-admitted", "default").
+					Queue("tas-main").
+					Priority(1).
+					ReserveQuota(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "5").
+							AssignmentPodCount(1).
+							TopologyAssignment(&kueue.TopologyAssignment{
+								Levels: utiltas.Levels(&defaultSingleLevelTopology),
+								Domains: []kueue.TopologyDomainAssignment{
+									{
+										Count: 1,
+										Values: []string{
+											"x1",
+										},
+									},
+								},
+							}).Obj(),
+					).
+					Admitted(true).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "2").
+					AssignmentPodCount(1).
+					Obj(),
+			},
+			eventCmpOpts: cmp.Options{cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message")},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					EventType: "Normal",
+					Reason:    "QuotaReserved",
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					EventType: "Normal",
+					Reason:    "Admitted",
+				},
+			},
+		},
 		"With pods count usage pressure on nodes: only low priority workload is preempted": {
 			// This test case demonstrates the baseline scenario where there
 			// is only one low-priority workload and it gets preempted even if node has pods count usage pressure.
@@ -5924,7 +8593,7 @@ func TestScheduleForTASPreemption(t *testing.T) {
 					Queue("tas-main").
 					Priority(3).
 					PodSets(*utiltesting.MakePodSet("one", 1).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "2").
 						Obj()).
 					Obj(),
@@ -5985,7 +8654,7 @@ func TestScheduleForTASPreemption(t *testing.T) {
 					Queue("tas-main").
 					Priority(3).
 					PodSets(*utiltesting.MakePodSet("one", 1).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "2").
 						Obj()).
 					Obj(),
@@ -6147,6 +8816,165 @@ func TestScheduleForTASPreemption(t *testing.T) {
 				},
 			},
 		},
+		"workload in the rack with fewer targets is preempted when the workload requires a rack": {
+			// The workloads in the rack r1 are the most recently admitted, so
+			// they are the first candidates. However, it's enough to preempt
+			// the workload in the rack r2 to fit the incoming workload there.
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("tas-node", "true").
+					Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+					Label(utiltesting.DefaultRackTopologyLevel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x2").
+					Label("tas-node", "true").
+					Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+					Label(utiltesting.DefaultRackTopologyLevel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y1").
+					Label("tas-node", "true").
+					Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+					Label(utiltesting.DefaultRackTopologyLevel, "r2").
+					Label(corev1.LabelHostname, "y1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("y2").
+					Label("tas-node", "true").
+					Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+					Label(utiltesting.DefaultRackTopologyLevel, "r2").
+					Label(corev1.LabelHostname, "y2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologies: []kueuealpha.Topology{*utiltesting.MakeDefaultThreeLevelTopology("tas-three-level")},
+			resourceFlavors: []kueue.ResourceFlavor{
+				*utiltesting.MakeResourceFlavor("tas-default").
+					NodeLabel("tas-node", "true").
+					TopologyName("tas-three-level").
+					Obj(),
+			},
+			clusterQueues: []kueue.ClusterQueue{defaultClusterQueueWithPreemption},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(3).
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(utiltesting.DefaultRackTopologyLevel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("r1-newest", "default").
+					Queue("tas-main").
+					Priority(1).
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "1").
+							AssignmentPodCount(1).
+							TopologyAssignment(&kueue.TopologyAssignment{
+								Levels: []string{corev1.LabelHostname},
+								Domains: []kueue.TopologyDomainAssignment{
+									{
+										Count:  1,
+										Values: []string{"x1"},
+									},
+								},
+							}).Obj(),
+						now.Add(-1*time.Minute),
+					).
+					Admitted(true).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("r1-newer", "default").
+					Queue("tas-main").
+					Priority(1).
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "1").
+							AssignmentPodCount(1).
+							TopologyAssignment(&kueue.TopologyAssignment{
+								Levels: []string{corev1.LabelHostname},
+								Domains: []kueue.TopologyDomainAssignment{
+									{
+										Count:  1,
+										Values: []string{"x2"},
+									},
+								},
+							}).Obj(),
+						now.Add(-2*time.Minute),
+					).
+					Admitted(true).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("r2-oldest", "default").
+					Queue("tas-main").
+					Priority(1).
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "1").
+							AssignmentPodCount(1).
+							TopologyAssignment(&kueue.TopologyAssignment{
+								Levels: []string{corev1.LabelHostname},
+								Domains: []kueue.TopologyDomainAssignment{
+									{
+										Count:  1,
+										Values: []string{"y1"},
+									},
+								},
+							}).Obj(),
+						now.Add(-3*time.Minute),
+					).
+					Admitted(true).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantPreempted: sets.New("default/r2-oldest"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "r2-oldest"},
+					EventType: "Normal",
+					Reason:    "Preempted",
+					Message:   "Preempted to accommodate a workload (UID: UNKNOWN, JobUID: UNKNOWN) due to prioritization in the ClusterQueue",
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					EventType: "Warning",
+					Reason:    "Pending",
+					Message:   `couldn't assign flavors to pod set one: topology "tas-three-level" allows to fit only 1 out of 2 pod(s). Pending the preemption of 1 workload(s)`,
+				},
+			},
+		},
 		"workload with equal priority awaits for other workloads to complete": {
 			// In this test case the waiting workload cannot preempt the running
 			// workload as they are both with the same priority. Still, the
@@ -6287,7 +9115,7 @@ func TestScheduleForTASPreemption(t *testing.T) {
 			))
 
 			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w, _ *kueue.Workload, _, _ string) error {
 				mu.Lock()
 				gotPreempted.Insert(workload.Key(w))
 				mu.Unlock()
@@ -7274,7 +10102,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-a").
 					Priority(2).
 					PodSets(*utiltesting.MakePodSet("one", 4).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7282,7 +10110,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-b").
 					Priority(1).
 					PodSets(*utiltesting.MakePodSet("one", 3).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7355,7 +10183,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-a").
 					Priority(2).
 					PodSets(*utiltesting.MakePodSet("one", 4).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7363,7 +10191,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-b").
 					Priority(1).
 					PodSets(*utiltesting.MakePodSet("one", 3).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7432,7 +10260,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-a").
 					Priority(2).
 					PodSets(*utiltesting.MakePodSet("one", 4).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7440,7 +10268,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 					Queue("tas-lq-b").
 					Priority(1).
 					PodSets(*utiltesting.MakePodSet("one", 3).
-						PreferredTopologyRequest(corev1.LabelHostname).
+						RequiredTopologyRequest(corev1.LabelHostname).
 						Request(corev1.ResourceCPU, "1").
 						Obj()).
 					Obj(),
@@ -7556,7 +10384,7 @@ func TestScheduleForTASCohorts(t *testing.T) {
 			))
 
 			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w, _ *kueue.Workload, _, _ string) error {
 				mu.Lock()
 				gotPreempted.Insert(workload.Key(w))
 				mu.Unlock()
@@ -232,6 +232,25 @@ func TestHeap_GetByKey(t *testing.T) {
 	}
 }
 
+// TestHeap_Peek tests Heap.Peek function.
+func TestHeap_Peek(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	if obj := h.Peek(); obj != nil {
+		t.Fatalf("didn't expect to get any object from an empty heap")
+	}
+	h.PushOrUpdate(mkHeapObj("foo", 10))
+	h.PushOrUpdate(mkHeapObj("bar", 1))
+	h.PushOrUpdate(mkHeapObj("baz", 11))
+
+	obj := h.Peek()
+	if obj == nil || obj.val != 1 {
+		t.Fatalf("unexpected head of the heap: %v", obj)
+	}
+	if h.Len() != 3 {
+		t.Fatalf("expected Peek to keep the head in the heap, got %d items", h.Len())
+	}
+}
+
 // TestHeap_List tests Heap.List function.
 func TestHeap_List(t *testing.T) {
 	h := New(testHeapObjectKeyFunc, compareInts)
@@ -73,11 +73,11 @@ func AsIndexer(builder *fake.ClientBuilder) client.FieldIndexer {
 }
 
 type EventRecord struct {
-	Key       types.NamespacedName
-	EventType string
-	Reason    string
-	Message   string
-	// add annotations if ever needed
+	Key         types.NamespacedName
+	EventType   string
+	Reason      string
+	Message     string
+	Annotations map[string]string
 }
 
 type EventRecorder struct {
@@ -104,18 +104,18 @@ func SortEvents(ei, ej EventRecord) bool {
 }
 
 func (tr *EventRecorder) Event(object runtime.Object, eventType, reason, message string) {
-	tr.generateEvent(object, eventType, reason, message)
+	tr.generateEvent(object, nil, eventType, reason, message)
 }
 
 func (tr *EventRecorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...any) {
 	tr.AnnotatedEventf(object, nil, eventType, reason, messageFmt, args...)
 }
 
-func (tr *EventRecorder) AnnotatedEventf(targetObject runtime.Object, _ map[string]string, eventType, reason, messageFmt string, args ...any) {
-	tr.generateEvent(targetObject, eventType, reason, fmt.Sprintf(messageFmt, args...))
+func (tr *EventRecorder) AnnotatedEventf(targetObject runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...any) {
+	tr.generateEvent(targetObject, annotations, eventType, reason, fmt.Sprintf(messageFmt, args...))
 }
 
-func (tr *EventRecorder) generateEvent(targetObject runtime.Object, eventType, reason, message string) {
+func (tr *EventRecorder) generateEvent(targetObject runtime.Object, annotations map[string]string, eventType, reason, message string) {
 	tr.lock.Lock()
 	defer tr.lock.Unlock()
 	key := types.NamespacedName{}
@@ -123,10 +123,11 @@ func (tr *EventRecorder) generateEvent(targetObject runtime.Object, eventType, r
 		key = client.ObjectKeyFromObject(cObj)
 	}
 	tr.RecordedEvents = append(tr.RecordedEvents, EventRecord{
-		Key:       key,
-		EventType: eventType,
-		Reason:    reason,
-		Message:   message,
+		Key:         key,
+		EventType:   eventType,
+		Reason:      reason,
+		Message:     message,
+		Annotations: annotations,
 	})
 }
 
@@ -265,6 +265,25 @@ func (w *WorkloadWrapper) Condition(condition metav1.Condition) *WorkloadWrapper
 	return w
 }
 
+// AdmissionHistory sets the admission history of the workload.
+func (w *WorkloadWrapper) AdmissionHistory(entries ...kueue.AdmissionHistoryEntry) *WorkloadWrapper {
+	w.Status.AdmissionHistory = entries
+	return w
+}
+
+// EvictionStatus sets the eviction count and the last eviction of the workload.
+func (w *WorkloadWrapper) EvictionStatus(count int32, reason string, t time.Time) *WorkloadWrapper {
+	w.Status.EvictionCount = &count
+	w.Status.LastEvictionReason = reason
+	w.Status.LastEvictionTime = ptr.To(metav1.NewTime(t))
+	return w
+}
+
+func (w *WorkloadWrapper) LastPlacement(p *kueue.WorkloadPlacement) *WorkloadWrapper {
+	w.Status.LastPlacement = p
+	return w
+}
+
 func (w *WorkloadWrapper) AdmissionCheck(ac kueue.AdmissionCheckState) *WorkloadWrapper {
 	w.Status.AdmissionChecks = append(w.Status.AdmissionChecks, ac)
 	return w
@@ -315,6 +334,16 @@ func (w *WorkloadWrapper) Admission(admission *kueue.Admission) *WorkloadWrapper
 	return w
 }
 
+func (w *WorkloadWrapper) AdmissionPath(path kueue.AdmissionPath) *WorkloadWrapper {
+	w.Status.AdmissionPath = path
+	return w
+}
+
+func (w *WorkloadWrapper) PreemptedWorkloads(preempted ...kueue.WorkloadPreemptionReference) *WorkloadWrapper {
+	w.Status.PreemptedWorkloads = preempted
+	return w
+}
+
 func (w *WorkloadWrapper) Conditions(conditions ...metav1.Condition) *WorkloadWrapper {
 	w.Status.Conditions = conditions
 	return w
@@ -341,6 +370,11 @@ func (w *WorkloadWrapper) DeletionTimestamp(t time.Time) *WorkloadWrapper {
 	return w
 }
 
+func (w *WorkloadWrapper) RequeueCount(count int32) *WorkloadWrapper {
+	w.Status.RequeueCount = &count
+	return w
+}
+
 func (w *WorkloadWrapper) RequeueState(count *int32, requeueAt *metav1.Time) *WorkloadWrapper {
 	if count == nil && requeueAt == nil {
 		w.Status.RequeueState = nil
@@ -479,6 +513,11 @@ func (p *PodSetWrapper) SetMinimumCount(mc int32) *PodSetWrapper {
 	return p
 }
 
+func (p *PodSetWrapper) Priority(priority int32) *PodSetWrapper {
+	p.PodSet.Priority = &priority
+	return p
+}
+
 func (p *PodSetWrapper) Toleration(t corev1.Toleration) *PodSetWrapper {
 	p.Template.Spec.Tolerations = append(p.Template.Spec.Tolerations, t)
 	return p
@@ -578,6 +617,16 @@ func (w *AdmissionWrapper) Obj() *kueue.Admission {
 	return &w.Admission
 }
 
+func (w *AdmissionWrapper) FallbackCohort(cohort kueue.CohortReference) *AdmissionWrapper {
+	w.Admission.FallbackCohort = cohort
+	return w
+}
+
+func (w *AdmissionWrapper) StartTime(t time.Time) *AdmissionWrapper {
+	w.Admission.StartTime = ptr.To(metav1.NewTime(t))
+	return w
+}
+
 func (w *AdmissionWrapper) Assignment(r corev1.ResourceName, f kueue.ResourceFlavorReference, value string) *AdmissionWrapper {
 	w.AssignmentWithIndex(0, r, f, value)
 	return w
@@ -665,6 +714,19 @@ func (q *LocalQueueWrapper) FairSharing(fs *kueue.FairSharing) *LocalQueueWrappe
 	return q
 }
 
+// ResourceLimit adds a resource limit, an empty quantity leaves the quota unset.
+func (q *LocalQueueWrapper) ResourceLimit(name corev1.ResourceName, nominalQuota, maxQuota string) *LocalQueueWrapper {
+	limit := kueue.LocalQueueResourceLimit{Name: name}
+	if nominalQuota != "" {
+		limit.NominalQuota = ptr.To(resource.MustParse(nominalQuota))
+	}
+	if maxQuota != "" {
+		limit.MaxQuota = ptr.To(resource.MustParse(maxQuota))
+	}
+	q.Spec.ResourceLimits = append(q.Spec.ResourceLimits, limit)
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -791,6 +853,11 @@ func (c *ClusterQueueWrapper) Cohort(cohort kueue.CohortReference) *ClusterQueue
 	return c
 }
 
+func (c *ClusterQueueWrapper) FallbackCohort(cohort kueue.CohortReference) *ClusterQueueWrapper {
+	c.Spec.FallbackCohort = cohort
+	return c
+}
+
 func (c *ClusterQueueWrapper) AdmissionCheckStrategy(acs ...kueue.AdmissionCheckStrategyRule) *ClusterQueueWrapper {
 	if c.Spec.AdmissionChecksStrategy == nil {
 		c.Spec.AdmissionChecksStrategy = &kueue.AdmissionChecksStrategy{}
@@ -891,6 +958,50 @@ func (c *ClusterQueueWrapper) StopPolicy(p kueue.StopPolicy) *ClusterQueueWrappe
 	return c
 }
 
+// MaxConcurrentChecks sets the maximum number of workloads in the admission checks phase.
+func (c *ClusterQueueWrapper) MaxConcurrentChecks(n int32) *ClusterQueueWrapper {
+	c.Spec.MaxConcurrentChecks = &n
+	return c
+}
+
+// MinAdmissionIntervalSeconds sets the minimum interval between admissions of the cluster queue.
+func (c *ClusterQueueWrapper) MinAdmissionIntervalSeconds(n int32) *ClusterQueueWrapper {
+	c.Spec.MinAdmissionIntervalSeconds = &n
+	return c
+}
+
+// BorrowingMinPriority sets the minimum priority of the workloads which can borrow in the cluster queue.
+func (c *ClusterQueueWrapper) BorrowingMinPriority(p int32) *ClusterQueueWrapper {
+	c.Spec.BorrowingMinPriority = &p
+	return c
+}
+
+// NearCapacityThresholdPercentage sets the near capacity threshold of the cluster queue.
+func (c *ClusterQueueWrapper) NearCapacityThresholdPercentage(p int32) *ClusterQueueWrapper {
+	c.Spec.NearCapacityThresholdPercentage = &p
+	return c
+}
+
+// AdmissionToleration adds a toleration to the admission tolerations of the cluster queue.
+func (c *ClusterQueueWrapper) AdmissionToleration(t corev1.Toleration) *ClusterQueueWrapper {
+	c.Spec.AdmissionTolerations = append(c.Spec.AdmissionTolerations, t)
+	return c
+}
+
+// ResourceOverhead adds a resource overhead to the cluster queue. An empty
+// factor or addend is left unset.
+func (c *ClusterQueueWrapper) ResourceOverhead(name corev1.ResourceName, factor, addend string) *ClusterQueueWrapper {
+	overhead := kueue.ResourceOverhead{Name: name}
+	if factor != "" {
+		overhead.Factor = ptr.To(resource.MustParse(factor))
+	}
+	if addend != "" {
+		overhead.Addend = ptr.To(resource.MustParse(addend))
+	}
+	c.Spec.ResourceOverheads = append(c.Spec.ResourceOverheads, overhead)
+	return c
+}
+
 // DeletionTimestamp sets a deletion timestamp for the cluster queue.
 func (c *ClusterQueueWrapper) DeletionTimestamp(t time.Time) *ClusterQueueWrapper {
 	c.ClusterQueue.DeletionTimestamp = ptr.To(metav1.NewTime(t).Rfc3339Copy())
@@ -948,6 +1059,12 @@ func (c *ClusterQueueWrapper) AdmittedWorkloads(n int32) *ClusterQueueWrapper {
 	return c
 }
 
+// ReservingWorkloads sets the reservingWorkloads in status.
+func (c *ClusterQueueWrapper) ReservingWorkloads(n int32) *ClusterQueueWrapper {
+	c.Status.ReservingWorkloads = n
+	return c
+}
+
 // FlavorQuotasWrapper wraps a FlavorQuotas object.
 type FlavorQuotasWrapper struct{ kueue.FlavorQuotas }
 
@@ -1010,6 +1127,39 @@ func (rq *ResourceQuotaWrapper) LendingLimit(quantity string) *ResourceQuotaWrap
 	return rq
 }
 
+func (rq *ResourceQuotaWrapper) Borrowable(borrowable bool) *ResourceQuotaWrapper {
+	rq.ResourceQuota.Borrowable = ptr.To(borrowable)
+	return rq
+}
+
+func (rq *ResourceQuotaWrapper) OvercommitFactor(factor string) *ResourceQuotaWrapper {
+	rq.ResourceQuota.OvercommitFactor = ptr.To(resource.MustParse(factor))
+	return rq
+}
+
+func (rq *ResourceQuotaWrapper) PriorityReservation(quantity string, minPriority int32) *ResourceQuotaWrapper {
+	rq.ResourceQuota.PriorityReservation = &kueue.PriorityReservation{
+		Quantity:    resource.MustParse(quantity),
+		MinPriority: minPriority,
+	}
+	return rq
+}
+
+func (rq *ResourceQuotaWrapper) ScheduledQuota(quantity string, startTime time.Time) *ResourceQuotaWrapper {
+	rq.ResourceQuota.ScheduledQuota = &kueue.ScheduledQuota{
+		Quantity:  resource.MustParse(quantity),
+		StartTime: metav1.NewTime(startTime),
+	}
+	return rq
+}
+
+func (rq *ResourceQuotaWrapper) ElasticQuota(nodeLabels map[string]string) *ResourceQuotaWrapper {
+	rq.ResourceQuota.ElasticQuota = &kueue.ElasticQuota{
+		NodeLabels: nodeLabels,
+	}
+	return rq
+}
+
 // Append appends the ResourceQuotaWrapper to its parent
 func (rq *ResourceQuotaWrapper) Append() *FlavorQuotasWrapper {
 	rq.parent.Resources = append(rq.parent.Resources, rq.ResourceQuota)
@@ -1036,12 +1186,32 @@ func (rf *ResourceFlavorWrapper) Obj() *kueue.ResourceFlavor {
 	return &rf.ResourceFlavor
 }
 
+// Disabled sets whether the ResourceFlavor is disabled.
+func (rf *ResourceFlavorWrapper) Disabled(disabled bool) *ResourceFlavorWrapper {
+	rf.Spec.Disabled = &disabled
+	return rf
+}
+
 // TopologyName sets the topology name
 func (rf *ResourceFlavorWrapper) TopologyName(name string) *ResourceFlavorWrapper {
 	rf.Spec.TopologyName = ptr.To(kueue.TopologyReference(name))
 	return rf
 }
 
+// DefaultRequiredTopologyLevel sets the topology level required for the
+// podsets which don't request a topology explicitly.
+func (rf *ResourceFlavorWrapper) DefaultRequiredTopologyLevel(level string) *ResourceFlavorWrapper {
+	rf.Spec.DefaultRequiredTopologyLevel = ptr.To(level)
+	return rf
+}
+
+// DeletionPolicy sets the handling of the workloads using the ResourceFlavor
+// when it is being deleted.
+func (rf *ResourceFlavorWrapper) DeletionPolicy(policy kueue.ResourceFlavorDeletionPolicy) *ResourceFlavorWrapper {
+	rf.Spec.DeletionPolicy = ptr.To(policy)
+	return rf
+}
+
 // Label sets the label on the ResourceFlavor.
 func (rf *ResourceFlavorWrapper) Label(k, v string) *ResourceFlavorWrapper {
 	if rf.Labels == nil {
@@ -1063,6 +1233,12 @@ func (rf *ResourceFlavorWrapper) Taint(t corev1.Taint) *ResourceFlavorWrapper {
 	return rf
 }
 
+// TaintToTolerate adds a taint that the pods must tolerate to the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) TaintToTolerate(t corev1.Taint) *ResourceFlavorWrapper {
+	rf.Spec.TaintsToTolerate = append(rf.Spec.TaintsToTolerate, t)
+	return rf
+}
+
 // Toleration  adds a taint to the ResourceFlavor.
 func (rf *ResourceFlavorWrapper) Toleration(t corev1.Toleration) *ResourceFlavorWrapper {
 	rf.Spec.Tolerations = append(rf.Spec.Tolerations, t)
@@ -1254,6 +1430,12 @@ func (ac *AdmissionCheckWrapper) Parameters(apigroup, kind, name string) *Admiss
 	return ac
 }
 
+// ReservationHoldSeconds sets the period during which the check holds the quota reservation.
+func (ac *AdmissionCheckWrapper) ReservationHoldSeconds(s int32) *AdmissionCheckWrapper {
+	ac.Spec.ReservationHoldSeconds = &s
+	return ac
+}
+
 func (ac *AdmissionCheckWrapper) Obj() *kueue.AdmissionCheck {
 	return &ac.AdmissionCheck
 }
@@ -1306,6 +1488,34 @@ func (mkc *MultiKueueConfigWrapper) Clusters(clusters ...string) *MultiKueueConf
 	return mkc
 }
 
+type CELAdmissionCheckConfigWrapper struct {
+	kueue.CELAdmissionCheckConfig
+}
+
+func MakeCELAdmissionCheckConfig(name string) *CELAdmissionCheckConfigWrapper {
+	return &CELAdmissionCheckConfigWrapper{
+		CELAdmissionCheckConfig: kueue.CELAdmissionCheckConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+}
+
+func (c *CELAdmissionCheckConfigWrapper) Obj() *kueue.CELAdmissionCheckConfig {
+	return &c.CELAdmissionCheckConfig
+}
+
+func (c *CELAdmissionCheckConfigWrapper) Expression(expression string) *CELAdmissionCheckConfigWrapper {
+	c.Spec.Expression = expression
+	return c
+}
+
+func (c *CELAdmissionCheckConfigWrapper) Message(message string) *CELAdmissionCheckConfigWrapper {
+	c.Spec.Message = message
+	return c
+}
+
 type MultiKueueClusterWrapper struct {
 	kueue.MultiKueueCluster
 }
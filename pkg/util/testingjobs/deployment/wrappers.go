@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
@@ -168,6 +169,15 @@ func (d *DeploymentWrapper) PodTemplateSpecManagedByKueue() *DeploymentWrapper {
 	return d.PodTemplateSpecLabel(constants.ManagedByKueueLabelKey, constants.ManagedByKueueLabelValue)
 }
 
+// MaxUnavailable sets the maximum number of unavailable Pods during a rolling update
+func (d *DeploymentWrapper) MaxUnavailable(maxUnavailable intstr.IntOrString) *DeploymentWrapper {
+	if d.Spec.Strategy.RollingUpdate == nil {
+		d.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+	}
+	d.Spec.Strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	return d
+}
+
 func (d *DeploymentWrapper) TerminationGracePeriod(seconds int64) *DeploymentWrapper {
 	d.Spec.Template.Spec.TerminationGracePeriodSeconds = &seconds
 	return d
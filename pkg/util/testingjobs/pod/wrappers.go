@@ -144,6 +144,11 @@ func (p *PodWrapper) GroupTotalCount(gtc string) *PodWrapper {
 	return p.Annotation(podconstants.GroupTotalCountAnnotation, gtc)
 }
 
+// GroupSuccessThreshold updates the pod.GroupSuccessThresholdAnnotation of the Pod
+func (p *PodWrapper) GroupSuccessThreshold(threshold string) *PodWrapper {
+	return p.Annotation(podconstants.GroupSuccessThresholdAnnotation, threshold)
+}
+
 // GroupIndex updates the pod.GroupIndexLabel of the Pod
 func (p *PodWrapper) GroupIndex(index string) *PodWrapper {
 	return p.Label(kueuealpha.PodGroupPodIndexLabel, index)
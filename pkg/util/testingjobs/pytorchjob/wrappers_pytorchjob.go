@@ -135,6 +135,15 @@ func (j *PyTorchJobWrapper) Label(key, value string) *PyTorchJobWrapper {
 	return j
 }
 
+// Annotation sets the annotation key and value
+func (j *PyTorchJobWrapper) Annotation(key, value string) *PyTorchJobWrapper {
+	if j.Annotations == nil {
+		j.Annotations = make(map[string]string)
+	}
+	j.Annotations[key] = value
+	return j
+}
+
 // PriorityClass updates job priorityclass.
 func (j *PyTorchJobWrapper) PriorityClass(pc string) *PyTorchJobWrapper {
 	if j.Spec.RunPolicy.SchedulingPolicy == nil {
@@ -190,6 +199,15 @@ func (j *PyTorchJobWrapper) Parallelism(p int32) *PyTorchJobWrapper {
 	return j
 }
 
+// ElasticPolicy sets the minimum and the maximum number of replicas of the elastic policy.
+func (j *PyTorchJobWrapper) ElasticPolicy(minReplicas, maxReplicas int32) *PyTorchJobWrapper {
+	j.Spec.ElasticPolicy = &kftraining.ElasticPolicy{
+		MinReplicas: ptr.To(minReplicas),
+		MaxReplicas: ptr.To(maxReplicas),
+	}
+	return j
+}
+
 // Suspend updates the suspend status of the job.
 func (j *PyTorchJobWrapper) Suspend(s bool) *PyTorchJobWrapper {
 	j.Spec.RunPolicy.Suspend = &s
@@ -172,6 +172,24 @@ func (j *ClusterWrapper) WithNumOfHosts(groupName string, value int32) *ClusterW
 	return j
 }
 
+func (j *ClusterWrapper) WithReplicas(groupName string, value int32) *ClusterWrapper {
+	for index, group := range j.Spec.WorkerGroupSpecs {
+		if group.GroupName == groupName {
+			j.Spec.WorkerGroupSpecs[index].Replicas = ptr.To(value)
+		}
+	}
+	return j
+}
+
+func (j *ClusterWrapper) WithMinReplicas(groupName string, value int32) *ClusterWrapper {
+	for index, group := range j.Spec.WorkerGroupSpecs {
+		if group.GroupName == groupName {
+			j.Spec.WorkerGroupSpecs[index].MinReplicas = ptr.To(value)
+		}
+	}
+	return j
+}
+
 // WorkloadPriorityClass updates job workloadpriorityclass.
 func (j *ClusterWrapper) WorkloadPriorityClass(wpc string) *ClusterWrapper {
 	if j.Labels == nil {
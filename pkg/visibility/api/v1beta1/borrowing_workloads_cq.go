@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+type borrowingWorkloadsInCqREST struct {
+	cache *cache.Cache
+	log   logr.Logger
+}
+
+var _ rest.Storage = &borrowingWorkloadsInCqREST{}
+var _ rest.Getter = &borrowingWorkloadsInCqREST{}
+var _ rest.Scoper = &borrowingWorkloadsInCqREST{}
+
+func NewBorrowingWorkloadsInCqREST(cache *cache.Cache) *borrowingWorkloadsInCqREST {
+	return &borrowingWorkloadsInCqREST{
+		cache: cache,
+		log:   ctrl.Log.WithName("borrowing-workload-in-cq"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *borrowingWorkloadsInCqREST) New() runtime.Object {
+	return &visibility.BorrowingWorkloadsSummary{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *borrowingWorkloadsInCqREST) Destroy() {}
+
+// Get implements rest.Getter interface
+// It fetches information about the workloads borrowing quota in the ClusterQueue
+func (m *borrowingWorkloadsInCqREST) Get(_ context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	borrowingWorkloads, err := m.cache.BorrowingWorkloads(kueue.ClusterQueueReference(name))
+	if err != nil {
+		if errors.Is(err, cache.ErrCqNotFound) {
+			return nil, apierrors.NewNotFound(visibility.Resource("clusterqueue"), name)
+		}
+		return nil, err
+	}
+
+	wls := make([]visibility.BorrowingWorkload, 0, len(borrowingWorkloads))
+	for _, bw := range borrowingWorkloads {
+		wls = append(wls, *newBorrowingWorkload(bw))
+	}
+	return &visibility.BorrowingWorkloadsSummary{Items: wls}, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *borrowingWorkloadsInCqREST) NamespaceScoped() bool {
+	return false
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestBorrowingWorkloadsInCQ(t *testing.T) {
+	const (
+		nsName = "foo"
+		lqName = "lq"
+	)
+	now := time.Now().Truncate(time.Second)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			Cohort("one").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource(corev1.ResourceMemory, "4Gi").
+				Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("one").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10").
+				Resource(corev1.ResourceMemory, "10Gi").
+				Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", nsName).
+			Queue(lqName).
+			Priority(100).
+			Creation(now).
+			Request(corev1.ResourceCPU, "3").
+			Request(corev1.ResourceMemory, "1Gi").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").
+				Assignment(corev1.ResourceCPU, "default", "3").
+				Assignment(corev1.ResourceMemory, "default", "1Gi").
+				Obj(), now).
+			Obj(),
+		utiltesting.MakeWorkload("b", nsName).
+			Queue(lqName).
+			Priority(50).
+			Creation(now).
+			Request(corev1.ResourceCPU, "2").
+			Request(corev1.ResourceMemory, "1Gi").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").
+				Assignment(corev1.ResourceCPU, "default", "2").
+				Assignment(corev1.ResourceMemory, "default", "1Gi").
+				Obj(), now.Add(time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("c", nsName).
+			Queue(lqName).
+			Priority(50).
+			Creation(now).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now).
+			Obj(),
+	}
+
+	cases := map[string]struct {
+		clusterQueue string
+		want         []visibility.BorrowingWorkload
+		wantErrMatch func(error) bool
+	}{
+		"workload borrowing quota": {
+			clusterQueue: "cq-a",
+			want: []visibility.BorrowingWorkload{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "b",
+						Namespace:         nsName,
+						CreationTimestamp: metav1.NewTime(now),
+					},
+					Priority:       50,
+					LocalQueueName: lqName,
+					Resources: []visibility.BorrowedResource{
+						{
+							Flavor:   "default",
+							Resource: corev1.ResourceCPU,
+							Total:    resource.MustParse("2"),
+							Borrowed: resource.MustParse("1"),
+						},
+						{
+							Flavor:   "default",
+							Resource: corev1.ResourceMemory,
+							Total:    resource.MustParse("1Gi"),
+							Borrowed: resource.MustParse("0"),
+						},
+					},
+				},
+			},
+		},
+		"no workload borrowing quota": {
+			clusterQueue: "cq-b",
+		},
+		"ClusterQueue not found": {
+			clusterQueue: "missing",
+			wantErrMatch: errors.IsNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
+				}
+			}
+			for _, w := range workloads {
+				cqCache.AddOrUpdateWorkload(w)
+			}
+			borrowingWorkloadsInCqRest := NewBorrowingWorkloadsInCqREST(cqCache)
+
+			info, err := borrowingWorkloadsInCqRest.Get(t.Context(), tc.clusterQueue, &metav1.GetOptions{})
+			switch {
+			case tc.wantErrMatch != nil:
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case err != nil:
+				t.Error(err)
+			default:
+				borrowingWorkloadsInfo := info.(*visibility.BorrowingWorkloadsSummary)
+				if diff := cmp.Diff(tc.want, borrowingWorkloadsInfo.Items, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Borrowing workloads differ: (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
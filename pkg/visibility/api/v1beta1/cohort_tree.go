@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+type cohortTreeREST struct {
+	cache *cache.Cache
+	log   logr.Logger
+}
+
+var _ rest.Storage = &cohortTreeREST{}
+var _ rest.Getter = &cohortTreeREST{}
+var _ rest.Scoper = &cohortTreeREST{}
+
+func NewCohortTreeREST(cache *cache.Cache) *cohortTreeREST {
+	return &cohortTreeREST{
+		cache: cache,
+		log:   ctrl.Log.WithName("cohort-tree"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *cohortTreeREST) New() runtime.Object {
+	return &visibility.CohortTree{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *cohortTreeREST) Destroy() {}
+
+// Get implements rest.Getter interface
+// It fetches the tree of Cohorts which the Cohort belongs to, starting from its root
+func (m *cohortTreeREST) Get(_ context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	tree, err := m.cache.CohortTree(kueue.CohortReference(name))
+	if err != nil {
+		switch {
+		case errors.Is(err, cache.ErrCohortNotFound):
+			return nil, apierrors.NewNotFound(visibility.Resource("cohort"), name)
+		case errors.Is(err, cache.ErrCohortHasCycle):
+			return nil, apierrors.NewConflict(visibility.Resource("cohort"), name, err)
+		}
+		return nil, err
+	}
+	return &visibility.CohortTree{Root: newCohortTreeNode(tree)}, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *cohortTreeREST) NamespaceScoped() bool {
+	return false
+}
@@ -0,0 +1,155 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestCohortTree(t *testing.T) {
+	cohorts := []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+		utiltesting.MakeCohort("team-b").Parent("root").Obj(),
+		utiltesting.MakeCohort("team-a").Parent("root").Obj(),
+		utiltesting.MakeCohort("cycle-a").Parent("cycle-b").Obj(),
+		utiltesting.MakeCohort("cycle-b").Parent("cycle-a").Obj(),
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a2").
+			Cohort("team-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-a1").
+			Cohort("team-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("team-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "ns").
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a1").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+			Admitted(true).
+			Obj(),
+		utiltesting.MakeWorkload("b", "ns").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Admitted(true).
+			Obj(),
+	}
+	cpuResource := func(nominal, usage string) []visibility.CohortResource {
+		return []visibility.CohortResource{{
+			Flavor:       "default",
+			Resource:     corev1.ResourceCPU,
+			NominalQuota: resource.MustParse(nominal),
+			Usage:        resource.MustParse(usage),
+		}}
+	}
+	wantTree := visibility.CohortTreeNode{
+		Name:      "root",
+		Resources: cpuResource("13", "4"),
+		Children: []visibility.CohortTreeNode{
+			{
+				Name:          "team-a",
+				Parent:        "root",
+				ClusterQueues: []kueue.ClusterQueueReference{"cq-a1", "cq-a2"},
+				Resources:     cpuResource("10", "3"),
+			},
+			{
+				Name:          "team-b",
+				Parent:        "root",
+				ClusterQueues: []kueue.ClusterQueueReference{"cq-b"},
+				Resources:     cpuResource("1", "1"),
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		cohort       string
+		want         visibility.CohortTreeNode
+		wantErrMatch func(error) bool
+	}{
+		"tree of the root": {
+			cohort: "root",
+			want:   wantTree,
+		},
+		"tree of a child": {
+			cohort: "team-b",
+			want:   wantTree,
+		},
+		"Cohort not found": {
+			cohort:       "missing",
+			wantErrMatch: errors.IsNotFound,
+		},
+		"Cohort in a cycle": {
+			cohort:       "cycle-a",
+			wantErrMatch: errors.IsConflict,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cohort := range cohorts {
+				// Adding the Cohorts forming a cycle returns an error.
+				_ = cqCache.AddOrUpdateCohort(cohort)
+			}
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
+				}
+			}
+			for _, w := range workloads {
+				cqCache.AddOrUpdateWorkload(w)
+			}
+			cohortTreeRest := NewCohortTreeREST(cqCache)
+
+			info, err := cohortTreeRest.Get(t.Context(), tc.cohort, &metav1.GetOptions{})
+			switch {
+			case tc.wantErrMatch != nil:
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case err != nil:
+				t.Error(err)
+			default:
+				tree := info.(*visibility.CohortTree)
+				if diff := cmp.Diff(tc.want, tree.Root, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Cohort tree differs: (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
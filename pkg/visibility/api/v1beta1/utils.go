@@ -17,9 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"cmp"
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -46,3 +51,58 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 		PositionInLocalQueue:   positionInLq,
 	}
 }
+
+func newCohortTreeNode(node *cache.CohortTreeNode) visibility.CohortTreeNode {
+	cohortResources := make([]visibility.CohortResource, 0, len(node.NominalQuota))
+	for fr, nominal := range node.NominalQuota {
+		cohortResources = append(cohortResources, visibility.CohortResource{
+			Flavor:       fr.Flavor,
+			Resource:     fr.Resource,
+			NominalQuota: resources.ResourceQuantity(fr.Resource, nominal),
+			Usage:        resources.ResourceQuantity(fr.Resource, node.AdmittedUsage[fr]),
+		})
+	}
+	slices.SortFunc(cohortResources, func(a, b visibility.CohortResource) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	var children []visibility.CohortTreeNode
+	for i := range node.Children {
+		children = append(children, newCohortTreeNode(&node.Children[i]))
+	}
+	return visibility.CohortTreeNode{
+		Name:          node.Name,
+		Parent:        node.Parent,
+		ClusterQueues: node.ClusterQueues,
+		Children:      children,
+		Resources:     cohortResources,
+	}
+}
+
+func newBorrowingWorkload(bw cache.BorrowingWorkload) *visibility.BorrowingWorkload {
+	borrowedResources := make([]visibility.BorrowedResource, 0, len(bw.Usage))
+	for fr, total := range bw.Usage {
+		borrowedResources = append(borrowedResources, visibility.BorrowedResource{
+			Flavor:   fr.Flavor,
+			Resource: fr.Resource,
+			Total:    resources.ResourceQuantity(fr.Resource, total),
+			Borrowed: resources.ResourceQuantity(fr.Resource, bw.Borrowed[fr]),
+		})
+	}
+	slices.SortFunc(borrowedResources, func(a, b visibility.BorrowedResource) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	var priority int32
+	if bw.Info.Obj.Spec.Priority != nil {
+		priority = *bw.Info.Obj.Spec.Priority
+	}
+	return &visibility.BorrowingWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              bw.Info.Obj.Name,
+			Namespace:         bw.Info.Obj.Namespace,
+			CreationTimestamp: bw.Info.Obj.CreationTimestamp,
+		},
+		Priority:       priority,
+		LocalQueueName: bw.Info.Obj.Spec.QueueName,
+		Resources:      borrowedResources,
+	}
+}
@@ -36,6 +36,7 @@ import (
 
 	generatedopenapi "sigs.k8s.io/kueue/apis/visibility/openapi"
 	visibilityv1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/visibility/api"
 
@@ -59,8 +60,8 @@ var (
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas,verbs=list;watch
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas/status,verbs=patch
 
-// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and starts it
-func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager) {
+// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and Cache and starts it
+func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager, cache *cache.Cache) {
 	config := newVisibilityServerConfig()
 	if err := applyVisibilityServerOptions(config); err != nil {
 		setupLog.Error(err, "Unable to apply VisibilityServerOptions")
@@ -73,7 +74,7 @@ func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager
 		os.Exit(1)
 	}
 
-	if err := api.Install(visibilityServer, kueueMgr); err != nil {
+	if err := api.Install(visibilityServer, kueueMgr, cache); err != nil {
 		setupLog.Error(err, "Unable to install visibility.kueue.x-k8s.io API")
 		os.Exit(1)
 	}
@@ -27,11 +27,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 )
@@ -39,15 +41,24 @@ import (
 const (
 	limitIsEmptyErrorMsgTemplate string = `must be nil when %s is empty`
 	lendingLimitErrorMsg         string = `must be less than or equal to the nominalQuota`
+	overcommitFactorErrorMsg     string = `must be greater than or equal to 1`
+	maxQuotaErrorMsg             string = `must be greater than or equal to the nominalQuota`
+	quotaPartErrorMsg            string = `must be less than or equal to the nominalQuota`
+	elasticQuotaErrorMsg         string = `must be null when elasticQuota is set`
 )
 
-type ClusterQueueWebhook struct{}
+type ClusterQueueWebhook struct {
+	cohortChangePolicy configapi.CohortChangePolicy
+}
 
-func setupWebhookForClusterQueue(mgr ctrl.Manager) error {
+func setupWebhookForClusterQueue(mgr ctrl.Manager, opts options) error {
+	wh := &ClusterQueueWebhook{
+		cohortChangePolicy: opts.cohortChangePolicy,
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kueue.ClusterQueue{}).
-		WithDefaulter(&ClusterQueueWebhook{}).
-		WithValidator(&ClusterQueueWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -81,11 +92,15 @@ func (w *ClusterQueueWebhook) ValidateCreate(ctx context.Context, obj runtime.Ob
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
 func (w *ClusterQueueWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCQ := oldObj.(*kueue.ClusterQueue)
 	newCQ := newObj.(*kueue.ClusterQueue)
 
 	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
 	log.V(5).Info("Validating update")
 	allErrs := ValidateClusterQueueUpdate(newCQ)
+	if w.cohortChangePolicy == configapi.CohortChangeReject {
+		allErrs = append(allErrs, validateCohortChange(oldCQ, newCQ)...)
+	}
 	return nil, allErrs.ToAggregate()
 }
 
@@ -110,6 +125,7 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 		allErrs = append(allErrs, validatePreemption(cq.Spec.Preemption, path.Child("preemption"))...)
 	}
 	allErrs = append(allErrs, validateFairSharing(cq.Spec.FairSharing, path.Child("fairSharing"))...)
+	allErrs = append(allErrs, validateResourceOverheads(cq.Spec.ResourceOverheads, path.Child("resourceOverheads"))...)
 	return allErrs
 }
 
@@ -117,6 +133,17 @@ func ValidateClusterQueueUpdate(newObj *kueue.ClusterQueue) field.ErrorList {
 	return ValidateClusterQueue(newObj)
 }
 
+// validateCohortChange rejects the cohort change of a ClusterQueue which has
+// workloads with quota reserved, as their usage is accounted in the cohort.
+func validateCohortChange(oldObj, newObj *kueue.ClusterQueue) field.ErrorList {
+	var allErrs field.ErrorList
+	if oldObj.Spec.Cohort != newObj.Spec.Cohort && oldObj.Status.ReservingWorkloads > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cohort"),
+			fmt.Sprintf("cannot be changed while the ClusterQueue has %d workloads with quota reserved", oldObj.Status.ReservingWorkloads)))
+	}
+	return allErrs
+}
+
 func validatePreemption(preemption *kueue.ClusterQueuePreemption, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	if preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever &&
@@ -181,6 +208,9 @@ func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []co
 			borrowingLimitPath := path.Child("borrowingLimit")
 			allErrs = append(allErrs, validateLimit(*rq.BorrowingLimit, config, borrowingLimitPath, isCohort)...)
 			allErrs = append(allErrs, validateResourceQuantity(*rq.BorrowingLimit, borrowingLimitPath)...)
+			if !ptr.Deref(rq.Borrowable, true) {
+				allErrs = append(allErrs, field.Invalid(borrowingLimitPath, rq.BorrowingLimit.String(), "must be null when borrowable is false"))
+			}
 		}
 		if features.Enabled(features.LendingLimit) && rq.LendingLimit != nil {
 			lendingLimitPath := path.Child("lendingLimit")
@@ -188,7 +218,41 @@ func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []co
 			allErrs = append(allErrs, validateLimit(*rq.LendingLimit, config, lendingLimitPath, isCohort)...)
 			allErrs = append(allErrs, validateLendingLimit(*rq.LendingLimit, rq.NominalQuota, config, lendingLimitPath)...)
 		}
+		if rq.OvercommitFactor != nil {
+			allErrs = append(allErrs, validateOvercommitFactor(*rq.OvercommitFactor, path.Child("overcommitFactor"))...)
+		}
+		if rq.PriorityReservation != nil {
+			allErrs = append(allErrs, validateQuotaPart(rq.PriorityReservation.Quantity, rq.NominalQuota, path.Child("priorityReservation", "quantity"))...)
+		}
+		if rq.ScheduledQuota != nil {
+			allErrs = append(allErrs, validateQuotaPart(rq.ScheduledQuota.Quantity, rq.NominalQuota, path.Child("scheduledQuota", "quantity"))...)
+		}
+		if rq.ElasticQuota != nil {
+			allErrs = append(allErrs, validateElasticQuota(&rq, path, isCohort)...)
+		}
+	}
+	return allErrs
+}
+
+// validateElasticQuota enforces that the nominalQuota following a pool of nodes
+// isn't constrained by the other fields of the quota, which could be violated
+// when the pool shrinks, and that the node labels are valid.
+func validateElasticQuota(rq *kueue.ResourceQuota, path *field.Path, isCohort bool) field.ErrorList {
+	var allErrs field.ErrorList
+	elasticQuotaPath := path.Child("elasticQuota")
+	if isCohort {
+		allErrs = append(allErrs, field.Forbidden(elasticQuotaPath, "must be null in a Cohort"))
+	}
+	if rq.LendingLimit != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("lendingLimit"), elasticQuotaErrorMsg))
 	}
+	if rq.PriorityReservation != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("priorityReservation"), elasticQuotaErrorMsg))
+	}
+	if rq.ScheduledQuota != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("scheduledQuota"), elasticQuotaErrorMsg))
+	}
+	allErrs = append(allErrs, validation.ValidateLabels(rq.ElasticQuota.NodeLabels, elasticQuotaPath.Child("nodeLabels"))...)
 	return allErrs
 }
 
@@ -216,6 +280,43 @@ func validateLimit(limit resource.Quantity, config validationConfig, fldPath *fi
 	return allErrs
 }
 
+// validateOvercommitFactor enforces that OvercommitFactor is not less than 1
+func validateOvercommitFactor(factor resource.Quantity, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if factor.Cmp(resource.MustParse("1")) < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, factor.String(), overcommitFactorErrorMsg))
+	}
+	return allErrs
+}
+
+// validateQuotaPart enforces that a part of the nominalQuota, like the quantity
+// reserved for high priority workloads, is non-negative and not greater than
+// the nominalQuota
+func validateQuotaPart(quantity, nominalQuota resource.Quantity, fldPath *field.Path) field.ErrorList {
+	allErrs := validateResourceQuantity(quantity, fldPath)
+	if quantity.Cmp(nominalQuota) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, quantity.String(), quotaPartErrorMsg))
+	}
+	return allErrs
+}
+
+// validateResourceOverheads enforces that the factor of each overhead is not
+// less than 1 and that its addend is non-negative.
+func validateResourceOverheads(overheads []kueue.ResourceOverhead, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, overhead := range overheads {
+		path := path.Index(i)
+		allErrs = append(allErrs, validateResourceName(overhead.Name, path.Child("name"))...)
+		if overhead.Factor != nil {
+			allErrs = append(allErrs, validateOvercommitFactor(*overhead.Factor, path.Child("factor"))...)
+		}
+		if overhead.Addend != nil {
+			allErrs = append(allErrs, validateResourceQuantity(*overhead.Addend, path.Child("addend"))...)
+		}
+	}
+	return allErrs
+}
+
 // validateLendingLimit enforces that LendingLimit is not greater than NominalQuota
 func validateLendingLimit(lend, nominal resource.Quantity, config validationConfig, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
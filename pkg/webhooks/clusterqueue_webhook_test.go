@@ -18,14 +18,17 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
@@ -170,6 +173,134 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("lendingLimit"), "2", lendingLimitErrorMsg),
 			},
 		},
+		{
+			name: "flavor quota with borrowable false",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Cohort("cohort").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("example.com/license").NominalQuota("1").Borrowable(false).Append().
+						Obj()).
+				Obj(),
+		},
+		{
+			name: "flavor quota with borrowable false and borrowingLimit",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Cohort("cohort").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("example.com/license").NominalQuota("1").BorrowingLimit("1").Borrowable(false).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("borrowingLimit"), "1", "must be null when borrowable is false"),
+			},
+		},
+		{
+			name: "flavor quota with priorityReservation",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("10").PriorityReservation("2", 100).Append().
+						Obj()).
+				Obj(),
+		},
+		{
+			name: "flavor quota with priorityReservation greater than nominalQuota",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("1").PriorityReservation("2", 100).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("priorityReservation", "quantity"), "2", quotaPartErrorMsg),
+			},
+		},
+		{
+			name: "flavor quota with scheduledQuota greater than nominalQuota",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("1").ScheduledQuota("2", time.Now()).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("scheduledQuota", "quantity"), "2", quotaPartErrorMsg),
+			},
+		},
+		{
+			name: "flavor quota with elasticQuota",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").ElasticQuota(map[string]string{"node-pool": "x86"}).Append().
+						Obj()).
+				Obj(),
+		},
+		{
+			name: "flavor quota with elasticQuota and scheduledQuota",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("1").ScheduledQuota("1", time.Now()).
+						ElasticQuota(map[string]string{"node-pool": "x86"}).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("scheduledQuota"), elasticQuotaErrorMsg),
+			},
+		},
+		{
+			name: "flavor quota with elasticQuota with invalid node labels",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").ElasticQuota(map[string]string{"node pool": "x86"}).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("elasticQuota", "nodeLabels"), "node pool", ""),
+			},
+		},
+		{
+			name: "flavor quota with overcommitFactor",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("1").OvercommitFactor("1.5").Append().
+						Obj()).
+				Obj(),
+		},
+		{
+			name: "flavor quota with overcommitFactor less than 1",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").NominalQuota("1").OvercommitFactor("0.5").Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("overcommitFactor"), "500m", overcommitFactorErrorMsg),
+			},
+		},
+		{
+			name: "resource overheads",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceOverhead(corev1.ResourceMemory, "1.1", "256Mi").
+				ResourceOverhead(corev1.ResourceCPU, "", "100m").
+				Obj(),
+		},
+		{
+			name: "resource overheads with factor less than 1 and negative addend",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceOverhead(corev1.ResourceMemory, "0.9", "-1Mi").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("resourceOverheads").Index(0).Child("factor"), "900m", overcommitFactorErrorMsg),
+				field.Invalid(specPath.Child("resourceOverheads").Index(0).Child("addend"), "-1Mi", apimachineryvalidation.IsNegativeErrorMsg),
+			},
+		},
 		{
 			name:                "flavor quota with lendingLimit and empty cohort, but feature disabled",
 			disableLendingLimit: true,
@@ -368,3 +499,55 @@ func TestValidateClusterQueueUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateClusterQueueCohortChange(t *testing.T) {
+	testcases := map[string]struct {
+		cohortChangePolicy configapi.CohortChangePolicy
+		oldClusterQueue    *kueue.ClusterQueue
+		newClusterQueue    *kueue.ClusterQueue
+		wantErr            field.ErrorList
+	}{
+		"cohort change is allowed with workloads with quota reserved by default": {
+			cohortChangePolicy: configapi.CohortChangeAllow,
+			oldClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").ReservingWorkloads(1).Obj(),
+			newClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-b").ReservingWorkloads(1).Obj(),
+		},
+		"cohort change is rejected with workloads with quota reserved": {
+			cohortChangePolicy: configapi.CohortChangeReject,
+			oldClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").ReservingWorkloads(1).Obj(),
+			newClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-b").ReservingWorkloads(1).Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "cohort"), "cannot be changed while the ClusterQueue has 1 workloads with quota reserved"),
+			},
+		},
+		"cohort removal is rejected with workloads with quota reserved": {
+			cohortChangePolicy: configapi.CohortChangeReject,
+			oldClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").ReservingWorkloads(2).Obj(),
+			newClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").ReservingWorkloads(2).Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "cohort"), "cannot be changed while the ClusterQueue has 2 workloads with quota reserved"),
+			},
+		},
+		"cohort change is allowed without workloads with quota reserved": {
+			cohortChangePolicy: configapi.CohortChangeReject,
+			oldClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").Obj(),
+			newClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-b").Obj(),
+		},
+		"other changes are allowed with workloads with quota reserved": {
+			cohortChangePolicy: configapi.CohortChangeReject,
+			oldClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").QueueingStrategy(kueue.StrictFIFO).ReservingWorkloads(1).Obj(),
+			newClusterQueue:    testingutil.MakeClusterQueue("cluster-queue").Cohort("cohort-a").QueueingStrategy(kueue.BestEffortFIFO).ReservingWorkloads(1).Obj(),
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			w := &ClusterQueueWebhook{cohortChangePolicy: tc.cohortChangePolicy}
+			ctx, _ := testingutil.ContextWithLog(t)
+			_, gotErr := w.ValidateUpdate(ctx, tc.oldClusterQueue, tc.newClusterQueue)
+			if diff := cmp.Diff(tc.wantErr.ToAggregate(), gotErr, cmpopts.EquateComparable(field.Error{})); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
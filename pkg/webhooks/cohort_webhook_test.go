@@ -48,6 +48,18 @@ func TestValidateCohort(t *testing.T) {
 				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("lendingLimit"), "1", "must be nil when parent is empty"),
 			},
 		},
+		{
+			name: "flavor quota with elasticQuota",
+			cohort: testingutil.MakeCohort("cohort").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").
+						ResourceQuotaWrapper("cpu").ElasticQuota(map[string]string{"node-pool": "x86"}).Append().
+						Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("elasticQuota"), "must be null in a Cohort"),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,114 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type LocalQueueWebhook struct {
+	client                    client.Client
+	missingClusterQueuePolicy configapi.MissingClusterQueuePolicy
+}
+
+func setupWebhookForLocalQueue(mgr ctrl.Manager, opts options) error {
+	wh := &LocalQueueWebhook{
+		client:                    mgr.GetClient(),
+		missingClusterQueuePolicy: opts.missingClusterQueuePolicy,
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kueue.LocalQueue{}).
+		WithValidator(wh).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta1-localqueue,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=localqueues,verbs=create;update,versions=v1beta1,name=vlocalqueue.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &LocalQueueWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	lq := obj.(*kueue.LocalQueue)
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating create")
+	if errs := validateLocalQueueResourceLimits(lq.Spec.ResourceLimits, field.NewPath("spec", "resourceLimits")); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return w.validateClusterQueueExists(ctx, lq)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	newLQ := newObj.(*kueue.LocalQueue)
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating update")
+	return nil, validateLocalQueueResourceLimits(newLQ.Spec.ResourceLimits, field.NewPath("spec", "resourceLimits")).ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *LocalQueueWebhook) validateClusterQueueExists(ctx context.Context, lq *kueue.LocalQueue) (admission.Warnings, error) {
+	if lq.Spec.ClusterQueue == "" {
+		return nil, nil
+	}
+	var cq kueue.ClusterQueue
+	err := w.client.Get(ctx, types.NamespacedName{Name: string(lq.Spec.ClusterQueue)}, &cq)
+	if err == nil {
+		return nil, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	clusterQueuePath := field.NewPath("spec", "clusterQueue")
+	if w.missingClusterQueuePolicy == configapi.MissingClusterQueueReject {
+		return nil, field.ErrorList{field.NotFound(clusterQueuePath, lq.Spec.ClusterQueue)}.ToAggregate()
+	}
+	return admission.Warnings{fmt.Sprintf("%s: ClusterQueue %q doesn't exist, workloads submitted to the LocalQueue won't be admitted until it is created", clusterQueuePath, lq.Spec.ClusterQueue)}, nil
+}
+
+func validateLocalQueueResourceLimits(limits []kueue.LocalQueueResourceLimit, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, limit := range limits {
+		path := fldPath.Index(i)
+		if limit.NominalQuota != nil {
+			allErrs = append(allErrs, validateResourceQuantity(*limit.NominalQuota, path.Child("nominalQuota"))...)
+		}
+		if limit.MaxQuota != nil {
+			allErrs = append(allErrs, validateResourceQuantity(*limit.MaxQuota, path.Child("maxQuota"))...)
+			if limit.NominalQuota != nil && limit.MaxQuota.Cmp(*limit.NominalQuota) < 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("maxQuota"), limit.MaxQuota.String(), maxQuotaErrorMsg))
+			}
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateLocalQueueCreate(t *testing.T) {
+	clusterQueuePath := field.NewPath("spec", "clusterQueue")
+	resourceLimitsPath := field.NewPath("spec", "resourceLimits")
+
+	testcases := map[string]struct {
+		lq           *kueue.LocalQueue
+		policy       configapi.MissingClusterQueuePolicy
+		wantWarnings admission.Warnings
+		wantErr      field.ErrorList
+	}{
+		"existing ClusterQueue": {
+			lq:     utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj(),
+			policy: configapi.MissingClusterQueueReject,
+		},
+		"missing ClusterQueue; warn": {
+			lq:     utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("missing").Obj(),
+			policy: configapi.MissingClusterQueueWarn,
+			wantWarnings: admission.Warnings{
+				`spec.clusterQueue: ClusterQueue "missing" doesn't exist, workloads submitted to the LocalQueue won't be admitted until it is created`,
+			},
+		},
+		"missing ClusterQueue; reject": {
+			lq:     utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("missing").Obj(),
+			policy: configapi.MissingClusterQueueReject,
+			wantErr: field.ErrorList{
+				field.NotFound(clusterQueuePath, kueue.ClusterQueueReference("missing")),
+			},
+		},
+		"valid resource limits": {
+			lq: utiltesting.MakeLocalQueue("lq", "default").
+				ClusterQueue("cq").
+				ResourceLimit(corev1.ResourceCPU, "2", "4").
+				ResourceLimit(corev1.ResourceMemory, "", "1Gi").
+				Obj(),
+			policy: configapi.MissingClusterQueueReject,
+		},
+		"negative nominalQuota": {
+			lq: utiltesting.MakeLocalQueue("lq", "default").
+				ClusterQueue("cq").
+				ResourceLimit(corev1.ResourceCPU, "-1", "").
+				Obj(),
+			policy: configapi.MissingClusterQueueReject,
+			wantErr: field.ErrorList{
+				field.Invalid(resourceLimitsPath.Index(0).Child("nominalQuota"), "-1", apimachineryvalidation.IsNegativeErrorMsg),
+			},
+		},
+		"maxQuota lower than nominalQuota": {
+			lq: utiltesting.MakeLocalQueue("lq", "default").
+				ClusterQueue("cq").
+				ResourceLimit(corev1.ResourceCPU, "4", "2").
+				Obj(),
+			policy: configapi.MissingClusterQueueReject,
+			wantErr: field.ErrorList{
+				field.Invalid(resourceLimitsPath.Index(0).Child("maxQuota"), "2", maxQuotaErrorMsg),
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(utiltesting.MakeClusterQueue("cq").Obj()).
+				Build()
+			wh := &LocalQueueWebhook{
+				client:                    cl,
+				missingClusterQueuePolicy: tc.policy,
+			}
+			gotWarnings, gotErr := wh.ValidateCreate(ctx, tc.lq)
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantErr.ToAggregate(), gotErr, cmpopts.EquateComparable(field.Error{})); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLocalQueueUpdate(t *testing.T) {
+	resourceLimitsPath := field.NewPath("spec", "resourceLimits")
+
+	testcases := map[string]struct {
+		lq      *kueue.LocalQueue
+		wantErr field.ErrorList
+	}{
+		"valid resource limits": {
+			lq: utiltesting.MakeLocalQueue("lq", "default").
+				ClusterQueue("cq").
+				ResourceLimit(corev1.ResourceCPU, "2", "2").
+				Obj(),
+		},
+		"maxQuota lower than nominalQuota": {
+			lq: utiltesting.MakeLocalQueue("lq", "default").
+				ClusterQueue("cq").
+				ResourceLimit(corev1.ResourceCPU, "4", "2").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceLimitsPath.Index(0).Child("maxQuota"), "2", maxQuotaErrorMsg),
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wh := &LocalQueueWebhook{}
+			oldLQ := utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+			_, gotErr := wh.ValidateUpdate(ctx, oldLQ, tc.lq)
+			if diff := cmp.Diff(tc.wantErr.ToAggregate(), gotErr, cmpopts.EquateComparable(field.Error{})); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
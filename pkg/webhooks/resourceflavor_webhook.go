@@ -93,6 +93,14 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	allErrs = append(allErrs, validateNodeTaints(rf.Spec.TaintsToTolerate, specPath.Child("taintsToTolerate"))...)
+	if rf.Spec.DefaultRequiredTopologyLevel != nil {
+		levelPath := specPath.Child("defaultRequiredTopologyLevel")
+		if rf.Spec.TopologyName == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("topologyName"), "must be set when defaultRequiredTopologyLevel is set"))
+		}
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(*rf.Spec.DefaultRequiredTopologyLevel, levelPath)...)
+	}
 	return allErrs
 }
 
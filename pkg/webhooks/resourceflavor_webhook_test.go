@@ -49,6 +49,17 @@ func TestValidateResourceFlavor(t *testing.T) {
 					Effect: corev1.TaintEffectNoSchedule,
 				}).Obj(),
 		},
+		{
+			name: "invalid taint to tolerate key",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				TaintToTolerate(corev1.Taint{
+					Key:    "@abc",
+					Effect: corev1.TaintEffectNoSchedule,
+				}).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "taintsToTolerate").Index(0).Child("key"), "@abc", ""),
+			},
+		},
 		{
 			name: "invalid label name",
 			rf:   utiltesting.MakeResourceFlavor("resource-flavor").NodeLabel("@abc", "foo").Obj(),
@@ -63,6 +74,34 @@ func TestValidateResourceFlavor(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "nodeLabels"), "@abc", ""),
 			},
 		},
+		{
+			name: "valid default required topology level",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				NodeLabel("foo", "bar").
+				TopologyName("default").
+				DefaultRequiredTopologyLevel("cloud.com/rack").
+				Obj(),
+		},
+		{
+			name: "default required topology level without topology",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				DefaultRequiredTopologyLevel("cloud.com/rack").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("spec", "topologyName"), ""),
+			},
+		},
+		{
+			name: "invalid default required topology level",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				NodeLabel("foo", "bar").
+				TopologyName("default").
+				DefaultRequiredTopologyLevel("@rack").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "defaultRequiredTopologyLevel"), "@rack", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -18,11 +18,51 @@ package webhooks
 
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 )
 
+type options struct {
+	missingClusterQueuePolicy configapi.MissingClusterQueuePolicy
+	cohortChangePolicy        configapi.CohortChangePolicy
+}
+
+// Option configures the webhooks.
+type Option func(*options)
+
+var defaultOptions = options{
+	missingClusterQueuePolicy: configapi.MissingClusterQueueWarn,
+	cohortChangePolicy:        configapi.CohortChangeAllow,
+}
+
+// WithMissingClusterQueuePolicy sets how LocalQueues referencing a ClusterQueue
+// which doesn't exist are handled on creation.
+func WithMissingClusterQueuePolicy(policy *configapi.MissingClusterQueuePolicy) Option {
+	return func(o *options) {
+		if policy != nil {
+			o.missingClusterQueuePolicy = *policy
+		}
+	}
+}
+
+// WithCohortChangePolicy sets how updates of the cohort of a ClusterQueue
+// are handled.
+func WithCohortChangePolicy(policy *configapi.CohortChangePolicy) Option {
+	return func(o *options) {
+		if policy != nil {
+			o.cohortChangePolicy = *policy
+		}
+	}
+}
+
 // Setup sets up the webhooks for core controllers. It returns the name of the
 // webhook that failed to create and an error, if any.
-func Setup(mgr ctrl.Manager) (string, error) {
+func Setup(mgr ctrl.Manager, opts ...Option) (string, error) {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	if err := setupWebhookForWorkload(mgr); err != nil {
 		return "Workload", err
 	}
@@ -31,7 +71,7 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "ResourceFlavor", err
 	}
 
-	if err := setupWebhookForClusterQueue(mgr); err != nil {
+	if err := setupWebhookForClusterQueue(mgr, options); err != nil {
 		return "ClusterQueue", err
 	}
 
@@ -39,5 +79,9 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "Cohort", err
 	}
 
+	if err := setupWebhookForLocalQueue(mgr, options); err != nil {
+		return "LocalQueue", err
+	}
+
 	return "", nil
 }
@@ -17,25 +17,33 @@ limitations under the License.
 package workload
 
 import (
+	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	resourcehelpers "k8s.io/component-helpers/resource"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 // SyncAdmittedCondition sync the state of the Admitted condition
-// with the state of QuotaReserved and AdmissionChecks.
+// with the state of QuotaReserved, AdmissionChecks and the admission start time.
 // Return true if any change was done.
 func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 	hasReservation := HasQuotaReservation(w)
 	hasAllChecksReady := HasAllChecksReady(w)
+	startTime := AdmissionStartTime(w)
+	hasStarted := startTime == nil || !now.Before(startTime.Time)
 	isAdmitted := IsAdmitted(w)
 
-	if isAdmitted == (hasReservation && hasAllChecksReady) {
+	if isAdmitted == (hasReservation && hasAllChecksReady && hasStarted) {
 		return false
 	}
 	newCondition := metav1.Condition{
@@ -58,6 +66,10 @@ func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 		newCondition.Status = metav1.ConditionFalse
 		newCondition.Reason = "UnsatisfiedChecks"
 		newCondition.Message = "The workload has not all checks ready"
+	case !hasStarted:
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = "WaitingForStartTime"
+		newCondition.Message = fmt.Sprintf("The workload waits for the quota scheduled to become available at %s", startTime.UTC().Format(time.RFC3339))
 	}
 
 	// Accumulate the admitted time if needed
@@ -100,6 +112,9 @@ func ResetChecksOnEviction(w *kueue.Workload, now time.Time) bool {
 			State:              kueue.CheckStatePending,
 			LastTransitionTime: metav1.NewTime(now),
 			Message:            "Reset to Pending after eviction. Previously: " + string(checks[i].State),
+			// The resources requested by the check are kept, to be accounted
+			// for when the quota is reserved again.
+			PodSetResources: checks[i].PodSetResources,
 		}
 		updated = true
 	}
@@ -130,6 +145,66 @@ func SetAdmissionCheckState(checks *[]kueue.AdmissionCheckState, newCheck kueue.
 	}
 	existingCondition.Message = newCheck.Message
 	existingCondition.PodSetUpdates = newCheck.PodSetUpdates
+	existingCondition.PodSetResources = newCheck.PodSetResources
+}
+
+// AdmissionCheckResources returns the resources requested by the admission
+// checks of the workload for each pod of its PodSets, summed across the checks.
+// Returns nil if the AdmissionCheckPodSetResources feature is disabled.
+func AdmissionCheckResources(w *kueue.Workload) map[kueue.PodSetReference]resources.Requests {
+	if !features.Enabled(features.AdmissionCheckPodSetResources) {
+		return nil
+	}
+	var res map[kueue.PodSetReference]resources.Requests
+	for i := range w.Status.AdmissionChecks {
+		for _, psr := range w.Status.AdmissionChecks[i].PodSetResources {
+			if res == nil {
+				res = make(map[kueue.PodSetReference]resources.Requests)
+			}
+			if _, found := res[psr.Name]; !found {
+				res[psr.Name] = resources.Requests{}
+			}
+			res[psr.Name].Add(resources.NewRequests(psr.Resources))
+		}
+	}
+	return res
+}
+
+// AdmissionCheckResourcesReserved returns whether the quota reserved for the
+// workload accounts for the resources requested by its admission checks.
+// The resources requested by a check after the quota was reserved are not
+// accounted for, until the quota is reserved again.
+func AdmissionCheckResourcesReserved(w *kueue.Workload) bool {
+	if w.Status.Admission == nil {
+		return true
+	}
+	checkResources := AdmissionCheckResources(w)
+	if len(checkResources) == 0 {
+		return true
+	}
+	podSets := make(map[kueue.PodSetReference]*kueue.PodSet, len(w.Spec.PodSets))
+	for i := range w.Spec.PodSets {
+		podSets[w.Spec.PodSets[i].Name] = &w.Spec.PodSets[i]
+	}
+	for _, psa := range w.Status.Admission.PodSetAssignments {
+		extra, found := checkResources[psa.Name]
+		ps := podSets[psa.Name]
+		if !found || ps == nil {
+			continue
+		}
+		count := ptr.Deref(psa.Count, ps.Count)
+		if count == 0 {
+			continue
+		}
+		reserved := resources.NewRequests(psa.ResourceUsage).ScaledDown(int64(count))
+		required := resources.NewRequests(resourcehelpers.PodRequests(&corev1.Pod{Spec: ps.Template.Spec}, resourcehelpers.PodResourcesOptions{}))
+		for name, v := range extra {
+			if reserved[name] < required[name]+v {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // RejectedChecks returns the list of Rejected admission checks
@@ -192,3 +267,33 @@ func HasRejectedChecks(wl *kueue.Workload) bool {
 	}
 	return false
 }
+
+// ReservationHoldRemaining returns the time left until the quota reservation of
+// the workload stops being held by its Pending admission checks, given the hold
+// periods of the checks. The returned duration is not positive when the hold
+// expired. The second return value is false when none of the Pending checks of
+// the workload holds the reservation.
+func ReservationHoldRemaining(wl *kueue.Workload, holds map[kueue.AdmissionCheckReference]time.Duration, now time.Time) (time.Duration, bool) {
+	if len(holds) == 0 {
+		return 0, false
+	}
+	reserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if reserved == nil || reserved.Status != metav1.ConditionTrue || IsAdmitted(wl) {
+		return 0, false
+	}
+	var remaining time.Duration
+	held := false
+	for i := range wl.Status.AdmissionChecks {
+		check := &wl.Status.AdmissionChecks[i]
+		hold, found := holds[check.Name]
+		if !found || check.State != kueue.CheckStatePending {
+			continue
+		}
+		checkRemaining := reserved.LastTransitionTime.Add(hold).Sub(now)
+		if !held || checkRemaining > remaining {
+			remaining = checkRemaining
+		}
+		held = true
+	}
+	return remaining, held
+}
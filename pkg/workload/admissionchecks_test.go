@@ -23,11 +23,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -37,6 +39,7 @@ func TestSyncAdmittedCondition(t *testing.T) {
 		checkStates      []kueue.AdmissionCheckState
 		conditions       []metav1.Condition
 		pastAdmittedTime int32
+		admission        *kueue.Admission
 
 		wantConditions   []metav1.Condition
 		wantChange       bool
@@ -252,6 +255,43 @@ func TestSyncAdmittedCondition(t *testing.T) {
 			wantChange:       true,
 			wantAdmittedTime: 2,
 		},
+		"reservation before the start time": {
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			admission: utiltesting.MakeAdmission("cq").StartTime(testTime.Add(time.Minute)).Obj(),
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+		"reservation after the start time": {
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			admission: utiltesting.MakeAdmission("cq").StartTime(testTime).Obj(),
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Admitted",
+					ObservedGeneration: 1,
+				},
+			},
+			wantChange: true,
+		},
 	}
 
 	for name, tc := range cases {
@@ -263,6 +303,9 @@ func TestSyncAdmittedCondition(t *testing.T) {
 			if tc.pastAdmittedTime > 0 {
 				builder = builder.PastAdmittedTime(tc.pastAdmittedTime)
 			}
+			if tc.admission != nil {
+				builder = builder.Admission(tc.admission)
+			}
 			wl := builder.Obj()
 
 			gotChange := SyncAdmittedCondition(wl, testTime)
@@ -463,3 +506,141 @@ func TestSetCheckState(t *testing.T) {
 		})
 	}
 }
+
+func TestReservationHoldRemaining(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	holds := map[kueue.AdmissionCheckReference]time.Duration{
+		"check1": 10 * time.Minute,
+		"check2": 20 * time.Minute,
+	}
+	cases := map[string]struct {
+		workload      *kueue.Workload
+		holds         map[kueue.AdmissionCheckReference]time.Duration
+		wantRemaining time.Duration
+		wantHeld      bool
+	}{
+		"no holds": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStatePending}).
+				Obj(),
+		},
+		"no quota reservation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStatePending}).
+				Obj(),
+			holds: holds,
+		},
+		"check not holding the reservation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check3", State: kueue.CheckStatePending}).
+				Obj(),
+			holds: holds,
+		},
+		"ready check": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStateReady}).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check3", State: kueue.CheckStatePending}).
+				Obj(),
+			holds: holds,
+		},
+		"pending check holding the reservation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStatePending}).
+				Obj(),
+			holds:         holds,
+			wantRemaining: 9 * time.Minute,
+			wantHeld:      true,
+		},
+		"longest hold of the pending checks": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStatePending}).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check2", State: kueue.CheckStatePending}).
+				Obj(),
+			holds:         holds,
+			wantRemaining: 19 * time.Minute,
+			wantHeld:      true,
+		},
+		"expired hold": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-15*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check1", State: kueue.CheckStatePending}).
+				Obj(),
+			holds:         holds,
+			wantRemaining: -5 * time.Minute,
+			wantHeld:      true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotRemaining, gotHeld := ReservationHoldRemaining(tc.workload, tc.holds, now)
+			if gotRemaining != tc.wantRemaining || gotHeld != tc.wantHeld {
+				t.Errorf("Unexpected result, want (%v, %t), got (%v, %t)", tc.wantRemaining, tc.wantHeld, gotRemaining, gotHeld)
+			}
+		})
+	}
+}
+
+func TestAdmissionCheckResourcesReserved(t *testing.T) {
+	sidecarCheck := kueue.AdmissionCheckState{
+		Name:  "check",
+		State: kueue.CheckStatePending,
+		PodSetResources: []kueue.PodSetResources{{
+			Name:      kueue.DefaultPodSetName,
+			Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		}},
+	}
+	baseWorkload := utiltesting.MakeWorkload("wl", "ns").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+			Request(corev1.ResourceCPU, "1").
+			Obj()).
+		AdmissionCheck(sidecarCheck)
+
+	cases := map[string]struct {
+		workload *kueue.Workload
+		want     bool
+	}{
+		"pending workload": {
+			workload: baseWorkload.Clone().Obj(),
+			want:     true,
+		},
+		"reservation accounts for the resources": {
+			workload: baseWorkload.Clone().
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "on-demand", "3").
+					AssignmentPodCount(2).
+					Obj()).
+				Obj(),
+			want: true,
+		},
+		"reservation doesn't account for the resources": {
+			workload: baseWorkload.Clone().
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "on-demand", "2").
+					AssignmentPodCount(2).
+					Obj()).
+				Obj(),
+		},
+		"partially admitted workload": {
+			workload: baseWorkload.Clone().
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "on-demand", "1500m").
+					AssignmentPodCount(1).
+					Obj()).
+				Obj(),
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdmissionCheckPodSetResources, true)
+			if got := AdmissionCheckResourcesReserved(tc.workload); got != tc.want {
+				t.Errorf("Unexpected AdmissionCheckResourcesReserved, want=%v, got=%v", tc.want, got)
+			}
+		})
+	}
+}
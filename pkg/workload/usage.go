@@ -27,4 +27,7 @@ type TASUsage map[kueue.ResourceFlavorReference]TASFlavorUsage
 type Usage struct {
 	Quota resources.FlavorResourceQuantities
 	TAS   TASUsage
+	// Fallback indicates that the Quota is provided by the fallback cohort
+	// of the ClusterQueue.
+	Fallback bool
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package workload
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"maps"
@@ -28,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,6 +44,7 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -190,6 +193,10 @@ type TopologyDomainRequests struct {
 	SinglePodRequests resources.Requests
 	// Count indicates how many pods are requested in this TopologyDomain.
 	Count int32
+	// NodeCapacityPercentage indicates the percentage of the capacity of the
+	// node requested by each pod, in addition to SinglePodRequests. It equals
+	// to 0 if the pods don't request a percentage of the node capacity.
+	NodeCapacityPercentage int32
 }
 
 func (t *TopologyDomainRequests) TotalRequests() resources.Requests {
@@ -236,19 +243,97 @@ func (i *Info) Update(wl *kueue.Workload) {
 	i.Obj = wl
 }
 
+// WithResourceOverheads returns the Info of the pending workload with the
+// overheads added to the requests of each of its pods, for quota accounting.
+// The requests of a workload with quota reserved are taken from its
+// admission, which already includes the overheads, so its Info is returned as is.
+func (i *Info) WithResourceOverheads(overheads []kueue.ResourceOverhead) *Info {
+	if len(overheads) == 0 || i.Obj.Status.Admission != nil {
+		return i
+	}
+	ret := *i
+	ret.TotalRequests = make([]PodSetResources, len(i.TotalRequests))
+	for idx, psr := range i.TotalRequests {
+		if psr.Count > 0 {
+			psr.Requests = applyResourceOverheads(psr.SinglePodRequests(), overheads)
+			psr.Requests.Mul(int64(psr.Count))
+		}
+		ret.TotalRequests[idx] = psr
+	}
+	return &ret
+}
+
+// WithAdmissionCheckResources returns the Info of the pending workload with
+// the resources requested by its admission checks added to the requests of
+// the pods of each PodSet, for quota accounting. The requests of a workload
+// with quota reserved are taken from its admission, which already includes
+// the resources, so its Info is returned as is.
+func (i *Info) WithAdmissionCheckResources() *Info {
+	if i.Obj.Status.Admission != nil {
+		return i
+	}
+	checkResources := AdmissionCheckResources(i.Obj)
+	if len(checkResources) == 0 {
+		return i
+	}
+	ret := *i
+	ret.TotalRequests = make([]PodSetResources, len(i.TotalRequests))
+	for idx, psr := range i.TotalRequests {
+		if extra, found := checkResources[psr.Name]; found && psr.Count > 0 {
+			psr.Requests = psr.SinglePodRequests()
+			psr.Requests.Add(extra)
+			psr.Requests.Mul(int64(psr.Count))
+		}
+		ret.TotalRequests[idx] = psr
+	}
+	return &ret
+}
+
+// applyResourceOverheads adds the overheads to the requests of a single pod.
+// The requests are multiplied by the factor, rounding up, before adding the addend.
+func applyResourceOverheads(requests resources.Requests, overheads []kueue.ResourceOverhead) resources.Requests {
+	for _, overhead := range overheads {
+		v, found := requests[overhead.Name]
+		if !found {
+			continue
+		}
+		if overhead.Factor != nil {
+			v = (v*overhead.Factor.MilliValue() + 999) / 1000
+		}
+		if overhead.Addend != nil {
+			v += resources.ResourceValue(overhead.Name, *overhead.Addend)
+		}
+		requests[overhead.Name] = v
+	}
+	return requests
+}
+
 func (i *Info) CanBePartiallyAdmitted() bool {
 	return CanBePartiallyAdmitted(i.Obj)
 }
 
+// MayPreempt returns false if the workload opted out of preempting other
+// workloads, with the kueue.x-k8s.io/may-preempt annotation.
+func (i *Info) MayPreempt() bool {
+	return i.Obj.Annotations[controllerconsts.MayPreemptAnnotation] != "false"
+}
+
 // Usage returns the total resource usage for the workload, including regular
 // quota and TAS usage.
 func (i *Info) Usage() Usage {
 	return Usage{
-		Quota: i.FlavorResourceUsage(),
-		TAS:   i.TASUsage(),
+		Quota:    i.FlavorResourceUsage(),
+		TAS:      i.TASUsage(),
+		Fallback: i.UsesFallbackCohort(),
 	}
 }
 
+// UsesFallbackCohort returns true if the quota reserved for the workload
+// is provided by the fallback cohort of its ClusterQueue.
+func (i *Info) UsesFallbackCohort() bool {
+	return i.Obj.Status.Admission != nil && i.Obj.Status.Admission.FallbackCohort != ""
+}
+
 // FlavorResourceUsage returns the total resource usage for the workload,
 // per flavor (if assigned, otherwise flavor shows as empty string), per resource.
 func (i *Info) FlavorResourceUsage() resources.FlavorResourceQuantities {
@@ -355,7 +440,14 @@ func applyResourceTransformations(input corev1.ResourceList, transforms map[core
 		if mapping, ok := transforms[inputName]; ok {
 			for outputName, baseFactor := range mapping.Outputs {
 				outputQuantity := baseFactor.DeepCopy()
-				outputQuantity.Mul(inputQuantity.Value())
+				if features.Enabled(features.ExtendedResourceMilliUnits) {
+					// Multiply as decimals, so that fractional inputs aren't rounded.
+					product := outputQuantity.AsDec()
+					product.Mul(product, inputQuantity.AsDec())
+					outputQuantity = *resource.NewDecimalQuantity(*product, baseFactor.Format)
+				} else {
+					outputQuantity.Mul(inputQuantity.Value())
+				}
 				if accumulated, ok := output[outputName]; ok {
 					outputQuantity.Add(accumulated)
 				}
@@ -381,6 +473,29 @@ func CanBePartiallyAdmitted(wl *kueue.Workload) bool {
 	return false
 }
 
+// PodSetsInPriorityOrder returns the indexes of the PodSets in the order in
+// which they are assigned flavors and topology domains: by decreasing
+// priority, keeping the order of the PodSets with the same priority.
+// Returns nil when the PodSets are assigned in their order, because the
+// PodSetPriority feature is disabled or all the PodSets have the same
+// priority.
+func PodSetsInPriorityOrder(podSets []kueue.PodSet) []int {
+	if !features.Enabled(features.PodSetPriority) || len(podSets) < 2 {
+		return nil
+	}
+	order := make([]int, len(podSets))
+	for i := range podSets {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(ptr.Deref(podSets[b].Priority, 0), ptr.Deref(podSets[a].Priority, 0))
+	})
+	if slices.IsSorted(order) {
+		return nil
+	}
+	return order
+}
+
 func Key(w *kueue.Workload) string {
 	return fmt.Sprintf("%s/%s", w.Namespace, w.Name)
 }
@@ -438,6 +553,29 @@ func totalRequestsFromPodSets(wl *kueue.Workload, info *InfoOptions) []PodSetRes
 	return res
 }
 
+// TotalRequests returns the total resources requested by all the pods of the
+// workload. The requests of a pod include its init and sidecar containers and
+// the pod overhead, and they are multiplied by the count of its PodSet.
+func TotalRequests(wl *kueue.Workload) corev1.ResourceList {
+	total := resources.Requests{}
+	for i := range wl.Spec.PodSets {
+		ps := &wl.Spec.PodSets[i]
+		podRequests := resources.NewRequests(resourcehelpers.PodRequests(&corev1.Pod{Spec: ps.Template.Spec}, resourcehelpers.PodResourcesOptions{}))
+		podRequests.Mul(int64(ps.Count))
+		total.Add(podRequests)
+	}
+	return total.ToResourceList()
+}
+
+// NodeCapacityPercentage returns the percentage of the node capacity requested
+// by each pod of the PodSet with the topology request, or 0 if not requested.
+func NodeCapacityPercentage(tr *kueue.PodSetTopologyRequest) int32 {
+	if tr == nil {
+		return 0
+	}
+	return ptr.Deref(tr.NodeCapacityPercentage, 0)
+}
+
 func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 	if wl.Status.Admission == nil {
 		return nil
@@ -445,6 +583,7 @@ func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 	res := make([]PodSetResources, 0, len(wl.Spec.PodSets))
 	currentCounts := podSetsCountsAfterReclaim(wl)
 	totalCounts := podSetsCounts(wl)
+	topologyRequests := PodSetNameToTopologyRequest(wl)
 	for _, psa := range wl.Status.Admission.PodSetAssignments {
 		setRes := PodSetResources{
 			Name:     psa.Name,
@@ -458,9 +597,10 @@ func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 			}
 			for _, domain := range psa.TopologyAssignment.Domains {
 				setRes.TopologyRequest.DomainRequests = append(setRes.TopologyRequest.DomainRequests, TopologyDomainRequests{
-					Values:            domain.Values,
-					SinglePodRequests: setRes.SinglePodRequests(),
-					Count:             domain.Count,
+					Values:                 domain.Values,
+					SinglePodRequests:      setRes.SinglePodRequests(),
+					Count:                  domain.Count,
+					NodeCapacityPercentage: NodeCapacityPercentage(topologyRequests[psa.Name]),
 				})
 			}
 		}
@@ -520,6 +660,10 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 		wl.Status.Admission = nil
 		changed = true
 	}
+	if wl.Status.AdmissionPath != "" {
+		wl.Status.AdmissionPath = ""
+		changed = true
+	}
 
 	// Reset the admitted condition if necessary.
 	if SyncAdmittedCondition(wl, now) {
@@ -555,6 +699,11 @@ func UpdateRequeueState(wl *kueue.Workload, backoffBaseSeconds int32, backoffMax
 	wl.Status.RequeueState.Count = &requeuingCount
 }
 
+// IncrementRequeueCount records a requeue of the workload after an eviction.
+func IncrementRequeueCount(wl *kueue.Workload) {
+	wl.Status.RequeueCount = ptr.To(ptr.Deref(wl.Status.RequeueCount, 0) + 1)
+}
+
 // SetRequeuedCondition sets the WorkloadRequeued condition to true
 func SetRequeuedCondition(wl *kueue.Workload, reason, message string, status bool) {
 	condition := metav1.Condition{
@@ -695,6 +844,7 @@ func PropagateResourceRequests(w *kueue.Workload, info *Info) bool {
 func AdmissionStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload, strict bool) {
 	wlCopy.Status.Admission = w.Status.Admission.DeepCopy()
 	wlCopy.Status.RequeueState = w.Status.RequeueState.DeepCopy()
+	wlCopy.Status.RequeueCount = w.Status.RequeueCount
 	if wlCopy.Status.Admission != nil {
 		// Clear ResourceRequests; Assignment.PodSetAssignment[].ResourceUsage supercedes it
 		wlCopy.Status.ResourceRequests = []kueue.PodSetRequest{}
@@ -712,6 +862,22 @@ func AdmissionStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload, strict bool
 		wlCopy.ResourceVersion = w.ResourceVersion
 	}
 	wlCopy.Status.AccumulatedPastExexcutionTimeSeconds = w.Status.AccumulatedPastExexcutionTimeSeconds
+	wlCopy.Status.PreemptedBy = w.Status.PreemptedBy.DeepCopy()
+	wlCopy.Status.AdmissionPath = w.Status.AdmissionPath
+	wlCopy.Status.AdmissionHistory = slices.Clone(w.Status.AdmissionHistory)
+	if features.Enabled(features.WorkloadAdmissionHistory) {
+		SyncAdmissionHistory(wlCopy)
+	}
+	wlCopy.Status.LastPlacement = w.Status.LastPlacement.DeepCopy()
+	if features.Enabled(features.WorkloadResumeHints) {
+		SyncLastPlacement(wlCopy)
+	}
+	wlCopy.Status.EvictionCount = w.Status.EvictionCount
+	wlCopy.Status.LastEvictionReason = w.Status.LastEvictionReason
+	wlCopy.Status.LastEvictionTime = w.Status.LastEvictionTime.DeepCopy()
+	if features.Enabled(features.WorkloadEvictionStatus) {
+		SyncEvictionStatus(wlCopy)
+	}
 }
 
 func AdmissionChecksStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload, c clock.Clock) {
@@ -775,6 +941,15 @@ func HasQuotaReservation(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadQuotaReserved)
 }
 
+// AdmissionStartTime returns the time before which the workload with the quota
+// reserved can't be admitted, or nil if it can be admitted immediately.
+func AdmissionStartTime(w *kueue.Workload) *metav1.Time {
+	if w.Status.Admission == nil {
+		return nil
+	}
+	return w.Status.Admission.StartTime
+}
+
 // UpdateReclaimablePods updates the ReclaimablePods list for the workload with SSA.
 func UpdateReclaimablePods(ctx context.Context, c client.Client, w *kueue.Workload, reclaimablePods []kueue.ReclaimablePod) error {
 	patch := BaseSSAWorkload(w)
@@ -782,6 +957,121 @@ func UpdateReclaimablePods(ctx context.Context, c client.Client, w *kueue.Worklo
 	return c.Status().Patch(ctx, patch, client.Apply, client.FieldOwner(constants.ReclaimablePodsMgr))
 }
 
+// PreemptionReference returns the reference to the workload recorded in the
+// status of the workloads involved in a preemption.
+func PreemptionReference(w *kueue.Workload) kueue.WorkloadPreemptionReference {
+	return kueue.WorkloadPreemptionReference{
+		Namespace: w.Namespace,
+		Name:      w.Name,
+		UID:       w.UID,
+	}
+}
+
+// UpdatePreemptedWorkloads updates the PreemptedWorkloads list for the workload with SSA.
+func UpdatePreemptedWorkloads(ctx context.Context, c client.Client, w *kueue.Workload, preempted []kueue.WorkloadPreemptionReference) error {
+	patch := BaseSSAWorkload(w)
+	patch.Status.PreemptedWorkloads = preempted
+	return c.Status().Patch(ctx, patch, client.Apply, client.FieldOwner(constants.PreemptedWorkloadsMgr), client.ForceOwnership)
+}
+
+// maxAdmissionHistoryEntries is the number of entries kept in the
+// admission history of a workload.
+const maxAdmissionHistoryEntries = 10
+
+// SyncAdmissionHistory appends to the admission history of the workload the
+// admission and the eviction recorded in its conditions, when not recorded
+// yet, keeping only the most recent entries.
+// Returns whether the history was updated.
+func SyncAdmissionHistory(w *kueue.Workload) bool {
+	var entries []kueue.AdmissionHistoryEntry
+	for _, conditionType := range []string{kueue.WorkloadAdmitted, kueue.WorkloadEvicted} {
+		cond := apimeta.FindStatusCondition(w.Status.Conditions, conditionType)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		recorded := slices.ContainsFunc(w.Status.AdmissionHistory, func(e kueue.AdmissionHistoryEntry) bool {
+			return e.Type == conditionType && e.Time.Equal(&cond.LastTransitionTime)
+		})
+		if !recorded {
+			entries = append(entries, newAdmissionHistoryEntry(w, cond))
+		}
+	}
+	if len(entries) == 0 {
+		return false
+	}
+	slices.SortStableFunc(entries, func(a, b kueue.AdmissionHistoryEntry) int {
+		return a.Time.Compare(b.Time.Time)
+	})
+	history := append(slices.Clone(w.Status.AdmissionHistory), entries...)
+	if len(history) > maxAdmissionHistoryEntries {
+		history = history[len(history)-maxAdmissionHistoryEntries:]
+	}
+	w.Status.AdmissionHistory = history
+	return true
+}
+
+func newAdmissionHistoryEntry(w *kueue.Workload, cond *metav1.Condition) kueue.AdmissionHistoryEntry {
+	entry := kueue.AdmissionHistoryEntry{
+		Type:   cond.Type,
+		Time:   cond.LastTransitionTime,
+		Reason: cond.Reason,
+	}
+	if w.Status.Admission != nil {
+		entry.ClusterQueue = w.Status.Admission.ClusterQueue
+		flavors := sets.New[kueue.ResourceFlavorReference]()
+		for _, psa := range w.Status.Admission.PodSetAssignments {
+			flavors.Insert(slices.Collect(maps.Values(psa.Flavors))...)
+		}
+		if flavors.Len() > 0 {
+			entry.Flavors = sets.List(flavors)
+		}
+	}
+	return entry
+}
+
+// SyncLastPlacement records the placement of the PodSets of an admitted
+// workload in its status, so that it is kept after the workload is evicted.
+// Returns whether the last placement was updated.
+func SyncLastPlacement(w *kueue.Workload) bool {
+	if !IsAdmitted(w) || w.Status.Admission == nil {
+		return false
+	}
+	placement := &kueue.WorkloadPlacement{
+		ClusterQueue: w.Status.Admission.ClusterQueue,
+		PodSets:      make([]kueue.PodSetPlacement, 0, len(w.Status.Admission.PodSetAssignments)),
+	}
+	for _, psa := range w.Status.Admission.PodSetAssignments {
+		placement.PodSets = append(placement.PodSets, kueue.PodSetPlacement{
+			Name:               psa.Name,
+			Flavors:            maps.Clone(psa.Flavors),
+			TopologyAssignment: psa.TopologyAssignment.DeepCopy(),
+		})
+	}
+	if equality.Semantic.DeepEqual(w.Status.LastPlacement, placement) {
+		return false
+	}
+	w.Status.LastPlacement = placement
+	return true
+}
+
+// SyncEvictionStatus records the eviction recorded in the Evicted condition
+// of the workload in its status, when not recorded yet, incrementing the
+// eviction count.
+// Returns whether the eviction status was updated.
+func SyncEvictionStatus(w *kueue.Workload) bool {
+	cond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadEvicted)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return false
+	}
+	if w.Status.LastEvictionTime != nil && !w.Status.LastEvictionTime.Before(&cond.LastTransitionTime) {
+		return false
+	}
+	w.Status.EvictionCount = ptr.To(ptr.Deref(w.Status.EvictionCount, 0) + 1)
+	w.Status.LastEvictionReason = cond.Reason
+	w.Status.LastEvictionTime = cond.LastTransitionTime.DeepCopy()
+	return true
+}
+
 // ReclaimablePodsAreEqual checks if two Reclaimable pods are semantically equal
 // having the same length and all keys have the same value.
 func ReclaimablePodsAreEqual(a, b []kueue.ReclaimablePod) bool {
@@ -17,12 +17,14 @@ limitations under the License.
 package workload
 
 import (
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -44,6 +46,7 @@ func TestNewInfo(t *testing.T) {
 		infoOptions                         []InfoOption
 		wantInfo                            Info
 		configurableResourceTransformations bool
+		enableMilliUnits                    bool
 	}{
 		"pending": {
 			workload: *utiltesting.MakeWorkload("", "").
@@ -155,6 +158,51 @@ func TestNewInfo(t *testing.T) {
 				},
 			},
 		},
+		"pending with fractional extended resources": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("workers", 3).
+						Request("nvidia.com/gpu", "250m").
+						Request("example.com/mig-1g.5gb", "1500m").
+						Obj(),
+				).
+				Obj(),
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "workers",
+						Requests: resources.Requests{
+							"nvidia.com/gpu":         3,
+							"example.com/mig-1g.5gb": 6,
+						},
+						Count: 3,
+					},
+				},
+			},
+		},
+		"pending with fractional extended resources in milli-units": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("workers", 3).
+						Request("nvidia.com/gpu", "250m").
+						Request("example.com/mig-1g.5gb", "1500m").
+						Obj(),
+				).
+				Obj(),
+			enableMilliUnits: true,
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "workers",
+						Requests: resources.Requests{
+							"nvidia.com/gpu":         750,
+							"example.com/mig-1g.5gb": 4500,
+						},
+						Count: 3,
+					},
+				},
+			},
+		},
 		"admitted with reclaim": {
 			workload: *utiltesting.MakeWorkload("", "").
 				PodSets(
@@ -351,10 +399,100 @@ func TestNewInfo(t *testing.T) {
 			},
 			configurableResourceTransformations: true,
 		},
+		"pending with sidecar init container": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+						Request(corev1.ResourceCPU, "1").
+						InitContainers(
+							*utiltesting.MakeContainer().
+								Name("sidecar").
+								AsSidecar().
+								WithResourceReq(corev1.ResourceCPU, "500m").
+								Obj(),
+						).
+						Obj(),
+				).
+				Obj(),
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: kueue.DefaultPodSetName,
+						Requests: resources.Requests{
+							corev1.ResourceCPU: 2 * 1500,
+						},
+						Count: 2,
+					},
+				},
+			},
+		},
+		"pending with init container started after a sidecar": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+						Request(corev1.ResourceCPU, "1").
+						InitContainers(
+							*utiltesting.MakeContainer().
+								Name("sidecar").
+								AsSidecar().
+								WithResourceReq(corev1.ResourceCPU, "500m").
+								Obj(),
+							*utiltesting.MakeContainer().
+								Name("init").
+								WithResourceReq(corev1.ResourceCPU, "2").
+								Obj(),
+						).
+						Obj(),
+				).
+				Obj(),
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: kueue.DefaultPodSetName,
+						Requests: resources.Requests{
+							corev1.ResourceCPU: 2 * 2500,
+						},
+						Count: 2,
+					},
+				},
+			},
+		},
+		"pending with init container started before a sidecar": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+						Request(corev1.ResourceCPU, "1").
+						InitContainers(
+							*utiltesting.MakeContainer().
+								Name("init").
+								WithResourceReq(corev1.ResourceCPU, "2").
+								Obj(),
+							*utiltesting.MakeContainer().
+								Name("sidecar").
+								AsSidecar().
+								WithResourceReq(corev1.ResourceCPU, "500m").
+								Obj(),
+						).
+						Obj(),
+				).
+				Obj(),
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: kueue.DefaultPodSetName,
+						Requests: resources.Requests{
+							corev1.ResourceCPU: 2 * 2000,
+						},
+						Count: 2,
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.ConfigurableResourceTransformations, tc.configurableResourceTransformations)
+			features.SetFeatureGateDuringTest(t, features.ExtendedResourceMilliUnits, tc.enableMilliUnits)
 			info := NewInfo(&tc.workload, tc.infoOptions...)
 			if diff := cmp.Diff(info, &tc.wantInfo, cmpopts.IgnoreFields(Info{}, "Obj")); diff != "" {
 				t.Errorf("NewInfo(_) = (-want,+got):\n%s", diff)
@@ -363,6 +501,297 @@ func TestNewInfo(t *testing.T) {
 	}
 }
 
+func TestInfoWithResourceOverheads(t *testing.T) {
+	pending := utiltesting.MakeWorkload("", "").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).
+				Request(corev1.ResourceCPU, "3m").
+				Obj(),
+			*utiltesting.MakePodSet("workers", 3).
+				Request(corev1.ResourceCPU, "100m").
+				Request(corev1.ResourceMemory, "1000Mi").
+				Obj(),
+		).
+		Obj()
+	admitted := pending.DeepCopy()
+	admitted.Status.Admission = utiltesting.MakeAdmission("cq", "driver", "workers").
+		Assignment(corev1.ResourceCPU, "f1", "3m").
+		AssignmentPodCount(1).
+		Obj()
+	admitted.Status.Admission.PodSetAssignments[1].ResourceUsage = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("300m"),
+		corev1.ResourceMemory: resource.MustParse("3000Mi"),
+	}
+	admitted.Status.Admission.PodSetAssignments[1].Count = ptr.To[int32](3)
+
+	cases := map[string]struct {
+		workload  *kueue.Workload
+		overheads []kueue.ResourceOverhead
+		want      []PodSetResources
+	}{
+		"without overheads": {
+			workload: pending,
+			want: []PodSetResources{
+				{
+					Name:     "driver",
+					Requests: resources.Requests{corev1.ResourceCPU: 3},
+					Count:    1,
+				},
+				{
+					Name: "workers",
+					Requests: resources.Requests{
+						corev1.ResourceCPU:    300,
+						corev1.ResourceMemory: 3000 * utiltesting.Mi,
+					},
+					Count: 3,
+				},
+			},
+		},
+		"with factor and addend": {
+			workload: pending,
+			overheads: []kueue.ResourceOverhead{
+				{
+					Name:   corev1.ResourceMemory,
+					Factor: ptr.To(resource.MustParse("1.5")),
+					Addend: ptr.To(resource.MustParse("100Mi")),
+				},
+			},
+			want: []PodSetResources{
+				{
+					Name:     "driver",
+					Requests: resources.Requests{corev1.ResourceCPU: 3},
+					Count:    1,
+				},
+				{
+					Name: "workers",
+					Requests: resources.Requests{
+						corev1.ResourceCPU:    300,
+						corev1.ResourceMemory: 3 * 1600 * utiltesting.Mi,
+					},
+					Count: 3,
+				},
+			},
+		},
+		"with factor rounded up": {
+			workload: pending,
+			overheads: []kueue.ResourceOverhead{
+				{
+					Name:   corev1.ResourceCPU,
+					Factor: ptr.To(resource.MustParse("1.15")),
+				},
+			},
+			want: []PodSetResources{
+				{
+					Name:     "driver",
+					Requests: resources.Requests{corev1.ResourceCPU: 4},
+					Count:    1,
+				},
+				{
+					Name: "workers",
+					Requests: resources.Requests{
+						corev1.ResourceCPU:    3 * 115,
+						corev1.ResourceMemory: 3000 * utiltesting.Mi,
+					},
+					Count: 3,
+				},
+			},
+		},
+		"admitted workload": {
+			workload: admitted,
+			overheads: []kueue.ResourceOverhead{
+				{
+					Name:   corev1.ResourceMemory,
+					Addend: ptr.To(resource.MustParse("100Mi")),
+				},
+			},
+			want: []PodSetResources{
+				{
+					Name:     "driver",
+					Requests: resources.Requests{corev1.ResourceCPU: 3},
+					Count:    1,
+					Flavors:  map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "f1"},
+				},
+				{
+					Name: "workers",
+					Requests: resources.Requests{
+						corev1.ResourceCPU:    300,
+						corev1.ResourceMemory: 3000 * utiltesting.Mi,
+					},
+					Count:   3,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			info := NewInfo(tc.workload)
+			original := slices.Clone(info.TotalRequests)
+			got := info.WithResourceOverheads(tc.overheads)
+			if diff := cmp.Diff(tc.want, got.TotalRequests); diff != "" {
+				t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, info.TotalRequests); diff != "" {
+				t.Errorf("Unexpected change of the original total requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInfoWithAdmissionCheckResources(t *testing.T) {
+	sidecarCheck := func(name kueue.AdmissionCheckReference, cpu string) kueue.AdmissionCheckState {
+		return kueue.AdmissionCheckState{
+			Name:  name,
+			State: kueue.CheckStatePending,
+			PodSetResources: []kueue.PodSetResources{{
+				Name:      "workers",
+				Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}},
+		}
+	}
+	pending := utiltesting.MakeWorkload("", "").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).
+				Request(corev1.ResourceCPU, "3m").
+				Obj(),
+			*utiltesting.MakePodSet("workers", 3).
+				Request(corev1.ResourceCPU, "100m").
+				Obj(),
+		)
+
+	cases := map[string]struct {
+		workload      *kueue.Workload
+		enableFeature bool
+		want          []PodSetResources
+	}{
+		"without resources requested by the checks": {
+			workload:      pending.Clone().AdmissionCheck(kueue.AdmissionCheckState{Name: "check", State: kueue.CheckStatePending}).Obj(),
+			enableFeature: true,
+			want: []PodSetResources{
+				{Name: "driver", Requests: resources.Requests{corev1.ResourceCPU: 3}, Count: 1},
+				{Name: "workers", Requests: resources.Requests{corev1.ResourceCPU: 300}, Count: 3},
+			},
+		},
+		"resources requested by the checks are summed": {
+			workload: pending.Clone().
+				AdmissionCheck(sidecarCheck("check1", "50m")).
+				AdmissionCheck(sidecarCheck("check2", "10m")).
+				Obj(),
+			enableFeature: true,
+			want: []PodSetResources{
+				{Name: "driver", Requests: resources.Requests{corev1.ResourceCPU: 3}, Count: 1},
+				{Name: "workers", Requests: resources.Requests{corev1.ResourceCPU: 3 * 160}, Count: 3},
+			},
+		},
+		"feature disabled": {
+			workload: pending.Clone().AdmissionCheck(sidecarCheck("check1", "50m")).Obj(),
+			want: []PodSetResources{
+				{Name: "driver", Requests: resources.Requests{corev1.ResourceCPU: 3}, Count: 1},
+				{Name: "workers", Requests: resources.Requests{corev1.ResourceCPU: 300}, Count: 3},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdmissionCheckPodSetResources, tc.enableFeature)
+			info := NewInfo(tc.workload)
+			original := slices.Clone(info.TotalRequests)
+			got := info.WithAdmissionCheckResources()
+			if diff := cmp.Diff(tc.want, got.TotalRequests); diff != "" {
+				t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, info.TotalRequests); diff != "" {
+				t.Errorf("Unexpected change of the original total requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTotalRequests(t *testing.T) {
+	cases := map[string]struct {
+		workload *kueue.Workload
+		want     corev1.ResourceList
+	}{
+		"no podsets": {
+			workload: utiltesting.MakeWorkload("wl", "ns").PodSets().Obj(),
+			want:     corev1.ResourceList{},
+		},
+		"multiple podsets": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "1").
+						Request(corev1.ResourceMemory, "1Gi").
+						Obj(),
+					*utiltesting.MakePodSet("workers", 3).
+						Request(corev1.ResourceCPU, "2").
+						Request(corev1.ResourceMemory, "2Gi").
+						Request("example.com/gpu", "1").
+						Obj(),
+				).
+				Obj(),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("7"),
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+				"example.com/gpu":     resource.MustParse("3"),
+			},
+		},
+		"multiple podsets with overhead and sidecars": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "1").
+						PodOverHead(corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						}).
+						Obj(),
+					*utiltesting.MakePodSet("workers", 2).
+						Request(corev1.ResourceCPU, "1").
+						InitContainers(
+							corev1.Container{
+								Name:          "sidecar",
+								RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("500m"),
+										corev1.ResourceMemory: resource.MustParse("128Mi"),
+									},
+								},
+							},
+							corev1.Container{
+								Name: "init",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("2"),
+									},
+								},
+							},
+						).
+						PodOverHead(corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("100m"),
+						}).
+						Obj(),
+				).
+				Obj(),
+			// driver: 1 + 100m overhead.
+			// workers: max(2 init + 500m sidecar, 1 + 500m sidecar) + 100m overhead, for 2 pods.
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("6300m"),
+				corev1.ResourceMemory: resource.MustParse("320Mi"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TotalRequests(tc.workload)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateWorkloadStatus(t *testing.T) {
 	now := time.Now()
 	fakeClock := testingclock.NewFakeClock(now)
@@ -1085,3 +1514,407 @@ func TestPropagateResourceRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncAdmissionHistory(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltesting.MakeAdmission("cq").
+		PodSets(
+			kueue.PodSetAssignment{
+				Name: "driver",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU:    "on-demand",
+					corev1.ResourceMemory: "on-demand",
+				},
+			},
+			kueue.PodSetAssignment{
+				Name: "workers",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "spot",
+				},
+			},
+		).
+		Obj()
+	admittedEntry := func(t time.Time) kueue.AdmissionHistoryEntry {
+		return kueue.AdmissionHistoryEntry{
+			Type:         kueue.WorkloadAdmitted,
+			Time:         metav1.NewTime(t),
+			Reason:       "ByTest",
+			ClusterQueue: "cq",
+			Flavors:      []kueue.ResourceFlavorReference{"on-demand", "spot"},
+		}
+	}
+	evictedCondition := metav1.Condition{
+		Type:               kueue.WorkloadEvicted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadEvictedByPreemption,
+		LastTransitionTime: metav1.NewTime(now.Add(time.Minute)),
+	}
+	evictedEntry := kueue.AdmissionHistoryEntry{
+		Type:         kueue.WorkloadEvicted,
+		Time:         metav1.NewTime(now.Add(time.Minute)),
+		Reason:       kueue.WorkloadEvictedByPreemption,
+		ClusterQueue: "cq",
+		Flavors:      []kueue.ResourceFlavorReference{"on-demand", "spot"},
+	}
+	fullHistory := make([]kueue.AdmissionHistoryEntry, 0, maxAdmissionHistoryEntries)
+	for i := range maxAdmissionHistoryEntries {
+		fullHistory = append(fullHistory, admittedEntry(now.Add(time.Duration(i-maxAdmissionHistoryEntries)*time.Hour)))
+	}
+
+	cases := map[string]struct {
+		workload    *kueue.Workload
+		wantUpdated bool
+		wantHistory []kueue.AdmissionHistoryEntry
+	}{
+		"pending workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
+		},
+		"admission is appended": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				Obj(),
+			wantUpdated: true,
+			wantHistory: []kueue.AdmissionHistoryEntry{admittedEntry(now)},
+		},
+		"admission is already recorded": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				AdmissionHistory(admittedEntry(now)).
+				Obj(),
+			wantHistory: []kueue.AdmissionHistoryEntry{admittedEntry(now)},
+		},
+		"admission and eviction are appended in order": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				Condition(evictedCondition).
+				AdmissionHistory(admittedEntry(now.Add(-time.Hour))).
+				Obj(),
+			wantUpdated: true,
+			wantHistory: []kueue.AdmissionHistoryEntry{
+				admittedEntry(now.Add(-time.Hour)),
+				admittedEntry(now),
+				evictedEntry,
+			},
+		},
+		"eviction after the quota was released": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(evictedCondition).
+				AdmissionHistory(admittedEntry(now)).
+				Obj(),
+			wantUpdated: true,
+			wantHistory: []kueue.AdmissionHistoryEntry{
+				admittedEntry(now),
+				{
+					Type:   kueue.WorkloadEvicted,
+					Time:   metav1.NewTime(now.Add(time.Minute)),
+					Reason: kueue.WorkloadEvictedByPreemption,
+				},
+			},
+		},
+		"history is capped": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				AdmissionHistory(fullHistory...).
+				Obj(),
+			wantUpdated: true,
+			wantHistory: append(slices.Clone(fullHistory[1:]), admittedEntry(now)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotUpdated := SyncAdmissionHistory(tc.workload)
+			if gotUpdated != tc.wantUpdated {
+				t.Errorf("Unexpected updated, want=%v, got=%v", tc.wantUpdated, gotUpdated)
+			}
+			if diff := cmp.Diff(tc.wantHistory, tc.workload.Status.AdmissionHistory); diff != "" {
+				t.Errorf("Unexpected admission history (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncLastPlacement(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	topologyAssignment := &kueue.TopologyAssignment{
+		Levels: []string{corev1.LabelHostname},
+		Domains: []kueue.TopologyDomainAssignment{
+			{Values: []string{"node-1"}, Count: 2},
+		},
+	}
+	admission := utiltesting.MakeAdmission("cq").
+		PodSets(
+			kueue.PodSetAssignment{
+				Name: "driver",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "on-demand",
+				},
+			},
+			kueue.PodSetAssignment{
+				Name: "workers",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "tas",
+				},
+				TopologyAssignment: topologyAssignment,
+			},
+		).
+		Obj()
+	placement := &kueue.WorkloadPlacement{
+		ClusterQueue: "cq",
+		PodSets: []kueue.PodSetPlacement{
+			{
+				Name: "driver",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "on-demand",
+				},
+			},
+			{
+				Name: "workers",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "tas",
+				},
+				TopologyAssignment: topologyAssignment,
+			},
+		},
+	}
+	otherPlacement := &kueue.WorkloadPlacement{
+		ClusterQueue: "other-cq",
+		PodSets: []kueue.PodSetPlacement{
+			{
+				Name: "driver",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: "spot",
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		workload          *kueue.Workload
+		wantUpdated       bool
+		wantLastPlacement *kueue.WorkloadPlacement
+	}{
+		"pending workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
+		},
+		"quota reserved, but not admitted": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				Obj(),
+		},
+		"placement is recorded": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				Obj(),
+			wantUpdated:       true,
+			wantLastPlacement: placement,
+		},
+		"placement is already recorded": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				LastPlacement(placement.DeepCopy()).
+				Obj(),
+			wantLastPlacement: placement,
+		},
+		"placement is replaced": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(admission, now).
+				AdmittedAt(true, now).
+				LastPlacement(otherPlacement.DeepCopy()).
+				Obj(),
+			wantUpdated:       true,
+			wantLastPlacement: placement,
+		},
+		"placement is kept after the eviction": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				LastPlacement(placement.DeepCopy()).
+				Obj(),
+			wantLastPlacement: placement,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotUpdated := SyncLastPlacement(tc.workload)
+			if gotUpdated != tc.wantUpdated {
+				t.Errorf("Unexpected updated, want=%v, got=%v", tc.wantUpdated, gotUpdated)
+			}
+			if diff := cmp.Diff(tc.wantLastPlacement, tc.workload.Status.LastPlacement); diff != "" {
+				t.Errorf("Unexpected last placement (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPodSetsInPriorityOrder(t *testing.T) {
+	cases := map[string]struct {
+		podSets              []kueue.PodSet
+		enablePodSetPriority bool
+		wantOrder            []int
+	}{
+		"feature disabled": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers", 4).Obj(),
+				*utiltesting.MakePodSet("driver", 1).Priority(100).Obj(),
+			},
+		},
+		"single podSet": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("driver", 1).Priority(100).Obj(),
+			},
+			enablePodSetPriority: true,
+		},
+		"podSets already in priority order": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("driver", 1).Priority(100).Obj(),
+				*utiltesting.MakePodSet("workers", 4).Obj(),
+			},
+			enablePodSetPriority: true,
+		},
+		"higher priority podSet goes first": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers", 4).Obj(),
+				*utiltesting.MakePodSet("launcher", 1).Priority(10).Obj(),
+				*utiltesting.MakePodSet("driver", 1).Priority(100).Obj(),
+			},
+			enablePodSetPriority: true,
+			wantOrder:            []int{2, 1, 0},
+		},
+		"podSets with the same priority keep their order": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers-a", 4).Obj(),
+				*utiltesting.MakePodSet("workers-b", 4).Priority(0).Obj(),
+				*utiltesting.MakePodSet("driver", 1).Priority(100).Obj(),
+			},
+			enablePodSetPriority: true,
+			wantOrder:            []int{2, 0, 1},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PodSetPriority, tc.enablePodSetPriority)
+			gotOrder := PodSetsInPriorityOrder(tc.podSets)
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncEvictionStatus(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	evictedCondition := func(reason string, t time.Time) metav1.Condition {
+		return metav1.Condition{
+			Type:               kueue.WorkloadEvicted,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			LastTransitionTime: metav1.NewTime(t),
+		}
+	}
+
+	cases := map[string]struct {
+		workload         *kueue.Workload
+		wantUpdated      bool
+		wantCount        *int32
+		wantReason       string
+		wantEvictionTime *metav1.Time
+	}{
+		"never evicted workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
+		},
+		"first eviction is recorded": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(evictedCondition(kueue.WorkloadEvictedByPreemption, now)).
+				Obj(),
+			wantUpdated:      true,
+			wantCount:        ptr.To[int32](1),
+			wantReason:       kueue.WorkloadEvictedByPreemption,
+			wantEvictionTime: ptr.To(metav1.NewTime(now)),
+		},
+		"eviction is already recorded": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(evictedCondition(kueue.WorkloadEvictedByPreemption, now)).
+				EvictionStatus(1, kueue.WorkloadEvictedByPreemption, now).
+				Obj(),
+			wantCount:        ptr.To[int32](1),
+			wantReason:       kueue.WorkloadEvictedByPreemption,
+			wantEvictionTime: ptr.To(metav1.NewTime(now)),
+		},
+		"new eviction increments the count": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(evictedCondition(kueue.WorkloadEvictedByPodsReadyTimeout, now)).
+				EvictionStatus(2, kueue.WorkloadEvictedByPreemption, now.Add(-time.Hour)).
+				Obj(),
+			wantUpdated:      true,
+			wantCount:        ptr.To[int32](3),
+			wantReason:       kueue.WorkloadEvictedByPodsReadyTimeout,
+			wantEvictionTime: ptr.To(metav1.NewTime(now)),
+		},
+		"readmitted workload keeps the last eviction": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadEvicted,
+					Status:             metav1.ConditionFalse,
+					Reason:             "QuotaReserved",
+					LastTransitionTime: metav1.NewTime(now),
+				}).
+				EvictionStatus(1, kueue.WorkloadEvictedByPreemption, now.Add(-time.Hour)).
+				Obj(),
+			wantCount:        ptr.To[int32](1),
+			wantReason:       kueue.WorkloadEvictedByPreemption,
+			wantEvictionTime: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotUpdated := SyncEvictionStatus(tc.workload)
+			if gotUpdated != tc.wantUpdated {
+				t.Errorf("Unexpected updated, want=%v, got=%v", tc.wantUpdated, gotUpdated)
+			}
+			if diff := cmp.Diff(tc.wantCount, tc.workload.Status.EvictionCount); diff != "" {
+				t.Errorf("Unexpected eviction count (-want,+got):\n%s", diff)
+			}
+			if tc.wantReason != tc.workload.Status.LastEvictionReason {
+				t.Errorf("Unexpected last eviction reason, want=%q, got=%q", tc.wantReason, tc.workload.Status.LastEvictionReason)
+			}
+			if diff := cmp.Diff(tc.wantEvictionTime, tc.workload.Status.LastEvictionTime); diff != "" {
+				t.Errorf("Unexpected last eviction time (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncEvictionStatusAcrossEvictions(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now().Truncate(time.Second))
+	wl := utiltesting.MakeWorkload("wl", "ns").Obj()
+	admission := utiltesting.MakeAdmission("cq").Obj()
+	reasons := []string{
+		kueue.WorkloadEvictedByPreemption,
+		kueue.WorkloadEvictedByPodsReadyTimeout,
+		kueue.WorkloadEvictedByPreemption,
+	}
+	for i, reason := range reasons {
+		SetQuotaReservation(wl, admission, fakeClock)
+		fakeClock.Step(time.Minute)
+		SetEvictedCondition(wl, reason, "By test")
+		cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+		cond.LastTransitionTime = metav1.NewTime(fakeClock.Now())
+		if !SyncEvictionStatus(wl) {
+			t.Fatalf("Eviction %d was not recorded", i+1)
+		}
+		if SyncEvictionStatus(wl) {
+			t.Errorf("Eviction %d was recorded twice", i+1)
+		}
+		if got := ptr.Deref(wl.Status.EvictionCount, 0); got != int32(i+1) {
+			t.Errorf("Unexpected eviction count after eviction %d, want=%d, got=%d", i+1, i+1, got)
+		}
+		if wl.Status.LastEvictionReason != reason {
+			t.Errorf("Unexpected last eviction reason after eviction %d, want=%q, got=%q", i+1, reason, wl.Status.LastEvictionReason)
+		}
+		fakeClock.Step(time.Minute)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -126,6 +127,90 @@ var _ = ginkgo.Describe("Deployment", func() {
 		})
 	})
 
+	ginkgo.It("should hand over the quota to the new pods during a rolling update", func() {
+		// The quota doesn't fit an additional replica, so the new pods can only
+		// start by taking over the quota of the replaced pods.
+		deployment := deploymenttesting.MakeDeployment("deployment", ns.Name).
+			Image(util.E2eTestAgnHostImage, util.BehaviorWaitForDeletion).
+			RequestAndLimit(corev1.ResourceCPU, "2").
+			TerminationGracePeriod(1).
+			Replicas(2).
+			MaxUnavailable(intstr.FromInt32(1)).
+			Queue(lq.Name).
+			Obj()
+
+		ginkgo.By("Create a deployment", func() {
+			util.MustCreate(ctx, k8sClient, deployment)
+		})
+
+		ginkgo.By("Wait for replicas ready", func() {
+			createdDeployment := &appsv1.Deployment{}
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), createdDeployment)).To(gomega.Succeed())
+				g.Expect(createdDeployment.Status.ReadyReplicas).To(gomega.Equal(int32(2)))
+			}, util.LongTimeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		ginkgo.By("Update the pod template of the deployment", func() {
+			createdDeployment := &appsv1.Deployment{}
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), createdDeployment)).To(gomega.Succeed())
+				createdDeployment.Spec.Template.Annotations["rollout"] = "1"
+				g.Expect(k8sClient.Update(ctx, createdDeployment)).To(gomega.Succeed())
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		ginkgo.By("Wait for the rolling update to complete", func() {
+			createdDeployment := &appsv1.Deployment{}
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), createdDeployment)).To(gomega.Succeed())
+				g.Expect(createdDeployment.Status.ObservedGeneration).To(gomega.Equal(createdDeployment.Generation))
+				g.Expect(createdDeployment.Status.Replicas).To(gomega.Equal(int32(2)))
+				g.Expect(createdDeployment.Status.UpdatedReplicas).To(gomega.Equal(int32(2)))
+				g.Expect(createdDeployment.Status.ReadyReplicas).To(gomega.Equal(int32(2)))
+			}, util.LongTimeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		ginkgo.By("Check that the quota was handed over to the new pods", func() {
+			gomega.Eventually(func(g gomega.Gomega) {
+				events := &corev1.EventList{}
+				g.Expect(k8sClient.List(ctx, events, client.InNamespace(ns.Name))).To(gomega.Succeed())
+				g.Expect(events.Items).To(gomega.ContainElement(gomega.HaveField("Reason", pod.ReasonQuotaHandedOver)))
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		pods := &corev1.PodList{}
+		gomega.Expect(k8sClient.List(ctx, pods, client.InNamespace(ns.Name),
+			client.MatchingLabels(deployment.Spec.Selector.MatchLabels))).To(gomega.Succeed())
+
+		createdWorkloads := make([]*kueue.Workload, 0, len(pods.Items))
+		ginkgo.By("Check that the workloads of the new pods are admitted", func() {
+			for _, p := range pods.Items {
+				if !p.DeletionTimestamp.IsZero() {
+					continue
+				}
+				createdWorkload := &kueue.Workload{}
+				wlLookupKey := types.NamespacedName{
+					Name:      pod.GetWorkloadNameForPod(p.Name, p.UID),
+					Namespace: p.Namespace,
+				}
+				gomega.Expect(k8sClient.Get(ctx, wlLookupKey, createdWorkload)).To(gomega.Succeed())
+				gomega.Expect(createdWorkload.Status.Conditions).To(testing.HaveConditionStatusTrue(kueue.WorkloadAdmitted))
+				createdWorkloads = append(createdWorkloads, createdWorkload)
+			}
+		})
+
+		ginkgo.By("Delete the deployment", func() {
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, deployment, true)
+		})
+
+		ginkgo.By("Check that workloads are deleted", func() {
+			for _, wl := range createdWorkloads {
+				util.ExpectObjectToBeDeletedWithTimeout(ctx, k8sClient, wl, false, util.LongTimeout)
+			}
+		})
+	})
+
 	ginkgo.It("should admit workloads after change queue-name if AvailableReplicas = 0", func() {
 		deployment := deploymenttesting.MakeDeployment("deployment", ns.Name).
 			Image(util.E2eTestAgnHostImage, util.BehaviorWaitForDeletion).
@@ -25,10 +25,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
 	"sigs.k8s.io/kueue/pkg/util/testing"
@@ -479,6 +481,64 @@ var _ = ginkgo.Describe("Kueue visibility server", func() {
 		})
 	})
 
+	ginkgo.When("There is a multi-level cohort tree", func() {
+		var (
+			rootCohort   *kueuealpha.Cohort
+			teamCohort   *kueuealpha.Cohort
+			clusterQueue *kueue.ClusterQueue
+		)
+		ginkgo.BeforeEach(func() {
+			defaultRF = testing.MakeResourceFlavor(defaultFlavor).Obj()
+			util.MustCreate(ctx, k8sClient, defaultRF)
+
+			rootCohort = testing.MakeCohort("visibility-root").
+				ResourceGroup(*testing.MakeFlavorQuotas(defaultFlavor).Resource(corev1.ResourceCPU, "2").Obj()).
+				Obj()
+			util.MustCreate(ctx, k8sClient, rootCohort)
+
+			teamCohort = testing.MakeCohort("visibility-team").Parent(kueue.CohortReference(rootCohort.Name)).Obj()
+			util.MustCreate(ctx, k8sClient, teamCohort)
+
+			clusterQueue = testing.MakeClusterQueue("cluster-queue").
+				Cohort(kueue.CohortReference(teamCohort.Name)).
+				ResourceGroup(*testing.MakeFlavorQuotas(defaultFlavor).Resource(corev1.ResourceCPU, "1").Obj()).
+				Obj()
+			util.MustCreate(ctx, k8sClient, clusterQueue)
+		})
+		ginkgo.AfterEach(func() {
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, clusterQueue, true)
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, teamCohort, true)
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, rootCohort, true)
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, defaultRF, true)
+		})
+
+		ginkgo.It("Should allow fetching the cohort tree", func() {
+			cpuResource := func(nominal string) []visibility.CohortResource {
+				return []visibility.CohortResource{{
+					Flavor:       defaultFlavor,
+					Resource:     corev1.ResourceCPU,
+					NominalQuota: resource.MustParse(nominal),
+					Usage:        resource.MustParse("0"),
+				}}
+			}
+			wantRoot := visibility.CohortTreeNode{
+				Name:      kueue.CohortReference(rootCohort.Name),
+				Resources: cpuResource("3"),
+				Children: []visibility.CohortTreeNode{{
+					Name:          kueue.CohortReference(teamCohort.Name),
+					Parent:        kueue.CohortReference(rootCohort.Name),
+					ClusterQueues: []kueue.ClusterQueueReference{kueue.ClusterQueueReference(clusterQueue.Name)},
+					Resources:     cpuResource("1"),
+				}},
+			}
+			gomega.Eventually(func(g gomega.Gomega) {
+				tree, err := visibilityClient.Cohorts().GetCohortTree(ctx, teamCohort.Name, metav1.GetOptions{})
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(tree.Root).Should(gomega.BeComparableTo(wantRoot, cmpopts.EquateEmpty()))
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+	})
+
 	ginkgo.When("A subject is bound to kueue-batch-admin-role", func() {
 		var clusterRoleBinding *rbacv1.ClusterRoleBinding
 